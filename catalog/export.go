@@ -0,0 +1,58 @@
+package catalog
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/chainalysis-oss/oslc"
+	"io"
+)
+
+// Filter selects the entries that are exported or imported. Fields left at their zero value match every entry.
+type Filter struct {
+	// Distributor matches entries with a distribution point of this distributor.
+	Distributor string
+	// License, if not nil, matches entries with exactly this license. A pointer to an empty string matches entries for
+	// which no license could be normalized.
+	License *string
+}
+
+// Matches reports whether the entry matches every field of the filter.
+func (f Filter) Matches(entry oslc.Entry) bool {
+	if f.License != nil && entry.License != *f.License {
+		return false
+	}
+	if f.Distributor == "" {
+		return true
+	}
+	for _, dp := range entry.DistributionPoints {
+		if dp.Distributor == f.Distributor {
+			return true
+		}
+	}
+	return false
+}
+
+// Export writes every entry of the datastore matching the filter to w, as one JSON object per line, and returns the
+// number of written entries. Entries are stored once per distributor, so an entry with distribution points of several
+// distributors is written once for each of them, unless the filter selects a single distributor.
+func Export(ctx context.Context, w io.Writer, it oslc.DatastoreIterator, filter Filter) (int, error) {
+	bw := bufio.NewWriter(w)
+	encoder := json.NewEncoder(bw)
+	n := 0
+	err := it.Iterate(ctx, oslc.SearchQuery{Distributor: filter.Distributor, License: filter.License}, func(e oslc.StoredEntry) error {
+		if err := encoder.Encode(e.Entry); err != nil {
+			return err
+		}
+		n++
+		return nil
+	})
+	if err != nil {
+		return n, fmt.Errorf("exporting entries: %w", err)
+	}
+	if err := bw.Flush(); err != nil {
+		return n, fmt.Errorf("exporting entries: %w", err)
+	}
+	return n, nil
+}
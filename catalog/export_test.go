@@ -0,0 +1,88 @@
+package catalog
+
+import (
+	"bytes"
+	"context"
+	"github.com/chainalysis-oss/oslc"
+	"github.com/chainalysis-oss/oslc/memory"
+	oslcMocks "github.com/chainalysis-oss/oslc/mocks/oslc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func testEntry(name, version, license string, distributors ...string) oslc.Entry {
+	e := oslc.Entry{Name: name, Version: version, License: license}
+	for _, distributor := range distributors {
+		e.DistributionPoints = append(e.DistributionPoints, oslc.DistributionPoint{Name: name, URL: "https://" + distributor + ".example.com/" + name, Distributor: distributor})
+	}
+	return e
+}
+
+func newTestDatastore(t *testing.T, entries ...oslc.Entry) *memory.Datastore {
+	t.Helper()
+	ds, err := memory.NewDatastore()
+	require.NoError(t, err)
+	for _, e := range entries {
+		require.NoError(t, ds.Save(context.Background(), e))
+	}
+	return ds
+}
+
+func TestFilter_Matches(t *testing.T) {
+	mit, empty := "MIT", ""
+	entry := testEntry("lodash", "4.17.21", "MIT", "npm", "github")
+	require.True(t, Filter{}.Matches(entry))
+	require.True(t, Filter{Distributor: "github"}.Matches(entry))
+	require.False(t, Filter{Distributor: "pypi"}.Matches(entry))
+	require.True(t, Filter{License: &mit}.Matches(entry))
+	require.False(t, Filter{License: &empty}.Matches(entry))
+}
+
+func TestExport(t *testing.T) {
+	ds := newTestDatastore(t,
+		testEntry("lodash", "4.17.21", "MIT", "npm"),
+		testEntry("requests", "2.32.3", "Apache-2.0", "pypi"),
+	)
+	var buf bytes.Buffer
+	n, err := Export(context.Background(), &buf, ds, Filter{})
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	require.Equal(t, `{"name":"lodash","distribution_points":[{"name":"lodash","url":"https://npm.example.com/lodash","distributor":"npm"}],"license":"MIT","version":"4.17.21"}
+{"name":"requests","distribution_points":[{"name":"requests","url":"https://pypi.example.com/requests","distributor":"pypi"}],"license":"Apache-2.0","version":"2.32.3"}
+`, buf.String())
+}
+
+func TestExport_filter(t *testing.T) {
+	mit := "MIT"
+	ds := newTestDatastore(t,
+		testEntry("lodash", "4.17.21", "MIT", "npm"),
+		testEntry("left-pad", "1.3.0", "WTFPL", "npm"),
+		testEntry("six", "1.16.0", "MIT", "pypi"),
+	)
+	cases := []struct {
+		name   string
+		filter Filter
+		want   int
+	}{
+		{"distributor", Filter{Distributor: "npm"}, 2},
+		{"license", Filter{License: &mit}, 2},
+		{"distributor and license", Filter{Distributor: "npm", License: &mit}, 1},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			n, err := Export(context.Background(), &buf, ds, tt.filter)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, n)
+			require.Equal(t, tt.want, bytes.Count(buf.Bytes(), []byte("\n")))
+		})
+	}
+}
+
+func TestExport_ErrIterate(t *testing.T) {
+	datastore := oslcMocks.NewMockDatastore(t)
+	datastore.EXPECT().Search(context.Background(), oslc.SearchQuery{Limit: DefaultPageSize}).Return(nil, assert.AnError).Once()
+	_, err := Export(context.Background(), &bytes.Buffer{}, Iterator(datastore), Filter{})
+	require.ErrorIs(t, err, assert.AnError)
+}
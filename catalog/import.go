@@ -0,0 +1,97 @@
+package catalog
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/chainalysis-oss/oslc"
+	"io"
+)
+
+// ImportMode determines what happens to imported entries that are already stored.
+type ImportMode string
+
+const (
+	// ImportUpsert saves every imported entry, replacing stored entries with the same name and version.
+	ImportUpsert ImportMode = "upsert"
+	// ImportSkipExisting saves only the imported entries that are not yet stored under every distributor of their
+	// distribution points, so entries that were fetched again since the export are kept.
+	ImportSkipExisting ImportMode = "skip-existing"
+)
+
+// ImportStore is the part of a datastore needed to import entries.
+type ImportStore interface {
+	oslc.DatastoreSaver
+	oslc.DatastoreRetriever
+}
+
+// ImportResult counts the entries read by [Import].
+type ImportResult struct {
+	// Imported is the number of saved entries.
+	Imported int
+	// Skipped is the number of entries that did not match the filter, or were already stored in ImportSkipExisting
+	// mode.
+	Skipped int
+}
+
+// Import reads entries written by [Export] from r, and saves those matching the filter to the datastore. It stops at
+// the first entry that cannot be read or saved; entries saved before are kept.
+func Import(ctx context.Context, r io.Reader, store ImportStore, filter Filter, mode ImportMode) (ImportResult, error) {
+	if mode != ImportUpsert && mode != ImportSkipExisting {
+		return ImportResult{}, fmt.Errorf("%w: %q", ErrInvalidImportMode, mode)
+	}
+
+	var result ImportResult
+	decoder := json.NewDecoder(bufio.NewReader(r))
+	for line := 1; ; line++ {
+		var entry oslc.Entry
+		err := decoder.Decode(&entry)
+		if errors.Is(err, io.EOF) {
+			return result, nil
+		}
+		if err != nil {
+			return result, fmt.Errorf("reading entry %d: %w", line, err)
+		}
+		if len(entry.DistributionPoints) == 0 {
+			return result, fmt.Errorf("reading entry %d: %w", line, ErrNoDistributionPoints)
+		}
+		if !filter.Matches(entry) {
+			result.Skipped++
+			continue
+		}
+		if mode == ImportSkipExisting {
+			stored, err := isStored(ctx, store, entry)
+			if err != nil {
+				return result, fmt.Errorf("importing entry %d: %w", line, err)
+			}
+			if stored {
+				result.Skipped++
+				continue
+			}
+		}
+		if err := store.Save(ctx, entry); err != nil {
+			return result, fmt.Errorf("importing entry %d: %w", line, err)
+		}
+		result.Imported++
+	}
+}
+
+// isStored reports whether the entry is stored under every distributor of its distribution points.
+func isStored(ctx context.Context, store oslc.DatastoreRetriever, entry oslc.Entry) (bool, error) {
+	for _, dp := range entry.DistributionPoints {
+		_, err := store.Retrieve(ctx, entry.Name, entry.Version, dp.Distributor)
+		if errors.Is(err, oslc.ErrDatastoreObjectNotFound) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+var ErrInvalidImportMode = errors.New("invalid import mode")
+
+var ErrNoDistributionPoints = errors.New("entry has no distribution points")
@@ -0,0 +1,127 @@
+package catalog
+
+import (
+	"bytes"
+	"context"
+	"github.com/chainalysis-oss/oslc"
+	oslcMocks "github.com/chainalysis-oss/oslc/mocks/oslc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestImport_roundTrip(t *testing.T) {
+	entries := []oslc.Entry{
+		testEntry("lodash", "4.17.21", "MIT", "npm"),
+		testEntry("requests", "2.32.3", "Apache-2.0", "pypi"),
+		testEntry("serde", "1.0.0", "MIT OR Apache-2.0", "crates.io"),
+	}
+	var buf bytes.Buffer
+	_, err := Export(context.Background(), &buf, newTestDatastore(t, entries...), Filter{})
+	require.NoError(t, err)
+
+	ds := newTestDatastore(t)
+	result, err := Import(context.Background(), &buf, ds, Filter{}, ImportUpsert)
+	require.NoError(t, err)
+	require.Equal(t, ImportResult{Imported: 3}, result)
+	for _, e := range entries {
+		got, err := ds.Retrieve(context.Background(), e.Name, e.Version, e.DistributionPoints[0].Distributor)
+		require.NoError(t, err)
+		require.Equal(t, e, got)
+	}
+}
+
+func TestImport_modes(t *testing.T) {
+	stored := testEntry("lodash", "4.17.21", "MIT", "npm")
+	imported := testEntry("lodash", "4.17.21", "Apache-2.0", "npm")
+	var buf bytes.Buffer
+	_, err := Export(context.Background(), &buf, newTestDatastore(t, imported, testEntry("left-pad", "1.3.0", "WTFPL", "npm")), Filter{})
+	require.NoError(t, err)
+
+	cases := []struct {
+		mode   ImportMode
+		result ImportResult
+		want   oslc.Entry
+	}{
+		{ImportUpsert, ImportResult{Imported: 2}, imported},
+		{ImportSkipExisting, ImportResult{Imported: 1, Skipped: 1}, stored},
+	}
+	for _, tt := range cases {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			ds := newTestDatastore(t, stored)
+			result, err := Import(context.Background(), bytes.NewReader(buf.Bytes()), ds, Filter{}, tt.mode)
+			require.NoError(t, err)
+			require.Equal(t, tt.result, result)
+			got, err := ds.Retrieve(context.Background(), "lodash", "4.17.21", "npm")
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestImport_skipExistingImportsMissingDistributors(t *testing.T) {
+	ds := newTestDatastore(t, testEntry("lodash", "4.17.21", "MIT", "npm"))
+	var buf bytes.Buffer
+	_, err := Export(context.Background(), &buf, newTestDatastore(t, testEntry("lodash", "4.17.21", "MIT", "npm", "github")), Filter{Distributor: "npm"})
+	require.NoError(t, err)
+
+	result, err := Import(context.Background(), &buf, ds, Filter{}, ImportSkipExisting)
+	require.NoError(t, err)
+	require.Equal(t, ImportResult{Imported: 1}, result)
+	_, err = ds.Retrieve(context.Background(), "lodash", "4.17.21", "github")
+	require.NoError(t, err)
+}
+
+func TestImport_filter(t *testing.T) {
+	mit := "MIT"
+	var buf bytes.Buffer
+	_, err := Export(context.Background(), &buf, newTestDatastore(t,
+		testEntry("lodash", "4.17.21", "MIT", "npm"),
+		testEntry("left-pad", "1.3.0", "WTFPL", "npm"),
+		testEntry("six", "1.16.0", "MIT", "pypi"),
+	), Filter{})
+	require.NoError(t, err)
+
+	ds := newTestDatastore(t)
+	result, err := Import(context.Background(), &buf, ds, Filter{Distributor: "npm", License: &mit}, ImportUpsert)
+	require.NoError(t, err)
+	require.Equal(t, ImportResult{Imported: 1, Skipped: 2}, result)
+	_, err = ds.Retrieve(context.Background(), "lodash", "4.17.21", "npm")
+	require.NoError(t, err)
+}
+
+func TestImport_errors(t *testing.T) {
+	valid := `{"name":"lodash","version":"4.17.21","distribution_points":[{"distributor":"npm"}]}` + "\n"
+	cases := []struct {
+		name  string
+		input string
+		mode  ImportMode
+		err   string
+	}{
+		{"invalid mode", "", "replace", `invalid import mode: "replace"`},
+		{"malformed", valid + "{", ImportUpsert, "reading entry 2"},
+		{"no distribution points", valid + `{"name":"lodash"}`, ImportUpsert, "reading entry 2: " + ErrNoDistributionPoints.Error()},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ds := newTestDatastore(t)
+			_, err := Import(context.Background(), strings.NewReader(tt.input), ds, Filter{}, tt.mode)
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestImport_ErrDatastore(t *testing.T) {
+	input := `{"name":"lodash","version":"4.17.21","distribution_points":[{"distributor":"npm"}]}`
+
+	datastore := oslcMocks.NewMockDatastore(t)
+	datastore.EXPECT().Save(context.Background(), mock.Anything).Return(assert.AnError).Once()
+	_, err := Import(context.Background(), strings.NewReader(input), datastore, Filter{}, ImportUpsert)
+	require.ErrorIs(t, err, assert.AnError)
+
+	datastore.EXPECT().Retrieve(context.Background(), "lodash", "4.17.21", "npm").Return(oslc.Entry{}, assert.AnError).Once()
+	_, err = Import(context.Background(), strings.NewReader(input), datastore, Filter{}, ImportSkipExisting)
+	require.ErrorIs(t, err, assert.AnError)
+}
@@ -0,0 +1,55 @@
+// Package catalog moves the entries of a datastore in and out of the server, as JSONL streams of [oslc.Entry] objects.
+// It is used to seed a datastore with a catalog warmed elsewhere, such as in another region or outside an air-gapped
+// network.
+package catalog
+
+import (
+	"context"
+	"github.com/chainalysis-oss/oslc"
+)
+
+// DefaultPageSize is the number of entries a [SearchIterator] requests per search when its page size is not set.
+const DefaultPageSize = 1000
+
+// Compile time check to ensure SearchIterator implements [oslc.DatastoreIterator].
+var _ oslc.DatastoreIterator = (*SearchIterator)(nil)
+
+// SearchIterator iterates a datastore by paging through its search results, using the cursor of the last entry of a
+// page to request the next one.
+type SearchIterator struct {
+	Searcher oslc.DatastoreSearcher
+	// PageSize is the number of entries requested per search. If it is not positive, DefaultPageSize is used.
+	PageSize int
+}
+
+func (it *SearchIterator) Iterate(ctx context.Context, query oslc.SearchQuery, f func(oslc.StoredEntry) error) error {
+	query.Limit = it.PageSize
+	if query.Limit <= 0 {
+		query.Limit = DefaultPageSize
+	}
+	for {
+		entries, err := it.Searcher.Search(ctx, query)
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			if err := f(e); err != nil {
+				return err
+			}
+		}
+		if len(entries) < query.Limit {
+			return nil
+		}
+		cursor := entries[len(entries)-1].Cursor()
+		query.After = &cursor
+	}
+}
+
+// Iterator returns an iterator for the datastore. Datastores implementing [oslc.DatastoreIterator] are returned as
+// is, and every other datastore is iterated with a [SearchIterator].
+func Iterator(searcher oslc.DatastoreSearcher) oslc.DatastoreIterator {
+	if it, ok := searcher.(oslc.DatastoreIterator); ok {
+		return it
+	}
+	return &SearchIterator{Searcher: searcher}
+}
@@ -0,0 +1,67 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"github.com/chainalysis-oss/oslc"
+	"github.com/chainalysis-oss/oslc/memory"
+	oslcMocks "github.com/chainalysis-oss/oslc/mocks/oslc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func storedEntry(distributor, name, version string) oslc.StoredEntry {
+	return oslc.StoredEntry{
+		Entry:       oslc.Entry{Name: name, Version: version, DistributionPoints: []oslc.DistributionPoint{{Name: name, Distributor: distributor}}},
+		Distributor: distributor,
+	}
+}
+
+func TestSearchIterator_Iterate(t *testing.T) {
+	datastore := oslcMocks.NewMockDatastore(t)
+	first, second, third := storedEntry("npm", "a", "1"), storedEntry("npm", "b", "1"), storedEntry("pypi", "a", "1")
+	datastore.EXPECT().Search(context.Background(), oslc.SearchQuery{Distributor: "npm", Limit: 2}).Return([]oslc.StoredEntry{first, second}, nil).Once()
+	cursor := second.Cursor()
+	datastore.EXPECT().Search(context.Background(), oslc.SearchQuery{Distributor: "npm", Limit: 2, After: &cursor}).Return([]oslc.StoredEntry{third}, nil).Once()
+
+	var visited []oslc.StoredEntry
+	it := &SearchIterator{Searcher: datastore, PageSize: 2}
+	err := it.Iterate(context.Background(), oslc.SearchQuery{Distributor: "npm", Limit: 1}, func(e oslc.StoredEntry) error {
+		visited = append(visited, e)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []oslc.StoredEntry{first, second, third}, visited)
+}
+
+func TestSearchIterator_Iterate_defaultPageSize(t *testing.T) {
+	datastore := oslcMocks.NewMockDatastore(t)
+	datastore.EXPECT().Search(context.Background(), oslc.SearchQuery{Limit: DefaultPageSize}).Return([]oslc.StoredEntry{}, nil).Once()
+
+	it := &SearchIterator{Searcher: datastore}
+	require.NoError(t, it.Iterate(context.Background(), oslc.SearchQuery{}, func(oslc.StoredEntry) error {
+		t.Fatal("no entries must be visited")
+		return nil
+	}))
+}
+
+func TestSearchIterator_Iterate_errors(t *testing.T) {
+	datastore := oslcMocks.NewMockDatastore(t)
+	datastore.EXPECT().Search(context.Background(), oslc.SearchQuery{Limit: 1}).Return(nil, assert.AnError).Once()
+	it := &SearchIterator{Searcher: datastore, PageSize: 1}
+	require.ErrorIs(t, it.Iterate(context.Background(), oslc.SearchQuery{}, func(oslc.StoredEntry) error { return nil }), assert.AnError)
+
+	stop := errors.New("stop")
+	datastore.EXPECT().Search(context.Background(), oslc.SearchQuery{Limit: 1}).Return([]oslc.StoredEntry{storedEntry("npm", "a", "1")}, nil).Once()
+	require.ErrorIs(t, it.Iterate(context.Background(), oslc.SearchQuery{}, func(oslc.StoredEntry) error { return stop }), stop)
+}
+
+func TestIterator(t *testing.T) {
+	datastore := oslcMocks.NewMockDatastore(t)
+	require.Equal(t, &SearchIterator{Searcher: datastore}, Iterator(datastore))
+
+	ds, err := memory.NewDatastore()
+	require.NoError(t, err)
+	require.Same(t, ds, Iterator(ds))
+}
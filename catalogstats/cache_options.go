@@ -0,0 +1,77 @@
+package catalogstats
+
+import (
+	"github.com/chainalysis-oss/oslc"
+	"log/slog"
+	"time"
+)
+
+type cacheOptions struct {
+	Logger  *slog.Logger
+	Counter oslc.DatastoreCounter
+	// RefreshInterval is the age after which statistics are recomputed.
+	RefreshInterval time.Duration
+	// CollectTimeout is the timeout for recomputing statistics while collecting Prometheus metrics.
+	CollectTimeout time.Duration
+	// Now returns the current time. It is replaced in tests.
+	Now func() time.Time
+}
+
+var defaultCacheOptions = cacheOptions{
+	Logger:          slog.Default(),
+	RefreshInterval: 5 * time.Minute,
+	CollectTimeout:  10 * time.Second,
+	Now:             time.Now,
+}
+
+var globalCacheOptions []CacheOption
+
+// CacheOption is an option for configuring a Cache.
+type CacheOption interface {
+	apply(*cacheOptions)
+}
+
+// funcCacheOption is a CacheOption that calls a function.
+// It is used to wrap a function, so it satisfies the CacheOption interface.
+type funcCacheOption struct {
+	f func(*cacheOptions)
+}
+
+func (fdo *funcCacheOption) apply(opts *cacheOptions) {
+	fdo.f(opts)
+}
+
+func newFuncCacheOption(f func(*cacheOptions)) *funcCacheOption {
+	return &funcCacheOption{
+		f: f,
+	}
+}
+
+// WithLogger returns a CacheOption that uses the provided logger.
+func WithLogger(logger *slog.Logger) CacheOption {
+	return newFuncCacheOption(func(opts *cacheOptions) {
+		opts.Logger = logger
+	})
+}
+
+// WithCounter returns a CacheOption that computes statistics from the counts of the provided datastore.
+func WithCounter(counter oslc.DatastoreCounter) CacheOption {
+	return newFuncCacheOption(func(opts *cacheOptions) {
+		opts.Counter = counter
+	})
+}
+
+// WithRefreshInterval returns a CacheOption that sets the age after which statistics are recomputed.
+func WithRefreshInterval(interval time.Duration) CacheOption {
+	return newFuncCacheOption(func(opts *cacheOptions) {
+		opts.RefreshInterval = interval
+	})
+}
+
+// WithCollectTimeout returns a CacheOption that sets the timeout for recomputing statistics while collecting
+// Prometheus metrics.
+func WithCollectTimeout(timeout time.Duration) CacheOption {
+	return newFuncCacheOption(func(opts *cacheOptions) {
+		opts.CollectTimeout = timeout
+	})
+}
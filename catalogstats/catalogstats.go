@@ -0,0 +1,100 @@
+// Package catalogstats computes [oslc.CatalogStats] from the package counts of a datastore, caches them, and exports
+// them as Prometheus gauges.
+package catalogstats
+
+import (
+	"context"
+	"errors"
+	"github.com/chainalysis-oss/oslc"
+	"github.com/chainalysis-oss/oslc/licensecategory"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// StatusUnknown is the status of [oslc.CatalogStats.ByNormalizationStatus] for entries that were stored before the
+// outcome of normalization was recorded. Other entries are counted under their [oslc.LicenseNormalizationStatus].
+const StatusUnknown = "unknown"
+
+// Compute returns the statistics for the provided counts, computed at the provided time.
+func Compute(counts []oslc.PackageCount, now time.Time) oslc.CatalogStats {
+	stats := oslc.CatalogStats{
+		ByLicense:             make(map[string]int64),
+		ByLicenseCategory:     make(map[string]int64),
+		ByDistributor:         make(map[string]int64),
+		ByNormalizationStatus: make(map[string]int64),
+		ComputedAt:            now,
+	}
+	for _, c := range counts {
+		stats.Total += c.Count
+		stats.ByLicense[c.License] += c.Count
+		stats.ByLicenseCategory[licensecategory.Of(c.License)] += c.Count
+		stats.ByDistributor[c.Distributor] += c.Count
+		status := string(c.NormalizationStatus)
+		if status == "" {
+			status = StatusUnknown
+		}
+		stats.ByNormalizationStatus[status] += c.Count
+	}
+	return stats
+}
+
+// Compile time check to ensure Cache implements [oslc.CatalogStatsProvider].
+var _ oslc.CatalogStatsProvider = (*Cache)(nil)
+
+// Cache provides [oslc.CatalogStats] computed from the counts of a [oslc.DatastoreCounter]. Statistics are recomputed
+// when they are requested and older than the refresh interval, so counting the datastore's entries happens at most
+// once per interval, no matter how often the statistics are requested.
+type Cache struct {
+	options *cacheOptions
+
+	mu    sync.Mutex
+	stats *oslc.CatalogStats
+}
+
+var ErrMissingOptionCounter = errors.New("missing option: counter")
+
+// NewCache returns a new Cache. The Counter option is required.
+func NewCache(options ...CacheOption) (*Cache, error) {
+	opts := defaultCacheOptions
+	for _, opt := range globalCacheOptions {
+		opt.apply(&opts)
+	}
+	for _, opt := range options {
+		opt.apply(&opts)
+	}
+
+	if opts.Counter == nil {
+		return nil, ErrMissingOptionCounter
+	}
+
+	return &Cache{
+		options: &opts,
+	}, nil
+}
+
+// CatalogStats returns the cached statistics, or recomputes them if they are older than the refresh interval. If
+// recomputing fails, the error is logged and the previous statistics are returned. The error is only returned if no
+// statistics were computed before.
+func (c *Cache) CatalogStats(ctx context.Context) (oslc.CatalogStats, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.options.Now()
+	if c.stats != nil && now.Sub(c.stats.ComputedAt) < c.options.RefreshInterval {
+		return *c.stats, nil
+	}
+
+	counts, err := c.options.Counter.CountPackages(ctx)
+	if err != nil {
+		if c.stats != nil {
+			c.options.Logger.ErrorContext(ctx, "failed to refresh catalog statistics, serving stale statistics",
+				slog.String("error", err.Error()), slog.Time("computed_at", c.stats.ComputedAt))
+			return *c.stats, nil
+		}
+		return oslc.CatalogStats{}, err
+	}
+	stats := Compute(counts, now)
+	c.stats = &stats
+	return stats, nil
+}
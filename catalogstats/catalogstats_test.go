@@ -0,0 +1,104 @@
+package catalogstats
+
+import (
+	"context"
+	"github.com/chainalysis-oss/oslc"
+	oslcMocks "github.com/chainalysis-oss/oslc/mocks/oslc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+var testCounts = []oslc.PackageCount{
+	{Distributor: oslc.DistributorNpm, License: "MIT", NormalizationStatus: oslc.LicenseNormalizationExact, Count: 10},
+	{Distributor: oslc.DistributorNpm, License: "GPL-3.0-only", NormalizationStatus: oslc.LicenseNormalizationAlias, Count: 2},
+	{Distributor: oslc.DistributorPypi, License: "MIT", NormalizationStatus: oslc.LicenseNormalizationExact, Count: 5},
+	{Distributor: oslc.DistributorPypi, License: "", NormalizationStatus: oslc.LicenseNormalizationFailed, Count: 3},
+}
+
+func TestCompute(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	require.Equal(t, oslc.CatalogStats{
+		Total:                 20,
+		ByLicense:             map[string]int64{"MIT": 15, "GPL-3.0-only": 2, "": 3},
+		ByLicenseCategory:     map[string]int64{"permissive": 15, "copyleft": 2, "unknown": 3},
+		ByDistributor:         map[string]int64{oslc.DistributorNpm: 12, oslc.DistributorPypi: 8},
+		ByNormalizationStatus: map[string]int64{"exact": 15, "alias": 2, "failed": 3},
+		ComputedAt:            now,
+	}, Compute(testCounts, now))
+}
+
+func TestCompute_unknownNormalizationStatus(t *testing.T) {
+	stats := Compute([]oslc.PackageCount{{Distributor: oslc.DistributorNpm, License: "MIT", Count: 4}}, time.Now())
+	require.Equal(t, map[string]int64{StatusUnknown: 4}, stats.ByNormalizationStatus)
+}
+
+func TestNewCache(t *testing.T) {
+	_, err := NewCache()
+	require.ErrorIs(t, err, ErrMissingOptionCounter)
+
+	c, err := NewCache(WithCounter(oslcMocks.NewMockDatastore(t)), WithRefreshInterval(time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, time.Minute, c.options.RefreshInterval)
+}
+
+// newTestCache returns a Cache with a clock that is advanced by setting the returned time.
+func newTestCache(t *testing.T) (*Cache, *oslcMocks.MockDatastore, *time.Time) {
+	t.Helper()
+	datastore := oslcMocks.NewMockDatastore(t)
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	c, err := NewCache(
+		WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		WithCounter(datastore),
+		WithRefreshInterval(time.Minute),
+	)
+	require.NoError(t, err)
+	c.options.Now = func() time.Time { return now }
+	return c, datastore, &now
+}
+
+func TestCache_CatalogStats(t *testing.T) {
+	c, datastore, now := newTestCache(t)
+	datastore.EXPECT().CountPackages(context.Background()).Return(testCounts, nil).Once()
+
+	stats, err := c.CatalogStats(context.Background())
+	require.NoError(t, err)
+	require.EqualValues(t, 20, stats.Total)
+
+	// Cached within the refresh interval.
+	*now = now.Add(59 * time.Second)
+	stats, err = c.CatalogStats(context.Background())
+	require.NoError(t, err)
+	require.EqualValues(t, 20, stats.Total)
+
+	// Recomputed after the refresh interval.
+	*now = now.Add(time.Second)
+	datastore.EXPECT().CountPackages(context.Background()).Return(testCounts[:1], nil).Once()
+	stats, err = c.CatalogStats(context.Background())
+	require.NoError(t, err)
+	require.EqualValues(t, 10, stats.Total)
+	require.Equal(t, *now, stats.ComputedAt)
+}
+
+func TestCache_CatalogStats_errors(t *testing.T) {
+	c, datastore, now := newTestCache(t)
+	datastore.EXPECT().CountPackages(context.Background()).Return(nil, assert.AnError).Once()
+	_, err := c.CatalogStats(context.Background())
+	require.ErrorIs(t, err, assert.AnError)
+
+	datastore.EXPECT().CountPackages(context.Background()).Return(testCounts, nil).Once()
+	_, err = c.CatalogStats(context.Background())
+	require.NoError(t, err)
+
+	// Stale statistics are served when refreshing fails.
+	computedAt := *now
+	*now = now.Add(time.Hour)
+	datastore.EXPECT().CountPackages(context.Background()).Return(nil, assert.AnError).Once()
+	stats, err := c.CatalogStats(context.Background())
+	require.NoError(t, err)
+	require.EqualValues(t, 20, stats.Total)
+	require.Equal(t, computedAt, stats.ComputedAt)
+}
@@ -0,0 +1,56 @@
+package catalogstats
+
+import (
+	"context"
+	"github.com/prometheus/client_golang/prometheus"
+	"log/slog"
+)
+
+var (
+	packagesDesc = prometheus.NewDesc("oslc_catalog_packages",
+		"Number of package versions in the catalog.", nil, nil)
+	packagesByLicenseDesc = prometheus.NewDesc("oslc_catalog_packages_by_license",
+		"Number of package versions in the catalog per license. Packages without a recognized license have an empty license.", []string{"license"}, nil)
+	packagesByLicenseCategoryDesc = prometheus.NewDesc("oslc_catalog_packages_by_license_category",
+		"Number of package versions in the catalog per license category.", []string{"category"}, nil)
+	packagesByDistributorDesc = prometheus.NewDesc("oslc_catalog_packages_by_distributor",
+		"Number of package versions in the catalog per distributor.", []string{"distributor"}, nil)
+	packagesByNormalizationStatusDesc = prometheus.NewDesc("oslc_catalog_packages_by_normalization_status",
+		"Number of package versions in the catalog per license normalization status.", []string{"status"}, nil)
+)
+
+// Compile time check to ensure Cache implements [prometheus.Collector].
+var _ prometheus.Collector = (*Cache)(nil)
+
+// Describe implements [prometheus.Collector].
+func (c *Cache) Describe(ch chan<- *prometheus.Desc) {
+	ch <- packagesDesc
+	ch <- packagesByLicenseDesc
+	ch <- packagesByLicenseCategoryDesc
+	ch <- packagesByDistributorDesc
+	ch <- packagesByNormalizationStatusDesc
+}
+
+// Collect implements [prometheus.Collector]. It exports the cached statistics as gauges, recomputing them first if
+// they are older than the refresh interval. If no statistics are available, no metrics are exported.
+func (c *Cache) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.options.CollectTimeout)
+	defer cancel()
+	stats, err := c.CatalogStats(ctx)
+	if err != nil {
+		c.options.Logger.Error("failed to collect catalog statistics", slog.String("error", err.Error()))
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(packagesDesc, prometheus.GaugeValue, float64(stats.Total))
+	for desc, counts := range map[*prometheus.Desc]map[string]int64{
+		packagesByLicenseDesc:             stats.ByLicense,
+		packagesByLicenseCategoryDesc:     stats.ByLicenseCategory,
+		packagesByDistributorDesc:         stats.ByDistributor,
+		packagesByNormalizationStatusDesc: stats.ByNormalizationStatus,
+	} {
+		for label, count := range counts {
+			ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, float64(count), label)
+		}
+	}
+}
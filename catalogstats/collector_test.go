@@ -0,0 +1,50 @@
+package catalogstats
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestCache_Collect(t *testing.T) {
+	c, datastore, _ := newTestCache(t)
+	datastore.EXPECT().CountPackages(mock.Anything).Return(testCounts, nil).Once()
+	registry := prometheus.NewRegistry()
+	require.NoError(t, registry.Register(c))
+
+	expected := `
+# HELP oslc_catalog_packages Number of package versions in the catalog.
+# TYPE oslc_catalog_packages gauge
+oslc_catalog_packages 20
+# HELP oslc_catalog_packages_by_distributor Number of package versions in the catalog per distributor.
+# TYPE oslc_catalog_packages_by_distributor gauge
+oslc_catalog_packages_by_distributor{distributor="npm"} 12
+oslc_catalog_packages_by_distributor{distributor="pypi"} 8
+# HELP oslc_catalog_packages_by_license Number of package versions in the catalog per license. Packages without a recognized license have an empty license.
+# TYPE oslc_catalog_packages_by_license gauge
+oslc_catalog_packages_by_license{license=""} 3
+oslc_catalog_packages_by_license{license="GPL-3.0-only"} 2
+oslc_catalog_packages_by_license{license="MIT"} 15
+# HELP oslc_catalog_packages_by_license_category Number of package versions in the catalog per license category.
+# TYPE oslc_catalog_packages_by_license_category gauge
+oslc_catalog_packages_by_license_category{category="copyleft"} 2
+oslc_catalog_packages_by_license_category{category="permissive"} 15
+oslc_catalog_packages_by_license_category{category="unknown"} 3
+# HELP oslc_catalog_packages_by_normalization_status Number of package versions in the catalog per license normalization status.
+# TYPE oslc_catalog_packages_by_normalization_status gauge
+oslc_catalog_packages_by_normalization_status{status="alias"} 2
+oslc_catalog_packages_by_normalization_status{status="exact"} 15
+oslc_catalog_packages_by_normalization_status{status="failed"} 3
+`
+	require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected)))
+}
+
+func TestCache_Collect_error(t *testing.T) {
+	c, datastore, _ := newTestCache(t)
+	datastore.EXPECT().CountPackages(mock.Anything).Return(nil, assert.AnError)
+	require.Equal(t, 0, testutil.CollectAndCount(c))
+}
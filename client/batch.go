@@ -0,0 +1,231 @@
+package client
+
+import (
+	"buf.build/gen/go/chainalysis-oss/oslc/grpc/go/chainalysis_oss/oslc/v1alpha/oslcv1alphagrpc"
+	oslcv1alpha "buf.build/gen/go/chainalysis-oss/oslc/protocolbuffers/go/chainalysis_oss/oslc/v1alpha"
+	"context"
+	"fmt"
+	"github.com/chainalysis-oss/oslc"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// batchCall is a lookup waiting for the batch it is part of.
+type batchCall struct {
+	request *oslcv1alpha.GetPackageInfoRequest
+	// done is closed after resp or err is set.
+	done chan struct{}
+	resp *oslcv1alpha.GetPackageInfoResponse
+	err  error
+}
+
+// pendingBatch is a batch that is waiting for its window to end, or to be full.
+type pendingBatch struct {
+	// ctx is the context of the first lookup of the batch, without its cancellation. The batch is sent with its values,
+	// such as the trace context and the outgoing metadata, which are the same for every lookup of the batch.
+	ctx context.Context
+	// deadline is the earliest deadline of the lookups of the batch, or zero if none of them has a deadline.
+	deadline time.Time
+	calls    []*batchCall
+	timer    *time.Timer
+}
+
+// batcher collects the lookups made within a window of each other, and sends them in a single BatchGetPackageInfo
+// request. Only lookups with the same outgoing metadata, such as the tenant header, share a batch. Servers that do not
+// support batches are detected, after which lookups are sent on their own.
+type batcher struct {
+	service oslcv1alphagrpc.OslcServiceClient
+	window  time.Duration
+	maxSize int
+	timeout time.Duration
+	logger  *slog.Logger
+
+	unsupported atomic.Bool
+
+	mu sync.Mutex
+	// pending are the pending batches by the key of their outgoing metadata.
+	pending map[string]*pendingBatch
+}
+
+func newBatcher(service oslcv1alphagrpc.OslcServiceClient, window time.Duration, maxSize int, timeout time.Duration, logger *slog.Logger) *batcher {
+	return &batcher{
+		service: service,
+		window:  window,
+		maxSize: max(maxSize, 1),
+		timeout: timeout,
+		logger:  logger,
+		pending: make(map[string]*pendingBatch),
+	}
+}
+
+// supported reports whether the server is not known to lack support for batches.
+func (b *batcher) supported() bool {
+	return !b.unsupported.Load()
+}
+
+// do adds the request to the pending batch of lookups with the same outgoing metadata, and waits for its result. If ctx
+// is done first, the request remains part of the batch, but its result is discarded.
+func (b *batcher) do(ctx context.Context, request *oslcv1alpha.GetPackageInfoRequest) (*oslcv1alpha.GetPackageInfoResponse, error) {
+	call := &batchCall{request: request, done: make(chan struct{})}
+	key := metadataKey(ctx)
+	b.mu.Lock()
+	batch, ok := b.pending[key]
+	if !ok {
+		batch = &pendingBatch{ctx: context.WithoutCancel(ctx)}
+		batch.timer = time.AfterFunc(b.window, func() { b.flush(key, batch) })
+		b.pending[key] = batch
+	}
+	batch.calls = append(batch.calls, call)
+	if deadline, ok := ctx.Deadline(); ok && (batch.deadline.IsZero() || deadline.Before(batch.deadline)) {
+		batch.deadline = deadline
+	}
+	if len(batch.calls) >= b.maxSize {
+		b.take(key, batch)
+		b.mu.Unlock()
+		go b.send(batch)
+	} else {
+		b.mu.Unlock()
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-call.done:
+		return call.resp, call.err
+	}
+}
+
+// take removes the batch from the pending batches, so later lookups start a new one. It reports whether the batch was
+// still pending. b.mu must be held.
+func (b *batcher) take(key string, batch *pendingBatch) bool {
+	if b.pending[key] != batch {
+		return false
+	}
+	batch.timer.Stop()
+	delete(b.pending, key)
+	return true
+}
+
+// flush sends the batch when its window ends, unless it was already sent because it was full.
+func (b *batcher) flush(key string, batch *pendingBatch) {
+	b.mu.Lock()
+	taken := b.take(key, batch)
+	b.mu.Unlock()
+	if taken {
+		b.send(batch)
+	}
+}
+
+// metadataKey returns a key identifying the outgoing metadata of ctx.
+func metadataKey(ctx context.Context) string {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	keys := make([]string, 0, len(md))
+	for k := range md {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	var sb strings.Builder
+	for _, k := range keys {
+		sb.WriteString(k)
+		for _, v := range md[k] {
+			sb.WriteByte(0)
+			sb.WriteString(v)
+		}
+		sb.WriteByte(1)
+	}
+	return sb.String()
+}
+
+// send sends the calls of the batch in a single request, with the values of the batch's context. Identical requests
+// are only sent once. The request times out after the batch timeout, or at the earliest deadline of its lookups if that
+// is sooner.
+func (b *batcher) send(batch *pendingBatch) {
+	deadline := time.Now().Add(b.timeout)
+	if !batch.deadline.IsZero() && batch.deadline.Before(deadline) {
+		deadline = batch.deadline
+	}
+	ctx, cancel := context.WithDeadline(batch.ctx, deadline)
+	defer cancel()
+
+	calls := batch.calls
+	type requestKey struct{ distributor, name, version string }
+	indexes := make(map[requestKey]int)
+	callIndexes := make([]int, len(calls))
+	var requests []*oslcv1alpha.GetPackageInfoRequest
+	for i, call := range calls {
+		key := requestKey{call.request.Distributor, call.request.Name, call.request.Version}
+		index, ok := indexes[key]
+		if !ok {
+			index = len(requests)
+			indexes[key] = index
+			requests = append(requests, call.request)
+		}
+		callIndexes[i] = index
+	}
+
+	resp, err := b.service.BatchGetPackageInfo(ctx, &oslcv1alpha.BatchGetPackageInfoRequest{Requests: requests})
+	if status.Code(err) == codes.Unimplemented {
+		b.logger.Info("server does not support batches, sending lookups separately")
+		b.unsupported.Store(true)
+		b.sendSeparately(ctx, calls)
+		return
+	}
+	if err == nil && len(resp.Results) != len(requests) {
+		err = status.Error(codes.Internal, fmt.Sprintf("batch response has %d results for %d requests", len(resp.Results), len(requests)))
+	}
+	for i, call := range calls {
+		if err != nil {
+			call.err = err
+		} else {
+			call.resp, call.err = batchResult(resp.Results[callIndexes[i]])
+		}
+		close(call.done)
+	}
+}
+
+// sendSeparately sends each call in a request of its own.
+func (b *batcher) sendSeparately(ctx context.Context, calls []*batchCall) {
+	var wg sync.WaitGroup
+	for _, call := range calls {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			call.resp, call.err = b.service.GetPackageInfo(ctx, call.request)
+			close(call.done)
+		}()
+	}
+	wg.Wait()
+}
+
+// batchResult returns the response of a batch result, or its error as the status error GetPackageInfo would have
+// returned.
+func batchResult(result *oslcv1alpha.BatchGetPackageInfoResult) (*oslcv1alpha.GetPackageInfoResponse, error) {
+	if resp := result.GetPackage(); resp != nil {
+		return resp, nil
+	}
+	e := result.GetError()
+	if e == nil {
+		return nil, status.Error(codes.Internal, "batch result has neither a package nor an error")
+	}
+	st := status.New(codes.Code(e.Code), e.Message)
+	var details []protoadapt.MessageV1
+	if e.Reason != "" {
+		details = append(details, &errdetails.ErrorInfo{Reason: e.Reason, Domain: oslc.ErrorInfoDomain})
+	}
+	if e.RetryDelay != nil {
+		details = append(details, &errdetails.RetryInfo{RetryDelay: e.RetryDelay})
+	}
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		st = withDetails
+	}
+	return nil, st.Err()
+}
@@ -0,0 +1,147 @@
+package client
+
+import (
+	oslcv1alpha "buf.build/gen/go/chainalysis-oss/oslc/protocolbuffers/go/chainalysis_oss/oslc/v1alpha"
+	"context"
+	"github.com/chainalysis-oss/oslc"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"sync"
+	"testing"
+	"time"
+)
+
+// lookupConcurrently looks up lodash n times at once, and returns the errors.
+func lookupConcurrently(c *Client, n int, version func(i int) string) []error {
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = c.GetPackageVersion(context.Background(), oslc.DistributorNpm, "lodash", version(i))
+		}()
+	}
+	wg.Wait()
+	return errs
+}
+
+func TestBatcher(t *testing.T) {
+	f := &fakeServer{}
+	c := newTestClient(t, f, WithBatching(100*time.Millisecond, 100))
+	errs := lookupConcurrently(c, 10, func(i int) string {
+		return []string{"4.17.21", "^4.0.0", "0.0.1"}[i%3]
+	})
+	for i, err := range errs {
+		if i%3 == 2 {
+			require.ErrorIs(t, err, oslc.ErrVersionNotFound)
+		} else {
+			require.NoError(t, err)
+		}
+	}
+	// The lookups are sent in a single batch, with identical requests sent once.
+	require.Len(t, f.batches, 1)
+	require.Len(t, f.batches[0], 3)
+}
+
+func TestBatcher_maxSize(t *testing.T) {
+	f := &fakeServer{}
+	// The window is long enough that the test would time out if batches were not sent as soon as they are full.
+	c := newTestClient(t, f, WithBatching(time.Hour, 2))
+	errs := lookupConcurrently(c, 4, func(i int) string { return "4.17.21" })
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+	require.Len(t, f.batches, 2)
+}
+
+func TestBatcher_unsupported(t *testing.T) {
+	f := &fakeServer{batchUnsupported: true}
+	c := newTestClient(t, f, WithBatching(time.Millisecond, 100))
+	for _, err := range lookupConcurrently(c, 3, func(i int) string { return "4.17.21" }) {
+		require.NoError(t, err)
+	}
+	require.False(t, c.batcher.supported())
+	require.EqualValues(t, 3, f.lookups.Load())
+
+	// Later lookups are sent separately straight away.
+	_, err := c.GetPackage(context.Background(), oslc.DistributorNpm, "lodash")
+	require.NoError(t, err)
+	require.EqualValues(t, 4, f.lookups.Load())
+}
+
+func TestBatcher_contextDone(t *testing.T) {
+	c := newTestClient(t, &fakeServer{}, WithBatching(time.Hour, 100))
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	_, err := c.GetPackage(ctx, oslc.DistributorNpm, "lodash")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestBatcher_metadata(t *testing.T) {
+	f := &fakeServer{}
+	c := newTestClient(t, f, WithBatching(100*time.Millisecond, 100))
+	var wg sync.WaitGroup
+	for i := range 6 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx := metadata.AppendToOutgoingContext(context.Background(), "x-oslc-tenant", []string{"payments", "search"}[i%2])
+			_, err := c.GetPackage(ctx, oslc.DistributorNpm, "lodash")
+			require.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	// Lookups of different tenants are never sent in the same batch.
+	require.Len(t, f.batches, 2)
+	require.ElementsMatch(t, []string{"payments", "search"}, f.batchTenants)
+}
+
+func TestBatcher_deadline(t *testing.T) {
+	f := &fakeServer{}
+	c := newTestClient(t, f, WithBatching(time.Millisecond, 100))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	want, _ := ctx.Deadline()
+	_, err := c.GetPackage(ctx, oslc.DistributorNpm, "lodash")
+	require.NoError(t, err)
+	require.Len(t, f.batchDeadlines, 1)
+	// The deadline of the lookup is sooner than the batch timeout, so the batch uses it.
+	require.WithinDuration(t, want, f.batchDeadlines[0], time.Second)
+
+	_, err = c.GetPackage(context.Background(), oslc.DistributorNpm, "underscore")
+	require.Error(t, err)
+	require.Len(t, f.batchDeadlines, 2)
+	require.WithinDuration(t, time.Now().Add(defaultClientOptions.BatchTimeout), f.batchDeadlines[1], time.Second)
+}
+
+func TestMetadataKey(t *testing.T) {
+	require.Empty(t, metadataKey(context.Background()))
+	a := metadata.NewOutgoingContext(context.Background(), metadata.Pairs("b", "2", "a", "1"))
+	b := metadata.NewOutgoingContext(context.Background(), metadata.Pairs("a", "1", "b", "2"))
+	require.Equal(t, metadataKey(a), metadataKey(b))
+	require.NotEqual(t, metadataKey(a), metadataKey(metadata.NewOutgoingContext(context.Background(), metadata.Pairs("a", "12"))))
+}
+
+func TestBatchResult(t *testing.T) {
+	resp, err := batchResult(&oslcv1alpha.BatchGetPackageInfoResult{Result: &oslcv1alpha.BatchGetPackageInfoResult_Package{Package: lodashResponse}})
+	require.NoError(t, err)
+	require.Equal(t, lodashResponse, resp)
+
+	_, err = batchResult(&oslcv1alpha.BatchGetPackageInfoResult{Result: &oslcv1alpha.BatchGetPackageInfoResult_Error{Error: &oslcv1alpha.PackageInfoError{
+		Code:       int32(codes.Unavailable),
+		Message:    "distributor unavailable",
+		Reason:     oslc.ReasonUpstreamUnavailable,
+		RetryDelay: durationpb.New(time.Minute),
+	}}})
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	require.Equal(t, "distributor unavailable", status.Convert(err).Message())
+	require.Equal(t, oslc.ReasonUpstreamUnavailable, errorInfoReason(status.Convert(err)))
+	require.Equal(t, time.Minute, retryInfoDelay(err))
+
+	_, err = batchResult(&oslcv1alpha.BatchGetPackageInfoResult{})
+	require.Equal(t, codes.Internal, status.Code(err))
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"github.com/chainalysis-oss/oslc"
+	"sync"
+	"time"
+)
+
+type cacheKey struct {
+	distributor string
+	name        string
+	version     string
+}
+
+type cacheEntry struct {
+	entry   oslc.Entry
+	expires time.Time
+}
+
+// cache holds lookups for a fixed time to live. When it is full, expired entries are evicted, and if none have
+// expired, an arbitrary entry.
+type cache struct {
+	ttl  time.Duration
+	size int
+	now  func() time.Time
+
+	mu      sync.Mutex
+	entries map[cacheKey]cacheEntry
+}
+
+func newCache(ttl time.Duration, size int) *cache {
+	return &cache{
+		ttl:     ttl,
+		size:    max(size, 1),
+		now:     time.Now,
+		entries: make(map[cacheKey]cacheEntry),
+	}
+}
+
+func (c *cache) get(key cacheKey) (oslc.Entry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return oslc.Entry{}, false
+	}
+	if !c.now().Before(e.expires) {
+		delete(c.entries, key)
+		return oslc.Entry{}, false
+	}
+	return e.entry, true
+}
+
+func (c *cache) set(key cacheKey, entry oslc.Entry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.size {
+		for k, e := range c.entries {
+			if !now.Before(e.expires) {
+				delete(c.entries, k)
+			}
+		}
+		for k := range c.entries {
+			if len(c.entries) < c.size {
+				break
+			}
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = cacheEntry{entry: entry, expires: now.Add(c.ttl)}
+}
@@ -0,0 +1,51 @@
+package client
+
+import (
+	"github.com/chainalysis-oss/oslc"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	c := newCache(time.Minute, 2)
+	c.now = func() time.Time { return now }
+
+	first := cacheKey{distributor: oslc.DistributorNpm, name: "lodash", version: "4.17.21"}
+	c.set(first, lodashEntry)
+	entry, ok := c.get(first)
+	require.True(t, ok)
+	require.Equal(t, lodashEntry, entry)
+
+	_, ok = c.get(cacheKey{distributor: oslc.DistributorNpm, name: "lodash"})
+	require.False(t, ok)
+
+	now = now.Add(time.Minute)
+	_, ok = c.get(first)
+	require.False(t, ok)
+	require.Empty(t, c.entries)
+}
+
+func TestCache_eviction(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	c := newCache(time.Minute, 2)
+	c.now = func() time.Time { return now }
+
+	c.set(cacheKey{name: "expired"}, oslc.Entry{})
+	now = now.Add(time.Minute)
+	c.set(cacheKey{name: "a"}, oslc.Entry{})
+	// The expired entry is evicted first.
+	c.set(cacheKey{name: "b"}, oslc.Entry{})
+	require.Len(t, c.entries, 2)
+	require.NotContains(t, c.entries, cacheKey{name: "expired"})
+
+	// Without expired entries, an arbitrary entry is evicted.
+	c.set(cacheKey{name: "c"}, oslc.Entry{})
+	require.Len(t, c.entries, 2)
+	require.Contains(t, c.entries, cacheKey{name: "c"})
+
+	// Replacing an entry does not evict others.
+	c.set(cacheKey{name: "c"}, lodashEntry)
+	require.Len(t, c.entries, 2)
+}
@@ -0,0 +1,245 @@
+// Package client is a Go client for the OSLC Request Server.
+//
+// The [Client] looks up the licenses of packages and returns them as [oslc.Entry] values. Lookups failing because the
+// server is unavailable are retried, successful lookups may be cached, and concurrent lookups are sent to the server in
+// batches. [Client.Distributor] returns an [oslc.DistributorClient], so that one OSLC server can serve the packages of
+// another.
+package client
+
+import (
+	"buf.build/gen/go/chainalysis-oss/oslc/grpc/go/chainalysis_oss/oslc/v1alpha/oslcv1alphagrpc"
+	oslcv1alpha "buf.build/gen/go/chainalysis-oss/oslc/protocolbuffers/go/chainalysis_oss/oslc/v1alpha"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/chainalysis-oss/oslc"
+	"github.com/chainalysis-oss/oslc/purl"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"log/slog"
+	"time"
+)
+
+// ErrMissingAddress is returned by [NewClient] if neither an address nor a connection is provided.
+var ErrMissingAddress = errors.New("missing option: address or connection is required")
+
+// Client looks up the licenses of packages from an OSLC Request Server. It is safe for concurrent use.
+type Client struct {
+	options *clientOptions
+	// conn is the connection created by the client, or nil if it was provided by [WithConn].
+	conn    *grpc.ClientConn
+	service oslcv1alphagrpc.OslcServiceClient
+	// cache is nil if caching is disabled.
+	cache *cache
+	// batcher is nil if batching is disabled.
+	batcher *batcher
+}
+
+// NewClient returns a new Client. Either [WithAddress] or [WithConn] must be provided.
+func NewClient(options ...ClientOption) (*Client, error) {
+	opts := defaultClientOptions
+	for _, opt := range globalClientOptions {
+		opt.apply(&opts)
+	}
+	for _, opt := range options {
+		opt.apply(&opts)
+	}
+
+	c := &Client{options: &opts}
+	conn := opts.Conn
+	if conn == nil {
+		if opts.Address == "" {
+			return nil, ErrMissingAddress
+		}
+		var err error
+		c.conn, err = grpc.NewClient(opts.Address, dialOptions(&opts)...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create grpc client: %w", err)
+		}
+		conn = c.conn
+	}
+	c.service = oslcv1alphagrpc.NewOslcServiceClient(conn)
+	if opts.CacheTTL > 0 {
+		c.cache = newCache(opts.CacheTTL, opts.CacheSize)
+	}
+	if opts.BatchWindow > 0 {
+		c.batcher = newBatcher(c.service, opts.BatchWindow, opts.MaxBatchSize, opts.BatchTimeout, opts.Logger)
+	}
+	return c, nil
+}
+
+func dialOptions(opts *clientOptions) []grpc.DialOption {
+	var options []grpc.DialOption
+	if opts.Insecure {
+		options = append(options, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	} else {
+		options = append(options, grpc.WithTransportCredentials(credentials.NewTLS(opts.TLSConfig)))
+	}
+	if opts.APIKey != "" {
+		options = append(options, grpc.WithPerRPCCredentials(apiKeyCredentials{apiKey: opts.APIKey, insecure: opts.Insecure}))
+	}
+	return append(options, opts.DialOptions...)
+}
+
+// apiKeyCredentials sends an API key as a bearer token, in the same way as the token of the admin service.
+type apiKeyCredentials struct {
+	apiKey   string
+	insecure bool
+}
+
+func (c apiKeyCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + c.apiKey}, nil
+}
+
+func (c apiKeyCredentials) RequireTransportSecurity() bool {
+	return !c.insecure
+}
+
+// Close closes the connection to the server, unless it was provided by [WithConn].
+func (c *Client) Close() error {
+	if c.conn == nil {
+		return nil
+	}
+	return c.conn.Close()
+}
+
+// GetPackage returns the latest version of the package of the distributor.
+func (c *Client) GetPackage(ctx context.Context, distributor, name string) (oslc.Entry, error) {
+	return c.GetPackageVersion(ctx, distributor, name, "")
+}
+
+// GetPackageVersion returns the version of the package of the distributor. The version may also be a version
+// constraint or, for npm, a dist-tag, in which case the highest matching version is returned. An empty version returns
+// the latest version.
+//
+// Errors are returned in the same way as by an [oslc.DistributorClient]: an [oslc.DistributorError] wrapping
+// [oslc.ErrNoSuchPackage] or [oslc.ErrVersionNotFound] if the package or version is not found, and otherwise of the
+// [oslc.DistributorErrorKind] matching the error returned by the server.
+func (c *Client) GetPackageVersion(ctx context.Context, distributor, name, version string) (oslc.Entry, error) {
+	key := cacheKey{distributor: distributor, name: name, version: version}
+	if c.cache != nil {
+		if entry, ok := c.cache.get(key); ok {
+			return entry, nil
+		}
+	}
+
+	request := &oslcv1alpha.GetPackageInfoRequest{Distributor: distributor, Name: name, Version: version}
+	resp, err := c.withRetries(ctx, func() (*oslcv1alpha.GetPackageInfoResponse, error) {
+		return c.getPackageInfo(ctx, request)
+	})
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return oslc.Entry{}, ctxErr
+		}
+		return oslc.Entry{}, statusToError(distributor, err)
+	}
+
+	entry := responseToEntry(resp)
+	if c.cache != nil {
+		c.cache.set(key, entry)
+		// Constraints and the latest version resolve to a version, which is also cached.
+		c.cache.set(cacheKey{distributor: distributor, name: name, version: entry.Version}, entry)
+	}
+	return entry, nil
+}
+
+// GetPackageByURL returns the package identified by a package URL, such as "pkg:npm/lodash@4.17.21". Package URLs
+// without a version return the latest version.
+func (c *Client) GetPackageByURL(ctx context.Context, packageURL string) (oslc.Entry, error) {
+	p, err := purl.Parse(packageURL)
+	if err != nil {
+		return oslc.Entry{}, err
+	}
+	return c.GetPackageVersion(ctx, p.Distributor, p.Name, p.Version)
+}
+
+// getPackageInfo sends the request, as part of a batch if batching is enabled and supported by the server.
+func (c *Client) getPackageInfo(ctx context.Context, request *oslcv1alpha.GetPackageInfoRequest) (*oslcv1alpha.GetPackageInfoResponse, error) {
+	if c.batcher != nil && c.batcher.supported() {
+		return c.batcher.do(ctx, request)
+	}
+	return c.service.GetPackageInfo(ctx, request)
+}
+
+// withRetries calls f until it succeeds, fails with an error other than UNAVAILABLE, or has been retried MaxRetries
+// times.
+func (c *Client) withRetries(ctx context.Context, f func() (*oslcv1alpha.GetPackageInfoResponse, error)) (*oslcv1alpha.GetPackageInfoResponse, error) {
+	backoff := c.options.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		resp, err := f()
+		if err == nil || attempt >= c.options.MaxRetries || !retryable(err) {
+			return resp, err
+		}
+		delay := backoff
+		if retryDelay := retryInfoDelay(err); retryDelay > 0 {
+			delay = retryDelay
+		}
+		delay = min(delay, c.options.MaxRetryBackoff)
+		c.options.Logger.DebugContext(ctx, "retrying lookup", slog.Int("attempt", attempt+1), slog.Duration("delay", delay), slog.String("error", err.Error()))
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+// Distributor returns an [oslc.DistributorClient] for the packages of the distributor.
+func (c *Client) Distributor(distributor string) *DistributorClient {
+	return &DistributorClient{client: c, distributor: distributor}
+}
+
+// Compile time check to ensure DistributorClient implements [oslc.DistributorClient].
+var _ oslc.DistributorClient = (*DistributorClient)(nil)
+
+// DistributorClient is an [oslc.DistributorClient] looking up the packages of a single distributor from an OSLC server.
+type DistributorClient struct {
+	client      *Client
+	distributor string
+}
+
+func (d *DistributorClient) GetPackage(ctx context.Context, name string) (oslc.Entry, error) {
+	return d.client.GetPackage(ctx, d.distributor, name)
+}
+
+func (d *DistributorClient) GetPackageVersion(ctx context.Context, name, version string) (oslc.Entry, error) {
+	return d.client.GetPackageVersion(ctx, d.distributor, name, version)
+}
+
+func responseToEntry(resp *oslcv1alpha.GetPackageInfoResponse) oslc.Entry {
+	entry := oslc.Entry{
+		Name:                resp.Name,
+		License:             resp.License,
+		Version:             resp.Version,
+		RawLicense:          resp.RawLicense,
+		NormalizationStatus: normalizationStatusFromProto(resp.NormalizationStatus),
+		LicenseListVersion:  resp.LicenseListVersion,
+	}
+	for _, dp := range resp.DistributionPoints {
+		entry.DistributionPoints = append(entry.DistributionPoints, oslc.DistributionPoint{
+			Name:        dp.Name,
+			URL:         dp.Url,
+			Distributor: dp.Distributor,
+		})
+	}
+	return entry
+}
+
+func normalizationStatusFromProto(status oslcv1alpha.LicenseNormalizationStatus) oslc.LicenseNormalizationStatus {
+	switch status {
+	case oslcv1alpha.LicenseNormalizationStatus_LICENSE_NORMALIZATION_STATUS_EXACT:
+		return oslc.LicenseNormalizationExact
+	case oslcv1alpha.LicenseNormalizationStatus_LICENSE_NORMALIZATION_STATUS_ALIAS:
+		return oslc.LicenseNormalizationAlias
+	case oslcv1alpha.LicenseNormalizationStatus_LICENSE_NORMALIZATION_STATUS_EXPRESSION:
+		return oslc.LicenseNormalizationExpression
+	case oslcv1alpha.LicenseNormalizationStatus_LICENSE_NORMALIZATION_STATUS_FAILED:
+		return oslc.LicenseNormalizationFailed
+	}
+	return ""
+}
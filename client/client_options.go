@@ -0,0 +1,161 @@
+package client
+
+import (
+	"crypto/tls"
+	"google.golang.org/grpc"
+	"log/slog"
+	"time"
+)
+
+type clientOptions struct {
+	// Address is the address of the server, as host:port.
+	Address string
+	// TLSConfig is the TLS configuration of the connection. If nil, the system's certificate authorities are trusted.
+	TLSConfig *tls.Config
+	// Insecure disables TLS.
+	Insecure bool
+	// APIKey is sent to the server as a bearer token, unless empty.
+	APIKey string
+	// Conn is a connection to use instead of dialing Address.
+	Conn        grpc.ClientConnInterface
+	DialOptions []grpc.DialOption
+	Logger      *slog.Logger
+	// MaxRetries is the number of times a lookup failing with UNAVAILABLE is retried.
+	MaxRetries int
+	// RetryBackoff is the delay before the first retry. It doubles with every retry, up to MaxRetryBackoff. A delay
+	// asked for by the server is used instead, also up to MaxRetryBackoff.
+	RetryBackoff    time.Duration
+	MaxRetryBackoff time.Duration
+	// CacheTTL is how long successful lookups are cached, or zero to disable the cache.
+	CacheTTL time.Duration
+	// CacheSize is the maximum number of cached lookups.
+	CacheSize int
+	// BatchWindow is how long a lookup waits for concurrent lookups to send with it in a single batch, or zero to
+	// disable batching.
+	BatchWindow time.Duration
+	// MaxBatchSize is the maximum number of lookups in a batch. A batch is sent as soon as it is full.
+	MaxBatchSize int
+	// BatchTimeout is the timeout of a batch. Batches are shared by several lookups, so a batch uses the earliest deadline
+	// of its lookups only if it is sooner.
+	BatchTimeout time.Duration
+}
+
+var defaultClientOptions = clientOptions{
+	Logger:          slog.Default(),
+	MaxRetries:      3,
+	RetryBackoff:    100 * time.Millisecond,
+	MaxRetryBackoff: 5 * time.Second,
+	CacheSize:       10000,
+	BatchWindow:     5 * time.Millisecond,
+	MaxBatchSize:    100,
+	BatchTimeout:    30 * time.Second,
+}
+
+var globalClientOptions []ClientOption
+
+// ClientOption is an option for configuring a Client.
+type ClientOption interface {
+	apply(*clientOptions)
+}
+
+// funcClientOption is a ClientOption that calls a function.
+// It is used to wrap a function, so it satisfies the ClientOption interface.
+type funcClientOption struct {
+	f func(*clientOptions)
+}
+
+func (fdo *funcClientOption) apply(opts *clientOptions) {
+	fdo.f(opts)
+}
+
+func newFuncClientOption(f func(*clientOptions)) *funcClientOption {
+	return &funcClientOption{
+		f: f,
+	}
+}
+
+// WithAddress returns a ClientOption that connects to the server at address, as host:port.
+func WithAddress(address string) ClientOption {
+	return newFuncClientOption(func(opts *clientOptions) {
+		opts.Address = address
+	})
+}
+
+// WithTLSConfig returns a ClientOption that uses the provided TLS configuration, for example to trust a private
+// certificate authority or to present a client certificate.
+func WithTLSConfig(config *tls.Config) ClientOption {
+	return newFuncClientOption(func(opts *clientOptions) {
+		opts.TLSConfig = config
+	})
+}
+
+// WithInsecure returns a ClientOption that connects to the server without TLS.
+func WithInsecure(insecure bool) ClientOption {
+	return newFuncClientOption(func(opts *clientOptions) {
+		opts.Insecure = insecure
+	})
+}
+
+// WithAPIKey returns a ClientOption that sends the API key to the server as a bearer token.
+func WithAPIKey(apiKey string) ClientOption {
+	return newFuncClientOption(func(opts *clientOptions) {
+		opts.APIKey = apiKey
+	})
+}
+
+// WithConn returns a ClientOption that uses the provided connection instead of connecting to an address. The connection
+// is not closed by [Client.Close], and the TLS and API key options do not apply to it.
+func WithConn(conn grpc.ClientConnInterface) ClientOption {
+	return newFuncClientOption(func(opts *clientOptions) {
+		opts.Conn = conn
+	})
+}
+
+// WithDialOptions returns a ClientOption that adds options for connecting to the server.
+func WithDialOptions(options ...grpc.DialOption) ClientOption {
+	return newFuncClientOption(func(opts *clientOptions) {
+		opts.DialOptions = append(opts.DialOptions, options...)
+	})
+}
+
+// WithLogger returns a ClientOption that uses the provided logger.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return newFuncClientOption(func(opts *clientOptions) {
+		opts.Logger = logger
+	})
+}
+
+// WithRetries returns a ClientOption that retries lookups failing with UNAVAILABLE up to maxRetries times, waiting
+// backoff before the first retry and doubling the wait with every retry, up to maxBackoff.
+func WithRetries(maxRetries int, backoff, maxBackoff time.Duration) ClientOption {
+	return newFuncClientOption(func(opts *clientOptions) {
+		opts.MaxRetries = maxRetries
+		opts.RetryBackoff = backoff
+		opts.MaxRetryBackoff = maxBackoff
+	})
+}
+
+// WithCache returns a ClientOption that caches up to size successful lookups for ttl. A ttl of zero disables the
+// cache, which is the default.
+func WithCache(ttl time.Duration, size int) ClientOption {
+	return newFuncClientOption(func(opts *clientOptions) {
+		opts.CacheTTL = ttl
+		opts.CacheSize = size
+	})
+}
+
+// WithBatching returns a ClientOption that sends lookups made within window of each other in a single batch of up to
+// maxSize lookups. A window of zero disables batching.
+func WithBatching(window time.Duration, maxSize int) ClientOption {
+	return newFuncClientOption(func(opts *clientOptions) {
+		opts.BatchWindow = window
+		opts.MaxBatchSize = maxSize
+	})
+}
+
+// WithBatchTimeout returns a ClientOption that sets the timeout of a batch of lookups.
+func WithBatchTimeout(timeout time.Duration) ClientOption {
+	return newFuncClientOption(func(opts *clientOptions) {
+		opts.BatchTimeout = timeout
+	})
+}
@@ -0,0 +1,129 @@
+package client
+
+import (
+	"crypto/tls"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestNewClient(t *testing.T) {
+	client, err := NewClient(WithAddress("localhost:8080"), WithLogger(slog.Default()))
+	require.NoError(t, err)
+	require.NotNil(t, client)
+	require.Equal(t, slog.Default(), client.options.Logger)
+	require.NotNil(t, client.conn)
+	require.Nil(t, client.cache)
+	require.NotNil(t, client.batcher)
+}
+
+func TestNewClient_globalOptionsAreApplied(t *testing.T) {
+	optCopy := make([]ClientOption, len(globalClientOptions))
+	copy(optCopy, globalClientOptions)
+	defer func() {
+		globalClientOptions = optCopy
+	}()
+
+	globalClientOptions = append(globalClientOptions, WithAddress("localhost:8080"))
+	client, err := NewClient()
+	require.NoError(t, err)
+	require.Equal(t, "localhost:8080", client.options.Address)
+}
+
+func TestFuncClientOption_apply(t *testing.T) {
+	opts := clientOptions{}
+	fdo := newFuncClientOption(func(o *clientOptions) {
+		o.Logger = slog.Default()
+	})
+	fdo.apply(&opts)
+	require.Equal(t, slog.Default(), opts.Logger)
+}
+
+func TestNewFuncClientOption(t *testing.T) {
+	fdo := newFuncClientOption(func(o *clientOptions) {
+		o.Logger = slog.Default()
+	})
+	require.NotNil(t, fdo)
+}
+
+func TestWithAddress(t *testing.T) {
+	opts := clientOptions{}
+	WithAddress("oslc.example.com:443").apply(&opts)
+	require.Equal(t, "oslc.example.com:443", opts.Address)
+}
+
+func TestWithTLSConfig(t *testing.T) {
+	config := &tls.Config{ServerName: "oslc.example.com"}
+	opts := clientOptions{}
+	WithTLSConfig(config).apply(&opts)
+	require.Equal(t, config, opts.TLSConfig)
+}
+
+func TestWithInsecure(t *testing.T) {
+	opts := clientOptions{}
+	WithInsecure(true).apply(&opts)
+	require.True(t, opts.Insecure)
+}
+
+func TestWithAPIKey(t *testing.T) {
+	opts := clientOptions{}
+	WithAPIKey("secret").apply(&opts)
+	require.Equal(t, "secret", opts.APIKey)
+}
+
+func TestWithConn(t *testing.T) {
+	conn, err := grpc.NewClient("localhost:8080", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	opts := clientOptions{}
+	WithConn(conn).apply(&opts)
+	require.Equal(t, conn, opts.Conn)
+}
+
+func TestWithDialOptions(t *testing.T) {
+	opts := clientOptions{}
+	WithDialOptions(grpc.WithUserAgent("test")).apply(&opts)
+	WithDialOptions(grpc.WithAuthority("oslc.example.com")).apply(&opts)
+	require.Len(t, opts.DialOptions, 2)
+}
+
+func TestWithLogger(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	require.NotNil(t, logger)
+	opts := clientOptions{}
+	f := WithLogger(logger)
+	f.apply(&opts)
+	require.Equal(t, logger, opts.Logger)
+}
+
+func TestWithRetries(t *testing.T) {
+	opts := clientOptions{}
+	WithRetries(5, time.Second, time.Minute).apply(&opts)
+	require.Equal(t, 5, opts.MaxRetries)
+	require.Equal(t, time.Second, opts.RetryBackoff)
+	require.Equal(t, time.Minute, opts.MaxRetryBackoff)
+}
+
+func TestWithCache(t *testing.T) {
+	opts := clientOptions{}
+	WithCache(time.Hour, 10).apply(&opts)
+	require.Equal(t, time.Hour, opts.CacheTTL)
+	require.Equal(t, 10, opts.CacheSize)
+}
+
+func TestWithBatching(t *testing.T) {
+	opts := clientOptions{}
+	WithBatching(time.Millisecond, 10).apply(&opts)
+	require.Equal(t, time.Millisecond, opts.BatchWindow)
+	require.Equal(t, 10, opts.MaxBatchSize)
+}
+
+func TestWithBatchTimeout(t *testing.T) {
+	opts := clientOptions{}
+	WithBatchTimeout(time.Minute).apply(&opts)
+	require.Equal(t, time.Minute, opts.BatchTimeout)
+}
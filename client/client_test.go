@@ -0,0 +1,310 @@
+package client
+
+import (
+	"buf.build/gen/go/chainalysis-oss/oslc/grpc/go/chainalysis_oss/oslc/v1alpha/oslcv1alphagrpc"
+	oslcv1alpha "buf.build/gen/go/chainalysis-oss/oslc/protocolbuffers/go/chainalysis_oss/oslc/v1alpha"
+	"context"
+	"errors"
+	"github.com/chainalysis-oss/oslc"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"io"
+	"log/slog"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var lodashResponse = &oslcv1alpha.GetPackageInfoResponse{
+	Name:                "lodash",
+	Version:             "4.17.21",
+	License:             "MIT",
+	DistributionPoints:  []*oslcv1alpha.DistributionPoint{{Name: "lodash", Url: "https://www.npmjs.com/package/lodash", Distributor: oslc.DistributorNpm}},
+	RawLicense:          "MIT",
+	NormalizationStatus: oslcv1alpha.LicenseNormalizationStatus_LICENSE_NORMALIZATION_STATUS_EXACT,
+	LicenseListVersion:  "3.25.0",
+}
+
+var lodashEntry = oslc.Entry{
+	Name:                "lodash",
+	Version:             "4.17.21",
+	License:             "MIT",
+	DistributionPoints:  []oslc.DistributionPoint{{Name: "lodash", URL: "https://www.npmjs.com/package/lodash", Distributor: oslc.DistributorNpm}},
+	RawLicense:          "MIT",
+	NormalizationStatus: oslc.LicenseNormalizationExact,
+	LicenseListVersion:  "3.25.0",
+}
+
+// fakeServer serves lodash 4.17.21, which is also the latest version and the version matching "^4.0.0". Other packages
+// and versions are not found.
+type fakeServer struct {
+	oslcv1alphagrpc.UnimplementedOslcServiceServer
+	// batchUnsupported makes BatchGetPackageInfo fail with UNIMPLEMENTED, as it does on older servers.
+	batchUnsupported bool
+	// failures is the number of lookups that fail with UNAVAILABLE before lookups succeed.
+	failures atomic.Int32
+
+	lookups atomic.Int32
+	mu      sync.Mutex
+	batches [][]*oslcv1alpha.GetPackageInfoRequest
+	// authorization are the authorization metadata of the requests.
+	authorization []string
+	// batchTenants are the tenant metadata of the batches.
+	batchTenants []string
+	// batchDeadlines are the deadlines of the batches.
+	batchDeadlines []time.Time
+}
+
+func (f *fakeServer) GetPackageInfo(ctx context.Context, request *oslcv1alpha.GetPackageInfoRequest) (*oslcv1alpha.GetPackageInfoResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	f.mu.Lock()
+	f.authorization = append(f.authorization, md.Get("authorization")...)
+	f.mu.Unlock()
+	f.lookups.Add(1)
+
+	if f.failures.Add(-1) >= 0 {
+		st, _ := status.New(codes.Unavailable, "distributor unavailable").WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(time.Millisecond)})
+		return nil, st.Err()
+	}
+	if request.Distributor != oslc.DistributorNpm || request.Name != "lodash" {
+		st, _ := status.New(codes.NotFound, "package not found").WithDetails(&errdetails.ErrorInfo{Reason: oslc.ReasonPackageNotFound, Domain: oslc.ErrorInfoDomain})
+		return nil, st.Err()
+	}
+	switch request.Version {
+	case "", "4.17.21":
+		return lodashResponse, nil
+	case "^4.0.0":
+		resp := proto.Clone(lodashResponse).(*oslcv1alpha.GetPackageInfoResponse)
+		resp.RequestedVersion = request.Version
+		return resp, nil
+	}
+	st, _ := status.New(codes.NotFound, "version not found").WithDetails(&errdetails.ErrorInfo{Reason: oslc.ReasonVersionNotFound, Domain: oslc.ErrorInfoDomain})
+	return nil, st.Err()
+}
+
+func (f *fakeServer) BatchGetPackageInfo(ctx context.Context, request *oslcv1alpha.BatchGetPackageInfoRequest) (*oslcv1alpha.BatchGetPackageInfoResponse, error) {
+	if f.batchUnsupported {
+		return nil, status.Error(codes.Unimplemented, "method BatchGetPackageInfo not implemented")
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	deadline, _ := ctx.Deadline()
+	f.mu.Lock()
+	f.batches = append(f.batches, request.Requests)
+	f.batchTenants = append(f.batchTenants, strings.Join(md.Get("x-oslc-tenant"), ","))
+	f.batchDeadlines = append(f.batchDeadlines, deadline)
+	f.mu.Unlock()
+
+	resp := &oslcv1alpha.BatchGetPackageInfoResponse{}
+	for _, r := range request.Requests {
+		info, err := f.GetPackageInfo(ctx, r)
+		if err != nil {
+			st := status.Convert(err)
+			e := &oslcv1alpha.PackageInfoError{Code: int32(st.Code()), Message: st.Message()}
+			for _, detail := range st.Details() {
+				switch d := detail.(type) {
+				case *errdetails.ErrorInfo:
+					e.Reason = d.Reason
+				case *errdetails.RetryInfo:
+					e.RetryDelay = d.RetryDelay
+				}
+			}
+			resp.Results = append(resp.Results, &oslcv1alpha.BatchGetPackageInfoResult{Result: &oslcv1alpha.BatchGetPackageInfoResult_Error{Error: e}})
+			continue
+		}
+		resp.Results = append(resp.Results, &oslcv1alpha.BatchGetPackageInfoResult{Result: &oslcv1alpha.BatchGetPackageInfoResult_Package{Package: info}})
+	}
+	return resp, nil
+}
+
+// newTestClient returns a client of f, served in memory.
+func newTestClient(t *testing.T, f *fakeServer, options ...ClientOption) *Client {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	oslcv1alphagrpc.RegisterOslcServiceServer(s, f)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	options = append([]ClientOption{
+		WithConn(conn),
+		WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		WithRetries(3, time.Millisecond, 10*time.Millisecond),
+	}, options...)
+	c, err := NewClient(options...)
+	require.NoError(t, err)
+	return c
+}
+
+func TestNewClient_missingAddress(t *testing.T) {
+	_, err := NewClient()
+	require.ErrorIs(t, err, ErrMissingAddress)
+}
+
+func TestClient_GetPackageVersion(t *testing.T) {
+	for _, batching := range []bool{false, true} {
+		f := &fakeServer{}
+		c := newTestClient(t, f, WithBatching(map[bool]time.Duration{true: time.Millisecond}[batching], 100))
+
+		entry, err := c.GetPackageVersion(context.Background(), oslc.DistributorNpm, "lodash", "4.17.21")
+		require.NoError(t, err)
+		require.Equal(t, lodashEntry, entry)
+
+		entry, err = c.GetPackage(context.Background(), oslc.DistributorNpm, "lodash")
+		require.NoError(t, err)
+		require.Equal(t, lodashEntry, entry)
+
+		entry, err = c.GetPackageByURL(context.Background(), "pkg:npm/lodash@%5E4.0.0")
+		require.NoError(t, err)
+		require.Equal(t, lodashEntry, entry)
+
+		_, err = c.GetPackageVersion(context.Background(), oslc.DistributorNpm, "missing", "1.0.0")
+		require.ErrorIs(t, err, oslc.ErrNoSuchPackage)
+		var de oslc.DistributorError
+		require.ErrorAs(t, err, &de)
+		require.Equal(t, oslc.DistributorNpm, de.Distributor)
+
+		_, err = c.GetPackageVersion(context.Background(), oslc.DistributorNpm, "lodash", "0.0.1")
+		require.ErrorIs(t, err, oslc.ErrVersionNotFound)
+
+		require.Equal(t, batching, len(f.batches) > 0)
+	}
+}
+
+func TestClient_GetPackageByURL_invalid(t *testing.T) {
+	c := newTestClient(t, &fakeServer{})
+	_, err := c.GetPackageByURL(context.Background(), "pkg:gem/rails@7.1.3")
+	require.Error(t, err)
+}
+
+func TestClient_retries(t *testing.T) {
+	f := &fakeServer{}
+	f.failures.Store(3)
+	c := newTestClient(t, f, WithBatching(0, 0))
+	entry, err := c.GetPackage(context.Background(), oslc.DistributorNpm, "lodash")
+	require.NoError(t, err)
+	require.Equal(t, lodashEntry, entry)
+	require.EqualValues(t, 4, f.lookups.Load())
+
+	// After the retries are used up, the error is returned.
+	f.failures.Store(4)
+	f.lookups.Store(0)
+	_, err = c.GetPackage(context.Background(), oslc.DistributorNpm, "lodash")
+	var de oslc.DistributorError
+	require.ErrorAs(t, err, &de)
+	require.Equal(t, oslc.DistributorErrorUnavailable, de.Kind)
+	require.Equal(t, time.Millisecond, de.RetryAfter)
+	require.EqualValues(t, 4, f.lookups.Load())
+
+	// Other errors are not retried.
+	f.failures.Store(0)
+	f.lookups.Store(0)
+	_, err = c.GetPackage(context.Background(), oslc.DistributorNpm, "missing")
+	require.ErrorIs(t, err, oslc.ErrNoSuchPackage)
+	require.EqualValues(t, 1, f.lookups.Load())
+}
+
+func TestClient_retries_batched(t *testing.T) {
+	f := &fakeServer{}
+	f.failures.Store(1)
+	c := newTestClient(t, f, WithBatching(time.Millisecond, 100))
+	entry, err := c.GetPackage(context.Background(), oslc.DistributorNpm, "lodash")
+	require.NoError(t, err)
+	require.Equal(t, lodashEntry, entry)
+	require.Len(t, f.batches, 2)
+}
+
+func TestClient_retries_contextDone(t *testing.T) {
+	f := &fakeServer{}
+	f.failures.Store(1 << 30)
+	c := newTestClient(t, f, WithBatching(0, 0), WithRetries(1<<30, time.Hour, time.Hour))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := c.GetPackage(ctx, oslc.DistributorNpm, "lodash")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestClient_cache(t *testing.T) {
+	f := &fakeServer{}
+	c := newTestClient(t, f, WithBatching(0, 0), WithCache(time.Hour, 100))
+	for range 3 {
+		entry, err := c.GetPackageVersion(context.Background(), oslc.DistributorNpm, "lodash", "^4.0.0")
+		require.NoError(t, err)
+		require.Equal(t, lodashEntry, entry)
+	}
+	// The resolved version is cached too.
+	_, err := c.GetPackageVersion(context.Background(), oslc.DistributorNpm, "lodash", "4.17.21")
+	require.NoError(t, err)
+	require.EqualValues(t, 1, f.lookups.Load())
+
+	// Errors are not cached.
+	for range 2 {
+		_, err := c.GetPackage(context.Background(), oslc.DistributorNpm, "missing")
+		require.Error(t, err)
+	}
+	require.EqualValues(t, 3, f.lookups.Load())
+}
+
+func TestClient_Distributor(t *testing.T) {
+	c := newTestClient(t, &fakeServer{})
+	d := c.Distributor(oslc.DistributorNpm)
+
+	entry, err := d.GetPackage(context.Background(), "lodash")
+	require.NoError(t, err)
+	require.Equal(t, lodashEntry, entry)
+
+	entry, err = d.GetPackageVersion(context.Background(), "lodash", "4.17.21")
+	require.NoError(t, err)
+	require.Equal(t, lodashEntry, entry)
+
+	_, err = d.GetPackageVersion(context.Background(), "missing", "1.0.0")
+	require.True(t, errors.Is(err, oslc.ErrNoSuchPackage))
+}
+
+func TestClient_apiKey(t *testing.T) {
+	f := &fakeServer{}
+	lis, err := net.Listen("tcp", "127.0.0.1:")
+	require.NoError(t, err)
+	s := grpc.NewServer()
+	oslcv1alphagrpc.RegisterOslcServiceServer(s, f)
+	go s.Serve(lis)
+	defer s.Stop()
+
+	c, err := NewClient(WithAddress(lis.Addr().String()), WithInsecure(true), WithAPIKey("secret"), WithBatching(0, 0))
+	require.NoError(t, err)
+	defer c.Close()
+	_, err = c.GetPackage(context.Background(), oslc.DistributorNpm, "lodash")
+	require.NoError(t, err)
+	require.Equal(t, []string{"Bearer secret"}, f.authorization)
+}
+
+func TestClient_Close(t *testing.T) {
+	c, err := NewClient(WithAddress("localhost:8080"))
+	require.NoError(t, err)
+	require.NoError(t, c.Close())
+
+	// Connections provided by WithConn are not closed.
+	c = newTestClient(t, &fakeServer{})
+	require.NoError(t, c.Close())
+	_, err = c.GetPackage(context.Background(), oslc.DistributorNpm, "lodash")
+	require.NoError(t, err)
+}
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"github.com/chainalysis-oss/oslc"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+// statusToError converts an error returned by the server for a package of the distributor to an
+// [oslc.DistributorError], so that callers can handle it in the same way as errors of other distributor clients.
+func statusToError(distributor string, err error) error {
+	st := status.Convert(err)
+	e := oslc.DistributorError{Distributor: distributor, Err: err, RetryAfter: retryInfoDelay(err)}
+	switch st.Code() {
+	case codes.NotFound:
+		if errorInfoReason(st) == oslc.ReasonVersionNotFound {
+			e.Err = oslc.ErrVersionNotFound
+		} else {
+			e.Err = oslc.ErrNoSuchPackage
+		}
+	case codes.InvalidArgument:
+		if errorInfoReason(st) == oslc.ReasonInvalidPackageName {
+			e.Kind = oslc.DistributorErrorInvalidName
+		}
+	case codes.Unavailable:
+		e.Kind = oslc.DistributorErrorUnavailable
+	case codes.DeadlineExceeded:
+		e.Kind = oslc.DistributorErrorTimeout
+	case codes.ResourceExhausted:
+		e.Kind = oslc.DistributorErrorRateLimited
+	}
+	return e
+}
+
+// retryable reports whether a lookup failing with err may be retried.
+func retryable(err error) bool {
+	return status.Code(err) == codes.Unavailable
+}
+
+// errorInfoReason returns the reason of the google.rpc.ErrorInfo detail of st, if it was attached by an OSLC server.
+func errorInfoReason(st *status.Status) string {
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.Domain == oslc.ErrorInfoDomain {
+			return info.Reason
+		}
+	}
+	return ""
+}
+
+// retryInfoDelay returns the retry delay of the google.rpc.RetryInfo detail of err, or zero if there is none.
+func retryInfoDelay(err error) time.Duration {
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok {
+			return info.RetryDelay.AsDuration()
+		}
+	}
+	return 0
+}
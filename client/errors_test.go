@@ -0,0 +1,69 @@
+package client
+
+import (
+	"errors"
+	"github.com/chainalysis-oss/oslc"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"testing"
+	"time"
+)
+
+func statusWithReason(t *testing.T, code codes.Code, reason string) error {
+	t.Helper()
+	st, err := status.New(code, "error").WithDetails(&errdetails.ErrorInfo{Reason: reason, Domain: oslc.ErrorInfoDomain})
+	require.NoError(t, err)
+	return st.Err()
+}
+
+func TestStatusToError(t *testing.T) {
+	testcases := []struct {
+		name    string
+		err     error
+		kind    oslc.DistributorErrorKind
+		wrapped error
+	}{
+		{"package not found", statusWithReason(t, codes.NotFound, oslc.ReasonPackageNotFound), oslc.DistributorErrorUnknown, oslc.ErrNoSuchPackage},
+		{"version not found", statusWithReason(t, codes.NotFound, oslc.ReasonVersionNotFound), oslc.DistributorErrorUnknown, oslc.ErrVersionNotFound},
+		{"not found without reason", status.Error(codes.NotFound, "not found"), oslc.DistributorErrorUnknown, oslc.ErrNoSuchPackage},
+		{"invalid name", statusWithReason(t, codes.InvalidArgument, oslc.ReasonInvalidPackageName), oslc.DistributorErrorInvalidName, nil},
+		{"invalid distributor", statusWithReason(t, codes.InvalidArgument, oslc.ReasonInvalidDistributor), oslc.DistributorErrorUnknown, nil},
+		{"unavailable", status.Error(codes.Unavailable, "unavailable"), oslc.DistributorErrorUnavailable, nil},
+		{"timeout", status.Error(codes.DeadlineExceeded, "timeout"), oslc.DistributorErrorTimeout, nil},
+		{"rate limited", status.Error(codes.ResourceExhausted, "rate limited"), oslc.DistributorErrorRateLimited, nil},
+		{"internal", status.Error(codes.Internal, "internal"), oslc.DistributorErrorUnknown, nil},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := statusToError(oslc.DistributorNpm, tt.err)
+			var de oslc.DistributorError
+			require.ErrorAs(t, err, &de)
+			require.Equal(t, oslc.DistributorNpm, de.Distributor)
+			require.Equal(t, tt.kind, de.Kind)
+			if tt.wrapped != nil {
+				require.ErrorIs(t, err, tt.wrapped)
+			} else {
+				require.Nil(t, errors.Unwrap(err))
+			}
+		})
+	}
+}
+
+func TestStatusToError_retryAfter(t *testing.T) {
+	st, err := status.New(codes.Unavailable, "unavailable").WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(time.Minute)})
+	require.NoError(t, err)
+	var de oslc.DistributorError
+	require.ErrorAs(t, statusToError(oslc.DistributorNpm, st.Err()), &de)
+	require.Equal(t, time.Minute, de.RetryAfter)
+}
+
+func TestErrorInfoReason(t *testing.T) {
+	require.Equal(t, oslc.ReasonPackageNotFound, errorInfoReason(status.Convert(statusWithReason(t, codes.NotFound, oslc.ReasonPackageNotFound))))
+	// Reasons of other domains are ignored.
+	st, err := status.New(codes.NotFound, "error").WithDetails(&errdetails.ErrorInfo{Reason: oslc.ReasonVersionNotFound, Domain: "example.com"})
+	require.NoError(t, err)
+	require.Empty(t, errorInfoReason(st))
+}
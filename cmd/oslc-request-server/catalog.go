@@ -0,0 +1,149 @@
+package main
+
+import (
+	"fmt"
+	"github.com/chainalysis-oss/oslc"
+	"github.com/chainalysis-oss/oslc/catalog"
+	"github.com/chainalysis-oss/oslc/memory"
+	"github.com/urfave/cli/v2"
+	"io"
+	"os"
+)
+
+// catalogFilterFlags are the flags that build a catalog.Filter with catalogFilter.
+var catalogFilterFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:  "distributor",
+		Usage: "Only include entries with a distribution point of this distributor",
+	},
+	&cli.StringFlag{
+		Name:  "license",
+		Usage: "Only include entries with exactly this license. An empty value includes entries without a normalized license",
+	},
+}
+
+var exportCommand = &cli.Command{
+	Name:  "export",
+	Usage: "Write the entries of the datastore as JSONL",
+	Description: `Writes every entry of the datastore as one JSON object per line, to be loaded into another datastore with
+the import command. An entry with distribution points of several distributors is written once for each of them.`,
+	Action: exportAction,
+	Flags: append([]cli.Flag{
+		&cli.StringFlag{
+			Name:    "output",
+			Aliases: []string{"o"},
+			Value:   "-",
+			Usage:   "File to write the entries to, or - for standard output",
+		},
+	}, catalogFilterFlags...),
+}
+
+var importCommand = &cli.Command{
+	Name:   "import",
+	Usage:  "Save entries written by the export command to the datastore",
+	Action: importAction,
+	Flags: append([]cli.Flag{
+		&cli.StringFlag{
+			Name:    "input",
+			Aliases: []string{"i"},
+			Value:   "-",
+			Usage:   "File to read the entries from, or - for standard input",
+		},
+		&cli.StringFlag{
+			Name:  "mode",
+			Value: string(catalog.ImportUpsert),
+			Usage: "upsert replaces stored entries with the same name and version, skip-existing keeps them",
+			Action: func(cCtx *cli.Context, s string) error {
+				switch catalog.ImportMode(s) {
+				case catalog.ImportUpsert, catalog.ImportSkipExisting:
+					return nil
+				default:
+					return &configValidationError{key: "mode", value: s, detail: "value must be one of upsert or skip-existing"}
+				}
+			},
+		},
+	}, catalogFilterFlags...),
+}
+
+// catalogFilter returns the filter selected by the catalogFilterFlags of cCtx.
+func catalogFilter(cCtx *cli.Context) catalog.Filter {
+	filter := catalog.Filter{Distributor: cCtx.String("distributor")}
+	if cCtx.IsSet("license") {
+		license := cCtx.String("license")
+		filter.License = &license
+	}
+	return filter
+}
+
+func exportAction(cCtx *cli.Context) error {
+	logger := getLogger(logLevelFromStr(cCtx.String(configLogLevelKey)), cCtx.String(configLogKindKey), cCtx.App.ErrWriter)
+	ds, closeDatastore, err := newDatastore(cCtx, logger)
+	if err != nil {
+		return err
+	}
+	defer closeDatastore()
+
+	n, err := writeCatalog(cCtx, catalog.Iterator(ds), cCtx.String("output"), catalogFilter(cCtx))
+	if err != nil {
+		return err
+	}
+	// The entries may be written to standard output, so the summary is written to standard error.
+	_, err = fmt.Fprintf(cCtx.App.ErrWriter, "exported %d entries\n", n)
+	return err
+}
+
+// writeCatalog exports the entries of it that match the filter to the file at output, or to the writer of the app if
+// output is "-".
+func writeCatalog(cCtx *cli.Context, it oslc.DatastoreIterator, output string, filter catalog.Filter) (int, error) {
+	if output == "-" {
+		return catalog.Export(cCtx.Context, cCtx.App.Writer, it, filter)
+	}
+	f, err := os.Create(output)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create %s: %w", output, err)
+	}
+	n, err := catalog.Export(cCtx.Context, f, it, filter)
+	if err != nil {
+		f.Close()
+		return n, err
+	}
+	if err := f.Close(); err != nil {
+		return n, fmt.Errorf("failed to write %s: %w", output, err)
+	}
+	return n, nil
+}
+
+func importAction(cCtx *cli.Context) error {
+	// The memory datastore is only persisted by its snapshots, so the imported entries would be lost without one.
+	if cCtx.String(configDatastoreKindKey) == datastoreKindMemory && cCtx.String(configDatastoreSnapshotPathKey) == "" {
+		return &configValidationError{key: configDatastoreSnapshotPathKey, detail: "value must be set to import into the memory datastore"}
+	}
+	logger := getLogger(logLevelFromStr(cCtx.String(configLogLevelKey)), cCtx.String(configLogKindKey), cCtx.App.ErrWriter)
+	ds, closeDatastore, err := newDatastore(cCtx, logger)
+	if err != nil {
+		return err
+	}
+	defer closeDatastore()
+
+	var r io.Reader = cCtx.App.Reader
+	if input := cCtx.String("input"); input != "-" {
+		f, err := os.Open(input)
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", input, err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	result, err := catalog.Import(cCtx.Context, r, ds, catalogFilter(cCtx), catalog.ImportMode(cCtx.String("mode")))
+	if err != nil {
+		return fmt.Errorf("imported %d entries before failing: %w", result.Imported, err)
+	}
+	if m, ok := ds.(*memory.Datastore); ok {
+		if err := m.Snapshot(); err != nil {
+			return fmt.Errorf("failed to save snapshot: %w", err)
+		}
+	}
+	_, err = fmt.Fprintf(cCtx.App.Writer, "imported %d entries, skipped %d\n", result.Imported, result.Skipped)
+	return err
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// runCatalogCommand runs the app with the export and import commands against the memory datastore persisted at
+// snapshotPath, and returns what the app wrote to its writer and error writer.
+func runCatalogCommand(t *testing.T, snapshotPath string, stdin string, args ...string) (string, string, error) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	app := &cli.App{
+		Flags:     flags,
+		Commands:  []*cli.Command{exportCommand, importCommand},
+		Reader:    strings.NewReader(stdin),
+		Writer:    &stdout,
+		ErrWriter: &stderr,
+	}
+	err := app.Run(append([]string{"oslc-request-server", "--log.kind=discard", "--datastore.kind=memory", "--datastore.snapshot-path=" + snapshotPath}, args...))
+	return stdout.String(), stderr.String(), err
+}
+
+const testCatalog = `{"name":"lodash","distribution_points":[{"name":"lodash","url":"https://registry.npmjs.org/lodash","distributor":"npm"}],"license":"MIT","version":"4.17.21"}
+{"name":"requests","distribution_points":[{"name":"requests","url":"https://pypi.org/project/requests","distributor":"pypi"}],"license":"Apache-2.0","version":"2.32.3"}
+`
+
+func TestImportAndExportCommands(t *testing.T) {
+	dir := t.TempDir()
+	snapshotPath := filepath.Join(dir, "oslc.jsonl")
+
+	stdout, _, err := runCatalogCommand(t, snapshotPath, testCatalog, "import")
+	require.NoError(t, err)
+	require.Equal(t, "imported 2 entries, skipped 0\n", stdout)
+
+	stdout, _, err = runCatalogCommand(t, snapshotPath, testCatalog, "import", "--mode", "skip-existing", "--distributor", "npm")
+	require.NoError(t, err)
+	require.Equal(t, "imported 0 entries, skipped 2\n", stdout)
+
+	stdout, stderr, err := runCatalogCommand(t, snapshotPath, "", "export")
+	require.NoError(t, err)
+	require.Equal(t, testCatalog, stdout)
+	require.Equal(t, "exported 2 entries\n", stderr)
+
+	output := filepath.Join(dir, "export.jsonl")
+	stdout, stderr, err = runCatalogCommand(t, snapshotPath, "", "export", "--output", output, "--license", "MIT")
+	require.NoError(t, err)
+	require.Empty(t, stdout)
+	require.Equal(t, "exported 1 entries\n", stderr)
+	content, err := os.ReadFile(output)
+	require.NoError(t, err)
+	require.Equal(t, strings.SplitAfter(testCatalog, "\n")[0], string(content))
+
+	stdout, _, err = runCatalogCommand(t, filepath.Join(dir, "other.jsonl"), "", "import", "--input", output)
+	require.NoError(t, err)
+	require.Equal(t, "imported 1 entries, skipped 0\n", stdout)
+}
+
+func TestImportCommand_errors(t *testing.T) {
+	snapshotPath := filepath.Join(t.TempDir(), "oslc.jsonl")
+
+	_, _, err := runCatalogCommand(t, snapshotPath, "", "import", "--mode", "replace")
+	var cfgValErr *configValidationError
+	require.ErrorAs(t, err, &cfgValErr)
+
+	_, _, err = runCatalogCommand(t, snapshotPath, "", "import", "--input", filepath.Join(t.TempDir(), "missing.jsonl"))
+	require.ErrorContains(t, err, "failed to open")
+
+	_, _, err = runCatalogCommand(t, snapshotPath, testCatalog+"{", "import")
+	require.ErrorContains(t, err, "imported 2 entries before failing")
+	// Nothing is persisted when the import fails.
+	_, err = os.Stat(snapshotPath)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	// Entries imported into the memory datastore would be lost without a snapshot.
+	stdout, _, err := runCatalogCommand(t, "", testCatalog, "import")
+	require.ErrorAs(t, err, &cfgValErr)
+	require.Equal(t, configDatastoreSnapshotPathKey, cfgValErr.key)
+	require.Empty(t, stdout)
+}
+
+func TestExportCommand_ErrCreate(t *testing.T) {
+	_, _, err := runCatalogCommand(t, filepath.Join(t.TempDir(), "oslc.jsonl"), "", "export", "--output", filepath.Join(t.TempDir(), "missing", "export.jsonl"))
+	require.ErrorContains(t, err, "failed to create")
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/chainalysis-oss/oslc/crawler"
+	"github.com/chainalysis-oss/oslc/memory"
+	"github.com/oklog/run"
+	"github.com/urfave/cli/v2"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// errCrawlerOffline is returned when the crawler is started in offline mode, in which it cannot reach the distributors.
+var errCrawlerOffline = errors.New("the crawler cannot run in offline mode")
+
+var crawlCommand = &cli.Command{
+	Name:      "crawl",
+	Usage:     "Resolve the packages of seed files ahead of the first request for them",
+	ArgsUsage: "SEED_FILE...",
+	Description: `Reads the packages of the seed files, and saves them to the datastore by looking them up at their
+distributors. Seed files are lockfiles, SBOMs, or JSONL logs of requests with a distributor, name and version per
+line, such as the miss queue of an offline server. Packages that are already stored are skipped. With --progress,
+finished packages are recorded, so an interrupted crawl resumes where it stopped.`,
+	Action: crawlAction,
+	Flags: []cli.Flag{
+		&cli.Float64Flag{
+			Name:   "rate",
+			Value:  5,
+			Usage:  "Maximum number of packages resolved per second from each distributor",
+			Action: cfgFloat64MustBePositive("rate"),
+		},
+		&cli.StringFlag{
+			Name:  "progress",
+			Usage: "JSONL file to record finished packages to, and to resume from",
+		},
+	},
+}
+
+func crawlAction(cCtx *cli.Context) error {
+	if cCtx.NArg() == 0 {
+		return errors.New("at least one seed file is required")
+	}
+	if cCtx.Bool(configOfflineEnabledKey) {
+		return errCrawlerOffline
+	}
+	logger := getLogger(logLevelFromStr(cCtx.String(configLogLevelKey)), cCtx.String(configLogKindKey), cCtx.App.ErrWriter)
+
+	seeds, err := crawler.LoadSeeds(cCtx.Args().Slice())
+	if err != nil {
+		return err
+	}
+	ds, closeDatastore, err := newDatastore(cCtx, logger)
+	if err != nil {
+		return err
+	}
+	defer closeDatastore()
+	srv, err := newLookupServer(cCtx, logger, ds)
+	if err != nil {
+		return err
+	}
+	c, err := crawler.NewCrawler(
+		crawler.WithLogger(logger),
+		crawler.WithResolver(srv),
+		crawler.WithDatastore(ds),
+		crawler.WithRate(cCtx.Float64("rate")),
+		crawler.WithProgressPath(cCtx.String("progress")),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create crawler: %w", err)
+	}
+
+	// An interrupted crawl stops after the package it is resolving, so the progress file is complete.
+	ctx, stop := signal.NotifyContext(cCtx.Context, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	result, crawlErr := c.Crawl(ctx, seeds)
+
+	// The memory datastore is only persisted by its snapshots, so the resolved packages would be lost without one.
+	if m, ok := ds.(*memory.Datastore); ok && cCtx.String(configDatastoreSnapshotPathKey) != "" {
+		if err := m.Snapshot(); err != nil {
+			return fmt.Errorf("failed to save snapshot: %w", err)
+		}
+	}
+	if crawlErr != nil {
+		return fmt.Errorf("crawl stopped after resolving %d packages: %w", result.Resolved, crawlErr)
+	}
+	_, err = fmt.Fprintf(cCtx.App.Writer, "resolved %d packages, %d already stored, %d resumed, %d failed\n",
+		result.Resolved, result.Cached, result.Resumed, result.Failed)
+	return err
+}
+
+// newServerCrawler returns the crawler of the server, which resolves packages with resolver and skips packages that
+// are stored in ds, or nil if the crawler is disabled.
+func newServerCrawler(cCtx *cli.Context, logger *slog.Logger, resolver crawler.Resolver, ds datastore) (*crawler.Crawler, error) {
+	if !cCtx.Bool(configCrawlerEnabledKey) {
+		return nil, nil
+	}
+	if cCtx.Bool(configOfflineEnabledKey) {
+		return nil, errCrawlerOffline
+	}
+	c, err := crawler.NewCrawler(
+		crawler.WithLogger(logger.With(slog.String("service", "crawler"))),
+		crawler.WithResolver(resolver),
+		crawler.WithDatastore(ds),
+		crawler.WithRate(cCtx.Float64(configCrawlerRateKey)),
+		crawler.WithInterval(cCtx.Duration(configCrawlerIntervalKey)),
+		crawler.WithProgressPath(cCtx.String(configCrawlerProgressPathKey)),
+		crawler.WithSeedPaths(cCtx.StringSlice(configCrawlerSeedsKey)...),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create crawler: %w", err)
+	}
+	return c, nil
+}
+
+func runCrawler(g *run.Group, c *crawler.Crawler) {
+	ctx, cancel := context.WithCancel(context.Background())
+	g.Add(func() error {
+		return c.Run(ctx)
+	}, func(error) {
+		cancel()
+	})
+}
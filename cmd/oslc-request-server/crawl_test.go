@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"github.com/chainalysis-oss/oslc/memory"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// runCrawlCommand runs the app with the crawl command against the memory datastore persisted at snapshotPath, and
+// returns what the app wrote to its writer.
+func runCrawlCommand(t *testing.T, snapshotPath string, args ...string) (string, error) {
+	t.Helper()
+	var stdout bytes.Buffer
+	app := &cli.App{
+		Flags:     flags,
+		Commands:  []*cli.Command{crawlCommand},
+		Writer:    &stdout,
+		ErrWriter: io.Discard,
+	}
+	err := app.Run(append([]string{"oslc-request-server", "--log.kind=discard", "--datastore.kind=memory", "--datastore.snapshot-path=" + snapshotPath, "crawl"}, args...))
+	return stdout.String(), err
+}
+
+func TestCrawlCommand(t *testing.T) {
+	dir := t.TempDir()
+	snapshotPath := filepath.Join(dir, "oslc.jsonl")
+	_, _, err := runCatalogCommand(t, snapshotPath, testCatalog, "import")
+	require.NoError(t, err)
+
+	// The stored package is skipped, and the package of the unsupported distributor fails without calling a
+	// distributor.
+	seedPath := filepath.Join(dir, "requests.jsonl")
+	require.NoError(t, os.WriteFile(seedPath, []byte(`{"distributor":"npm","name":"lodash","version":"4.17.21"}
+{"distributor":"cpan","name":"test","version":"1.0.0"}
+`), 0o644))
+	progressPath := filepath.Join(dir, "progress.jsonl")
+
+	stdout, err := runCrawlCommand(t, snapshotPath, "--progress", progressPath, seedPath)
+	require.NoError(t, err)
+	require.Equal(t, "resolved 0 packages, 1 already stored, 0 resumed, 1 failed\n", stdout)
+
+	stdout, err = runCrawlCommand(t, snapshotPath, "--progress", progressPath, seedPath)
+	require.NoError(t, err)
+	require.Equal(t, "resolved 0 packages, 0 already stored, 2 resumed, 0 failed\n", stdout)
+}
+
+func TestCrawlCommand_errors(t *testing.T) {
+	snapshotPath := filepath.Join(t.TempDir(), "oslc.jsonl")
+
+	_, err := runCrawlCommand(t, snapshotPath)
+	require.ErrorContains(t, err, "at least one seed file is required")
+
+	_, err = runCrawlCommand(t, snapshotPath, filepath.Join(t.TempDir(), "missing.jsonl"))
+	require.ErrorContains(t, err, "failed to read")
+
+	_, err = runCrawlCommand(t, snapshotPath, "--rate", "0", "requests.jsonl")
+	var cfgValErr *configValidationError
+	require.ErrorAs(t, err, &cfgValErr)
+}
+
+func TestNewServerCrawler(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	c, err := newServerCrawler(createContextWithStringFlag(t, configCrawlerEnabledKey, "false"), logger, nil, nil)
+	require.NoError(t, err)
+	require.Nil(t, c)
+
+	_, err = newServerCrawler(createContextWithStringFlags(t, map[string]string{
+		configCrawlerEnabledKey: "true",
+		configOfflineEnabledKey: "true",
+	}), logger, nil, nil)
+	require.ErrorIs(t, err, errCrawlerOffline)
+
+	_, err = newServerCrawler(createContextWithStringFlags(t, map[string]string{
+		configCrawlerEnabledKey: "true",
+		configOfflineEnabledKey: "false",
+	}), logger, nil, nil)
+	require.ErrorContains(t, err, "failed to create crawler")
+
+	ds, err := memory.NewDatastore(memory.WithLogger(logger))
+	require.NoError(t, err)
+	srv, err := newLookupServer(createContextWithDistributorFlags(t, nil), logger, ds)
+	require.NoError(t, err)
+	c, err = newServerCrawler(createContextWithStringFlags(t, map[string]string{
+		configCrawlerEnabledKey:  "true",
+		configOfflineEnabledKey:  "false",
+		configCrawlerRateKey:     "5",
+		configCrawlerIntervalKey: time.Hour.String(),
+	}), logger, srv, ds)
+	require.NoError(t, err)
+	require.NotNil(t, c)
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/chainalysis-oss/oslc"
+	"github.com/chainalysis-oss/oslc/memory"
+	"github.com/chainalysis-oss/oslc/postgres"
+	"github.com/chainalysis-oss/oslc/sqlite"
+	"github.com/urfave/cli/v2"
+	"log/slog"
+	"net"
+	"net/url"
+	"strconv"
+)
+
+const (
+	datastoreKindPostgres = "postgres"
+	datastoreKindSqlite   = "sqlite"
+	datastoreKindMemory   = "memory"
+)
+
+// datastore is implemented by every datastore backend the server can run against. Backends with a schema also
+// implement migrator.
+type datastore interface {
+	oslc.Datastore
+	oslc.CurationStore
+	oslc.WebhookStore
+	oslc.JobStore
+}
+
+// Compile time check to ensure that the datastore backends implement the datastore interface, and the backends with a
+// schema implement the migrator interface.
+var (
+	_ datastore = (*postgres.Datastore)(nil)
+	_ datastore = (*sqlite.Datastore)(nil)
+	_ datastore = (*memory.Datastore)(nil)
+	_ migrator  = (*postgres.Datastore)(nil)
+	_ migrator  = (*sqlite.Datastore)(nil)
+)
+
+// newDatastore connects to the datastore configured in cCtx. The returned function releases the connections of the
+// datastore and must be called by the caller.
+func newDatastore(cCtx *cli.Context, logger *slog.Logger) (datastore, func(), error) {
+	switch kind := cCtx.String(configDatastoreKindKey); kind {
+	case datastoreKindPostgres:
+		return newPostgresDatastore(cCtx, logger)
+	case datastoreKindSqlite:
+		return newSqliteDatastore(cCtx, logger)
+	case datastoreKindMemory:
+		return newMemoryDatastore(cCtx, logger)
+	default:
+		return nil, nil, &configValidationError{key: configDatastoreKindKey, value: kind, detail: "value must be one of postgres, sqlite or memory"}
+	}
+}
+
+// postgresConnString returns the connection string of the postgres datastore: the configured DSN, or a URL built from
+// the individual connection settings.
+func postgresConnString(cCtx *cli.Context) string {
+	if dsn := cCtx.String(configDatastoreDsnKey); dsn != "" {
+		return dsn
+	}
+	query := url.Values{}
+	if sslMode := cCtx.String(configDatastoreSslModeKey); sslMode != "" {
+		query.Set("sslmode", sslMode)
+	}
+	if rootCert := cCtx.String(configDatastoreSslRootCertKey); rootCert != "" {
+		query.Set("sslrootcert", rootCert)
+	}
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(cCtx.String(configDatastoreUsernameKey), cCtx.String(configDatastorePasswordKey)),
+		Host:     net.JoinHostPort(cCtx.String(configDatastoreHostKey), strconv.Itoa(cCtx.Int(configDatastorePortKey))),
+		Path:     "/" + cCtx.String(configDatastoreDatabaseKey),
+		RawQuery: query.Encode(),
+	}
+	return u.String()
+}
+
+// postgresPoolOptions returns the options of the pools of the postgres datastore. They apply to the primary and the
+// replica pool alike.
+func postgresPoolOptions(cCtx *cli.Context) []postgres.PoolOption {
+	return []postgres.PoolOption{
+		postgres.WithMaxConns(int32(cCtx.Int(configDatastoreMaxConnsKey))),
+		postgres.WithMinConns(int32(cCtx.Int(configDatastoreMinConnsKey))),
+		postgres.WithStatementTimeout(cCtx.Duration(configDatastoreStatementTimeoutKey)),
+		postgres.WithApplicationName(cCtx.String(configDatastoreAppNameKey)),
+	}
+}
+
+func newPostgresDatastore(cCtx *cli.Context, logger *slog.Logger) (datastore, func(), error) {
+	poolOptions := postgresPoolOptions(cCtx)
+	dbPool, err := postgres.NewPool(context.Background(), postgresConnString(cCtx), poolOptions...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create database pool: %w", err)
+	}
+	closePools := dbPool.Close
+
+	options := []postgres.DatastoreOption{
+		postgres.WithLogger(logger),
+		postgres.WithPool(dbPool),
+	}
+	if replicaDsn := cCtx.String(configDatastoreReplicaDsnKey); replicaDsn != "" {
+		replicaPool, err := postgres.NewPool(context.Background(), replicaDsn, poolOptions...)
+		if err != nil {
+			dbPool.Close()
+			return nil, nil, fmt.Errorf("failed to create replica database pool: %w", err)
+		}
+		closePools = func() {
+			replicaPool.Close()
+			dbPool.Close()
+		}
+		options = append(options, postgres.WithReplicaPool(replicaPool))
+	}
+
+	ds, err := postgres.NewDatastore(options...)
+	if err != nil {
+		closePools()
+		return nil, nil, fmt.Errorf("failed to create datastore: %w", err)
+	}
+	return ds, closePools, nil
+}
+
+func newSqliteDatastore(cCtx *cli.Context, logger *slog.Logger) (datastore, func(), error) {
+	db, err := sqlite.Open(context.Background(), cCtx.String(configDatastorePathKey))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	ds, err := sqlite.NewDatastore(
+		sqlite.WithLogger(logger),
+		sqlite.WithDB(db))
+	if err != nil {
+		db.Close()
+		return nil, nil, fmt.Errorf("failed to create datastore: %w", err)
+	}
+	return ds, func() { db.Close() }, nil
+}
+
+// newMemoryDatastore creates a memory datastore, loaded from its snapshot if one is configured. Snapshots are saved by
+// the datastore's Run method, which the caller must run for the datastore to be persisted.
+func newMemoryDatastore(cCtx *cli.Context, logger *slog.Logger) (datastore, func(), error) {
+	ds, err := memory.NewDatastore(
+		memory.WithLogger(logger),
+		memory.WithSnapshotPath(cCtx.String(configDatastoreSnapshotPathKey)),
+		memory.WithSnapshotInterval(cCtx.Duration(configDatastoreSnapshotIntervalKey)))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create datastore: %w", err)
+	}
+	return ds, func() {}, nil
+}
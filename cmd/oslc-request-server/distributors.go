@@ -0,0 +1,259 @@
+package main
+
+import (
+	"fmt"
+	"github.com/chainalysis-oss/oslc"
+	"github.com/chainalysis-oss/oslc/cratesio"
+	"github.com/chainalysis-oss/oslc/goproxy"
+	ownHTTP "github.com/chainalysis-oss/oslc/http"
+	"github.com/chainalysis-oss/oslc/maven"
+	"github.com/chainalysis-oss/oslc/npm"
+	"github.com/chainalysis-oss/oslc/pypi"
+	"github.com/urfave/cli/v2"
+	"github.com/urfave/cli/v2/altsrc"
+	"log/slog"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// The following constants are the settings of a distributor. The configuration key of a setting is
+// "distributors.<distributor>.<setting>", for example "distributors.pypi.base_url".
+const (
+	distributorSettingEnabled   = "enabled"
+	distributorSettingBaseURL   = "base_url"
+	distributorSettingTimeout   = "timeout"
+	distributorSettingUserAgent = "user_agent"
+	distributorSettingHeaders   = "headers"
+)
+
+// distributorDefaultTimeout is the timeout of the requests to a distributor, unless one is configured.
+const distributorDefaultTimeout = 10 * time.Second
+
+// distributorConfig describes how the client of a distributor is created from its configuration.
+type distributorConfig struct {
+	// name is the name of the distributor in the configuration keys.
+	name string
+	// distributor is the name of the distributor in requests.
+	distributor string
+	// headers are the headers sent to the distributor, before the configured ones are added.
+	headers http.Header
+	// client returns a client that sends requests with c to baseURL. The default base URL of the client is used if
+	// baseURL is empty.
+	client func(logger *slog.Logger, c *ownHTTP.Client, baseURL string) (oslc.DistributorClient, error)
+}
+
+// distributorConfigs are the configurations of every supported distributor.
+var distributorConfigs = []distributorConfig{
+	{
+		name:        "pypi",
+		distributor: oslc.DistributorPypi,
+		headers:     http.Header{"Accept": {"application/json"}},
+		client: func(logger *slog.Logger, c *ownHTTP.Client, baseURL string) (oslc.DistributorClient, error) {
+			opts := []pypi.ClientOption{pypi.WithLogger(logger), pypi.WithHTTPClient(c)}
+			if baseURL != "" {
+				opts = append(opts, pypi.WithBaseURL(baseURL))
+			}
+			return pypi.NewClient(opts...)
+		},
+	},
+	{
+		name:        "npm",
+		distributor: oslc.DistributorNpm,
+		headers:     http.Header{},
+		client: func(logger *slog.Logger, c *ownHTTP.Client, baseURL string) (oslc.DistributorClient, error) {
+			opts := []npm.ClientOption{npm.WithLogger(logger), npm.WithHTTPClient(c)}
+			if baseURL != "" {
+				opts = append(opts, npm.WithBaseURL(baseURL))
+			}
+			return npm.NewClient(opts...)
+		},
+	},
+	{
+		name:        "maven",
+		distributor: oslc.DistributorMaven,
+		headers:     http.Header{},
+		client: func(logger *slog.Logger, c *ownHTTP.Client, baseURL string) (oslc.DistributorClient, error) {
+			opts := []maven.ClientOption{maven.WithLogger(logger), maven.WithHTTPClient(c)}
+			if baseURL != "" {
+				opts = append(opts, maven.WithBaseURL(baseURL))
+			}
+			return maven.NewClient(opts...)
+		},
+	},
+	{
+		name:        "cratesio",
+		distributor: oslc.DistributorCratesIo,
+		headers:     http.Header{"Accept": {"application/json"}},
+		client: func(logger *slog.Logger, c *ownHTTP.Client, baseURL string) (oslc.DistributorClient, error) {
+			opts := []cratesio.ClientOption{cratesio.WithLogger(logger), cratesio.WithHTTPClient(c)}
+			if baseURL != "" {
+				opts = append(opts, cratesio.WithBaseURL(baseURL))
+			}
+			return cratesio.NewClient(opts...)
+		},
+	},
+	{
+		name:        "go",
+		distributor: oslc.DistributorGo,
+		headers:     http.Header{"Accept": {"application/json"}},
+		client: func(logger *slog.Logger, c *ownHTTP.Client, baseURL string) (oslc.DistributorClient, error) {
+			opts := []goproxy.ClientOption{goproxy.WithLogger(logger), goproxy.WithHTTPClient(c)}
+			if baseURL != "" {
+				opts = append(opts, goproxy.WithBaseURL(baseURL))
+			}
+			return goproxy.NewClient(opts...)
+		},
+	},
+}
+
+// distributorKey returns the configuration key of the setting of the distributor.
+func distributorKey(name, setting string) string {
+	return "distributors." + name + "." + setting
+}
+
+// distributorEnv returns the environment variable of the setting of the distributor.
+func distributorEnv(name, setting string) string {
+	return "OSLC_DISTRIBUTORS_" + strings.ToUpper(name) + "_" + strings.ToUpper(setting)
+}
+
+// distributorFile returns the path of the file holding the setting of the distributor. Headers usually carry
+// credentials for mirrors, which are best kept out of environment variables and configuration files.
+func distributorFile(name, setting string) string {
+	return getFilePathWithPrefix(strings.ToLower(distributorEnv(name, setting)))
+}
+
+// distributorFlags returns the flags configuring every supported distributor.
+func distributorFlags() []cli.Flag {
+	var fs []cli.Flag
+	for _, d := range distributorConfigs {
+		fs = append(fs,
+			altsrc.NewBoolFlag(&cli.BoolFlag{
+				Name:     distributorKey(d.name, distributorSettingEnabled),
+				Value:    true,
+				Usage:    fmt.Sprintf("Look packages up at %s. Requests for its packages fail if disabled", d.name),
+				EnvVars:  []string{distributorEnv(d.name, distributorSettingEnabled)},
+				FilePath: distributorFile(d.name, distributorSettingEnabled),
+			}),
+			altsrc.NewStringFlag(&cli.StringFlag{
+				Name:     distributorKey(d.name, distributorSettingBaseURL),
+				Usage:    fmt.Sprintf("Base URL of %s, such as a mirror. The public registry is used if empty", d.name),
+				EnvVars:  []string{distributorEnv(d.name, distributorSettingBaseURL)},
+				FilePath: distributorFile(d.name, distributorSettingBaseURL),
+				Action:   cfgStringMustBeBaseURL(distributorKey(d.name, distributorSettingBaseURL)),
+			}),
+			altsrc.NewDurationFlag(&cli.DurationFlag{
+				Name:     distributorKey(d.name, distributorSettingTimeout),
+				Value:    distributorDefaultTimeout,
+				Usage:    fmt.Sprintf("Timeout of the requests to %s", d.name),
+				EnvVars:  []string{distributorEnv(d.name, distributorSettingTimeout)},
+				FilePath: distributorFile(d.name, distributorSettingTimeout),
+				Action:   cfgDurationMustBePositive(distributorKey(d.name, distributorSettingTimeout)),
+			}),
+			altsrc.NewStringFlag(&cli.StringFlag{
+				Name:     distributorKey(d.name, distributorSettingUserAgent),
+				Usage:    fmt.Sprintf("User agent of the requests to %s. OSLC's user agent is used if empty", d.name),
+				EnvVars:  []string{distributorEnv(d.name, distributorSettingUserAgent)},
+				FilePath: distributorFile(d.name, distributorSettingUserAgent),
+			}),
+			altsrc.NewStringSliceFlag(&cli.StringSliceFlag{
+				Name:     distributorKey(d.name, distributorSettingHeaders),
+				Usage:    fmt.Sprintf("Extra headers of the requests to %s, as 'Name: value'", d.name),
+				EnvVars:  []string{distributorEnv(d.name, distributorSettingHeaders)},
+				FilePath: distributorFile(d.name, distributorSettingHeaders),
+				Action:   cfgStringSliceMustBeHeaders(distributorKey(d.name, distributorSettingHeaders)),
+			}),
+		)
+	}
+	return fs
+}
+
+func cfgStringMustBeBaseURL(key string) func(cCtx *cli.Context, s string) error {
+	return func(cCtx *cli.Context, s string) error {
+		u, err := url.Parse(s)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return &configValidationError{key: key, value: s, detail: "value must be an absolute http or https URL"}
+		}
+		return nil
+	}
+}
+
+func cfgStringSliceMustBeHeaders(key string) func(cCtx *cli.Context, s []string) error {
+	return func(cCtx *cli.Context, s []string) error {
+		if _, err := parseHeaders(s); err != nil {
+			return &configValidationError{key: key, value: strings.Join(s, ","), detail: err.Error()}
+		}
+		return nil
+	}
+}
+
+// parseHeaders parses headers given as "Name: value".
+func parseHeaders(s []string) (http.Header, error) {
+	headers := make(http.Header)
+	for _, h := range s {
+		name, value, ok := strings.Cut(h, ":")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" || strings.ContainsAny(name, " \t") {
+			return nil, fmt.Errorf("header %q must be formatted as 'Name: value'", name)
+		}
+		headers.Add(textproto.CanonicalMIMEHeaderKey(name), strings.TrimSpace(value))
+	}
+	return headers, nil
+}
+
+// distributorHTTPClient returns the HTTP client sending requests to the distributor, as it is configured.
+func distributorHTTPClient(cfg configValues, logger *slog.Logger, d distributorConfig) (*ownHTTP.Client, error) {
+	extra, err := parseHeaders(cfg.StringSlice(distributorKey(d.name, distributorSettingHeaders)))
+	if err != nil {
+		return nil, err
+	}
+	headers := d.headers.Clone()
+	for name, values := range extra {
+		headers[name] = values
+	}
+	opts := []ownHTTP.ClientOption{
+		ownHTTP.WithLogger(logger),
+		ownHTTP.WithHTTPClient(&http.Client{Timeout: cfg.Duration(distributorKey(d.name, distributorSettingTimeout))}),
+		ownHTTP.WithHeaders(headers),
+	}
+	if ua := cfg.String(distributorKey(d.name, distributorSettingUserAgent)); ua != "" {
+		opts = append(opts, ownHTTP.WithUserAgent(ua))
+	}
+	return ownHTTP.NewClient(opts...)
+}
+
+// distributorClients returns the clients of every enabled distributor, keyed by distributor. Requests for the packages
+// of a disabled distributor fail with FAILED_PRECONDITION.
+func distributorClients(cfg configValues, logger *slog.Logger) (map[string]oslc.DistributorClient, error) {
+	clients := make(map[string]oslc.DistributorClient)
+	for _, d := range distributorConfigs {
+		if !cfg.Bool(distributorKey(d.name, distributorSettingEnabled)) {
+			logger.Info("distributor is disabled", slog.String("distributor", d.name))
+			continue
+		}
+		c, err := distributorHTTPClient(cfg, logger, d)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s HTTP client: %w", d.name, err)
+		}
+		baseURL := strings.TrimSuffix(cfg.String(distributorKey(d.name, distributorSettingBaseURL)), "/")
+		client, err := d.client(logger, c, baseURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s client: %w", d.name, err)
+		}
+		clients[d.distributor] = client
+	}
+	return clients, nil
+}
+
+// distributorKeys returns the configuration keys of the settings of every distributor.
+func distributorKeys() []string {
+	var keys []string
+	for _, d := range distributorConfigs {
+		for _, setting := range []string{distributorSettingEnabled, distributorSettingBaseURL, distributorSettingTimeout, distributorSettingUserAgent, distributorSettingHeaders} {
+			keys = append(keys, distributorKey(d.name, setting))
+		}
+	}
+	return keys
+}
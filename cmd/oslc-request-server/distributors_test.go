@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"github.com/chainalysis-oss/oslc"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// createContextWithDistributorFlags returns a context with the distributor flags at their defaults, except for the
+// provided values.
+func createContextWithDistributorFlags(t *testing.T, values map[string]string) *cli.Context {
+	t.Helper()
+	fs := flag.NewFlagSet("", flag.ExitOnError)
+	for _, f := range distributorFlags() {
+		require.NoError(t, f.Apply(fs))
+	}
+	for name, value := range values {
+		require.NoError(t, fs.Set(name, value))
+	}
+	return cli.NewContext(cli.NewApp(), fs, nil)
+}
+
+func TestDistributorFlags(t *testing.T) {
+	fs := distributorFlags()
+	require.Len(t, fs, len(distributorConfigs)*5)
+	require.Contains(t, fs[1].Names(), "distributors.pypi.base_url")
+}
+
+func TestDistributorEnv(t *testing.T) {
+	require.Equal(t, "OSLC_DISTRIBUTORS_CRATESIO_BASE_URL", distributorEnv("cratesio", distributorSettingBaseURL))
+	require.Equal(t, "/run/secrets/oslc_distributors_npm_headers", distributorFile("npm", distributorSettingHeaders))
+}
+
+func TestCfgStringMustBeBaseURL(t *testing.T) {
+	cCtx := createContextWithStringFlag(t, "key", "")
+	var cfgValErr *configValidationError
+	for _, s := range []string{"", "pypi.org", "ftp://pypi.org", "https://"} {
+		require.ErrorAs(t, cfgStringMustBeBaseURL("key")(cCtx, s), &cfgValErr, s)
+	}
+	require.NoError(t, cfgStringMustBeBaseURL("key")(cCtx, "https://mirror.example.com/pypi"))
+	require.NoError(t, cfgStringMustBeBaseURL("key")(cCtx, "http://localhost:8080/"))
+}
+
+func TestCfgStringSliceMustBeHeaders(t *testing.T) {
+	cCtx := createContextWithStringFlag(t, "key", "")
+	var cfgValErr *configValidationError
+	for _, s := range []string{"Authorization", ": value", "Bad Name: value"} {
+		require.ErrorAs(t, cfgStringSliceMustBeHeaders("key")(cCtx, []string{s}), &cfgValErr, s)
+	}
+	require.NoError(t, cfgStringSliceMustBeHeaders("key")(cCtx, []string{"Authorization: Bearer token", "X-Empty:"}))
+}
+
+func TestParseHeaders(t *testing.T) {
+	headers, err := parseHeaders([]string{"authorization: Bearer a:b", "X-Mirror:  eu ", "X-Mirror: us"})
+	require.NoError(t, err)
+	require.Equal(t, http.Header{
+		"Authorization": {"Bearer a:b"},
+		"X-Mirror":      {"eu", "us"},
+	}, headers)
+}
+
+func TestDistributorHTTPClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "application/json", r.Header.Get("Accept"))
+		require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		require.Equal(t, "mirror-client", r.Header.Get("User-Agent"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	cCtx := createContextWithDistributorFlags(t, map[string]string{
+		"distributors.pypi.headers":    "Authorization: Bearer token",
+		"distributors.pypi.user_agent": "mirror-client",
+	})
+	c, err := distributorHTTPClient(cCtx, slog.New(slog.NewTextHandler(io.Discard, nil)), distributorConfigs[0])
+	require.NoError(t, err)
+	resp, err := c.Query(context.Background(), srv.URL)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestDistributorHTTPClient_timeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer srv.Close()
+
+	cCtx := createContextWithDistributorFlags(t, map[string]string{
+		"distributors.npm.timeout": "10ms",
+	})
+	c, err := distributorHTTPClient(cCtx, slog.New(slog.NewTextHandler(io.Discard, nil)), distributorConfigs[1])
+	require.NoError(t, err)
+	_, err = c.Query(context.Background(), srv.URL)
+	require.Error(t, err)
+}
+
+func TestDistributorClients(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	clients, err := distributorClients(createContextWithDistributorFlags(t, nil), logger)
+	require.NoError(t, err)
+	require.Len(t, clients, len(distributorConfigs))
+	require.Contains(t, clients, oslc.DistributorCratesIo)
+
+	clients, err = distributorClients(createContextWithDistributorFlags(t, map[string]string{
+		"distributors.maven.enabled": "false",
+		"distributors.go.base_url":   "https://goproxy.example.com/",
+	}), logger)
+	require.NoError(t, err)
+	require.Len(t, clients, len(distributorConfigs)-1)
+	require.NotContains(t, clients, oslc.DistributorMaven)
+}
+
+func TestDistributorKeys(t *testing.T) {
+	keys := distributorKeys()
+	require.Len(t, keys, len(distributorFlags()))
+	for i, f := range distributorFlags() {
+		require.Equal(t, f.Names()[0], keys[i])
+	}
+}
@@ -2,11 +2,13 @@ package main
 
 import (
 	"fmt"
+	"github.com/chainalysis-oss/oslc/tracing"
 	"github.com/urfave/cli/v2"
 	"github.com/urfave/cli/v2/altsrc"
 	"os"
 	"path"
 	"strings"
+	"time"
 )
 
 // configValidationError is an error type for config validation errors.
@@ -32,39 +34,117 @@ const valNotBeEmpty = "value must not be empty"
 // "datastore.username" is used to retrieve the value of the username field in the datastore structure for JSON and
 // YAML configuration files.
 const (
-	configDatastoreUsernameKey string = "datastore.username"
-	configDatastorePasswordKey string = "datastore.password"
-	configDatastoreHostKey     string = "datastore.host"
-	configDatastorePortKey     string = "datastore.port"
-	configDatastoreDatabaseKey string = "datastore.database"
-	configGrpcInterfaceKey     string = "grpc.interface"
-	configGrpcPortKey          string = "grpc.port"
-	configMetricsEnabledKey    string = "metrics.enabled"
-	configMetricsInterfaceKey  string = "metrics.interface"
-	configMetricsPortKey       string = "metrics.port"
-	configLogLevelKey          string = "log.level"
-	configLogKindKey           string = "log.kind"
-	configTlsCertFilePathKey   string = "tls.cert_file_path"
-	configTlsKeyFilePathKey    string = "tls.key_file_path"
+	configDatastoreKindKey             string = "datastore.kind"
+	configDatastorePathKey             string = "datastore.path"
+	configDatastoreSnapshotPathKey     string = "datastore.snapshot-path"
+	configDatastoreSnapshotIntervalKey string = "datastore.snapshot-interval"
+	configDatastoreUsernameKey         string = "datastore.username"
+	configDatastorePasswordKey         string = "datastore.password"
+	configDatastoreHostKey             string = "datastore.host"
+	configDatastorePortKey             string = "datastore.port"
+	configDatastoreDatabaseKey         string = "datastore.database"
+	configDatastoreAutoMigrateKey      string = "datastore.auto-migrate"
+	configDatastoreDsnKey              string = "datastore.dsn"
+	configDatastoreSslModeKey          string = "datastore.sslmode"
+	configDatastoreSslRootCertKey      string = "datastore.sslrootcert"
+	configDatastoreMaxConnsKey         string = "datastore.max-conns"
+	configDatastoreMinConnsKey         string = "datastore.min-conns"
+	configDatastoreStatementTimeoutKey string = "datastore.statement-timeout"
+	configDatastoreAppNameKey          string = "datastore.application-name"
+	configDatastoreReplicaDsnKey       string = "datastore.replica-dsn"
+	configGrpcInterfaceKey             string = "grpc.interface"
+	configGrpcPortKey                  string = "grpc.port"
+	configMetricsEnabledKey            string = "metrics.enabled"
+	configMetricsInterfaceKey          string = "metrics.interface"
+	configMetricsPortKey               string = "metrics.port"
+	configLogLevelKey                  string = "log.level"
+	configLogKindKey                   string = "log.kind"
+	configTlsCertFilePathKey           string = "tls.cert_file_path"
+	configTlsKeyFilePathKey            string = "tls.key_file_path"
+	configTlsReloadIntervalKey         string = "tls.reload_interval"
+	configTracingExporterKey           string = "tracing.exporter"
+	configTracingEndpointKey           string = "tracing.otlp.endpoint"
+	configTracingInsecureKey           string = "tracing.otlp.insecure"
+	configTracingSampleKey             string = "tracing.sample_ratio"
+	configAdminEnabledKey              string = "admin.enabled"
+	configAdminTokenKey                string = "admin.token"
+	configNotificationsEnabledKey      string = "notifications.enabled"
+	configNotificationsPollIntervalKey string = "notifications.poll-interval"
+	configNotificationsMaxAttemptsKey  string = "notifications.max-attempts"
+	configStatsRefreshIntervalKey      string = "stats.refresh-interval"
+	configWatchBufferSizeKey           string = "watch.buffer-size"
+	configWatchMaxSubscriptionsKey     string = "watch.max-subscriptions"
+	configOfflineEnabledKey            string = "offline.enabled"
+	configOfflineMissQueuePathKey      string = "offline.miss-queue-path"
+	configCrawlerEnabledKey            string = "crawler.enabled"
+	configCrawlerSeedsKey              string = "crawler.seeds"
+	configCrawlerRateKey               string = "crawler.rate"
+	configCrawlerIntervalKey           string = "crawler.interval"
+	configCrawlerProgressPathKey       string = "crawler.progress-path"
+	configJobsEnabledKey               string = "jobs.enabled"
+	configJobsWorkersKey               string = "jobs.workers"
+	configJobsMaxAttemptsKey           string = "jobs.max-attempts"
+	configJobsRetentionKey             string = "jobs.retention"
+	configTenantsConfigKey             string = "tenants.config"
+	configHistoryFetchLimitKey         string = "history.fetch-limit"
 )
 
 // The following constants are used to define the environment variables that can be used to set the configuration
 // values for the application.
 const (
-	configDatastoreUsernameEnv string = "OSLC_DATASTORE_USERNAME"
-	configDatastorePasswordEnv string = "OSLC_DATASTORE_PASSWORD"
-	configDatastoreHostEnv     string = "OSLC_DATASTORE_HOST"
-	configDatastorePortEnv     string = "OSLC_DATASTORE_PORT"
-	configDatastoreDatabaseEnv string = "OSLC_DATASTORE_DB"
-	configGrpcInterfaceEnv     string = "OSLC_GRPC_INTERFACE"
-	configGrpcPortEnv          string = "OSLC_GRPC_PORT"
-	configMetricsEnabledEnv    string = "OSLC_METRICS_ENABLED"
-	configMetricsInterfaceEnv  string = "OSLC_METRICS_INTERFACE"
-	configMetricsPortEnv       string = "OSLC_METRICS_PORT"
-	configLogLevelEnv          string = "OSLC_LOG_LEVEL"
-	configLogKindEnv           string = "OSLC_LOG_KIND"
-	configTlsCertFilePathEnv   string = "OSLC_TLS_CERT_FILE_PATH"
-	configTlsKeyFilePathEnv    string = "OSLC_TLS_KEY_FILE_PATH"
+	configDatastoreKindEnv             string = "OSLC_DATASTORE_KIND"
+	configDatastorePathEnv             string = "OSLC_DATASTORE_PATH"
+	configDatastoreSnapshotPathEnv     string = "OSLC_DATASTORE_SNAPSHOT_PATH"
+	configDatastoreSnapshotIntervalEnv string = "OSLC_DATASTORE_SNAPSHOT_INTERVAL"
+	configDatastoreUsernameEnv         string = "OSLC_DATASTORE_USERNAME"
+	configDatastorePasswordEnv         string = "OSLC_DATASTORE_PASSWORD"
+	configDatastoreHostEnv             string = "OSLC_DATASTORE_HOST"
+	configDatastorePortEnv             string = "OSLC_DATASTORE_PORT"
+	configDatastoreDatabaseEnv         string = "OSLC_DATASTORE_DB"
+	configDatastoreAutoMigrateEnv      string = "OSLC_DATASTORE_AUTO_MIGRATE"
+	configDatastoreDsnEnv              string = "OSLC_DATASTORE_DSN"
+	configDatastoreSslModeEnv          string = "OSLC_DATASTORE_SSLMODE"
+	configDatastoreSslRootCertEnv      string = "OSLC_DATASTORE_SSLROOTCERT"
+	configDatastoreMaxConnsEnv         string = "OSLC_DATASTORE_MAX_CONNS"
+	configDatastoreMinConnsEnv         string = "OSLC_DATASTORE_MIN_CONNS"
+	configDatastoreStatementTimeoutEnv string = "OSLC_DATASTORE_STATEMENT_TIMEOUT"
+	configDatastoreAppNameEnv          string = "OSLC_DATASTORE_APPLICATION_NAME"
+	configDatastoreReplicaDsnEnv       string = "OSLC_DATASTORE_REPLICA_DSN"
+	configGrpcInterfaceEnv             string = "OSLC_GRPC_INTERFACE"
+	configGrpcPortEnv                  string = "OSLC_GRPC_PORT"
+	configMetricsEnabledEnv            string = "OSLC_METRICS_ENABLED"
+	configMetricsInterfaceEnv          string = "OSLC_METRICS_INTERFACE"
+	configMetricsPortEnv               string = "OSLC_METRICS_PORT"
+	configLogLevelEnv                  string = "OSLC_LOG_LEVEL"
+	configLogKindEnv                   string = "OSLC_LOG_KIND"
+	configTlsCertFilePathEnv           string = "OSLC_TLS_CERT_FILE_PATH"
+	configTlsKeyFilePathEnv            string = "OSLC_TLS_KEY_FILE_PATH"
+	configTlsReloadIntervalEnv         string = "OSLC_TLS_RELOAD_INTERVAL"
+	configTracingExporterEnv           string = "OSLC_TRACING_EXPORTER"
+	configTracingEndpointEnv           string = "OSLC_TRACING_OTLP_ENDPOINT"
+	configTracingInsecureEnv           string = "OSLC_TRACING_OTLP_INSECURE"
+	configTracingSampleEnv             string = "OSLC_TRACING_SAMPLE_RATIO"
+	configAdminEnabledEnv              string = "OSLC_ADMIN_ENABLED"
+	configAdminTokenEnv                string = "OSLC_ADMIN_TOKEN"
+	configNotificationsEnabledEnv      string = "OSLC_NOTIFICATIONS_ENABLED"
+	configNotificationsPollIntervalEnv string = "OSLC_NOTIFICATIONS_POLL_INTERVAL"
+	configNotificationsMaxAttemptsEnv  string = "OSLC_NOTIFICATIONS_MAX_ATTEMPTS"
+	configStatsRefreshIntervalEnv      string = "OSLC_STATS_REFRESH_INTERVAL"
+	configWatchBufferSizeEnv           string = "OSLC_WATCH_BUFFER_SIZE"
+	configWatchMaxSubscriptionsEnv     string = "OSLC_WATCH_MAX_SUBSCRIPTIONS"
+	configOfflineEnabledEnv            string = "OSLC_OFFLINE_ENABLED"
+	configOfflineMissQueuePathEnv      string = "OSLC_OFFLINE_MISS_QUEUE_PATH"
+	configCrawlerEnabledEnv            string = "OSLC_CRAWLER_ENABLED"
+	configCrawlerSeedsEnv              string = "OSLC_CRAWLER_SEEDS"
+	configCrawlerRateEnv               string = "OSLC_CRAWLER_RATE"
+	configCrawlerIntervalEnv           string = "OSLC_CRAWLER_INTERVAL"
+	configCrawlerProgressPathEnv       string = "OSLC_CRAWLER_PROGRESS_PATH"
+	configJobsEnabledEnv               string = "OSLC_JOBS_ENABLED"
+	configJobsWorkersEnv               string = "OSLC_JOBS_WORKERS"
+	configJobsMaxAttemptsEnv           string = "OSLC_JOBS_MAX_ATTEMPTS"
+	configJobsRetentionEnv             string = "OSLC_JOBS_RETENTION"
+	configTenantsConfigEnv             string = "OSLC_TENANTS_CONFIG"
+	configHistoryFetchLimitEnv         string = "OSLC_HISTORY_FETCH_LIMIT"
 )
 
 const filePrefixFallback = "/run/secrets"
@@ -89,20 +169,59 @@ func getFilePathWithPrefix(s string) string {
 // These file paths are mostly used to store sensitive configuration values that one does not want to expose in
 // environment variables or configuration files.
 var (
-	configDatastoreUsernameFile = getFilePathWithPrefix(strings.ToLower(configDatastoreUsernameEnv))
-	configDatastorePasswordFile = getFilePathWithPrefix(strings.ToLower(configDatastorePasswordEnv))
-	configDatastoreHostFile     = getFilePathWithPrefix(strings.ToLower(configDatastoreHostEnv))
-	configDatastorePortFile     = getFilePathWithPrefix(strings.ToLower(configDatastorePortEnv))
-	configDatastoreDatabaseFile = getFilePathWithPrefix(strings.ToLower(configDatastoreDatabaseEnv))
-	configGrpcInterfaceFile     = getFilePathWithPrefix(strings.ToLower(configGrpcInterfaceEnv))
-	configGrpcPortFile          = getFilePathWithPrefix(strings.ToLower(configGrpcPortEnv))
-	configMetricsEnabledFile    = getFilePathWithPrefix(strings.ToLower(configMetricsEnabledEnv))
-	configMetricsInterfaceFile  = getFilePathWithPrefix(strings.ToLower(configMetricsInterfaceEnv))
-	configMetricsPortFile       = getFilePathWithPrefix(strings.ToLower(configMetricsPortEnv))
-	configLogLevelFile          = getFilePathWithPrefix(strings.ToLower(configLogLevelEnv))
-	configLogKindFile           = getFilePathWithPrefix(strings.ToLower(configLogKindEnv))
-	configTlsCertFilePathFile   = getFilePathWithPrefix(strings.ToLower(configTlsCertFilePathEnv))
-	configTlsKeyFilePathFile    = getFilePathWithPrefix(strings.ToLower(configTlsKeyFilePathEnv))
+	configDatastoreKindFile             = getFilePathWithPrefix(strings.ToLower(configDatastoreKindEnv))
+	configDatastorePathFile             = getFilePathWithPrefix(strings.ToLower(configDatastorePathEnv))
+	configDatastoreSnapshotPathFile     = getFilePathWithPrefix(strings.ToLower(configDatastoreSnapshotPathEnv))
+	configDatastoreSnapshotIntervalFile = getFilePathWithPrefix(strings.ToLower(configDatastoreSnapshotIntervalEnv))
+	configDatastoreUsernameFile         = getFilePathWithPrefix(strings.ToLower(configDatastoreUsernameEnv))
+	configDatastorePasswordFile         = getFilePathWithPrefix(strings.ToLower(configDatastorePasswordEnv))
+	configDatastoreHostFile             = getFilePathWithPrefix(strings.ToLower(configDatastoreHostEnv))
+	configDatastorePortFile             = getFilePathWithPrefix(strings.ToLower(configDatastorePortEnv))
+	configDatastoreDatabaseFile         = getFilePathWithPrefix(strings.ToLower(configDatastoreDatabaseEnv))
+	configDatastoreAutoMigrateFile      = getFilePathWithPrefix(strings.ToLower(configDatastoreAutoMigrateEnv))
+	configDatastoreDsnFile              = getFilePathWithPrefix(strings.ToLower(configDatastoreDsnEnv))
+	configDatastoreSslModeFile          = getFilePathWithPrefix(strings.ToLower(configDatastoreSslModeEnv))
+	configDatastoreSslRootCertFile      = getFilePathWithPrefix(strings.ToLower(configDatastoreSslRootCertEnv))
+	configDatastoreMaxConnsFile         = getFilePathWithPrefix(strings.ToLower(configDatastoreMaxConnsEnv))
+	configDatastoreMinConnsFile         = getFilePathWithPrefix(strings.ToLower(configDatastoreMinConnsEnv))
+	configDatastoreStatementTimeoutFile = getFilePathWithPrefix(strings.ToLower(configDatastoreStatementTimeoutEnv))
+	configDatastoreAppNameFile          = getFilePathWithPrefix(strings.ToLower(configDatastoreAppNameEnv))
+	configDatastoreReplicaDsnFile       = getFilePathWithPrefix(strings.ToLower(configDatastoreReplicaDsnEnv))
+	configGrpcInterfaceFile             = getFilePathWithPrefix(strings.ToLower(configGrpcInterfaceEnv))
+	configGrpcPortFile                  = getFilePathWithPrefix(strings.ToLower(configGrpcPortEnv))
+	configMetricsEnabledFile            = getFilePathWithPrefix(strings.ToLower(configMetricsEnabledEnv))
+	configMetricsInterfaceFile          = getFilePathWithPrefix(strings.ToLower(configMetricsInterfaceEnv))
+	configMetricsPortFile               = getFilePathWithPrefix(strings.ToLower(configMetricsPortEnv))
+	configLogLevelFile                  = getFilePathWithPrefix(strings.ToLower(configLogLevelEnv))
+	configLogKindFile                   = getFilePathWithPrefix(strings.ToLower(configLogKindEnv))
+	configTlsCertFilePathFile           = getFilePathWithPrefix(strings.ToLower(configTlsCertFilePathEnv))
+	configTlsKeyFilePathFile            = getFilePathWithPrefix(strings.ToLower(configTlsKeyFilePathEnv))
+	configTlsReloadIntervalFile         = getFilePathWithPrefix(strings.ToLower(configTlsReloadIntervalEnv))
+	configTracingExporterFile           = getFilePathWithPrefix(strings.ToLower(configTracingExporterEnv))
+	configTracingEndpointFile           = getFilePathWithPrefix(strings.ToLower(configTracingEndpointEnv))
+	configTracingInsecureFile           = getFilePathWithPrefix(strings.ToLower(configTracingInsecureEnv))
+	configTracingSampleFile             = getFilePathWithPrefix(strings.ToLower(configTracingSampleEnv))
+	configAdminEnabledFile              = getFilePathWithPrefix(strings.ToLower(configAdminEnabledEnv))
+	configAdminTokenFile                = getFilePathWithPrefix(strings.ToLower(configAdminTokenEnv))
+	configNotificationsEnabledFile      = getFilePathWithPrefix(strings.ToLower(configNotificationsEnabledEnv))
+	configNotificationsPollIntervalFile = getFilePathWithPrefix(strings.ToLower(configNotificationsPollIntervalEnv))
+	configNotificationsMaxAttemptsFile  = getFilePathWithPrefix(strings.ToLower(configNotificationsMaxAttemptsEnv))
+	configStatsRefreshIntervalFile      = getFilePathWithPrefix(strings.ToLower(configStatsRefreshIntervalEnv))
+	configWatchBufferSizeFile           = getFilePathWithPrefix(strings.ToLower(configWatchBufferSizeEnv))
+	configWatchMaxSubscriptionsFile     = getFilePathWithPrefix(strings.ToLower(configWatchMaxSubscriptionsEnv))
+	configOfflineEnabledFile            = getFilePathWithPrefix(strings.ToLower(configOfflineEnabledEnv))
+	configOfflineMissQueuePathFile      = getFilePathWithPrefix(strings.ToLower(configOfflineMissQueuePathEnv))
+	configCrawlerEnabledFile            = getFilePathWithPrefix(strings.ToLower(configCrawlerEnabledEnv))
+	configCrawlerSeedsFile              = getFilePathWithPrefix(strings.ToLower(configCrawlerSeedsEnv))
+	configCrawlerRateFile               = getFilePathWithPrefix(strings.ToLower(configCrawlerRateEnv))
+	configCrawlerIntervalFile           = getFilePathWithPrefix(strings.ToLower(configCrawlerIntervalEnv))
+	configCrawlerProgressPathFile       = getFilePathWithPrefix(strings.ToLower(configCrawlerProgressPathEnv))
+	configJobsEnabledFile               = getFilePathWithPrefix(strings.ToLower(configJobsEnabledEnv))
+	configJobsWorkersFile               = getFilePathWithPrefix(strings.ToLower(configJobsWorkersEnv))
+	configJobsMaxAttemptsFile           = getFilePathWithPrefix(strings.ToLower(configJobsMaxAttemptsEnv))
+	configJobsRetentionFile             = getFilePathWithPrefix(strings.ToLower(configJobsRetentionEnv))
+	configTenantsConfigFile             = getFilePathWithPrefix(strings.ToLower(configTenantsConfigEnv))
+	configHistoryFetchLimitFile         = getFilePathWithPrefix(strings.ToLower(configHistoryFetchLimitEnv))
 )
 
 func cfgStringMustNotBeEmpty(key string) func(cCtx *cli.Context, s string) error {
@@ -145,12 +264,129 @@ func cfgStringMustBeValidLoggingKind(key string) func(cCtx *cli.Context, s strin
 	}
 }
 
-var flags = []cli.Flag{
+func cfgStringMustBeValidDatastoreKind(key string) func(cCtx *cli.Context, s string) error {
+	return func(cCtx *cli.Context, s string) error {
+		switch s {
+		case datastoreKindPostgres, datastoreKindSqlite, datastoreKindMemory:
+			return nil
+		default:
+			return &configValidationError{key: key, value: s, detail: "value must be one of postgres, sqlite or memory"}
+		}
+	}
+}
+
+func cfgStringMustBeValidTracingExporter(key string) func(cCtx *cli.Context, s string) error {
+	return func(cCtx *cli.Context, s string) error {
+		switch s {
+		case tracing.ExporterNone, tracing.ExporterStdout, tracing.ExporterOTLP:
+			return nil
+		default:
+			return &configValidationError{key: key, value: s, detail: "value must be one of none, stdout or otlp"}
+		}
+	}
+}
+
+func cfgStringMustBeValidSslMode(key string) func(cCtx *cli.Context, s string) error {
+	return func(cCtx *cli.Context, s string) error {
+		switch s {
+		case "disable", "allow", "prefer", "require", "verify-ca", "verify-full":
+			return nil
+		default:
+			return &configValidationError{key: key, value: s, detail: "value must be one of disable, allow, prefer, require, verify-ca or verify-full"}
+		}
+	}
+}
+
+func cfgFloat64MustBeRatio(key string) func(cCtx *cli.Context, f float64) error {
+	return func(cCtx *cli.Context, f float64) error {
+		if f < 0 || f > 1 {
+			return &configValidationError{key: key, value: fmt.Sprintf("%g", f), detail: "value must be between 0 and 1"}
+		}
+		return nil
+	}
+}
+
+func cfgFloat64MustBePositive(key string) func(cCtx *cli.Context, f float64) error {
+	return func(cCtx *cli.Context, f float64) error {
+		if f <= 0 {
+			return &configValidationError{key: key, value: fmt.Sprintf("%g", f), detail: "value must be positive"}
+		}
+		return nil
+	}
+}
+
+func cfgIntMustBePositive(key string) func(cCtx *cli.Context, i int) error {
+	return func(cCtx *cli.Context, i int) error {
+		if i < 1 {
+			return &configValidationError{key: key, value: fmt.Sprintf("%d", i), detail: "value must be positive"}
+		}
+		return nil
+	}
+}
+
+func cfgIntMustNotBeNegative(key string) func(cCtx *cli.Context, i int) error {
+	return func(cCtx *cli.Context, i int) error {
+		if i < 0 {
+			return &configValidationError{key: key, value: fmt.Sprintf("%d", i), detail: "value must not be negative"}
+		}
+		return nil
+	}
+}
+
+func cfgDurationMustBePositive(key string) func(cCtx *cli.Context, d time.Duration) error {
+	return func(cCtx *cli.Context, d time.Duration) error {
+		if d <= 0 {
+			return &configValidationError{key: key, value: d.String(), detail: "value must be positive"}
+		}
+		return nil
+	}
+}
+
+func cfgDurationMustNotBeNegative(key string) func(cCtx *cli.Context, d time.Duration) error {
+	return func(cCtx *cli.Context, d time.Duration) error {
+		if d < 0 {
+			return &configValidationError{key: key, value: d.String(), detail: "value must not be negative"}
+		}
+		return nil
+	}
+}
+
+var flags = append([]cli.Flag{
 	&cli.StringFlag{
 		Name:    "config",
 		Aliases: []string{"c"},
 		Value:   "config.yaml",
 	},
+	altsrc.NewStringFlag(&cli.StringFlag{
+		Name:     configDatastoreKindKey,
+		Value:    datastoreKindPostgres,
+		Usage:    "Kind of OSLC's datastore. One of postgres, sqlite or memory",
+		EnvVars:  []string{configDatastoreKindEnv},
+		FilePath: configDatastoreKindFile,
+		Action:   cfgStringMustBeValidDatastoreKind(configDatastoreKindKey),
+	}),
+	altsrc.NewStringFlag(&cli.StringFlag{
+		Name:     configDatastorePathKey,
+		Value:    "oslc.db",
+		Usage:    "Path to the database file of OSLC's datastore. Only used by the sqlite datastore",
+		EnvVars:  []string{configDatastorePathEnv},
+		FilePath: configDatastorePathFile,
+		Action:   cfgStringMustNotBeEmpty(configDatastorePathKey),
+	}),
+	altsrc.NewStringFlag(&cli.StringFlag{
+		Name:     configDatastoreSnapshotPathKey,
+		Usage:    "Path to the JSONL file the memory datastore is loaded from at startup, and saved to periodically and at shutdown. The memory datastore is not persisted if empty",
+		EnvVars:  []string{configDatastoreSnapshotPathEnv},
+		FilePath: configDatastoreSnapshotPathFile,
+	}),
+	altsrc.NewDurationFlag(&cli.DurationFlag{
+		Name:     configDatastoreSnapshotIntervalKey,
+		Value:    5 * time.Minute,
+		Usage:    "Interval at which the memory datastore is saved to its snapshot",
+		EnvVars:  []string{configDatastoreSnapshotIntervalEnv},
+		FilePath: configDatastoreSnapshotIntervalFile,
+		Action:   cfgDurationMustBePositive(configDatastoreSnapshotIntervalKey),
+	}),
 	altsrc.NewStringFlag(&cli.StringFlag{
 		Name:     configDatastoreUsernameKey,
 		Value:    "postgres",
@@ -191,6 +427,67 @@ var flags = []cli.Flag{
 		FilePath: configDatastoreDatabaseFile,
 		Action:   cfgStringMustNotBeEmpty(configDatastoreDatabaseKey),
 	}),
+	altsrc.NewBoolFlag(&cli.BoolFlag{
+		Name:     configDatastoreAutoMigrateKey,
+		Value:    false,
+		Usage:    "Apply pending schema migrations to the datastore at startup. Migrations can also be applied with the migrate command",
+		EnvVars:  []string{configDatastoreAutoMigrateEnv},
+		FilePath: configDatastoreAutoMigrateFile,
+	}),
+	altsrc.NewStringFlag(&cli.StringFlag{
+		Name:     configDatastoreDsnKey,
+		Usage:    "Connection string of the postgres datastore, as a URL or in key=value form. If set, the username, password, host, port, database, sslmode and sslrootcert settings are ignored",
+		EnvVars:  []string{configDatastoreDsnEnv},
+		FilePath: configDatastoreDsnFile,
+	}),
+	altsrc.NewStringFlag(&cli.StringFlag{
+		Name:     configDatastoreSslModeKey,
+		Value:    "prefer",
+		Usage:    "SSL mode of the connections to the postgres datastore. One of disable, allow, prefer, require, verify-ca or verify-full",
+		EnvVars:  []string{configDatastoreSslModeEnv},
+		FilePath: configDatastoreSslModeFile,
+		Action:   cfgStringMustBeValidSslMode(configDatastoreSslModeKey),
+	}),
+	altsrc.NewStringFlag(&cli.StringFlag{
+		Name:     configDatastoreSslRootCertKey,
+		Usage:    "Path to the root certificates the certificate of the postgres datastore is verified against. The system certificates are used if empty",
+		EnvVars:  []string{configDatastoreSslRootCertEnv},
+		FilePath: configDatastoreSslRootCertFile,
+	}),
+	altsrc.NewIntFlag(&cli.IntFlag{
+		Name:     configDatastoreMaxConnsKey,
+		Usage:    "Maximum number of connections to the postgres datastore, per pool. The larger of 4 and the number of CPUs is used if 0, unless the connection string sets pool_max_conns",
+		EnvVars:  []string{configDatastoreMaxConnsEnv},
+		FilePath: configDatastoreMaxConnsFile,
+		Action:   cfgIntMustNotBeNegative(configDatastoreMaxConnsKey),
+	}),
+	altsrc.NewIntFlag(&cli.IntFlag{
+		Name:     configDatastoreMinConnsKey,
+		Usage:    "Number of connections to the postgres datastore that are kept open when idle, per pool",
+		EnvVars:  []string{configDatastoreMinConnsEnv},
+		FilePath: configDatastoreMinConnsFile,
+		Action:   cfgIntMustNotBeNegative(configDatastoreMinConnsKey),
+	}),
+	altsrc.NewDurationFlag(&cli.DurationFlag{
+		Name:     configDatastoreStatementTimeoutKey,
+		Usage:    "Time after which the postgres datastore cancels a statement. Statements have no timeout if 0",
+		EnvVars:  []string{configDatastoreStatementTimeoutEnv},
+		FilePath: configDatastoreStatementTimeoutFile,
+		Action:   cfgDurationMustNotBeNegative(configDatastoreStatementTimeoutKey),
+	}),
+	altsrc.NewStringFlag(&cli.StringFlag{
+		Name:     configDatastoreAppNameKey,
+		Value:    "oslc-request-server",
+		Usage:    "Application name the connections to the postgres datastore report, as shown in pg_stat_activity",
+		EnvVars:  []string{configDatastoreAppNameEnv},
+		FilePath: configDatastoreAppNameFile,
+	}),
+	altsrc.NewStringFlag(&cli.StringFlag{
+		Name:     configDatastoreReplicaDsnKey,
+		Usage:    "Connection string of a read replica of the postgres datastore. If set, package lookups read from the replica, and everything else uses the primary",
+		EnvVars:  []string{configDatastoreReplicaDsnEnv},
+		FilePath: configDatastoreReplicaDsnFile,
+	}),
 	altsrc.NewStringFlag(&cli.StringFlag{
 		Name:     configGrpcInterfaceKey,
 		Value:    "0.0.0.0",
@@ -262,4 +559,199 @@ var flags = []cli.Flag{
 		FilePath: configTlsKeyFilePathFile,
 		Action:   cfgStringMustNotBeEmpty(configTlsKeyFilePathKey),
 	}),
-}
+	altsrc.NewDurationFlag(&cli.DurationFlag{
+		Name:     configTlsReloadIntervalKey,
+		Value:    time.Minute,
+		Usage:    "Interval at which the TLS certificate and key files are checked for changes, and reloaded when they change. The certificate is also reloaded on SIGHUP",
+		EnvVars:  []string{configTlsReloadIntervalEnv},
+		FilePath: configTlsReloadIntervalFile,
+		Action:   cfgDurationMustBePositive(configTlsReloadIntervalKey),
+	}),
+	altsrc.NewStringFlag(&cli.StringFlag{
+		Name:     configTracingExporterKey,
+		Value:    tracing.ExporterNone,
+		Usage:    "Exporter for OpenTelemetry traces - valid values are none, stdout and otlp. Setting the exporter to none disables tracing",
+		EnvVars:  []string{configTracingExporterEnv},
+		FilePath: configTracingExporterFile,
+		Action:   cfgStringMustBeValidTracingExporter(configTracingExporterKey),
+	}),
+	altsrc.NewStringFlag(&cli.StringFlag{
+		Name:     configTracingEndpointKey,
+		Value:    "localhost:4317",
+		Usage:    fmt.Sprintf("Host and port of the OTLP collector traces are sent to when %s is otlp", configTracingExporterKey),
+		EnvVars:  []string{configTracingEndpointEnv},
+		FilePath: configTracingEndpointFile,
+		Action:   cfgStringMustNotBeEmpty(configTracingEndpointKey),
+	}),
+	altsrc.NewBoolFlag(&cli.BoolFlag{
+		Name:     configTracingInsecureKey,
+		Value:    false,
+		Usage:    "Disable TLS when connecting to the OTLP collector",
+		EnvVars:  []string{configTracingInsecureEnv},
+		FilePath: configTracingInsecureFile,
+	}),
+	altsrc.NewFloat64Flag(&cli.Float64Flag{
+		Name:     configTracingSampleKey,
+		Value:    1,
+		Usage:    "Fraction of requests that are traced, between 0 and 1",
+		EnvVars:  []string{configTracingSampleEnv},
+		FilePath: configTracingSampleFile,
+		Action:   cfgFloat64MustBeRatio(configTracingSampleKey),
+	}),
+	altsrc.NewBoolFlag(&cli.BoolFlag{
+		Name:     configAdminEnabledKey,
+		Value:    false,
+		Usage:    fmt.Sprintf("Enable the admin gRPC service used to manage license overrides and catalog entries. Requires %s to be set", configAdminTokenKey),
+		EnvVars:  []string{configAdminEnabledEnv},
+		FilePath: configAdminEnabledFile,
+	}),
+	altsrc.NewStringFlag(&cli.StringFlag{
+		Name:     configAdminTokenKey,
+		Usage:    "Bearer token clients must provide in the authorization metadata to call the admin gRPC service",
+		EnvVars:  []string{configAdminTokenEnv},
+		FilePath: configAdminTokenFile,
+	}),
+	altsrc.NewBoolFlag(&cli.BoolFlag{
+		Name:     configNotificationsEnabledKey,
+		Value:    false,
+		Usage:    "Enable webhook notifications when a package version's license differs from the preceding version. Subscriptions are managed through the admin gRPC service",
+		EnvVars:  []string{configNotificationsEnabledEnv},
+		FilePath: configNotificationsEnabledFile,
+	}),
+	altsrc.NewDurationFlag(&cli.DurationFlag{
+		Name:     configNotificationsPollIntervalKey,
+		Value:    5 * time.Second,
+		Usage:    "Interval between checks for webhook deliveries that are due",
+		EnvVars:  []string{configNotificationsPollIntervalEnv},
+		FilePath: configNotificationsPollIntervalFile,
+		Action:   cfgDurationMustBePositive(configNotificationsPollIntervalKey),
+	}),
+	altsrc.NewIntFlag(&cli.IntFlag{
+		Name:     configNotificationsMaxAttemptsKey,
+		Value:    8,
+		Usage:    "Number of attempts after which a webhook delivery is given up",
+		EnvVars:  []string{configNotificationsMaxAttemptsEnv},
+		FilePath: configNotificationsMaxAttemptsFile,
+		Action:   cfgIntMustBePositive(configNotificationsMaxAttemptsKey),
+	}),
+	altsrc.NewDurationFlag(&cli.DurationFlag{
+		Name:     configStatsRefreshIntervalKey,
+		Value:    5 * time.Minute,
+		Usage:    "Age after which catalog statistics, served by GetCatalogStats and exported as metrics, are recomputed",
+		EnvVars:  []string{configStatsRefreshIntervalEnv},
+		FilePath: configStatsRefreshIntervalFile,
+		Action:   cfgDurationMustBePositive(configStatsRefreshIntervalKey),
+	}),
+	altsrc.NewIntFlag(&cli.IntFlag{
+		Name:     configWatchBufferSizeKey,
+		Value:    64,
+		Usage:    "Number of events buffered for each WatchPackages stream. Streams that fall further behind are ended",
+		EnvVars:  []string{configWatchBufferSizeEnv},
+		FilePath: configWatchBufferSizeFile,
+		Action:   cfgIntMustBePositive(configWatchBufferSizeKey),
+	}),
+	altsrc.NewIntFlag(&cli.IntFlag{
+		Name:     configWatchMaxSubscriptionsKey,
+		Value:    1000,
+		Usage:    "Maximum number of concurrent WatchPackages streams, or 0 for no limit",
+		EnvVars:  []string{configWatchMaxSubscriptionsEnv},
+		FilePath: configWatchMaxSubscriptionsFile,
+		Action:   cfgIntMustNotBeNegative(configWatchMaxSubscriptionsKey),
+	}),
+	altsrc.NewBoolFlag(&cli.BoolFlag{
+		Name:     configOfflineEnabledKey,
+		Aliases:  []string{"offline"},
+		Value:    false,
+		Usage:    "Serve packages from the datastore only, without ever calling a distributor. Requests for packages that are not in the datastore fail, and are recorded to the miss queue",
+		EnvVars:  []string{configOfflineEnabledEnv},
+		FilePath: configOfflineEnabledFile,
+	}),
+	altsrc.NewStringFlag(&cli.StringFlag{
+		Name:     configOfflineMissQueuePathKey,
+		Value:    "oslc-misses.jsonl",
+		Usage:    "Path to the JSONL file misses are recorded to in offline mode. The misses are resolved with the resolve command on a machine with access to the distributors",
+		EnvVars:  []string{configOfflineMissQueuePathEnv},
+		FilePath: configOfflineMissQueuePathFile,
+		Action:   cfgStringMustNotBeEmpty(configOfflineMissQueuePathKey),
+	}),
+	altsrc.NewBoolFlag(&cli.BoolFlag{
+		Name:     configCrawlerEnabledKey,
+		Value:    false,
+		Usage:    "Pre-warm the catalog by resolving the packages of the crawler seed files in the background",
+		EnvVars:  []string{configCrawlerEnabledEnv},
+		FilePath: configCrawlerEnabledFile,
+	}),
+	altsrc.NewStringSliceFlag(&cli.StringSliceFlag{
+		Name:     configCrawlerSeedsKey,
+		Usage:    "Seed files of the crawler: lockfiles, SBOMs, or JSONL logs of requests with a distributor, name and version per line. The files are read again before every crawl",
+		EnvVars:  []string{configCrawlerSeedsEnv},
+		FilePath: configCrawlerSeedsFile,
+	}),
+	altsrc.NewFloat64Flag(&cli.Float64Flag{
+		Name:     configCrawlerRateKey,
+		Value:    5,
+		Usage:    "Maximum number of packages the crawler resolves per second from each distributor",
+		EnvVars:  []string{configCrawlerRateEnv},
+		FilePath: configCrawlerRateFile,
+		Action:   cfgFloat64MustBePositive(configCrawlerRateKey),
+	}),
+	altsrc.NewDurationFlag(&cli.DurationFlag{
+		Name:     configCrawlerIntervalKey,
+		Value:    time.Hour,
+		Usage:    "Interval between crawls of the seed files",
+		EnvVars:  []string{configCrawlerIntervalEnv},
+		FilePath: configCrawlerIntervalFile,
+		Action:   cfgDurationMustBePositive(configCrawlerIntervalKey),
+	}),
+	altsrc.NewStringFlag(&cli.StringFlag{
+		Name:     configCrawlerProgressPathKey,
+		Usage:    "Path to the JSONL file the crawler records finished packages to, so they are not resolved again after a restart. Progress is not recorded if empty",
+		EnvVars:  []string{configCrawlerProgressPathEnv},
+		FilePath: configCrawlerProgressPathFile,
+	}),
+	altsrc.NewBoolFlag(&cli.BoolFlag{
+		Name:     configJobsEnabledKey,
+		Value:    false,
+		Usage:    "Accept async GetPackageInfo requests, and resolve them with a pool of background workers",
+		EnvVars:  []string{configJobsEnabledEnv},
+		FilePath: configJobsEnabledFile,
+	}),
+	altsrc.NewIntFlag(&cli.IntFlag{
+		Name:     configJobsWorkersKey,
+		Value:    4,
+		Usage:    "Number of jobs resolved concurrently by the workers",
+		EnvVars:  []string{configJobsWorkersEnv},
+		FilePath: configJobsWorkersFile,
+		Action:   cfgIntMustBePositive(configJobsWorkersKey),
+	}),
+	altsrc.NewIntFlag(&cli.IntFlag{
+		Name:     configJobsMaxAttemptsKey,
+		Value:    5,
+		Usage:    "Number of attempts after which a job that fails with a temporary error is given up",
+		EnvVars:  []string{configJobsMaxAttemptsEnv},
+		FilePath: configJobsMaxAttemptsFile,
+		Action:   cfgIntMustBePositive(configJobsMaxAttemptsKey),
+	}),
+	altsrc.NewDurationFlag(&cli.DurationFlag{
+		Name:     configJobsRetentionKey,
+		Value:    24 * time.Hour,
+		Usage:    "Time for which finished jobs, and their results, can be retrieved with GetJob",
+		EnvVars:  []string{configJobsRetentionEnv},
+		FilePath: configJobsRetentionFile,
+		Action:   cfgDurationMustBePositive(configJobsRetentionKey),
+	}),
+	altsrc.NewStringFlag(&cli.StringFlag{
+		Name:     configTenantsConfigKey,
+		Usage:    "Path to the YAML file configuring the tenants, with their API keys, license policies and quotas. Requests are not made for tenants if empty",
+		EnvVars:  []string{configTenantsConfigEnv},
+		FilePath: configTenantsConfigFile,
+	}),
+	altsrc.NewIntFlag(&cli.IntFlag{
+		Name:     configHistoryFetchLimitKey,
+		Value:    5,
+		Usage:    "Maximum number of versions fetched from a distributor by a single GetLicenseHistory request, on top of the request listing the versions. Histories are made of the stored versions only if 0",
+		EnvVars:  []string{configHistoryFetchLimitEnv},
+		FilePath: configHistoryFetchLimitFile,
+		Action:   cfgIntMustNotBeNegative(configHistoryFetchLimitKey),
+	}),
+}, distributorFlags()...)
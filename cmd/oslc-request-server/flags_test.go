@@ -6,6 +6,7 @@ import (
 	"github.com/urfave/cli/v2"
 	"strconv"
 	"testing"
+	"time"
 )
 
 func TestGetFilePathWithPrefix(t *testing.T) {
@@ -198,3 +199,204 @@ func TestCfgStringMustBeValidLoggingKind(t *testing.T) {
 		})
 	}
 }
+
+func TestCfgStringMustBeValidDatastoreKind(t *testing.T) {
+	cCtx := createContextWithStringFlag(t, "key", "invalid")
+	err := cfgStringMustBeValidDatastoreKind("key")(cCtx, "invalid")
+	var cfgValErr *configValidationError
+	require.ErrorAs(t, err, &cfgValErr)
+
+	cases := []struct {
+		value string
+	}{
+		{"postgres"},
+		{"sqlite"},
+		{"memory"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.value, func(t *testing.T) {
+			cCtx = createContextWithStringFlag(t, "key", tt.value)
+			err = cfgStringMustBeValidDatastoreKind("key")(cCtx, tt.value)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestCfgStringMustBeValidTracingExporter(t *testing.T) {
+	cCtx := createContextWithStringFlag(t, "key", "invalid")
+	err := cfgStringMustBeValidTracingExporter("key")(cCtx, "invalid")
+	var cfgValErr *configValidationError
+	require.ErrorAs(t, err, &cfgValErr)
+
+	cases := []struct {
+		value string
+	}{
+		{"none"},
+		{"stdout"},
+		{"otlp"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.value, func(t *testing.T) {
+			cCtx = createContextWithStringFlag(t, "key", tt.value)
+			err = cfgStringMustBeValidTracingExporter("key")(cCtx, tt.value)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestCfgStringMustBeValidSslMode(t *testing.T) {
+	err := cfgStringMustBeValidSslMode("key")(nil, "invalid")
+	var cfgValErr *configValidationError
+	require.ErrorAs(t, err, &cfgValErr)
+
+	for _, value := range []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"} {
+		t.Run(value, func(t *testing.T) {
+			require.NoError(t, cfgStringMustBeValidSslMode("key")(nil, value))
+		})
+	}
+}
+
+func TestCfgFloat64MustBeRatio(t *testing.T) {
+	cases := []struct {
+		value   float64
+		wantErr bool
+	}{
+		{-0.1, true},
+		{0, false},
+		{0.5, false},
+		{1, false},
+		{1.1, true},
+	}
+
+	for _, tt := range cases {
+		t.Run(strconv.FormatFloat(tt.value, 'g', -1, 64), func(t *testing.T) {
+			err := cfgFloat64MustBeRatio("key")(nil, tt.value)
+			if tt.wantErr {
+				var cfgValErr *configValidationError
+				require.ErrorAs(t, err, &cfgValErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestCfgFloat64MustBePositive(t *testing.T) {
+	cases := []struct {
+		value   float64
+		wantErr bool
+	}{
+		{-1, true},
+		{0, true},
+		{0.5, false},
+		{10, false},
+	}
+
+	for _, tt := range cases {
+		t.Run(strconv.FormatFloat(tt.value, 'g', -1, 64), func(t *testing.T) {
+			err := cfgFloat64MustBePositive("key")(nil, tt.value)
+			if tt.wantErr {
+				var cfgValErr *configValidationError
+				require.ErrorAs(t, err, &cfgValErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestCfgIntMustBePositive(t *testing.T) {
+	cases := []struct {
+		value   int
+		wantErr bool
+	}{
+		{-1, true},
+		{0, true},
+		{1, false},
+		{100, false},
+	}
+
+	for _, tt := range cases {
+		t.Run(strconv.Itoa(tt.value), func(t *testing.T) {
+			err := cfgIntMustBePositive("key")(nil, tt.value)
+			if tt.wantErr {
+				var cfgValErr *configValidationError
+				require.ErrorAs(t, err, &cfgValErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestCfgIntMustNotBeNegative(t *testing.T) {
+	cases := []struct {
+		value   int
+		wantErr bool
+	}{
+		{-1, true},
+		{0, false},
+		{1, false},
+	}
+
+	for _, tt := range cases {
+		t.Run(strconv.Itoa(tt.value), func(t *testing.T) {
+			err := cfgIntMustNotBeNegative("key")(nil, tt.value)
+			if tt.wantErr {
+				var cfgValErr *configValidationError
+				require.ErrorAs(t, err, &cfgValErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestCfgDurationMustBePositive(t *testing.T) {
+	cases := []struct {
+		value   time.Duration
+		wantErr bool
+	}{
+		{-time.Second, true},
+		{0, true},
+		{time.Millisecond, false},
+		{time.Minute, false},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.value.String(), func(t *testing.T) {
+			err := cfgDurationMustBePositive("key")(nil, tt.value)
+			if tt.wantErr {
+				var cfgValErr *configValidationError
+				require.ErrorAs(t, err, &cfgValErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestCfgDurationMustNotBeNegative(t *testing.T) {
+	cases := []struct {
+		value   time.Duration
+		wantErr bool
+	}{
+		{-time.Second, true},
+		{0, false},
+		{time.Second, false},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.value.String(), func(t *testing.T) {
+			err := cfgDurationMustNotBeNegative("key")(nil, tt.value)
+			if tt.wantErr {
+				var cfgValErr *configValidationError
+				require.ErrorAs(t, err, &cfgValErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
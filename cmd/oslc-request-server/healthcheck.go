@@ -19,7 +19,7 @@ var healthCheckCommand = &cli.Command{
 }
 
 func healthcheckAction(cCtx *cli.Context) error {
-	logger := getLogger(cCtx.String(configLogLevelKey), cCtx.String(configLogKindKey), cCtx.App.Writer)
+	logger := getLogger(logLevelFromStr(cCtx.String(configLogLevelKey)), cCtx.String(configLogKindKey), cCtx.App.Writer)
 
 	conn, err := grpc.NewClient(
 		net.JoinHostPort(cCtx.String(configGrpcInterfaceKey), cCtx.String(configGrpcPortKey)),
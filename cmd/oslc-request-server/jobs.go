@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/chainalysis-oss/oslc/jobs"
+	"github.com/chainalysis-oss/oslc/oslc"
+	"github.com/oklog/run"
+	"github.com/urfave/cli/v2"
+	"log/slog"
+)
+
+// errJobsOffline is returned when async lookups are enabled in offline mode, in which every request is answered from
+// the datastore, and the workers cannot reach the distributors.
+var errJobsOffline = errors.New("async lookups cannot be enabled in offline mode")
+
+// jobServerOptions returns the server options for async lookups, if they are enabled.
+func jobServerOptions(cCtx *cli.Context, ds datastore) []oslc.ServerOption {
+	if !cCtx.Bool(configJobsEnabledKey) {
+		return nil
+	}
+	return []oslc.ServerOption{oslc.WithJobStore(ds)}
+}
+
+// newJobPool returns the pool of workers that resolves the jobs of async lookups in ds with resolver, or nil if async
+// lookups are disabled.
+func newJobPool(cCtx *cli.Context, logger *slog.Logger, resolver jobs.Resolver, ds datastore) (*jobs.Pool, error) {
+	if !cCtx.Bool(configJobsEnabledKey) {
+		return nil, nil
+	}
+	if cCtx.Bool(configOfflineEnabledKey) {
+		return nil, errJobsOffline
+	}
+	p, err := jobs.NewPool(
+		jobs.WithLogger(logger.With(slog.String("service", "jobs"))),
+		jobs.WithStore(ds),
+		jobs.WithResolver(resolver),
+		jobs.WithWorkers(cCtx.Int(configJobsWorkersKey)),
+		jobs.WithMaxAttempts(cCtx.Int(configJobsMaxAttemptsKey)),
+		jobs.WithRetention(cCtx.Duration(configJobsRetentionKey)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create job pool: %w", err)
+	}
+	return p, nil
+}
+
+func runJobPool(g *run.Group, p *jobs.Pool) {
+	ctx, cancel := context.WithCancel(context.Background())
+	g.Add(func() error {
+		return p.Run(ctx)
+	}, func(error) {
+		cancel()
+	})
+}
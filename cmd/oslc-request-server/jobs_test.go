@@ -0,0 +1,52 @@
+package main
+
+import (
+	"github.com/chainalysis-oss/oslc/memory"
+	"github.com/stretchr/testify/require"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestJobServerOptions(t *testing.T) {
+	require.Empty(t, jobServerOptions(createContextWithStringFlag(t, configJobsEnabledKey, "false"), nil))
+
+	ds, err := memory.NewDatastore(memory.WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	require.NoError(t, err)
+	require.Len(t, jobServerOptions(createContextWithStringFlag(t, configJobsEnabledKey, "true"), ds), 1)
+}
+
+func TestNewJobPool(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	p, err := newJobPool(createContextWithStringFlag(t, configJobsEnabledKey, "false"), logger, nil, nil)
+	require.NoError(t, err)
+	require.Nil(t, p)
+
+	_, err = newJobPool(createContextWithStringFlags(t, map[string]string{
+		configJobsEnabledKey:    "true",
+		configOfflineEnabledKey: "true",
+	}), logger, nil, nil)
+	require.ErrorIs(t, err, errJobsOffline)
+
+	ds, err := memory.NewDatastore(memory.WithLogger(logger))
+	require.NoError(t, err)
+	_, err = newJobPool(createContextWithStringFlags(t, map[string]string{
+		configJobsEnabledKey:    "true",
+		configOfflineEnabledKey: "false",
+	}), logger, nil, ds)
+	require.ErrorContains(t, err, "failed to create job pool")
+
+	srv, err := newLookupServer(createContextWithDistributorFlags(t, nil), logger, ds)
+	require.NoError(t, err)
+	p, err = newJobPool(createContextWithStringFlags(t, map[string]string{
+		configJobsEnabledKey:     "true",
+		configOfflineEnabledKey:  "false",
+		configJobsWorkersKey:     "2",
+		configJobsMaxAttemptsKey: "3",
+		configJobsRetentionKey:   time.Hour.String(),
+	}), logger, srv, ds)
+	require.NoError(t, err)
+	require.NotNil(t, p)
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"github.com/chainalysis-oss/oslc/tracing"
 	"io"
 	"log/slog"
 	"strings"
@@ -25,18 +26,19 @@ func logLevelFromStr(level string) slog.Level {
 
 // getLogger returns a logger based on the provided level and kind.
 // If the kind is not a valid kind, the logger is set to nil.
-// If the level is not a valid level, the level is set to info.
-func getLogger(level, kind string, writer io.Writer) *slog.Logger {
+// The level may be a [*slog.LevelVar], so it can be changed while the logger is in use.
+// Records logged with a context carrying a span are annotated with the trace and span IDs.
+func getLogger(level slog.Leveler, kind string, writer io.Writer) *slog.Logger {
 	ho := &slog.HandlerOptions{
-		Level: logLevelFromStr(level),
+		Level: level,
 	}
 	switch kind {
 	case strings.ToLower("text"):
-		return slog.New(slog.NewTextHandler(writer, ho))
+		return slog.New(tracing.NewLogHandler(slog.NewTextHandler(writer, ho)))
 	case strings.ToLower("json"):
-		return slog.New(slog.NewJSONHandler(writer, ho))
+		return slog.New(tracing.NewLogHandler(slog.NewJSONHandler(writer, ho)))
 	case strings.ToLower("discard"):
-		return slog.New(slog.NewTextHandler(io.Discard, ho))
+		return slog.New(tracing.NewLogHandler(slog.NewTextHandler(io.Discard, ho)))
 	default:
 		return nil
 	}
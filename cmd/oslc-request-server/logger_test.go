@@ -1,6 +1,7 @@
 package main
 
 import (
+	"github.com/chainalysis-oss/oslc/tracing"
 	"github.com/stretchr/testify/require"
 	"io"
 	"log/slog"
@@ -74,9 +75,9 @@ func Test_getLogger(t *testing.T) {
 				level: "info",
 				kind:  "text",
 			},
-			want: slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{
+			want: slog.New(tracing.NewLogHandler(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{
 				Level: slog.LevelInfo,
-			})),
+			}))),
 		},
 		{
 			name: "json",
@@ -84,9 +85,9 @@ func Test_getLogger(t *testing.T) {
 				level: "warn",
 				kind:  "json",
 			},
-			want: slog.New(slog.NewJSONHandler(io.Discard, &slog.HandlerOptions{
+			want: slog.New(tracing.NewLogHandler(slog.NewJSONHandler(io.Discard, &slog.HandlerOptions{
 				Level: slog.LevelWarn,
-			})),
+			}))),
 		},
 		{
 			name: "invalid",
@@ -98,7 +99,7 @@ func Test_getLogger(t *testing.T) {
 		},
 	}
 	for _, tt := range tests {
-		logger := getLogger(tt.args.level, tt.args.kind, io.Discard)
+		logger := getLogger(logLevelFromStr(tt.args.level), tt.args.kind, io.Discard)
 		require.Equal(t, logger, tt.want)
 	}
 }
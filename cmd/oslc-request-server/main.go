@@ -4,24 +4,26 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"github.com/chainalysis-oss/oslc/cratesio"
-	"github.com/chainalysis-oss/oslc/goproxy"
+	"github.com/chainalysis-oss/oslc/catalogstats"
 	"github.com/chainalysis-oss/oslc/grpc"
-	"github.com/chainalysis-oss/oslc/maven"
+	"github.com/chainalysis-oss/oslc/memory"
 	"github.com/chainalysis-oss/oslc/metrics"
-	"github.com/chainalysis-oss/oslc/npm"
+	"github.com/chainalysis-oss/oslc/notify"
 	"github.com/chainalysis-oss/oslc/oslc"
 	"github.com/chainalysis-oss/oslc/postgres"
-	"github.com/chainalysis-oss/oslc/pypi"
 	"github.com/chainalysis-oss/oslc/sll"
 	"github.com/chainalysis-oss/oslc/spdxnormalizer"
+	"github.com/chainalysis-oss/oslc/tracing"
+	"github.com/chainalysis-oss/oslc/watch"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
 	"log/slog"
 	"net"
-	"net/url"
 	"os"
 	"strings"
 	"syscall"
+	"time"
 
 	_ "embed"
 	"github.com/oklog/run"
@@ -78,10 +80,16 @@ the 'datastore.username' configuration key is '--datastore.username'. These are
 		Version: Version,
 		Commands: []*cli.Command{
 			healthCheckCommand,
+			migrateCommand,
+			exportCommand,
+			importCommand,
+			resolveCommand,
+			crawlCommand,
 			asMarkdownCmd,
 		},
 		Flags: flags,
 		Before: func(cCtx *cli.Context) error {
+			recordExplicitConfigKeys(cCtx)
 			err := altsrc.InitInputSourceWithContext(flags, altsrc.NewYamlSourceFromFlagFunc("config"))(cCtx)
 			if err != nil {
 				// We're forced to use [strings.Contains] here, since urfave/cli doesn't have error types for these
@@ -135,25 +143,54 @@ func asMarkdownAction(cCtx *cli.Context) error {
 
 func rootAction(cCtx *cli.Context) error {
 	logger := slog.New(slog.NewJSONHandler(cCtx.App.Writer, nil))
-	logger = getLogger(cCtx.String(configLogLevelKey), cCtx.String(configLogKindKey), cCtx.App.Writer)
+	// The level can be changed by reloading the configuration.
+	logLevel := new(slog.LevelVar)
+	logLevel.Set(logLevelFromStr(cCtx.String(configLogLevelKey)))
+	logger = getLogger(logLevel, cCtx.String(configLogKindKey), cCtx.App.Writer)
 	logger.Info("starting oslc-request-server", slog.String("version", Version))
 
-	pypiClient, _ := pypi.NewClient(pypi.WithLogger(logger))
-	npmClient, _ := npm.NewClient(npm.WithLogger(logger))
-	mavenClient, _ := maven.NewClient(maven.WithLogger(logger))
-	cratesioClient, err := cratesio.NewClient(cratesio.WithLogger(logger))
-	goClient, err := goproxy.NewClient(goproxy.WithLogger(logger))
-
-	dbPool, err := postgres.NewPool(context.Background(), fmt.Sprintf("postgres://%s:%s@%s:%d/%s", url.QueryEscape(cCtx.String(configDatastoreUsernameKey)), url.QueryEscape(cCtx.String(configDatastorePasswordKey)), cCtx.String(configDatastoreHostKey), cCtx.Int(configDatastorePortKey), cCtx.String(configDatastoreDatabaseKey)))
+	tracingProvider, err := tracing.NewProvider(context.Background(),
+		tracing.WithLogger(logger),
+		tracing.WithExporter(cCtx.String(configTracingExporterKey)),
+		tracing.WithOTLPEndpoint(cCtx.String(configTracingEndpointKey)),
+		tracing.WithOTLPInsecure(cCtx.Bool(configTracingInsecureKey)),
+		tracing.WithSampleRatio(cCtx.Float64(configTracingSampleKey)),
+		tracing.WithWriter(cCtx.App.Writer),
+		tracing.WithServiceVersion(Version),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create tracing provider: %w", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		// tracingProvider.Shutdown() already logs the error, which is all we'd do here anyway.
+		_ = tracingProvider.Shutdown(ctx)
+	}()
+	// The global provider and propagator are used by the HTTP clients of the distributors, the datastore and the
+	// gRPC server, unless they are explicitly configured otherwise.
+	otel.SetTracerProvider(tracingProvider.TracerProvider())
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	clients, err := distributorClients(cCtx, logger)
 	if err != nil {
-		return fmt.Errorf("failed to create database pool: %w", err)
+		return err
 	}
+	// The clients are replaced when the configuration is reloaded.
+	distributors := oslc.NewDistributorClients(clients)
 
-	datastore, err := postgres.NewDatastore(
-		postgres.WithLogger(logger),
-		postgres.WithPool(dbPool))
+	datastore, closeDatastore, err := newDatastore(cCtx, logger)
 	if err != nil {
-		return fmt.Errorf("failed to create datastore: %w", err)
+		return err
+	}
+	defer closeDatastore()
+
+	if m, ok := datastore.(migrator); ok && cCtx.Bool(configDatastoreAutoMigrateKey) {
+		applied, err := m.MigrateUp(context.Background())
+		if err != nil {
+			return fmt.Errorf("failed to migrate datastore: %w", err)
+		}
+		logger.Info("migrated datastore", slog.Int("applied", len(applied)))
 	}
 
 	normalizer, err := spdxnormalizer.NewNormalizer(
@@ -164,22 +201,83 @@ func rootAction(cCtx *cli.Context) error {
 		return fmt.Errorf("failed to create SPDX normalizer: %w", err)
 	}
 
-	oslcSrv, err := oslc.NewServer(
+	catalogStats, err := catalogstats.NewCache(
+		catalogstats.WithLogger(logger.With(slog.String("service", "catalogstats"))),
+		catalogstats.WithCounter(datastore),
+		catalogstats.WithRefreshInterval(cCtx.Duration(configStatsRefreshIntervalKey)),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create catalog statistics: %w", err)
+	}
+
+	var dispatcher *notify.Dispatcher
+	var notificationServerOptions []oslc.ServerOption
+	if cCtx.Bool(configNotificationsEnabledKey) {
+		notifyLogger := logger.With(slog.String("service", "notify"))
+		notifier, err := notify.NewNotifier(
+			notify.WithNotifierLogger(notifyLogger),
+			notify.WithNotifierStore(datastore),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create notifier: %w", err)
+		}
+		dispatcher, err = notify.NewDispatcher(
+			notify.WithDispatcherLogger(notifyLogger),
+			notify.WithDispatcherStore(datastore),
+			notify.WithUserAgent("oslc-request-server/"+Version),
+			notify.WithPollInterval(cCtx.Duration(configNotificationsPollIntervalKey)),
+			notify.WithMaxAttempts(cCtx.Int(configNotificationsMaxAttemptsKey)),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create webhook dispatcher: %w", err)
+		}
+		notificationServerOptions = append(notificationServerOptions,
+			oslc.WithLicenseChangeNotifier(notifier),
+			oslc.WithWebhookStore(datastore),
+		)
+	}
+
+	broker, err := watch.NewBroker(
+		watch.WithLogger(logger.With(slog.String("service", "watch"))),
+		watch.WithBufferSize(cCtx.Int(configWatchBufferSizeKey)),
+		watch.WithMaxSubscriptions(cCtx.Int(configWatchMaxSubscriptionsKey)),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create package event broker: %w", err)
+	}
+	notificationServerOptions = append(notificationServerOptions, oslc.WithPackageEventBroker(broker))
+
+	offlineOptions, closeMissQueue, err := offlineServerOptions(cCtx, logger)
+	if err != nil {
+		return err
+	}
+	defer closeMissQueue()
+	// The distributor clients and offline mode are shared by the oslc and admin servers, like the notification options.
+	notificationServerOptions = append(notificationServerOptions, oslc.WithDistributorClients(distributors))
+	notificationServerOptions = append(notificationServerOptions, offlineOptions...)
+
+	oslcServerOptions := append([]oslc.ServerOption{
 		oslc.WithLogger(logger),
-		oslc.WithPypiClient(pypiClient),
-		oslc.WithNpmClient(npmClient),
-		oslc.WithMavenClient(mavenClient),
-		oslc.WithCratesIoClient(cratesioClient),
-		oslc.WithGoClient(goClient),
 		oslc.WithDatastore(datastore),
 		oslc.WithLicenseIDNormalizer(normalizer),
-	)
+		oslc.WithCurationStore(datastore),
+		oslc.WithCatalogStatsProvider(catalogStats),
+		oslc.WithLicenseHistoryFetchLimit(cCtx.Int(configHistoryFetchLimitKey)),
+	}, notificationServerOptions...)
+	// Async lookups are only served by the oslc server, the admin server has no use for them.
+	oslcServerOptions = append(oslcServerOptions, jobServerOptions(cCtx, datastore)...)
+	tenantOslcOptions, tenantGrpcOptions, err := tenantServerOptions(cCtx, logger)
+	if err != nil {
+		return err
+	}
+	oslcServerOptions = append(oslcServerOptions, tenantOslcOptions...)
+	oslcSrv, err := oslc.NewServer(oslcServerOptions...)
 	if err != nil {
 		return fmt.Errorf("failed to create oslc server: %w", err)
 	}
 
 	var metricsServer *metrics.Server
-	var optionalGrpcServerOptions []grpc.ServerOption
+	optionalGrpcServerOptions := tenantGrpcOptions
 
 	rpcLogger := logger.With(slog.String("service", "gRPC/server"))
 	metricsLogger := logger.With(slog.String("service", "metrics/server"))
@@ -191,6 +289,10 @@ func rootAction(cCtx *cli.Context) error {
 		if err != nil {
 			return fmt.Errorf("failed to create metrics server: %w", err)
 		}
+		metricsServer.GetPrometheusRegistry().MustRegister(catalogStats)
+		if pools, ok := datastore.(*postgres.Datastore); ok {
+			metricsServer.GetPrometheusRegistry().MustRegister(pools)
+		}
 		optionalGrpcServerOptions = append(optionalGrpcServerOptions, grpc.WithPrometheusRegistry(metricsServer.GetPrometheusRegistry()))
 		optionalGrpcServerOptions = append(optionalGrpcServerOptions, grpc.WithPanicsTotalCounter(promauto.With(metricsServer.GetPrometheusRegistry()).NewCounter(prometheus.CounterOpts{
 			Name: "grpc_req_panics_recovered_total",
@@ -198,7 +300,39 @@ func rootAction(cCtx *cli.Context) error {
 		})))
 	}
 
-	optionalGrpcServerOptions = append(optionalGrpcServerOptions, grpc.WithTLS(cCtx.String(configTlsCertFilePathKey), cCtx.String(configTlsKeyFilePathKey)))
+	serverCrawler, err := newServerCrawler(cCtx, logger, oslcSrv, datastore)
+	if err != nil {
+		return err
+	}
+	if serverCrawler != nil && metricsServer != nil {
+		metricsServer.GetPrometheusRegistry().MustRegister(serverCrawler)
+	}
+
+	jobPool, err := newJobPool(cCtx, logger, oslcSrv, datastore)
+	if err != nil {
+		return err
+	}
+
+	if cCtx.Bool(configAdminEnabledKey) {
+		adminSrv, err := oslc.NewAdminServer(append([]oslc.ServerOption{
+			oslc.WithLogger(logger.With(slog.String("service", "admin"))),
+			oslc.WithDatastore(datastore),
+			oslc.WithLicenseIDNormalizer(normalizer),
+			oslc.WithCurationStore(datastore),
+		}, append(notificationServerOptions, tenantOslcOptions...)...)...)
+		if err != nil {
+			return fmt.Errorf("failed to create admin server: %w", err)
+		}
+		optionalGrpcServerOptions = append(optionalGrpcServerOptions,
+			grpc.WithOslcAdminServiceServer(adminSrv),
+			grpc.WithAdminToken(cCtx.String(configAdminTokenKey)),
+		)
+	}
+
+	optionalGrpcServerOptions = append(optionalGrpcServerOptions,
+		grpc.WithTLS(cCtx.String(configTlsCertFilePathKey), cCtx.String(configTlsKeyFilePathKey)),
+		grpc.WithTLSReloadInterval(cCtx.Duration(configTlsReloadIntervalKey)),
+	)
 
 	grpcServerOptions := []grpc.ServerOption{
 		grpc.WithLogger(rpcLogger),
@@ -218,6 +352,9 @@ func rootAction(cCtx *cli.Context) error {
 
 	g := &run.Group{}
 
+	// Interrupts run in the order actors are added, so the broker must be added first: closing it ends the
+	// WatchPackages streams, which would otherwise keep the gRPC server from stopping gracefully.
+	runBroker(g, broker)
 	runGrpcServer(g, grpcServer, listeners.Grpc)
 
 	if cCtx.Bool(configMetricsEnabledKey) {
@@ -227,6 +364,28 @@ func rootAction(cCtx *cli.Context) error {
 		runMetricsServer(g, metricsServer, listeners.Metrics)
 	}
 
+	if dispatcher != nil {
+		runDispatcher(g, dispatcher)
+	}
+
+	if serverCrawler != nil {
+		runCrawler(g, serverCrawler)
+	}
+
+	if jobPool != nil {
+		runJobPool(g, jobPool)
+	}
+
+	if snapshotter, ok := datastore.(*memory.Datastore); ok {
+		runSnapshotter(g, snapshotter)
+	}
+
+	reloader := newConfigReloader(cCtx, logger.With(slog.String("service", "config")), logLevel, grpcServer, distributors)
+	if serverCrawler != nil {
+		reloader.crawler = serverCrawler
+	}
+	runConfigReloader(g, reloader)
+
 	g.Add(run.SignalHandler(context.Background(), syscall.SIGINT, syscall.SIGTERM))
 	if err := g.Run(); err != nil {
 		var sigErr run.SignalError
@@ -264,6 +423,43 @@ func NewListeners(cCtx *cli.Context) (*Listeners, error) {
 	return listeners, nil
 }
 
+// newLookupServer returns an oslc server that looks packages up at the distributors, and saves them to ds. It is used
+// by the commands that fill the catalog outside of the server.
+func newLookupServer(cCtx *cli.Context, logger *slog.Logger, ds datastore) (*oslc.Server, error) {
+	normalizer, err := spdxnormalizer.NewNormalizer(
+		spdxnormalizer.WithLogger(logger),
+		spdxnormalizer.WithLicenseRetriever(sll.AsLicenseRetriever()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SPDX normalizer: %w", err)
+	}
+	clients, err := distributorClients(cCtx, logger)
+	if err != nil {
+		return nil, err
+	}
+	srv, err := oslc.NewServer(
+		oslc.WithLogger(logger),
+		oslc.WithDatastore(ds),
+		oslc.WithLicenseIDNormalizer(normalizer),
+		oslc.WithDistributorClients(oslc.NewDistributorClients(clients)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create oslc server: %w", err)
+	}
+	return srv, nil
+}
+
+func runBroker(g *run.Group, broker *watch.Broker) {
+	done := make(chan struct{})
+	g.Add(func() error {
+		<-done
+		return nil
+	}, func(error) {
+		broker.Close()
+		close(done)
+	})
+}
+
 func runGrpcServer(g *run.Group, grpcServer *grpc.Server, listener net.Listener) {
 	g.Add(func() error {
 		return grpcServer.Serve(listener)
@@ -281,3 +477,23 @@ func runMetricsServer(g *run.Group, metricsServer *metrics.Server, listener net.
 		_ = metricsServer.Close()
 	})
 }
+
+// runSnapshotter saves snapshots of the memory datastore while the server runs. The last snapshot is saved when the
+// group is interrupted, so the actor must be added after the servers that write to the datastore.
+func runSnapshotter(g *run.Group, ds *memory.Datastore) {
+	ctx, cancel := context.WithCancel(context.Background())
+	g.Add(func() error {
+		return ds.Run(ctx)
+	}, func(error) {
+		cancel()
+	})
+}
+
+func runDispatcher(g *run.Group, dispatcher *notify.Dispatcher) {
+	ctx, cancel := context.WithCancel(context.Background())
+	g.Add(func() error {
+		return dispatcher.Run(ctx)
+	}, func(error) {
+		cancel()
+	})
+}
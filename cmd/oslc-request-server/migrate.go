@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/chainalysis-oss/oslc/migrate"
+	"github.com/urfave/cli/v2"
+	"io"
+	"text/tabwriter"
+	"time"
+)
+
+// migrator applies and reverts the schema migrations of the datastore.
+type migrator interface {
+	MigrateUp(ctx context.Context) ([]migrate.Migration, error)
+	MigrateDown(ctx context.Context, steps int) ([]migrate.Migration, error)
+	MigrationStatus(ctx context.Context) ([]migrate.Status, error)
+}
+
+var migrateCommand = &cli.Command{
+	Name:  "migrate",
+	Usage: "Manage the schema of the datastore",
+	Description: `Applies or reverts the schema migrations embedded in the binary. Migrations are recorded in the
+schema_migrations table. On postgres, migrations are run while holding an advisory lock, so it is safe to migrate while
+other replicas are starting.`,
+	Subcommands: []*cli.Command{
+		{
+			Name:   "up",
+			Usage:  "Apply all pending migrations",
+			Action: migrateUpAction,
+		},
+		{
+			Name:   "down",
+			Usage:  "Revert the most recently applied migrations",
+			Action: migrateDownAction,
+			Flags: []cli.Flag{
+				&cli.IntFlag{
+					Name:   "steps",
+					Value:  1,
+					Usage:  "Number of migrations to revert",
+					Action: cfgIntMustBePositive("steps"),
+				},
+			},
+		},
+		{
+			Name:   "status",
+			Usage:  "Show which migrations have been applied",
+			Action: migrateStatusAction,
+		},
+	},
+}
+
+// withMigrator calls f with the datastore configured in cCtx. It fails if the datastore has no schema.
+func withMigrator(cCtx *cli.Context, f func(m migrator) error) error {
+	logger := getLogger(logLevelFromStr(cCtx.String(configLogLevelKey)), cCtx.String(configLogKindKey), cCtx.App.ErrWriter)
+	ds, closeDatastore, err := newDatastore(cCtx, logger)
+	if err != nil {
+		return err
+	}
+	defer closeDatastore()
+	m, ok := ds.(migrator)
+	if !ok {
+		return fmt.Errorf("the %s datastore has no schema to migrate", cCtx.String(configDatastoreKindKey))
+	}
+	return f(m)
+}
+
+func migrateUpAction(cCtx *cli.Context) error {
+	return withMigrator(cCtx, func(m migrator) error {
+		return migrateUp(cCtx.Context, cCtx.App.Writer, m)
+	})
+}
+
+func migrateDownAction(cCtx *cli.Context) error {
+	return withMigrator(cCtx, func(m migrator) error {
+		return migrateDown(cCtx.Context, cCtx.App.Writer, m, cCtx.Int("steps"))
+	})
+}
+
+func migrateStatusAction(cCtx *cli.Context) error {
+	return withMigrator(cCtx, func(m migrator) error {
+		return migrationStatus(cCtx.Context, cCtx.App.Writer, m)
+	})
+}
+
+func migrateUp(ctx context.Context, w io.Writer, m migrator) error {
+	applied, err := m.MigrateUp(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to apply migrations: %w", err)
+	}
+	if len(applied) == 0 {
+		_, err = fmt.Fprintln(w, "no pending migrations")
+		return err
+	}
+	for _, migration := range applied {
+		if _, err := fmt.Fprintf(w, "applied %d_%s\n", migration.Version, migration.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func migrateDown(ctx context.Context, w io.Writer, m migrator, steps int) error {
+	reverted, err := m.MigrateDown(ctx, steps)
+	if err != nil {
+		return fmt.Errorf("failed to revert migrations: %w", err)
+	}
+	if len(reverted) == 0 {
+		_, err = fmt.Fprintln(w, "no applied migrations")
+		return err
+	}
+	for _, migration := range reverted {
+		if _, err := fmt.Fprintf(w, "reverted %d_%s\n", migration.Version, migration.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func migrationStatus(ctx context.Context, w io.Writer, m migrator) error {
+	statuses, err := m.MigrationStatus(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get migration status: %w", err)
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+	for _, status := range statuses {
+		state, appliedAt := "pending", ""
+		if status.Applied {
+			state, appliedAt = "applied", status.AppliedAt.UTC().Format(time.RFC3339)
+		}
+		if status.Unknown {
+			state = "unknown"
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n", status.Version, status.Name, state, appliedAt)
+	}
+	return tw.Flush()
+}
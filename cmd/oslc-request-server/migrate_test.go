@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"github.com/chainalysis-oss/oslc/migrate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+	"testing"
+	"time"
+)
+
+type fakeMigrator struct {
+	applied  []migrate.Migration
+	reverted []migrate.Migration
+	statuses []migrate.Status
+	steps    int
+	err      error
+}
+
+func (f *fakeMigrator) MigrateUp(context.Context) ([]migrate.Migration, error) {
+	return f.applied, f.err
+}
+
+func (f *fakeMigrator) MigrateDown(_ context.Context, steps int) ([]migrate.Migration, error) {
+	f.steps = steps
+	return f.reverted, f.err
+}
+
+func (f *fakeMigrator) MigrationStatus(context.Context) ([]migrate.Status, error) {
+	return f.statuses, f.err
+}
+
+func TestMigrateUp(t *testing.T) {
+	var buf bytes.Buffer
+	m := &fakeMigrator{applied: []migrate.Migration{{Version: 4, Name: "package_search"}, {Version: 5, Name: "raw_license"}}}
+	require.NoError(t, migrateUp(context.Background(), &buf, m))
+	require.Equal(t, "applied 4_package_search\napplied 5_raw_license\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, migrateUp(context.Background(), &buf, &fakeMigrator{}))
+	require.Equal(t, "no pending migrations\n", buf.String())
+
+	require.ErrorIs(t, migrateUp(context.Background(), &buf, &fakeMigrator{err: assert.AnError}), assert.AnError)
+}
+
+func TestMigrateDown(t *testing.T) {
+	var buf bytes.Buffer
+	m := &fakeMigrator{reverted: []migrate.Migration{{Version: 5, Name: "raw_license"}}}
+	require.NoError(t, migrateDown(context.Background(), &buf, m, 1))
+	require.Equal(t, 1, m.steps)
+	require.Equal(t, "reverted 5_raw_license\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, migrateDown(context.Background(), &buf, &fakeMigrator{}, 1))
+	require.Equal(t, "no applied migrations\n", buf.String())
+
+	require.ErrorIs(t, migrateDown(context.Background(), &buf, &fakeMigrator{err: assert.AnError}, 1), assert.AnError)
+}
+
+func TestMigrationStatus(t *testing.T) {
+	var buf bytes.Buffer
+	appliedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	m := &fakeMigrator{statuses: []migrate.Status{
+		{Version: 1, Name: "the_beginning", Applied: true, AppliedAt: appliedAt},
+		{Version: 2, Name: "license_overrides"},
+		{Version: 9, Name: "future", Applied: true, AppliedAt: appliedAt, Unknown: true},
+	}}
+	require.NoError(t, migrationStatus(context.Background(), &buf, m))
+	require.Equal(t, `VERSION  NAME               STATUS   APPLIED AT
+1        the_beginning      applied  2024-01-02T03:04:05Z
+2        license_overrides  pending  
+9        future             unknown  2024-01-02T03:04:05Z
+`, buf.String())
+
+	require.ErrorIs(t, migrationStatus(context.Background(), &buf, &fakeMigrator{err: assert.AnError}), assert.AnError)
+}
+
+func TestWithMigrator_noSchema(t *testing.T) {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.String(configLogLevelKey, "info", "")
+	fs.String(configLogKindKey, "discard", "")
+	fs.String(configDatastoreKindKey, datastoreKindMemory, "")
+	fs.String(configDatastoreSnapshotPathKey, "", "")
+	fs.Duration(configDatastoreSnapshotIntervalKey, time.Minute, "")
+	cCtx := cli.NewContext(cli.NewApp(), fs, nil)
+
+	err := withMigrator(cCtx, func(m migrator) error {
+		t.Fatal("withMigrator must not call f for a datastore without schema")
+		return nil
+	})
+	require.EqualError(t, err, "the memory datastore has no schema to migrate")
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	oslcv1alpha "buf.build/gen/go/chainalysis-oss/oslc/protocolbuffers/go/chainalysis_oss/oslc/v1alpha"
+	"fmt"
+	"github.com/chainalysis-oss/oslc/catalog"
+	"github.com/chainalysis-oss/oslc/memory"
+	"github.com/chainalysis-oss/oslc/offline"
+	"github.com/chainalysis-oss/oslc/oslc"
+	"github.com/urfave/cli/v2"
+	"google.golang.org/grpc/status"
+	"io"
+	"log/slog"
+	"os"
+)
+
+// offlineServerOptions returns the server options for offline mode, if it is enabled. The returned function closes the
+// miss queue, and must be called once the servers are stopped.
+func offlineServerOptions(cCtx *cli.Context, logger *slog.Logger) ([]oslc.ServerOption, func(), error) {
+	if !cCtx.Bool(configOfflineEnabledKey) {
+		return nil, func() {}, nil
+	}
+	queue, err := offline.NewQueue(
+		offline.WithLogger(logger.With(slog.String("service", "offline"))),
+		offline.WithPath(cCtx.String(configOfflineMissQueuePathKey)),
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create miss queue: %w", err)
+	}
+	logger.Info("serving in offline mode, distributors will not be called")
+	closeQueue := func() {
+		if err := queue.Close(); err != nil {
+			logger.Error("failed to close miss queue", slog.String("error", err.Error()))
+		}
+	}
+	return []oslc.ServerOption{oslc.WithOffline(true), oslc.WithMissRecorder(queue)}, closeQueue, nil
+}
+
+var resolveCommand = &cli.Command{
+	Name:  "resolve",
+	Usage: "Look up the packages of a miss queue at the distributors, and write them as JSONL",
+	Description: `Reads the miss queue written by a server in offline mode, looks up every miss at its distributor, and
+writes the resulting entries in the format of the export command. Run it on a machine with access to the
+distributors, and load its output into the offline datastore with the import command. The datastore of the
+configuration is not used.`,
+	Action: resolveAction,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:    "input",
+			Aliases: []string{"i"},
+			Value:   "-",
+			Usage:   "Miss queue to read, or - for standard input",
+		},
+		&cli.StringFlag{
+			Name:    "output",
+			Aliases: []string{"o"},
+			Value:   "-",
+			Usage:   "File to write the entries to, or - for standard output",
+		},
+	},
+}
+
+func resolveAction(cCtx *cli.Context) error {
+	logger := getLogger(logLevelFromStr(cCtx.String(configLogLevelKey)), cCtx.String(configLogKindKey), cCtx.App.ErrWriter)
+
+	var r io.Reader = cCtx.App.Reader
+	if input := cCtx.String("input"); input != "-" {
+		f, err := os.Open(input)
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", input, err)
+		}
+		defer f.Close()
+		r = f
+	}
+	misses, err := offline.ReadMisses(r)
+	if err != nil {
+		return fmt.Errorf("failed to read misses: %w", err)
+	}
+
+	// The entries are collected in a memory datastore, so a package requested by several misses is written once.
+	ds, err := memory.NewDatastore(memory.WithLogger(logger))
+	if err != nil {
+		return err
+	}
+	srv, err := newLookupServer(cCtx, logger, ds)
+	if err != nil {
+		return err
+	}
+
+	var failed int
+	for _, miss := range misses {
+		_, err := srv.GetPackageInfo(cCtx.Context, &oslcv1alpha.GetPackageInfoRequest{
+			Distributor: miss.Distributor,
+			Name:        miss.Name,
+			Version:     miss.Version,
+		})
+		if err != nil {
+			failed++
+			label := miss.Name
+			if miss.Version != "" {
+				label += "@" + miss.Version
+			}
+			_, _ = fmt.Fprintf(cCtx.App.ErrWriter, "failed to resolve %s package %s: %s\n", miss.Distributor, label, status.Convert(err).Message())
+		}
+	}
+
+	n, err := writeCatalog(cCtx, ds, cCtx.String("output"), catalog.Filter{})
+	if err != nil {
+		return err
+	}
+	// The entries may be written to standard output, so the summary is written to standard error.
+	_, err = fmt.Fprintf(cCtx.App.ErrWriter, "resolved %d of %d misses, exported %d entries\n", len(misses)-failed, len(misses), n)
+	return err
+}
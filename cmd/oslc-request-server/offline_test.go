@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestOfflineServerOptions_disabled(t *testing.T) {
+	cCtx := createContextWithStringFlag(t, configOfflineEnabledKey, "false")
+	options, closeQueue, err := offlineServerOptions(cCtx, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+	require.Empty(t, options)
+	closeQueue()
+}
+
+func TestOfflineServerOptions_enabled(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "misses.jsonl")
+	cCtx := createContextWithStringFlags(t, map[string]string{
+		configOfflineEnabledKey:       "true",
+		configOfflineMissQueuePathKey: path,
+	})
+	options, closeQueue, err := offlineServerOptions(cCtx, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+	defer closeQueue()
+	require.Len(t, options, 2)
+	require.FileExists(t, path)
+}
+
+func TestOfflineServerOptions_invalidQueue(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "misses.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte("not json\n"), 0o644))
+	cCtx := createContextWithStringFlags(t, map[string]string{
+		configOfflineEnabledKey:       "true",
+		configOfflineMissQueuePathKey: path,
+	})
+	_, _, err := offlineServerOptions(cCtx, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.ErrorContains(t, err, "failed to create miss queue")
+}
+
+// runResolveCommand runs the app with the resolve command, and returns what the app wrote to its writer and error
+// writer.
+func runResolveCommand(t *testing.T, stdin string, args ...string) (string, string, error) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	app := &cli.App{
+		Flags:     flags,
+		Commands:  []*cli.Command{resolveCommand},
+		Reader:    strings.NewReader(stdin),
+		Writer:    &stdout,
+		ErrWriter: &stderr,
+	}
+	err := app.Run(append([]string{"oslc-request-server", "--log.kind=discard", "resolve"}, args...))
+	return stdout.String(), stderr.String(), err
+}
+
+func TestResolveCommand(t *testing.T) {
+	// The distributor of the misses is not supported, so the distributors are not called.
+	stdout, stderr, err := runResolveCommand(t, `{"distributor":"cpan","name":"test","version":"1.0.0"}`+"\n")
+	require.NoError(t, err)
+	require.Empty(t, stdout)
+	require.Equal(t, "failed to resolve cpan package test@1.0.0: invalid distributor\nresolved 0 of 1 misses, exported 0 entries\n", stderr)
+
+	output := filepath.Join(t.TempDir(), "catalog.jsonl")
+	_, stderr, err = runResolveCommand(t, "", "--output", output)
+	require.NoError(t, err)
+	require.Equal(t, "resolved 0 of 0 misses, exported 0 entries\n", stderr)
+	require.FileExists(t, output)
+}
+
+func TestResolveCommand_errors(t *testing.T) {
+	_, _, err := runResolveCommand(t, "", "--input", filepath.Join(t.TempDir(), "missing.jsonl"))
+	require.ErrorContains(t, err, "failed to open")
+
+	_, _, err = runResolveCommand(t, "{\n")
+	require.ErrorContains(t, err, "failed to read misses")
+}
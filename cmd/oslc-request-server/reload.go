@@ -0,0 +1,280 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/chainalysis-oss/oslc/oslc"
+	"github.com/oklog/run"
+	"github.com/urfave/cli/v2"
+	"github.com/urfave/cli/v2/altsrc"
+	"log/slog"
+	"os"
+	"os/signal"
+	"slices"
+	"syscall"
+	"time"
+)
+
+// explicitConfigKeysMetadataKey is the key of the [cli.App] metadata holding the reloadable configuration keys that are
+// set by a flag, environment variable or file, as recorded by [recordExplicitConfigKeys].
+const explicitConfigKeysMetadataKey = "explicitConfigKeys"
+
+// reloadableConfigKeys are the configuration keys whose values in the configuration file are applied again when the
+// server receives SIGHUP. Changes to other keys require a restart.
+var reloadableConfigKeys = append([]string{
+	configLogLevelKey,
+	configCrawlerRateKey,
+}, distributorKeys()...)
+
+// configValues are the values of configuration keys. It is implemented by [cli.Context].
+type configValues interface {
+	Bool(name string) bool
+	Duration(name string) time.Duration
+	Float64(name string) float64
+	String(name string) string
+	StringSlice(name string) []string
+}
+
+// reloadedConfig is the configuration after a reload. The values of the explicit keys are those at startup, and the
+// values of the other keys those reloaded.
+type reloadedConfig struct {
+	startup  configValues
+	reloaded configValues
+	explicit map[string]bool
+}
+
+func (c reloadedConfig) values(name string) configValues {
+	if c.explicit[name] {
+		return c.startup
+	}
+	return c.reloaded
+}
+
+func (c reloadedConfig) Bool(name string) bool {
+	return c.values(name).Bool(name)
+}
+
+func (c reloadedConfig) Duration(name string) time.Duration {
+	return c.values(name).Duration(name)
+}
+
+func (c reloadedConfig) Float64(name string) float64 {
+	return c.values(name).Float64(name)
+}
+
+func (c reloadedConfig) String(name string) string {
+	return c.values(name).String(name)
+}
+
+func (c reloadedConfig) StringSlice(name string) []string {
+	return c.values(name).StringSlice(name)
+}
+
+// reloadableFlags returns copies of the flags of the reloadable configuration keys. Applying a flag changes it, so
+// copies are applied when reloading, leaving the flags of the running application untouched.
+func reloadableFlags() []cli.Flag {
+	var fs []cli.Flag
+	for _, f := range flags {
+		if !slices.Contains(reloadableConfigKeys, f.Names()[0]) {
+			continue
+		}
+		switch f := f.(type) {
+		case *altsrc.BoolFlag:
+			c := *f.BoolFlag
+			fs = append(fs, altsrc.NewBoolFlag(&c))
+		case *altsrc.DurationFlag:
+			c := *f.DurationFlag
+			fs = append(fs, altsrc.NewDurationFlag(&c))
+		case *altsrc.Float64Flag:
+			c := *f.Float64Flag
+			fs = append(fs, altsrc.NewFloat64Flag(&c))
+		case *altsrc.StringFlag:
+			c := *f.StringFlag
+			fs = append(fs, altsrc.NewStringFlag(&c))
+		case *altsrc.StringSliceFlag:
+			c := *f.StringSliceFlag
+			fs = append(fs, altsrc.NewStringSliceFlag(&c))
+		default:
+			panic(fmt.Sprintf("reloadable flag %s has unsupported type %T", f.Names()[0], f))
+		}
+	}
+	return fs
+}
+
+// loadReloadableConfig returns a context holding the values of the reloadable flags, read from the environment, their
+// files and source, in that order of precedence, as at startup. The values are validated by the actions of the flags.
+func loadReloadableConfig(source altsrc.InputSourceContext) (*cli.Context, error) {
+	reloadable := reloadableFlags()
+	set := flag.NewFlagSet("reload", flag.ContinueOnError)
+	for _, f := range reloadable {
+		if err := f.Apply(set); err != nil {
+			return nil, err
+		}
+	}
+	app := cli.NewApp()
+	app.Flags = reloadable
+	cCtx := cli.NewContext(app, set, nil)
+	if err := altsrc.ApplyInputSourceValues(cCtx, source, reloadable); err != nil {
+		return nil, err
+	}
+	for _, f := range reloadable {
+		if err := runFlagAction(cCtx, f); err != nil {
+			return nil, err
+		}
+	}
+	return cCtx, nil
+}
+
+// runFlagAction runs the action of the flag, if it is set, as the application does at startup.
+func runFlagAction(cCtx *cli.Context, f cli.Flag) error {
+	name := f.Names()[0]
+	if !cCtx.IsSet(name) {
+		return nil
+	}
+	switch f := f.(type) {
+	case *altsrc.BoolFlag:
+		if f.Action != nil {
+			return f.Action(cCtx, cCtx.Bool(name))
+		}
+	case *altsrc.DurationFlag:
+		if f.Action != nil {
+			return f.Action(cCtx, cCtx.Duration(name))
+		}
+	case *altsrc.Float64Flag:
+		if f.Action != nil {
+			return f.Action(cCtx, cCtx.Float64(name))
+		}
+	case *altsrc.StringFlag:
+		if f.Action != nil {
+			return f.Action(cCtx, cCtx.String(name))
+		}
+	case *altsrc.StringSliceFlag:
+		if f.Action != nil {
+			return f.Action(cCtx, cCtx.StringSlice(name))
+		}
+	}
+	return nil
+}
+
+// recordExplicitConfigKeys records which reloadable configuration keys are set by a source taking precedence over the
+// configuration file. It must be called before the configuration file is read, since afterwards every key found in the
+// file is reported as set.
+func recordExplicitConfigKeys(cCtx *cli.Context) {
+	explicit := make(map[string]bool)
+	for _, key := range reloadableConfigKeys {
+		if cCtx.IsSet(key) {
+			explicit[key] = true
+		}
+	}
+	if cCtx.App.Metadata == nil {
+		cCtx.App.Metadata = make(map[string]interface{})
+	}
+	cCtx.App.Metadata[explicitConfigKeysMetadataKey] = explicit
+}
+
+// tlsReloader reloads the certificate of a TLS server.
+type tlsReloader interface {
+	ReloadTLS() error
+}
+
+// crawlerRateSetter changes the rate of a crawler.
+type crawlerRateSetter interface {
+	SetRate(rate float64) error
+}
+
+// configReloader applies the reloadable configuration and reloads the TLS certificate when the server receives SIGHUP.
+type configReloader struct {
+	logger     *slog.Logger
+	configPath string
+	// startup holds the configuration at startup.
+	startup configValues
+	// explicit are the reloadable keys set by a flag, environment variable or file. Their values in the configuration
+	// file are ignored, as they are at startup.
+	explicit map[string]bool
+	logLevel *slog.LevelVar
+	tls      tlsReloader
+	// distributors holds the clients of the distributors, which are replaced by clients with the reloaded settings.
+	distributors *oslc.DistributorClients
+	// crawler is the crawler of the server, if it has one.
+	crawler crawlerRateSetter
+}
+
+func newConfigReloader(cCtx *cli.Context, logger *slog.Logger, logLevel *slog.LevelVar, tls tlsReloader, distributors *oslc.DistributorClients) *configReloader {
+	explicit, _ := cCtx.App.Metadata[explicitConfigKeysMetadataKey].(map[string]bool)
+	return &configReloader{
+		logger:       logger,
+		configPath:   cCtx.String("config"),
+		startup:      cCtx,
+		explicit:     explicit,
+		logLevel:     logLevel,
+		tls:          tls,
+		distributors: distributors,
+	}
+}
+
+// Reload applies the reloadable configuration from the configuration file, and reloads the TLS certificate. Keys
+// missing from the file are reset to their default. If the file is invalid, no configuration is changed, but the
+// certificate is still reloaded.
+func (r *configReloader) Reload() error {
+	return errors.Join(r.applyConfigFile(), r.tls.ReloadTLS())
+}
+
+func (r *configReloader) applyConfigFile() error {
+	if r.configPath == "" {
+		return nil
+	}
+	if _, err := os.Stat(r.configPath); os.IsNotExist(err) {
+		// As at startup, a missing configuration file is not an error.
+		return nil
+	}
+	source, err := altsrc.NewYamlSourceFromFile(r.configPath)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	reloaded, err := loadReloadableConfig(source)
+	if err != nil {
+		return err
+	}
+	cfg := reloadedConfig{startup: r.startup, reloaded: reloaded, explicit: r.explicit}
+
+	// Every value is validated and every client created before anything is applied, so that nothing changes if the
+	// file is invalid.
+	clients, err := distributorClients(cfg, r.logger)
+	if err != nil {
+		return err
+	}
+	r.logLevel.Set(logLevelFromStr(cfg.String(configLogLevelKey)))
+	r.distributors.Set(clients)
+	if r.crawler != nil {
+		return r.crawler.SetRate(cfg.Float64(configCrawlerRateKey))
+	}
+	return nil
+}
+
+// runConfigReloader reloads the configuration whenever the process receives SIGHUP, until the group is interrupted.
+// Failing to reload is logged, and leaves the previous configuration in effect.
+func runConfigReloader(g *run.Group, r *configReloader) {
+	ctx, cancel := context.WithCancel(context.Background())
+	g.Add(func() error {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGHUP)
+		defer signal.Stop(signals)
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-signals:
+				if err := r.Reload(); err != nil {
+					r.logger.Error("failed to reload configuration", slog.String("error", err.Error()))
+					continue
+				}
+				r.logger.Info("reloaded configuration", slog.String("log_level", r.logLevel.Level().String()))
+			}
+		}
+	}, func(error) {
+		cancel()
+	})
+}
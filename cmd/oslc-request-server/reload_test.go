@@ -0,0 +1,228 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/chainalysis-oss/oslc"
+	oslcserver "github.com/chainalysis-oss/oslc/oslc"
+	"github.com/oklog/run"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+type fakeTLSReloader struct {
+	reloads atomic.Int32
+	err     error
+}
+
+func (f *fakeTLSReloader) ReloadTLS() error {
+	f.reloads.Add(1)
+	return f.err
+}
+
+func newTestConfigReloader(t *testing.T, config string, explicit map[string]bool) (*configReloader, *fakeTLSReloader) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(config), 0o600))
+	logLevel := new(slog.LevelVar)
+	tls := &fakeTLSReloader{}
+	return &configReloader{
+		logger:       slog.New(slog.NewTextHandler(io.Discard, nil)),
+		configPath:   path,
+		startup:      createContextWithReloadableFlags(t, nil),
+		explicit:     explicit,
+		logLevel:     logLevel,
+		tls:          tls,
+		distributors: oslcserver.NewDistributorClients(nil),
+	}, tls
+}
+
+// createContextWithReloadableFlags returns a context with the reloadable flags at their defaults, except for the
+// provided values.
+func createContextWithReloadableFlags(t *testing.T, values map[string]string) *cli.Context {
+	t.Helper()
+	fs := flag.NewFlagSet("", flag.ExitOnError)
+	for _, f := range reloadableFlags() {
+		require.NoError(t, f.Apply(fs))
+	}
+	for name, value := range values {
+		require.NoError(t, fs.Set(name, value))
+	}
+	return cli.NewContext(cli.NewApp(), fs, nil)
+}
+
+type fakeCrawler struct {
+	rate float64
+}
+
+func (f *fakeCrawler) SetRate(rate float64) error {
+	f.rate = rate
+	return nil
+}
+
+func TestRecordExplicitConfigKeys(t *testing.T) {
+	cCtx := createContextWithStringFlag(t, configLogLevelKey, "debug")
+	recordExplicitConfigKeys(cCtx)
+	r := newConfigReloader(cCtx, slog.New(slog.NewTextHandler(io.Discard, nil)), new(slog.LevelVar), &fakeTLSReloader{}, oslcserver.NewDistributorClients(nil))
+	require.Equal(t, map[string]bool{configLogLevelKey: true}, r.explicit)
+
+	cCtx = createContextWithStringFlag(t, "config", "config.yaml")
+	recordExplicitConfigKeys(cCtx)
+	r = newConfigReloader(cCtx, slog.New(slog.NewTextHandler(io.Discard, nil)), new(slog.LevelVar), &fakeTLSReloader{}, oslcserver.NewDistributorClients(nil))
+	require.Empty(t, r.explicit)
+	require.Equal(t, "config.yaml", r.configPath)
+}
+
+func TestConfigReloader_Reload(t *testing.T) {
+	r, tls := newTestConfigReloader(t, "log:\n  level: debug\n", nil)
+	require.NoError(t, r.Reload())
+	require.Equal(t, slog.LevelDebug, r.logLevel.Level())
+	require.EqualValues(t, 1, tls.reloads.Load())
+
+	// Keys missing from the file are reset to their default.
+	require.NoError(t, os.WriteFile(r.configPath, []byte("grpc:\n  port: 8080\n"), 0o600))
+	require.NoError(t, r.Reload())
+	require.Equal(t, slog.LevelInfo, r.logLevel.Level())
+}
+
+func TestConfigReloader_Reload_explicitKeysAreKept(t *testing.T) {
+	r, _ := newTestConfigReloader(t, "log:\n  level: debug\n", map[string]bool{configLogLevelKey: true})
+	r.startup = createContextWithReloadableFlags(t, map[string]string{configLogLevelKey: "warn"})
+	r.logLevel.Set(slog.LevelWarn)
+	require.NoError(t, r.Reload())
+	require.Equal(t, slog.LevelWarn, r.logLevel.Level())
+}
+
+func TestConfigReloader_Reload_distributors(t *testing.T) {
+	var requests atomic.Int32
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer mirror.Close()
+	r, _ := newTestConfigReloader(t, fmt.Sprintf(`
+distributors:
+  pypi:
+    base_url: %s
+  maven:
+    enabled: false
+  npm:
+    enabled: false
+`, mirror.URL), map[string]bool{"distributors.npm.enabled": true})
+	require.NoError(t, r.Reload())
+
+	require.Nil(t, r.distributors.Client(oslc.DistributorMaven))
+	// Explicit keys keep their value at startup.
+	require.NotNil(t, r.distributors.Client(oslc.DistributorNpm))
+	pypi := r.distributors.Client(oslc.DistributorPypi)
+	require.NotNil(t, pypi)
+	_, err := pypi.GetPackage(context.Background(), "requests")
+	require.ErrorIs(t, err, oslc.ErrNoSuchPackage)
+	require.Positive(t, requests.Load())
+
+	// Keys missing from the file are reset to their default.
+	require.NoError(t, os.WriteFile(r.configPath, []byte("log:\n  level: info\n"), 0o600))
+	require.NoError(t, r.Reload())
+	require.NotNil(t, r.distributors.Client(oslc.DistributorMaven))
+	require.NotEqual(t, pypi, r.distributors.Client(oslc.DistributorPypi))
+}
+
+func TestConfigReloader_Reload_crawlerRate(t *testing.T) {
+	r, _ := newTestConfigReloader(t, "crawler:\n  rate: 0.5\n", nil)
+	crawler := &fakeCrawler{}
+	r.crawler = crawler
+	require.NoError(t, r.Reload())
+	require.Equal(t, 0.5, crawler.rate)
+
+	require.NoError(t, os.WriteFile(r.configPath, []byte("log:\n  level: info\n"), 0o600))
+	require.NoError(t, r.Reload())
+	require.Equal(t, 5.0, crawler.rate)
+}
+
+func TestConfigReloader_Reload_errors(t *testing.T) {
+	t.Run("invalid level", func(t *testing.T) {
+		r, tls := newTestConfigReloader(t, "log:\n  level: verbose\n", nil)
+		r.logLevel.Set(slog.LevelWarn)
+		var cfgValErr *configValidationError
+		require.ErrorAs(t, r.Reload(), &cfgValErr)
+		require.Equal(t, slog.LevelWarn, r.logLevel.Level())
+		// The certificate is reloaded regardless.
+		require.EqualValues(t, 1, tls.reloads.Load())
+	})
+	t.Run("invalid crawler rate", func(t *testing.T) {
+		r, _ := newTestConfigReloader(t, "log:\n  level: debug\ncrawler:\n  rate: -0.5\n", nil)
+		crawler := &fakeCrawler{rate: 2}
+		r.crawler = crawler
+		var cfgValErr *configValidationError
+		require.ErrorAs(t, r.Reload(), &cfgValErr)
+		// Nothing is changed if any value is invalid.
+		require.Equal(t, 2.0, crawler.rate)
+		require.Equal(t, slog.LevelInfo, r.logLevel.Level())
+	})
+	t.Run("invalid distributor", func(t *testing.T) {
+		r, _ := newTestConfigReloader(t, "distributors:\n  npm:\n    base_url: registry.npmjs.org\n", nil)
+		clients := r.distributors
+		var cfgValErr *configValidationError
+		require.ErrorAs(t, r.Reload(), &cfgValErr)
+		require.Nil(t, clients.Client(oslc.DistributorNpm))
+	})
+	t.Run("invalid file", func(t *testing.T) {
+		r, _ := newTestConfigReloader(t, "log: [", nil)
+		require.Error(t, r.Reload())
+	})
+	t.Run("tls", func(t *testing.T) {
+		r, tls := newTestConfigReloader(t, "log:\n  level: debug\n", nil)
+		tls.err = assert.AnError
+		require.ErrorIs(t, r.Reload(), assert.AnError)
+		require.Equal(t, slog.LevelDebug, r.logLevel.Level())
+	})
+	t.Run("missing file", func(t *testing.T) {
+		r, _ := newTestConfigReloader(t, "", nil)
+		r.configPath = filepath.Join(t.TempDir(), "missing.yaml")
+		require.NoError(t, r.Reload())
+	})
+}
+
+func TestRunConfigReloader(t *testing.T) {
+	r, tls := newTestConfigReloader(t, "log:\n  level: error\n", nil)
+	// Without a handler, SIGHUP terminates the process, so it is also handled here in case it is sent before the actor
+	// starts handling it.
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	defer signal.Stop(signals)
+
+	g := &run.Group{}
+	runConfigReloader(g, r)
+	stop := make(chan struct{})
+	g.Add(func() error {
+		<-stop
+		return nil
+	}, func(error) {})
+	done := make(chan error)
+	go func() {
+		done <- g.Run()
+	}()
+
+	require.Eventually(t, func() bool {
+		// The signal is only handled once the actor is running, so it is sent until it takes effect.
+		require.NoError(t, syscall.Kill(syscall.Getpid(), syscall.SIGHUP))
+		return r.logLevel.Level() == slog.LevelError
+	}, 5*time.Second, 50*time.Millisecond)
+	require.Positive(t, tls.reloads.Load())
+
+	close(stop)
+	require.NoError(t, <-done)
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"fmt"
+	"github.com/chainalysis-oss/oslc/grpc"
+	"github.com/chainalysis-oss/oslc/oslc"
+	"github.com/chainalysis-oss/oslc/tenant"
+	"github.com/urfave/cli/v2"
+	"log/slog"
+)
+
+// tenantServerOptions loads the tenants, if they are configured, and returns the options of the oslc and gRPC servers
+// that serve them. The gRPC server identifies the tenant of every request and enforces its quota, the oslc server
+// evaluates responses against the policy of the tenant and counts the requests of batches against its quota, and the
+// admin server validates the tenants named by requests.
+func tenantServerOptions(cCtx *cli.Context, logger *slog.Logger) ([]oslc.ServerOption, []grpc.ServerOption, error) {
+	path := cCtx.String(configTenantsConfigKey)
+	if path == "" {
+		return nil, nil, nil
+	}
+	registry, err := tenant.Load(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load tenants: %w", err)
+	}
+	logger.Info("serving tenants", slog.String("config", path))
+	return []oslc.ServerOption{oslc.WithTenantPolicies(registry), oslc.WithTenantQuotas(registry), oslc.WithTenants(registry)}, []grpc.ServerOption{grpc.WithTenantResolver(registry)}, nil
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"github.com/chainalysis-oss/oslc/tenant"
+	"github.com/stretchr/testify/require"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTenantServerOptions_disabled(t *testing.T) {
+	cCtx := createContextWithStringFlag(t, configTenantsConfigKey, "")
+	oslcOptions, grpcOptions, err := tenantServerOptions(cCtx, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+	require.Empty(t, oslcOptions)
+	require.Empty(t, grpcOptions)
+}
+
+func TestTenantServerOptions_enabled(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tenants.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("tenants: [{name: payments, api_keys: [key]}]"), 0o600))
+	cCtx := createContextWithStringFlag(t, configTenantsConfigKey, path)
+	oslcOptions, grpcOptions, err := tenantServerOptions(cCtx, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+	require.Len(t, oslcOptions, 3)
+	require.Len(t, grpcOptions, 1)
+}
+
+func TestTenantServerOptions_invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tenants.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("tenants: [{api_keys: [key]}]"), 0o600))
+	cCtx := createContextWithStringFlag(t, configTenantsConfigKey, path)
+	_, _, err := tenantServerOptions(cCtx, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.ErrorIs(t, err, tenant.ErrInvalidConfig)
+}
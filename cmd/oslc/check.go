@@ -0,0 +1,126 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"github.com/chainalysis-oss/oslc/policy"
+	"github.com/urfave/cli/v2"
+	"io"
+	"text/tabwriter"
+)
+
+const flagPolicy = "policy"
+
+// exitViolations is the exit code when packages violate the policy.
+const exitViolations = 1
+
+var checkCommand = &cli.Command{
+	Name:      "check",
+	Usage:     "Check the licenses of the packages in lockfiles or SBOMs against a license policy",
+	ArgsUsage: "<file>...",
+	Description: `Reads the packages from lockfiles or SBOMs, looks up their licenses, and prints the packages whose licenses violate the
+license policy. See the documentation of the policy package for the format of the policy file.
+
+The exit code is 1 if any package violates the policy, and 2 if the license of any package could not be looked up.`,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:      flagPolicy,
+			Usage:     "The license policy file, in YAML",
+			Aliases:   []string{"p"},
+			Required:  true,
+			TakesFile: true,
+		},
+		&cli.StringFlag{
+			Name:    flagFormat,
+			Usage:   "The output format of the violations, one of table, json or csv",
+			Value:   formatTable,
+			Aliases: []string{"f"},
+			Action: func(_ *cli.Context, format string) error {
+				return validateFormat(format)
+			},
+		},
+		concurrencyFlag,
+	},
+	Action: checkAction,
+}
+
+// violation is a package violating the policy, as printed by the check command.
+type violation struct {
+	Distributor string `json:"distributor"`
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	License     string `json:"license"`
+	Reason      string `json:"reason"`
+}
+
+func checkAction(cCtx *cli.Context) error {
+	p, err := policy.Load(cCtx.String(flagPolicy))
+	if err != nil {
+		return err
+	}
+	results, err := scan(cCtx)
+	if err != nil {
+		return err
+	}
+	if err := lookupErrors(results); err != nil {
+		// Packages whose license is not known cannot be checked, so the check is not reported as passing.
+		_ = writeResults(cCtx.App.ErrWriter, formatTable, failedResults(results))
+		return err
+	}
+
+	violations := []violation{}
+	for _, r := range results {
+		v, violated := p.Check(policy.Package{Distributor: r.Distributor, Name: r.Name, Version: r.Version, License: r.License})
+		if violated {
+			violations = append(violations, violation{Distributor: r.Distributor, Name: r.Name, Version: r.Version, License: r.License, Reason: v.Reason})
+		}
+	}
+	if err := writeViolations(cCtx.App.Writer, cCtx.String(flagFormat), violations); err != nil {
+		return err
+	}
+	if len(violations) > 0 {
+		return cli.Exit(fmt.Sprintf("%d of %d packages violate the license policy", len(violations), len(results)), exitViolations)
+	}
+	return nil
+}
+
+func failedResults(results []result) []result {
+	var failed []result
+	for _, r := range results {
+		if r.Error != "" {
+			failed = append(failed, r)
+		}
+	}
+	return failed
+}
+
+func writeViolations(w io.Writer, format string, violations []violation) error {
+	switch format {
+	case formatJSON:
+		return writeJSON(w, violations)
+	case formatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write([]string{"distributor", "name", "version", "license", "reason"}); err != nil {
+			return err
+		}
+		for _, v := range violations {
+			if err := cw.Write([]string{v.Distributor, v.Name, v.Version, v.License, v.Reason}); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	case formatTable:
+		if len(violations) == 0 {
+			_, err := fmt.Fprintln(w, "No license policy violations found")
+			return err
+		}
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "DISTRIBUTOR\tNAME\tVERSION\tLICENSE\tREASON")
+		for _, v := range violations {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", v.Distributor, v.Name, v.Version, v.License, v.Reason)
+		}
+		return tw.Flush()
+	}
+	return validateFormat(format)
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"github.com/chainalysis-oss/oslc/policy"
+	"github.com/stretchr/testify/require"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckAction(t *testing.T) {
+	addr := startServer(t, &fakeServer{licenses: map[[3]string]string{
+		{"npm", "lodash", "4.17.21"}: "MIT",
+		{"npm", "left-pad", "1.3.0"}: "WTFPL",
+	}})
+	packageLock := writeFile(t, "package-lock.json", testPackageLock)
+
+	stdout, _, err := runApp(t, "--server", addr, "--insecure", "check", "--policy", writeFile(t, "policy.yaml", "allow_categories: [permissive]"), packageLock)
+	require.Equal(t, exitViolations, exitCode(err))
+	require.Equal(t, `DISTRIBUTOR  NAME      VERSION  LICENSE  REASON
+npm          left-pad  1.3.0    WTFPL    license WTFPL is not allowed
+`, stdout)
+
+	stdout, _, err = runApp(t, "--server", addr, "--insecure", "check", "--format", "json", "--policy", writeFile(t, "policy.yaml", "allow_categories: [permissive]"), packageLock)
+	require.Equal(t, exitViolations, exitCode(err))
+	require.JSONEq(t, `[{"distributor": "npm", "name": "left-pad", "version": "1.3.0", "license": "WTFPL", "reason": "license WTFPL is not allowed"}]`, stdout)
+
+	stdout, _, err = runApp(t, "--server", addr, "--insecure", "check", "--policy", writeFile(t, "policy.yaml", "allow_categories: [permissive, public-domain]"), packageLock)
+	require.NoError(t, err)
+	require.Equal(t, "No license policy violations found\n", stdout)
+
+	stdout, _, err = runApp(t, "--server", addr, "--insecure", "check", "--format", "csv", "--policy", writeFile(t, "policy.yaml", "deny: [MIT]"), packageLock)
+	require.Equal(t, exitViolations, exitCode(err))
+	require.Equal(t, "distributor,name,version,license,reason\nnpm,lodash,4.17.21,MIT,license MIT is denied\n", stdout)
+}
+
+func TestCheckAction_errors(t *testing.T) {
+	addr := startServer(t, &fakeServer{})
+	packageLock := writeFile(t, "package-lock.json", testPackageLock)
+
+	_, stderr, err := runApp(t, "--server", addr, "--insecure", "check", "--policy", writeFile(t, "policy.yaml", "allow_unknown: true"), packageLock)
+	require.Equal(t, exitLookupFailed, exitCode(err))
+	require.Contains(t, stderr, "error: package not found")
+
+	_, _, err = runApp(t, "--server", addr, "--insecure", "check", "--policy", writeFile(t, "policy.yaml", "allow: MIT"), packageLock)
+	require.ErrorIs(t, err, policy.ErrInvalidPolicy)
+
+	_, _, err = runApp(t, "--server", addr, "--insecure", "check", "--policy", filepath.Join(t.TempDir(), "policy.yaml"), packageLock)
+	require.ErrorContains(t, err, "failed to read policy")
+
+	_, _, err = runApp(t, "--server", addr, "--insecure", "check", packageLock)
+	require.ErrorContains(t, err, "Required flag \"policy\" not set")
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"buf.build/gen/go/chainalysis-oss/oslc/grpc/go/chainalysis_oss/oslc/v1alpha/oslcv1alphagrpc"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"github.com/urfave/cli/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"os"
+)
+
+// ErrInvalidCAFile is returned when the CA file does not contain any PEM certificates.
+var ErrInvalidCAFile = errors.New("no certificates found in CA file")
+
+// newClient returns a client for the server configured by the global flags. The returned function closes the
+// connection.
+func newClient(cCtx *cli.Context) (oslcv1alphagrpc.OslcServiceClient, func() error, error) {
+	options := []grpc.DialOption{}
+	if cCtx.Bool(flagInsecure) {
+		options = append(options, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	} else {
+		tlsConfig, err := tlsConfig(cCtx.String(flagCAFile))
+		if err != nil {
+			return nil, nil, err
+		}
+		options = append(options, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	}
+	if apiKey := cCtx.String(flagAPIKey); apiKey != "" {
+		options = append(options, grpc.WithUnaryInterceptor(newAPIKeyInterceptor(apiKey)))
+	}
+
+	conn, err := grpc.NewClient(cCtx.String(flagServer), options...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create grpc client: %w", err)
+	}
+	return oslcv1alphagrpc.NewOslcServiceClient(conn), conn.Close, nil
+}
+
+// tlsConfig returns the TLS configuration trusting the certificate authorities in caFile, or the system's certificate
+// authorities if caFile is empty.
+func tlsConfig(caFile string) (*tls.Config, error) {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caFile == "" {
+		return config, nil
+	}
+	pem, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA file: %w", err)
+	}
+	config.RootCAs = x509.NewCertPool()
+	if !config.RootCAs.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCAFile, caFile)
+	}
+	return config, nil
+}
+
+// newAPIKeyInterceptor returns an interceptor sending apiKey as a bearer token in the authorization metadata, in the
+// same way as the token of the admin service.
+func newAPIKeyInterceptor(apiKey string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+apiKey)
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"github.com/stretchr/testify/require"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTLSConfig(t *testing.T) {
+	config, err := tlsConfig("")
+	require.NoError(t, err)
+	require.Nil(t, config.RootCAs)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "oslc test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	caFile := filepath.Join(t.TempDir(), "ca.crt")
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+
+	config, err = tlsConfig(caFile)
+	require.NoError(t, err)
+	require.NotNil(t, config.RootCAs)
+
+	invalidFile := filepath.Join(t.TempDir(), "invalid.crt")
+	require.NoError(t, os.WriteFile(invalidFile, []byte("not a certificate"), 0o600))
+	_, err = tlsConfig(invalidFile)
+	require.ErrorIs(t, err, ErrInvalidCAFile)
+
+	_, err = tlsConfig(filepath.Join(t.TempDir(), "missing.crt"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestNewClient_apiKey(t *testing.T) {
+	f := &fakeServer{licenses: map[[3]string]string{{"npm", "lodash", "4.17.21"}: "MIT"}}
+	addr := startServer(t, f)
+
+	_, _, err := runApp(t, "--server", addr, "--insecure", "--api-key", "secret", "query", "npm", "lodash", "4.17.21")
+	require.NoError(t, err)
+	_, _, err = runApp(t, "--server", addr, "--insecure", "query", "npm", "lodash", "4.17.21")
+	require.NoError(t, err)
+	require.Equal(t, []string{"Bearer secret"}, f.authorization)
+}
+
+func TestNewClient_tls(t *testing.T) {
+	f := &fakeServer{}
+	addr := startServer(t, f)
+
+	// The server does not serve TLS, so the handshake fails.
+	_, _, err := runApp(t, "--server", addr, "--timeout", "1s", "query", "npm", "lodash")
+	require.ErrorContains(t, err, "failed to look up npm package lodash")
+
+	_, _, err = runApp(t, "--server", addr, "--ca-file", filepath.Join(t.TempDir(), "missing.crt"), "query", "npm", "lodash")
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"buf.build/gen/go/chainalysis-oss/oslc/grpc/go/chainalysis_oss/oslc/v1alpha/oslcv1alphagrpc"
+	oslcv1alpha "buf.build/gen/go/chainalysis-oss/oslc/protocolbuffers/go/chainalysis_oss/oslc/v1alpha"
+	"context"
+	"github.com/chainalysis-oss/oslc/lockfile"
+	"google.golang.org/grpc/status"
+	"sync"
+	"time"
+)
+
+// result is the license of a package as reported by the server.
+type result struct {
+	Distributor string `json:"distributor"`
+	Name        string `json:"name"`
+	// Version is the version the license applies to. If the requested version was a constraint, it is the version the
+	// server resolved it to.
+	Version string `json:"version"`
+	// RequestedVersion is the version constraint the version was resolved from, if any.
+	RequestedVersion string `json:"requested_version,omitempty"`
+	License          string `json:"license"`
+	RawLicense       string `json:"raw_license,omitempty"`
+	Curated          bool   `json:"curated"`
+	// Error is the error returned by the server, if the license could not be looked up.
+	Error string `json:"error,omitempty"`
+}
+
+// lookup returns the license of pkg. Errors are recorded in the result, so a failure for a single package does not
+// prevent reporting the others.
+func lookup(ctx context.Context, client oslcv1alphagrpc.OslcServiceClient, timeout time.Duration, pkg lockfile.Package) result {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	r := result{Distributor: pkg.Distributor, Name: pkg.Name, Version: pkg.Version}
+	resp, err := client.GetPackageInfo(ctx, &oslcv1alpha.GetPackageInfoRequest{
+		Distributor: pkg.Distributor,
+		Name:        pkg.Name,
+		Version:     pkg.Version,
+	})
+	if err != nil {
+		r.Error = status.Convert(err).Message()
+		return r
+	}
+	r.Version = resp.Version
+	r.RequestedVersion = resp.RequestedVersion
+	r.License = resp.License
+	r.RawLicense = resp.RawLicense
+	r.Curated = resp.Curated
+	return r
+}
+
+// lookupAll returns the licenses of packages, in the same order, looking up at most concurrency packages at a time.
+func lookupAll(ctx context.Context, client oslcv1alphagrpc.OslcServiceClient, timeout time.Duration, concurrency int, packages []lockfile.Package) []result {
+	results := make([]result, len(packages))
+	semaphore := make(chan struct{}, max(concurrency, 1))
+	var wg sync.WaitGroup
+	for i, pkg := range packages {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			results[i] = lookup(ctx, client, timeout, pkg)
+		}()
+	}
+	wg.Wait()
+	return results
+}
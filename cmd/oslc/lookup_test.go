@@ -0,0 +1,36 @@
+package main
+
+import (
+	"buf.build/gen/go/chainalysis-oss/oslc/grpc/go/chainalysis_oss/oslc/v1alpha/oslcv1alphagrpc"
+	"context"
+	"github.com/chainalysis-oss/oslc/lockfile"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"testing"
+	"time"
+)
+
+func TestLookupAll(t *testing.T) {
+	addr := startServer(t, &fakeServer{licenses: map[[3]string]string{
+		{"npm", "lodash", "4.17.21"}: "MIT",
+		{"npm", "react", "1.2.0"}:    "MIT",
+		{"pypi", "requests", "2.0"}:  "Apache-2.0",
+	}})
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	results := lookupAll(context.Background(), oslcv1alphagrpc.NewOslcServiceClient(conn), time.Second, 2, []lockfile.Package{
+		{Distributor: "npm", Name: "lodash", Version: "4.17.21"},
+		{Distributor: "npm", Name: "missing", Version: "1.0.0"},
+		{Distributor: "npm", Name: "react", Version: "^1.0.0"},
+		{Distributor: "pypi", Name: "requests", Version: "2.0"},
+	})
+	require.Equal(t, []result{
+		{Distributor: "npm", Name: "lodash", Version: "4.17.21", License: "MIT"},
+		{Distributor: "npm", Name: "missing", Version: "1.0.0", Error: "package not found"},
+		{Distributor: "npm", Name: "react", Version: "1.2.0", RequestedVersion: "^1.0.0", License: "MIT"},
+		{Distributor: "pypi", Name: "requests", Version: "2.0", License: "Apache-2.0"},
+	}, results)
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"github.com/urfave/cli/v2"
+	"log/slog"
+	"os"
+	"time"
+)
+
+var Version = "0.0.0"
+
+const (
+	flagServer   = "server"
+	flagCAFile   = "ca-file"
+	flagInsecure = "insecure"
+	flagAPIKey   = "api-key"
+	flagTimeout  = "timeout"
+	flagFormat   = "format"
+)
+
+func main() {
+	app := newApp()
+	if err := app.Run(os.Args); err != nil {
+		// Errors created with cli.Exit are handled by the app itself, so only other errors reach this point.
+		logger := slog.New(slog.NewTextHandler(app.ErrWriter, nil))
+		logger.Error("failed to run app", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+}
+
+func newApp() *cli.App {
+	return &cli.App{
+		Name:  "oslc",
+		Usage: "Query an OSLC Request Server for the licenses of packages",
+		Description: `oslc is a client for the OSLC Request Server. It looks up the licenses of single packages, of every package in a
+lockfile or SBOM, and checks them against a license policy, which makes it suitable for use in CI pipelines.
+
+The supported lockfiles are package-lock.json, npm-shrinkwrap.json, requirements.txt, poetry.lock, Cargo.lock and
+go.sum. CycloneDX and SPDX SBOMs in JSON are also supported.`,
+		Version:  Version,
+		Commands: []*cli.Command{queryCommand, scanCommand, checkCommand},
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    flagServer,
+				Usage:   "The address of the OSLC Request Server, as host:port",
+				EnvVars: []string{"OSLC_SERVER"},
+				Value:   "localhost:8080",
+			},
+			&cli.StringFlag{
+				Name:      flagCAFile,
+				Usage:     "A PEM file of the certificate authorities trusted to sign the server's certificate. The system's certificate authorities are used if unset",
+				EnvVars:   []string{"OSLC_CA_FILE"},
+				TakesFile: true,
+			},
+			&cli.BoolFlag{
+				Name:    flagInsecure,
+				Usage:   "Connect to the server without TLS",
+				EnvVars: []string{"OSLC_INSECURE"},
+			},
+			&cli.StringFlag{
+				Name:    flagAPIKey,
+				Usage:   "The API key sent to the server as a bearer token",
+				EnvVars: []string{"OSLC_API_KEY"},
+			},
+			&cli.DurationFlag{
+				Name:    flagTimeout,
+				Usage:   "The timeout of each request to the server",
+				EnvVars: []string{"OSLC_TIMEOUT"},
+				Value:   30 * time.Second,
+			},
+		},
+		Writer:    os.Stdout,
+		ErrWriter: os.Stderr,
+	}
+}
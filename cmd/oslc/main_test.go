@@ -0,0 +1,89 @@
+package main
+
+import (
+	"buf.build/gen/go/chainalysis-oss/oslc/grpc/go/chainalysis_oss/oslc/v1alpha/oslcv1alphagrpc"
+	oslcv1alpha "buf.build/gen/go/chainalysis-oss/oslc/protocolbuffers/go/chainalysis_oss/oslc/v1alpha"
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"net"
+	"sync"
+	"testing"
+)
+
+// fakeServer serves the licenses of the packages in licenses, keyed by distributor, name and version. Other packages
+// are not found.
+type fakeServer struct {
+	oslcv1alphagrpc.UnimplementedOslcServiceServer
+	licenses map[[3]string]string
+
+	mu            sync.Mutex
+	authorization []string
+}
+
+func (f *fakeServer) GetPackageInfo(ctx context.Context, request *oslcv1alpha.GetPackageInfoRequest) (*oslcv1alpha.GetPackageInfoResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	f.mu.Lock()
+	f.authorization = append(f.authorization, md.Get("authorization")...)
+	f.mu.Unlock()
+
+	version := request.Version
+	requestedVersion := ""
+	if version == "^1.0.0" {
+		version, requestedVersion = "1.2.0", request.Version
+	}
+	license, ok := f.licenses[[3]string{request.Distributor, request.Name, version}]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "package not found")
+	}
+	return &oslcv1alpha.GetPackageInfoResponse{Name: request.Name, Version: version, RequestedVersion: requestedVersion, License: license}, nil
+}
+
+// startServer serves f without TLS, and returns its address.
+func startServer(t *testing.T, f *fakeServer) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:")
+	require.NoError(t, err)
+	s := grpc.NewServer()
+	oslcv1alphagrpc.RegisterOslcServiceServer(s, f)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+	return lis.Addr().String()
+}
+
+// runApp runs the app with args, and returns what it wrote to its standard output and error. Exit errors are returned
+// instead of exiting.
+func runApp(t *testing.T, args ...string) (string, string, error) {
+	t.Helper()
+	app := newApp()
+	var stdout, stderr bytes.Buffer
+	app.Writer = &stdout
+	app.ErrWriter = &stderr
+	app.ExitErrHandler = func(*cli.Context, error) {}
+	err := app.Run(append([]string{"oslc"}, args...))
+	return stdout.String(), stderr.String(), err
+}
+
+// exitCode returns the exit code of err, which is 0 if err is nil.
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	if exitErr, ok := err.(cli.ExitCoder); ok {
+		return exitErr.ExitCode()
+	}
+	return 1
+}
+
+func TestNewApp(t *testing.T) {
+	stdout, _, err := runApp(t, "--help")
+	require.NoError(t, err)
+	for _, command := range []string{"query", "scan", "check"} {
+		require.Contains(t, stdout, command)
+	}
+}
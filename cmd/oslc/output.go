@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+)
+
+// The output formats of the results.
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatCSV   = "csv"
+)
+
+// ErrUnsupportedOutputFormat is returned when the output format is not one of the supported formats.
+var ErrUnsupportedOutputFormat = errors.New("unsupported output format")
+
+// validateFormat is the action of the format flag.
+func validateFormat(format string) error {
+	switch format {
+	case formatTable, formatJSON, formatCSV:
+		return nil
+	}
+	return fmt.Errorf("%w %q, must be one of %s, %s or %s", ErrUnsupportedOutputFormat, format, formatTable, formatJSON, formatCSV)
+}
+
+func writeResults(w io.Writer, format string, results []result) error {
+	switch format {
+	case formatJSON:
+		return writeJSON(w, results)
+	case formatCSV:
+		return writeCSV(w, results)
+	case formatTable:
+		return writeTable(w, results)
+	}
+	return validateFormat(format)
+}
+
+func writeJSON(w io.Writer, v any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+func writeCSV(w io.Writer, results []result) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"distributor", "name", "version", "requested_version", "license", "raw_license", "curated", "error"}); err != nil {
+		return err
+	}
+	for _, r := range results {
+		if err := cw.Write([]string{r.Distributor, r.Name, r.Version, r.RequestedVersion, r.License, r.RawLicense, strconv.FormatBool(r.Curated), r.Error}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeTable(w io.Writer, results []result) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "DISTRIBUTOR\tNAME\tVERSION\tLICENSE")
+	for _, r := range results {
+		license := r.License
+		switch {
+		case r.Error != "":
+			license = "error: " + r.Error
+		case license == "":
+			license = "unknown"
+		case r.Curated:
+			license += " (curated)"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", r.Distributor, r.Name, r.Version, license)
+	}
+	return tw.Flush()
+}
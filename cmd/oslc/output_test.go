@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+var testResults = []result{
+	{Distributor: "npm", Name: "lodash", Version: "4.17.21", License: "MIT"},
+	{Distributor: "npm", Name: "react", Version: "1.2.0", RequestedVersion: "^1.0.0", License: "MIT", Curated: true},
+	{Distributor: "npm", Name: "left-pad", Version: "1.0.0"},
+	{Distributor: "npm", Name: "missing", Version: "1.0.0", Error: "package not found"},
+}
+
+func TestWriteResults(t *testing.T) {
+	testcases := []struct {
+		format string
+		want   string
+	}{
+		{formatTable, `DISTRIBUTOR  NAME      VERSION  LICENSE
+npm          lodash    4.17.21  MIT
+npm          react     1.2.0    MIT (curated)
+npm          left-pad  1.0.0    unknown
+npm          missing   1.0.0    error: package not found
+`},
+		{formatCSV, `distributor,name,version,requested_version,license,raw_license,curated,error
+npm,lodash,4.17.21,,MIT,,false,
+npm,react,1.2.0,^1.0.0,MIT,,true,
+npm,left-pad,1.0.0,,,,false,
+npm,missing,1.0.0,,,,false,package not found
+`},
+		{formatJSON, `[
+  {
+    "distributor": "npm",
+    "name": "lodash",
+    "version": "4.17.21",
+    "license": "MIT",
+    "curated": false
+  },
+  {
+    "distributor": "npm",
+    "name": "react",
+    "version": "1.2.0",
+    "requested_version": "^1.0.0",
+    "license": "MIT",
+    "curated": true
+  },
+  {
+    "distributor": "npm",
+    "name": "left-pad",
+    "version": "1.0.0",
+    "license": "",
+    "curated": false
+  },
+  {
+    "distributor": "npm",
+    "name": "missing",
+    "version": "1.0.0",
+    "license": "",
+    "curated": false,
+    "error": "package not found"
+  }
+]
+`},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, writeResults(&buf, tt.format, testResults))
+			require.Equal(t, tt.want, buf.String())
+		})
+	}
+
+	require.ErrorIs(t, writeResults(&bytes.Buffer{}, "xml", testResults), ErrUnsupportedOutputFormat)
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"github.com/chainalysis-oss/oslc/lockfile"
+	"github.com/urfave/cli/v2"
+)
+
+var queryCommand = &cli.Command{
+	Name:      "query",
+	Usage:     "Look up the license of a single package",
+	ArgsUsage: "<distributor> <name> [version]",
+	Description: `Looks up the license of a version of a package. If the version is omitted, the latest version is used. The version may
+also be a version constraint in the native syntax of the distributor, such as "^4.17.0" for npm.`,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:    flagFormat,
+			Usage:   "The output format, one of table, json or csv",
+			Value:   formatTable,
+			Aliases: []string{"f"},
+			Action: func(_ *cli.Context, format string) error {
+				return validateFormat(format)
+			},
+		},
+	},
+	Action: queryAction,
+}
+
+func queryAction(cCtx *cli.Context) error {
+	if cCtx.NArg() < 2 || cCtx.NArg() > 3 {
+		return fmt.Errorf("expected 2 or 3 arguments, got %d", cCtx.NArg())
+	}
+	client, closeClient, err := newClient(cCtx)
+	if err != nil {
+		return err
+	}
+	defer closeClient()
+
+	pkg := lockfile.Package{Distributor: cCtx.Args().Get(0), Name: cCtx.Args().Get(1), Version: cCtx.Args().Get(2)}
+	r := lookup(cCtx.Context, client, cCtx.Duration(flagTimeout), pkg)
+	if r.Error != "" {
+		return fmt.Errorf("failed to look up %s package %s: %s", pkg.Distributor, pkg.Name, r.Error)
+	}
+	return writeResults(cCtx.App.Writer, cCtx.String(flagFormat), []result{r})
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestQueryAction(t *testing.T) {
+	addr := startServer(t, &fakeServer{licenses: map[[3]string]string{{"npm", "react", "1.2.0"}: "MIT"}})
+
+	stdout, _, err := runApp(t, "--server", addr, "--insecure", "query", "--format", "csv", "npm", "react", "^1.0.0")
+	require.NoError(t, err)
+	require.Equal(t, "distributor,name,version,requested_version,license,raw_license,curated,error\nnpm,react,1.2.0,^1.0.0,MIT,,false,\n", stdout)
+
+	_, _, err = runApp(t, "--server", addr, "--insecure", "query", "npm", "missing")
+	require.ErrorContains(t, err, "failed to look up npm package missing: package not found")
+
+	_, _, err = runApp(t, "--server", addr, "--insecure", "query", "npm")
+	require.ErrorContains(t, err, "expected 2 or 3 arguments")
+
+	_, _, err = runApp(t, "--server", addr, "--insecure", "query", "--format", "xml", "npm", "react")
+	require.ErrorIs(t, err, ErrUnsupportedOutputFormat)
+}
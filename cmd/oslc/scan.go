@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"github.com/chainalysis-oss/oslc/lockfile"
+	"github.com/urfave/cli/v2"
+	"os"
+)
+
+const flagConcurrency = "concurrency"
+
+// exitLookupFailed is the exit code when the license of a package could not be looked up.
+const exitLookupFailed = 2
+
+var scanCommand = &cli.Command{
+	Name:      "scan",
+	Usage:     "Look up the licenses of the packages in lockfiles or SBOMs",
+	ArgsUsage: "<file>...",
+	Description: `Reads the packages from lockfiles or SBOMs, and prints their licenses. The format of each file is detected from its
+name, and for JSON files with other names, from its contents.
+
+The exit code is 2 if the license of any package could not be looked up. The licenses of the remaining packages are
+still printed.`,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:    flagFormat,
+			Usage:   "The output format, one of table, json or csv",
+			Value:   formatTable,
+			Aliases: []string{"f"},
+			Action: func(_ *cli.Context, format string) error {
+				return validateFormat(format)
+			},
+		},
+		concurrencyFlag,
+	},
+	Action: scanAction,
+}
+
+var concurrencyFlag = &cli.IntFlag{
+	Name:  flagConcurrency,
+	Usage: "The number of packages looked up at the same time",
+	Value: 8,
+	Action: func(_ *cli.Context, concurrency int) error {
+		if concurrency < 1 {
+			return fmt.Errorf("%s must be positive", flagConcurrency)
+		}
+		return nil
+	},
+}
+
+func scanAction(cCtx *cli.Context) error {
+	results, err := scan(cCtx)
+	if err != nil {
+		return err
+	}
+	if err := writeResults(cCtx.App.Writer, cCtx.String(flagFormat), results); err != nil {
+		return err
+	}
+	return lookupErrors(results)
+}
+
+// scan looks up the licenses of the packages in the files passed as arguments.
+func scan(cCtx *cli.Context) ([]result, error) {
+	if cCtx.NArg() == 0 {
+		return nil, fmt.Errorf("expected at least one file")
+	}
+	packages, err := readPackages(cCtx.Args().Slice())
+	if err != nil {
+		return nil, err
+	}
+	client, closeClient, err := newClient(cCtx)
+	if err != nil {
+		return nil, err
+	}
+	defer closeClient()
+	return lookupAll(cCtx.Context, client, cCtx.Duration(flagTimeout), cCtx.Int(flagConcurrency), packages), nil
+}
+
+// readPackages returns the packages in the files, without duplicates, in the order they are first found.
+func readPackages(paths []string) ([]lockfile.Package, error) {
+	var packages []lockfile.Package
+	seen := make(map[lockfile.Package]bool)
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read lockfile: %w", err)
+		}
+		found, err := lockfile.Parse(path, data)
+		if err != nil {
+			return nil, err
+		}
+		for _, pkg := range found {
+			if !seen[pkg] {
+				seen[pkg] = true
+				packages = append(packages, pkg)
+			}
+		}
+	}
+	return packages, nil
+}
+
+// lookupErrors returns an exit error if the license of any package could not be looked up.
+func lookupErrors(results []result) error {
+	failed := 0
+	for _, r := range results {
+		if r.Error != "" {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return cli.Exit(fmt.Sprintf("failed to look up the license of %d of %d packages", failed, len(results)), exitLookupFailed)
+	}
+	return nil
+}
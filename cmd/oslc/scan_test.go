@@ -0,0 +1,75 @@
+package main
+
+import (
+	"github.com/chainalysis-oss/oslc/lockfile"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testPackageLock = `{
+  "lockfileVersion": 3,
+  "packages": {
+    "": {"name": "app"},
+    "node_modules/lodash": {"version": "4.17.21"},
+    "node_modules/left-pad": {"version": "1.3.0"}
+  }
+}`
+
+// writeFile writes data to the file with the provided name in a temporary directory, and returns its path.
+func writeFile(t *testing.T, name, data string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+	return path
+}
+
+func TestScanAction(t *testing.T) {
+	addr := startServer(t, &fakeServer{licenses: map[[3]string]string{
+		{"npm", "lodash", "4.17.21"}: "MIT",
+		{"npm", "left-pad", "1.3.0"}: "WTFPL",
+		{"pypi", "requests", "2.0"}:  "Apache-2.0",
+	}})
+	packageLock := writeFile(t, "package-lock.json", testPackageLock)
+	requirements := writeFile(t, "requirements.txt", "requests==2.0\n")
+
+	stdout, _, err := runApp(t, "--server", addr, "--insecure", "scan", packageLock, requirements)
+	require.NoError(t, err)
+	require.Equal(t, `DISTRIBUTOR  NAME      VERSION  LICENSE
+npm          left-pad  1.3.0    WTFPL
+npm          lodash    4.17.21  MIT
+pypi         requests  2.0      Apache-2.0
+`, stdout)
+
+	missing := writeFile(t, "requirements.txt", "missing==1.0\n")
+	stdout, _, err = runApp(t, "--server", addr, "--insecure", "scan", "--format", "csv", requirements, missing)
+	require.Equal(t, exitLookupFailed, exitCode(err))
+	require.Contains(t, stdout, "pypi,missing,1.0,,,,false,package not found\n")
+}
+
+func TestScanAction_errors(t *testing.T) {
+	_, _, err := runApp(t, "--insecure", "scan")
+	require.ErrorContains(t, err, "expected at least one file")
+
+	_, _, err = runApp(t, "--insecure", "scan", writeFile(t, "pom.xml", ""))
+	require.ErrorIs(t, err, lockfile.ErrUnsupportedFormat)
+
+	_, _, err = runApp(t, "--insecure", "scan", filepath.Join(t.TempDir(), "go.sum"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	_, _, err = runApp(t, "--insecure", "scan", "--concurrency", "0", writeFile(t, "go.sum", ""))
+	require.ErrorContains(t, err, "concurrency must be positive")
+}
+
+func TestReadPackages(t *testing.T) {
+	first := writeFile(t, "requirements.txt", "requests==2.0\nflask==3.0\n")
+	second := writeFile(t, "requirements.txt", "requests==2.0\nclick==8.0\n")
+	packages, err := readPackages([]string{first, second})
+	require.NoError(t, err)
+	require.Equal(t, []lockfile.Package{
+		{Distributor: "pypi", Name: "flask", Version: "3.0"},
+		{Distributor: "pypi", Name: "requests", Version: "2.0"},
+		{Distributor: "pypi", Name: "click", Version: "8.0"},
+	}, packages)
+}
@@ -1,6 +1,7 @@
 package cratesio
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/chainalysis-oss/oslc"
@@ -108,15 +109,15 @@ func NewClient(options ...ClientOption) (*Client, error) {
 
 // GetPackageVersion returns the package with the given name and version.
 // If version is empty, the latest version is returned.
-func (c *Client) GetPackageVersion(name, version string) (oslc.Entry, error) {
+func (c *Client) GetPackageVersion(ctx context.Context, name, version string) (oslc.Entry, error) {
 	path := fmt.Sprintf("api/v1/crates/%s/%s", name, version)
 	if version == "" {
 		path = fmt.Sprintf("api/v1/crates/%s", name)
 	}
 
-	resp, err := c.options.HttpClient.Query(fmt.Sprintf("%s/%s", c.options.BaseURL, path))
+	resp, err := c.options.HttpClient.Query(ctx, fmt.Sprintf("%s/%s", c.options.BaseURL, path))
 	if err != nil {
-		return oslc.Entry{}, oslc.DistributorError{Distributor: oslc.DistributorCratesIo, Err: err}
+		return oslc.Entry{}, oslc.NewTransportError(oslc.DistributorCratesIo, err)
 	}
 
 	if resp.StatusCode == http.StatusNotFound {
@@ -129,7 +130,7 @@ func (c *Client) GetPackageVersion(name, version string) (oslc.Entry, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return oslc.Entry{}, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return oslc.Entry{}, oslc.NewStatusError(oslc.DistributorCratesIo, resp, fmt.Errorf("unexpected status code: %d", resp.StatusCode))
 	}
 
 	defer resp.Body.Close()
@@ -157,6 +158,33 @@ func (c *Client) GetPackageVersion(name, version string) (oslc.Entry, error) {
 
 // GetPackage returns the package with the given name. It is a convenience function for [GetPackageVersion]
 // with an empty version.
-func (c *Client) GetPackage(name string) (oslc.Entry, error) {
-	return c.GetPackageVersion(name, "")
+func (c *Client) GetPackage(ctx context.Context, name string) (oslc.Entry, error) {
+	return c.GetPackageVersion(ctx, name, "")
+}
+
+// ListVersions returns the versions listed for the crate with the given name.
+func (c *Client) ListVersions(ctx context.Context, name string) ([]string, error) {
+	resp, err := c.options.HttpClient.Query(ctx, fmt.Sprintf("%s/api/v1/crates/%s", c.options.BaseURL, name))
+	if err != nil {
+		return nil, oslc.NewTransportError(oslc.DistributorCratesIo, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, oslc.DistributorError{Distributor: oslc.DistributorCratesIo, Err: fmt.Errorf("%w: %s", oslc.ErrNoSuchPackage, name)}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, oslc.NewStatusError(oslc.DistributorCratesIo, resp, fmt.Errorf("unexpected status code: %d", resp.StatusCode))
+	}
+
+	var crt crateResponse
+	err = json.NewDecoder(resp.Body).Decode(&crt)
+	if err != nil {
+		return nil, oslc.DistributorError{Distributor: oslc.DistributorCratesIo, Err: err}
+	}
+	versions := make([]string, len(crt.Versions))
+	for i, v := range crt.Versions {
+		versions[i] = v.Num
+	}
+	return versions, nil
 }
@@ -2,6 +2,7 @@ package cratesio
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"github.com/chainalysis-oss/oslc"
 	ownHTTP "github.com/chainalysis-oss/oslc/http"
@@ -220,7 +221,7 @@ func TestClient_GetPackageVersion(t *testing.T) {
 	for _, tt := range testcases {
 		t.Run(tt.name, func(t *testing.T) {
 			c := setupClient(t, setupHttpClientWithBody(t, tt.body))
-			out, err := c.GetPackageVersion(tt.pkgName, tt.pkgVersion)
+			out, err := c.GetPackageVersion(context.Background(), tt.pkgName, tt.pkgVersion)
 			require.NoError(t, err)
 			require.Equal(t, tt.expected, out)
 		})
@@ -234,37 +235,106 @@ func TestClient_GetPackageVersion_http_client_error(t *testing.T) {
 	httpClient, err := ownHTTP.NewClient(ownHTTP.WithHTTPClient(mock))
 	require.NoError(t, err)
 	c := setupClient(t, httpClient)
-	_, err = c.GetPackageVersion("test", "")
+	_, err = c.GetPackageVersion(context.Background(), "test", "")
 	require.Error(t, err)
 }
 
 func TestClient_GetPackageVersion_http_client_status_error(t *testing.T) {
 	c := setupClient(t, setupHttpClientWithStatusAndBody(t, http.StatusNotFound, ""))
-	_, err := c.GetPackageVersion("test", "")
+	_, err := c.GetPackageVersion(context.Background(), "test", "")
 	require.Error(t, err)
 }
 
 func TestClient_GetPackageVersion_latest_version_json_decode_error(t *testing.T) {
 	c := setupClient(t, setupHttpClientWithBody(t, "test"))
-	_, err := c.GetPackageVersion("test", "")
+	_, err := c.GetPackageVersion(context.Background(), "test", "")
 	require.Error(t, err)
 }
 
 func TestClient_GetPackageVersion_specific_version_json_decode_error(t *testing.T) {
 	c := setupClient(t, setupHttpClientWithBody(t, "test"))
-	_, err := c.GetPackageVersion("test", "0.0.1")
+	_, err := c.GetPackageVersion(context.Background(), "test", "0.0.1")
 	require.Error(t, err)
 }
 
 func TestClient_GetPackageVersion_latest_version_no_version(t *testing.T) {
 	c := setupClient(t, setupHttpClientWithBody(t, "{}"))
-	_, err := c.GetPackageVersion("test", "")
+	_, err := c.GetPackageVersion(context.Background(), "test", "")
 	require.Error(t, err)
 }
 
 func TestClient_GetPackage(t *testing.T) {
 	c := setupClient(t, setupHttpClientWithBody(t, getTestData(t, "testdata/crate.json")))
-	out, err := c.GetPackage("snarkvm-marlin")
+	out, err := c.GetPackage(context.Background(), "snarkvm-marlin")
 	require.NoError(t, err)
 	require.NotEmpty(t, out)
 }
+
+func TestClient_ListVersions(t *testing.T) {
+	testcases := []struct {
+		name     string
+		status   int
+		body     string
+		expected []string
+		err      error
+	}{
+		{
+			name:     "versions",
+			status:   http.StatusOK,
+			body:     `{"crate":{"name":"test"},"versions":[{"num":"2.0.0"},{"num":"1.1.0"},{"num":"1.0.0"}]}`,
+			expected: []string{"1.0.0", "1.1.0", "2.0.0"},
+		},
+		{
+			name:     "no versions",
+			status:   http.StatusOK,
+			body:     `{}`,
+			expected: []string{},
+		},
+		{
+			name:   "not found",
+			status: http.StatusNotFound,
+			err:    oslc.ErrNoSuchPackage,
+		},
+		{
+			name:   "unexpected status",
+			status: http.StatusInternalServerError,
+			err:    oslc.DistributorError{},
+		},
+		{
+			name:   "decode error",
+			status: http.StatusOK,
+			body:   "test",
+			err:    oslc.DistributorError{},
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.name, func(t *testing.T) {
+			c := setupClient(t, setupHttpClientWithStatusAndBody(t, tt.status, tt.body))
+			out, err := c.ListVersions(context.Background(), "test")
+			if tt.err != nil {
+				require.ErrorAs(t, err, &oslc.DistributorError{})
+				if tt.err != (oslc.DistributorError{}) {
+					require.ErrorIs(t, err, tt.err)
+				}
+				return
+			}
+			require.NoError(t, err)
+			require.ElementsMatch(t, tt.expected, out)
+		})
+	}
+}
+
+func TestClient_ListVersions_path(t *testing.T) {
+	mock := ownHTTP.NewTestHTTPClient(func(req *http.Request) (*http.Response, error) {
+		require.Equal(t, "https://crates.io/api/v1/crates/test", req.URL.String())
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBuffer([]byte(`{}`))),
+		}, nil
+	})
+	httpClient, err := ownHTTP.NewClient(ownHTTP.WithHTTPClient(mock))
+	require.NoError(t, err)
+	c := setupClient(t, httpClient)
+	_, err = c.ListVersions(context.Background(), "test")
+	require.NoError(t, err)
+}
@@ -0,0 +1,305 @@
+// Package crawler pre-warms the catalog. It resolves the packages of seed lists, such as lockfiles and logs of past
+// requests, ahead of the first request for them, at a limited rate per distributor.
+package crawler
+
+import (
+	oslcv1alpha "buf.build/gen/go/chainalysis-oss/oslc/protocolbuffers/go/chainalysis_oss/oslc/v1alpha"
+	"context"
+	"errors"
+	"github.com/chainalysis-oss/oslc/versions"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Resolver resolves packages. It is implemented by the oslc.Server of the oslc/oslc package, and by the OSLC gRPC
+// client.
+type Resolver interface {
+	GetPackageInfo(ctx context.Context, request *oslcv1alpha.GetPackageInfoRequest) (*oslcv1alpha.GetPackageInfoResponse, error)
+}
+
+// The outcomes of crawling a seed, used as the result label of the seeds metric.
+const (
+	// OutcomeResolved is the outcome of seeds that were resolved.
+	OutcomeResolved = "resolved"
+	// OutcomeCached is the outcome of seeds that were already stored in the datastore.
+	OutcomeCached = "cached"
+	// OutcomeResumed is the outcome of seeds that were finished by an earlier crawl.
+	OutcomeResumed = "resumed"
+	// OutcomeFailed is the outcome of seeds that could not be resolved.
+	OutcomeFailed = "failed"
+)
+
+// Result counts the outcomes of the seeds of a crawl.
+type Result struct {
+	Resolved int
+	Cached   int
+	Resumed  int
+	Failed   int
+}
+
+func (r *Result) add(outcome string) {
+	switch outcome {
+	case OutcomeResolved:
+		r.Resolved++
+	case OutcomeCached:
+		r.Cached++
+	case OutcomeResumed:
+		r.Resumed++
+	case OutcomeFailed:
+		r.Failed++
+	}
+}
+
+// Crawler resolves seeds with a [Resolver]. Seeds of different distributors are resolved concurrently, and seeds of
+// the same distributor one at a time, at most at the rate configured for the distributor.
+type Crawler struct {
+	options *crawlerOptions
+	// rate holds the bits of the rate of the distributors without an entry in the Rates option, which can be changed
+	// while the crawler is running.
+	rate atomic.Uint64
+
+	seeds   *prometheus.CounterVec
+	pending *prometheus.GaugeVec
+}
+
+// NewCrawler returns a new Crawler. The Resolver option is required.
+func NewCrawler(options ...CrawlerOption) (*Crawler, error) {
+	opts := defaultCrawlerOptions
+	for _, opt := range globalCrawlerOptions {
+		opt.apply(&opts)
+	}
+	for _, opt := range options {
+		opt.apply(&opts)
+	}
+
+	if opts.Resolver == nil {
+		return nil, ErrMissingOptionResolver
+	}
+	if opts.Rate <= 0 {
+		return nil, ErrInvalidRate
+	}
+	for _, rate := range opts.Rates {
+		if rate <= 0 {
+			return nil, ErrInvalidRate
+		}
+	}
+	if opts.Interval <= 0 {
+		return nil, ErrInvalidInterval
+	}
+
+	c := &Crawler{
+		options: &opts,
+		seeds: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "oslc_crawler_seeds_total",
+			Help: "Number of seeds crawled, by distributor and result.",
+		}, []string{"distributor", "result"}),
+		pending: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "oslc_crawler_seeds_pending",
+			Help: "Number of seeds of the current crawl that have not been crawled yet, by distributor.",
+		}, []string{"distributor"}),
+	}
+	c.rate.Store(math.Float64bits(opts.Rate))
+	return c, nil
+}
+
+var ErrMissingOptionResolver = errors.New("missing option: resolver")
+var ErrInvalidRate = errors.New("rate must be positive")
+var ErrInvalidInterval = errors.New("interval must be positive")
+
+// SetRate changes the maximum number of seeds resolved per second for each distributor without a rate of its own. It
+// takes effect from the next seed, including during a crawl.
+func (c *Crawler) SetRate(rate float64) error {
+	if rate <= 0 {
+		return ErrInvalidRate
+	}
+	c.rate.Store(math.Float64bits(rate))
+	return nil
+}
+
+// Run crawls the seeds of the seed paths every interval, until ctx is cancelled. Errors are logged, and do not stop
+// the crawler. Run returns nil once ctx is cancelled.
+func (c *Crawler) Run(ctx context.Context) error {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-timer.C:
+		}
+
+		seeds, err := LoadSeeds(c.options.SeedPaths)
+		if err != nil {
+			c.options.Logger.ErrorContext(ctx, "failed to load seeds", slog.String("error", err.Error()))
+		} else if _, err := c.Crawl(ctx, seeds); err != nil && ctx.Err() == nil {
+			c.options.Logger.ErrorContext(ctx, "crawl failed", slog.String("error", err.Error()))
+		}
+		timer.Reset(c.options.Interval)
+	}
+}
+
+// Crawl resolves the seeds and returns the outcomes. Seeds that fail are counted, and do not stop the crawl. Seeds
+// that were resolved, were already stored, or failed permanently because the distributor does not know them, are
+// recorded to the progress file. Crawl returns an error if the progress file cannot be used, or ctx is cancelled.
+func (c *Crawler) Crawl(ctx context.Context, seeds []Seed) (Result, error) {
+	var p *progress
+	if c.options.ProgressPath != "" {
+		var err error
+		if p, err = openProgress(c.options.ProgressPath); err != nil {
+			return Result{}, err
+		}
+		defer p.close()
+	}
+
+	byDistributor := make(map[string][]Seed)
+	for _, seed := range seeds {
+		byDistributor[seed.Distributor] = append(byDistributor[seed.Distributor], seed)
+	}
+
+	start := time.Now()
+	c.options.Logger.InfoContext(ctx, "crawl started", slog.Int("seeds", len(seeds)))
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var result Result
+	var firstErr error
+	for distributor, seeds := range byDistributor {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r, err := c.crawlDistributor(ctx, p, distributor, seeds)
+			mu.Lock()
+			defer mu.Unlock()
+			result.Resolved += r.Resolved
+			result.Cached += r.Cached
+			result.Resumed += r.Resumed
+			result.Failed += r.Failed
+			if err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}()
+	}
+	wg.Wait()
+
+	c.options.Logger.InfoContext(ctx, "crawl finished",
+		slog.Int("resolved", result.Resolved),
+		slog.Int("cached", result.Cached),
+		slog.Int("resumed", result.Resumed),
+		slog.Int("failed", result.Failed),
+		slog.Duration("duration", time.Since(start)),
+	)
+	return result, firstErr
+}
+
+// crawlDistributor crawls the seeds of a single distributor one at a time, waiting between the seeds that are
+// resolved to stay within the rate of the distributor.
+func (c *Crawler) crawlDistributor(ctx context.Context, p *progress, distributor string, seeds []Seed) (Result, error) {
+	var result Result
+	pending := c.pending.WithLabelValues(distributor)
+	pending.Set(float64(len(seeds)))
+	defer pending.Set(0)
+
+	var next time.Time
+	for _, seed := range seeds {
+		outcome, err := c.crawlSeed(ctx, p, seed, c.interval(distributor), &next)
+		if err != nil {
+			return result, err
+		}
+		result.add(outcome)
+		c.seeds.WithLabelValues(distributor, outcome).Inc()
+		pending.Dec()
+	}
+	return result, nil
+}
+
+// interval returns the time to wait between the seeds of the distributor that are resolved.
+func (c *Crawler) interval(distributor string) time.Duration {
+	rate, ok := c.options.Rates[distributor]
+	if !ok {
+		rate = math.Float64frombits(c.rate.Load())
+	}
+	return time.Duration(float64(time.Second) / rate)
+}
+
+// crawlSeed crawls a single seed and returns its outcome. Next is the earliest time the next seed of the distributor
+// may be resolved. An error is only returned if the progress file cannot be written, or ctx is cancelled.
+func (c *Crawler) crawlSeed(ctx context.Context, p *progress, seed Seed, interval time.Duration, next *time.Time) (string, error) {
+	if p.isDone(seed) {
+		return OutcomeResumed, nil
+	}
+	logger := c.options.Logger.With(
+		slog.String("distributor", seed.Distributor),
+		slog.String("name", seed.Name),
+		slog.String("version", seed.Version),
+	)
+
+	if c.options.Datastore != nil && seed.Version != "" && !versions.IsConstraint(seed.Distributor, seed.Version) {
+		if _, err := c.options.Datastore.Retrieve(ctx, seed.Name, seed.Version, seed.Distributor); err == nil {
+			return OutcomeCached, p.markDone(seed)
+		}
+	}
+
+	if err := sleepUntil(ctx, *next); err != nil {
+		return "", err
+	}
+	*next = time.Now().Add(interval)
+
+	_, err := c.options.Resolver.GetPackageInfo(ctx, &oslcv1alpha.GetPackageInfoRequest{
+		Distributor: seed.Distributor,
+		Name:        seed.Name,
+		Version:     seed.Version,
+	})
+	if ctx.Err() != nil {
+		return "", ctx.Err()
+	}
+	switch code := status.Code(err); code {
+	case codes.OK:
+		logger.DebugContext(ctx, "resolved seed")
+		return OutcomeResolved, p.markDone(seed)
+	case codes.NotFound, codes.InvalidArgument:
+		// The seed will not resolve on a later attempt either, so it is finished.
+		logger.InfoContext(ctx, "seed cannot be resolved", slog.String("error", err.Error()))
+		return OutcomeFailed, p.markDone(seed)
+	default:
+		logger.WarnContext(ctx, "failed to resolve seed", slog.String("error", err.Error()))
+		return OutcomeFailed, nil
+	}
+}
+
+// sleepUntil waits until t, or until ctx is cancelled.
+func sleepUntil(ctx context.Context, t time.Time) error {
+	d := time.Until(t)
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Compile time check to ensure Crawler implements [prometheus.Collector].
+var _ prometheus.Collector = (*Crawler)(nil)
+
+// Describe implements [prometheus.Collector].
+func (c *Crawler) Describe(ch chan<- *prometheus.Desc) {
+	c.seeds.Describe(ch)
+	c.pending.Describe(ch)
+}
+
+// Collect implements [prometheus.Collector]. It exports the number of seeds crawled since the crawler was created, and
+// the number of seeds pending in the current crawl.
+func (c *Crawler) Collect(ch chan<- prometheus.Metric) {
+	c.seeds.Collect(ch)
+	c.pending.Collect(ch)
+}
@@ -0,0 +1,119 @@
+package crawler
+
+import (
+	"github.com/chainalysis-oss/oslc"
+	"log/slog"
+	"time"
+)
+
+type crawlerOptions struct {
+	Logger *slog.Logger
+	// Resolver resolves the seeds. It is usually an oslc.Server, which saves the packages it fetches to its datastore.
+	Resolver Resolver
+	// Datastore, if set, is checked for seeds with a single version before they are resolved. Seeds that are already
+	// stored are not resolved, and do not count towards the rate limit.
+	Datastore oslc.DatastoreRetriever
+	// Rate is the maximum number of seeds resolved per second for each distributor without an entry in Rates.
+	Rate float64
+	// Rates are the maximum number of seeds resolved per second for specific distributors.
+	Rates map[string]float64
+	// ProgressPath is the path of the JSONL file finished seeds are recorded to. Seeds in the file are not resolved
+	// again, so an interrupted crawl resumes where it stopped. If empty, progress is not recorded.
+	ProgressPath string
+	// SeedPaths are the files Run loads seeds from, with LoadSeeds.
+	SeedPaths []string
+	// Interval is the time between the crawls of Run.
+	Interval time.Duration
+}
+
+var defaultCrawlerOptions = crawlerOptions{
+	Logger:   slog.Default(),
+	Rate:     5,
+	Interval: time.Hour,
+}
+
+var globalCrawlerOptions []CrawlerOption
+
+// CrawlerOption is an option for configuring a Crawler.
+type CrawlerOption interface {
+	apply(*crawlerOptions)
+}
+
+// funcCrawlerOption is a CrawlerOption that calls a function.
+// It is used to wrap a function, so it satisfies the CrawlerOption interface.
+type funcCrawlerOption struct {
+	f func(*crawlerOptions)
+}
+
+func (fco *funcCrawlerOption) apply(opts *crawlerOptions) {
+	fco.f(opts)
+}
+
+func newFuncCrawlerOption(f func(*crawlerOptions)) *funcCrawlerOption {
+	return &funcCrawlerOption{
+		f: f,
+	}
+}
+
+// WithLogger returns a CrawlerOption that uses the provided logger.
+func WithLogger(logger *slog.Logger) CrawlerOption {
+	return newFuncCrawlerOption(func(opts *crawlerOptions) {
+		opts.Logger = logger
+	})
+}
+
+// WithResolver returns a CrawlerOption that resolves seeds with the provided Resolver.
+func WithResolver(r Resolver) CrawlerOption {
+	return newFuncCrawlerOption(func(opts *crawlerOptions) {
+		opts.Resolver = r
+	})
+}
+
+// WithDatastore returns a CrawlerOption that skips seeds that are already stored in the provided datastore.
+func WithDatastore(d oslc.DatastoreRetriever) CrawlerOption {
+	return newFuncCrawlerOption(func(opts *crawlerOptions) {
+		opts.Datastore = d
+	})
+}
+
+// WithRate returns a CrawlerOption that limits the number of seeds resolved per second for each distributor.
+func WithRate(rate float64) CrawlerOption {
+	return newFuncCrawlerOption(func(opts *crawlerOptions) {
+		opts.Rate = rate
+	})
+}
+
+// WithDistributorRate returns a CrawlerOption that limits the number of seeds of the distributor resolved per second,
+// overriding the rate set by WithRate.
+func WithDistributorRate(distributor string, rate float64) CrawlerOption {
+	return newFuncCrawlerOption(func(opts *crawlerOptions) {
+		rates := make(map[string]float64, len(opts.Rates)+1)
+		for d, r := range opts.Rates {
+			rates[d] = r
+		}
+		rates[distributor] = rate
+		opts.Rates = rates
+	})
+}
+
+// WithProgressPath returns a CrawlerOption that records finished seeds to the JSONL file at path, so interrupted
+// crawls can be resumed.
+func WithProgressPath(path string) CrawlerOption {
+	return newFuncCrawlerOption(func(opts *crawlerOptions) {
+		opts.ProgressPath = path
+	})
+}
+
+// WithSeedPaths returns a CrawlerOption that sets the files Run loads seeds from.
+func WithSeedPaths(paths ...string) CrawlerOption {
+	return newFuncCrawlerOption(func(opts *crawlerOptions) {
+		opts.SeedPaths = paths
+	})
+}
+
+// WithInterval returns a CrawlerOption that sets the time between the crawls of Run.
+func WithInterval(interval time.Duration) CrawlerOption {
+	return newFuncCrawlerOption(func(opts *crawlerOptions) {
+		opts.Interval = interval
+	})
+}
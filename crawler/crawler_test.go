@@ -0,0 +1,182 @@
+package crawler
+
+import (
+	oslcv1alpha "buf.build/gen/go/chainalysis-oss/oslc/protocolbuffers/go/chainalysis_oss/oslc/v1alpha"
+	"context"
+	"github.com/chainalysis-oss/oslc"
+	oslcMocks "github.com/chainalysis-oss/oslc/mocks/oslc"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeResolver answers GetPackageInfo requests with the errors in errs, keyed by package name, and records the names
+// of the requested packages.
+type fakeResolver struct {
+	mu        sync.Mutex
+	errs      map[string]error
+	requested []string
+}
+
+func (r *fakeResolver) GetPackageInfo(_ context.Context, request *oslcv1alpha.GetPackageInfoRequest) (*oslcv1alpha.GetPackageInfoResponse, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requested = append(r.requested, request.Name)
+	if err := r.errs[request.Name]; err != nil {
+		return nil, err
+	}
+	return &oslcv1alpha.GetPackageInfoResponse{Name: request.Name, Version: request.Version}, nil
+}
+
+func (r *fakeResolver) requests() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.requested...)
+}
+
+func newTestCrawler(t *testing.T, options ...CrawlerOption) *Crawler {
+	t.Helper()
+	c, err := NewCrawler(append([]CrawlerOption{
+		WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		WithRate(1000),
+	}, options...)...)
+	require.NoError(t, err)
+	return c
+}
+
+func TestNewCrawler_errors(t *testing.T) {
+	resolver := &fakeResolver{}
+	tests := []struct {
+		name    string
+		options []CrawlerOption
+		wantErr error
+	}{
+		{name: "missing resolver", wantErr: ErrMissingOptionResolver},
+		{name: "invalid rate", options: []CrawlerOption{WithResolver(resolver), WithRate(0)}, wantErr: ErrInvalidRate},
+		{name: "invalid distributor rate", options: []CrawlerOption{WithResolver(resolver), WithDistributorRate(oslc.DistributorGo, -1)}, wantErr: ErrInvalidRate},
+		{name: "invalid interval", options: []CrawlerOption{WithResolver(resolver), WithInterval(0)}, wantErr: ErrInvalidInterval},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewCrawler(tt.options...)
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestCrawler_Crawl(t *testing.T) {
+	progressPath := filepath.Join(t.TempDir(), "progress.jsonl")
+	resolver := &fakeResolver{errs: map[string]error{
+		"missing":     status.Error(codes.NotFound, "package not found"),
+		"unavailable": status.Error(codes.Unavailable, "distributor unavailable"),
+	}}
+	datastore := oslcMocks.NewMockDatastore(t)
+	datastore.EXPECT().Retrieve(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, name, version, distributor string) (oslc.Entry, error) {
+			if name == "stored" {
+				return oslc.Entry{Name: name, Version: version}, nil
+			}
+			return oslc.Entry{}, oslc.ErrDatastoreObjectNotFound
+		})
+	c := newTestCrawler(t, WithResolver(resolver), WithDatastore(datastore), WithProgressPath(progressPath))
+	seeds := []Seed{
+		{Distributor: oslc.DistributorNpm, Name: "stored", Version: "1.0.0"},
+		{Distributor: oslc.DistributorNpm, Name: "new", Version: "1.0.0"},
+		{Distributor: oslc.DistributorNpm, Name: "constraint", Version: "^1.0.0"},
+		{Distributor: oslc.DistributorPypi, Name: "missing", Version: "1.0.0"},
+		{Distributor: oslc.DistributorPypi, Name: "unavailable", Version: "1.0.0"},
+	}
+
+	result, err := c.Crawl(context.Background(), seeds)
+	require.NoError(t, err)
+	require.Equal(t, Result{Resolved: 2, Cached: 1, Failed: 2}, result)
+	require.ElementsMatch(t, []string{"new", "constraint", "missing", "unavailable"}, resolver.requests())
+	require.Equal(t, 2.0, testutil.ToFloat64(c.seeds.WithLabelValues(oslc.DistributorNpm, OutcomeResolved)))
+	require.Equal(t, 1.0, testutil.ToFloat64(c.seeds.WithLabelValues(oslc.DistributorNpm, OutcomeCached)))
+	require.Equal(t, 2.0, testutil.ToFloat64(c.seeds.WithLabelValues(oslc.DistributorPypi, OutcomeFailed)))
+	require.Equal(t, 0.0, testutil.ToFloat64(c.pending.WithLabelValues(oslc.DistributorPypi)))
+
+	// The second crawl resumes the first, so only the seed that failed temporarily is resolved again.
+	resolver.requested = nil
+	result, err = c.Crawl(context.Background(), seeds)
+	require.NoError(t, err)
+	require.Equal(t, Result{Resumed: 4, Failed: 1}, result)
+	require.Equal(t, []string{"unavailable"}, resolver.requests())
+}
+
+func TestCrawler_Crawl_rate(t *testing.T) {
+	resolver := &fakeResolver{}
+	c := newTestCrawler(t, WithResolver(resolver), WithDistributorRate(oslc.DistributorNpm, 20))
+	seeds := []Seed{
+		{Distributor: oslc.DistributorNpm, Name: "a"},
+		{Distributor: oslc.DistributorNpm, Name: "b"},
+		{Distributor: oslc.DistributorNpm, Name: "c"},
+		{Distributor: oslc.DistributorPypi, Name: "d"},
+	}
+
+	start := time.Now()
+	result, err := c.Crawl(context.Background(), seeds)
+	require.NoError(t, err)
+	require.Equal(t, 4, result.Resolved)
+	// Three npm seeds at 20 per second take at least two intervals of 50ms.
+	require.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+}
+
+func TestCrawler_SetRate(t *testing.T) {
+	c := newTestCrawler(t, WithResolver(&fakeResolver{}), WithDistributorRate(oslc.DistributorNpm, 20))
+	require.Equal(t, time.Millisecond, c.interval(oslc.DistributorPypi))
+
+	require.NoError(t, c.SetRate(4))
+	require.Equal(t, 250*time.Millisecond, c.interval(oslc.DistributorPypi))
+	// Distributors with a rate of their own keep it.
+	require.Equal(t, 50*time.Millisecond, c.interval(oslc.DistributorNpm))
+
+	require.ErrorIs(t, c.SetRate(0), ErrInvalidRate)
+	require.Equal(t, 250*time.Millisecond, c.interval(oslc.DistributorPypi))
+}
+
+func TestCrawler_Crawl_cancelled(t *testing.T) {
+	c := newTestCrawler(t, WithResolver(&fakeResolver{}), WithRate(1))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := c.Crawl(ctx, []Seed{{Distributor: oslc.DistributorNpm, Name: "a"}, {Distributor: oslc.DistributorNpm, Name: "b"}})
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestCrawler_Crawl_invalidProgressFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "progress.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte("not json\n"), 0o644))
+	c := newTestCrawler(t, WithResolver(&fakeResolver{}), WithProgressPath(path))
+	_, err := c.Crawl(context.Background(), []Seed{{Distributor: oslc.DistributorNpm, Name: "a"}})
+	require.ErrorContains(t, err, "reading progress file")
+}
+
+func TestCrawler_Run(t *testing.T) {
+	seedPath := filepath.Join(t.TempDir(), "requests.jsonl")
+	require.NoError(t, os.WriteFile(seedPath, []byte(`{"distributor":"npm","name":"a"}`+"\n"), 0o644))
+	resolver := &fakeResolver{}
+	c := newTestCrawler(t, WithResolver(resolver), WithSeedPaths(seedPath), WithInterval(10*time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- c.Run(ctx) }()
+	require.Eventually(t, func() bool { return len(resolver.requests()) >= 2 }, time.Second, 5*time.Millisecond)
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestCrawler_Collect(t *testing.T) {
+	c := newTestCrawler(t, WithResolver(&fakeResolver{}))
+	_, err := c.Crawl(context.Background(), []Seed{{Distributor: oslc.DistributorNpm, Name: "a"}})
+	require.NoError(t, err)
+	require.Equal(t, 2, testutil.CollectAndCount(c))
+}
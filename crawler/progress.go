@@ -0,0 +1,81 @@
+package crawler
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// progress records the seeds a crawl has finished in a JSONL file, so an interrupted crawl can be resumed. A nil
+// progress records nothing.
+type progress struct {
+	mu   sync.Mutex
+	file *os.File
+	done map[Seed]struct{}
+}
+
+// openProgress opens the progress file at path, creating it if it does not exist, and loads the seeds it lists.
+func openProgress(path string) (*progress, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("opening progress file: %w", err)
+	}
+	p := &progress{file: f, done: make(map[Seed]struct{})}
+	decoder := json.NewDecoder(bufio.NewReader(f))
+	for line := 1; ; line++ {
+		var seed Seed
+		err := decoder.Decode(&seed)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			f.Close()
+			return nil, fmt.Errorf("reading progress file %s: record %d: %w", path, line, err)
+		}
+		p.done[seed] = struct{}{}
+	}
+	return p, nil
+}
+
+// isDone reports whether the seed has been finished by this or an earlier crawl.
+func (p *progress) isDone(seed Seed) bool {
+	if p == nil {
+		return false
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, ok := p.done[seed]
+	return ok
+}
+
+// markDone records that the seed has been finished.
+func (p *progress) markDone(seed Seed) error {
+	if p == nil {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.done[seed]; ok {
+		return nil
+	}
+	b, err := json.Marshal(seed)
+	if err != nil {
+		return err
+	}
+	if _, err := p.file.Write(append(b, '\n')); err != nil {
+		return fmt.Errorf("writing progress file: %w", err)
+	}
+	p.done[seed] = struct{}{}
+	return nil
+}
+
+func (p *progress) close() error {
+	if p == nil {
+		return nil
+	}
+	return p.file.Close()
+}
@@ -0,0 +1,46 @@
+package crawler
+
+import (
+	"github.com/chainalysis-oss/oslc"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProgress(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "progress.jsonl")
+	seed := Seed{Distributor: oslc.DistributorNpm, Name: "a", Version: "1.0.0"}
+
+	p, err := openProgress(path)
+	require.NoError(t, err)
+	require.False(t, p.isDone(seed))
+	require.NoError(t, p.markDone(seed))
+	require.NoError(t, p.markDone(seed))
+	require.True(t, p.isDone(seed))
+	require.NoError(t, p.close())
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, `{"distributor":"npm","name":"a","version":"1.0.0"}`+"\n", string(content))
+
+	p, err = openProgress(path)
+	require.NoError(t, err)
+	defer p.close()
+	require.True(t, p.isDone(seed))
+}
+
+func TestProgress_nil(t *testing.T) {
+	var p *progress
+	seed := Seed{Distributor: oslc.DistributorNpm, Name: "a"}
+	require.NoError(t, p.markDone(seed))
+	require.False(t, p.isDone(seed))
+	require.NoError(t, p.close())
+}
+
+func TestOpenProgress_invalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "progress.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte("not json\n"), 0o644))
+	_, err := openProgress(path)
+	require.ErrorContains(t, err, "record 1")
+}
@@ -0,0 +1,96 @@
+package crawler
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/chainalysis-oss/oslc/lockfile"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Seed is a package for the crawler to resolve. Version may be a version constraint, a dist-tag, or empty for the
+// latest version, as in a GetPackageInfo request.
+type Seed struct {
+	Distributor string `json:"distributor"`
+	Name        string `json:"name"`
+	Version     string `json:"version,omitempty"`
+}
+
+// ErrIncompleteSeed is returned by [ReadSeeds] for request log lines without a distributor or name.
+var ErrIncompleteSeed = errors.New("seed must have a distributor and a name")
+
+// ReadSeeds returns the seeds in the file with the provided name and contents. Files with the .jsonl extension are read
+// as request logs, with one JSON object per line that has the distributor, name and version of a requested package.
+// Other fields of the objects are ignored, so the miss queue of an offline server is a request log as well. All other
+// files are read as lockfiles or SBOMs by [lockfile.Parse].
+func ReadSeeds(name string, data []byte) ([]Seed, error) {
+	if strings.EqualFold(filepath.Ext(name), ".jsonl") {
+		seeds, err := readRequestLog(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", filepath.Base(name), err)
+		}
+		return seeds, nil
+	}
+	packages, err := lockfile.Parse(name, data)
+	if err != nil {
+		return nil, err
+	}
+	seeds := make([]Seed, len(packages))
+	for i, p := range packages {
+		seeds[i] = Seed{Distributor: p.Distributor, Name: p.Name, Version: p.Version}
+	}
+	return seeds, nil
+}
+
+func readRequestLog(data []byte) ([]Seed, error) {
+	seeds := make([]Seed, 0)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		b := scanner.Bytes()
+		if len(bytes.TrimSpace(b)) == 0 {
+			continue
+		}
+		var seed Seed
+		if err := json.Unmarshal(b, &seed); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if seed.Distributor == "" || seed.Name == "" {
+			return nil, fmt.Errorf("line %d: %w", line, ErrIncompleteSeed)
+		}
+		seeds = append(seeds, seed)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return seeds, nil
+}
+
+// LoadSeeds reads the seeds of every file at paths with [ReadSeeds]. Duplicate seeds are returned once, in the order
+// they were first read.
+func LoadSeeds(paths []string) ([]Seed, error) {
+	seeds := make([]Seed, 0)
+	seen := make(map[Seed]struct{})
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		found, err := ReadSeeds(path, data)
+		if err != nil {
+			return nil, err
+		}
+		for _, seed := range found {
+			if _, ok := seen[seed]; ok {
+				continue
+			}
+			seen[seed] = struct{}{}
+			seeds = append(seeds, seed)
+		}
+	}
+	return seeds, nil
+}
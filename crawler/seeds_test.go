@@ -0,0 +1,86 @@
+package crawler
+
+import (
+	"github.com/chainalysis-oss/oslc"
+	"github.com/chainalysis-oss/oslc/lockfile"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadSeeds(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		data string
+		want []Seed
+	}{
+		{
+			name: "request log",
+			file: "requests.jsonl",
+			data: `{"distributor":"npm","name":"a","version":"^1.0.0","requested_at":"2024-01-01T00:00:00Z"}
+
+{"distributor":"pypi","name":"b"}
+`,
+			want: []Seed{{oslc.DistributorNpm, "a", "^1.0.0"}, {oslc.DistributorPypi, "b", ""}},
+		},
+		{
+			name: "lockfile",
+			file: "go.sum",
+			data: "example.com/a v1.0.0 h1:abc=\n",
+			want: []Seed{{oslc.DistributorGo, "example.com/a", "v1.0.0"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seeds, err := ReadSeeds(tt.file, []byte(tt.data))
+			require.NoError(t, err)
+			require.Equal(t, tt.want, seeds)
+		})
+	}
+}
+
+func TestReadSeeds_errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		data    string
+		wantErr error
+	}{
+		{name: "invalid json", file: "requests.jsonl", data: "{\n"},
+		{name: "missing name", file: "requests.jsonl", data: `{"distributor":"npm"}`, wantErr: ErrIncompleteSeed},
+		{name: "missing distributor", file: "requests.jsonl", data: `{"name":"a"}`, wantErr: ErrIncompleteSeed},
+		{name: "unsupported format", file: "Gemfile.lock", data: "", wantErr: lockfile.ErrUnsupportedFormat},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadSeeds(tt.file, []byte(tt.data))
+			require.Error(t, err)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadSeeds(t *testing.T) {
+	dir := t.TempDir()
+	log := filepath.Join(dir, "requests.jsonl")
+	require.NoError(t, os.WriteFile(log, []byte(`{"distributor":"go","name":"example.com/b","version":"v2.0.0"}
+{"distributor":"go","name":"example.com/a","version":"v1.0.0"}
+`), 0o644))
+	sum := filepath.Join(dir, "go.sum")
+	require.NoError(t, os.WriteFile(sum, []byte("example.com/a v1.0.0 h1:abc=\nexample.com/c v3.0.0 h1:abc=\n"), 0o644))
+
+	seeds, err := LoadSeeds([]string{log, sum})
+	require.NoError(t, err)
+	require.Equal(t, []Seed{
+		{oslc.DistributorGo, "example.com/b", "v2.0.0"},
+		{oslc.DistributorGo, "example.com/a", "v1.0.0"},
+		{oslc.DistributorGo, "example.com/c", "v3.0.0"},
+	}, seeds)
+
+	_, err = LoadSeeds([]string{filepath.Join(dir, "missing.jsonl")})
+	require.ErrorContains(t, err, "failed to read")
+}
@@ -2,6 +2,7 @@ package goproxy
 
 import (
 	"archive/zip"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -15,6 +16,7 @@ import (
 	"os"
 	"path"
 	"strconv"
+	"strings"
 )
 
 func init() {
@@ -27,18 +29,18 @@ type Client struct {
 	options *clientOptions
 }
 
-func (c *Client) GetPackage(name string) (oslc.Entry, error) {
-	return c.GetPackageVersion(name, "")
+func (c *Client) GetPackage(ctx context.Context, name string) (oslc.Entry, error) {
+	return c.GetPackageVersion(ctx, name, "")
 }
 
-func (c *Client) GetPackageVersion(name, version string) (oslc.Entry, error) {
-	vi, err := c.getInfo(name, version)
+func (c *Client) GetPackageVersion(ctx context.Context, name, version string) (oslc.Entry, error) {
+	vi, err := c.getInfo(ctx, name, version)
 	if err != nil {
-		return oslc.Entry{}, oslc.DistributorError{Distributor: oslc.DistributorGo, Err: err}
+		return oslc.Entry{}, oslc.WrapDistributorError(oslc.DistributorGo, err)
 	}
-	license, err := c.getLicense(name, vi.Version)
+	license, err := c.getLicense(ctx, name, vi.Version)
 	if err != nil {
-		return oslc.Entry{}, oslc.DistributorError{Distributor: oslc.DistributorGo, Err: err}
+		return oslc.Entry{}, oslc.WrapDistributorError(oslc.DistributorGo, err)
 	}
 	return oslc.Entry{
 		Name:    name,
@@ -54,13 +56,13 @@ func (c *Client) GetPackageVersion(name, version string) (oslc.Entry, error) {
 	}, nil
 }
 
-func (c *Client) getLicense(name, version string) (string, error) {
+func (c *Client) getLicense(ctx context.Context, name, version string) (string, error) {
 	if version == "" {
 		return "", fmt.Errorf("version is empty")
 	}
-	resp, err := c.options.HttpClient.Query(c.options.BaseURL + "/" + name + "/@v/" + version + ".zip")
+	resp, err := c.options.HttpClient.Query(ctx, c.options.BaseURL+"/"+name+"/@v/"+version+".zip")
 	if err != nil {
-		return "", err
+		return "", oslc.NewTransportError(oslc.DistributorGo, err)
 	}
 	defer resp.Body.Close()
 	err = os.MkdirAll(c.options.TempDir, os.ModePerm)
@@ -137,10 +139,10 @@ type versionInfo struct {
 	Time    string
 }
 
-func (c *Client) moduleExists(name string) (bool, error) {
-	resp, err := c.options.HttpClient.Query(c.options.BaseURL + "/" + name + "/@latest")
+func (c *Client) moduleExists(ctx context.Context, name string) (bool, error) {
+	resp, err := c.options.HttpClient.Query(ctx, c.options.BaseURL+"/"+name+"/@latest")
 	if err != nil {
-		return false, fmt.Errorf("constructing HTTP query for upstream '%s': %s", c.options.BaseURL, err)
+		return false, oslc.NewTransportError(oslc.DistributorGo, fmt.Errorf("constructing HTTP query for upstream '%s': %w", c.options.BaseURL, err))
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode == http.StatusOK {
@@ -149,24 +151,47 @@ func (c *Client) moduleExists(name string) (bool, error) {
 	if resp.StatusCode == http.StatusNotFound {
 		return false, nil
 	}
-	return false, fmt.Errorf("unexpected status code determining if module exists: %d", resp.StatusCode)
+	return false, oslc.NewStatusError(oslc.DistributorGo, resp, fmt.Errorf("unexpected status code determining if module exists: %d", resp.StatusCode))
+}
+
+// ListVersions returns the versions of the module with the given name, as listed by the @v/list endpoint of the proxy.
+// Modules that were never tagged have no listed versions, so the list may be empty for existing modules.
+func (c *Client) ListVersions(ctx context.Context, name string) ([]string, error) {
+	resp, err := c.options.HttpClient.Query(ctx, c.options.BaseURL+"/"+name+"/@v/list")
+	if err != nil {
+		return nil, oslc.NewTransportError(oslc.DistributorGo, fmt.Errorf("constructing HTTP query for upstream '%s': %w", c.options.BaseURL, err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, oslc.DistributorError{Distributor: oslc.DistributorGo, Err: fmt.Errorf("%w: %s", oslc.ErrNoSuchPackage, name)}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, oslc.NewStatusError(oslc.DistributorGo, resp, fmt.Errorf("non-200 status code listing versions of module '%s' from upstream '%s': %d", name, c.options.BaseURL, resp.StatusCode))
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, oslc.DistributorError{Distributor: oslc.DistributorGo, Err: err}
+	}
+	return strings.Fields(string(body)), nil
 }
 
 // getInfo returns information about the version of the package.
-func (c *Client) getInfo(name, version string) (versionInfo, error) {
+func (c *Client) getInfo(ctx context.Context, name, version string) (versionInfo, error) {
 	var err error
 	var resp *http.Response
 	if version == "" {
-		resp, err = c.options.HttpClient.Query(c.options.BaseURL + "/" + name + "/@latest")
+		resp, err = c.options.HttpClient.Query(ctx, c.options.BaseURL+"/"+name+"/@latest")
 	} else {
-		resp, err = c.options.HttpClient.Query(c.options.BaseURL + "/" + name + "/@v/" + version + ".info")
+		resp, err = c.options.HttpClient.Query(ctx, c.options.BaseURL+"/"+name+"/@v/"+version+".info")
 	}
 	if err != nil {
-		return versionInfo{}, fmt.Errorf("constructing HTTP query for upstream '%s': %s", c.options.BaseURL, err)
+		return versionInfo{}, oslc.NewTransportError(oslc.DistributorGo, fmt.Errorf("constructing HTTP query for upstream '%s': %w", c.options.BaseURL, err))
 	}
 	if resp.StatusCode == http.StatusNotFound {
 		var ok bool
-		ok, err = c.moduleExists(name)
+		ok, err = c.moduleExists(ctx, name)
 		if err != nil {
 			return versionInfo{}, err
 		}
@@ -179,7 +204,7 @@ func (c *Client) getInfo(name, version string) (versionInfo, error) {
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(io.LimitReader(resp.Body, 400)) // actual number pulled out of thin air
 		defer resp.Body.Close()
-		return versionInfo{}, oslc.DistributorError{Distributor: oslc.DistributorGo, Err: fmt.Errorf("non-200 status code getting version '%s' of module '%s' from upstream '%s': %d - Body (base64): %s", version, name, c.options.BaseURL, resp.StatusCode, base64.StdEncoding.EncodeToString(body))}
+		return versionInfo{}, oslc.NewStatusError(oslc.DistributorGo, resp, fmt.Errorf("non-200 status code getting version '%s' of module '%s' from upstream '%s': %d - Body (base64): %s", version, name, c.options.BaseURL, resp.StatusCode, base64.StdEncoding.EncodeToString(body)))
 	}
 	defer resp.Body.Close()
 	vi := versionInfo{}
@@ -49,6 +49,14 @@ func WithHTTPClient(c *http.Client) ClientOption {
 	})
 }
 
+// WithBaseURL returns a ClientOption that sends requests to the provided base URL instead of the public registry, such
+// as a mirror. The URL must not end with a slash.
+func WithBaseURL(url string) ClientOption {
+	return newFuncClientOption(func(opts *clientOptions) {
+		opts.BaseURL = url
+	})
+}
+
 // WithLogger returns a ClientOption that uses the provided logger.
 func WithLogger(logger *slog.Logger) ClientOption {
 	return newFuncClientOption(func(opts *clientOptions) {
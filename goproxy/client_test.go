@@ -2,6 +2,7 @@ package goproxy
 
 import (
 	"bytes"
+	"context"
 	"github.com/chainalysis-oss/oslc"
 	ownHTTP "github.com/chainalysis-oss/oslc/http"
 	"github.com/chainalysis-oss/oslc/httptestcorpus"
@@ -34,6 +35,11 @@ func TestClient(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, customLogger, client.options.Logger)
 	})
+	t.Run("Get new client with base URL", func(t *testing.T) {
+		client, err := NewClient(WithBaseURL("https://athens.example.com"))
+		require.NoError(t, err)
+		require.Equal(t, "https://athens.example.com", client.options.BaseURL)
+	})
 	t.Run("Get new client with custom HTTP client", func(t *testing.T) {
 		t.Skip("Not implemented")
 	})
@@ -77,7 +83,7 @@ func TestGetInfo_for_specific_version(t *testing.T) {
 	}
 	for _, tc := range testcases {
 		t.Run(tc.version, func(t *testing.T) {
-			resp, err := client.getInfo("github.com/chainalysis-oss/oslc", tc.version)
+			resp, err := client.getInfo(context.Background(), "github.com/chainalysis-oss/oslc", tc.version)
 			require.NoError(t, err)
 			require.Equal(t, tc.expected, resp)
 		})
@@ -105,7 +111,7 @@ func TestGetInfo_for_latest(t *testing.T) {
 	}
 	for _, tc := range testcases {
 		t.Run(tc.module, func(t *testing.T) {
-			resp, err := client.getInfo(tc.module, "")
+			resp, err := client.getInfo(context.Background(), tc.module, "")
 			require.NoError(t, err)
 			require.Equal(t, tc.expected, resp)
 		})
@@ -125,7 +131,7 @@ func TestGetInfo_for_bad_module(t *testing.T) {
 	}
 	for _, tc := range testcases {
 		t.Run(tc.module, func(t *testing.T) {
-			resp, err := client.getInfo(tc.module, "")
+			resp, err := client.getInfo(context.Background(), tc.module, "")
 			require.ErrorIs(t, err, oslc.ErrNoSuchPackage)
 			require.Empty(t, resp)
 		})
@@ -143,7 +149,7 @@ func TestGetInfo_for_bad_version(t *testing.T) {
 	}
 	for _, tc := range testcases {
 		t.Run(tc.version, func(t *testing.T) {
-			resp, err := client.getInfo("github.com/chainalysis-oss/oslc", tc.version)
+			resp, err := client.getInfo(context.Background(), "github.com/chainalysis-oss/oslc", tc.version)
 			require.ErrorIs(t, err, oslc.ErrVersionNotFound)
 			require.Empty(t, resp)
 		})
@@ -179,7 +185,7 @@ func TestGetInfo_upstream_error(t *testing.T) {
 			require.NoError(t, err)
 			require.NotNil(t, client)
 
-			resp, err := client.getInfo("thisdoesnotmatter", "alsodoesnotmatter")
+			resp, err := client.getInfo(context.Background(), "thisdoesnotmatter", "alsodoesnotmatter")
 			require.Empty(t, resp)
 			require.ErrorAs(t, err, tc.expectedError)
 		})
@@ -238,7 +244,7 @@ func TestClient_GetPackageVersion(t *testing.T) {
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			resp, err := client.GetPackageVersion(tc.module, tc.version)
+			resp, err := client.GetPackageVersion(context.Background(), tc.module, tc.version)
 			require.NoError(t, err)
 			require.Equal(t, tc.expected, resp)
 		})
@@ -274,9 +280,80 @@ func TestClient_GetPackage(t *testing.T) {
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			resp, err := client.GetPackage(tc.module)
+			resp, err := client.GetPackage(context.Background(), tc.module)
 			require.NoError(t, err)
 			require.Equal(t, tc.expected, resp)
 		})
 	}
 }
+
+func TestClient_ListVersions(t *testing.T) {
+	testcases := []struct {
+		name     string
+		status   int
+		body     string
+		expected []string
+		err      error
+	}{
+		{
+			name:     "versions",
+			status:   http.StatusOK,
+			body:     "1.0.0\n1.1.0\n2.0.0\n",
+			expected: []string{"1.0.0", "1.1.0", "2.0.0"},
+		},
+		{
+			name:     "no versions",
+			status:   http.StatusOK,
+			body:     "",
+			expected: []string{},
+		},
+		{
+			name:   "not found",
+			status: http.StatusNotFound,
+			err:    oslc.ErrNoSuchPackage,
+		},
+		{
+			name:   "unexpected status",
+			status: http.StatusInternalServerError,
+			err:    oslc.DistributorError{},
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.name, func(t *testing.T) {
+			c := func() *Client {
+				c, err := NewClient(WithHTTPClient(setupHttpClientWithStatusAndBody(t, tt.status, tt.body)))
+				require.NoError(t, err)
+				return c
+			}()
+			out, err := c.ListVersions(context.Background(), "test")
+			if tt.err != nil {
+				require.ErrorAs(t, err, &oslc.DistributorError{})
+				if tt.err != (oslc.DistributorError{}) {
+					require.ErrorIs(t, err, tt.err)
+				}
+				return
+			}
+			require.NoError(t, err)
+			require.ElementsMatch(t, tt.expected, out)
+		})
+	}
+}
+
+func TestClient_ListVersions_path(t *testing.T) {
+	mock := ownHTTP.NewTestHTTPClient(func(req *http.Request) (*http.Response, error) {
+		require.Equal(t, "https://proxy.golang.org/test/@v/list", req.URL.String())
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBuffer([]byte(""))),
+		}, nil
+	})
+	httpClient, err := ownHTTP.NewClient(ownHTTP.WithHTTPClient(mock))
+	require.NoError(t, err)
+	c := func() *Client {
+		c, err := NewClient(WithHTTPClient(httpClient))
+		require.NoError(t, err)
+		return c
+	}()
+	_, err = c.ListVersions(context.Background(), "test")
+	require.NoError(t, err)
+}
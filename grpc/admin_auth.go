@@ -0,0 +1,81 @@
+package grpc
+
+import (
+	"buf.build/gen/go/chainalysis-oss/oslc/grpc/go/chainalysis_oss/oslc/v1alpha/oslcv1alphagrpc"
+	"context"
+	"crypto/subtle"
+	"errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"strings"
+)
+
+// adminServiceName is the full name of the OslcAdminService, as used in the full method name of its methods.
+var adminServiceName = oslcv1alphagrpc.OslcAdminService_ServiceDesc.ServiceName
+
+// ErrMissingAdminToken is returned by [NewServer] when the admin service is enabled without an admin token.
+var ErrMissingAdminToken = errors.New("missing option: admin token is required when the admin service is enabled")
+
+// isAdminMethod reports whether fullMethod is a method of the OslcAdminService.
+func isAdminMethod(fullMethod string) bool {
+	service, _ := splitFullMethod(fullMethod)
+	return service == adminServiceName
+}
+
+// adminTokenFromContext returns the bearer token from the authorization metadata of the incoming request, or an empty
+// string if there is none.
+func adminTokenFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return ""
+	}
+	scheme, token, ok := strings.Cut(values[0], " ")
+	if !ok || !strings.EqualFold(scheme, "bearer") {
+		return ""
+	}
+	return token
+}
+
+// authorizeAdmin returns an error status unless the call to fullMethod is either not a call to the OslcAdminService, or
+// carries the provided token as a bearer token in the authorization metadata.
+func authorizeAdmin(ctx context.Context, fullMethod string, token string) error {
+	if !isAdminMethod(fullMethod) {
+		return nil
+	}
+	provided := adminTokenFromContext(ctx)
+	if provided == "" {
+		return status.Error(codes.Unauthenticated, "missing admin token")
+	}
+	if subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+		return status.Error(codes.PermissionDenied, "invalid admin token")
+	}
+	return nil
+}
+
+// newAdminAuthUnaryServerInterceptor returns an interceptor that requires every call to the OslcAdminService to carry
+// the provided token as a bearer token in the authorization metadata. Calls to other services are passed through
+// unchanged, so the public OslcService remains unauthenticated.
+func newAdminAuthUnaryServerInterceptor(token string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := authorizeAdmin(ctx, info.FullMethod, token); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// newAdminAuthStreamServerInterceptor is the stream counterpart of [newAdminAuthUnaryServerInterceptor].
+func newAdminAuthStreamServerInterceptor(token string) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := authorizeAdmin(ss.Context(), info.FullMethod, token); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
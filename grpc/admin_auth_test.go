@@ -0,0 +1,100 @@
+package grpc
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+func TestIsAdminMethod(t *testing.T) {
+	require.True(t, isAdminMethod("/chainalysis_oss.oslc.v1alpha.OslcAdminService/RefreshPackage"))
+	require.False(t, isAdminMethod("/chainalysis_oss.oslc.v1alpha.OslcService/GetPackageInfo"))
+	require.False(t, isAdminMethod("/grpc.health.v1.Health/Check"))
+}
+
+func TestAdminTokenFromContext(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{"no metadata", context.Background(), ""},
+		{"no authorization", metadata.NewIncomingContext(context.Background(), metadata.Pairs("foo", "bar")), ""},
+		{"bearer", metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer secret")), "secret"},
+		{"lowercase scheme", metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "bearer secret")), "secret"},
+		{"other scheme", metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Basic secret")), ""},
+		{"no scheme", metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "secret")), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, adminTokenFromContext(tt.ctx))
+		})
+	}
+}
+
+func TestNewAdminAuthUnaryServerInterceptor(t *testing.T) {
+	interceptor := newAdminAuthUnaryServerInterceptor("secret")
+	handler := func(ctx context.Context, req any) (any, error) {
+		return "ok", nil
+	}
+	adminInfo := &grpc.UnaryServerInfo{FullMethod: "/chainalysis_oss.oslc.v1alpha.OslcAdminService/RefreshPackage"}
+	withToken := func(token string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+	}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		info *grpc.UnaryServerInfo
+		want codes.Code
+	}{
+		{"public method without token", context.Background(), &grpc.UnaryServerInfo{FullMethod: "/chainalysis_oss.oslc.v1alpha.OslcService/GetPackageInfo"}, codes.OK},
+		{"admin method without token", context.Background(), adminInfo, codes.Unauthenticated},
+		{"admin method with wrong token", withToken("wrong"), adminInfo, codes.PermissionDenied},
+		{"admin method with token", withToken("secret"), adminInfo, codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := interceptor(tt.ctx, nil, tt.info, handler)
+			require.Equal(t, tt.want, status.Code(err))
+			if tt.want == codes.OK {
+				require.Equal(t, "ok", resp)
+			}
+		})
+	}
+}
+
+func TestNewAdminAuthStreamServerInterceptor(t *testing.T) {
+	interceptor := newAdminAuthStreamServerInterceptor("secret")
+	adminInfo := &grpc.StreamServerInfo{FullMethod: "/chainalysis_oss.oslc.v1alpha.OslcAdminService/Watch"}
+	withToken := func(token string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+	}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		info *grpc.StreamServerInfo
+		want codes.Code
+	}{
+		{"public method without token", context.Background(), &grpc.StreamServerInfo{FullMethod: "/chainalysis_oss.oslc.v1alpha.OslcService/WatchPackages"}, codes.OK},
+		{"admin method without token", context.Background(), adminInfo, codes.Unauthenticated},
+		{"admin method with wrong token", withToken("wrong"), adminInfo, codes.PermissionDenied},
+		{"admin method with token", withToken("secret"), adminInfo, codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			err := interceptor(nil, testServerStream{ctx: tt.ctx}, tt.info, func(srv any, stream grpc.ServerStream) error {
+				called = true
+				return nil
+			})
+			require.Equal(t, tt.want, status.Code(err))
+			require.Equal(t, tt.want == codes.OK, called)
+		})
+	}
+}
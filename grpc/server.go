@@ -3,6 +3,7 @@ package grpc
 import (
 	"buf.build/gen/go/chainalysis-oss/oslc/grpc/go/chainalysis_oss/oslc/v1alpha/oslcv1alphagrpc"
 	"context"
+	"crypto/tls"
 	"fmt"
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/recovery"
@@ -30,6 +31,8 @@ type grpcServer interface {
 type Server struct {
 	options    *serverOptions
 	gprcServer grpcServer
+	// certificates serves the TLS certificate, or is nil if TLS is disabled.
+	certificates *certificateReloader
 }
 
 func NewServer(options ...ServerOption) (*Server, error) {
@@ -41,33 +44,61 @@ func NewServer(options ...ServerOption) (*Server, error) {
 		opt.apply(&opts)
 	}
 
+	if opts.adminServer != nil && opts.AdminToken == "" {
+		return nil, ErrMissingAdminToken
+	}
+
+	recoveryHandler := recovery.WithRecoveryHandler(newGrpcRecoveryHandler(opts.Logger, opts.PanicsTotalCounter))
 	unaryInterceptors := make([]grpc.UnaryServerInterceptor, 0)
+	streamInterceptors := make([]grpc.StreamServerInterceptor, 0)
+	// The tracing interceptor must run first, so the remaining interceptors log and measure within the request's span.
+	unaryInterceptors = append(unaryInterceptors, newTracingUnaryServerInterceptor(opts.TracerProvider, opts.Propagator))
+	streamInterceptors = append(streamInterceptors, newTracingStreamServerInterceptor(opts.TracerProvider, opts.Propagator))
 	if opts.Metrics != nil {
 		unaryInterceptors = append(unaryInterceptors, opts.Metrics.UnaryServerInterceptor())
+		streamInterceptors = append(streamInterceptors, opts.Metrics.StreamServerInterceptor())
 	}
 	unaryInterceptors = append(unaryInterceptors, logging.UnaryServerInterceptor(interceptorLogger(opts.Logger)))
+	streamInterceptors = append(streamInterceptors, logging.StreamServerInterceptor(interceptorLogger(opts.Logger)))
+	if opts.adminServer != nil {
+		unaryInterceptors = append(unaryInterceptors, newAdminAuthUnaryServerInterceptor(opts.AdminToken))
+		streamInterceptors = append(streamInterceptors, newAdminAuthStreamServerInterceptor(opts.AdminToken))
+	}
+	if opts.TenantResolver != nil {
+		unaryInterceptors = append(unaryInterceptors, newTenantUnaryServerInterceptor(opts.TenantResolver))
+		streamInterceptors = append(streamInterceptors, newTenantStreamServerInterceptor(opts.TenantResolver))
+	}
 	unaryInterceptors = append(unaryInterceptors, newGrpcErrorHandler(opts.Logger))
-	unaryInterceptors = append(unaryInterceptors, recovery.UnaryServerInterceptor(recovery.WithRecoveryHandler(newGrpcRecoveryHandler(opts.Logger, opts.PanicsTotalCounter))))
+	streamInterceptors = append(streamInterceptors, newGrpcStreamErrorHandler(opts.Logger))
+	unaryInterceptors = append(unaryInterceptors, recovery.UnaryServerInterceptor(recoveryHandler))
+	streamInterceptors = append(streamInterceptors, recovery.StreamServerInterceptor(recoveryHandler))
 
 	grpcOpts := make([]grpc.ServerOption, 0)
 	grpcOpts = append(grpcOpts, grpc.ChainUnaryInterceptor(unaryInterceptors...))
+	grpcOpts = append(grpcOpts, grpc.ChainStreamInterceptor(streamInterceptors...))
 
+	var certificates *certificateReloader
 	if opts.CertFile != "" || opts.KeyFile != "" {
-		creds, err := credentials.NewServerTLSFromFile(opts.CertFile, opts.KeyFile)
+		var err error
+		certificates, err = newCertificateReloader(opts.CertFile, opts.KeyFile, opts.Logger)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create TLS credentials: %w", err)
 		}
-		grpcOpts = append(grpcOpts, grpc.Creds(creds))
+		grpcOpts = append(grpcOpts, grpc.Creds(credentials.NewTLS(&tls.Config{GetCertificate: certificates.GetCertificate})))
 	}
 
 	s := &Server{
-		options:    &opts,
-		gprcServer: grpc.NewServer(grpcOpts...),
+		options:      &opts,
+		gprcServer:   grpc.NewServer(grpcOpts...),
+		certificates: certificates,
 	}
 
 	healthcheck := health.NewServer()
 	healthgrpc.RegisterHealthServer(s.gprcServer, healthcheck)
 	oslcv1alphagrpc.RegisterOslcServiceServer(s.gprcServer, opts.oslcv1alphagrpc)
+	if opts.adminServer != nil {
+		oslcv1alphagrpc.RegisterOslcAdminServiceServer(s.gprcServer, opts.adminServer)
+	}
 	reflection.Register(s.gprcServer)
 	if opts.Metrics != nil {
 		opts.Metrics.InitializeMetrics(s.gprcServer)
@@ -78,11 +109,28 @@ func NewServer(options ...ServerOption) (*Server, error) {
 	return s, nil
 }
 
+// Serve accepts connections on l until the server is stopped. While serving, the TLS certificate is reloaded whenever
+// its files change.
 func (s *Server) Serve(l net.Listener) error {
 	s.options.Logger.Info("starting grpc server", slog.String("address", l.Addr().String()))
+	if s.certificates != nil && s.options.TLSReloadInterval > 0 {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go s.certificates.watch(ctx, s.options.TLSReloadInterval)
+	}
 	return s.gprcServer.Serve(l)
 }
 
+// ReloadTLS reloads the TLS certificate from its files, regardless of whether they changed. Connections already
+// established keep the certificate they were made with. If loading fails, the current certificate remains in use. It
+// does nothing if TLS is disabled.
+func (s *Server) ReloadTLS() error {
+	if s.certificates == nil {
+		return nil
+	}
+	return s.certificates.Reload()
+}
+
 func (s *Server) GracefulStop() {
 	s.options.Logger.Info("stopping grpc server")
 	s.gprcServer.GracefulStop()
@@ -127,17 +175,27 @@ func newGrpcRecoveryHandler(logger *slog.Logger, panicsTotal prometheus.Counter)
 func newGrpcErrorHandler(logger *slog.Logger) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
 		m, err := handler(ctx, req)
-		if err == nil {
-			return m, err
-		}
+		return m, toGrpcError(logger, err)
+	}
+}
 
-		_, ok := status.FromError(err)
-		if ok {
-			return m, err
-		}
-		logger.Error("non-grpc error encountered, returning internal server error instead", slog.String("error", err.Error()))
-		return m, InternalServerError
+// newGrpcStreamErrorHandler is the stream counterpart of [newGrpcErrorHandler].
+func newGrpcStreamErrorHandler(logger *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return toGrpcError(logger, handler(srv, ss))
+	}
+}
+
+// toGrpcError returns err if it is nil or a status error, and [InternalServerError] otherwise.
+func toGrpcError(logger *slog.Logger, err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
 	}
+	logger.Error("non-grpc error encountered, returning internal server error instead", slog.String("error", err.Error()))
+	return InternalServerError
 }
 
 // InternalServerError is a status error that represents an internal server error. This is a fairly non-descriptive
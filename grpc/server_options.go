@@ -4,7 +4,11 @@ import (
 	"buf.build/gen/go/chainalysis-oss/oslc/grpc/go/chainalysis_oss/oslc/v1alpha/oslcv1alphagrpc"
 	grpcprom "github.com/grpc-ecosystem/go-grpc-middleware/providers/prometheus"
 	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 	"log/slog"
+	"time"
 )
 
 type serverOptions struct {
@@ -13,8 +17,16 @@ type serverOptions struct {
 	PanicsTotalCounter prometheus.Counter
 	PrometheusRegistry *prometheus.Registry
 	oslcv1alphagrpc    oslcv1alphagrpc.OslcServiceServer
+	adminServer        oslcv1alphagrpc.OslcAdminServiceServer
+	AdminToken         string
+	TenantResolver     TenantResolver
 	CertFile           string
 	KeyFile            string
+	// TLSReloadInterval is the interval at which the TLS certificate files are checked for changes, or zero to only
+	// reload them through [Server.ReloadTLS].
+	TLSReloadInterval time.Duration
+	TracerProvider    trace.TracerProvider
+	Propagator        propagation.TextMapPropagator
 }
 
 var defaultServerOptions = serverOptions{
@@ -24,6 +36,9 @@ var defaultServerOptions = serverOptions{
 			grpcprom.WithHistogramBuckets([]float64{0.001, 0.01, 0.1, 0.3, 0.6, 1, 3, 6, 9, 20, 30, 60, 90, 120}),
 		),
 	),
+	TLSReloadInterval: time.Minute,
+	TracerProvider:    otel.GetTracerProvider(),
+	Propagator:        otel.GetTextMapPropagator(),
 }
 
 var globalServerOptions []ServerOption
@@ -77,6 +92,29 @@ func WithOslcServiceServer(oslcv1alpha oslcv1alphagrpc.OslcServiceServer) Server
 	})
 }
 
+// WithOslcAdminServiceServer returns a ServerOption that uses the provided OslcAdminServiceServer. The admin service
+// is only registered when this option is provided.
+func WithOslcAdminServiceServer(admin oslcv1alphagrpc.OslcAdminServiceServer) ServerOption {
+	return newFuncClientOption(func(opts *serverOptions) {
+		opts.adminServer = admin
+	})
+}
+
+// WithAdminToken returns a ServerOption that sets the bearer token clients must provide to call the admin service.
+func WithAdminToken(token string) ServerOption {
+	return newFuncClientOption(func(opts *serverOptions) {
+		opts.AdminToken = token
+	})
+}
+
+// WithTenantResolver returns a ServerOption that identifies the tenant of every request with the provided resolver,
+// and enforces the request quotas of tenants. Without a resolver, no request is made for a tenant.
+func WithTenantResolver(resolver TenantResolver) ServerOption {
+	return newFuncClientOption(func(opts *serverOptions) {
+		opts.TenantResolver = resolver
+	})
+}
+
 // WithTLS returns a ServerOption that uses the provided TLS configuration.
 func WithTLS(certFile, keyFile string) ServerOption {
 	return newFuncClientOption(func(opts *serverOptions) {
@@ -84,3 +122,26 @@ func WithTLS(certFile, keyFile string) ServerOption {
 		opts.KeyFile = keyFile
 	})
 }
+
+// WithTLSReloadInterval returns a ServerOption that checks the TLS certificate files for changes every interval, and
+// reloads the certificate when they change. An interval of zero disables the checks.
+func WithTLSReloadInterval(interval time.Duration) ServerOption {
+	return newFuncClientOption(func(opts *serverOptions) {
+		opts.TLSReloadInterval = interval
+	})
+}
+
+// WithTracerProvider returns a ServerOption that uses the provided TracerProvider to start a span for every request.
+func WithTracerProvider(tp trace.TracerProvider) ServerOption {
+	return newFuncClientOption(func(opts *serverOptions) {
+		opts.TracerProvider = tp
+	})
+}
+
+// WithPropagator returns a ServerOption that uses the provided propagator to extract the trace context of the caller
+// from the request metadata.
+func WithPropagator(p propagation.TextMapPropagator) ServerOption {
+	return newFuncClientOption(func(opts *serverOptions) {
+		opts.Propagator = p
+	})
+}
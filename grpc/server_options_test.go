@@ -4,10 +4,13 @@ import (
 	"buf.build/gen/go/chainalysis-oss/oslc/grpc/go/chainalysis_oss/oslc/v1alpha/oslcv1alphagrpc"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace/noop"
 	"io"
 	"log/slog"
 	"os"
 	"testing"
+	"time"
 )
 
 func TestNewServer(t *testing.T) {
@@ -82,6 +85,48 @@ func TestWithOslcServiceServer(t *testing.T) {
 	require.Equal(t, thing, opts.oslcv1alphagrpc)
 }
 
+func TestWithOslcAdminServiceServer(t *testing.T) {
+	thing := oslcv1alphagrpc.UnimplementedOslcAdminServiceServer{}
+	opts := serverOptions{}
+	f := WithOslcAdminServiceServer(thing)
+	f.apply(&opts)
+	require.Equal(t, thing, opts.adminServer)
+}
+
+func TestNewServer_adminService(t *testing.T) {
+	server, err := NewServer(WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	require.NoError(t, err)
+	require.NotContains(t, server.GetServiceInfo(), adminServiceName)
+
+	_, err = NewServer(
+		WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		WithOslcAdminServiceServer(&oslcv1alphagrpc.UnimplementedOslcAdminServiceServer{}),
+	)
+	require.ErrorIs(t, err, ErrMissingAdminToken)
+
+	server, err = NewServer(
+		WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		WithOslcAdminServiceServer(&oslcv1alphagrpc.UnimplementedOslcAdminServiceServer{}),
+		WithAdminToken("secret"),
+	)
+	require.NoError(t, err)
+	require.Contains(t, server.GetServiceInfo(), adminServiceName)
+}
+
+func TestWithAdminToken(t *testing.T) {
+	opts := serverOptions{}
+	f := WithAdminToken("secret")
+	f.apply(&opts)
+	require.Equal(t, "secret", opts.AdminToken)
+}
+
+func TestWithTenantResolver(t *testing.T) {
+	opts := serverOptions{}
+	f := WithTenantResolver(fakeTenantResolver{})
+	f.apply(&opts)
+	require.Equal(t, fakeTenantResolver{}, opts.TenantResolver)
+}
+
 func TestWithTLS(t *testing.T) {
 	opts := serverOptions{}
 	f := WithTLS("certFile", "keyFile")
@@ -89,3 +134,26 @@ func TestWithTLS(t *testing.T) {
 	require.Equal(t, "certFile", opts.CertFile)
 	require.Equal(t, "keyFile", opts.KeyFile)
 }
+
+func TestWithTLSReloadInterval(t *testing.T) {
+	opts := serverOptions{}
+	f := WithTLSReloadInterval(time.Second)
+	f.apply(&opts)
+	require.Equal(t, time.Second, opts.TLSReloadInterval)
+}
+
+func TestWithTracerProvider(t *testing.T) {
+	tp := noop.NewTracerProvider()
+	opts := serverOptions{}
+	f := WithTracerProvider(tp)
+	f.apply(&opts)
+	require.Equal(t, tp, opts.TracerProvider)
+}
+
+func TestWithPropagator(t *testing.T) {
+	p := propagation.TraceContext{}
+	opts := serverOptions{}
+	f := WithPropagator(p)
+	f.apply(&opts)
+	require.Equal(t, p, opts.Propagator)
+}
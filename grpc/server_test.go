@@ -9,12 +9,15 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"io"
 	"log/slog"
 	"net"
+	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestServer_Serve(t *testing.T) {
@@ -33,6 +36,35 @@ func TestServer_Serve(t *testing.T) {
 	_ = s.Serve(listener)
 }
 
+func TestNewServer_TLS(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeCertificate(t, dir, "first", time.Now().Add(-time.Hour))
+	s, err := NewServer(
+		WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		WithOslcServiceServer(&oslcv1alphagrpc.UnimplementedOslcServiceServer{}),
+		WithTLS(certFile, keyFile),
+	)
+	require.NoError(t, err)
+	require.Equal(t, "first", commonName(t, s.certificates))
+
+	// ReloadTLS reloads the certificate even if its files appear unchanged.
+	writeCertificate(t, dir, "second", time.Now().Add(-time.Hour))
+	require.NoError(t, s.ReloadTLS())
+	require.Equal(t, "second", commonName(t, s.certificates))
+
+	_, err = NewServer(
+		WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		WithOslcServiceServer(&oslcv1alphagrpc.UnimplementedOslcServiceServer{}),
+		WithTLS(certFile, filepath.Join(dir, "missing.key")),
+	)
+	require.Error(t, err)
+}
+
+func TestServer_ReloadTLS_withoutTLS(t *testing.T) {
+	s := &Server{options: &serverOptions{}}
+	require.NoError(t, s.ReloadTLS())
+}
+
 func TestServer_GracefulStop(t *testing.T) {
 	var logs bytes.Buffer
 	logger := slog.New(slog.NewTextHandler(&logs, nil))
@@ -143,3 +175,31 @@ func TestNewGrpcErrorHandler(t *testing.T) {
 
 	}
 }
+
+// testServerStream is a grpc.ServerStream with a fixed context.
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s testServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestNewGrpcStreamErrorHandler(t *testing.T) {
+	cases := []struct {
+		err         error
+		expectedErr error
+	}{
+		{err: nil, expectedErr: nil},
+		{err: io.EOF, expectedErr: InternalServerError},
+		{err: status.Errorf(codes.Internal, "test"), expectedErr: status.Errorf(codes.Internal, "test")},
+	}
+	for _, c := range cases {
+		interceptor := newGrpcStreamErrorHandler(slog.New(slog.NewTextHandler(io.Discard, nil)))
+		err := interceptor(nil, testServerStream{ctx: context.Background()}, &grpc.StreamServerInfo{}, func(srv any, stream grpc.ServerStream) error {
+			return c.err
+		})
+		require.Equal(t, c.expectedErr, err)
+	}
+}
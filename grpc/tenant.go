@@ -0,0 +1,65 @@
+package grpc
+
+import (
+	"context"
+	"github.com/chainalysis-oss/oslc"
+	middleware "github.com/grpc-ecosystem/go-grpc-middleware/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// TenantResolver identifies the tenants of requests, and enforces their request quotas.
+type TenantResolver interface {
+	// ResolveTenant returns the tenant of the request with the provided incoming metadata, or an empty string if the
+	// request is not made for a tenant. The returned errors are returned to the client, so they should be gRPC status
+	// errors.
+	ResolveTenant(md metadata.MD) (string, error)
+	// AllowRequest reports whether the quota of the tenant allows another request. Every call counts as one request,
+	// including batches; the oslc server counts the other requests of a batch.
+	AllowRequest(tenant string) bool
+}
+
+// resolveTenant returns the context of the request, carrying the tenant the resolver identifies for it. It returns an
+// error status if the tenant cannot be identified, or is over its quota.
+func resolveTenant(ctx context.Context, resolver TenantResolver) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	tenant, err := resolver.ResolveTenant(md)
+	if err != nil {
+		return nil, err
+	}
+	if tenant == "" {
+		return ctx, nil
+	}
+	if !resolver.AllowRequest(tenant) {
+		return nil, status.Error(codes.ResourceExhausted, "tenant quota exceeded")
+	}
+	return oslc.ContextWithTenant(ctx, tenant), nil
+}
+
+// newTenantUnaryServerInterceptor returns an interceptor that adds the tenant of every request to its context, and
+// rejects the requests of tenants that are over their quota.
+func newTenantUnaryServerInterceptor(resolver TenantResolver) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := resolveTenant(ctx, resolver)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// newTenantStreamServerInterceptor is the stream counterpart of [newTenantUnaryServerInterceptor]. The quota of the
+// tenant is charged once per stream, not once per message.
+func newTenantStreamServerInterceptor(resolver TenantResolver) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := resolveTenant(ss.Context(), resolver)
+		if err != nil {
+			return err
+		}
+		wrapped := middleware.WrapServerStream(ss)
+		wrapped.WrappedContext = ctx
+		return handler(srv, wrapped)
+	}
+}
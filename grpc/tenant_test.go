@@ -0,0 +1,93 @@
+package grpc
+
+import (
+	"context"
+	"github.com/chainalysis-oss/oslc"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+// fakeTenantResolver identifies tenants by the x-tenant metadata. Tenants named "unknown" are rejected, and tenants
+// named "exhausted" are over their quota.
+type fakeTenantResolver struct{}
+
+func (fakeTenantResolver) ResolveTenant(md metadata.MD) (string, error) {
+	values := md.Get("x-tenant")
+	if len(values) == 0 {
+		return "", nil
+	}
+	if values[0] == "unknown" {
+		return "", status.Error(codes.PermissionDenied, "unknown tenant")
+	}
+	return values[0], nil
+}
+
+func (fakeTenantResolver) AllowRequest(tenant string) bool {
+	return tenant != "exhausted"
+}
+
+func withTenant(tenant string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-tenant", tenant))
+}
+
+func TestNewTenantUnaryServerInterceptor(t *testing.T) {
+	interceptor := newTenantUnaryServerInterceptor(fakeTenantResolver{})
+	info := &grpc.UnaryServerInfo{FullMethod: "/chainalysis_oss.oslc.v1alpha.OslcService/GetPackageInfo"}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return oslc.TenantFromContext(ctx), nil
+	}
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		want       codes.Code
+		wantTenant string
+	}{
+		{"no metadata", context.Background(), codes.OK, ""},
+		{"tenant", withTenant("payments"), codes.OK, "payments"},
+		{"unknown tenant", withTenant("unknown"), codes.PermissionDenied, ""},
+		{"over quota", withTenant("exhausted"), codes.ResourceExhausted, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := interceptor(tt.ctx, nil, info, handler)
+			require.Equal(t, tt.want, status.Code(err))
+			if tt.want == codes.OK {
+				require.Equal(t, tt.wantTenant, resp)
+			}
+		})
+	}
+}
+
+func TestNewTenantStreamServerInterceptor(t *testing.T) {
+	interceptor := newTenantStreamServerInterceptor(fakeTenantResolver{})
+	info := &grpc.StreamServerInfo{FullMethod: "/chainalysis_oss.oslc.v1alpha.OslcService/WatchPackages"}
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		want       codes.Code
+		wantTenant string
+	}{
+		{"no metadata", context.Background(), codes.OK, ""},
+		{"tenant", withTenant("payments"), codes.OK, "payments"},
+		{"unknown tenant", withTenant("unknown"), codes.PermissionDenied, ""},
+		{"over quota", withTenant("exhausted"), codes.ResourceExhausted, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			err := interceptor(nil, testServerStream{ctx: tt.ctx}, info, func(srv any, stream grpc.ServerStream) error {
+				called = true
+				require.Equal(t, tt.wantTenant, oslc.TenantFromContext(stream.Context()))
+				return nil
+			})
+			require.Equal(t, tt.want, status.Code(err))
+			require.Equal(t, tt.want == codes.OK, called)
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// certificateReloader serves a TLS certificate from a pair of files, and reloads it when the files change. This allows
+// certificates to be rotated, for example by cert-manager, without restarting the server.
+type certificateReloader struct {
+	certFile string
+	keyFile  string
+	logger   *slog.Logger
+
+	mu          sync.RWMutex
+	certificate *tls.Certificate
+	// modTimes are the modification times of the certificate and key files when they were last loaded.
+	modTimes [2]time.Time
+}
+
+// newCertificateReloader returns a certificateReloader for the provided files. It fails if the certificate cannot be
+// loaded.
+func newCertificateReloader(certFile, keyFile string, logger *slog.Logger) (*certificateReloader, error) {
+	r := &certificateReloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+		logger:   logger,
+	}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// GetCertificate returns the most recently loaded certificate. It is used as the [tls.Config] GetCertificate callback,
+// so every handshake uses the current certificate.
+func (r *certificateReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.certificate, nil
+}
+
+// Reload loads the certificate from its files. If loading fails, the previously loaded certificate is kept.
+func (r *certificateReloader) Reload() error {
+	modTimes, err := r.currentModTimes()
+	if err != nil {
+		return err
+	}
+	certificate, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.certificate = &certificate
+	r.modTimes = modTimes
+	return nil
+}
+
+// reloadIfChanged reloads the certificate if either file was modified since it was last loaded. Errors are logged,
+// since the previous certificate remains in use, and the next check tries again.
+func (r *certificateReloader) reloadIfChanged() {
+	modTimes, err := r.currentModTimes()
+	if err != nil {
+		r.logger.Error("failed to check TLS certificate for changes", slog.String("error", err.Error()))
+		return
+	}
+	r.mu.RLock()
+	changed := modTimes != r.modTimes
+	r.mu.RUnlock()
+	if !changed {
+		return
+	}
+	if err := r.Reload(); err != nil {
+		r.logger.Error("failed to reload TLS certificate", slog.String("error", err.Error()))
+		return
+	}
+	r.logger.Info("reloaded TLS certificate", slog.String("cert_file", r.certFile))
+}
+
+// currentModTimes returns the modification times of the certificate and key files. Symbolic links are followed, so
+// files replaced by swapping a link, as is done for Kubernetes secrets, are detected as modified.
+func (r *certificateReloader) currentModTimes() ([2]time.Time, error) {
+	var modTimes [2]time.Time
+	for i, file := range []string{r.certFile, r.keyFile} {
+		info, err := os.Stat(file)
+		if err != nil {
+			return modTimes, fmt.Errorf("failed to stat TLS file: %w", err)
+		}
+		modTimes[i] = info.ModTime()
+	}
+	return modTimes, nil
+}
+
+// watch checks the files for changes every interval until ctx is done.
+func (r *certificateReloader) watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.reloadIfChanged()
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package grpc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"github.com/stretchr/testify/require"
+	"io"
+	"log/slog"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeCertificate writes a self-signed certificate for commonName and its key to the files in dir, and returns their
+// paths. The files' modification time is set to modTime.
+func writeCertificate(t *testing.T, dir, commonName string, modTime time.Time) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600))
+	require.NoError(t, os.Chtimes(certFile, modTime, modTime))
+	require.NoError(t, os.Chtimes(keyFile, modTime, modTime))
+	return certFile, keyFile
+}
+
+// commonName returns the common name of the certificate currently served by r.
+func commonName(t *testing.T, r *certificateReloader) string {
+	t.Helper()
+	certificate, err := r.GetCertificate(nil)
+	require.NoError(t, err)
+	leaf, err := x509.ParseCertificate(certificate.Certificate[0])
+	require.NoError(t, err)
+	return leaf.Subject.CommonName
+}
+
+func TestNewCertificateReloader(t *testing.T) {
+	certFile, keyFile := writeCertificate(t, t.TempDir(), "first", time.Now())
+	r, err := newCertificateReloader(certFile, keyFile, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+	require.Equal(t, "first", commonName(t, r))
+
+	_, err = newCertificateReloader(certFile, filepath.Join(t.TempDir(), "missing.key"), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.Error(t, err)
+}
+
+func TestCertificateReloader_reloadIfChanged(t *testing.T) {
+	dir := t.TempDir()
+	loadedAt := time.Now().Add(-time.Hour)
+	certFile, keyFile := writeCertificate(t, dir, "first", loadedAt)
+	r, err := newCertificateReloader(certFile, keyFile, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+
+	// Files whose modification time did not change are not reloaded.
+	writeCertificate(t, dir, "unnoticed", loadedAt)
+	r.reloadIfChanged()
+	require.Equal(t, "first", commonName(t, r))
+
+	writeCertificate(t, dir, "second", time.Now())
+	r.reloadIfChanged()
+	require.Equal(t, "second", commonName(t, r))
+
+	// An invalid certificate keeps the previous one in use.
+	require.NoError(t, os.WriteFile(certFile, []byte("invalid"), 0o600))
+	require.NoError(t, os.Chtimes(certFile, time.Now().Add(time.Minute), time.Now().Add(time.Minute)))
+	r.reloadIfChanged()
+	require.Equal(t, "second", commonName(t, r))
+	require.Error(t, r.Reload())
+	require.Equal(t, "second", commonName(t, r))
+
+	require.NoError(t, os.Remove(keyFile))
+	r.reloadIfChanged()
+	require.Equal(t, "second", commonName(t, r))
+}
+
+func TestCertificateReloader_watch(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeCertificate(t, dir, "first", time.Now().Add(-time.Hour))
+	r, err := newCertificateReloader(certFile, keyFile, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		r.watch(ctx, time.Millisecond)
+		close(done)
+	}()
+	writeCertificate(t, dir, "second", time.Now())
+	require.Eventually(t, func() bool {
+		return commonName(t, r) == "second"
+	}, time.Second, time.Millisecond)
+
+	cancel()
+	<-done
+}
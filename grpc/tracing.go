@@ -0,0 +1,101 @@
+package grpc
+
+import (
+	"context"
+	middleware "github.com/grpc-ecosystem/go-grpc-middleware/v2"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"strings"
+)
+
+// tracerName is the name of the tracer used to create spans for incoming requests.
+const tracerName = "github.com/chainalysis-oss/oslc/grpc"
+
+// metadataCarrier adapts [metadata.MD] to the [propagation.TextMapCarrier] interface.
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	v := metadata.MD(c).Get(key)
+	if len(v) == 0 {
+		return ""
+	}
+	return v[0]
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// splitFullMethod splits a full gRPC method name in the form "/package.Service/Method" into its service and method.
+func splitFullMethod(fullMethod string) (string, string) {
+	service, method, found := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if !found {
+		return "", service
+	}
+	return service, method
+}
+
+// startServerSpan starts a server span for a call to fullMethod. The span is a child of the caller's span if the caller
+// propagated its trace context in the request metadata.
+func startServerSpan(ctx context.Context, tracer trace.Tracer, propagator propagation.TextMapPropagator, fullMethod string) (context.Context, trace.Span) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if ok {
+		ctx = propagator.Extract(ctx, metadataCarrier(md))
+	}
+
+	service, method := splitFullMethod(fullMethod)
+	return tracer.Start(ctx, strings.TrimPrefix(fullMethod, "/"),
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(semconv.RPCSystemGRPC, semconv.RPCService(service), semconv.RPCMethod(method)),
+	)
+}
+
+// endServerSpan records the status of a call returning err on span, and ends it.
+func endServerSpan(span trace.Span, err error) {
+	s, _ := status.FromError(err)
+	span.SetAttributes(attribute.Int("rpc.grpc.status_code", int(s.Code())))
+	if err != nil {
+		span.SetStatus(otelcodes.Error, s.Message())
+	}
+	span.End()
+}
+
+// newTracingUnaryServerInterceptor returns an interceptor that starts a server span for every request. The span is a
+// child of the caller's span if the caller propagated its trace context in the request metadata.
+func newTracingUnaryServerInterceptor(tp trace.TracerProvider, propagator propagation.TextMapPropagator) grpc.UnaryServerInterceptor {
+	tracer := tp.Tracer(tracerName)
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, span := startServerSpan(ctx, tracer, propagator, info.FullMethod)
+		resp, err := handler(ctx, req)
+		endServerSpan(span, err)
+		return resp, err
+	}
+}
+
+// newTracingStreamServerInterceptor returns an interceptor that starts a server span for every stream, which lasts
+// until the stream ends. Like [newTracingUnaryServerInterceptor], it continues the caller's trace.
+func newTracingStreamServerInterceptor(tp trace.TracerProvider, propagator propagation.TextMapPropagator) grpc.StreamServerInterceptor {
+	tracer := tp.Tracer(tracerName)
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, span := startServerSpan(ss.Context(), tracer, propagator, info.FullMethod)
+		wrapped := middleware.WrapServerStream(ss)
+		wrapped.WrappedContext = ctx
+		err := handler(srv, wrapped)
+		endServerSpan(span, err)
+		return err
+	}
+}
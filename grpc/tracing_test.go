@@ -0,0 +1,109 @@
+package grpc
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+func TestSplitFullMethod(t *testing.T) {
+	service, method := splitFullMethod("/chainalysis_oss.oslc.v1alpha.OslcService/GetPackageInfo")
+	require.Equal(t, "chainalysis_oss.oslc.v1alpha.OslcService", service)
+	require.Equal(t, "GetPackageInfo", method)
+
+	service, method = splitFullMethod("GetPackageInfo")
+	require.Equal(t, "", service)
+	require.Equal(t, "GetPackageInfo", method)
+}
+
+func TestMetadataCarrier(t *testing.T) {
+	c := metadataCarrier(metadata.MD{})
+	require.Equal(t, "", c.Get("traceparent"))
+	c.Set("traceparent", "value")
+	require.Equal(t, "value", c.Get("traceparent"))
+	require.Equal(t, []string{"traceparent"}, c.Keys())
+}
+
+func TestNewTracingUnaryServerInterceptor(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	interceptor := newTracingUnaryServerInterceptor(tp, propagation.TraceContext{})
+
+	traceID, err := trace.TraceIDFromHex("0102030405060708090a0b0c0d0e0f10")
+	require.NoError(t, err)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("traceparent", "00-0102030405060708090a0b0c0d0e0f10-0102030405060708-01"))
+	info := &grpc.UnaryServerInfo{FullMethod: "/chainalysis_oss.oslc.v1alpha.OslcService/GetPackageInfo"}
+
+	var handlerSpan trace.SpanContext
+	_, err = interceptor(ctx, nil, info, func(ctx context.Context, req any) (any, error) {
+		handlerSpan = trace.SpanContextFromContext(ctx)
+		return nil, status.Error(codes.NotFound, "package not found")
+	})
+	require.Error(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	require.Equal(t, "chainalysis_oss.oslc.v1alpha.OslcService/GetPackageInfo", spans[0].Name())
+	require.Equal(t, trace.SpanKindServer, spans[0].SpanKind())
+	require.Equal(t, traceID, spans[0].SpanContext().TraceID())
+	require.Equal(t, spans[0].SpanContext(), handlerSpan)
+	require.Contains(t, spans[0].Attributes(), attribute.String("rpc.method", "GetPackageInfo"))
+	require.Contains(t, spans[0].Attributes(), attribute.Int("rpc.grpc.status_code", int(codes.NotFound)))
+	require.Equal(t, otelcodes.Error, spans[0].Status().Code)
+}
+
+func TestNewTracingUnaryServerInterceptor_noMetadata(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	interceptor := newTracingUnaryServerInterceptor(tp, propagation.TraceContext{})
+
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/svc/Method"}, func(ctx context.Context, req any) (any, error) {
+		return nil, nil
+	})
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	require.False(t, spans[0].Parent().IsValid())
+	require.Equal(t, otelcodes.Unset, spans[0].Status().Code)
+}
+
+func TestNewTracingStreamServerInterceptor(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	interceptor := newTracingStreamServerInterceptor(tp, propagation.TraceContext{})
+
+	traceID, err := trace.TraceIDFromHex("0102030405060708090a0b0c0d0e0f10")
+	require.NoError(t, err)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("traceparent", "00-0102030405060708090a0b0c0d0e0f10-0102030405060708-01"))
+	info := &grpc.StreamServerInfo{FullMethod: "/chainalysis_oss.oslc.v1alpha.OslcService/WatchPackages", IsServerStream: true}
+
+	var handlerSpan trace.SpanContext
+	err = interceptor(nil, testServerStream{ctx: ctx}, info, func(srv any, stream grpc.ServerStream) error {
+		handlerSpan = trace.SpanContextFromContext(stream.Context())
+		// The span must not end before the stream does.
+		require.Empty(t, recorder.Ended())
+		return status.Error(codes.Unavailable, "server is shutting down")
+	})
+	require.Error(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	require.Equal(t, "chainalysis_oss.oslc.v1alpha.OslcService/WatchPackages", spans[0].Name())
+	require.Equal(t, trace.SpanKindServer, spans[0].SpanKind())
+	require.Equal(t, traceID, spans[0].SpanContext().TraceID())
+	require.Equal(t, spans[0].SpanContext(), handlerSpan)
+	require.Contains(t, spans[0].Attributes(), attribute.String("rpc.method", "WatchPackages"))
+	require.Contains(t, spans[0].Attributes(), attribute.Int("rpc.grpc.status_code", int(codes.Unavailable)))
+	require.Equal(t, otelcodes.Error, spans[0].Status().Code)
+}
@@ -3,6 +3,11 @@ package http
 import (
 	"bytes"
 	"context"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 	"io"
 	"log/slog"
 	"net/http"
@@ -10,6 +15,9 @@ import (
 	"time"
 )
 
+// tracerName is the name of the tracer used to create spans for outgoing requests.
+const tracerName = "github.com/chainalysis-oss/oslc/http"
+
 var DefaultClient *Client
 
 func init() {
@@ -45,46 +53,90 @@ func NewClient(options ...ClientOption) (*Client, error) {
 
 // Query executes a GET request against the given url and returns the response and any errors associated with it.
 //
+// A client span is started for the request using the TracerProvider in the [clientOptions] struct, and the trace
+// context carried by ctx is propagated to the upstream server through the request headers.
+//
 // The HTTP Headers defined under the [clientOptions] struct are added to the request. If the User-Agent header is
 // not set, it is set to the value of the UserAgent field in the [clientOptions] struct.
 //
 // The response body is limited to the value of the ReaderLimit field in the [clientOptions] struct and an error
 // is returned if the limit is exceeded. Additionally, the response body will be read by this function to facilitate
 // logging, yet returned to the caller as a ReadCloser to be handled like any other response body.
-func (c *Client) Query(url string) (*http.Response, error) {
-	req, err := http.NewRequest("GET", url, nil)
+func (c *Client) Query(ctx context.Context, url string) (*http.Response, error) {
+	return c.QueryWithHeaders(ctx, url, nil)
+}
+
+// QueryWithHeaders executes a GET request against the given url like [Client.Query], with the provided headers added
+// to the request. They replace the headers of the same name defined under the [clientOptions] struct.
+func (c *Client) QueryWithHeaders(ctx context.Context, url string, headers http.Header) (*http.Response, error) {
+	ctx, span := c.options.TracerProvider.Tracer(tracerName).Start(ctx, http.MethodGet, trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
 		return nil, err
 	}
-	req.Header = c.options.Headers
+	span.SetAttributes(semconv.HTTPRequestMethodGet, semconv.URLFull(req.URL.String()), semconv.ServerAddress(req.URL.Hostname()))
+
+	// The headers are cloned, as the propagator writes the trace context into them and the configured headers are
+	// shared between all requests made by the client.
+	req.Header = c.options.Headers.Clone()
+	for name, values := range headers {
+		req.Header[http.CanonicalHeaderKey(name)] = values
+	}
 	if req.Header.Get("User-Agent") == "" {
 		req.Header.Set("User-Agent", c.options.UserAgent)
 	}
+	c.options.Propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
 
 	logHeader := make([]any, 0)
 	for header := range req.Header {
-		logHeader = append(logHeader, slog.String(strings.ToLower(header), req.Header.Get(header)))
+		logHeader = append(logHeader, slog.String(strings.ToLower(header), logHeaderValue(header, req.Header.Get(header))))
 	}
-	c.options.Logger.LogAttrs(req.Context(), slog.LevelDebug, "outgoing request", slog.String("path", req.Method), slog.String("url", req.URL.String()), slog.Group("headers", logHeader...))
+	c.options.Logger.LogAttrs(ctx, slog.LevelDebug, "outgoing request", slog.String("path", req.Method), slog.String("url", req.URL.String()), slog.Group("headers", logHeader...))
 
 	resp, err := c.options.HttpClient.Do(req)
 	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
 		return nil, err
 	}
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	if resp.StatusCode >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, resp.Status)
+	}
 
 	body, err := io.ReadAll(io.LimitReader(resp.Body, c.options.ReaderLimit))
 	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
 		return nil, err
 	}
+	span.SetAttributes(attribute.Int("http.response.body.size", len(body)))
 	defer resp.Body.Close()
 
 	// Reset the body so it can be read again. The body is a ReadCloser, but [bytes.NewBuffer] does not implement
 	// ReadCloser, so we need to use [io.NopCloser] to wrap it.
 	resp.Body = io.NopCloser(bytes.NewBuffer(body))
-	c.options.Logger.LogAttrs(context.Background(), slog.LevelDebug, "response", slog.Int("status", resp.StatusCode), slog.String("body", string(body)))
+	c.options.Logger.LogAttrs(ctx, slog.LevelDebug, "response", slog.Int("status", resp.StatusCode), slog.String("body", string(body)))
 	return resp, err
 }
 
+// sensitiveHeaders are the headers whose values are credentials, and must not be logged.
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "X-Api-Key"}
+
+// logHeaderValue returns the value of the header as it is logged. The values of sensitive headers are redacted.
+func logHeaderValue(header, value string) string {
+	for _, h := range sensitiveHeaders {
+		if strings.EqualFold(header, h) {
+			return "REDACTED"
+		}
+	}
+	return value
+}
+
 type RoundTripFunc func(req *http.Request) (*http.Response, error)
 
 func (f RoundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
@@ -1,6 +1,9 @@
 package http
 
 import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 	"log/slog"
 	"net/http"
 	"time"
@@ -13,6 +16,10 @@ type clientOptions struct {
 	Headers    http.Header
 	// ReaderLimit is the maximum number of bytes to read from the response body.
 	ReaderLimit int64
+	// TracerProvider is used to create spans for outgoing requests.
+	TracerProvider trace.TracerProvider
+	// Propagator is used to inject the trace context into the headers of outgoing requests.
+	Propagator propagation.TextMapPropagator
 }
 
 var defaultClientOptions = clientOptions{
@@ -20,8 +27,10 @@ var defaultClientOptions = clientOptions{
 	HttpClient: &http.Client{
 		Timeout: 10 * time.Second,
 	},
-	UserAgent:   "Open Software License Catalogue (github.com/chainalysis-oss/oslc)",
-	ReaderLimit: 20 * 1024 * 1024,
+	UserAgent:      "Open Software License Catalogue (github.com/chainalysis-oss/oslc)",
+	ReaderLimit:    20 * 1024 * 1024,
+	TracerProvider: otel.GetTracerProvider(),
+	Propagator:     otel.GetTextMapPropagator(),
 }
 
 var globalClientOptions []ClientOption
@@ -73,3 +82,15 @@ func WithReaderLimit(limit int64) ClientOption {
 		opts.ReaderLimit = limit
 	})
 }
+
+func WithTracerProvider(tp trace.TracerProvider) ClientOption {
+	return newFuncClientOption(func(opts *clientOptions) {
+		opts.TracerProvider = tp
+	})
+}
+
+func WithPropagator(p propagation.TextMapPropagator) ClientOption {
+	return newFuncClientOption(func(opts *clientOptions) {
+		opts.Propagator = p
+	})
+}
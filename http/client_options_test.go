@@ -2,6 +2,8 @@ package http
 
 import (
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace/noop"
 	"log/slog"
 	"net/http"
 	"os"
@@ -84,3 +86,19 @@ func TestWithReaderLimit(t *testing.T) {
 	f.apply(&opts)
 	require.Equal(t, int64(10), opts.ReaderLimit)
 }
+
+func TestWithTracerProvider(t *testing.T) {
+	tp := noop.NewTracerProvider()
+	opts := clientOptions{}
+	f := WithTracerProvider(tp)
+	f.apply(&opts)
+	require.Equal(t, tp, opts.TracerProvider)
+}
+
+func TestWithPropagator(t *testing.T) {
+	p := propagation.TraceContext{}
+	opts := clientOptions{}
+	f := WithPropagator(p)
+	f.apply(&opts)
+	require.Equal(t, p, opts.Propagator)
+}
@@ -2,8 +2,13 @@ package http
 
 import (
 	"bytes"
+	"context"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"io"
 	"log/slog"
 	"net/http"
@@ -104,7 +109,7 @@ func TestClient_Query(t *testing.T) {
 			tt.additionalOptions = append(tt.additionalOptions, WithHTTPClient(mock))
 			c, err := NewClient(tt.additionalOptions...)
 			require.NoError(t, err)
-			resp, err := c.Query(tt.url)
+			resp, err := c.Query(context.Background(), tt.url)
 			if tt.wantErr {
 				require.Error(t, err)
 				return
@@ -128,7 +133,7 @@ func BenchmarkClient_Query_debug_logging(b *testing.B) {
 	require.NoError(b, err)
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, err := c.Query("https://example.com")
+		_, err := c.Query(context.Background(), "https://example.com")
 		require.NoError(b, err)
 	}
 }
@@ -144,7 +149,77 @@ func BenchmarkClient_Query_no_debug_logging(b *testing.B) {
 	require.NoError(b, err)
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, err := c.Query("https://example.com")
+		_, err := c.Query(context.Background(), "https://example.com")
 		require.NoError(b, err)
 	}
 }
+
+func TestClient_Query_tracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	var traceparent string
+	mock := NewTestHTTPClient(func(req *http.Request) (*http.Response, error) {
+		traceparent = req.Header.Get("Traceparent")
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       http.NoBody,
+		}, nil
+	})
+	headers := http.Header{"Accept": {"application/json"}}
+	c, err := NewClient(WithHTTPClient(mock), WithHeaders(headers), WithTracerProvider(tp), WithPropagator(propagation.TraceContext{}))
+	require.NoError(t, err)
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "parent")
+	_, err = c.Query(ctx, "https://example.com/path")
+	require.NoError(t, err)
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	require.Equal(t, "GET", spans[0].Name())
+	require.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	require.Contains(t, spans[0].Attributes(), attribute.Int("http.response.status_code", http.StatusOK))
+	require.Contains(t, traceparent, parent.SpanContext().TraceID().String())
+	// The configured headers must not be modified by the propagator.
+	require.Empty(t, headers.Get("Traceparent"))
+}
+
+func TestLogHeaderValue(t *testing.T) {
+	require.Equal(t, "application/json", logHeaderValue("Accept", "application/json"))
+	require.Equal(t, "REDACTED", logHeaderValue("Authorization", "Bearer secret"))
+	require.Equal(t, "REDACTED", logHeaderValue("x-api-key", "secret"))
+}
+
+func TestClient_Query_redactsHeaders(t *testing.T) {
+	var logs bytes.Buffer
+	mock := NewTestHTTPClient(func(req *http.Request) (*http.Response, error) {
+		require.Equal(t, "Bearer secret", req.Header.Get("Authorization"))
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	})
+	c, err := NewClient(
+		WithHTTPClient(mock),
+		WithHeaders(http.Header{"Authorization": {"Bearer secret"}}),
+		WithLogger(slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))),
+	)
+	require.NoError(t, err)
+	_, err = c.Query(context.Background(), "https://example.com")
+	require.NoError(t, err)
+	require.NotContains(t, logs.String(), "secret")
+	require.Contains(t, logs.String(), "REDACTED")
+}
+
+func TestClient_QueryWithHeaders(t *testing.T) {
+	mock := NewTestHTTPClient(func(req *http.Request) (*http.Response, error) {
+		require.Equal(t, "application/vnd.npm.install-v1+json", req.Header.Get("Accept"))
+		require.Equal(t, "value", req.Header.Get("X-Client"))
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	})
+	c, err := NewClient(
+		WithHTTPClient(mock),
+		WithHeaders(http.Header{"Accept": {"application/json"}, "X-Client": {"value"}}),
+	)
+	require.NoError(t, err)
+	_, err = c.QueryWithHeaders(context.Background(), "https://example.com", http.Header{"accept": {"application/vnd.npm.install-v1+json"}})
+	require.NoError(t, err)
+}
@@ -0,0 +1,206 @@
+// Package jobs resolves packages in the background, for async lookups. GetPackageInfo requests that may not wait for a
+// distributor queue a job in an [oslc.JobStore], and a [Pool] of workers resolves the queued jobs, retrying the ones
+// that fail temporarily with exponential backoff.
+package jobs
+
+import (
+	oslcv1alpha "buf.build/gen/go/chainalysis-oss/oslc/protocolbuffers/go/chainalysis_oss/oslc/v1alpha"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/chainalysis-oss/oslc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Resolver resolves packages. It is implemented by the oslc.Server of the oslc/oslc package.
+type Resolver interface {
+	GetPackageInfo(ctx context.Context, request *oslcv1alpha.GetPackageInfoRequest) (*oslcv1alpha.GetPackageInfoResponse, error)
+}
+
+// pruneInterval is the time between deletions of the finished jobs that are past their retention.
+const pruneInterval = 10 * time.Minute
+
+// Pool resolves the jobs of an [oslc.JobStore] with a number of concurrent workers. Jobs are claimed with a lease, so
+// several pools, for example of several servers, can share the same store.
+type Pool struct {
+	options *poolOptions
+}
+
+// NewPool returns a new Pool. The Store and Resolver options are required.
+func NewPool(options ...PoolOption) (*Pool, error) {
+	opts := defaultPoolOptions
+	for _, opt := range globalPoolOptions {
+		opt.apply(&opts)
+	}
+	for _, opt := range options {
+		opt.apply(&opts)
+	}
+
+	if opts.Store == nil {
+		return nil, ErrMissingOptionStore
+	}
+	if opts.Resolver == nil {
+		return nil, ErrMissingOptionResolver
+	}
+	if opts.Workers <= 0 {
+		return nil, ErrInvalidWorkers
+	}
+	if opts.MaxAttempts <= 0 {
+		return nil, ErrInvalidMaxAttempts
+	}
+
+	return &Pool{
+		options: &opts,
+	}, nil
+}
+
+var ErrMissingOptionStore = errors.New("missing option: store")
+var ErrMissingOptionResolver = errors.New("missing option: resolver")
+var ErrInvalidWorkers = errors.New("number of workers must be positive")
+var ErrInvalidMaxAttempts = errors.New("maximum number of attempts must be positive")
+
+// Run starts the workers, and deletes finished jobs once they are past their retention, until ctx is cancelled. Errors
+// from the store are logged, and do not stop the pool. Run returns nil once ctx is cancelled and every worker has
+// stopped. Jobs that are interrupted are claimed again once their lease expires.
+func (p *Pool) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+	for range p.options.Workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.work(ctx)
+		}()
+	}
+
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+	for {
+		p.prune(ctx)
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// work resolves due jobs one at a time until ctx is cancelled, waiting for the poll interval whenever no job is due.
+func (p *Pool) work(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		claimed, err := p.RunOnce(ctx)
+		if err != nil && ctx.Err() == nil {
+			p.options.Logger.ErrorContext(ctx, "failed to run job", slog.String("error", err.Error()))
+		}
+		if claimed && err == nil {
+			timer.Reset(0)
+		} else {
+			timer.Reset(p.options.PollInterval)
+		}
+	}
+}
+
+// prune deletes the finished jobs that are past their retention.
+func (p *Pool) prune(ctx context.Context) {
+	deleted, err := p.options.Store.DeleteFinishedJobs(ctx, time.Now().Add(-p.options.Retention))
+	if err != nil {
+		if ctx.Err() == nil {
+			p.options.Logger.ErrorContext(ctx, "failed to delete finished jobs", slog.String("error", err.Error()))
+		}
+		return
+	}
+	if deleted > 0 {
+		p.options.Logger.DebugContext(ctx, "deleted finished jobs", slog.Int("deleted", deleted))
+	}
+}
+
+// RunOnce claims a single due job and resolves it. It reports whether a job was claimed.
+func (p *Pool) RunOnce(ctx context.Context) (bool, error) {
+	jobs, err := p.options.Store.ClaimJobs(ctx, 1, p.options.Lease)
+	if err != nil {
+		return false, fmt.Errorf("claiming jobs: %w", err)
+	}
+	if len(jobs) == 0 {
+		return false, nil
+	}
+	return true, p.run(ctx, jobs[0])
+}
+
+// run resolves the package of a claimed job, and records the outcome in the store. Jobs that fail temporarily are
+// retried with exponential backoff, or marked as failed once the maximum number of attempts is reached. Other failures
+// are final.
+func (p *Pool) run(ctx context.Context, job oslc.Job) error {
+	logger := p.options.Logger.With(
+		slog.Int64("job_id", job.ID),
+		slog.String("distributor", job.Distributor),
+		slog.String("name", job.Name),
+		slog.String("version", job.Version),
+		slog.Int("attempts", job.Attempts),
+	)
+
+	attemptCtx, cancel := context.WithTimeout(ctx, p.options.Lease)
+	response, resolveErr := p.options.Resolver.GetPackageInfo(attemptCtx, &oslcv1alpha.GetPackageInfoRequest{
+		Distributor: job.Distributor,
+		Name:        job.Name,
+		Version:     job.Version,
+	})
+	cancel()
+	if ctx.Err() != nil {
+		// The job is left to its lease, and claimed again once the lease expires.
+		return ctx.Err()
+	}
+
+	var err error
+	switch {
+	case resolveErr == nil:
+		var result []byte
+		if result, err = EncodeResponse(response); err == nil {
+			logger.DebugContext(ctx, "job succeeded")
+			err = p.options.Store.CompleteJob(ctx, job.ID, result)
+		}
+	case !retryable(resolveErr) || job.Attempts >= p.options.MaxAttempts:
+		var result []byte
+		if result, err = EncodeError(resolveErr); err == nil {
+			logger.InfoContext(ctx, "job failed", slog.String("error", resolveErr.Error()))
+			err = p.options.Store.FailJob(ctx, job.ID, result, resolveErr.Error())
+		}
+	default:
+		next := time.Now().Add(p.backoff(job.Attempts))
+		logger.InfoContext(ctx, "job attempt failed, retrying", slog.String("error", resolveErr.Error()), slog.Time("next_attempt", next))
+		err = p.options.Store.RetryJob(ctx, job.ID, next, resolveErr.Error())
+	}
+	if err != nil {
+		return fmt.Errorf("resolving job %d: %w", job.ID, err)
+	}
+	return nil
+}
+
+// retryable reports whether a lookup that failed with err may succeed on a later attempt.
+func retryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted, codes.Internal, codes.Unknown:
+		return true
+	}
+	return false
+}
+
+// backoff returns the delay before the next attempt of a job that has failed the provided number of attempts.
+func (p *Pool) backoff(attempts int) time.Duration {
+	delay := p.options.BackoffBase
+	for i := 1; i < attempts && delay < p.options.BackoffMax; i++ {
+		delay *= 2
+	}
+	return min(delay, p.options.BackoffMax)
+}
@@ -0,0 +1,131 @@
+package jobs
+
+import (
+	"github.com/chainalysis-oss/oslc"
+	"log/slog"
+	"time"
+)
+
+type poolOptions struct {
+	Logger *slog.Logger
+	// Store holds the job queue.
+	Store oslc.JobStore
+	// Resolver resolves the packages of the jobs. It is usually an oslc.Server, which saves the packages it fetches to
+	// its datastore.
+	Resolver Resolver
+	// Workers is the number of jobs resolved at the same time.
+	Workers int
+	// PollInterval is the time an idle worker waits before checking for due jobs again.
+	PollInterval time.Duration
+	// Lease is the time a claimed job is unavailable to other workers. It is also the timeout of a single attempt, so
+	// an attempt never outlives its lease.
+	Lease time.Duration
+	// MaxAttempts is the number of attempts after which a job that keeps failing temporarily is marked as failed.
+	MaxAttempts int
+	// BackoffBase is the delay before the first retry. The delay doubles with every further attempt.
+	BackoffBase time.Duration
+	// BackoffMax is the maximum delay between attempts.
+	BackoffMax time.Duration
+	// Retention is the time finished jobs are kept, so their result can be polled.
+	Retention time.Duration
+}
+
+var defaultPoolOptions = poolOptions{
+	Logger:       slog.Default(),
+	Workers:      4,
+	PollInterval: time.Second,
+	Lease:        5 * time.Minute,
+	MaxAttempts:  5,
+	BackoffBase:  10 * time.Second,
+	BackoffMax:   10 * time.Minute,
+	Retention:    24 * time.Hour,
+}
+
+var globalPoolOptions []PoolOption
+
+// PoolOption is an option for configuring a Pool.
+type PoolOption interface {
+	apply(*poolOptions)
+}
+
+// funcPoolOption is a PoolOption that calls a function.
+// It is used to wrap a function, so it satisfies the PoolOption interface.
+type funcPoolOption struct {
+	f func(*poolOptions)
+}
+
+func (fpo *funcPoolOption) apply(opts *poolOptions) {
+	fpo.f(opts)
+}
+
+func newFuncPoolOption(f func(*poolOptions)) *funcPoolOption {
+	return &funcPoolOption{
+		f: f,
+	}
+}
+
+// WithLogger returns a PoolOption that uses the provided logger.
+func WithLogger(logger *slog.Logger) PoolOption {
+	return newFuncPoolOption(func(opts *poolOptions) {
+		opts.Logger = logger
+	})
+}
+
+// WithStore returns a PoolOption that uses the provided store for the job queue.
+func WithStore(store oslc.JobStore) PoolOption {
+	return newFuncPoolOption(func(opts *poolOptions) {
+		opts.Store = store
+	})
+}
+
+// WithResolver returns a PoolOption that resolves the packages of jobs with the provided Resolver.
+func WithResolver(r Resolver) PoolOption {
+	return newFuncPoolOption(func(opts *poolOptions) {
+		opts.Resolver = r
+	})
+}
+
+// WithWorkers returns a PoolOption that sets the number of jobs resolved at the same time.
+func WithWorkers(workers int) PoolOption {
+	return newFuncPoolOption(func(opts *poolOptions) {
+		opts.Workers = workers
+	})
+}
+
+// WithPollInterval returns a PoolOption that sets the time an idle worker waits before checking for due jobs again.
+func WithPollInterval(interval time.Duration) PoolOption {
+	return newFuncPoolOption(func(opts *poolOptions) {
+		opts.PollInterval = interval
+	})
+}
+
+// WithLease returns a PoolOption that sets the time a claimed job is unavailable to other workers, which is also the
+// timeout of a single attempt.
+func WithLease(lease time.Duration) PoolOption {
+	return newFuncPoolOption(func(opts *poolOptions) {
+		opts.Lease = lease
+	})
+}
+
+// WithMaxAttempts returns a PoolOption that sets the number of attempts after which a job is marked as failed.
+func WithMaxAttempts(attempts int) PoolOption {
+	return newFuncPoolOption(func(opts *poolOptions) {
+		opts.MaxAttempts = attempts
+	})
+}
+
+// WithBackoff returns a PoolOption that sets the delay before the first retry of a job, and the maximum delay between
+// attempts.
+func WithBackoff(base, max time.Duration) PoolOption {
+	return newFuncPoolOption(func(opts *poolOptions) {
+		opts.BackoffBase = base
+		opts.BackoffMax = max
+	})
+}
+
+// WithRetention returns a PoolOption that sets the time finished jobs are kept before they are deleted.
+func WithRetention(retention time.Duration) PoolOption {
+	return newFuncPoolOption(func(opts *poolOptions) {
+		opts.Retention = retention
+	})
+}
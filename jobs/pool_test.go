@@ -0,0 +1,223 @@
+package jobs
+
+import (
+	oslcv1alpha "buf.build/gen/go/chainalysis-oss/oslc/protocolbuffers/go/chainalysis_oss/oslc/v1alpha"
+	"context"
+	"github.com/chainalysis-oss/oslc"
+	"github.com/chainalysis-oss/oslc/memory"
+	oslcMocks "github.com/chainalysis-oss/oslc/mocks/oslc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeResolver answers GetPackageInfo requests with the errors in errs, keyed by package name, and records the names
+// of the requested packages.
+type fakeResolver struct {
+	mu        sync.Mutex
+	errs      map[string]error
+	requested []string
+}
+
+func (r *fakeResolver) GetPackageInfo(_ context.Context, request *oslcv1alpha.GetPackageInfoRequest) (*oslcv1alpha.GetPackageInfoResponse, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requested = append(r.requested, request.Name)
+	if err := r.errs[request.Name]; err != nil {
+		return nil, err
+	}
+	return &oslcv1alpha.GetPackageInfoResponse{Name: request.Name, Version: "1.0.0", License: "MIT"}, nil
+}
+
+func (r *fakeResolver) requests() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.requested...)
+}
+
+func newTestStore(t *testing.T) *memory.Datastore {
+	t.Helper()
+	ds, err := memory.NewDatastore(memory.WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	require.NoError(t, err)
+	return ds
+}
+
+func newTestPool(t *testing.T, options ...PoolOption) *Pool {
+	t.Helper()
+	p, err := NewPool(append([]PoolOption{
+		WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		WithPollInterval(5 * time.Millisecond),
+	}, options...)...)
+	require.NoError(t, err)
+	return p
+}
+
+func enqueue(t *testing.T, store oslc.JobStore, name string) oslc.Job {
+	t.Helper()
+	job, err := store.EnqueueJob(context.Background(), oslc.JobRequest{Distributor: oslc.DistributorGo, Name: name})
+	require.NoError(t, err)
+	return job
+}
+
+func TestNewPool_errors(t *testing.T) {
+	store := oslcMocks.NewMockJobStore(t)
+	resolver := &fakeResolver{}
+	tests := []struct {
+		name    string
+		options []PoolOption
+		wantErr error
+	}{
+		{name: "missing store", options: []PoolOption{WithResolver(resolver)}, wantErr: ErrMissingOptionStore},
+		{name: "missing resolver", options: []PoolOption{WithStore(store)}, wantErr: ErrMissingOptionResolver},
+		{name: "invalid workers", options: []PoolOption{WithStore(store), WithResolver(resolver), WithWorkers(0)}, wantErr: ErrInvalidWorkers},
+		{name: "invalid max attempts", options: []PoolOption{WithStore(store), WithResolver(resolver), WithMaxAttempts(0)}, wantErr: ErrInvalidMaxAttempts},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewPool(tt.options...)
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestPool_RunOnce(t *testing.T) {
+	store := newTestStore(t)
+	resolver := &fakeResolver{errs: map[string]error{
+		"missing":     status.Error(codes.NotFound, "package not found"),
+		"unavailable": status.Error(codes.Unavailable, "distributor unavailable"),
+	}}
+	p := newTestPool(t, WithStore(store), WithResolver(resolver), WithMaxAttempts(2), WithBackoff(0, 0))
+	resolved := enqueue(t, store, "resolved")
+	missing := enqueue(t, store, "missing")
+	unavailable := enqueue(t, store, "unavailable")
+	ctx := context.Background()
+
+	for range 3 {
+		claimed, err := p.RunOnce(ctx)
+		require.NoError(t, err)
+		require.True(t, claimed)
+	}
+
+	job, err := store.GetJob(ctx, resolved.ID)
+	require.NoError(t, err)
+	require.Equal(t, oslc.JobSucceeded, job.State)
+	response, err := DecodeResponse(job.Result)
+	require.NoError(t, err)
+	require.Equal(t, "MIT", response.License)
+
+	// Errors that will not go away on a later attempt are final.
+	job, err = store.GetJob(ctx, missing.ID)
+	require.NoError(t, err)
+	require.Equal(t, oslc.JobFailed, job.State)
+	jobErr, err := DecodeError(job.Result)
+	require.NoError(t, err)
+	require.Equal(t, codes.NotFound, status.Code(jobErr))
+
+	// Temporary errors are retried until the maximum number of attempts is reached.
+	job, err = store.GetJob(ctx, unavailable.ID)
+	require.NoError(t, err)
+	require.Equal(t, oslc.JobPending, job.State)
+	require.Equal(t, "rpc error: code = Unavailable desc = distributor unavailable", job.LastError)
+	claimed, err := p.RunOnce(ctx)
+	require.NoError(t, err)
+	require.True(t, claimed)
+	job, err = store.GetJob(ctx, unavailable.ID)
+	require.NoError(t, err)
+	require.Equal(t, oslc.JobFailed, job.State)
+	require.Equal(t, 2, job.Attempts)
+
+	claimed, err = p.RunOnce(ctx)
+	require.NoError(t, err)
+	require.False(t, claimed)
+	require.Equal(t, []string{"resolved", "missing", "unavailable", "unavailable"}, resolver.requests())
+}
+
+func TestPool_RunOnce_errors(t *testing.T) {
+	t.Run("claim", func(t *testing.T) {
+		store := oslcMocks.NewMockJobStore(t)
+		store.EXPECT().ClaimJobs(mock.Anything, 1, 5*time.Minute).Return(nil, assert.AnError)
+		p := newTestPool(t, WithStore(store), WithResolver(&fakeResolver{}))
+		_, err := p.RunOnce(context.Background())
+		require.ErrorIs(t, err, assert.AnError)
+	})
+	t.Run("complete", func(t *testing.T) {
+		store := oslcMocks.NewMockJobStore(t)
+		store.EXPECT().ClaimJobs(mock.Anything, 1, 5*time.Minute).Return([]oslc.Job{{ID: 1, Attempts: 1}}, nil)
+		store.EXPECT().CompleteJob(mock.Anything, int64(1), mock.Anything).Return(assert.AnError)
+		p := newTestPool(t, WithStore(store), WithResolver(&fakeResolver{}))
+		claimed, err := p.RunOnce(context.Background())
+		require.True(t, claimed)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+	t.Run("cancelled", func(t *testing.T) {
+		store := oslcMocks.NewMockJobStore(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		// The job is left to its lease when the pool stops during an attempt.
+		store.EXPECT().ClaimJobs(mock.Anything, 1, 5*time.Minute).RunAndReturn(func(context.Context, int, time.Duration) ([]oslc.Job, error) {
+			cancel()
+			return []oslc.Job{{ID: 1, Attempts: 1}}, nil
+		})
+		p := newTestPool(t, WithStore(store), WithResolver(&fakeResolver{}))
+		_, err := p.RunOnce(ctx)
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestPool_backoff(t *testing.T) {
+	p := newTestPool(t, WithStore(oslcMocks.NewMockJobStore(t)), WithResolver(&fakeResolver{}), WithBackoff(time.Second, 5*time.Second))
+	require.Equal(t, time.Second, p.backoff(1))
+	require.Equal(t, 2*time.Second, p.backoff(2))
+	require.Equal(t, 4*time.Second, p.backoff(3))
+	require.Equal(t, 5*time.Second, p.backoff(4))
+	require.Equal(t, 5*time.Second, p.backoff(100))
+}
+
+func TestPool_Run(t *testing.T) {
+	store := newTestStore(t)
+	resolver := &fakeResolver{}
+	p := newTestPool(t, WithStore(store), WithResolver(resolver), WithWorkers(2), WithRetention(time.Hour))
+	finished := enqueue(t, store, "finished")
+	_, err := store.ClaimJobs(context.Background(), 1, time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, store.CompleteJob(context.Background(), finished.ID, nil))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- p.Run(ctx) }()
+	for _, name := range []string{"a", "b", "c"} {
+		enqueue(t, store, name)
+	}
+	require.Eventually(t, func() bool { return len(resolver.requests()) == 3 }, time.Second, 5*time.Millisecond)
+	cancel()
+	require.NoError(t, <-done)
+	require.ElementsMatch(t, []string{"a", "b", "c"}, resolver.requests())
+
+	// Finished jobs are kept for their retention.
+	_, err = store.GetJob(context.Background(), finished.ID)
+	require.NoError(t, err)
+}
+
+func TestPool_Run_prunes(t *testing.T) {
+	store := oslcMocks.NewMockJobStore(t)
+	store.EXPECT().ClaimJobs(mock.Anything, 1, 5*time.Minute).Return(nil, nil)
+	pruned := make(chan time.Time, 1)
+	store.EXPECT().DeleteFinishedJobs(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, before time.Time) (int, error) {
+		pruned <- before
+		return 1, nil
+	}).Once()
+	p := newTestPool(t, WithStore(store), WithResolver(&fakeResolver{}), WithRetention(time.Hour))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- p.Run(ctx) }()
+	require.WithinDuration(t, time.Now().Add(-time.Hour), <-pruned, time.Minute)
+	cancel()
+	require.NoError(t, <-done)
+}
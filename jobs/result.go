@@ -0,0 +1,48 @@
+package jobs
+
+import (
+	oslcv1alpha "buf.build/gen/go/chainalysis-oss/oslc/protocolbuffers/go/chainalysis_oss/oslc/v1alpha"
+	"fmt"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// The result of a finished job is stored as a protobuf message: the GetPackageInfoResponse of a succeeded job, and the
+// google.rpc.Status of the error of a failed job.
+
+// EncodeResponse encodes the result of a succeeded job.
+func EncodeResponse(response *oslcv1alpha.GetPackageInfoResponse) ([]byte, error) {
+	result, err := proto.Marshal(response)
+	if err != nil {
+		return nil, fmt.Errorf("encoding response: %w", err)
+	}
+	return result, nil
+}
+
+// DecodeResponse decodes the result of a succeeded job.
+func DecodeResponse(result []byte) (*oslcv1alpha.GetPackageInfoResponse, error) {
+	var response oslcv1alpha.GetPackageInfoResponse
+	if err := proto.Unmarshal(result, &response); err != nil {
+		return nil, fmt.Errorf("decoding response: %w", err)
+	}
+	return &response, nil
+}
+
+// EncodeError encodes the result of a failed job, keeping the code and details of err if it is a gRPC status error.
+func EncodeError(err error) ([]byte, error) {
+	result, encodeErr := proto.Marshal(status.Convert(err).Proto())
+	if encodeErr != nil {
+		return nil, fmt.Errorf("encoding error: %w", encodeErr)
+	}
+	return result, nil
+}
+
+// DecodeError decodes the result of a failed job to the gRPC status error of the job.
+func DecodeError(result []byte) (error, error) {
+	var st spb.Status
+	if err := proto.Unmarshal(result, &st); err != nil {
+		return nil, fmt.Errorf("decoding error: %w", err)
+	}
+	return status.ErrorProto(&st), nil
+}
@@ -0,0 +1,46 @@
+package jobs
+
+import (
+	oslcv1alpha "buf.build/gen/go/chainalysis-oss/oslc/protocolbuffers/go/chainalysis_oss/oslc/v1alpha"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"testing"
+)
+
+func TestEncodeResponse(t *testing.T) {
+	response := &oslcv1alpha.GetPackageInfoResponse{Name: "test", Version: "1.0.0", License: "MIT"}
+	result, err := EncodeResponse(response)
+	require.NoError(t, err)
+	decoded, err := DecodeResponse(result)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(response, decoded))
+
+	_, err = DecodeResponse([]byte("not protobuf"))
+	require.ErrorContains(t, err, "decoding response")
+}
+
+func TestEncodeError(t *testing.T) {
+	st, err := status.New(codes.NotFound, "package not found").WithDetails(&errdetails.ErrorInfo{Reason: "PACKAGE_NOT_FOUND"})
+	require.NoError(t, err)
+	result, err := EncodeError(st.Err())
+	require.NoError(t, err)
+	decoded, err := DecodeError(result)
+	require.NoError(t, err)
+	require.Equal(t, codes.NotFound, status.Code(decoded))
+	require.Len(t, status.Convert(decoded).Details(), 1)
+
+	// Errors without a status are reported as unknown errors.
+	result, err = EncodeError(errors.New("boom"))
+	require.NoError(t, err)
+	decoded, err = DecodeError(result)
+	require.NoError(t, err)
+	require.Equal(t, codes.Unknown, status.Code(decoded))
+	require.Equal(t, "boom", status.Convert(decoded).Message())
+
+	_, err = DecodeError([]byte("not protobuf"))
+	require.ErrorContains(t, err, "decoding error")
+}
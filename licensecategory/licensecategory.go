@@ -0,0 +1,105 @@
+// Package licensecategory groups SPDX license identifiers into broad categories describing the obligations they
+// impose. The categories are intended for filtering and reporting, and are not legal advice.
+package licensecategory
+
+import "strings"
+
+// The license categories. Identifiers that are not recognized, including license expressions, are [Unknown].
+const (
+	PublicDomain    = "public-domain"
+	Permissive      = "permissive"
+	WeakCopyleft    = "weak-copyleft"
+	Copyleft        = "copyleft"
+	NetworkCopyleft = "network-copyleft"
+	SourceAvailable = "source-available"
+	Unknown         = "unknown"
+)
+
+// All returns every category, in order from least to most restrictive, followed by [Unknown].
+func All() []string {
+	return []string{PublicDomain, Permissive, WeakCopyleft, Copyleft, NetworkCopyleft, SourceAvailable, Unknown}
+}
+
+// Valid reports whether category is one of the categories returned by [All].
+func Valid(category string) bool {
+	for _, c := range All() {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+var categories = map[string]string{
+	"0BSD":                          PublicDomain,
+	"CC0-1.0":                       PublicDomain,
+	"Unlicense":                     PublicDomain,
+	"WTFPL":                         PublicDomain,
+	"AFL-3.0":                       Permissive,
+	"Apache-1.1":                    Permissive,
+	"Apache-2.0":                    Permissive,
+	"Artistic-2.0":                  Permissive,
+	"BlueOak-1.0.0":                 Permissive,
+	"BSD-1-Clause":                  Permissive,
+	"BSD-2-Clause":                  Permissive,
+	"BSD-3-Clause":                  Permissive,
+	"BSD-3-Clause-Clear":            Permissive,
+	"BSL-1.0":                       Permissive,
+	"CC-BY-4.0":                     Permissive,
+	"ISC":                           Permissive,
+	"MIT":                           Permissive,
+	"MIT-0":                         Permissive,
+	"NCSA":                          Permissive,
+	"PostgreSQL":                    Permissive,
+	"PSF-2.0":                       Permissive,
+	"Python-2.0":                    Permissive,
+	"Unicode-3.0":                   Permissive,
+	"Unicode-DFS-2016":              Permissive,
+	"UPL-1.0":                       Permissive,
+	"X11":                           Permissive,
+	"Zlib":                          Permissive,
+	"CDDL-1.0":                      WeakCopyleft,
+	"CDDL-1.1":                      WeakCopyleft,
+	"CPL-1.0":                       WeakCopyleft,
+	"EPL-1.0":                       WeakCopyleft,
+	"EPL-2.0":                       WeakCopyleft,
+	"LGPL-2.0-only":                 WeakCopyleft,
+	"LGPL-2.0-or-later":             WeakCopyleft,
+	"LGPL-2.1-only":                 WeakCopyleft,
+	"LGPL-2.1-or-later":             WeakCopyleft,
+	"LGPL-3.0-only":                 WeakCopyleft,
+	"LGPL-3.0-or-later":             WeakCopyleft,
+	"MPL-1.1":                       WeakCopyleft,
+	"MPL-2.0":                       WeakCopyleft,
+	"CC-BY-SA-4.0":                  Copyleft,
+	"EUPL-1.1":                      Copyleft,
+	"EUPL-1.2":                      Copyleft,
+	"GPL-2.0-only":                  Copyleft,
+	"GPL-2.0-or-later":              Copyleft,
+	"GPL-3.0-only":                  Copyleft,
+	"GPL-3.0-or-later":              Copyleft,
+	"OSL-3.0":                       Copyleft,
+	"AGPL-1.0-only":                 NetworkCopyleft,
+	"AGPL-1.0-or-later":             NetworkCopyleft,
+	"AGPL-3.0-only":                 NetworkCopyleft,
+	"AGPL-3.0-or-later":             NetworkCopyleft,
+	"BUSL-1.1":                      SourceAvailable,
+	"CC-BY-NC-4.0":                  SourceAvailable,
+	"Elastic-2.0":                   SourceAvailable,
+	"PolyForm-Noncommercial-1.0.0":  SourceAvailable,
+	"PolyForm-Small-Business-1.0.0": SourceAvailable,
+	"SSPL-1.0":                      SourceAvailable,
+}
+
+// Of returns the category of the license with the provided SPDX identifier. Matching is case-insensitive.
+func Of(id string) string {
+	if c, ok := categories[id]; ok {
+		return c
+	}
+	for k, c := range categories {
+		if strings.EqualFold(k, id) {
+			return c
+		}
+	}
+	return Unknown
+}
@@ -0,0 +1,45 @@
+package licensecategory
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestOf(t *testing.T) {
+	tests := []struct {
+		id   string
+		want string
+	}{
+		{"MIT", Permissive},
+		{"mit", Permissive},
+		{"Apache-2.0", Permissive},
+		{"CC0-1.0", PublicDomain},
+		{"MPL-2.0", WeakCopyleft},
+		{"GPL-3.0-only", Copyleft},
+		{"AGPL-3.0-or-later", NetworkCopyleft},
+		{"SSPL-1.0", SourceAvailable},
+		{"BUSL-1.1", SourceAvailable},
+		{"MIT OR Apache-2.0", Unknown},
+		{"", Unknown},
+		{"Unknown", Unknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			require.Equal(t, tt.want, Of(tt.id))
+		})
+	}
+}
+
+func TestValid(t *testing.T) {
+	for _, c := range All() {
+		require.True(t, Valid(c))
+	}
+	require.False(t, Valid("invalid"))
+	require.False(t, Valid(""))
+}
+
+func TestCategoriesAreValid(t *testing.T) {
+	for id, c := range categories {
+		require.True(t, Valid(c), id)
+	}
+}
@@ -0,0 +1,26 @@
+package lockfile
+
+import (
+	"github.com/BurntSushi/toml"
+	"github.com/chainalysis-oss/oslc"
+	"strings"
+)
+
+// parseCargoLock parses a Cargo.lock file. Packages that are not from the crates.io registry, such as members of the
+// workspace and git dependencies, are skipped.
+func parseCargoLock(data []byte) ([]Package, error) {
+	var lock tomlLock
+	if err := toml.Unmarshal(data, &lock); err != nil {
+		return nil, err
+	}
+	var packages []Package
+	for _, p := range lock.Package {
+		source, _ := p.Source.(string)
+		if !strings.HasPrefix(source, "registry+https://github.com/rust-lang/crates.io-index") &&
+			!strings.HasPrefix(source, "sparse+https://index.crates.io/") {
+			continue
+		}
+		packages = append(packages, Package{Distributor: oslc.DistributorCratesIo, Name: p.Name, Version: p.Version})
+	}
+	return packages, nil
+}
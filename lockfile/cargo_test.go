@@ -0,0 +1,39 @@
+package lockfile
+
+import (
+	"github.com/chainalysis-oss/oslc"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParseCargoLock(t *testing.T) {
+	packages, err := parseCargoLock([]byte(`
+version = 3
+
+[[package]]
+name = "app"
+version = "0.1.0"
+dependencies = ["serde"]
+
+[[package]]
+name = "serde"
+version = "1.0.197"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+checksum = "abc"
+
+[[package]]
+name = "anyhow"
+version = "1.0.81"
+source = "sparse+https://index.crates.io/"
+
+[[package]]
+name = "forked"
+version = "0.1.0"
+source = "git+https://github.com/example/forked#abc"
+`))
+	require.NoError(t, err)
+	require.Equal(t, []Package{
+		{oslc.DistributorCratesIo, "serde", "1.0.197"},
+		{oslc.DistributorCratesIo, "anyhow", "1.0.81"},
+	}, packages)
+}
@@ -0,0 +1,30 @@
+package lockfile
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"github.com/chainalysis-oss/oslc"
+	"strings"
+)
+
+// parseGoSum parses a go.sum file. Modules only listed with the checksum of their go.mod file are skipped, since their
+// source code is not part of the build.
+func parseGoSum(data []byte) ([]Package, error) {
+	var packages []Package
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("line %d: expected module, version and checksum", n)
+		}
+		if strings.HasSuffix(fields[1], "/go.mod") {
+			continue
+		}
+		packages = append(packages, Package{Distributor: oslc.DistributorGo, Name: fields[0], Version: fields[1]})
+	}
+	return packages, scanner.Err()
+}
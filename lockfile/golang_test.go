@@ -0,0 +1,20 @@
+package lockfile
+
+import (
+	"github.com/chainalysis-oss/oslc"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParseGoSum(t *testing.T) {
+	packages, err := parseGoSum([]byte(`github.com/stretchr/testify v1.10.0 h1:abc=
+github.com/stretchr/testify v1.10.0/go.mod h1:def=
+golang.org/x/text v0.3.0/go.mod h1:ghi=
+
+`))
+	require.NoError(t, err)
+	require.Equal(t, []Package{{oslc.DistributorGo, "github.com/stretchr/testify", "v1.10.0"}}, packages)
+
+	_, err = parseGoSum([]byte("github.com/stretchr/testify v1.10.0\n"))
+	require.ErrorContains(t, err, "line 1")
+}
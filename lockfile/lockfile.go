@@ -0,0 +1,94 @@
+// Package lockfile reads the packages a project depends on from lockfiles and software bills of materials (SBOMs).
+//
+// The supported formats are npm's package-lock.json and npm-shrinkwrap.json, pip's requirements.txt, poetry.lock,
+// Cargo.lock, go.sum, and CycloneDX and SPDX SBOMs in JSON. Packages in SBOMs are identified by their package URL, and
+// are only read if it refers to a distributor supported by OSLC.
+package lockfile
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/chainalysis-oss/oslc/versions"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Package is a package a project depends on.
+type Package struct {
+	Distributor string
+	Name        string
+	// Version is the version of the package. For formats that need not pin versions, such as requirements.txt, it may
+	// be a version constraint, or empty if any version is allowed.
+	Version string
+}
+
+// ErrUnsupportedFormat is returned by [Parse] when the format of a file is not recognized.
+var ErrUnsupportedFormat = errors.New("unsupported lockfile format")
+
+// Parse returns the packages in the lockfile or SBOM with the provided file name and contents. The format is detected
+// from the file name, and for JSON files whose name is not recognized, from their contents.
+//
+// Packages are returned sorted by distributor, name and version, without duplicates.
+func Parse(name string, data []byte) ([]Package, error) {
+	parse, err := parserFor(filepath.Base(name), data)
+	if err != nil {
+		return nil, err
+	}
+	packages, err := parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filepath.Base(name), err)
+	}
+	return sortPackages(packages), nil
+}
+
+func parserFor(base string, data []byte) (func([]byte) ([]Package, error), error) {
+	switch {
+	case base == "package-lock.json", base == "npm-shrinkwrap.json":
+		return parsePackageLock, nil
+	case base == "poetry.lock":
+		return parsePoetryLock, nil
+	case base == "Cargo.lock":
+		return parseCargoLock, nil
+	case base == "go.sum":
+		return parseGoSum, nil
+	case strings.HasSuffix(base, ".txt") && strings.Contains(base, "requirements"):
+		return parseRequirements, nil
+	case strings.HasSuffix(base, ".json"):
+		return sbomParserFor(data)
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedFormat, base)
+}
+
+// sbomParserFor returns the parser for a JSON SBOM, detected from the fields identifying its format.
+func sbomParserFor(data []byte) (func([]byte) ([]Package, error), error) {
+	var header struct {
+		BOMFormat   string `json:"bomFormat"`
+		SPDXVersion string `json:"spdxVersion"`
+	}
+	if err := json.NewDecoder(bytes.NewReader(data)).Decode(&header); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnsupportedFormat, err)
+	}
+	switch {
+	case header.BOMFormat == "CycloneDX":
+		return parseCycloneDX, nil
+	case header.SPDXVersion != "":
+		return parseSPDX, nil
+	}
+	return nil, fmt.Errorf("%w: JSON file is neither a CycloneDX nor an SPDX document", ErrUnsupportedFormat)
+}
+
+func sortPackages(packages []Package) []Package {
+	slices.SortFunc(packages, func(a, b Package) int {
+		return cmp.Or(
+			cmp.Compare(a.Distributor, b.Distributor),
+			cmp.Compare(a.Name, b.Name),
+			versions.Comparer(a.Distributor)(a.Version, b.Version),
+			cmp.Compare(a.Version, b.Version),
+		)
+	})
+	return slices.Compact(packages)
+}
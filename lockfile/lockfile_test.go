@@ -0,0 +1,74 @@
+package lockfile
+
+import (
+	"github.com/chainalysis-oss/oslc"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		data string
+		want []Package
+	}{
+		{"package-lock.json", "app/package-lock.json", `{"packages": {"node_modules/a": {"version": "1.0.0"}}}`, []Package{{oslc.DistributorNpm, "a", "1.0.0"}}},
+		{"npm-shrinkwrap.json", "npm-shrinkwrap.json", `{"packages": {"node_modules/a": {"version": "1.0.0"}}}`, []Package{{oslc.DistributorNpm, "a", "1.0.0"}}},
+		{"requirements.txt", "requirements.txt", "a==1.0.0\n", []Package{{oslc.DistributorPypi, "a", "1.0.0"}}},
+		{"requirements-dev.txt", "requirements-dev.txt", "a==1.0.0\n", []Package{{oslc.DistributorPypi, "a", "1.0.0"}}},
+		{"poetry.lock", "poetry.lock", "[[package]]\nname = \"a\"\nversion = \"1.0.0\"\n", []Package{{oslc.DistributorPypi, "a", "1.0.0"}}},
+		{"Cargo.lock", "Cargo.lock", "[[package]]\nname = \"a\"\nversion = \"1.0.0\"\nsource = \"registry+https://github.com/rust-lang/crates.io-index\"\n", []Package{{oslc.DistributorCratesIo, "a", "1.0.0"}}},
+		{"go.sum", "go.sum", "example.com/a v1.0.0 h1:abc=\n", []Package{{oslc.DistributorGo, "example.com/a", "v1.0.0"}}},
+		{"CycloneDX", "bom.json", `{"bomFormat": "CycloneDX", "components": [{"purl": "pkg:npm/a@1.0.0"}]}`, []Package{{oslc.DistributorNpm, "a", "1.0.0"}}},
+		{"SPDX", "sbom.spdx.json", `{"spdxVersion": "SPDX-2.3", "packages": [{"externalRefs": [{"referenceType": "purl", "referenceLocator": "pkg:npm/a@1.0.0"}]}]}`, []Package{{oslc.DistributorNpm, "a", "1.0.0"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			packages, err := Parse(tt.file, []byte(tt.data))
+			require.NoError(t, err)
+			require.Equal(t, tt.want, packages)
+		})
+	}
+}
+
+func TestParse_sortsAndRemovesDuplicates(t *testing.T) {
+	packages, err := Parse("package-lock.json", []byte(`{"packages": {
+		"node_modules/b": {"version": "1.0.0"},
+		"node_modules/a": {"version": "1.10.0"},
+		"node_modules/b/node_modules/a": {"version": "1.9.0"},
+		"node_modules/c/node_modules/a": {"version": "1.10.0"}
+	}}`))
+	require.NoError(t, err)
+	require.Equal(t, []Package{
+		{oslc.DistributorNpm, "a", "1.9.0"},
+		{oslc.DistributorNpm, "a", "1.10.0"},
+		{oslc.DistributorNpm, "b", "1.0.0"},
+	}, packages)
+}
+
+func TestParse_errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		file        string
+		data        string
+		unsupported bool
+	}{
+		{"unknown file", "Gemfile.lock", "", true},
+		{"unknown JSON", "data.json", `{"name": "a"}`, true},
+		{"invalid JSON", "data.json", `{`, true},
+		{"invalid package-lock.json", "package-lock.json", `{`, false},
+		{"invalid Cargo.lock", "Cargo.lock", `[[package]`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.file, []byte(tt.data))
+			require.Error(t, err)
+			if tt.unsupported {
+				require.ErrorIs(t, err, ErrUnsupportedFormat)
+			} else {
+				require.NotErrorIs(t, err, ErrUnsupportedFormat)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package lockfile
+
+import (
+	"encoding/json"
+	"github.com/chainalysis-oss/oslc"
+	"strings"
+)
+
+type packageLock struct {
+	// Packages is used by lockfile versions 2 and 3, and maps the path of each installed package to the package.
+	Packages map[string]packageLockEntry `json:"packages"`
+	// Dependencies is used by lockfile version 1, and maps the name of each package to the package.
+	Dependencies map[string]packageLockEntry `json:"dependencies"`
+}
+
+type packageLockEntry struct {
+	// Name is only set for packages installed under a different name, such as aliases.
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	// Link is set for packages that are symbolic links to a directory, such as workspace members.
+	Link         bool                        `json:"link"`
+	Dependencies map[string]packageLockEntry `json:"dependencies"`
+}
+
+// parsePackageLock parses an npm package-lock.json or npm-shrinkwrap.json file.
+func parsePackageLock(data []byte) ([]Package, error) {
+	var lock packageLock
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, err
+	}
+	var packages []Package
+	if len(lock.Packages) > 0 {
+		for path, entry := range lock.Packages {
+			// The empty path is the project itself, and paths outside node_modules are workspace members.
+			i := strings.LastIndex(path, "node_modules/")
+			if i < 0 || entry.Link || entry.Version == "" {
+				continue
+			}
+			name := entry.Name
+			if name == "" {
+				name = path[i+len("node_modules/"):]
+			}
+			packages = append(packages, Package{Distributor: oslc.DistributorNpm, Name: name, Version: entry.Version})
+		}
+		return packages, nil
+	}
+	return appendPackageLockDependencies(packages, lock.Dependencies), nil
+}
+
+// appendPackageLockDependencies appends the packages of a version 1 lockfile, in which nested dependencies are nested
+// within the package depending on them.
+func appendPackageLockDependencies(packages []Package, dependencies map[string]packageLockEntry) []Package {
+	for name, entry := range dependencies {
+		if name, version, ok := packageLockVersion(name, entry.Version); ok {
+			packages = append(packages, Package{Distributor: oslc.DistributorNpm, Name: name, Version: version})
+		}
+		packages = appendPackageLockDependencies(packages, entry.Dependencies)
+	}
+	return packages
+}
+
+// packageLockVersion returns the name and version of a package in a version 1 lockfile. Aliases have versions in the
+// form "npm:name@version", which are resolved to the aliased package. The boolean is false for dependencies that are
+// not installed from the registry, whose versions are URLs or paths, such as "file:../lib".
+func packageLockVersion(name, version string) (string, string, bool) {
+	if alias, ok := strings.CutPrefix(version, "npm:"); ok {
+		at := strings.LastIndex(alias, "@")
+		if at <= 0 {
+			return "", "", false
+		}
+		return alias[:at], alias[at+1:], true
+	}
+	if version == "" || strings.Contains(version, ":") {
+		return "", "", false
+	}
+	return name, version, true
+}
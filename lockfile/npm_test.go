@@ -0,0 +1,57 @@
+package lockfile
+
+import (
+	"github.com/chainalysis-oss/oslc"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParsePackageLock(t *testing.T) {
+	packages, err := parsePackageLock([]byte(`{
+		"name": "app",
+		"lockfileVersion": 3,
+		"packages": {
+			"": {"name": "app", "version": "1.0.0"},
+			"node_modules/lodash": {"version": "4.17.21"},
+			"node_modules/@babel/core": {"version": "7.24.0"},
+			"node_modules/@babel/core/node_modules/semver": {"version": "6.3.1"},
+			"node_modules/old-lodash": {"name": "lodash", "version": "3.10.1"},
+			"node_modules/workspace-member": {"resolved": "packages/member", "link": true},
+			"packages/member": {"name": "member", "version": "0.1.0"}
+		}
+	}`))
+	require.NoError(t, err)
+	require.ElementsMatch(t, []Package{
+		{oslc.DistributorNpm, "lodash", "4.17.21"},
+		{oslc.DistributorNpm, "@babel/core", "7.24.0"},
+		{oslc.DistributorNpm, "semver", "6.3.1"},
+		{oslc.DistributorNpm, "lodash", "3.10.1"},
+	}, packages)
+}
+
+func TestParsePackageLock_version1(t *testing.T) {
+	packages, err := parsePackageLock([]byte(`{
+		"name": "app",
+		"lockfileVersion": 1,
+		"dependencies": {
+			"lodash": {"version": "4.17.21"},
+			"old-lodash": {"version": "npm:lodash@3.10.1"},
+			"@babel/core": {
+				"version": "7.24.0",
+				"dependencies": {"semver": {"version": "6.3.1"}}
+			},
+			"lib": {
+				"version": "file:../lib",
+				"dependencies": {"left-pad": {"version": "1.3.0"}}
+			}
+		}
+	}`))
+	require.NoError(t, err)
+	require.ElementsMatch(t, []Package{
+		{oslc.DistributorNpm, "lodash", "4.17.21"},
+		{oslc.DistributorNpm, "lodash", "3.10.1"},
+		{oslc.DistributorNpm, "@babel/core", "7.24.0"},
+		{oslc.DistributorNpm, "semver", "6.3.1"},
+		{oslc.DistributorNpm, "left-pad", "1.3.0"},
+	}, packages)
+}
@@ -0,0 +1,79 @@
+package lockfile
+
+import (
+	"bufio"
+	"bytes"
+	"github.com/BurntSushi/toml"
+	"github.com/chainalysis-oss/oslc"
+	"regexp"
+	"strings"
+)
+
+// requirementPattern matches a requirement specifier, capturing the name and the version specifier. Extras, such as
+// "[security]", are matched but not captured.
+var requirementPattern = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)\s*(?:\[[^\]]*\])?\s*((?:[=!<>~]=?=?\s*[^,\s]+\s*,?\s*)*)$`)
+
+// parseRequirements parses a pip requirements file. Requirements pinned with "==" or "===" have their version set to
+// the pinned version, while other version specifiers, such as ">=2.0,<3", are kept as version constraints. Options,
+// such as "-r other.txt", and requirements that are URLs or paths are skipped.
+func parseRequirements(data []byte) ([]Package, error) {
+	var packages []Package
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	var line string
+	for scanner.Scan() {
+		// A trailing backslash continues the requirement on the next line.
+		if continued, ok := strings.CutSuffix(scanner.Text(), `\`); ok {
+			line += continued + " "
+			continue
+		}
+		line += scanner.Text()
+		requirement := line
+		line = ""
+
+		requirement, _, _ = strings.Cut(requirement, "#")
+		// Environment markers, such as `; python_version < "3.8"`, and options, such as "--hash", follow the specifier.
+		requirement, _, _ = strings.Cut(requirement, ";")
+		requirement, _, _ = strings.Cut(requirement, " --")
+		requirement = strings.TrimSpace(requirement)
+		if requirement == "" || strings.HasPrefix(requirement, "-") {
+			continue
+		}
+		m := requirementPattern.FindStringSubmatch(requirement)
+		if m == nil {
+			continue
+		}
+		version := strings.Join(strings.Fields(m[2]), "")
+		if pinned, ok := strings.CutPrefix(version, "==="); ok && !strings.Contains(pinned, ",") {
+			version = pinned
+		} else if pinned, ok := strings.CutPrefix(version, "=="); ok && !strings.ContainsAny(pinned, ",*") {
+			version = pinned
+		}
+		packages = append(packages, Package{Distributor: oslc.DistributorPypi, Name: m[1], Version: version})
+	}
+	return packages, scanner.Err()
+}
+
+type tomlLock struct {
+	Package []struct {
+		Name    string `toml:"name"`
+		Version string `toml:"version"`
+		Source  any    `toml:"source"`
+	} `toml:"package"`
+}
+
+// parsePoetryLock parses a poetry.lock file. Packages installed from a directory, file, URL or VCS repository rather
+// than a package index are skipped.
+func parsePoetryLock(data []byte) ([]Package, error) {
+	var lock tomlLock
+	if err := toml.Unmarshal(data, &lock); err != nil {
+		return nil, err
+	}
+	var packages []Package
+	for _, p := range lock.Package {
+		if source, ok := p.Source.(map[string]any); ok && source["type"] != "legacy" {
+			continue
+		}
+		packages = append(packages, Package{Distributor: oslc.DistributorPypi, Name: p.Name, Version: p.Version})
+	}
+	return packages, nil
+}
@@ -0,0 +1,72 @@
+package lockfile
+
+import (
+	"github.com/chainalysis-oss/oslc"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParseRequirements(t *testing.T) {
+	packages, err := parseRequirements([]byte(`# Comment
+-r base.txt
+--index-url https://pypi.org/simple
+
+requests==2.31.0
+urllib3 == 2.2.1  # pinned
+Django>=4.2,<5
+charset-normalizer~=3.3
+cryptography[ssh]==42.0.5 ; python_version >= "3.8"
+idna==3.6 \
+    --hash=sha256:abc
+six
+numpy===1.26.4
+pytz==2024.*
+mypkg @ https://example.com/mypkg.tar.gz
+./local
+`))
+	require.NoError(t, err)
+	require.Equal(t, []Package{
+		{oslc.DistributorPypi, "requests", "2.31.0"},
+		{oslc.DistributorPypi, "urllib3", "2.2.1"},
+		{oslc.DistributorPypi, "Django", ">=4.2,<5"},
+		{oslc.DistributorPypi, "charset-normalizer", "~=3.3"},
+		{oslc.DistributorPypi, "cryptography", "42.0.5"},
+		{oslc.DistributorPypi, "idna", "3.6"},
+		{oslc.DistributorPypi, "six", ""},
+		{oslc.DistributorPypi, "numpy", "1.26.4"},
+		{oslc.DistributorPypi, "pytz", "==2024.*"},
+	}, packages)
+}
+
+func TestParsePoetryLock(t *testing.T) {
+	packages, err := parsePoetryLock([]byte(`
+[[package]]
+name = "requests"
+version = "2.31.0"
+
+[[package]]
+name = "internal"
+version = "1.0.0"
+
+[package.source]
+type = "legacy"
+url = "https://pypi.example.com/simple"
+reference = "private"
+
+[[package]]
+name = "local"
+version = "0.1.0"
+
+[package.source]
+type = "directory"
+url = "../local"
+
+[metadata]
+lock-version = "2.0"
+`))
+	require.NoError(t, err)
+	require.Equal(t, []Package{
+		{oslc.DistributorPypi, "requests", "2.31.0"},
+		{oslc.DistributorPypi, "internal", "1.0.0"},
+	}, packages)
+}
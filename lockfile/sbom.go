@@ -0,0 +1,70 @@
+package lockfile
+
+import (
+	"encoding/json"
+	"github.com/chainalysis-oss/oslc/purl"
+)
+
+type cycloneDXComponent struct {
+	PURL       string               `json:"purl"`
+	Components []cycloneDXComponent `json:"components"`
+}
+
+// parseCycloneDX parses a CycloneDX SBOM in JSON. Components are read from their package URL, including components
+// nested in other components.
+func parseCycloneDX(data []byte) ([]Package, error) {
+	var bom struct {
+		Components []cycloneDXComponent `json:"components"`
+	}
+	if err := json.Unmarshal(data, &bom); err != nil {
+		return nil, err
+	}
+	var packages []Package
+	var appendComponents func(components []cycloneDXComponent)
+	appendComponents = func(components []cycloneDXComponent) {
+		for _, c := range components {
+			if p, err := parsePURL(c.PURL); err == nil {
+				packages = append(packages, p)
+			}
+			appendComponents(c.Components)
+		}
+	}
+	appendComponents(bom.Components)
+	return packages, nil
+}
+
+// parseSPDX parses an SPDX document in JSON. Packages are read from their package URL external references.
+func parseSPDX(data []byte) ([]Package, error) {
+	var doc struct {
+		Packages []struct {
+			ExternalRefs []struct {
+				ReferenceType    string `json:"referenceType"`
+				ReferenceLocator string `json:"referenceLocator"`
+			} `json:"externalRefs"`
+		} `json:"packages"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	var packages []Package
+	for _, p := range doc.Packages {
+		for _, ref := range p.ExternalRefs {
+			if ref.ReferenceType != "purl" {
+				continue
+			}
+			if p, err := parsePURL(ref.ReferenceLocator); err == nil {
+				packages = append(packages, p)
+			}
+		}
+	}
+	return packages, nil
+}
+
+// parsePURL returns the package identified by a package URL.
+func parsePURL(s string) (Package, error) {
+	p, err := purl.Parse(s)
+	if err != nil {
+		return Package{}, err
+	}
+	return Package(p), nil
+}
@@ -0,0 +1,48 @@
+package lockfile
+
+import (
+	"github.com/chainalysis-oss/oslc"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParseCycloneDX(t *testing.T) {
+	packages, err := parseCycloneDX([]byte(`{
+		"bomFormat": "CycloneDX",
+		"specVersion": "1.5",
+		"components": [
+			{"type": "library", "name": "core", "purl": "pkg:npm/%40babel/core@7.24.0"},
+			{"type": "library", "name": "guava", "purl": "pkg:maven/com.google.guava/guava@33.0.0-jre?type=jar",
+				"components": [{"type": "library", "purl": "pkg:pypi/requests@2.31.0"}]},
+			{"type": "library", "name": "rails", "purl": "pkg:gem/rails@7.1.3"},
+			{"type": "application", "name": "no purl"}
+		]
+	}`))
+	require.NoError(t, err)
+	require.Equal(t, []Package{
+		{oslc.DistributorNpm, "@babel/core", "7.24.0"},
+		{oslc.DistributorMaven, "com.google.guava:guava", "33.0.0-jre"},
+		{oslc.DistributorPypi, "requests", "2.31.0"},
+	}, packages)
+}
+
+func TestParseSPDX(t *testing.T) {
+	packages, err := parseSPDX([]byte(`{
+		"spdxVersion": "SPDX-2.3",
+		"packages": [
+			{"name": "serde", "externalRefs": [
+				{"referenceCategory": "SECURITY", "referenceType": "cpe23Type", "referenceLocator": "cpe:2.3:a:serde:serde:1.0.197"},
+				{"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:cargo/serde@1.0.197"}
+			]},
+			{"name": "testify", "externalRefs": [
+				{"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:golang/github.com/stretchr/testify@v1.10.0"}
+			]},
+			{"name": "no refs"}
+		]
+	}`))
+	require.NoError(t, err)
+	require.Equal(t, []Package{
+		{oslc.DistributorCratesIo, "serde", "1.0.197"},
+		{oslc.DistributorGo, "github.com/stretchr/testify", "v1.10.0"},
+	}, packages)
+}
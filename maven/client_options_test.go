@@ -61,3 +61,10 @@ func TestWithHTTPClient(t *testing.T) {
 	f.apply(&opts)
 	require.Equal(t, client, opts.HttpClient)
 }
+
+func TestWithBaseURL(t *testing.T) {
+	opts := clientOptions{}
+	f := WithBaseURL("https://mirror.example.com")
+	f.apply(&opts)
+	require.Equal(t, "https://mirror.example.com", opts.BaseURL)
+}
@@ -1,6 +1,7 @@
 package maven
 
 import (
+	"context"
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
@@ -74,6 +75,16 @@ func NewClient(options ...ClientOption) (*Client, error) {
 	}, nil
 }
 
+// invalidNameError returns the error for a name that is not of the form groupId:artifactId. As no package can have such
+// a name, the error wraps [oslc.ErrNoSuchPackage].
+func invalidNameError(name string) oslc.DistributorError {
+	return oslc.DistributorError{
+		Distributor: oslc.DistributorMaven,
+		Kind:        oslc.DistributorErrorInvalidName,
+		Err:         fmt.Errorf("%w: name %q is not of the form groupId:artifactId", oslc.ErrNoSuchPackage, name),
+	}
+}
+
 func nameIsValid(name string) bool {
 	s := strings.Split(name, ":")
 	if len(s) != 2 {
@@ -87,9 +98,9 @@ func nameIsValid(name string) bool {
 
 // GetPackageVersion returns the package with the given name and version.
 // If version is empty, the latest version is returned.
-func (c *Client) GetPackageVersion(name, version string) (oslc.Entry, error) {
+func (c *Client) GetPackageVersion(ctx context.Context, name, version string) (oslc.Entry, error) {
 	if !nameIsValid(name) {
-		return oslc.Entry{}, fmt.Errorf("%w: %s", oslc.ErrNoSuchPackage, name)
+		return oslc.Entry{}, invalidNameError(name)
 	}
 	if version == "latest" {
 		version = ""
@@ -103,22 +114,22 @@ func (c *Client) GetPackageVersion(name, version string) (oslc.Entry, error) {
 	normGroupId := strings.ReplaceAll(groupId, ".", "/")
 	artifactId = strings.Split(name, ":")[1]
 	if version == "" {
-		version, err = c.getLatestVersion(groupId, artifactId)
+		version, err = c.getLatestVersion(ctx, groupId, artifactId)
 		if err != nil {
-			return oslc.Entry{}, oslc.DistributorError{Distributor: oslc.DistributorMaven, Err: err}
+			return oslc.Entry{}, oslc.WrapDistributorError(oslc.DistributorMaven, err)
 		}
 	}
 	path := fmt.Sprintf("remotecontent?filepath=%s/%s/%s/%s-%s.pom", normGroupId, artifactId, version, artifactId, version)
-	resp, err := c.options.HttpClient.Query(fmt.Sprintf("%s/%s", c.options.BaseURL, path))
+	resp, err := c.options.HttpClient.Query(ctx, fmt.Sprintf("%s/%s", c.options.BaseURL, path))
 	if err != nil {
-		return oslc.Entry{}, oslc.DistributorError{Distributor: oslc.DistributorMaven, Err: err}
+		return oslc.Entry{}, oslc.NewTransportError(oslc.DistributorMaven, err)
 	}
 
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
-		ok, err := c.doesPackageExist(groupId, artifactId)
+		ok, err := c.doesPackageExist(ctx, groupId, artifactId)
 		if err != nil {
-			return oslc.Entry{}, oslc.DistributorError{Distributor: oslc.DistributorMaven, Err: err}
+			return oslc.Entry{}, oslc.WrapDistributorError(oslc.DistributorMaven, err)
 		}
 		if !ok {
 			return oslc.Entry{}, oslc.DistributorError{Distributor: oslc.DistributorMaven, Err: fmt.Errorf("%w: %s", oslc.ErrNoSuchPackage, name)}
@@ -136,8 +147,45 @@ func (c *Client) GetPackageVersion(name, version string) (oslc.Entry, error) {
 
 // GetPackage returns the package with the given name. It is a convenience function for [GetPackageVersion]
 // with an empty version.
-func (c *Client) GetPackage(name string) (oslc.Entry, error) {
-	return c.GetPackageVersion(name, "")
+func (c *Client) GetPackage(ctx context.Context, name string) (oslc.Entry, error) {
+	return c.GetPackageVersion(ctx, name, "")
+}
+
+// mavenMetadata is the artifact-level maven-metadata.xml document, which lists the versions of an artifact.
+type mavenMetadata struct {
+	Versioning struct {
+		Versions []string `xml:"versions>version"`
+	} `xml:"versioning"`
+}
+
+// ListVersions returns the versions listed in the repository metadata of the package with the given name.
+func (c *Client) ListVersions(ctx context.Context, name string) ([]string, error) {
+	if !nameIsValid(name) {
+		return nil, invalidNameError(name)
+	}
+	groupId, artifactId, _ := strings.Cut(name, ":")
+	path := fmt.Sprintf("remotecontent?filepath=%s/%s/maven-metadata.xml", strings.ReplaceAll(groupId, ".", "/"), artifactId)
+	resp, err := c.options.HttpClient.Query(ctx, fmt.Sprintf("%s/%s", c.options.BaseURL, path))
+	if err != nil {
+		return nil, oslc.NewTransportError(oslc.DistributorMaven, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, oslc.DistributorError{Distributor: oslc.DistributorMaven, Err: fmt.Errorf("%w: %s", oslc.ErrNoSuchPackage, name)}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, oslc.NewStatusError(oslc.DistributorMaven, resp, fmt.Errorf("unexpected status code: %d", resp.StatusCode))
+	}
+
+	var metadata mavenMetadata
+	if err := xml.NewDecoder(resp.Body).Decode(&metadata); err != nil {
+		return nil, oslc.DistributorError{Distributor: oslc.DistributorMaven, Err: err}
+	}
+	if metadata.Versioning.Versions == nil {
+		return []string{}, nil
+	}
+	return metadata.Versioning.Versions, nil
 }
 
 type solrResponse struct {
@@ -153,15 +201,15 @@ type solrResponse struct {
 	} `json:"response"`
 }
 
-func (c *Client) doesPackageExist(groupId, artifactId string) (bool, error) {
+func (c *Client) doesPackageExist(ctx context.Context, groupId, artifactId string) (bool, error) {
 	path := fmt.Sprintf("solrsearch/select?q=g:%s+AND+a:%s&rows=1&wt=json", groupId, artifactId)
-	resp, err := c.options.HttpClient.Query(fmt.Sprintf("%s/%s", c.options.BaseURL, path))
+	resp, err := c.options.HttpClient.Query(ctx, fmt.Sprintf("%s/%s", c.options.BaseURL, path))
 	if err != nil {
-		return false, err
+		return false, oslc.NewTransportError(oslc.DistributorMaven, err)
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
-		return false, fmt.Errorf("error determining if package exists: unexpected status code: %d", resp.StatusCode)
+		return false, oslc.NewStatusError(oslc.DistributorMaven, resp, fmt.Errorf("error determining if package exists: unexpected status code: %d", resp.StatusCode))
 	}
 
 	var pkg solrResponse
@@ -175,15 +223,15 @@ func (c *Client) doesPackageExist(groupId, artifactId string) (bool, error) {
 	return true, nil
 }
 
-func (c *Client) getLatestVersion(groupId, artifactId string) (string, error) {
+func (c *Client) getLatestVersion(ctx context.Context, groupId, artifactId string) (string, error) {
 	path := fmt.Sprintf("solrsearch/select?q=g:%s+AND+a:%s&rows=1&wt=json", groupId, artifactId)
-	resp, err := c.options.HttpClient.Query(fmt.Sprintf("%s/%s", c.options.BaseURL, path))
+	resp, err := c.options.HttpClient.Query(ctx, fmt.Sprintf("%s/%s", c.options.BaseURL, path))
 	if err != nil {
-		return "", err
+		return "", oslc.NewTransportError(oslc.DistributorMaven, err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("error getting latest version: unexpected status code: %d", resp.StatusCode)
+		return "", oslc.NewStatusError(oslc.DistributorMaven, resp, fmt.Errorf("error getting latest version: unexpected status code: %d", resp.StatusCode))
 	}
 
 	defer resp.Body.Close()
@@ -2,6 +2,7 @@ package maven
 
 import (
 	"bytes"
+	"context"
 	"github.com/chainalysis-oss/oslc"
 	ownHTTP "github.com/chainalysis-oss/oslc/http"
 	"github.com/chainalysis-oss/oslc/httptestcorpus"
@@ -189,7 +190,7 @@ func TestClient_GetPackageVersion(t *testing.T) {
 	for _, tt := range testcases {
 		t.Run(tt.name, func(t *testing.T) {
 			c := setupClient(t, setupHttpClientWithBody(t, tt.body))
-			out, err := c.GetPackageVersion(tt.pkgName, tt.pkgVersion)
+			out, err := c.GetPackageVersion(context.Background(), tt.pkgName, tt.pkgVersion)
 			require.NoError(t, err)
 			require.Equal(t, tt.expected, out)
 		})
@@ -207,7 +208,7 @@ func TestClient_GetPackageVersion_latest(t *testing.T) {
 	httpClient, err := ownHTTP.NewClient(ownHTTP.WithHTTPClient(mock))
 	require.NoError(t, err)
 	c := setupClient(t, httpClient)
-	_, err = c.GetPackageVersion("testGroupId:testArtifactId", "latest")
+	_, err = c.GetPackageVersion(context.Background(), "testGroupId:testArtifactId", "latest")
 	assert.Error(t, err)
 }
 
@@ -222,7 +223,7 @@ func TestClient_GetPackageVersion_version_path(t *testing.T) {
 	httpClient, err := ownHTTP.NewClient(ownHTTP.WithHTTPClient(mock))
 	require.NoError(t, err)
 	c := setupClient(t, httpClient)
-	_, err = c.GetPackageVersion("testGroupId:testArtifactId", "1.0.0")
+	_, err = c.GetPackageVersion(context.Background(), "testGroupId:testArtifactId", "1.0.0")
 	require.NoError(t, err)
 }
 
@@ -233,7 +234,7 @@ func TestClient_GetPackageVersion_latest_version_error(t *testing.T) {
 	httpClient, err := ownHTTP.NewClient(ownHTTP.WithHTTPClient(mock))
 	require.NoError(t, err)
 	c := setupClient(t, httpClient)
-	_, err = c.GetPackageVersion("testGroupId:testArtifactId", "")
+	_, err = c.GetPackageVersion(context.Background(), "testGroupId:testArtifactId", "")
 	assert.Error(t, err)
 }
 
@@ -244,19 +245,19 @@ func TestClient_GetPackageVersion_http_client_error(t *testing.T) {
 	httpClient, err := ownHTTP.NewClient(ownHTTP.WithHTTPClient(mock))
 	require.NoError(t, err)
 	c := setupClient(t, httpClient)
-	_, err = c.GetPackageVersion("testGroupId:testArtifactId", "1.0.0")
+	_, err = c.GetPackageVersion(context.Background(), "testGroupId:testArtifactId", "1.0.0")
 	assert.Error(t, err)
 }
 
 func TestClient_GetPackageVersion_http_client_status_error(t *testing.T) {
 	c := setupClient(t, setupHttpClientWithStatusAndBody(t, http.StatusNotFound, ""))
-	_, err := c.GetPackageVersion("testGroupId:testArtifactId", "1.0.0")
+	_, err := c.GetPackageVersion(context.Background(), "testGroupId:testArtifactId", "1.0.0")
 	assert.Error(t, err)
 }
 
 func TestClient_GetPackageVersion_xml_decode_error(t *testing.T) {
 	c := setupClient(t, setupHttpClientWithBody(t, "test"))
-	_, err := c.GetPackageVersion("testGroupId:testArtifactId", "1.0.0")
+	_, err := c.GetPackageVersion(context.Background(), "testGroupId:testArtifactId", "1.0.0")
 	assert.Error(t, err)
 }
 
@@ -280,7 +281,7 @@ func TestClient_GetPackage(t *testing.T) {
 	httpClient, err := ownHTTP.NewClient(ownHTTP.WithHTTPClient(mock))
 	require.NoError(t, err)
 	c := setupClient(t, httpClient)
-	_, err = c.GetPackage("testGroupId:testArtifactId")
+	_, err = c.GetPackage(context.Background(), "testGroupId:testArtifactId")
 	require.NoError(t, err)
 }
 
@@ -294,7 +295,7 @@ func TestClient_GetLatestVersion_error_json_decode(t *testing.T) {
 	httpClient, err := ownHTTP.NewClient(ownHTTP.WithHTTPClient(mock))
 	require.NoError(t, err)
 	c := setupClient(t, httpClient)
-	_, err = c.getLatestVersion("testGroupId", "testArtifactId")
+	_, err = c.getLatestVersion(context.Background(), "testGroupId", "testArtifactId")
 	assert.Error(t, err)
 }
 
@@ -315,10 +316,91 @@ func TestClient_GetPackageVersion_invalid_package_names(t *testing.T) {
 			require.NoError(t, err)
 			client, err := NewClient(WithHTTPClient(httpClient))
 			require.NoError(t, err)
-			resp, err := client.GetPackageVersion(tc.packageName, "1.0.0")
+			resp, err := client.GetPackageVersion(context.Background(), tc.packageName, "1.0.0")
 			require.Empty(t, resp)
 			require.ErrorIs(t, err, oslc.ErrNoSuchPackage)
+			var de oslc.DistributorError
+			require.ErrorAs(t, err, &de)
+			require.Equal(t, oslc.DistributorErrorInvalidName, de.Kind)
 
 		})
 	}
 }
+
+func TestClient_ListVersions(t *testing.T) {
+	testcases := []struct {
+		name     string
+		status   int
+		body     string
+		expected []string
+		err      error
+	}{
+		{
+			name:     "versions",
+			status:   http.StatusOK,
+			body:     `<metadata><versioning><versions><version>1.0</version><version>1.1</version></versions></versioning></metadata>`,
+			expected: []string{"1.0", "1.1"},
+		},
+		{
+			name:     "no versions",
+			status:   http.StatusOK,
+			body:     `<metadata></metadata>`,
+			expected: []string{},
+		},
+		{
+			name:   "not found",
+			status: http.StatusNotFound,
+			err:    oslc.ErrNoSuchPackage,
+		},
+		{
+			name:   "unexpected status",
+			status: http.StatusInternalServerError,
+			err:    oslc.DistributorError{},
+		},
+		{
+			name:   "decode error",
+			status: http.StatusOK,
+			body:   "test",
+			err:    oslc.DistributorError{},
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.name, func(t *testing.T) {
+			c := setupClient(t, setupHttpClientWithStatusAndBody(t, tt.status, tt.body))
+			out, err := c.ListVersions(context.Background(), "testGroupId:testArtifactId")
+			if tt.err != nil {
+				require.ErrorAs(t, err, &oslc.DistributorError{})
+				if tt.err != (oslc.DistributorError{}) {
+					require.ErrorIs(t, err, tt.err)
+				}
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, out)
+		})
+	}
+}
+
+func TestClient_ListVersions_path(t *testing.T) {
+	mock := ownHTTP.NewTestHTTPClient(func(req *http.Request) (*http.Response, error) {
+		require.Equal(t, "https://search.maven.org/remotecontent?filepath=org/example/test/maven-metadata.xml", req.URL.String())
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBuffer([]byte(`<metadata></metadata>`))),
+		}, nil
+	})
+	httpClient, err := ownHTTP.NewClient(ownHTTP.WithHTTPClient(mock))
+	require.NoError(t, err)
+	c := setupClient(t, httpClient)
+	_, err = c.ListVersions(context.Background(), "org.example:test")
+	require.NoError(t, err)
+}
+
+func TestClient_ListVersions_invalid_name(t *testing.T) {
+	c := setupClient(t, setupHttpClientWithStatusAndBody(t, http.StatusOK, ""))
+	_, err := c.ListVersions(context.Background(), "invalid")
+	require.ErrorIs(t, err, oslc.ErrNoSuchPackage)
+	var de oslc.DistributorError
+	require.ErrorAs(t, err, &de)
+	require.Equal(t, oslc.DistributorErrorInvalidName, de.Kind)
+}
@@ -0,0 +1,58 @@
+package memory
+
+import (
+	"context"
+	"github.com/chainalysis-oss/oslc"
+	"slices"
+)
+
+// Compile time check to ensure Datastore implements [oslc.CurationStore].
+var _ oslc.CurationStore = (*Datastore)(nil)
+
+// overrideKey identifies a license override.
+type overrideKey struct {
+	tenant       string
+	distributor  string
+	name         string
+	versionRange string
+}
+
+// overrideKeyOf returns the key identifying the override.
+func overrideKeyOf(o oslc.LicenseOverride) overrideKey {
+	return overrideKey{tenant: o.Tenant, distributor: o.Distributor, name: o.Name, versionRange: o.VersionRange}
+}
+
+func (d *Datastore) SetOverride(_ context.Context, override oslc.LicenseOverride) (oslc.LicenseOverride, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	override.UpdatedAt = d.options.Now().UTC()
+	d.overrides[overrideKeyOf(override)] = override
+	return override, nil
+}
+
+func (d *Datastore) ListOverrides(_ context.Context, tenant, distributor, name string) ([]oslc.LicenseOverride, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	overrides := make([]oslc.LicenseOverride, 0)
+	for _, o := range d.overrides {
+		if o.Tenant == tenant && (distributor == "" || o.Distributor == distributor) && (name == "" || o.Name == name) {
+			overrides = append(overrides, o)
+		}
+	}
+	slices.SortFunc(overrides, func(a, b oslc.LicenseOverride) int { return b.UpdatedAt.Compare(a.UpdatedAt) })
+	return overrides, nil
+}
+
+func (d *Datastore) DeleteOverride(_ context.Context, tenant, distributor, name, versionRange string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	key := overrideKey{tenant: tenant, distributor: distributor, name: name, versionRange: versionRange}
+	if _, ok := d.overrides[key]; !ok {
+		return oslc.ErrDatastoreObjectNotFound
+	}
+	delete(d.overrides, key)
+	return nil
+}
@@ -0,0 +1,81 @@
+package memory
+
+import (
+	"context"
+	"github.com/chainalysis-oss/oslc"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestDatastore_SetOverride(t *testing.T) {
+	ds := newTestDatastore(t)
+	ctx := context.Background()
+	override := oslc.LicenseOverride{Distributor: oslc.DistributorPypi, Name: "requests", VersionRange: ">=2", License: "Apache-2.0", Justification: "LICENSE file", Author: "alice"}
+	saved, err := ds.SetOverride(ctx, override)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, 1, 2, 3, 4, 6, 0, time.UTC), saved.UpdatedAt)
+
+	override.License = "MIT"
+	replaced, err := ds.SetOverride(ctx, override)
+	require.NoError(t, err)
+
+	overrides, err := ds.ListOverrides(ctx, "", oslc.DistributorPypi, "requests")
+	require.NoError(t, err)
+	require.Equal(t, []oslc.LicenseOverride{replaced}, overrides)
+}
+
+func TestDatastore_ListOverrides(t *testing.T) {
+	ds := newTestDatastore(t)
+	ctx := context.Background()
+	first, err := ds.SetOverride(ctx, oslc.LicenseOverride{Distributor: oslc.DistributorPypi, Name: "requests", VersionRange: "*"})
+	require.NoError(t, err)
+	second, err := ds.SetOverride(ctx, oslc.LicenseOverride{Distributor: oslc.DistributorNpm, Name: "lodash", VersionRange: "*"})
+	require.NoError(t, err)
+
+	overrides, err := ds.ListOverrides(ctx, "", "", "")
+	require.NoError(t, err)
+	require.Equal(t, []oslc.LicenseOverride{second, first}, overrides)
+
+	overrides, err = ds.ListOverrides(ctx, "", oslc.DistributorNpm, "")
+	require.NoError(t, err)
+	require.Equal(t, []oslc.LicenseOverride{second}, overrides)
+
+	overrides, err = ds.ListOverrides(ctx, "", "", "missing")
+	require.NoError(t, err)
+	require.NotNil(t, overrides)
+	require.Empty(t, overrides)
+}
+
+func TestDatastore_DeleteOverride(t *testing.T) {
+	ds := newTestDatastore(t)
+	ctx := context.Background()
+	_, err := ds.SetOverride(ctx, oslc.LicenseOverride{Distributor: oslc.DistributorPypi, Name: "requests", VersionRange: ">=2"})
+	require.NoError(t, err)
+
+	require.NoError(t, ds.DeleteOverride(ctx, "", oslc.DistributorPypi, "requests", ">=2"))
+	require.ErrorIs(t, ds.DeleteOverride(ctx, "", oslc.DistributorPypi, "requests", ">=2"), oslc.ErrDatastoreObjectNotFound)
+}
+
+func TestDatastore_overrides_tenants(t *testing.T) {
+	ds := newTestDatastore(t)
+	ctx := context.Background()
+	shared, err := ds.SetOverride(ctx, oslc.LicenseOverride{Distributor: oslc.DistributorPypi, Name: "requests", VersionRange: "*", License: "MIT"})
+	require.NoError(t, err)
+	tenant, err := ds.SetOverride(ctx, oslc.LicenseOverride{Tenant: "payments", Distributor: oslc.DistributorPypi, Name: "requests", VersionRange: "*", License: "Apache-2.0"})
+	require.NoError(t, err)
+
+	// Overrides of the same package and version range are kept apart for each tenant.
+	overrides, err := ds.ListOverrides(ctx, "", "", "")
+	require.NoError(t, err)
+	require.Equal(t, []oslc.LicenseOverride{shared}, overrides)
+	overrides, err = ds.ListOverrides(ctx, "payments", "", "")
+	require.NoError(t, err)
+	require.Equal(t, []oslc.LicenseOverride{tenant}, overrides)
+
+	require.ErrorIs(t, ds.DeleteOverride(ctx, "research", oslc.DistributorPypi, "requests", "*"), oslc.ErrDatastoreObjectNotFound)
+	require.NoError(t, ds.DeleteOverride(ctx, "payments", oslc.DistributorPypi, "requests", "*"))
+	overrides, err = ds.ListOverrides(ctx, "", "", "")
+	require.NoError(t, err)
+	require.Equal(t, []oslc.LicenseOverride{shared}, overrides)
+}
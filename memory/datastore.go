@@ -0,0 +1,309 @@
+// Package memory implements the OSLC datastore in memory, for tests, demos and ephemeral runners that should not need
+// a database. The datastore can be persisted to a JSONL snapshot, so the cache survives restarts.
+package memory
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"github.com/chainalysis-oss/oslc"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// packageKey identifies a package of a distributor.
+type packageKey struct {
+	distributor string
+	name        string
+}
+
+// version is a stored version of a package.
+type version struct {
+	entry     oslc.Entry
+	fetchedAt time.Time
+	// seq orders versions by the time they were first saved.
+	seq int64
+}
+
+// Datastore is safe for concurrent use. Every operation holds a lock on the whole datastore, and searches scan every
+// stored version, so it is meant for catalogs of modest size.
+type Datastore struct {
+	options *datastoreOptions
+
+	mu       sync.RWMutex
+	packages map[packageKey]map[string]version
+	seq      int64
+
+	overrides map[overrideKey]oslc.LicenseOverride
+
+	subscriptions      map[int64]oslc.WebhookSubscription
+	deliveries         map[int64]*delivery
+	lastSubscriptionID int64
+	lastDeliveryID     int64
+
+	jobs map[int64]*job
+	// activeJobs holds the IDs of the pending and running jobs by their request, so every request has at most one.
+	activeJobs map[oslc.JobRequest]int64
+	lastJobID  int64
+}
+
+// NewDatastore creates a datastore. If a snapshot path is configured and the snapshot exists, the datastore is loaded
+// from it.
+func NewDatastore(options ...DatastoreOption) (*Datastore, error) {
+	opts := defaultDatastoreOptions
+	for _, opt := range globalDatastoreOptions {
+		opt.apply(&opts)
+	}
+	for _, opt := range options {
+		opt.apply(&opts)
+	}
+
+	if opts.SnapshotInterval <= 0 {
+		return nil, ErrInvalidSnapshotInterval
+	}
+
+	d := &Datastore{
+		options:       &opts,
+		packages:      make(map[packageKey]map[string]version),
+		overrides:     make(map[overrideKey]oslc.LicenseOverride),
+		subscriptions: make(map[int64]oslc.WebhookSubscription),
+		deliveries:    make(map[int64]*delivery),
+		jobs:          make(map[int64]*job),
+		activeJobs:    make(map[oslc.JobRequest]int64),
+	}
+	if opts.SnapshotPath != "" {
+		if err := d.load(); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
+// Compile time check to ensure Datastore implements [oslc.Datastore].
+var _ oslc.Datastore = (*Datastore)(nil)
+
+// cloneEntry returns a copy of e that does not share distribution points with it, so stored entries cannot be modified
+// by callers.
+func cloneEntry(e oslc.Entry) oslc.Entry {
+	e.DistributionPoints = slices.Clone(e.DistributionPoints)
+	return e
+}
+
+// Save stores the entry under the distributor of each of its distribution points, so it can be retrieved with any of
+// them. Every stored copy holds all distribution points of the entry. Entries without distribution points are not
+// stored, since they have no distributor.
+func (d *Datastore) Save(_ context.Context, entry oslc.Entry) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	fetchedAt := d.options.Now().UTC()
+	for _, dp := range entry.DistributionPoints {
+		key := packageKey{distributor: dp.Distributor, name: entry.Name}
+		versions, ok := d.packages[key]
+		if !ok {
+			versions = make(map[string]version)
+			d.packages[key] = versions
+		}
+		v, ok := versions[entry.Version]
+		if !ok {
+			d.seq++
+			v.seq = d.seq
+		}
+		v.entry = cloneEntry(entry)
+		v.fetchedAt = fetchedAt
+		versions[entry.Version] = v
+	}
+	return nil
+}
+
+func (d *Datastore) Retrieve(_ context.Context, name, version, distributor string) (oslc.Entry, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	v, ok := d.packages[packageKey{distributor: distributor, name: name}][version]
+	if !ok {
+		return oslc.Entry{}, oslc.ErrDatastoreObjectNotFound
+	}
+	return cloneEntry(v.entry), nil
+}
+
+// RetrieveVersions returns the versions in the order they were first saved.
+func (d *Datastore) RetrieveVersions(_ context.Context, name, distributor string) ([]oslc.Entry, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	versions := d.packages[packageKey{distributor: distributor, name: name}]
+	ordered := make([]version, 0, len(versions))
+	for _, v := range versions {
+		ordered = append(ordered, v)
+	}
+	slices.SortFunc(ordered, func(a, b version) int { return cmp.Compare(a.seq, b.seq) })
+
+	entries := make([]oslc.Entry, 0, len(ordered))
+	for _, v := range ordered {
+		entries = append(entries, cloneEntry(v.entry))
+	}
+	return entries, nil
+}
+
+// matchPattern reports whether name matches pattern, where an asterisk (*) matches any sequence of characters,
+// including none. All other characters must match exactly.
+func matchPattern(pattern, name string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == name
+	}
+	if !strings.HasPrefix(name, parts[0]) {
+		return false
+	}
+	name = name[len(parts[0]):]
+	last := parts[len(parts)-1]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(name, part)
+		if i < 0 {
+			return false
+		}
+		name = name[i+len(part):]
+	}
+	return strings.HasSuffix(name, last)
+}
+
+func (d *Datastore) Invalidate(_ context.Context, filter oslc.EntryFilter) (int64, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var n int64
+	for key, versions := range d.packages {
+		if filter.Distributor != "" && key.distributor != filter.Distributor {
+			continue
+		}
+		if filter.NamePattern != "" && !matchPattern(filter.NamePattern, key.name) {
+			continue
+		}
+		for ver, v := range versions {
+			if filter.Version != "" && ver != filter.Version {
+				continue
+			}
+			if filter.License != nil && v.entry.License != *filter.License {
+				continue
+			}
+			delete(versions, ver)
+			n++
+		}
+		if len(versions) == 0 {
+			delete(d.packages, key)
+		}
+	}
+	return n, nil
+}
+
+// inExpression reports whether license is one of the identifiers of the license expression.
+func inExpression(expression, license string) bool {
+	expression = strings.NewReplacer("(", " ", ")", " ").Replace(expression)
+	return strings.Contains(" "+expression+" ", " "+license+" ")
+}
+
+// compareCursors orders cursors by distributor, name and version.
+func compareCursors(a, b oslc.SearchCursor) int {
+	if c := strings.Compare(a.Distributor, b.Distributor); c != 0 {
+		return c
+	}
+	if c := strings.Compare(a.Name, b.Name); c != 0 {
+		return c
+	}
+	return strings.Compare(a.Version, b.Version)
+}
+
+// matchesSearch reports whether the stored entry matches every field of the query except Limit.
+func matchesSearch(query oslc.SearchQuery, e oslc.StoredEntry) bool {
+	if query.Distributor != "" && e.Distributor != query.Distributor {
+		return false
+	}
+	if !strings.HasPrefix(e.Name, query.NamePrefix) || !strings.Contains(e.Name, query.NameContains) {
+		return false
+	}
+	if query.License != nil && e.License != *query.License {
+		// An empty license is never part of an expression.
+		if !query.LicenseInExpression || *query.License == "" || !inExpression(e.License, *query.License) {
+			return false
+		}
+	}
+	if !query.FetchedAfter.IsZero() && e.FetchedAt.Before(query.FetchedAfter) {
+		return false
+	}
+	if !query.FetchedBefore.IsZero() && !e.FetchedAt.Before(query.FetchedBefore) {
+		return false
+	}
+	return query.After == nil || compareCursors(e.Cursor(), *query.After) > 0
+}
+
+// search returns every entry matching the query, ignoring query.Limit, in the order of search results.
+func (d *Datastore) search(query oslc.SearchQuery) []oslc.StoredEntry {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	entries := make([]oslc.StoredEntry, 0)
+	for key, versions := range d.packages {
+		for _, v := range versions {
+			e := oslc.StoredEntry{Entry: v.entry, Distributor: key.distributor, FetchedAt: v.fetchedAt}
+			if matchesSearch(query, e) {
+				e.Entry = cloneEntry(e.Entry)
+				entries = append(entries, e)
+			}
+		}
+	}
+	slices.SortFunc(entries, func(a, b oslc.StoredEntry) int { return compareCursors(a.Cursor(), b.Cursor()) })
+	return entries
+}
+
+func (d *Datastore) Search(_ context.Context, query oslc.SearchQuery) ([]oslc.StoredEntry, error) {
+	entries := d.search(query)
+	if len(entries) > query.Limit {
+		entries = entries[:max(query.Limit, 0)]
+	}
+	return entries, nil
+}
+
+// Compile time check to ensure Datastore implements [oslc.DatastoreIterator].
+var _ oslc.DatastoreIterator = (*Datastore)(nil)
+
+// Iterate sorts the matching entries once, rather than once per page of search results. The datastore is not locked
+// while f is called, so f may modify the datastore; entries saved after Iterate is called are not visited.
+func (d *Datastore) Iterate(ctx context.Context, query oslc.SearchQuery, f func(oslc.StoredEntry) error) error {
+	for _, e := range d.search(query) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := f(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *Datastore) CountPackages(_ context.Context) ([]oslc.PackageCount, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	type countKey struct {
+		distributor string
+		license     string
+		status      oslc.LicenseNormalizationStatus
+	}
+	byKey := make(map[countKey]int64)
+	for key, versions := range d.packages {
+		for _, v := range versions {
+			byKey[countKey{distributor: key.distributor, license: v.entry.License, status: v.entry.NormalizationStatus}]++
+		}
+	}
+
+	counts := make([]oslc.PackageCount, 0, len(byKey))
+	for key, count := range byKey {
+		counts = append(counts, oslc.PackageCount{Distributor: key.distributor, License: key.license, NormalizationStatus: key.status, Count: count})
+	}
+	return counts, nil
+}
+
+var ErrInvalidSnapshotInterval = errors.New("snapshot interval must be positive")
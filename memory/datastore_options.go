@@ -0,0 +1,68 @@
+package memory
+
+import (
+	"log/slog"
+	"time"
+)
+
+type datastoreOptions struct {
+	Logger *slog.Logger
+	// SnapshotPath is the path of the JSONL file the datastore is loaded from when it is created, and saved to by
+	// Snapshot. The datastore is not persisted if it is empty.
+	SnapshotPath string
+	// SnapshotInterval is the interval at which Run saves a snapshot.
+	SnapshotInterval time.Duration
+	// Now returns the current time. It is replaced in tests.
+	Now func() time.Time
+}
+
+var defaultDatastoreOptions = datastoreOptions{
+	Logger:           slog.Default(),
+	SnapshotInterval: 5 * time.Minute,
+	Now:              time.Now,
+}
+
+var globalDatastoreOptions []DatastoreOption
+
+// DatastoreOption is an option for configuring a Datastore.
+type DatastoreOption interface {
+	apply(*datastoreOptions)
+}
+
+// funcDatastoreOption is a DatastoreOption that calls a function.
+// It is used to wrap a function, so it satisfies the DatastoreOption interface.
+type funcDatastoreOption struct {
+	f func(*datastoreOptions)
+}
+
+func (fdo *funcDatastoreOption) apply(opts *datastoreOptions) {
+	fdo.f(opts)
+}
+
+func newFuncDatastoreOption(f func(*datastoreOptions)) *funcDatastoreOption {
+	return &funcDatastoreOption{
+		f: f,
+	}
+}
+
+// WithLogger returns a DatastoreOption that uses the provided logger.
+func WithLogger(logger *slog.Logger) DatastoreOption {
+	return newFuncDatastoreOption(func(opts *datastoreOptions) {
+		opts.Logger = logger
+	})
+}
+
+// WithSnapshotPath returns a DatastoreOption that persists the datastore to the JSONL file at path. If the file
+// exists, the datastore is loaded from it when it is created.
+func WithSnapshotPath(path string) DatastoreOption {
+	return newFuncDatastoreOption(func(opts *datastoreOptions) {
+		opts.SnapshotPath = path
+	})
+}
+
+// WithSnapshotInterval returns a DatastoreOption that sets the interval at which Run saves a snapshot.
+func WithSnapshotInterval(interval time.Duration) DatastoreOption {
+	return newFuncDatastoreOption(func(opts *datastoreOptions) {
+		opts.SnapshotInterval = interval
+	})
+}
@@ -0,0 +1,321 @@
+package memory
+
+import (
+	"context"
+	"github.com/chainalysis-oss/oslc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+)
+
+// clock returns a function that returns a time one second later every time it is called.
+func clock() func() time.Time {
+	var mu sync.Mutex
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	return func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		now = now.Add(time.Second)
+		return now
+	}
+}
+
+func withNow(now func() time.Time) DatastoreOption {
+	return newFuncDatastoreOption(func(opts *datastoreOptions) {
+		opts.Now = now
+	})
+}
+
+func newTestDatastore(t *testing.T, options ...DatastoreOption) *Datastore {
+	t.Helper()
+	ds, err := NewDatastore(append([]DatastoreOption{WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))), withNow(clock())}, options...)...)
+	require.NoError(t, err)
+	return ds
+}
+
+func testEntry(name, version, license string, distributors ...string) oslc.Entry {
+	e := oslc.Entry{Name: name, Version: version, License: license}
+	for _, distributor := range distributors {
+		e.DistributionPoints = append(e.DistributionPoints, oslc.DistributionPoint{Name: name, URL: "https://" + distributor + ".example.com/" + name, Distributor: distributor})
+	}
+	return e
+}
+
+func TestNewDatastore(t *testing.T) {
+	ds, err := NewDatastore()
+	require.NoError(t, err)
+	require.Equal(t, defaultDatastoreOptions.SnapshotInterval, ds.options.SnapshotInterval)
+}
+
+func TestNewDatastore_ErrInvalidSnapshotInterval(t *testing.T) {
+	_, err := NewDatastore(WithSnapshotInterval(0))
+	require.Equal(t, ErrInvalidSnapshotInterval, err)
+}
+
+func TestNewDatastore_globalOptionsAreApplied(t *testing.T) {
+	optCopy := make([]DatastoreOption, len(globalDatastoreOptions))
+	copy(optCopy, globalDatastoreOptions)
+	defer func() {
+		globalDatastoreOptions = optCopy
+	}()
+
+	globalDatastoreOptions = append(globalDatastoreOptions, WithSnapshotInterval(time.Hour))
+	ds, err := NewDatastore()
+	require.NoError(t, err)
+	require.Equal(t, time.Hour, ds.options.SnapshotInterval)
+}
+
+func TestWithLogger(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	opts := datastoreOptions{}
+	WithLogger(logger).apply(&opts)
+	require.Equal(t, logger, opts.Logger)
+}
+
+func TestWithSnapshotPath(t *testing.T) {
+	opts := datastoreOptions{}
+	WithSnapshotPath("oslc.jsonl").apply(&opts)
+	require.Equal(t, "oslc.jsonl", opts.SnapshotPath)
+}
+
+func TestWithSnapshotInterval(t *testing.T) {
+	opts := datastoreOptions{}
+	WithSnapshotInterval(time.Minute).apply(&opts)
+	require.Equal(t, time.Minute, opts.SnapshotInterval)
+}
+
+func TestDatastore_Save(t *testing.T) {
+	ds := newTestDatastore(t)
+	ctx := context.Background()
+	entry := testEntry("test", "1.0.0", "MIT", "a", "b")
+	require.NoError(t, ds.Save(ctx, entry))
+
+	for _, distributor := range []string{"a", "b"} {
+		got, err := ds.Retrieve(ctx, "test", "1.0.0", distributor)
+		require.NoError(t, err)
+		require.Equal(t, entry, got)
+	}
+	_, err := ds.Retrieve(ctx, "test", "1.0.0", "c")
+	require.ErrorIs(t, err, oslc.ErrDatastoreObjectNotFound)
+}
+
+func TestDatastore_Save_withoutDistributionPoints(t *testing.T) {
+	ds := newTestDatastore(t)
+	require.NoError(t, ds.Save(context.Background(), oslc.Entry{Name: "test", Version: "1.0.0"}))
+	counts, err := ds.CountPackages(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, counts)
+}
+
+func TestDatastore_Save_doesNotShareDistributionPoints(t *testing.T) {
+	ds := newTestDatastore(t)
+	ctx := context.Background()
+	entry := testEntry("test", "1.0.0", "MIT", "a")
+	require.NoError(t, ds.Save(ctx, entry))
+	entry.DistributionPoints[0].URL = "https://modified.example.com"
+
+	got, err := ds.Retrieve(ctx, "test", "1.0.0", "a")
+	require.NoError(t, err)
+	require.Equal(t, "https://a.example.com/test", got.DistributionPoints[0].URL)
+	got.DistributionPoints[0].URL = "https://modified.example.com"
+
+	got, err = ds.Retrieve(ctx, "test", "1.0.0", "a")
+	require.NoError(t, err)
+	require.Equal(t, "https://a.example.com/test", got.DistributionPoints[0].URL)
+}
+
+func TestDatastore_RetrieveVersions(t *testing.T) {
+	ds := newTestDatastore(t)
+	ctx := context.Background()
+	require.NoError(t, ds.Save(ctx, testEntry("test", "2.0.0", "MIT", "a")))
+	require.NoError(t, ds.Save(ctx, testEntry("test", "1.0.0", "MIT", "a")))
+	// Saving a version again does not move it.
+	require.NoError(t, ds.Save(ctx, testEntry("test", "2.0.0", "Apache-2.0", "a")))
+
+	entries, err := ds.RetrieveVersions(ctx, "test", "a")
+	require.NoError(t, err)
+	require.Equal(t, []oslc.Entry{testEntry("test", "2.0.0", "Apache-2.0", "a"), testEntry("test", "1.0.0", "MIT", "a")}, entries)
+
+	entries, err = ds.RetrieveVersions(ctx, "test", "b")
+	require.NoError(t, err)
+	require.NotNil(t, entries)
+	require.Empty(t, entries)
+}
+
+func TestDatastore_Invalidate(t *testing.T) {
+	mit := "MIT"
+	cases := []struct {
+		name   string
+		filter oslc.EntryFilter
+		want   int64
+	}{
+		{"all", oslc.EntryFilter{}, 4},
+		{"distributor", oslc.EntryFilter{Distributor: "b"}, 1},
+		{"name pattern", oslc.EntryFilter{NamePattern: "@types/*"}, 2},
+		{"exact name", oslc.EntryFilter{NamePattern: "@types"}, 0},
+		{"version", oslc.EntryFilter{Version: "1.0.0"}, 3},
+		{"license", oslc.EntryFilter{License: &mit}, 3},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ds := newTestDatastore(t)
+			ctx := context.Background()
+			require.NoError(t, ds.Save(ctx, testEntry("@types/node", "1.0.0", "MIT", "a")))
+			require.NoError(t, ds.Save(ctx, testEntry("@types/react", "2.0.0", "Apache-2.0", "a")))
+			require.NoError(t, ds.Save(ctx, testEntry("left-pad", "1.0.0", "MIT", "a", "b")))
+
+			n, err := ds.Invalidate(ctx, tt.filter)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, n)
+
+			counts, err := ds.CountPackages(ctx)
+			require.NoError(t, err)
+			var remaining int64
+			for _, c := range counts {
+				remaining += c.Count
+			}
+			require.Equal(t, 4-tt.want, remaining)
+		})
+	}
+}
+
+func TestMatchPattern(t *testing.T) {
+	require.True(t, matchPattern("left-pad", "left-pad"))
+	require.False(t, matchPattern("left", "left-pad"))
+	require.True(t, matchPattern("*", ""))
+	require.True(t, matchPattern("@types/*", "@types/node"))
+	require.True(t, matchPattern("*-pad", "left-pad"))
+	require.True(t, matchPattern("l*t*d", "left-pad"))
+	require.False(t, matchPattern("l*x*d", "left-pad"))
+	require.False(t, matchPattern("left*left", "left"))
+}
+
+func TestInExpression(t *testing.T) {
+	require.True(t, inExpression("MIT", "MIT"))
+	require.True(t, inExpression("MIT OR Apache-2.0", "Apache-2.0"))
+	require.True(t, inExpression("(MIT OR Apache-2.0) AND BSD-3-Clause", "MIT"))
+	require.False(t, inExpression("MIT-0", "MIT"))
+	require.False(t, inExpression("GPL-2.0-or-later", "GPL-2.0"))
+}
+
+func TestDatastore_Search(t *testing.T) {
+	ds := newTestDatastore(t)
+	ctx := context.Background()
+	require.NoError(t, ds.Save(ctx, testEntry("lodash", "4.17.21", "MIT", "npm")))
+	require.NoError(t, ds.Save(ctx, testEntry("left-pad", "1.3.0", "WTFPL", "npm")))
+	require.NoError(t, ds.Save(ctx, testEntry("requests", "2.32.3", "Apache-2.0", "pypi")))
+	require.NoError(t, ds.Save(ctx, testEntry("serde", "1.0.0", "MIT OR Apache-2.0", "crates.io")))
+	apache := "Apache-2.0"
+	empty := ""
+
+	names := func(entries []oslc.StoredEntry) []string {
+		names := make([]string, 0, len(entries))
+		for _, e := range entries {
+			names = append(names, e.Name)
+		}
+		return names
+	}
+	cases := []struct {
+		name  string
+		query oslc.SearchQuery
+		want  []string
+	}{
+		{"all", oslc.SearchQuery{Limit: 10}, []string{"serde", "left-pad", "lodash", "requests"}},
+		{"limit", oslc.SearchQuery{Limit: 2}, []string{"serde", "left-pad"}},
+		{"zero limit", oslc.SearchQuery{}, []string{}},
+		{"distributor", oslc.SearchQuery{Distributor: "npm", Limit: 10}, []string{"left-pad", "lodash"}},
+		{"prefix", oslc.SearchQuery{NamePrefix: "l", Limit: 10}, []string{"left-pad", "lodash"}},
+		{"contains", oslc.SearchQuery{NameContains: "es", Limit: 10}, []string{"requests"}},
+		{"license", oslc.SearchQuery{License: &apache, Limit: 10}, []string{"requests"}},
+		{"license in expression", oslc.SearchQuery{License: &apache, LicenseInExpression: true, Limit: 10}, []string{"serde", "requests"}},
+		{"empty license", oslc.SearchQuery{License: &empty, LicenseInExpression: true, Limit: 10}, []string{}},
+		{"after", oslc.SearchQuery{After: &oslc.SearchCursor{Distributor: "npm", Name: "left-pad", Version: "1.3.0"}, Limit: 10}, []string{"lodash", "requests"}},
+		{"fetched after", oslc.SearchQuery{FetchedAfter: time.Date(2024, 1, 2, 3, 4, 8, 0, time.UTC), Limit: 10}, []string{"serde", "requests"}},
+		{"fetched before", oslc.SearchQuery{FetchedBefore: time.Date(2024, 1, 2, 3, 4, 8, 0, time.UTC), Limit: 10}, []string{"left-pad", "lodash"}},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := ds.Search(ctx, tt.query)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, names(entries))
+		})
+	}
+}
+
+func TestDatastore_Search_storedEntry(t *testing.T) {
+	ds := newTestDatastore(t)
+	ctx := context.Background()
+	entry := testEntry("lodash", "4.17.21", "MIT", "npm")
+	require.NoError(t, ds.Save(ctx, entry))
+
+	entries, err := ds.Search(ctx, oslc.SearchQuery{Limit: 1})
+	require.NoError(t, err)
+	require.Equal(t, []oslc.StoredEntry{{Entry: entry, Distributor: "npm", FetchedAt: time.Date(2024, 1, 2, 3, 4, 6, 0, time.UTC)}}, entries)
+}
+
+func TestDatastore_CountPackages(t *testing.T) {
+	ds := newTestDatastore(t)
+	ctx := context.Background()
+	require.NoError(t, ds.Save(ctx, testEntry("a", "1.0.0", "MIT", "npm", "pypi")))
+	require.NoError(t, ds.Save(ctx, testEntry("b", "1.0.0", "MIT", "npm")))
+	c := testEntry("c", "1.0.0", "", "npm")
+	c.NormalizationStatus = oslc.LicenseNormalizationFailed
+	require.NoError(t, ds.Save(ctx, c))
+
+	counts, err := ds.CountPackages(ctx)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []oslc.PackageCount{
+		{Distributor: "npm", License: "MIT", Count: 2},
+		{Distributor: "pypi", License: "MIT", Count: 1},
+		{Distributor: "npm", License: "", NormalizationStatus: oslc.LicenseNormalizationFailed, Count: 1},
+	}, counts)
+}
+
+func TestDatastore_concurrentUse(t *testing.T) {
+	ds := newTestDatastore(t)
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				require.NoError(t, ds.Save(ctx, testEntry("test", "1.0.0", "MIT", "a")))
+				_, err := ds.Retrieve(ctx, "test", "1.0.0", "a")
+				require.NoError(t, err)
+				_, err = ds.Search(ctx, oslc.SearchQuery{Limit: 10})
+				require.NoError(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestDatastore_Iterate(t *testing.T) {
+	ds := newTestDatastore(t)
+	ctx := context.Background()
+	require.NoError(t, ds.Save(ctx, testEntry("b", "1.0.0", "MIT", "npm")))
+	require.NoError(t, ds.Save(ctx, testEntry("a", "1.0.0", "MIT", "npm")))
+	require.NoError(t, ds.Save(ctx, testEntry("c", "1.0.0", "MIT", "pypi")))
+
+	var names []string
+	err := ds.Iterate(ctx, oslc.SearchQuery{Distributor: "npm", Limit: 1}, func(e oslc.StoredEntry) error {
+		names = append(names, e.Name)
+		// The datastore is not locked while entries are visited.
+		return ds.Save(ctx, testEntry("d", "1.0.0", "MIT", "npm"))
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b"}, names)
+
+	err = ds.Iterate(ctx, oslc.SearchQuery{}, func(oslc.StoredEntry) error { return assert.AnError })
+	require.ErrorIs(t, err, assert.AnError)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	err = ds.Iterate(cancelled, oslc.SearchQuery{}, func(oslc.StoredEntry) error { return nil })
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,177 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"github.com/chainalysis-oss/oslc"
+	"slices"
+	"time"
+)
+
+// Compile time check to ensure Datastore implements [oslc.JobStore].
+var _ oslc.JobStore = (*Datastore)(nil)
+
+// job is a stored lookup job.
+type job struct {
+	ID            int64         `json:"id"`
+	Distributor   string        `json:"distributor"`
+	Name          string        `json:"name"`
+	Version       string        `json:"version,omitempty"`
+	State         oslc.JobState `json:"state"`
+	Attempts      int           `json:"attempts"`
+	NextAttemptAt time.Time     `json:"next_attempt_at"`
+	Result        []byte        `json:"result,omitempty"`
+	LastError     string        `json:"last_error,omitempty"`
+	CreatedAt     time.Time     `json:"created_at"`
+	UpdatedAt     time.Time     `json:"updated_at"`
+}
+
+func (j *job) request() oslc.JobRequest {
+	return oslc.JobRequest{Distributor: j.Distributor, Name: j.Name, Version: j.Version}
+}
+
+// active reports whether the job is pending or running.
+func (j *job) active() bool {
+	return j.State == oslc.JobPending || j.State == oslc.JobRunning
+}
+
+// toJob returns a copy of j that does not share its result with it.
+func (j *job) toJob() oslc.Job {
+	return oslc.Job{
+		ID:         j.ID,
+		JobRequest: j.request(),
+		State:      j.State,
+		Attempts:   j.Attempts,
+		Result:     slices.Clone(j.Result),
+		LastError:  j.LastError,
+		CreatedAt:  j.CreatedAt,
+		UpdatedAt:  j.UpdatedAt,
+	}
+}
+
+func (d *Datastore) EnqueueJob(_ context.Context, request oslc.JobRequest) (oslc.Job, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if id, ok := d.activeJobs[request]; ok {
+		return d.jobs[id].toJob(), nil
+	}
+	now := d.options.Now().UTC()
+	d.lastJobID++
+	j := &job{
+		ID:            d.lastJobID,
+		Distributor:   request.Distributor,
+		Name:          request.Name,
+		Version:       request.Version,
+		State:         oslc.JobPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	d.jobs[j.ID] = j
+	d.activeJobs[request] = j.ID
+	return j.toJob(), nil
+}
+
+func (d *Datastore) GetJob(_ context.Context, id int64) (oslc.Job, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	j, ok := d.jobs[id]
+	if !ok {
+		return oslc.Job{}, oslc.ErrDatastoreObjectNotFound
+	}
+	return j.toJob(), nil
+}
+
+// ClaimJobs claims due jobs by marking them as running and moving their next attempt past the lease.
+func (d *Datastore) ClaimJobs(_ context.Context, limit int, lease time.Duration) ([]oslc.Job, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := d.options.Now()
+	due := make([]*job, 0)
+	for _, j := range d.jobs {
+		if j.active() && !j.NextAttemptAt.After(now) {
+			due = append(due, j)
+		}
+	}
+	slices.SortFunc(due, func(a, b *job) int {
+		if c := a.NextAttemptAt.Compare(b.NextAttemptAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+	if len(due) > limit {
+		due = due[:max(limit, 0)]
+	}
+
+	jobs := make([]oslc.Job, 0, len(due))
+	for _, j := range due {
+		j.State = oslc.JobRunning
+		j.Attempts++
+		j.NextAttemptAt = now.Add(lease).UTC()
+		j.UpdatedAt = now.UTC()
+		jobs = append(jobs, j.toJob())
+	}
+	return jobs, nil
+}
+
+func (d *Datastore) CompleteJob(_ context.Context, id int64, result []byte) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.finishJob(id, oslc.JobSucceeded, result, "")
+	return nil
+}
+
+func (d *Datastore) RetryJob(_ context.Context, id int64, next time.Time, lastError string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if j, ok := d.jobs[id]; ok && j.active() {
+		j.State = oslc.JobPending
+		j.NextAttemptAt = next.UTC()
+		j.LastError = lastError
+		j.UpdatedAt = d.options.Now().UTC()
+	}
+	return nil
+}
+
+func (d *Datastore) FailJob(_ context.Context, id int64, result []byte, lastError string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.finishJob(id, oslc.JobFailed, result, lastError)
+	return nil
+}
+
+// finishJob moves an active job to a final state, so its request may be enqueued again. An empty lastError keeps the
+// error of the last failed attempt. The caller must hold the lock.
+func (d *Datastore) finishJob(id int64, state oslc.JobState, result []byte, lastError string) {
+	j, ok := d.jobs[id]
+	if !ok || !j.active() {
+		return
+	}
+	delete(d.activeJobs, j.request())
+	j.State = state
+	j.Result = slices.Clone(result)
+	if lastError != "" {
+		j.LastError = lastError
+	}
+	j.UpdatedAt = d.options.Now().UTC()
+}
+
+func (d *Datastore) DeleteFinishedJobs(_ context.Context, before time.Time) (int, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	deleted := 0
+	for id, j := range d.jobs {
+		if !j.active() && j.UpdatedAt.Before(before) {
+			delete(d.jobs, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
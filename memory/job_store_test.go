@@ -0,0 +1,74 @@
+package memory
+
+import (
+	"context"
+	"github.com/chainalysis-oss/oslc"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestDatastore_ClaimJobs(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	ds := newTestDatastore(t, withNow(func() time.Time { return now }))
+	ctx := context.Background()
+	for _, name := range []string{"a", "b", "c"} {
+		_, err := ds.EnqueueJob(ctx, oslc.JobRequest{Distributor: oslc.DistributorNpm, Name: name})
+		require.NoError(t, err)
+	}
+
+	claimed, err := ds.ClaimJobs(ctx, 2, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, claimed, 2)
+	require.Equal(t, "a", claimed[0].Name)
+	require.Equal(t, "b", claimed[1].Name)
+	require.Equal(t, oslc.JobRunning, claimed[0].State)
+	require.NoError(t, ds.RetryJob(ctx, claimed[1].ID, now.Add(time.Hour), "timeout"))
+
+	// The lease of a job that is not resolved expires, and it is claimed again.
+	now = now.Add(2 * time.Minute)
+	later, err := ds.ClaimJobs(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, later, 2)
+	require.Equal(t, "c", later[0].Name)
+	require.Equal(t, claimed[0].ID, later[1].ID)
+	require.Equal(t, 2, later[1].Attempts)
+}
+
+func TestDatastore_GetJob_copiesResult(t *testing.T) {
+	ds := newTestDatastore(t)
+	ctx := context.Background()
+	job, err := ds.EnqueueJob(ctx, oslc.JobRequest{Distributor: oslc.DistributorNpm, Name: "test"})
+	require.NoError(t, err)
+	result := []byte("response")
+	require.NoError(t, ds.CompleteJob(ctx, job.ID, result))
+	result[0] = 'R'
+
+	stored, err := ds.GetJob(ctx, job.ID)
+	require.NoError(t, err)
+	require.Equal(t, []byte("response"), stored.Result)
+	stored.Result[0] = 'R'
+	stored, err = ds.GetJob(ctx, job.ID)
+	require.NoError(t, err)
+	require.Equal(t, []byte("response"), stored.Result)
+}
+
+func TestDatastore_FailJob_keepsJobsOfOtherRequests(t *testing.T) {
+	ds := newTestDatastore(t)
+	ctx := context.Background()
+	failed, err := ds.EnqueueJob(ctx, oslc.JobRequest{Distributor: oslc.DistributorNpm, Name: "test"})
+	require.NoError(t, err)
+	require.NoError(t, ds.FailJob(ctx, failed.ID, nil, "not found"))
+	active, err := ds.EnqueueJob(ctx, oslc.JobRequest{Distributor: oslc.DistributorNpm, Name: "test"})
+	require.NoError(t, err)
+
+	// Resolving the failed job again does not release the active job of its request.
+	require.NoError(t, ds.FailJob(ctx, failed.ID, nil, "not found"))
+	require.NoError(t, ds.CompleteJob(ctx, failed.ID, nil))
+	same, err := ds.EnqueueJob(ctx, oslc.JobRequest{Distributor: oslc.DistributorNpm, Name: "test"})
+	require.NoError(t, err)
+	require.Equal(t, active.ID, same.ID)
+	stored, err := ds.GetJob(ctx, failed.ID)
+	require.NoError(t, err)
+	require.Equal(t, oslc.JobFailed, stored.State)
+}
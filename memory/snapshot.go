@@ -0,0 +1,252 @@
+package memory
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/chainalysis-oss/oslc"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// snapshotRecord is a line of a snapshot. Exactly one of its fields is set.
+type snapshotRecord struct {
+	Entry        *snapshotEntry        `json:"entry,omitempty"`
+	Override     *oslc.LicenseOverride `json:"override,omitempty"`
+	Subscription *snapshotSubscription `json:"subscription,omitempty"`
+	Delivery     *delivery             `json:"delivery,omitempty"`
+	Job          *job                  `json:"job,omitempty"`
+}
+
+// snapshotEntry is an entry stored under a single distributor.
+type snapshotEntry struct {
+	oslc.Entry
+	Distributor string    `json:"distributor"`
+	FetchedAt   time.Time `json:"fetched_at"`
+}
+
+type snapshotSubscription struct {
+	ID                int64     `json:"id"`
+	URL               string    `json:"url"`
+	Secret            string    `json:"secret"`
+	Distributor       string    `json:"distributor,omitempty"`
+	PackagePattern    string    `json:"package_pattern,omitempty"`
+	LicenseCategories []string  `json:"license_categories,omitempty"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+// records returns the content of the datastore as snapshot records. Entries are ordered by the time they were first
+// saved, so loading the snapshot preserves the order of RetrieveVersions.
+func (d *Datastore) records() []snapshotRecord {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	type orderedEntry struct {
+		seq   int64
+		entry snapshotEntry
+	}
+	entries := make([]orderedEntry, 0)
+	for key, versions := range d.packages {
+		for _, v := range versions {
+			entries = append(entries, orderedEntry{seq: v.seq, entry: snapshotEntry{Entry: cloneEntry(v.entry), Distributor: key.distributor, FetchedAt: v.fetchedAt}})
+		}
+	}
+	slices.SortFunc(entries, func(a, b orderedEntry) int { return cmp.Compare(a.seq, b.seq) })
+
+	records := make([]snapshotRecord, 0, len(entries)+len(d.overrides)+len(d.subscriptions)+len(d.deliveries)+len(d.jobs))
+	for _, e := range entries {
+		records = append(records, snapshotRecord{Entry: &e.entry})
+	}
+	for _, o := range d.overrides {
+		records = append(records, snapshotRecord{Override: &o})
+	}
+	// Subscriptions are written before deliveries, so every delivery is loaded after its subscription.
+	for _, s := range d.subscriptions {
+		records = append(records, snapshotRecord{Subscription: &snapshotSubscription{
+			ID:                s.ID,
+			URL:               s.URL,
+			Secret:            s.Secret,
+			Distributor:       s.Distributor,
+			PackagePattern:    s.PackagePattern,
+			LicenseCategories: slices.Clone(s.LicenseCategories),
+			CreatedAt:         s.CreatedAt,
+		}})
+	}
+	for _, dl := range d.deliveries {
+		dl := *dl
+		records = append(records, snapshotRecord{Delivery: &dl})
+	}
+	for _, j := range d.jobs {
+		j := *j
+		records = append(records, snapshotRecord{Job: &j})
+	}
+	return records
+}
+
+// Snapshot saves the content of the datastore to the snapshot path, as one JSON object per line. The snapshot is
+// written to a temporary file that replaces the previous snapshot once it is complete, so a snapshot is never left
+// half-written. The datastore is not locked while the snapshot is written.
+func (d *Datastore) Snapshot() (err error) {
+	if d.options.SnapshotPath == "" {
+		return ErrMissingOptionSnapshotPath
+	}
+	records := d.records()
+
+	// The temporary file is created with permissions 0600, since the snapshot holds the secrets of webhooks.
+	f, err := os.CreateTemp(filepath.Dir(d.options.SnapshotPath), filepath.Base(d.options.SnapshotPath)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("creating snapshot: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			f.Close()
+			os.Remove(f.Name())
+		}
+	}()
+
+	w := bufio.NewWriter(f)
+	encoder := json.NewEncoder(w)
+	for _, record := range records {
+		if err = encoder.Encode(record); err != nil {
+			return fmt.Errorf("writing snapshot: %w", err)
+		}
+	}
+	if err = w.Flush(); err != nil {
+		return fmt.Errorf("writing snapshot: %w", err)
+	}
+	if err = f.Sync(); err != nil {
+		return fmt.Errorf("writing snapshot: %w", err)
+	}
+	if err = f.Close(); err != nil {
+		return fmt.Errorf("writing snapshot: %w", err)
+	}
+	if err = os.Rename(f.Name(), d.options.SnapshotPath); err != nil {
+		return fmt.Errorf("replacing snapshot: %w", err)
+	}
+	return nil
+}
+
+// load loads the datastore from the snapshot path. A missing snapshot leaves the datastore empty.
+func (d *Datastore) load() error {
+	f, err := os.Open(d.options.SnapshotPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("opening snapshot: %w", err)
+	}
+	defer f.Close()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	decoder := json.NewDecoder(bufio.NewReader(f))
+	for line := 1; ; line++ {
+		var record snapshotRecord
+		err := decoder.Decode(&record)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("reading snapshot %s: record %d: %w", d.options.SnapshotPath, line, err)
+		}
+		if err := d.loadRecord(record); err != nil {
+			return fmt.Errorf("reading snapshot %s: record %d: %w", d.options.SnapshotPath, line, err)
+		}
+	}
+
+	d.options.Logger.Info("loaded snapshot",
+		slog.String("path", d.options.SnapshotPath),
+		slog.Int("packages", len(d.packages)),
+		slog.Int("overrides", len(d.overrides)),
+		slog.Int("subscriptions", len(d.subscriptions)),
+		slog.Int("deliveries", len(d.deliveries)),
+		slog.Int("jobs", len(d.jobs)),
+	)
+	return nil
+}
+
+// loadRecord adds the content of a snapshot record to the datastore. The caller must hold the lock.
+func (d *Datastore) loadRecord(record snapshotRecord) error {
+	switch {
+	case record.Entry != nil:
+		key := packageKey{distributor: record.Entry.Distributor, name: record.Entry.Name}
+		versions, ok := d.packages[key]
+		if !ok {
+			versions = make(map[string]version)
+			d.packages[key] = versions
+		}
+		d.seq++
+		versions[record.Entry.Version] = version{entry: record.Entry.Entry, fetchedAt: record.Entry.FetchedAt, seq: d.seq}
+	case record.Override != nil:
+		o := *record.Override
+		d.overrides[overrideKeyOf(o)] = o
+	case record.Subscription != nil:
+		s := record.Subscription
+		categories := s.LicenseCategories
+		if categories == nil {
+			categories = []string{}
+		}
+		d.subscriptions[s.ID] = oslc.WebhookSubscription{
+			ID:                s.ID,
+			URL:               s.URL,
+			Secret:            s.Secret,
+			Distributor:       s.Distributor,
+			PackagePattern:    s.PackagePattern,
+			LicenseCategories: categories,
+			CreatedAt:         s.CreatedAt,
+		}
+		d.lastSubscriptionID = max(d.lastSubscriptionID, s.ID)
+	case record.Delivery != nil:
+		if _, ok := d.subscriptions[record.Delivery.SubscriptionID]; !ok {
+			return fmt.Errorf("delivery %d: subscription %d: %w", record.Delivery.ID, record.Delivery.SubscriptionID, oslc.ErrDatastoreObjectNotFound)
+		}
+		dl := *record.Delivery
+		d.deliveries[dl.ID] = &dl
+		d.lastDeliveryID = max(d.lastDeliveryID, dl.ID)
+	case record.Job != nil:
+		j := *record.Job
+		d.jobs[j.ID] = &j
+		if j.active() {
+			d.activeJobs[j.request()] = j.ID
+		}
+		d.lastJobID = max(d.lastJobID, j.ID)
+	default:
+		return ErrEmptySnapshotRecord
+	}
+	return nil
+}
+
+// Run saves a snapshot every snapshot interval until ctx is cancelled, and a last snapshot when it is. Errors of
+// periodic snapshots are logged, and do not stop Run. Run returns the error of the last snapshot. If no snapshot path
+// is configured, Run waits for ctx to be cancelled and returns nil.
+func (d *Datastore) Run(ctx context.Context) error {
+	if d.options.SnapshotPath == "" {
+		<-ctx.Done()
+		return nil
+	}
+
+	ticker := time.NewTicker(d.options.SnapshotInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return d.Snapshot()
+		case <-ticker.C:
+			if err := d.Snapshot(); err != nil {
+				d.options.Logger.ErrorContext(ctx, "failed to save snapshot", slog.String("error", err.Error()))
+			}
+		}
+	}
+}
+
+var ErrMissingOptionSnapshotPath = errors.New("missing option: snapshot path")
+
+var ErrEmptySnapshotRecord = errors.New("snapshot record is empty")
@@ -0,0 +1,148 @@
+package memory
+
+import (
+	"context"
+	"github.com/chainalysis-oss/oslc"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDatastore_Snapshot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "oslc.jsonl")
+	ds := newTestDatastore(t, WithSnapshotPath(path))
+	ctx := context.Background()
+	require.NoError(t, ds.Save(ctx, testEntry("test", "2.0.0", "MIT", "a", "b")))
+	require.NoError(t, ds.Save(ctx, testEntry("test", "1.0.0", "MIT", "a")))
+	override, err := ds.SetOverride(ctx, oslc.LicenseOverride{Distributor: "a", Name: "test", VersionRange: "*", License: "MIT"})
+	require.NoError(t, err)
+	subscription, err := ds.CreateSubscription(ctx, oslc.WebhookSubscription{URL: "https://example.com", Secret: "s", LicenseCategories: []string{"copyleft"}})
+	require.NoError(t, err)
+	require.NoError(t, ds.EnqueueDelivery(ctx, subscription.ID, oslc.LicenseChangeEvent{Name: "test"}))
+	finished, err := ds.EnqueueJob(ctx, oslc.JobRequest{Distributor: "a", Name: "test"})
+	require.NoError(t, err)
+	require.NoError(t, ds.CompleteJob(ctx, finished.ID, []byte("response")))
+	_, err = ds.EnqueueJob(ctx, oslc.JobRequest{Distributor: "a", Name: "other"})
+	require.NoError(t, err)
+	require.NoError(t, ds.Snapshot())
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	loaded := newTestDatastore(t, WithSnapshotPath(path))
+	require.Equal(t, ds.packages, loaded.packages)
+	require.Equal(t, ds.seq, loaded.seq)
+	versions, err := loaded.RetrieveVersions(ctx, "test", "a")
+	require.NoError(t, err)
+	require.Equal(t, []oslc.Entry{testEntry("test", "2.0.0", "MIT", "a", "b"), testEntry("test", "1.0.0", "MIT", "a")}, versions)
+	overrides, err := loaded.ListOverrides(ctx, "", "", "")
+	require.NoError(t, err)
+	require.Equal(t, []oslc.LicenseOverride{override}, overrides)
+	subscriptions, err := loaded.ListSubscriptions(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []oslc.WebhookSubscription{subscription}, subscriptions)
+	require.Equal(t, ds.deliveries, loaded.deliveries)
+	require.Equal(t, ds.jobs, loaded.jobs)
+	require.Equal(t, ds.activeJobs, loaded.activeJobs)
+
+	// Identifiers continue after the loaded ones.
+	next, err := loaded.CreateSubscription(ctx, oslc.WebhookSubscription{URL: "https://example.com"})
+	require.NoError(t, err)
+	require.EqualValues(t, 2, next.ID)
+	job, err := loaded.EnqueueJob(ctx, oslc.JobRequest{Distributor: "a", Name: "test"})
+	require.NoError(t, err)
+	require.EqualValues(t, 3, job.ID)
+}
+
+func TestDatastore_Snapshot_ErrMissingOptionSnapshotPath(t *testing.T) {
+	ds := newTestDatastore(t)
+	require.Equal(t, ErrMissingOptionSnapshotPath, ds.Snapshot())
+}
+
+func TestDatastore_Snapshot_ErrCreate(t *testing.T) {
+	ds := newTestDatastore(t, WithSnapshotPath(filepath.Join(t.TempDir(), "missing", "oslc.jsonl")))
+	require.ErrorContains(t, ds.Snapshot(), "creating snapshot")
+}
+
+func TestDatastore_Snapshot_replacesPrevious(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "oslc.jsonl")
+	ds := newTestDatastore(t, WithSnapshotPath(path))
+	require.NoError(t, ds.Save(context.Background(), testEntry("test", "1.0.0", "MIT", "a")))
+	require.NoError(t, ds.Snapshot())
+	_, err := ds.Invalidate(context.Background(), oslc.EntryFilter{})
+	require.NoError(t, err)
+	require.NoError(t, ds.Snapshot())
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Empty(t, content)
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+}
+
+func TestNewDatastore_missingSnapshot(t *testing.T) {
+	ds := newTestDatastore(t, WithSnapshotPath(filepath.Join(t.TempDir(), "oslc.jsonl")))
+	require.Empty(t, ds.packages)
+}
+
+func TestNewDatastore_invalidSnapshot(t *testing.T) {
+	cases := []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{"malformed", "{\"entry\":{}}\nnot json\n", "record 2"},
+		{"empty record", "{}\n", ErrEmptySnapshotRecord.Error()},
+		{"unknown subscription", "{\"delivery\":{\"id\":1,\"subscription_id\":1}}\n", oslc.ErrDatastoreObjectNotFound.Error()},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "oslc.jsonl")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+			_, err := NewDatastore(WithSnapshotPath(path))
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestNewDatastore_ErrOpenSnapshot(t *testing.T) {
+	// A directory cannot be read as a snapshot.
+	_, err := NewDatastore(WithSnapshotPath(t.TempDir()))
+	require.Error(t, err)
+}
+
+func TestDatastore_Run(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "oslc.jsonl")
+	ds := newTestDatastore(t, WithSnapshotPath(path), WithSnapshotInterval(10*time.Millisecond))
+	require.NoError(t, ds.Save(context.Background(), testEntry("test", "1.0.0", "MIT", "a")))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- ds.Run(ctx) }()
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(path)
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, ds.Save(context.Background(), testEntry("test", "2.0.0", "MIT", "a")))
+	cancel()
+	require.NoError(t, <-done)
+
+	// The last snapshot is saved when Run returns.
+	loaded := newTestDatastore(t, WithSnapshotPath(path))
+	versions, err := loaded.RetrieveVersions(context.Background(), "test", "a")
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+}
+
+func TestDatastore_Run_withoutSnapshotPath(t *testing.T) {
+	ds := newTestDatastore(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.NoError(t, ds.Run(ctx))
+}
@@ -0,0 +1,159 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"github.com/chainalysis-oss/oslc"
+	"slices"
+	"time"
+)
+
+// Compile time check to ensure Datastore implements [oslc.WebhookStore].
+var _ oslc.WebhookStore = (*Datastore)(nil)
+
+// delivery is a queued delivery of an event to a webhook subscription.
+type delivery struct {
+	ID             int64                   `json:"id"`
+	SubscriptionID int64                   `json:"subscription_id"`
+	Event          oslc.LicenseChangeEvent `json:"event"`
+	Attempts       int                     `json:"attempts"`
+	NextAttemptAt  time.Time               `json:"next_attempt_at"`
+	Failed         bool                    `json:"failed,omitempty"`
+	LastError      string                  `json:"last_error,omitempty"`
+}
+
+// cloneSubscription returns a copy of s that does not share license categories with it.
+func cloneSubscription(s oslc.WebhookSubscription) oslc.WebhookSubscription {
+	s.LicenseCategories = slices.Clone(s.LicenseCategories)
+	return s
+}
+
+func (d *Datastore) CreateSubscription(_ context.Context, subscription oslc.WebhookSubscription) (oslc.WebhookSubscription, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if subscription.LicenseCategories == nil {
+		subscription.LicenseCategories = []string{}
+	}
+	d.lastSubscriptionID++
+	subscription.ID = d.lastSubscriptionID
+	subscription.CreatedAt = d.options.Now().UTC()
+	subscription = cloneSubscription(subscription)
+	d.subscriptions[subscription.ID] = subscription
+	return cloneSubscription(subscription), nil
+}
+
+func (d *Datastore) ListSubscriptions(_ context.Context) ([]oslc.WebhookSubscription, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	subscriptions := make([]oslc.WebhookSubscription, 0, len(d.subscriptions))
+	for _, s := range d.subscriptions {
+		subscriptions = append(subscriptions, cloneSubscription(s))
+	}
+	slices.SortFunc(subscriptions, func(a, b oslc.WebhookSubscription) int { return cmp.Compare(a.ID, b.ID) })
+	return subscriptions, nil
+}
+
+func (d *Datastore) DeleteSubscription(_ context.Context, id int64) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, ok := d.subscriptions[id]; !ok {
+		return oslc.ErrDatastoreObjectNotFound
+	}
+	delete(d.subscriptions, id)
+	for deliveryID, dl := range d.deliveries {
+		if dl.SubscriptionID == id {
+			delete(d.deliveries, deliveryID)
+		}
+	}
+	return nil
+}
+
+func (d *Datastore) EnqueueDelivery(_ context.Context, subscriptionID int64, event oslc.LicenseChangeEvent) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, ok := d.subscriptions[subscriptionID]; !ok {
+		return fmt.Errorf("subscription %d: %w", subscriptionID, oslc.ErrDatastoreObjectNotFound)
+	}
+	d.lastDeliveryID++
+	d.deliveries[d.lastDeliveryID] = &delivery{
+		ID:             d.lastDeliveryID,
+		SubscriptionID: subscriptionID,
+		Event:          event,
+		NextAttemptAt:  d.options.Now().UTC(),
+	}
+	return nil
+}
+
+// ClaimDeliveries claims due deliveries by moving their next attempt past the lease.
+func (d *Datastore) ClaimDeliveries(_ context.Context, limit int, lease time.Duration) ([]oslc.WebhookDelivery, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := d.options.Now()
+	due := make([]*delivery, 0)
+	for _, dl := range d.deliveries {
+		if !dl.Failed && !dl.NextAttemptAt.After(now) {
+			due = append(due, dl)
+		}
+	}
+	slices.SortFunc(due, func(a, b *delivery) int {
+		if c := a.NextAttemptAt.Compare(b.NextAttemptAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+	if len(due) > limit {
+		due = due[:max(limit, 0)]
+	}
+
+	deliveries := make([]oslc.WebhookDelivery, 0, len(due))
+	for _, dl := range due {
+		dl.Attempts++
+		dl.NextAttemptAt = now.Add(lease).UTC()
+		subscription := d.subscriptions[dl.SubscriptionID]
+		deliveries = append(deliveries, oslc.WebhookDelivery{
+			ID:             dl.ID,
+			SubscriptionID: dl.SubscriptionID,
+			URL:            subscription.URL,
+			Secret:         subscription.Secret,
+			Event:          dl.Event,
+			Attempts:       dl.Attempts,
+		})
+	}
+	return deliveries, nil
+}
+
+func (d *Datastore) CompleteDelivery(_ context.Context, id int64) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.deliveries, id)
+	return nil
+}
+
+func (d *Datastore) RetryDelivery(_ context.Context, id int64, next time.Time, lastError string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if dl, ok := d.deliveries[id]; ok {
+		dl.NextAttemptAt = next.UTC()
+		dl.LastError = lastError
+	}
+	return nil
+}
+
+func (d *Datastore) FailDelivery(_ context.Context, id int64, lastError string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if dl, ok := d.deliveries[id]; ok {
+		dl.Failed = true
+		dl.LastError = lastError
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package memory
+
+import (
+	"context"
+	"github.com/chainalysis-oss/oslc"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestDatastore_CreateSubscription(t *testing.T) {
+	ds := newTestDatastore(t)
+	ctx := context.Background()
+	first, err := ds.CreateSubscription(ctx, oslc.WebhookSubscription{URL: "https://example.com/1", Secret: "s"})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, first.ID)
+	require.Equal(t, []string{}, first.LicenseCategories)
+	second, err := ds.CreateSubscription(ctx, oslc.WebhookSubscription{URL: "https://example.com/2", Secret: "s", LicenseCategories: []string{"copyleft"}})
+	require.NoError(t, err)
+	require.EqualValues(t, 2, second.ID)
+
+	second.LicenseCategories[0] = "modified"
+	subscriptions, err := ds.ListSubscriptions(ctx)
+	require.NoError(t, err)
+	require.Len(t, subscriptions, 2)
+	require.Equal(t, first, subscriptions[0])
+	require.Equal(t, []string{"copyleft"}, subscriptions[1].LicenseCategories)
+}
+
+func TestDatastore_DeleteSubscription(t *testing.T) {
+	ds := newTestDatastore(t)
+	ctx := context.Background()
+	subscription, err := ds.CreateSubscription(ctx, oslc.WebhookSubscription{URL: "https://example.com", Secret: "s"})
+	require.NoError(t, err)
+	require.NoError(t, ds.EnqueueDelivery(ctx, subscription.ID, oslc.LicenseChangeEvent{Name: "test"}))
+
+	require.NoError(t, ds.DeleteSubscription(ctx, subscription.ID))
+	require.ErrorIs(t, ds.DeleteSubscription(ctx, subscription.ID), oslc.ErrDatastoreObjectNotFound)
+	require.Empty(t, ds.deliveries)
+}
+
+func TestDatastore_EnqueueDelivery_ErrUnknownSubscription(t *testing.T) {
+	ds := newTestDatastore(t)
+	err := ds.EnqueueDelivery(context.Background(), 42, oslc.LicenseChangeEvent{})
+	require.ErrorIs(t, err, oslc.ErrDatastoreObjectNotFound)
+}
+
+func TestDatastore_ClaimDeliveries(t *testing.T) {
+	ds := newTestDatastore(t)
+	ctx := context.Background()
+	subscription, err := ds.CreateSubscription(ctx, oslc.WebhookSubscription{URL: "https://example.com", Secret: "s"})
+	require.NoError(t, err)
+	for _, name := range []string{"a", "b", "c"} {
+		require.NoError(t, ds.EnqueueDelivery(ctx, subscription.ID, oslc.LicenseChangeEvent{Name: name}))
+	}
+
+	claimed, err := ds.ClaimDeliveries(ctx, 2, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, claimed, 2)
+	require.Equal(t, oslc.WebhookDelivery{ID: 1, SubscriptionID: subscription.ID, URL: "https://example.com", Secret: "s", Event: oslc.LicenseChangeEvent{Name: "a"}, Attempts: 1}, claimed[0])
+	require.Equal(t, "b", claimed[1].Event.Name)
+
+	// Claimed deliveries are leased, so only the remaining delivery is due.
+	claimed, err = ds.ClaimDeliveries(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	require.Equal(t, "c", claimed[0].Event.Name)
+
+	require.NoError(t, ds.CompleteDelivery(ctx, 1))
+	require.NoError(t, ds.FailDelivery(ctx, 2, "gone"))
+	require.NoError(t, ds.RetryDelivery(ctx, 3, time.Time{}, "timeout"))
+	claimed, err = ds.ClaimDeliveries(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	require.EqualValues(t, 3, claimed[0].ID)
+	require.Equal(t, 2, claimed[0].Attempts)
+	require.True(t, ds.deliveries[2].Failed)
+	require.Equal(t, "gone", ds.deliveries[2].LastError)
+}
+
+func TestDatastore_resolveUnknownDelivery(t *testing.T) {
+	ds := newTestDatastore(t)
+	ctx := context.Background()
+	require.NoError(t, ds.CompleteDelivery(ctx, 1))
+	require.NoError(t, ds.RetryDelivery(ctx, 1, time.Now(), ""))
+	require.NoError(t, ds.FailDelivery(ctx, 1, ""))
+}
@@ -0,0 +1,148 @@
+// Package migrate loads versioned schema migrations, and works out which of them to apply or revert. Applying them is
+// left to the datastores, which hold the migrations for their own SQL dialect.
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// Migration is a schema migration.
+type Migration struct {
+	Version int
+	Name    string
+	// Up is the SQL applying the migration, and Down the SQL reverting it.
+	Up   string
+	Down string
+}
+
+// Applied is a migration that has been applied to a database.
+type Applied struct {
+	Version   int
+	Name      string
+	AppliedAt time.Time
+}
+
+// Status is the state of a migration in a database.
+type Status struct {
+	Version int
+	Name    string
+	// Applied is whether the migration has been applied, and AppliedAt when.
+	Applied   bool
+	AppliedAt time.Time
+	// Unknown is set for migrations that have been applied, but are not known to this version of OSLC, because the
+	// database was migrated by a newer version.
+	Unknown bool
+}
+
+var (
+	// ErrInvalidMigrations is returned when migrations are malformed.
+	ErrInvalidMigrations = errors.New("invalid migrations")
+	// ErrUnknownMigration is returned when reverting a migration that is not known to this version of OSLC.
+	ErrUnknownMigration = errors.New("unknown migration")
+)
+
+var migrationFileRegexp = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Load reads the migrations in dir, ordered by version. Each migration is a pair of files named
+// <version>_<name>.up.sql and <version>_<name>.down.sql, where versions are consecutive integers starting at 1.
+func Load(fsys fs.FS, dir string) ([]Migration, error) {
+	files, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	byVersion := make(map[int]*Migration)
+	for _, file := range files {
+		match := migrationFileRegexp.FindStringSubmatch(file.Name())
+		if match == nil {
+			return nil, fmt.Errorf("%w: unexpected file %s", ErrInvalidMigrations, file.Name())
+		}
+		version, _ := strconv.Atoi(match[1])
+		content, err := fs.ReadFile(fsys, path.Join(dir, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("%w: version %d has names %s and %s", ErrInvalidMigrations, version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for version := 1; version <= len(byVersion); version++ {
+		m, ok := byVersion[version]
+		if !ok {
+			return nil, fmt.Errorf("%w: version %d is missing", ErrInvalidMigrations, version)
+		}
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("%w: version %d must have both an up and a down migration", ErrInvalidMigrations, version)
+		}
+		migrations = append(migrations, *m)
+	}
+	return migrations, nil
+}
+
+// Pending returns the migrations that have not been applied, in the order they must be applied.
+func Pending(migrations []Migration, applied []Applied) []Migration {
+	done := make(map[int]bool)
+	for _, a := range applied {
+		done[a.Version] = true
+	}
+	var pending []Migration
+	for _, m := range migrations {
+		if !done[m.Version] {
+			pending = append(pending, m)
+		}
+	}
+	return pending
+}
+
+// Revert returns the most recently applied migrations, up to steps of them, in the order they must be reverted.
+// applied must be ordered by version. [ErrUnknownMigration] is returned if one of them is not in migrations.
+func Revert(migrations []Migration, applied []Applied, steps int) ([]Migration, error) {
+	var reverted []Migration
+	for i := len(applied) - 1; i >= 0 && len(reverted) < steps; i-- {
+		a := applied[i]
+		index := slices.IndexFunc(migrations, func(m Migration) bool { return m.Version == a.Version })
+		if index < 0 {
+			return nil, fmt.Errorf("%w: %d_%s", ErrUnknownMigration, a.Version, a.Name)
+		}
+		reverted = append(reverted, migrations[index])
+	}
+	return reverted, nil
+}
+
+// Statuses returns the status of every migration, followed by the applied migrations that are not in migrations.
+func Statuses(migrations []Migration, applied []Applied) []Status {
+	byVersion := make(map[int]Applied)
+	for _, a := range applied {
+		byVersion[a.Version] = a
+	}
+	statuses := make([]Status, 0, len(migrations))
+	for _, m := range migrations {
+		a, ok := byVersion[m.Version]
+		statuses = append(statuses, Status{Version: m.Version, Name: m.Name, Applied: ok, AppliedAt: a.AppliedAt})
+		delete(byVersion, m.Version)
+	}
+	for _, a := range applied {
+		if _, ok := byVersion[a.Version]; ok {
+			statuses = append(statuses, Status{Version: a.Version, Name: a.Name, Applied: true, AppliedAt: a.AppliedAt, Unknown: true})
+		}
+	}
+	return statuses
+}
@@ -0,0 +1,94 @@
+package migrate
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+var testMigrations = []Migration{
+	{Version: 1, Name: "first", Up: "up 1", Down: "down 1"},
+	{Version: 2, Name: "second", Up: "up 2", Down: "down 2"},
+	{Version: 3, Name: "third", Up: "up 3", Down: "down 3"},
+}
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"m/2_second.up.sql":   {Data: []byte("up 2")},
+		"m/1_first.down.sql":  {Data: []byte("down 1")},
+		"m/1_first.up.sql":    {Data: []byte("up 1")},
+		"m/2_second.down.sql": {Data: []byte("down 2")},
+	}
+	migrations, err := Load(fsys, "m")
+	require.NoError(t, err)
+	require.Equal(t, testMigrations[:2], migrations)
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tests := map[string]fstest.MapFS{
+		"unexpected file": {
+			"m/README.md": {Data: []byte("readme")},
+		},
+		"missing down": {
+			"m/1_first.up.sql": {Data: []byte("up 1")},
+		},
+		"missing version": {
+			"m/2_second.up.sql":   {Data: []byte("up 2")},
+			"m/2_second.down.sql": {Data: []byte("down 2")},
+		},
+		"mismatched names": {
+			"m/1_first.up.sql":   {Data: []byte("up 1")},
+			"m/1_other.down.sql": {Data: []byte("down 1")},
+		},
+	}
+	for name, fsys := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Load(fsys, "m")
+			require.ErrorIs(t, err, ErrInvalidMigrations)
+		})
+	}
+}
+
+func TestLoad_ErrMissingDir(t *testing.T) {
+	_, err := Load(fstest.MapFS{}, "m")
+	require.Error(t, err)
+}
+
+func TestPending(t *testing.T) {
+	require.Equal(t, testMigrations, Pending(testMigrations, nil))
+	require.Equal(t, testMigrations[1:], Pending(testMigrations, []Applied{{Version: 1, Name: "first"}}))
+	require.Empty(t, Pending(testMigrations, []Applied{{Version: 1}, {Version: 2}, {Version: 3}}))
+}
+
+func TestRevert(t *testing.T) {
+	applied := []Applied{{Version: 1}, {Version: 2}, {Version: 3}}
+	reverted, err := Revert(testMigrations, applied, 2)
+	require.NoError(t, err)
+	require.Equal(t, []Migration{testMigrations[2], testMigrations[1]}, reverted)
+
+	reverted, err = Revert(testMigrations, applied, 10)
+	require.NoError(t, err)
+	require.Len(t, reverted, 3)
+
+	reverted, err = Revert(testMigrations, nil, 1)
+	require.NoError(t, err)
+	require.Empty(t, reverted)
+
+	_, err = Revert(testMigrations, []Applied{{Version: 1}, {Version: 4, Name: "future"}}, 1)
+	require.ErrorIs(t, err, ErrUnknownMigration)
+}
+
+func TestStatuses(t *testing.T) {
+	appliedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	statuses := Statuses(testMigrations, []Applied{
+		{Version: 1, Name: "first", AppliedAt: appliedAt},
+		{Version: 4, Name: "future", AppliedAt: appliedAt},
+	})
+	require.Equal(t, []Status{
+		{Version: 1, Name: "first", Applied: true, AppliedAt: appliedAt},
+		{Version: 2, Name: "second"},
+		{Version: 3, Name: "third"},
+		{Version: 4, Name: "future", Applied: true, AppliedAt: appliedAt, Unknown: true},
+	}, statuses)
+}
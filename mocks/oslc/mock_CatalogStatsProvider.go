@@ -0,0 +1,93 @@
+// Code generated by mockery v2.50.1. DO NOT EDIT.
+
+package oslc
+
+import (
+	context "context"
+
+	oslc "github.com/chainalysis-oss/oslc"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockCatalogStatsProvider is an autogenerated mock type for the CatalogStatsProvider type
+type MockCatalogStatsProvider struct {
+	mock.Mock
+}
+
+type MockCatalogStatsProvider_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCatalogStatsProvider) EXPECT() *MockCatalogStatsProvider_Expecter {
+	return &MockCatalogStatsProvider_Expecter{mock: &_m.Mock}
+}
+
+// CatalogStats provides a mock function with given fields: ctx
+func (_m *MockCatalogStatsProvider) CatalogStats(ctx context.Context) (oslc.CatalogStats, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CatalogStats")
+	}
+
+	var r0 oslc.CatalogStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (oslc.CatalogStats, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) oslc.CatalogStats); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(oslc.CatalogStats)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCatalogStatsProvider_CatalogStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CatalogStats'
+type MockCatalogStatsProvider_CatalogStats_Call struct {
+	*mock.Call
+}
+
+// CatalogStats is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockCatalogStatsProvider_Expecter) CatalogStats(ctx interface{}) *MockCatalogStatsProvider_CatalogStats_Call {
+	return &MockCatalogStatsProvider_CatalogStats_Call{Call: _e.mock.On("CatalogStats", ctx)}
+}
+
+func (_c *MockCatalogStatsProvider_CatalogStats_Call) Run(run func(ctx context.Context)) *MockCatalogStatsProvider_CatalogStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockCatalogStatsProvider_CatalogStats_Call) Return(_a0 oslc.CatalogStats, _a1 error) *MockCatalogStatsProvider_CatalogStats_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCatalogStatsProvider_CatalogStats_Call) RunAndReturn(run func(context.Context) (oslc.CatalogStats, error)) *MockCatalogStatsProvider_CatalogStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCatalogStatsProvider creates a new instance of MockCatalogStatsProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCatalogStatsProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCatalogStatsProvider {
+	mock := &MockCatalogStatsProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,205 @@
+// Code generated by mockery v2.50.1. DO NOT EDIT.
+
+package oslc
+
+import (
+	context "context"
+
+	oslc "github.com/chainalysis-oss/oslc"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockCurationStore is an autogenerated mock type for the CurationStore type
+type MockCurationStore struct {
+	mock.Mock
+}
+
+type MockCurationStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCurationStore) EXPECT() *MockCurationStore_Expecter {
+	return &MockCurationStore_Expecter{mock: &_m.Mock}
+}
+
+// DeleteOverride provides a mock function with given fields: ctx, tenant, distributor, name, versionRange
+func (_m *MockCurationStore) DeleteOverride(ctx context.Context, tenant string, distributor string, name string, versionRange string) error {
+	ret := _m.Called(ctx, tenant, distributor, name, versionRange)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteOverride")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) error); ok {
+		r0 = rf(ctx, tenant, distributor, name, versionRange)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCurationStore_DeleteOverride_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteOverride'
+type MockCurationStore_DeleteOverride_Call struct {
+	*mock.Call
+}
+
+// DeleteOverride is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenant string
+//   - distributor string
+//   - name string
+//   - versionRange string
+func (_e *MockCurationStore_Expecter) DeleteOverride(ctx interface{}, tenant interface{}, distributor interface{}, name interface{}, versionRange interface{}) *MockCurationStore_DeleteOverride_Call {
+	return &MockCurationStore_DeleteOverride_Call{Call: _e.mock.On("DeleteOverride", ctx, tenant, distributor, name, versionRange)}
+}
+
+func (_c *MockCurationStore_DeleteOverride_Call) Run(run func(ctx context.Context, tenant string, distributor string, name string, versionRange string)) *MockCurationStore_DeleteOverride_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *MockCurationStore_DeleteOverride_Call) Return(_a0 error) *MockCurationStore_DeleteOverride_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCurationStore_DeleteOverride_Call) RunAndReturn(run func(context.Context, string, string, string, string) error) *MockCurationStore_DeleteOverride_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListOverrides provides a mock function with given fields: ctx, tenant, distributor, name
+func (_m *MockCurationStore) ListOverrides(ctx context.Context, tenant string, distributor string, name string) ([]oslc.LicenseOverride, error) {
+	ret := _m.Called(ctx, tenant, distributor, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOverrides")
+	}
+
+	var r0 []oslc.LicenseOverride
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) ([]oslc.LicenseOverride, error)); ok {
+		return rf(ctx, tenant, distributor, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) []oslc.LicenseOverride); ok {
+		r0 = rf(ctx, tenant, distributor, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]oslc.LicenseOverride)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, tenant, distributor, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCurationStore_ListOverrides_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOverrides'
+type MockCurationStore_ListOverrides_Call struct {
+	*mock.Call
+}
+
+// ListOverrides is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tenant string
+//   - distributor string
+//   - name string
+func (_e *MockCurationStore_Expecter) ListOverrides(ctx interface{}, tenant interface{}, distributor interface{}, name interface{}) *MockCurationStore_ListOverrides_Call {
+	return &MockCurationStore_ListOverrides_Call{Call: _e.mock.On("ListOverrides", ctx, tenant, distributor, name)}
+}
+
+func (_c *MockCurationStore_ListOverrides_Call) Run(run func(ctx context.Context, tenant string, distributor string, name string)) *MockCurationStore_ListOverrides_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockCurationStore_ListOverrides_Call) Return(_a0 []oslc.LicenseOverride, _a1 error) *MockCurationStore_ListOverrides_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCurationStore_ListOverrides_Call) RunAndReturn(run func(context.Context, string, string, string) ([]oslc.LicenseOverride, error)) *MockCurationStore_ListOverrides_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetOverride provides a mock function with given fields: ctx, override
+func (_m *MockCurationStore) SetOverride(ctx context.Context, override oslc.LicenseOverride) (oslc.LicenseOverride, error) {
+	ret := _m.Called(ctx, override)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetOverride")
+	}
+
+	var r0 oslc.LicenseOverride
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, oslc.LicenseOverride) (oslc.LicenseOverride, error)); ok {
+		return rf(ctx, override)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, oslc.LicenseOverride) oslc.LicenseOverride); ok {
+		r0 = rf(ctx, override)
+	} else {
+		r0 = ret.Get(0).(oslc.LicenseOverride)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, oslc.LicenseOverride) error); ok {
+		r1 = rf(ctx, override)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCurationStore_SetOverride_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetOverride'
+type MockCurationStore_SetOverride_Call struct {
+	*mock.Call
+}
+
+// SetOverride is a helper method to define mock.On call
+//   - ctx context.Context
+//   - override oslc.LicenseOverride
+func (_e *MockCurationStore_Expecter) SetOverride(ctx interface{}, override interface{}) *MockCurationStore_SetOverride_Call {
+	return &MockCurationStore_SetOverride_Call{Call: _e.mock.On("SetOverride", ctx, override)}
+}
+
+func (_c *MockCurationStore_SetOverride_Call) Run(run func(ctx context.Context, override oslc.LicenseOverride)) *MockCurationStore_SetOverride_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(oslc.LicenseOverride))
+	})
+	return _c
+}
+
+func (_c *MockCurationStore_SetOverride_Call) Return(_a0 oslc.LicenseOverride, _a1 error) *MockCurationStore_SetOverride_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCurationStore_SetOverride_Call) RunAndReturn(run func(context.Context, oslc.LicenseOverride) (oslc.LicenseOverride, error)) *MockCurationStore_SetOverride_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCurationStore creates a new instance of MockCurationStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCurationStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCurationStore {
+	mock := &MockCurationStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
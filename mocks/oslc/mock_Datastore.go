@@ -22,6 +22,121 @@ func (_m *MockDatastore) EXPECT() *MockDatastore_Expecter {
 	return &MockDatastore_Expecter{mock: &_m.Mock}
 }
 
+// CountPackages provides a mock function with given fields: ctx
+func (_m *MockDatastore) CountPackages(ctx context.Context) ([]oslc.PackageCount, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountPackages")
+	}
+
+	var r0 []oslc.PackageCount
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]oslc.PackageCount, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []oslc.PackageCount); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]oslc.PackageCount)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatastore_CountPackages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountPackages'
+type MockDatastore_CountPackages_Call struct {
+	*mock.Call
+}
+
+// CountPackages is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockDatastore_Expecter) CountPackages(ctx interface{}) *MockDatastore_CountPackages_Call {
+	return &MockDatastore_CountPackages_Call{Call: _e.mock.On("CountPackages", ctx)}
+}
+
+func (_c *MockDatastore_CountPackages_Call) Run(run func(ctx context.Context)) *MockDatastore_CountPackages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockDatastore_CountPackages_Call) Return(_a0 []oslc.PackageCount, _a1 error) *MockDatastore_CountPackages_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatastore_CountPackages_Call) RunAndReturn(run func(context.Context) ([]oslc.PackageCount, error)) *MockDatastore_CountPackages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Invalidate provides a mock function with given fields: ctx, filter
+func (_m *MockDatastore) Invalidate(ctx context.Context, filter oslc.EntryFilter) (int64, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Invalidate")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, oslc.EntryFilter) (int64, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, oslc.EntryFilter) int64); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, oslc.EntryFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatastore_Invalidate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Invalidate'
+type MockDatastore_Invalidate_Call struct {
+	*mock.Call
+}
+
+// Invalidate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter oslc.EntryFilter
+func (_e *MockDatastore_Expecter) Invalidate(ctx interface{}, filter interface{}) *MockDatastore_Invalidate_Call {
+	return &MockDatastore_Invalidate_Call{Call: _e.mock.On("Invalidate", ctx, filter)}
+}
+
+func (_c *MockDatastore_Invalidate_Call) Run(run func(ctx context.Context, filter oslc.EntryFilter)) *MockDatastore_Invalidate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(oslc.EntryFilter))
+	})
+	return _c
+}
+
+func (_c *MockDatastore_Invalidate_Call) Return(_a0 int64, _a1 error) *MockDatastore_Invalidate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatastore_Invalidate_Call) RunAndReturn(run func(context.Context, oslc.EntryFilter) (int64, error)) *MockDatastore_Invalidate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Retrieve provides a mock function with given fields: ctx, name, version, distributor
 func (_m *MockDatastore) Retrieve(ctx context.Context, name string, version string, distributor string) (oslc.Entry, error) {
 	ret := _m.Called(ctx, name, version, distributor)
@@ -81,6 +196,66 @@ func (_c *MockDatastore_Retrieve_Call) RunAndReturn(run func(context.Context, st
 	return _c
 }
 
+// RetrieveVersions provides a mock function with given fields: ctx, name, distributor
+func (_m *MockDatastore) RetrieveVersions(ctx context.Context, name string, distributor string) ([]oslc.Entry, error) {
+	ret := _m.Called(ctx, name, distributor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveVersions")
+	}
+
+	var r0 []oslc.Entry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]oslc.Entry, error)); ok {
+		return rf(ctx, name, distributor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []oslc.Entry); ok {
+		r0 = rf(ctx, name, distributor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]oslc.Entry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, name, distributor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatastore_RetrieveVersions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetrieveVersions'
+type MockDatastore_RetrieveVersions_Call struct {
+	*mock.Call
+}
+
+// RetrieveVersions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - distributor string
+func (_e *MockDatastore_Expecter) RetrieveVersions(ctx interface{}, name interface{}, distributor interface{}) *MockDatastore_RetrieveVersions_Call {
+	return &MockDatastore_RetrieveVersions_Call{Call: _e.mock.On("RetrieveVersions", ctx, name, distributor)}
+}
+
+func (_c *MockDatastore_RetrieveVersions_Call) Run(run func(ctx context.Context, name string, distributor string)) *MockDatastore_RetrieveVersions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockDatastore_RetrieveVersions_Call) Return(_a0 []oslc.Entry, _a1 error) *MockDatastore_RetrieveVersions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatastore_RetrieveVersions_Call) RunAndReturn(run func(context.Context, string, string) ([]oslc.Entry, error)) *MockDatastore_RetrieveVersions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Save provides a mock function with given fields: ctx, entry
 func (_m *MockDatastore) Save(ctx context.Context, entry oslc.Entry) error {
 	ret := _m.Called(ctx, entry)
@@ -128,6 +303,65 @@ func (_c *MockDatastore_Save_Call) RunAndReturn(run func(context.Context, oslc.E
 	return _c
 }
 
+// Search provides a mock function with given fields: ctx, query
+func (_m *MockDatastore) Search(ctx context.Context, query oslc.SearchQuery) ([]oslc.StoredEntry, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 []oslc.StoredEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, oslc.SearchQuery) ([]oslc.StoredEntry, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, oslc.SearchQuery) []oslc.StoredEntry); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]oslc.StoredEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, oslc.SearchQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDatastore_Search_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Search'
+type MockDatastore_Search_Call struct {
+	*mock.Call
+}
+
+// Search is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query oslc.SearchQuery
+func (_e *MockDatastore_Expecter) Search(ctx interface{}, query interface{}) *MockDatastore_Search_Call {
+	return &MockDatastore_Search_Call{Call: _e.mock.On("Search", ctx, query)}
+}
+
+func (_c *MockDatastore_Search_Call) Run(run func(ctx context.Context, query oslc.SearchQuery)) *MockDatastore_Search_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(oslc.SearchQuery))
+	})
+	return _c
+}
+
+func (_c *MockDatastore_Search_Call) Return(_a0 []oslc.StoredEntry, _a1 error) *MockDatastore_Search_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDatastore_Search_Call) RunAndReturn(run func(context.Context, oslc.SearchQuery) ([]oslc.StoredEntry, error)) *MockDatastore_Search_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockDatastore creates a new instance of MockDatastore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockDatastore(t interface {
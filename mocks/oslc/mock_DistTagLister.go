@@ -0,0 +1,95 @@
+// Code generated by mockery v2.50.1. DO NOT EDIT.
+
+package oslc
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockDistTagLister is an autogenerated mock type for the DistTagLister type
+type MockDistTagLister struct {
+	mock.Mock
+}
+
+type MockDistTagLister_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDistTagLister) EXPECT() *MockDistTagLister_Expecter {
+	return &MockDistTagLister_Expecter{mock: &_m.Mock}
+}
+
+// ListDistTags provides a mock function with given fields: ctx, name
+func (_m *MockDistTagLister) ListDistTags(ctx context.Context, name string) (map[string]string, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDistTags")
+	}
+
+	var r0 map[string]string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (map[string]string, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) map[string]string); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDistTagLister_ListDistTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDistTags'
+type MockDistTagLister_ListDistTags_Call struct {
+	*mock.Call
+}
+
+// ListDistTags is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockDistTagLister_Expecter) ListDistTags(ctx interface{}, name interface{}) *MockDistTagLister_ListDistTags_Call {
+	return &MockDistTagLister_ListDistTags_Call{Call: _e.mock.On("ListDistTags", ctx, name)}
+}
+
+func (_c *MockDistTagLister_ListDistTags_Call) Run(run func(ctx context.Context, name string)) *MockDistTagLister_ListDistTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockDistTagLister_ListDistTags_Call) Return(_a0 map[string]string, _a1 error) *MockDistTagLister_ListDistTags_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDistTagLister_ListDistTags_Call) RunAndReturn(run func(context.Context, string) (map[string]string, error)) *MockDistTagLister_ListDistTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockDistTagLister creates a new instance of MockDistTagLister. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDistTagLister(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDistTagLister {
+	mock := &MockDistTagLister{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
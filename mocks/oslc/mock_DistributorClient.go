@@ -3,6 +3,8 @@
 package oslc
 
 import (
+	context "context"
+
 	oslc "github.com/chainalysis-oss/oslc"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -20,9 +22,9 @@ func (_m *MockDistributorClient) EXPECT() *MockDistributorClient_Expecter {
 	return &MockDistributorClient_Expecter{mock: &_m.Mock}
 }
 
-// GetPackage provides a mock function with given fields: name
-func (_m *MockDistributorClient) GetPackage(name string) (oslc.Entry, error) {
-	ret := _m.Called(name)
+// GetPackage provides a mock function with given fields: ctx, name
+func (_m *MockDistributorClient) GetPackage(ctx context.Context, name string) (oslc.Entry, error) {
+	ret := _m.Called(ctx, name)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetPackage")
@@ -30,17 +32,17 @@ func (_m *MockDistributorClient) GetPackage(name string) (oslc.Entry, error) {
 
 	var r0 oslc.Entry
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (oslc.Entry, error)); ok {
-		return rf(name)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (oslc.Entry, error)); ok {
+		return rf(ctx, name)
 	}
-	if rf, ok := ret.Get(0).(func(string) oslc.Entry); ok {
-		r0 = rf(name)
+	if rf, ok := ret.Get(0).(func(context.Context, string) oslc.Entry); ok {
+		r0 = rf(ctx, name)
 	} else {
 		r0 = ret.Get(0).(oslc.Entry)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(name)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -54,14 +56,15 @@ type MockDistributorClient_GetPackage_Call struct {
 }
 
 // GetPackage is a helper method to define mock.On call
+//   - ctx context.Context
 //   - name string
-func (_e *MockDistributorClient_Expecter) GetPackage(name interface{}) *MockDistributorClient_GetPackage_Call {
-	return &MockDistributorClient_GetPackage_Call{Call: _e.mock.On("GetPackage", name)}
+func (_e *MockDistributorClient_Expecter) GetPackage(ctx interface{}, name interface{}) *MockDistributorClient_GetPackage_Call {
+	return &MockDistributorClient_GetPackage_Call{Call: _e.mock.On("GetPackage", ctx, name)}
 }
 
-func (_c *MockDistributorClient_GetPackage_Call) Run(run func(name string)) *MockDistributorClient_GetPackage_Call {
+func (_c *MockDistributorClient_GetPackage_Call) Run(run func(ctx context.Context, name string)) *MockDistributorClient_GetPackage_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -71,14 +74,14 @@ func (_c *MockDistributorClient_GetPackage_Call) Return(_a0 oslc.Entry, _a1 erro
 	return _c
 }
 
-func (_c *MockDistributorClient_GetPackage_Call) RunAndReturn(run func(string) (oslc.Entry, error)) *MockDistributorClient_GetPackage_Call {
+func (_c *MockDistributorClient_GetPackage_Call) RunAndReturn(run func(context.Context, string) (oslc.Entry, error)) *MockDistributorClient_GetPackage_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPackageVersion provides a mock function with given fields: name, version
-func (_m *MockDistributorClient) GetPackageVersion(name string, version string) (oslc.Entry, error) {
-	ret := _m.Called(name, version)
+// GetPackageVersion provides a mock function with given fields: ctx, name, version
+func (_m *MockDistributorClient) GetPackageVersion(ctx context.Context, name string, version string) (oslc.Entry, error) {
+	ret := _m.Called(ctx, name, version)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetPackageVersion")
@@ -86,17 +89,17 @@ func (_m *MockDistributorClient) GetPackageVersion(name string, version string)
 
 	var r0 oslc.Entry
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (oslc.Entry, error)); ok {
-		return rf(name, version)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (oslc.Entry, error)); ok {
+		return rf(ctx, name, version)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) oslc.Entry); ok {
-		r0 = rf(name, version)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) oslc.Entry); ok {
+		r0 = rf(ctx, name, version)
 	} else {
 		r0 = ret.Get(0).(oslc.Entry)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(name, version)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, name, version)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -110,15 +113,16 @@ type MockDistributorClient_GetPackageVersion_Call struct {
 }
 
 // GetPackageVersion is a helper method to define mock.On call
+//   - ctx context.Context
 //   - name string
 //   - version string
-func (_e *MockDistributorClient_Expecter) GetPackageVersion(name interface{}, version interface{}) *MockDistributorClient_GetPackageVersion_Call {
-	return &MockDistributorClient_GetPackageVersion_Call{Call: _e.mock.On("GetPackageVersion", name, version)}
+func (_e *MockDistributorClient_Expecter) GetPackageVersion(ctx interface{}, name interface{}, version interface{}) *MockDistributorClient_GetPackageVersion_Call {
+	return &MockDistributorClient_GetPackageVersion_Call{Call: _e.mock.On("GetPackageVersion", ctx, name, version)}
 }
 
-func (_c *MockDistributorClient_GetPackageVersion_Call) Run(run func(name string, version string)) *MockDistributorClient_GetPackageVersion_Call {
+func (_c *MockDistributorClient_GetPackageVersion_Call) Run(run func(ctx context.Context, name string, version string)) *MockDistributorClient_GetPackageVersion_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
 	})
 	return _c
 }
@@ -128,7 +132,7 @@ func (_c *MockDistributorClient_GetPackageVersion_Call) Return(_a0 oslc.Entry, _
 	return _c
 }
 
-func (_c *MockDistributorClient_GetPackageVersion_Call) RunAndReturn(run func(string, string) (oslc.Entry, error)) *MockDistributorClient_GetPackageVersion_Call {
+func (_c *MockDistributorClient_GetPackageVersion_Call) RunAndReturn(run func(context.Context, string, string) (oslc.Entry, error)) *MockDistributorClient_GetPackageVersion_Call {
 	_c.Call.Return(run)
 	return _c
 }
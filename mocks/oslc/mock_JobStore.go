@@ -0,0 +1,415 @@
+// Code generated by mockery v2.50.1. DO NOT EDIT.
+
+package oslc
+
+import (
+	context "context"
+	time "time"
+
+	oslc "github.com/chainalysis-oss/oslc"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockJobStore is an autogenerated mock type for the JobStore type
+type MockJobStore struct {
+	mock.Mock
+}
+
+type MockJobStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockJobStore) EXPECT() *MockJobStore_Expecter {
+	return &MockJobStore_Expecter{mock: &_m.Mock}
+}
+
+// ClaimJobs provides a mock function with given fields: ctx, limit, lease
+func (_m *MockJobStore) ClaimJobs(ctx context.Context, limit int, lease time.Duration) ([]oslc.Job, error) {
+	ret := _m.Called(ctx, limit, lease)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimJobs")
+	}
+
+	var r0 []oslc.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Duration) ([]oslc.Job, error)); ok {
+		return rf(ctx, limit, lease)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Duration) []oslc.Job); ok {
+		r0 = rf(ctx, limit, lease)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]oslc.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, time.Duration) error); ok {
+		r1 = rf(ctx, limit, lease)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockJobStore_ClaimJobs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimJobs'
+type MockJobStore_ClaimJobs_Call struct {
+	*mock.Call
+}
+
+// ClaimJobs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+//   - lease time.Duration
+func (_e *MockJobStore_Expecter) ClaimJobs(ctx interface{}, limit interface{}, lease interface{}) *MockJobStore_ClaimJobs_Call {
+	return &MockJobStore_ClaimJobs_Call{Call: _e.mock.On("ClaimJobs", ctx, limit, lease)}
+}
+
+func (_c *MockJobStore_ClaimJobs_Call) Run(run func(ctx context.Context, limit int, lease time.Duration)) *MockJobStore_ClaimJobs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockJobStore_ClaimJobs_Call) Return(_a0 []oslc.Job, _a1 error) *MockJobStore_ClaimJobs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockJobStore_ClaimJobs_Call) RunAndReturn(run func(context.Context, int, time.Duration) ([]oslc.Job, error)) *MockJobStore_ClaimJobs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CompleteJob provides a mock function with given fields: ctx, id, result
+func (_m *MockJobStore) CompleteJob(ctx context.Context, id int64, result []byte) error {
+	ret := _m.Called(ctx, id, result)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompleteJob")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []byte) error); ok {
+		r0 = rf(ctx, id, result)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockJobStore_CompleteJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompleteJob'
+type MockJobStore_CompleteJob_Call struct {
+	*mock.Call
+}
+
+// CompleteJob is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - result []byte
+func (_e *MockJobStore_Expecter) CompleteJob(ctx interface{}, id interface{}, result interface{}) *MockJobStore_CompleteJob_Call {
+	return &MockJobStore_CompleteJob_Call{Call: _e.mock.On("CompleteJob", ctx, id, result)}
+}
+
+func (_c *MockJobStore_CompleteJob_Call) Run(run func(ctx context.Context, id int64, result []byte)) *MockJobStore_CompleteJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockJobStore_CompleteJob_Call) Return(_a0 error) *MockJobStore_CompleteJob_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockJobStore_CompleteJob_Call) RunAndReturn(run func(context.Context, int64, []byte) error) *MockJobStore_CompleteJob_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteFinishedJobs provides a mock function with given fields: ctx, before
+func (_m *MockJobStore) DeleteFinishedJobs(ctx context.Context, before time.Time) (int, error) {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFinishedJobs")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockJobStore_DeleteFinishedJobs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFinishedJobs'
+type MockJobStore_DeleteFinishedJobs_Call struct {
+	*mock.Call
+}
+
+// DeleteFinishedJobs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *MockJobStore_Expecter) DeleteFinishedJobs(ctx interface{}, before interface{}) *MockJobStore_DeleteFinishedJobs_Call {
+	return &MockJobStore_DeleteFinishedJobs_Call{Call: _e.mock.On("DeleteFinishedJobs", ctx, before)}
+}
+
+func (_c *MockJobStore_DeleteFinishedJobs_Call) Run(run func(ctx context.Context, before time.Time)) *MockJobStore_DeleteFinishedJobs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockJobStore_DeleteFinishedJobs_Call) Return(_a0 int, _a1 error) *MockJobStore_DeleteFinishedJobs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockJobStore_DeleteFinishedJobs_Call) RunAndReturn(run func(context.Context, time.Time) (int, error)) *MockJobStore_DeleteFinishedJobs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnqueueJob provides a mock function with given fields: ctx, request
+func (_m *MockJobStore) EnqueueJob(ctx context.Context, request oslc.JobRequest) (oslc.Job, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnqueueJob")
+	}
+
+	var r0 oslc.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, oslc.JobRequest) (oslc.Job, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, oslc.JobRequest) oslc.Job); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Get(0).(oslc.Job)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, oslc.JobRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockJobStore_EnqueueJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnqueueJob'
+type MockJobStore_EnqueueJob_Call struct {
+	*mock.Call
+}
+
+// EnqueueJob is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request oslc.JobRequest
+func (_e *MockJobStore_Expecter) EnqueueJob(ctx interface{}, request interface{}) *MockJobStore_EnqueueJob_Call {
+	return &MockJobStore_EnqueueJob_Call{Call: _e.mock.On("EnqueueJob", ctx, request)}
+}
+
+func (_c *MockJobStore_EnqueueJob_Call) Run(run func(ctx context.Context, request oslc.JobRequest)) *MockJobStore_EnqueueJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(oslc.JobRequest))
+	})
+	return _c
+}
+
+func (_c *MockJobStore_EnqueueJob_Call) Return(_a0 oslc.Job, _a1 error) *MockJobStore_EnqueueJob_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockJobStore_EnqueueJob_Call) RunAndReturn(run func(context.Context, oslc.JobRequest) (oslc.Job, error)) *MockJobStore_EnqueueJob_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FailJob provides a mock function with given fields: ctx, id, result, lastError
+func (_m *MockJobStore) FailJob(ctx context.Context, id int64, result []byte, lastError string) error {
+	ret := _m.Called(ctx, id, result, lastError)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FailJob")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []byte, string) error); ok {
+		r0 = rf(ctx, id, result, lastError)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockJobStore_FailJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FailJob'
+type MockJobStore_FailJob_Call struct {
+	*mock.Call
+}
+
+// FailJob is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - result []byte
+//   - lastError string
+func (_e *MockJobStore_Expecter) FailJob(ctx interface{}, id interface{}, result interface{}, lastError interface{}) *MockJobStore_FailJob_Call {
+	return &MockJobStore_FailJob_Call{Call: _e.mock.On("FailJob", ctx, id, result, lastError)}
+}
+
+func (_c *MockJobStore_FailJob_Call) Run(run func(ctx context.Context, id int64, result []byte, lastError string)) *MockJobStore_FailJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].([]byte), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockJobStore_FailJob_Call) Return(_a0 error) *MockJobStore_FailJob_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockJobStore_FailJob_Call) RunAndReturn(run func(context.Context, int64, []byte, string) error) *MockJobStore_FailJob_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetJob provides a mock function with given fields: ctx, id
+func (_m *MockJobStore) GetJob(ctx context.Context, id int64) (oslc.Job, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetJob")
+	}
+
+	var r0 oslc.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (oslc.Job, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) oslc.Job); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(oslc.Job)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockJobStore_GetJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetJob'
+type MockJobStore_GetJob_Call struct {
+	*mock.Call
+}
+
+// GetJob is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *MockJobStore_Expecter) GetJob(ctx interface{}, id interface{}) *MockJobStore_GetJob_Call {
+	return &MockJobStore_GetJob_Call{Call: _e.mock.On("GetJob", ctx, id)}
+}
+
+func (_c *MockJobStore_GetJob_Call) Run(run func(ctx context.Context, id int64)) *MockJobStore_GetJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockJobStore_GetJob_Call) Return(_a0 oslc.Job, _a1 error) *MockJobStore_GetJob_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockJobStore_GetJob_Call) RunAndReturn(run func(context.Context, int64) (oslc.Job, error)) *MockJobStore_GetJob_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RetryJob provides a mock function with given fields: ctx, id, next, lastError
+func (_m *MockJobStore) RetryJob(ctx context.Context, id int64, next time.Time, lastError string) error {
+	ret := _m.Called(ctx, id, next, lastError)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetryJob")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time, string) error); ok {
+		r0 = rf(ctx, id, next, lastError)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockJobStore_RetryJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetryJob'
+type MockJobStore_RetryJob_Call struct {
+	*mock.Call
+}
+
+// RetryJob is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - next time.Time
+//   - lastError string
+func (_e *MockJobStore_Expecter) RetryJob(ctx interface{}, id interface{}, next interface{}, lastError interface{}) *MockJobStore_RetryJob_Call {
+	return &MockJobStore_RetryJob_Call{Call: _e.mock.On("RetryJob", ctx, id, next, lastError)}
+}
+
+func (_c *MockJobStore_RetryJob_Call) Run(run func(ctx context.Context, id int64, next time.Time, lastError string)) *MockJobStore_RetryJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(time.Time), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockJobStore_RetryJob_Call) Return(_a0 error) *MockJobStore_RetryJob_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockJobStore_RetryJob_Call) RunAndReturn(run func(context.Context, int64, time.Time, string) error) *MockJobStore_RetryJob_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockJobStore creates a new instance of MockJobStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockJobStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockJobStore {
+	mock := &MockJobStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,84 @@
+// Code generated by mockery v2.50.1. DO NOT EDIT.
+
+package oslc
+
+import (
+	context "context"
+
+	oslc "github.com/chainalysis-oss/oslc"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockLicenseChangeNotifier is an autogenerated mock type for the LicenseChangeNotifier type
+type MockLicenseChangeNotifier struct {
+	mock.Mock
+}
+
+type MockLicenseChangeNotifier_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockLicenseChangeNotifier) EXPECT() *MockLicenseChangeNotifier_Expecter {
+	return &MockLicenseChangeNotifier_Expecter{mock: &_m.Mock}
+}
+
+// NotifyLicenseChange provides a mock function with given fields: ctx, event
+func (_m *MockLicenseChangeNotifier) NotifyLicenseChange(ctx context.Context, event oslc.LicenseChangeEvent) error {
+	ret := _m.Called(ctx, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NotifyLicenseChange")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, oslc.LicenseChangeEvent) error); ok {
+		r0 = rf(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockLicenseChangeNotifier_NotifyLicenseChange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NotifyLicenseChange'
+type MockLicenseChangeNotifier_NotifyLicenseChange_Call struct {
+	*mock.Call
+}
+
+// NotifyLicenseChange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - event oslc.LicenseChangeEvent
+func (_e *MockLicenseChangeNotifier_Expecter) NotifyLicenseChange(ctx interface{}, event interface{}) *MockLicenseChangeNotifier_NotifyLicenseChange_Call {
+	return &MockLicenseChangeNotifier_NotifyLicenseChange_Call{Call: _e.mock.On("NotifyLicenseChange", ctx, event)}
+}
+
+func (_c *MockLicenseChangeNotifier_NotifyLicenseChange_Call) Run(run func(ctx context.Context, event oslc.LicenseChangeEvent)) *MockLicenseChangeNotifier_NotifyLicenseChange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(oslc.LicenseChangeEvent))
+	})
+	return _c
+}
+
+func (_c *MockLicenseChangeNotifier_NotifyLicenseChange_Call) Return(_a0 error) *MockLicenseChangeNotifier_NotifyLicenseChange_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLicenseChangeNotifier_NotifyLicenseChange_Call) RunAndReturn(run func(context.Context, oslc.LicenseChangeEvent) error) *MockLicenseChangeNotifier_NotifyLicenseChange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockLicenseChangeNotifier creates a new instance of MockLicenseChangeNotifier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockLicenseChangeNotifier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockLicenseChangeNotifier {
+	mock := &MockLicenseChangeNotifier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,131 @@
+// Code generated by mockery v2.50.1. DO NOT EDIT.
+
+package oslc
+
+import (
+	context "context"
+
+	oslc "github.com/chainalysis-oss/oslc"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockLicenseNormalizer is an autogenerated mock type for the LicenseNormalizer type
+type MockLicenseNormalizer struct {
+	mock.Mock
+}
+
+type MockLicenseNormalizer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockLicenseNormalizer) EXPECT() *MockLicenseNormalizer_Expecter {
+	return &MockLicenseNormalizer_Expecter{mock: &_m.Mock}
+}
+
+// Normalize provides a mock function with given fields: ctx, license
+func (_m *MockLicenseNormalizer) Normalize(ctx context.Context, license string) oslc.LicenseNormalization {
+	ret := _m.Called(ctx, license)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Normalize")
+	}
+
+	var r0 oslc.LicenseNormalization
+	if rf, ok := ret.Get(0).(func(context.Context, string) oslc.LicenseNormalization); ok {
+		r0 = rf(ctx, license)
+	} else {
+		r0 = ret.Get(0).(oslc.LicenseNormalization)
+	}
+
+	return r0
+}
+
+// MockLicenseNormalizer_Normalize_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Normalize'
+type MockLicenseNormalizer_Normalize_Call struct {
+	*mock.Call
+}
+
+// Normalize is a helper method to define mock.On call
+//   - ctx context.Context
+//   - license string
+func (_e *MockLicenseNormalizer_Expecter) Normalize(ctx interface{}, license interface{}) *MockLicenseNormalizer_Normalize_Call {
+	return &MockLicenseNormalizer_Normalize_Call{Call: _e.mock.On("Normalize", ctx, license)}
+}
+
+func (_c *MockLicenseNormalizer_Normalize_Call) Run(run func(ctx context.Context, license string)) *MockLicenseNormalizer_Normalize_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockLicenseNormalizer_Normalize_Call) Return(_a0 oslc.LicenseNormalization) *MockLicenseNormalizer_Normalize_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLicenseNormalizer_Normalize_Call) RunAndReturn(run func(context.Context, string) oslc.LicenseNormalization) *MockLicenseNormalizer_Normalize_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NormalizeID provides a mock function with given fields: ctx, id
+func (_m *MockLicenseNormalizer) NormalizeID(ctx context.Context, id string) string {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NormalizeID")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MockLicenseNormalizer_NormalizeID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NormalizeID'
+type MockLicenseNormalizer_NormalizeID_Call struct {
+	*mock.Call
+}
+
+// NormalizeID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockLicenseNormalizer_Expecter) NormalizeID(ctx interface{}, id interface{}) *MockLicenseNormalizer_NormalizeID_Call {
+	return &MockLicenseNormalizer_NormalizeID_Call{Call: _e.mock.On("NormalizeID", ctx, id)}
+}
+
+func (_c *MockLicenseNormalizer_NormalizeID_Call) Run(run func(ctx context.Context, id string)) *MockLicenseNormalizer_NormalizeID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockLicenseNormalizer_NormalizeID_Call) Return(_a0 string) *MockLicenseNormalizer_NormalizeID_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLicenseNormalizer_NormalizeID_Call) RunAndReturn(run func(context.Context, string) string) *MockLicenseNormalizer_NormalizeID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockLicenseNormalizer creates a new instance of MockLicenseNormalizer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockLicenseNormalizer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockLicenseNormalizer {
+	mock := &MockLicenseNormalizer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
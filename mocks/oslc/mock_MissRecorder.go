@@ -0,0 +1,84 @@
+// Code generated by mockery v2.50.1. DO NOT EDIT.
+
+package oslc
+
+import (
+	context "context"
+
+	oslc "github.com/chainalysis-oss/oslc"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockMissRecorder is an autogenerated mock type for the MissRecorder type
+type MockMissRecorder struct {
+	mock.Mock
+}
+
+type MockMissRecorder_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockMissRecorder) EXPECT() *MockMissRecorder_Expecter {
+	return &MockMissRecorder_Expecter{mock: &_m.Mock}
+}
+
+// RecordMiss provides a mock function with given fields: ctx, miss
+func (_m *MockMissRecorder) RecordMiss(ctx context.Context, miss oslc.PackageMiss) error {
+	ret := _m.Called(ctx, miss)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordMiss")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, oslc.PackageMiss) error); ok {
+		r0 = rf(ctx, miss)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockMissRecorder_RecordMiss_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordMiss'
+type MockMissRecorder_RecordMiss_Call struct {
+	*mock.Call
+}
+
+// RecordMiss is a helper method to define mock.On call
+//   - ctx context.Context
+//   - miss oslc.PackageMiss
+func (_e *MockMissRecorder_Expecter) RecordMiss(ctx interface{}, miss interface{}) *MockMissRecorder_RecordMiss_Call {
+	return &MockMissRecorder_RecordMiss_Call{Call: _e.mock.On("RecordMiss", ctx, miss)}
+}
+
+func (_c *MockMissRecorder_RecordMiss_Call) Run(run func(ctx context.Context, miss oslc.PackageMiss)) *MockMissRecorder_RecordMiss_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(oslc.PackageMiss))
+	})
+	return _c
+}
+
+func (_c *MockMissRecorder_RecordMiss_Call) Return(_a0 error) *MockMissRecorder_RecordMiss_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockMissRecorder_RecordMiss_Call) RunAndReturn(run func(context.Context, oslc.PackageMiss) error) *MockMissRecorder_RecordMiss_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockMissRecorder creates a new instance of MockMissRecorder. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockMissRecorder(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockMissRecorder {
+	mock := &MockMissRecorder{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,175 @@
+// Code generated by mockery v2.50.1. DO NOT EDIT.
+
+package oslc
+
+import (
+	context "context"
+
+	oslc "github.com/chainalysis-oss/oslc"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockPackageEventBroker is an autogenerated mock type for the PackageEventBroker type
+type MockPackageEventBroker struct {
+	mock.Mock
+}
+
+type MockPackageEventBroker_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPackageEventBroker) EXPECT() *MockPackageEventBroker_Expecter {
+	return &MockPackageEventBroker_Expecter{mock: &_m.Mock}
+}
+
+// PublishPackageEvent provides a mock function with given fields: ctx, event
+func (_m *MockPackageEventBroker) PublishPackageEvent(ctx context.Context, event oslc.PackageEvent) {
+	_m.Called(ctx, event)
+}
+
+// MockPackageEventBroker_PublishPackageEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublishPackageEvent'
+type MockPackageEventBroker_PublishPackageEvent_Call struct {
+	*mock.Call
+}
+
+// PublishPackageEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - event oslc.PackageEvent
+func (_e *MockPackageEventBroker_Expecter) PublishPackageEvent(ctx interface{}, event interface{}) *MockPackageEventBroker_PublishPackageEvent_Call {
+	return &MockPackageEventBroker_PublishPackageEvent_Call{Call: _e.mock.On("PublishPackageEvent", ctx, event)}
+}
+
+func (_c *MockPackageEventBroker_PublishPackageEvent_Call) Run(run func(ctx context.Context, event oslc.PackageEvent)) *MockPackageEventBroker_PublishPackageEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(oslc.PackageEvent))
+	})
+	return _c
+}
+
+func (_c *MockPackageEventBroker_PublishPackageEvent_Call) Return() *MockPackageEventBroker_PublishPackageEvent_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockPackageEventBroker_PublishPackageEvent_Call) RunAndReturn(run func(context.Context, oslc.PackageEvent)) *MockPackageEventBroker_PublishPackageEvent_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SubscribePackageEvents provides a mock function with given fields: ctx, filter
+func (_m *MockPackageEventBroker) SubscribePackageEvents(ctx context.Context, filter oslc.PackageEventFilter) (oslc.PackageEventSubscription, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscribePackageEvents")
+	}
+
+	var r0 oslc.PackageEventSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, oslc.PackageEventFilter) (oslc.PackageEventSubscription, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, oslc.PackageEventFilter) oslc.PackageEventSubscription); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(oslc.PackageEventSubscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, oslc.PackageEventFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPackageEventBroker_SubscribePackageEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SubscribePackageEvents'
+type MockPackageEventBroker_SubscribePackageEvents_Call struct {
+	*mock.Call
+}
+
+// SubscribePackageEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter oslc.PackageEventFilter
+func (_e *MockPackageEventBroker_Expecter) SubscribePackageEvents(ctx interface{}, filter interface{}) *MockPackageEventBroker_SubscribePackageEvents_Call {
+	return &MockPackageEventBroker_SubscribePackageEvents_Call{Call: _e.mock.On("SubscribePackageEvents", ctx, filter)}
+}
+
+func (_c *MockPackageEventBroker_SubscribePackageEvents_Call) Run(run func(ctx context.Context, filter oslc.PackageEventFilter)) *MockPackageEventBroker_SubscribePackageEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(oslc.PackageEventFilter))
+	})
+	return _c
+}
+
+func (_c *MockPackageEventBroker_SubscribePackageEvents_Call) Return(_a0 oslc.PackageEventSubscription, _a1 error) *MockPackageEventBroker_SubscribePackageEvents_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPackageEventBroker_SubscribePackageEvents_Call) RunAndReturn(run func(context.Context, oslc.PackageEventFilter) (oslc.PackageEventSubscription, error)) *MockPackageEventBroker_SubscribePackageEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Subscriptions provides a mock function with no fields
+func (_m *MockPackageEventBroker) Subscriptions() int {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Subscriptions")
+	}
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// MockPackageEventBroker_Subscriptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Subscriptions'
+type MockPackageEventBroker_Subscriptions_Call struct {
+	*mock.Call
+}
+
+// Subscriptions is a helper method to define mock.On call
+func (_e *MockPackageEventBroker_Expecter) Subscriptions() *MockPackageEventBroker_Subscriptions_Call {
+	return &MockPackageEventBroker_Subscriptions_Call{Call: _e.mock.On("Subscriptions")}
+}
+
+func (_c *MockPackageEventBroker_Subscriptions_Call) Run(run func()) *MockPackageEventBroker_Subscriptions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockPackageEventBroker_Subscriptions_Call) Return(_a0 int) *MockPackageEventBroker_Subscriptions_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPackageEventBroker_Subscriptions_Call) RunAndReturn(run func() int) *MockPackageEventBroker_Subscriptions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPackageEventBroker creates a new instance of MockPackageEventBroker. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPackageEventBroker(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPackageEventBroker {
+	mock := &MockPackageEventBroker{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,127 @@
+// Code generated by mockery v2.50.1. DO NOT EDIT.
+
+package oslc
+
+import (
+	oslc "github.com/chainalysis-oss/oslc"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockPackageEventSubscription is an autogenerated mock type for the PackageEventSubscription type
+type MockPackageEventSubscription struct {
+	mock.Mock
+}
+
+type MockPackageEventSubscription_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPackageEventSubscription) EXPECT() *MockPackageEventSubscription_Expecter {
+	return &MockPackageEventSubscription_Expecter{mock: &_m.Mock}
+}
+
+// Err provides a mock function with no fields
+func (_m *MockPackageEventSubscription) Err() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Err")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockPackageEventSubscription_Err_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Err'
+type MockPackageEventSubscription_Err_Call struct {
+	*mock.Call
+}
+
+// Err is a helper method to define mock.On call
+func (_e *MockPackageEventSubscription_Expecter) Err() *MockPackageEventSubscription_Err_Call {
+	return &MockPackageEventSubscription_Err_Call{Call: _e.mock.On("Err")}
+}
+
+func (_c *MockPackageEventSubscription_Err_Call) Run(run func()) *MockPackageEventSubscription_Err_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockPackageEventSubscription_Err_Call) Return(_a0 error) *MockPackageEventSubscription_Err_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPackageEventSubscription_Err_Call) RunAndReturn(run func() error) *MockPackageEventSubscription_Err_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Events provides a mock function with no fields
+func (_m *MockPackageEventSubscription) Events() <-chan oslc.PackageEvent {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Events")
+	}
+
+	var r0 <-chan oslc.PackageEvent
+	if rf, ok := ret.Get(0).(func() <-chan oslc.PackageEvent); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan oslc.PackageEvent)
+		}
+	}
+
+	return r0
+}
+
+// MockPackageEventSubscription_Events_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Events'
+type MockPackageEventSubscription_Events_Call struct {
+	*mock.Call
+}
+
+// Events is a helper method to define mock.On call
+func (_e *MockPackageEventSubscription_Expecter) Events() *MockPackageEventSubscription_Events_Call {
+	return &MockPackageEventSubscription_Events_Call{Call: _e.mock.On("Events")}
+}
+
+func (_c *MockPackageEventSubscription_Events_Call) Run(run func()) *MockPackageEventSubscription_Events_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockPackageEventSubscription_Events_Call) Return(_a0 <-chan oslc.PackageEvent) *MockPackageEventSubscription_Events_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPackageEventSubscription_Events_Call) RunAndReturn(run func() <-chan oslc.PackageEvent) *MockPackageEventSubscription_Events_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPackageEventSubscription creates a new instance of MockPackageEventSubscription. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPackageEventSubscription(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPackageEventSubscription {
+	mock := &MockPackageEventSubscription{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,95 @@
+// Code generated by mockery v2.50.1. DO NOT EDIT.
+
+package oslc
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockVersionLister is an autogenerated mock type for the VersionLister type
+type MockVersionLister struct {
+	mock.Mock
+}
+
+type MockVersionLister_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockVersionLister) EXPECT() *MockVersionLister_Expecter {
+	return &MockVersionLister_Expecter{mock: &_m.Mock}
+}
+
+// ListVersions provides a mock function with given fields: ctx, name
+func (_m *MockVersionLister) ListVersions(ctx context.Context, name string) ([]string, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListVersions")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockVersionLister_ListVersions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListVersions'
+type MockVersionLister_ListVersions_Call struct {
+	*mock.Call
+}
+
+// ListVersions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockVersionLister_Expecter) ListVersions(ctx interface{}, name interface{}) *MockVersionLister_ListVersions_Call {
+	return &MockVersionLister_ListVersions_Call{Call: _e.mock.On("ListVersions", ctx, name)}
+}
+
+func (_c *MockVersionLister_ListVersions_Call) Run(run func(ctx context.Context, name string)) *MockVersionLister_ListVersions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockVersionLister_ListVersions_Call) Return(_a0 []string, _a1 error) *MockVersionLister_ListVersions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockVersionLister_ListVersions_Call) RunAndReturn(run func(context.Context, string) ([]string, error)) *MockVersionLister_ListVersions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockVersionLister creates a new instance of MockVersionLister. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockVersionLister(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockVersionLister {
+	mock := &MockVersionLister{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
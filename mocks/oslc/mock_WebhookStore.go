@@ -0,0 +1,452 @@
+// Code generated by mockery v2.50.1. DO NOT EDIT.
+
+package oslc
+
+import (
+	context "context"
+	time "time"
+
+	oslc "github.com/chainalysis-oss/oslc"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockWebhookStore is an autogenerated mock type for the WebhookStore type
+type MockWebhookStore struct {
+	mock.Mock
+}
+
+type MockWebhookStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockWebhookStore) EXPECT() *MockWebhookStore_Expecter {
+	return &MockWebhookStore_Expecter{mock: &_m.Mock}
+}
+
+// ClaimDeliveries provides a mock function with given fields: ctx, limit, lease
+func (_m *MockWebhookStore) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]oslc.WebhookDelivery, error) {
+	ret := _m.Called(ctx, limit, lease)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimDeliveries")
+	}
+
+	var r0 []oslc.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Duration) ([]oslc.WebhookDelivery, error)); ok {
+		return rf(ctx, limit, lease)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Duration) []oslc.WebhookDelivery); ok {
+		r0 = rf(ctx, limit, lease)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]oslc.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, time.Duration) error); ok {
+		r1 = rf(ctx, limit, lease)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookStore_ClaimDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimDeliveries'
+type MockWebhookStore_ClaimDeliveries_Call struct {
+	*mock.Call
+}
+
+// ClaimDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+//   - lease time.Duration
+func (_e *MockWebhookStore_Expecter) ClaimDeliveries(ctx interface{}, limit interface{}, lease interface{}) *MockWebhookStore_ClaimDeliveries_Call {
+	return &MockWebhookStore_ClaimDeliveries_Call{Call: _e.mock.On("ClaimDeliveries", ctx, limit, lease)}
+}
+
+func (_c *MockWebhookStore_ClaimDeliveries_Call) Run(run func(ctx context.Context, limit int, lease time.Duration)) *MockWebhookStore_ClaimDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockWebhookStore_ClaimDeliveries_Call) Return(_a0 []oslc.WebhookDelivery, _a1 error) *MockWebhookStore_ClaimDeliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookStore_ClaimDeliveries_Call) RunAndReturn(run func(context.Context, int, time.Duration) ([]oslc.WebhookDelivery, error)) *MockWebhookStore_ClaimDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CompleteDelivery provides a mock function with given fields: ctx, id
+func (_m *MockWebhookStore) CompleteDelivery(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompleteDelivery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWebhookStore_CompleteDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompleteDelivery'
+type MockWebhookStore_CompleteDelivery_Call struct {
+	*mock.Call
+}
+
+// CompleteDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *MockWebhookStore_Expecter) CompleteDelivery(ctx interface{}, id interface{}) *MockWebhookStore_CompleteDelivery_Call {
+	return &MockWebhookStore_CompleteDelivery_Call{Call: _e.mock.On("CompleteDelivery", ctx, id)}
+}
+
+func (_c *MockWebhookStore_CompleteDelivery_Call) Run(run func(ctx context.Context, id int64)) *MockWebhookStore_CompleteDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockWebhookStore_CompleteDelivery_Call) Return(_a0 error) *MockWebhookStore_CompleteDelivery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWebhookStore_CompleteDelivery_Call) RunAndReturn(run func(context.Context, int64) error) *MockWebhookStore_CompleteDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateSubscription provides a mock function with given fields: ctx, subscription
+func (_m *MockWebhookStore) CreateSubscription(ctx context.Context, subscription oslc.WebhookSubscription) (oslc.WebhookSubscription, error) {
+	ret := _m.Called(ctx, subscription)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSubscription")
+	}
+
+	var r0 oslc.WebhookSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, oslc.WebhookSubscription) (oslc.WebhookSubscription, error)); ok {
+		return rf(ctx, subscription)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, oslc.WebhookSubscription) oslc.WebhookSubscription); ok {
+		r0 = rf(ctx, subscription)
+	} else {
+		r0 = ret.Get(0).(oslc.WebhookSubscription)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, oslc.WebhookSubscription) error); ok {
+		r1 = rf(ctx, subscription)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookStore_CreateSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSubscription'
+type MockWebhookStore_CreateSubscription_Call struct {
+	*mock.Call
+}
+
+// CreateSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscription oslc.WebhookSubscription
+func (_e *MockWebhookStore_Expecter) CreateSubscription(ctx interface{}, subscription interface{}) *MockWebhookStore_CreateSubscription_Call {
+	return &MockWebhookStore_CreateSubscription_Call{Call: _e.mock.On("CreateSubscription", ctx, subscription)}
+}
+
+func (_c *MockWebhookStore_CreateSubscription_Call) Run(run func(ctx context.Context, subscription oslc.WebhookSubscription)) *MockWebhookStore_CreateSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(oslc.WebhookSubscription))
+	})
+	return _c
+}
+
+func (_c *MockWebhookStore_CreateSubscription_Call) Return(_a0 oslc.WebhookSubscription, _a1 error) *MockWebhookStore_CreateSubscription_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookStore_CreateSubscription_Call) RunAndReturn(run func(context.Context, oslc.WebhookSubscription) (oslc.WebhookSubscription, error)) *MockWebhookStore_CreateSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteSubscription provides a mock function with given fields: ctx, id
+func (_m *MockWebhookStore) DeleteSubscription(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSubscription")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWebhookStore_DeleteSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSubscription'
+type MockWebhookStore_DeleteSubscription_Call struct {
+	*mock.Call
+}
+
+// DeleteSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *MockWebhookStore_Expecter) DeleteSubscription(ctx interface{}, id interface{}) *MockWebhookStore_DeleteSubscription_Call {
+	return &MockWebhookStore_DeleteSubscription_Call{Call: _e.mock.On("DeleteSubscription", ctx, id)}
+}
+
+func (_c *MockWebhookStore_DeleteSubscription_Call) Run(run func(ctx context.Context, id int64)) *MockWebhookStore_DeleteSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *MockWebhookStore_DeleteSubscription_Call) Return(_a0 error) *MockWebhookStore_DeleteSubscription_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWebhookStore_DeleteSubscription_Call) RunAndReturn(run func(context.Context, int64) error) *MockWebhookStore_DeleteSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnqueueDelivery provides a mock function with given fields: ctx, subscriptionID, event
+func (_m *MockWebhookStore) EnqueueDelivery(ctx context.Context, subscriptionID int64, event oslc.LicenseChangeEvent) error {
+	ret := _m.Called(ctx, subscriptionID, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnqueueDelivery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, oslc.LicenseChangeEvent) error); ok {
+		r0 = rf(ctx, subscriptionID, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWebhookStore_EnqueueDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnqueueDelivery'
+type MockWebhookStore_EnqueueDelivery_Call struct {
+	*mock.Call
+}
+
+// EnqueueDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscriptionID int64
+//   - event oslc.LicenseChangeEvent
+func (_e *MockWebhookStore_Expecter) EnqueueDelivery(ctx interface{}, subscriptionID interface{}, event interface{}) *MockWebhookStore_EnqueueDelivery_Call {
+	return &MockWebhookStore_EnqueueDelivery_Call{Call: _e.mock.On("EnqueueDelivery", ctx, subscriptionID, event)}
+}
+
+func (_c *MockWebhookStore_EnqueueDelivery_Call) Run(run func(ctx context.Context, subscriptionID int64, event oslc.LicenseChangeEvent)) *MockWebhookStore_EnqueueDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(oslc.LicenseChangeEvent))
+	})
+	return _c
+}
+
+func (_c *MockWebhookStore_EnqueueDelivery_Call) Return(_a0 error) *MockWebhookStore_EnqueueDelivery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWebhookStore_EnqueueDelivery_Call) RunAndReturn(run func(context.Context, int64, oslc.LicenseChangeEvent) error) *MockWebhookStore_EnqueueDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FailDelivery provides a mock function with given fields: ctx, id, lastError
+func (_m *MockWebhookStore) FailDelivery(ctx context.Context, id int64, lastError string) error {
+	ret := _m.Called(ctx, id, lastError)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FailDelivery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, id, lastError)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWebhookStore_FailDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FailDelivery'
+type MockWebhookStore_FailDelivery_Call struct {
+	*mock.Call
+}
+
+// FailDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - lastError string
+func (_e *MockWebhookStore_Expecter) FailDelivery(ctx interface{}, id interface{}, lastError interface{}) *MockWebhookStore_FailDelivery_Call {
+	return &MockWebhookStore_FailDelivery_Call{Call: _e.mock.On("FailDelivery", ctx, id, lastError)}
+}
+
+func (_c *MockWebhookStore_FailDelivery_Call) Run(run func(ctx context.Context, id int64, lastError string)) *MockWebhookStore_FailDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockWebhookStore_FailDelivery_Call) Return(_a0 error) *MockWebhookStore_FailDelivery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWebhookStore_FailDelivery_Call) RunAndReturn(run func(context.Context, int64, string) error) *MockWebhookStore_FailDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSubscriptions provides a mock function with given fields: ctx
+func (_m *MockWebhookStore) ListSubscriptions(ctx context.Context) ([]oslc.WebhookSubscription, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSubscriptions")
+	}
+
+	var r0 []oslc.WebhookSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]oslc.WebhookSubscription, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []oslc.WebhookSubscription); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]oslc.WebhookSubscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookStore_ListSubscriptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSubscriptions'
+type MockWebhookStore_ListSubscriptions_Call struct {
+	*mock.Call
+}
+
+// ListSubscriptions is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockWebhookStore_Expecter) ListSubscriptions(ctx interface{}) *MockWebhookStore_ListSubscriptions_Call {
+	return &MockWebhookStore_ListSubscriptions_Call{Call: _e.mock.On("ListSubscriptions", ctx)}
+}
+
+func (_c *MockWebhookStore_ListSubscriptions_Call) Run(run func(ctx context.Context)) *MockWebhookStore_ListSubscriptions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockWebhookStore_ListSubscriptions_Call) Return(_a0 []oslc.WebhookSubscription, _a1 error) *MockWebhookStore_ListSubscriptions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookStore_ListSubscriptions_Call) RunAndReturn(run func(context.Context) ([]oslc.WebhookSubscription, error)) *MockWebhookStore_ListSubscriptions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RetryDelivery provides a mock function with given fields: ctx, id, next, lastError
+func (_m *MockWebhookStore) RetryDelivery(ctx context.Context, id int64, next time.Time, lastError string) error {
+	ret := _m.Called(ctx, id, next, lastError)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetryDelivery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time, string) error); ok {
+		r0 = rf(ctx, id, next, lastError)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWebhookStore_RetryDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetryDelivery'
+type MockWebhookStore_RetryDelivery_Call struct {
+	*mock.Call
+}
+
+// RetryDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - next time.Time
+//   - lastError string
+func (_e *MockWebhookStore_Expecter) RetryDelivery(ctx interface{}, id interface{}, next interface{}, lastError interface{}) *MockWebhookStore_RetryDelivery_Call {
+	return &MockWebhookStore_RetryDelivery_Call{Call: _e.mock.On("RetryDelivery", ctx, id, next, lastError)}
+}
+
+func (_c *MockWebhookStore_RetryDelivery_Call) Run(run func(ctx context.Context, id int64, next time.Time, lastError string)) *MockWebhookStore_RetryDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(time.Time), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockWebhookStore_RetryDelivery_Call) Return(_a0 error) *MockWebhookStore_RetryDelivery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWebhookStore_RetryDelivery_Call) RunAndReturn(run func(context.Context, int64, time.Time, string) error) *MockWebhookStore_RetryDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockWebhookStore creates a new instance of MockWebhookStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockWebhookStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockWebhookStore {
+	mock := &MockWebhookStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,174 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/chainalysis-oss/oslc"
+	"github.com/chainalysis-oss/oslc/licensecategory"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Payload is the JSON body of a webhook request.
+type Payload struct {
+	// Event is the type of the event. It is always [EventLicenseChanged].
+	Event string `json:"event"`
+	oslc.LicenseChangeEvent
+	// LicenseCategory is the category of License, as determined by the licensecategory package.
+	LicenseCategory string `json:"license_category"`
+	// PreviousLicenseCategory is the category of PreviousLicense.
+	PreviousLicenseCategory string `json:"previous_license_category"`
+}
+
+// NewPayload returns the payload delivered for the event.
+func NewPayload(event oslc.LicenseChangeEvent) Payload {
+	return Payload{
+		Event:                   EventLicenseChanged,
+		LicenseChangeEvent:      event,
+		LicenseCategory:         licensecategory.Of(event.License),
+		PreviousLicenseCategory: licensecategory.Of(event.PreviousLicense),
+	}
+}
+
+// Dispatcher delivers the queued deliveries of a [oslc.WebhookStore].
+type Dispatcher struct {
+	options *dispatcherOptions
+}
+
+// NewDispatcher returns a new Dispatcher. The Store option is required.
+func NewDispatcher(options ...DispatcherOption) (*Dispatcher, error) {
+	opts := defaultDispatcherOptions
+	for _, opt := range globalDispatcherOptions {
+		opt.apply(&opts)
+	}
+	for _, opt := range options {
+		opt.apply(&opts)
+	}
+
+	if opts.Store == nil {
+		return nil, ErrMissingOptionStore
+	}
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = &http.Client{}
+	}
+
+	return &Dispatcher{
+		options: &opts,
+	}, nil
+}
+
+// Run delivers due deliveries every poll interval until ctx is cancelled. Errors from the store are logged, and do not
+// stop the dispatcher. Run returns nil once ctx is cancelled.
+func (d *Dispatcher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(d.options.PollInterval)
+	defer ticker.Stop()
+	for {
+		if _, err := d.DeliverDue(ctx); err != nil && ctx.Err() == nil {
+			d.options.Logger.ErrorContext(ctx, "failed to deliver webhooks", slog.String("error", err.Error()))
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeliverDue claims due deliveries from the store in batches and delivers them, until no due deliveries are left. It
+// returns the number of deliveries that were claimed. Failed deliveries are rescheduled with exponential backoff, or
+// marked as failed once the maximum number of attempts is reached.
+func (d *Dispatcher) DeliverDue(ctx context.Context) (int, error) {
+	claimed := 0
+	for {
+		deliveries, err := d.options.Store.ClaimDeliveries(ctx, d.options.BatchSize, d.options.Lease)
+		if err != nil {
+			return claimed, fmt.Errorf("claiming webhook deliveries: %w", err)
+		}
+		claimed += len(deliveries)
+		for _, delivery := range deliveries {
+			if err := d.resolve(ctx, delivery, d.deliver(ctx, delivery)); err != nil {
+				return claimed, err
+			}
+		}
+		if len(deliveries) < d.options.BatchSize {
+			return claimed, nil
+		}
+	}
+}
+
+// resolve records the outcome of a delivery attempt in the store.
+func (d *Dispatcher) resolve(ctx context.Context, delivery oslc.WebhookDelivery, deliveryErr error) error {
+	logger := d.options.Logger.With(
+		slog.Int64("delivery_id", delivery.ID),
+		slog.Int64("subscription_id", delivery.SubscriptionID),
+		slog.Int("attempts", delivery.Attempts),
+	)
+	var err error
+	switch {
+	case deliveryErr == nil:
+		logger.DebugContext(ctx, "delivered webhook")
+		err = d.options.Store.CompleteDelivery(ctx, delivery.ID)
+	case delivery.Attempts >= d.options.MaxAttempts:
+		logger.WarnContext(ctx, "giving up on webhook delivery", slog.String("error", deliveryErr.Error()))
+		err = d.options.Store.FailDelivery(ctx, delivery.ID, deliveryErr.Error())
+	default:
+		next := time.Now().Add(d.backoff(delivery.Attempts))
+		logger.InfoContext(ctx, "webhook delivery failed, retrying", slog.String("error", deliveryErr.Error()), slog.Time("next_attempt", next))
+		err = d.options.Store.RetryDelivery(ctx, delivery.ID, next, deliveryErr.Error())
+	}
+	if err != nil {
+		return fmt.Errorf("resolving webhook delivery %d: %w", delivery.ID, err)
+	}
+	return nil
+}
+
+// backoff returns the delay before the next attempt of a delivery that has failed the provided number of attempts.
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.options.BackoffBase
+	for i := 1; i < attempts && delay < d.options.BackoffMax; i++ {
+		delay *= 2
+	}
+	return min(delay, d.options.BackoffMax)
+}
+
+var errUnexpectedStatus = errors.New("unexpected status code")
+
+// deliver sends a single delivery to its subscription's URL. Any response status other than 2xx is an error.
+func (d *Dispatcher) deliver(ctx context.Context, delivery oslc.WebhookDelivery) error {
+	body, err := json.Marshal(NewPayload(delivery.Event))
+	if err != nil {
+		return fmt.Errorf("encoding payload: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.options.RequestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	now := time.Now()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", d.options.UserAgent)
+	req.Header.Set(HeaderEvent, EventLicenseChanged)
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set(HeaderSignature, Sign(delivery.Secret, now, body))
+
+	resp, err := d.options.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// Drain the body so the connection can be reused.
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%w: %d", errUnexpectedStatus, resp.StatusCode)
+	}
+	return nil
+}
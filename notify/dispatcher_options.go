@@ -0,0 +1,137 @@
+package notify
+
+import (
+	"github.com/chainalysis-oss/oslc"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+type dispatcherOptions struct {
+	Logger     *slog.Logger
+	Store      oslc.WebhookStore
+	HTTPClient *http.Client
+	UserAgent  string
+	// PollInterval is the time between checks for due deliveries.
+	PollInterval time.Duration
+	// BatchSize is the maximum number of deliveries claimed at once.
+	BatchSize int
+	// MaxAttempts is the number of attempts after which a delivery is marked as failed.
+	MaxAttempts int
+	// BackoffBase is the delay before the first retry. The delay doubles with every further attempt.
+	BackoffBase time.Duration
+	// BackoffMax is the maximum delay between attempts.
+	BackoffMax time.Duration
+	// Lease is the time a claimed delivery is unavailable to other dispatchers.
+	Lease time.Duration
+	// RequestTimeout is the timeout for a single delivery request.
+	RequestTimeout time.Duration
+}
+
+var defaultDispatcherOptions = dispatcherOptions{
+	Logger:         slog.Default(),
+	UserAgent:      "oslc-webhooks",
+	PollInterval:   5 * time.Second,
+	BatchSize:      20,
+	MaxAttempts:    8,
+	BackoffBase:    30 * time.Second,
+	BackoffMax:     time.Hour,
+	Lease:          time.Minute,
+	RequestTimeout: 10 * time.Second,
+}
+
+var globalDispatcherOptions []DispatcherOption
+
+// DispatcherOption is an option for configuring a Dispatcher.
+type DispatcherOption interface {
+	apply(*dispatcherOptions)
+}
+
+// funcDispatcherOption is a DispatcherOption that calls a function.
+// It is used to wrap a function, so it satisfies the DispatcherOption interface.
+type funcDispatcherOption struct {
+	f func(*dispatcherOptions)
+}
+
+func (fdo *funcDispatcherOption) apply(opts *dispatcherOptions) {
+	fdo.f(opts)
+}
+
+func newFuncDispatcherOption(f func(*dispatcherOptions)) *funcDispatcherOption {
+	return &funcDispatcherOption{
+		f: f,
+	}
+}
+
+// WithDispatcherLogger returns a DispatcherOption that uses the provided logger.
+func WithDispatcherLogger(logger *slog.Logger) DispatcherOption {
+	return newFuncDispatcherOption(func(opts *dispatcherOptions) {
+		opts.Logger = logger
+	})
+}
+
+// WithDispatcherStore returns a DispatcherOption that uses the provided store for the delivery queue.
+func WithDispatcherStore(store oslc.WebhookStore) DispatcherOption {
+	return newFuncDispatcherOption(func(opts *dispatcherOptions) {
+		opts.Store = store
+	})
+}
+
+// WithHTTPClient returns a DispatcherOption that uses the provided HTTP client to deliver webhooks.
+func WithHTTPClient(client *http.Client) DispatcherOption {
+	return newFuncDispatcherOption(func(opts *dispatcherOptions) {
+		opts.HTTPClient = client
+	})
+}
+
+// WithUserAgent returns a DispatcherOption that sets the User-Agent header of webhook requests.
+func WithUserAgent(userAgent string) DispatcherOption {
+	return newFuncDispatcherOption(func(opts *dispatcherOptions) {
+		opts.UserAgent = userAgent
+	})
+}
+
+// WithPollInterval returns a DispatcherOption that sets the time between checks for due deliveries.
+func WithPollInterval(interval time.Duration) DispatcherOption {
+	return newFuncDispatcherOption(func(opts *dispatcherOptions) {
+		opts.PollInterval = interval
+	})
+}
+
+// WithBatchSize returns a DispatcherOption that sets the maximum number of deliveries claimed at once.
+func WithBatchSize(size int) DispatcherOption {
+	return newFuncDispatcherOption(func(opts *dispatcherOptions) {
+		opts.BatchSize = size
+	})
+}
+
+// WithMaxAttempts returns a DispatcherOption that sets the number of attempts after which a delivery is marked as
+// failed.
+func WithMaxAttempts(attempts int) DispatcherOption {
+	return newFuncDispatcherOption(func(opts *dispatcherOptions) {
+		opts.MaxAttempts = attempts
+	})
+}
+
+// WithBackoff returns a DispatcherOption that sets the delay before the first retry, and the maximum delay between
+// attempts.
+func WithBackoff(base, max time.Duration) DispatcherOption {
+	return newFuncDispatcherOption(func(opts *dispatcherOptions) {
+		opts.BackoffBase = base
+		opts.BackoffMax = max
+	})
+}
+
+// WithLease returns a DispatcherOption that sets the time a claimed delivery is unavailable to other dispatchers.
+func WithLease(lease time.Duration) DispatcherOption {
+	return newFuncDispatcherOption(func(opts *dispatcherOptions) {
+		opts.Lease = lease
+	})
+}
+
+// WithRequestTimeout returns a DispatcherOption that sets the timeout for a single delivery request.
+func WithRequestTimeout(timeout time.Duration) DispatcherOption {
+	return newFuncDispatcherOption(func(opts *dispatcherOptions) {
+		opts.RequestTimeout = timeout
+	})
+}
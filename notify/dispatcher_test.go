@@ -0,0 +1,167 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/chainalysis-oss/oslc"
+	oslcMocks "github.com/chainalysis-oss/oslc/mocks/oslc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNewDispatcher(t *testing.T) {
+	_, err := NewDispatcher()
+	require.ErrorIs(t, err, ErrMissingOptionStore)
+
+	d, err := NewDispatcher(WithDispatcherStore(oslcMocks.NewMockWebhookStore(t)))
+	require.NoError(t, err)
+	require.NotNil(t, d.options.HTTPClient)
+	require.Equal(t, defaultDispatcherOptions.BatchSize, d.options.BatchSize)
+}
+
+func TestNewPayload(t *testing.T) {
+	payload := NewPayload(oslc.LicenseChangeEvent{
+		Distributor:     oslc.DistributorNpm,
+		Name:            "test",
+		Version:         "2.0.0",
+		License:         "AGPL-3.0-only",
+		PreviousVersion: "1.0.0",
+		PreviousLicense: "MIT",
+		DetectedAt:      time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	})
+	body, err := json.Marshal(payload)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"event": "license.changed",
+		"distributor": "npm",
+		"name": "test",
+		"version": "2.0.0",
+		"license": "AGPL-3.0-only",
+		"license_category": "network-copyleft",
+		"previous_version": "1.0.0",
+		"previous_license": "MIT",
+		"previous_license_category": "permissive",
+		"detected_at": "2024-01-02T03:04:05Z"
+	}`, string(body))
+}
+
+func TestDispatcher_backoff(t *testing.T) {
+	d, err := NewDispatcher(WithDispatcherStore(oslcMocks.NewMockWebhookStore(t)), WithBackoff(time.Second, 10*time.Second))
+	require.NoError(t, err)
+	require.Equal(t, time.Second, d.backoff(1))
+	require.Equal(t, 2*time.Second, d.backoff(2))
+	require.Equal(t, 8*time.Second, d.backoff(4))
+	require.Equal(t, 10*time.Second, d.backoff(5))
+	require.Equal(t, 10*time.Second, d.backoff(100))
+}
+
+func TestDispatcher_DeliverDue(t *testing.T) {
+	event := oslc.LicenseChangeEvent{
+		Distributor:     oslc.DistributorPypi,
+		Name:            "test",
+		Version:         "2.0.0",
+		License:         "GPL-3.0-only",
+		PreviousVersion: "1.0.0",
+		PreviousLicense: "MIT",
+	}
+	received := make(chan Payload, 1)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		if err := Verify("secret", r.Header, body, time.Minute, time.Now()); err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		require.Equal(t, EventLicenseChanged, r.Header.Get(HeaderEvent))
+		require.Equal(t, "1", r.Header.Get(HeaderDelivery))
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		var payload Payload
+		require.NoError(t, json.Unmarshal(body, &payload))
+		received <- payload
+	}))
+	defer receiver.Close()
+
+	store := oslcMocks.NewMockWebhookStore(t)
+	store.EXPECT().ClaimDeliveries(mock.Anything, 20, time.Minute).Return([]oslc.WebhookDelivery{
+		{ID: 1, SubscriptionID: 1, URL: receiver.URL, Secret: "secret", Event: event, Attempts: 1},
+	}, nil).Once()
+	store.EXPECT().CompleteDelivery(mock.Anything, int64(1)).Return(nil)
+	d, err := NewDispatcher(WithDispatcherStore(store), WithHTTPClient(receiver.Client()))
+	require.NoError(t, err)
+
+	n, err := d.DeliverDue(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	require.Equal(t, NewPayload(event), <-received)
+}
+
+func TestDispatcher_DeliverDue_failures(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer receiver.Close()
+
+	store := oslcMocks.NewMockWebhookStore(t)
+	store.EXPECT().ClaimDeliveries(mock.Anything, 2, time.Minute).Return([]oslc.WebhookDelivery{
+		{ID: 1, URL: receiver.URL, Attempts: 1},
+		{ID: 2, URL: receiver.URL, Attempts: 3},
+	}, nil).Once()
+	store.EXPECT().ClaimDeliveries(mock.Anything, 2, time.Minute).Return(nil, nil).Once()
+	before := time.Now()
+	store.EXPECT().RetryDelivery(mock.Anything, int64(1), mock.AnythingOfType("time.Time"), "unexpected status code: 500").
+		Run(func(_ context.Context, _ int64, next time.Time, _ string) {
+			require.WithinRange(t, next, before.Add(time.Second), time.Now().Add(time.Second))
+		}).Return(nil)
+	store.EXPECT().FailDelivery(mock.Anything, int64(2), "unexpected status code: 500").Return(nil)
+	d, err := NewDispatcher(
+		WithDispatcherStore(store),
+		WithHTTPClient(receiver.Client()),
+		WithBatchSize(2),
+		WithMaxAttempts(3),
+		WithBackoff(time.Second, time.Minute),
+	)
+	require.NoError(t, err)
+
+	n, err := d.DeliverDue(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+}
+
+func TestDispatcher_DeliverDue_store_errors(t *testing.T) {
+	t.Run("claim", func(t *testing.T) {
+		store := oslcMocks.NewMockWebhookStore(t)
+		store.EXPECT().ClaimDeliveries(mock.Anything, 20, time.Minute).Return(nil, assert.AnError)
+		d, err := NewDispatcher(WithDispatcherStore(store))
+		require.NoError(t, err)
+		_, err = d.DeliverDue(context.Background())
+		require.ErrorIs(t, err, assert.AnError)
+	})
+	t.Run("resolve", func(t *testing.T) {
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer receiver.Close()
+		store := oslcMocks.NewMockWebhookStore(t)
+		store.EXPECT().ClaimDeliveries(mock.Anything, 20, time.Minute).Return([]oslc.WebhookDelivery{{ID: 1, URL: receiver.URL, Attempts: 1}}, nil)
+		store.EXPECT().CompleteDelivery(mock.Anything, int64(1)).Return(assert.AnError)
+		d, err := NewDispatcher(WithDispatcherStore(store), WithHTTPClient(receiver.Client()))
+		require.NoError(t, err)
+		_, err = d.DeliverDue(context.Background())
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestDispatcher_Run(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	store := oslcMocks.NewMockWebhookStore(t)
+	store.EXPECT().ClaimDeliveries(mock.Anything, 20, time.Minute).Run(func(context.Context, int, time.Duration) {
+		cancel()
+	}).Return(nil, nil)
+	d, err := NewDispatcher(WithDispatcherStore(store), WithPollInterval(time.Millisecond))
+	require.NoError(t, err)
+	require.NoError(t, d.Run(ctx))
+}
@@ -0,0 +1,111 @@
+// Package notify delivers notifications about license changes to webhook subscribers.
+//
+// The [Notifier] matches events against the subscriptions in a [oslc.WebhookStore] and queues a delivery for every
+// matching subscription. The [Dispatcher] delivers queued events as signed HTTP POST requests, and retries failed
+// deliveries with exponential backoff. Since the queue is persistent, deliveries survive restarts of the server.
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/chainalysis-oss/oslc"
+	"github.com/chainalysis-oss/oslc/licensecategory"
+	"log/slog"
+	"strings"
+)
+
+// Compile time check to ensure Notifier implements [oslc.LicenseChangeNotifier].
+var _ oslc.LicenseChangeNotifier = (*Notifier)(nil)
+
+// Notifier queues [oslc.LicenseChangeEvent] notifications for delivery to matching webhook subscriptions.
+type Notifier struct {
+	options *notifierOptions
+}
+
+// NewNotifier returns a new Notifier. The Store option is required.
+func NewNotifier(options ...NotifierOption) (*Notifier, error) {
+	opts := defaultNotifierOptions
+	for _, opt := range globalNotifierOptions {
+		opt.apply(&opts)
+	}
+	for _, opt := range options {
+		opt.apply(&opts)
+	}
+
+	if opts.Store == nil {
+		return nil, ErrMissingOptionStore
+	}
+
+	return &Notifier{
+		options: &opts,
+	}, nil
+}
+
+var ErrMissingOptionStore = errors.New("missing option: store")
+
+// NotifyLicenseChange queues the event for delivery to every subscription that matches it. Queuing stops at the first
+// error, so the event may have been queued for some subscriptions when an error is returned.
+func (n *Notifier) NotifyLicenseChange(ctx context.Context, event oslc.LicenseChangeEvent) error {
+	subscriptions, err := n.options.Store.ListSubscriptions(ctx)
+	if err != nil {
+		return fmt.Errorf("listing webhook subscriptions: %w", err)
+	}
+	for _, subscription := range subscriptions {
+		if !Matches(subscription, event) {
+			continue
+		}
+		if err := n.options.Store.EnqueueDelivery(ctx, subscription.ID, event); err != nil {
+			return fmt.Errorf("queuing delivery for webhook subscription %d: %w", subscription.ID, err)
+		}
+		n.options.Logger.DebugContext(ctx, "queued license change notification",
+			slog.Int64("subscription_id", subscription.ID),
+			slog.String("distributor", event.Distributor),
+			slog.String("name", event.Name),
+			slog.String("version", event.Version),
+		)
+	}
+	return nil
+}
+
+// Matches reports whether the event matches every filter of the subscription.
+func Matches(subscription oslc.WebhookSubscription, event oslc.LicenseChangeEvent) bool {
+	if subscription.Distributor != "" && subscription.Distributor != event.Distributor {
+		return false
+	}
+	if subscription.PackagePattern != "" && !matchPattern(subscription.PackagePattern, event.Name) {
+		return false
+	}
+	if len(subscription.LicenseCategories) > 0 {
+		category := licensecategory.Of(event.License)
+		for _, c := range subscription.LicenseCategories {
+			if c == category {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// matchPattern reports whether name matches pattern, where an asterisk (*) matches any sequence of characters,
+// including none. All other characters must match exactly.
+func matchPattern(pattern, name string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == name
+	}
+	if !strings.HasPrefix(name, parts[0]) {
+		return false
+	}
+	name = name[len(parts[0]):]
+	last := parts[len(parts)-1]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(name, part)
+		if i < 0 {
+			return false
+		}
+		name = name[i+len(part):]
+	}
+	return strings.HasSuffix(name, last)
+}
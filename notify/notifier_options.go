@@ -0,0 +1,52 @@
+package notify
+
+import (
+	"github.com/chainalysis-oss/oslc"
+	"log/slog"
+)
+
+type notifierOptions struct {
+	Logger *slog.Logger
+	Store  oslc.WebhookStore
+}
+
+var defaultNotifierOptions = notifierOptions{
+	Logger: slog.Default(),
+}
+
+var globalNotifierOptions []NotifierOption
+
+// NotifierOption is an option for configuring a Notifier.
+type NotifierOption interface {
+	apply(*notifierOptions)
+}
+
+// funcNotifierOption is a NotifierOption that calls a function.
+// It is used to wrap a function, so it satisfies the NotifierOption interface.
+type funcNotifierOption struct {
+	f func(*notifierOptions)
+}
+
+func (fdo *funcNotifierOption) apply(opts *notifierOptions) {
+	fdo.f(opts)
+}
+
+func newFuncNotifierOption(f func(*notifierOptions)) *funcNotifierOption {
+	return &funcNotifierOption{
+		f: f,
+	}
+}
+
+// WithNotifierLogger returns a NotifierOption that uses the provided logger.
+func WithNotifierLogger(logger *slog.Logger) NotifierOption {
+	return newFuncNotifierOption(func(opts *notifierOptions) {
+		opts.Logger = logger
+	})
+}
+
+// WithNotifierStore returns a NotifierOption that uses the provided store for subscriptions and the delivery queue.
+func WithNotifierStore(store oslc.WebhookStore) NotifierOption {
+	return newFuncNotifierOption(func(opts *notifierOptions) {
+		opts.Store = store
+	})
+}
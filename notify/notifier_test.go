@@ -0,0 +1,116 @@
+package notify
+
+import (
+	"context"
+	"github.com/chainalysis-oss/oslc"
+	oslcMocks "github.com/chainalysis-oss/oslc/mocks/oslc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestNewNotifier(t *testing.T) {
+	_, err := NewNotifier()
+	require.ErrorIs(t, err, ErrMissingOptionStore)
+
+	store := oslcMocks.NewMockWebhookStore(t)
+	n, err := NewNotifier(WithNotifierStore(store))
+	require.NoError(t, err)
+	require.Equal(t, store, n.options.Store)
+}
+
+func TestMatches(t *testing.T) {
+	event := oslc.LicenseChangeEvent{
+		Distributor: oslc.DistributorNpm,
+		Name:        "@types/node",
+		Version:     "2.0.0",
+		License:     "GPL-3.0-only",
+	}
+	testcases := []struct {
+		name         string
+		subscription oslc.WebhookSubscription
+		expected     bool
+	}{
+		{"no filters", oslc.WebhookSubscription{}, true},
+		{"distributor", oslc.WebhookSubscription{Distributor: oslc.DistributorNpm}, true},
+		{"other distributor", oslc.WebhookSubscription{Distributor: oslc.DistributorPypi}, false},
+		{"exact name", oslc.WebhookSubscription{PackagePattern: "@types/node"}, true},
+		{"other name", oslc.WebhookSubscription{PackagePattern: "@types/nod"}, false},
+		{"scope glob", oslc.WebhookSubscription{PackagePattern: "@types/*"}, true},
+		{"other scope glob", oslc.WebhookSubscription{PackagePattern: "@babel/*"}, false},
+		{"category", oslc.WebhookSubscription{LicenseCategories: []string{"permissive", "copyleft"}}, true},
+		{"other category", oslc.WebhookSubscription{LicenseCategories: []string{"permissive"}}, false},
+		{"all filters", oslc.WebhookSubscription{Distributor: oslc.DistributorNpm, PackagePattern: "*node", LicenseCategories: []string{"copyleft"}}, true},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, Matches(tt.subscription, event))
+		})
+	}
+}
+
+func TestMatchPattern(t *testing.T) {
+	testcases := []struct {
+		pattern  string
+		name     string
+		expected bool
+	}{
+		{"test", "test", true},
+		{"test", "test2", false},
+		{"*", "", true},
+		{"*", "anything/at/all", true},
+		{"test*", "test", true},
+		{"test*", "testing", true},
+		{"*test", "my-test", true},
+		{"*test", "test-my", false},
+		{"a*b*c", "aXbYc", true},
+		{"a*b*c", "abc", true},
+		{"a*b*c", "acb", false},
+		{"github.com/*/oslc", "github.com/chainalysis-oss/oslc", true},
+		{"ab*ba", "aba", false},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.pattern+" "+tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, matchPattern(tt.pattern, tt.name))
+		})
+	}
+}
+
+func TestNotifier_NotifyLicenseChange(t *testing.T) {
+	event := oslc.LicenseChangeEvent{
+		Distributor: oslc.DistributorPypi,
+		Name:        "test",
+		Version:     "2.0.0",
+		License:     "MIT",
+	}
+	store := oslcMocks.NewMockWebhookStore(t)
+	store.EXPECT().ListSubscriptions(context.Background()).Return([]oslc.WebhookSubscription{
+		{ID: 1},
+		{ID: 2, Distributor: oslc.DistributorNpm},
+		{ID: 3, LicenseCategories: []string{"permissive"}},
+	}, nil)
+	store.EXPECT().EnqueueDelivery(context.Background(), int64(1), event).Return(nil)
+	store.EXPECT().EnqueueDelivery(context.Background(), int64(3), event).Return(nil)
+	n, err := NewNotifier(WithNotifierStore(store))
+	require.NoError(t, err)
+
+	require.NoError(t, n.NotifyLicenseChange(context.Background(), event))
+}
+
+func TestNotifier_NotifyLicenseChange_errors(t *testing.T) {
+	t.Run("list", func(t *testing.T) {
+		store := oslcMocks.NewMockWebhookStore(t)
+		store.EXPECT().ListSubscriptions(context.Background()).Return(nil, assert.AnError)
+		n, err := NewNotifier(WithNotifierStore(store))
+		require.NoError(t, err)
+		require.ErrorIs(t, n.NotifyLicenseChange(context.Background(), oslc.LicenseChangeEvent{}), assert.AnError)
+	})
+	t.Run("enqueue", func(t *testing.T) {
+		store := oslcMocks.NewMockWebhookStore(t)
+		store.EXPECT().ListSubscriptions(context.Background()).Return([]oslc.WebhookSubscription{{ID: 1}, {ID: 2}}, nil)
+		store.EXPECT().EnqueueDelivery(context.Background(), int64(1), oslc.LicenseChangeEvent{}).Return(assert.AnError)
+		n, err := NewNotifier(WithNotifierStore(store))
+		require.NoError(t, err)
+		require.ErrorIs(t, n.NotifyLicenseChange(context.Background(), oslc.LicenseChangeEvent{}), assert.AnError)
+	})
+}
@@ -0,0 +1,69 @@
+package notify
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The headers set on every webhook request.
+const (
+	// HeaderEvent is the type of the delivered event. It is always [EventLicenseChanged].
+	HeaderEvent = "X-Oslc-Event"
+	// HeaderDelivery is the ID of the delivery. It is identical across retries of the same delivery, so receivers can
+	// use it to discard duplicates.
+	HeaderDelivery = "X-Oslc-Delivery"
+	// HeaderTimestamp is the time the request was signed, in seconds since the Unix epoch.
+	HeaderTimestamp = "X-Oslc-Timestamp"
+	// HeaderSignature is the signature of the request, see [Sign].
+	HeaderSignature = "X-Oslc-Signature"
+)
+
+// EventLicenseChanged is the event type of [oslc.LicenseChangeEvent] deliveries.
+const EventLicenseChanged = "license.changed"
+
+// signaturePrefix identifies the algorithm used to compute the signature.
+const signaturePrefix = "sha256="
+
+var (
+	// ErrInvalidSignature is returned by [Verify] if the signature does not match the request.
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	// ErrExpiredSignature is returned by [Verify] if the request was signed too long ago.
+	ErrExpiredSignature = errors.New("expired webhook signature")
+)
+
+// Sign returns the value of the [HeaderSignature] header for a request with the provided body, signed at timestamp.
+// The signature is the hex encoded HMAC-SHA256 of the timestamp, a period, and the body, keyed with the subscription's
+// secret, prefixed with "sha256=". Including the timestamp allows receivers to reject replayed requests.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature of a webhook request with the provided headers and body. Requests signed more than
+// tolerance before now are rejected with [ErrExpiredSignature]. A tolerance of zero disables this check.
+//
+// Verify is intended for use by receivers written in Go, and documents the signature scheme for everyone else.
+func Verify(secret string, header http.Header, body []byte, tolerance time.Duration, now time.Time) error {
+	unix, err := strconv.ParseInt(header.Get(HeaderTimestamp), 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	timestamp := time.Unix(unix, 0)
+	signature := header.Get(HeaderSignature)
+	if !strings.HasPrefix(signature, signaturePrefix) || !hmac.Equal([]byte(signature), []byte(Sign(secret, timestamp, body))) {
+		return ErrInvalidSignature
+	}
+	if tolerance > 0 && now.Sub(timestamp) > tolerance {
+		return ErrExpiredSignature
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package notify
+
+import (
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestSign(t *testing.T) {
+	timestamp := time.Unix(1700000000, 0)
+	// Computed with: printf '1700000000.{}' | openssl dgst -sha256 -hmac secret
+	require.Equal(t, "sha256=b8569b78799ff9e3cbff0fc2d63a33a2b57f3282abd07c37ae5e8e7d79a5f163", Sign("secret", timestamp, []byte("{}")))
+	require.NotEqual(t, Sign("secret", timestamp, []byte("{}")), Sign("other", timestamp, []byte("{}")))
+	require.NotEqual(t, Sign("secret", timestamp, []byte("{}")), Sign("secret", timestamp.Add(time.Second), []byte("{}")))
+}
+
+func TestVerify(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	body := []byte(`{"name":"test"}`)
+	header := func(secret string, timestamp time.Time, body []byte) http.Header {
+		h := http.Header{}
+		h.Set(HeaderTimestamp, strconv.FormatInt(timestamp.Unix(), 10))
+		h.Set(HeaderSignature, Sign(secret, timestamp, body))
+		return h
+	}
+
+	require.NoError(t, Verify("secret", header("secret", now, body), body, time.Minute, now))
+	require.ErrorIs(t, Verify("secret", header("other", now, body), body, time.Minute, now), ErrInvalidSignature)
+	require.ErrorIs(t, Verify("secret", header("secret", now, body), []byte(`{}`), time.Minute, now), ErrInvalidSignature)
+	require.ErrorIs(t, Verify("secret", http.Header{}, body, time.Minute, now), ErrInvalidSignature)
+	require.ErrorIs(t, Verify("secret", header("secret", now.Add(-time.Hour), body), body, time.Minute, now), ErrExpiredSignature)
+	require.NoError(t, Verify("secret", header("secret", now.Add(-time.Hour), body), body, 0, now))
+
+	h := header("secret", now, body)
+	h.Set(HeaderSignature, h.Get(HeaderSignature)[len(signaturePrefix):])
+	require.ErrorIs(t, Verify("secret", h, body, time.Minute, now), ErrInvalidSignature)
+}
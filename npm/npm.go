@@ -1,6 +1,7 @@
 package npm
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/chainalysis-oss/oslc"
@@ -89,18 +90,18 @@ func NewClient(options ...ClientOption) (*Client, error) {
 
 // GetPackageVersion returns the package with the given name and version.
 // If version is empty, the latest version is returned.
-func (c *Client) GetPackageVersion(name, version string) (oslc.Entry, error) {
+func (c *Client) GetPackageVersion(ctx context.Context, name, version string) (oslc.Entry, error) {
 	if name == "" {
-		return oslc.Entry{}, oslc.DistributorError{Distributor: oslc.DistributorNpm, Err: fmt.Errorf("%w: package is empty", oslc.ErrNoSuchPackage)}
+		return oslc.Entry{}, oslc.DistributorError{Distributor: oslc.DistributorNpm, Kind: oslc.DistributorErrorInvalidName, Err: fmt.Errorf("%w: package name is empty", oslc.ErrNoSuchPackage)}
 	}
 	path := fmt.Sprintf("%s/%s", name, version)
 	if version == "" {
 		path = fmt.Sprintf("%s", name)
 	}
 
-	resp, err := c.options.HttpClient.Query(fmt.Sprintf("%s/%s", c.options.BaseURL, path))
+	resp, err := c.options.HttpClient.Query(ctx, fmt.Sprintf("%s/%s", c.options.BaseURL, path))
 	if err != nil {
-		return oslc.Entry{}, oslc.DistributorError{Distributor: oslc.DistributorNpm, Err: err}
+		return oslc.Entry{}, oslc.NewTransportError(oslc.DistributorNpm, err)
 	}
 
 	if resp.StatusCode == http.StatusNotFound {
@@ -113,7 +114,7 @@ func (c *Client) GetPackageVersion(name, version string) (oslc.Entry, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return oslc.Entry{}, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return oslc.Entry{}, oslc.NewStatusError(oslc.DistributorNpm, resp, fmt.Errorf("unexpected status code: %d", resp.StatusCode))
 	}
 
 	defer resp.Body.Close()
@@ -128,6 +129,70 @@ func (c *Client) GetPackageVersion(name, version string) (oslc.Entry, error) {
 
 // GetPackage returns the package with the given name. It is a convenience function for [GetPackageVersion]
 // with an empty version.
-func (c *Client) GetPackage(name string) (oslc.Entry, error) {
-	return c.GetPackageVersion(name, "")
+func (c *Client) GetPackage(ctx context.Context, name string) (oslc.Entry, error) {
+	return c.GetPackageVersion(ctx, name, "")
+}
+
+// abbreviatedPackageDocument is the media type of the abbreviated package document, which only holds what is needed to
+// install the package, such as its versions and dist-tags. Unlike the full document, its size does not grow with the
+// READMEs of the package, which exceed the reader limit of the HTTP client for large packages.
+const abbreviatedPackageDocument = "application/vnd.npm.install-v1+json"
+
+// ListVersions returns the versions listed in the abbreviated package document of the package with the given name.
+func (c *Client) ListVersions(ctx context.Context, name string) ([]string, error) {
+	var pkg npmPackageResponse
+	if err := c.getPackageDocument(ctx, name, &pkg); err != nil {
+		return nil, err
+	}
+	versions := make([]string, 0, len(pkg.Versions))
+	for v := range pkg.Versions {
+		versions = append(versions, v)
+	}
+	return versions, nil
+}
+
+// npmDistTagsResponse is the part of a package document listing the dist-tags of the package.
+type npmDistTagsResponse struct {
+	DistTags map[string]string `json:"dist-tags"`
+}
+
+// ListDistTags returns the dist-tags of the package with the given name, such as "latest" or "next", mapped to the
+// versions they point to. They are read from the abbreviated package document.
+func (c *Client) ListDistTags(ctx context.Context, name string) (map[string]string, error) {
+	var pkg npmDistTagsResponse
+	if err := c.getPackageDocument(ctx, name, &pkg); err != nil {
+		return nil, err
+	}
+	if pkg.DistTags == nil {
+		return map[string]string{}, nil
+	}
+	return pkg.DistTags, nil
+}
+
+// getPackageDocument retrieves the abbreviated package document of the package with the given name and decodes it
+// into dst.
+func (c *Client) getPackageDocument(ctx context.Context, name string, dst any) error {
+	if name == "" {
+		return oslc.DistributorError{Distributor: oslc.DistributorNpm, Kind: oslc.DistributorErrorInvalidName, Err: fmt.Errorf("%w: package name is empty", oslc.ErrNoSuchPackage)}
+	}
+
+	resp, err := c.options.HttpClient.QueryWithHeaders(ctx, fmt.Sprintf("%s/%s", c.options.BaseURL, name), http.Header{
+		"Accept": {abbreviatedPackageDocument},
+	})
+	if err != nil {
+		return oslc.NewTransportError(oslc.DistributorNpm, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return oslc.DistributorError{Distributor: oslc.DistributorNpm, Err: fmt.Errorf("%w: %s", oslc.ErrNoSuchPackage, name)}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return oslc.NewStatusError(oslc.DistributorNpm, resp, fmt.Errorf("unexpected status code: %d", resp.StatusCode))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(dst); err != nil {
+		return oslc.DistributorError{Distributor: oslc.DistributorNpm, Err: err}
+	}
+	return nil
 }
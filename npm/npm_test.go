@@ -2,6 +2,7 @@ package npm
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"github.com/chainalysis-oss/oslc"
 	ownHTTP "github.com/chainalysis-oss/oslc/http"
@@ -243,7 +244,7 @@ func TestClient_GetPackageVersion(t *testing.T) {
 	for _, tt := range testcases {
 		t.Run(tt.name, func(t *testing.T) {
 			c := setupClient(t, setupHttpClientWithBody(t, tt.body))
-			out, err := c.GetPackageVersion(tt.pkgName, tt.pkgVersion)
+			out, err := c.GetPackageVersion(context.Background(), tt.pkgName, tt.pkgVersion)
 			require.NoError(t, err)
 			require.Equal(t, tt.expected, out)
 		})
@@ -261,7 +262,7 @@ func TestClient_GetPackageVersion_version_path(t *testing.T) {
 	httpClient, err := ownHTTP.NewClient(ownHTTP.WithHTTPClient(mock))
 	require.NoError(t, err)
 	c := setupClient(t, httpClient)
-	_, err = c.GetPackageVersion("test", "1.0.0")
+	_, err = c.GetPackageVersion(context.Background(), "test", "1.0.0")
 	require.NoError(t, err)
 }
 
@@ -276,7 +277,7 @@ func TestClient_GetPackageVersion_no_version_path(t *testing.T) {
 	httpClient, err := ownHTTP.NewClient(ownHTTP.WithHTTPClient(mock))
 	require.NoError(t, err)
 	c := setupClient(t, httpClient)
-	_, err = c.GetPackageVersion("test", "")
+	_, err = c.GetPackageVersion(context.Background(), "test", "")
 	require.NoError(t, err)
 }
 
@@ -287,19 +288,19 @@ func TestClient_GetPackageVersion_http_client_error(t *testing.T) {
 	httpClient, err := ownHTTP.NewClient(ownHTTP.WithHTTPClient(mock))
 	require.NoError(t, err)
 	c := setupClient(t, httpClient)
-	_, err = c.GetPackageVersion("test", "")
+	_, err = c.GetPackageVersion(context.Background(), "test", "")
 	assert.Error(t, err)
 }
 
 func TestClient_GetPackageVersion_http_client_status_error(t *testing.T) {
 	c := setupClient(t, setupHttpClientWithStatusAndBody(t, http.StatusNotFound, ""))
-	_, err := c.GetPackageVersion("test", "")
+	_, err := c.GetPackageVersion(context.Background(), "test", "")
 	assert.Error(t, err)
 }
 
 func TestClient_GetPackageVersion_json_decode_error(t *testing.T) {
 	c := setupClient(t, setupHttpClientWithBody(t, "test"))
-	_, err := c.GetPackageVersion("test", "")
+	_, err := c.GetPackageVersion(context.Background(), "test", "")
 	assert.Error(t, err)
 }
 
@@ -314,6 +315,139 @@ func TestClient_GetPackage(t *testing.T) {
 	httpClient, err := ownHTTP.NewClient(ownHTTP.WithHTTPClient(mock))
 	require.NoError(t, err)
 	c := setupClient(t, httpClient)
-	_, err = c.GetPackage("test")
+	_, err = c.GetPackage(context.Background(), "test")
 	require.NoError(t, err)
 }
+
+func TestClient_ListVersions(t *testing.T) {
+	testcases := []struct {
+		name     string
+		status   int
+		body     string
+		expected []string
+		err      error
+	}{
+		{
+			name:     "versions",
+			status:   http.StatusOK,
+			body:     `{"name":"test","versions":{"1.0.0":{},"1.1.0":{},"2.0.0":{}}}`,
+			expected: []string{"1.0.0", "1.1.0", "2.0.0"},
+		},
+		{
+			name:     "no versions",
+			status:   http.StatusOK,
+			body:     `{}`,
+			expected: []string{},
+		},
+		{
+			name:   "not found",
+			status: http.StatusNotFound,
+			err:    oslc.ErrNoSuchPackage,
+		},
+		{
+			name:   "unexpected status",
+			status: http.StatusInternalServerError,
+			err:    oslc.DistributorError{},
+		},
+		{
+			name:   "decode error",
+			status: http.StatusOK,
+			body:   "test",
+			err:    oslc.DistributorError{},
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.name, func(t *testing.T) {
+			c := setupClient(t, setupHttpClientWithStatusAndBody(t, tt.status, tt.body))
+			out, err := c.ListVersions(context.Background(), "test")
+			if tt.err != nil {
+				require.ErrorAs(t, err, &oslc.DistributorError{})
+				if tt.err != (oslc.DistributorError{}) {
+					require.ErrorIs(t, err, tt.err)
+				}
+				return
+			}
+			require.NoError(t, err)
+			require.ElementsMatch(t, tt.expected, out)
+		})
+	}
+}
+
+func TestClient_ListVersions_path(t *testing.T) {
+	mock := ownHTTP.NewTestHTTPClient(func(req *http.Request) (*http.Response, error) {
+		require.Equal(t, "https://registry.npmjs.org/test", req.URL.String())
+		require.Equal(t, "application/vnd.npm.install-v1+json", req.Header.Get("Accept"))
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBuffer([]byte(`{}`))),
+		}, nil
+	})
+	httpClient, err := ownHTTP.NewClient(ownHTTP.WithHTTPClient(mock))
+	require.NoError(t, err)
+	c := setupClient(t, httpClient)
+	_, err = c.ListVersions(context.Background(), "test")
+	require.NoError(t, err)
+}
+
+func TestClient_ListDistTags(t *testing.T) {
+	testcases := []struct {
+		name     string
+		status   int
+		body     string
+		expected map[string]string
+		err      error
+	}{
+		{
+			name:     "dist-tags",
+			status:   http.StatusOK,
+			body:     `{"name":"test","dist-tags":{"latest":"1.1.0","next":"2.0.0-rc.1"}}`,
+			expected: map[string]string{"latest": "1.1.0", "next": "2.0.0-rc.1"},
+		},
+		{
+			name:     "no dist-tags",
+			status:   http.StatusOK,
+			body:     `{}`,
+			expected: map[string]string{},
+		},
+		{
+			name:   "not found",
+			status: http.StatusNotFound,
+			err:    oslc.ErrNoSuchPackage,
+		},
+		{
+			name:   "unexpected status",
+			status: http.StatusInternalServerError,
+			err:    oslc.DistributorError{},
+		},
+		{
+			name:   "decode error",
+			status: http.StatusOK,
+			body:   "test",
+			err:    oslc.DistributorError{},
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.name, func(t *testing.T) {
+			c := setupClient(t, setupHttpClientWithStatusAndBody(t, tt.status, tt.body))
+			out, err := c.ListDistTags(context.Background(), "test")
+			if tt.err != nil {
+				require.ErrorAs(t, err, &oslc.DistributorError{})
+				if tt.err != (oslc.DistributorError{}) {
+					require.ErrorIs(t, err, tt.err)
+				}
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, out)
+		})
+	}
+}
+
+func TestClient_ListDistTags_empty_name(t *testing.T) {
+	c := setupClient(t, setupHttpClientWithStatusAndBody(t, http.StatusOK, `{}`))
+	_, err := c.ListDistTags(context.Background(), "")
+	require.ErrorIs(t, err, oslc.ErrNoSuchPackage)
+	var de oslc.DistributorError
+	require.ErrorAs(t, err, &de)
+	require.Equal(t, oslc.DistributorErrorInvalidName, de.Kind)
+}
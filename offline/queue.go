@@ -0,0 +1,140 @@
+// Package offline supports serving the catalog without access to the distributors. A server in offline mode records
+// the packages it cannot answer to a [Queue]. The queue is resolved on a machine with access to the distributors, and
+// the result is imported into the offline catalog.
+package offline
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/chainalysis-oss/oslc"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+)
+
+// Compile time check to ensure Queue satisfies the oslc.MissRecorder interface.
+var _ oslc.MissRecorder = (*Queue)(nil)
+
+// Queue is an [oslc.MissRecorder] that appends misses to a JSONL file, one [oslc.PackageMiss] per line. Each miss is
+// recorded once, so the file lists every package that needs to be resolved without duplicates.
+type Queue struct {
+	options *queueOptions
+
+	mu   sync.Mutex
+	file *os.File
+	seen map[oslc.PackageMiss]struct{}
+}
+
+// NewQueue creates a queue, creating the file if it does not exist. The queue must be closed with Close.
+func NewQueue(options ...QueueOption) (*Queue, error) {
+	opts := defaultQueueOptions
+	for _, opt := range globalQueueOptions {
+		opt.apply(&opts)
+	}
+	for _, opt := range options {
+		opt.apply(&opts)
+	}
+
+	if opts.Path == "" {
+		return nil, ErrMissingOptionPath
+	}
+
+	f, err := os.OpenFile(opts.Path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("opening miss queue: %w", err)
+	}
+	misses, err := ReadMisses(f)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("reading miss queue %s: %w", opts.Path, err)
+	}
+
+	q := &Queue{
+		options: &opts,
+		file:    f,
+		seen:    make(map[oslc.PackageMiss]struct{}, len(misses)),
+	}
+	for _, miss := range misses {
+		q.seen[miss] = struct{}{}
+	}
+	opts.Logger.Info("opened miss queue", slog.String("path", opts.Path), slog.Int("misses", len(misses)))
+	return q, nil
+}
+
+var ErrMissingOptionPath = errors.New("missing option: path")
+
+// RecordMiss appends the miss to the file, unless it has already been recorded.
+func (q *Queue) RecordMiss(ctx context.Context, miss oslc.PackageMiss) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if _, ok := q.seen[miss]; ok {
+		return nil
+	}
+	b, err := json.Marshal(miss)
+	if err != nil {
+		return err
+	}
+	if _, err := q.file.Write(append(b, '\n')); err != nil {
+		return fmt.Errorf("writing miss queue: %w", err)
+	}
+	q.seen[miss] = struct{}{}
+	q.options.Logger.InfoContext(ctx, "recorded miss",
+		slog.String("distributor", miss.Distributor),
+		slog.String("name", miss.Name),
+		slog.String("version", miss.Version),
+	)
+	return nil
+}
+
+// Len returns the number of misses in the queue.
+func (q *Queue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.seen)
+}
+
+// Close closes the file of the queue.
+func (q *Queue) Close() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.file.Close()
+}
+
+// ReadMisses reads misses written by a [Queue] from r. Blank lines are skipped, and duplicate misses are returned once,
+// in the order they were first read.
+func ReadMisses(r io.Reader) ([]oslc.PackageMiss, error) {
+	misses := make([]oslc.PackageMiss, 0)
+	seen := make(map[oslc.PackageMiss]struct{})
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		b := scanner.Bytes()
+		if len(bytes.TrimSpace(b)) == 0 {
+			continue
+		}
+		var miss oslc.PackageMiss
+		if err := json.Unmarshal(b, &miss); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if miss.Distributor == "" || miss.Name == "" {
+			return nil, fmt.Errorf("line %d: %w", line, ErrIncompleteMiss)
+		}
+		if _, ok := seen[miss]; ok {
+			continue
+		}
+		seen[miss] = struct{}{}
+		misses = append(misses, miss)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return misses, nil
+}
+
+// ErrIncompleteMiss is returned by [ReadMisses] for misses without a distributor or name.
+var ErrIncompleteMiss = errors.New("miss must have a distributor and a name")
@@ -0,0 +1,53 @@
+package offline
+
+import (
+	"log/slog"
+)
+
+type queueOptions struct {
+	Logger *slog.Logger
+	// Path is the path of the JSONL file misses are appended to. Misses already in the file are loaded when the queue
+	// is created, so they are not recorded again.
+	Path string
+}
+
+var defaultQueueOptions = queueOptions{
+	Logger: slog.Default(),
+}
+
+var globalQueueOptions []QueueOption
+
+// QueueOption is an option for configuring a Queue.
+type QueueOption interface {
+	apply(*queueOptions)
+}
+
+// funcQueueOption is a QueueOption that calls a function.
+// It is used to wrap a function, so it satisfies the QueueOption interface.
+type funcQueueOption struct {
+	f func(*queueOptions)
+}
+
+func (fqo *funcQueueOption) apply(opts *queueOptions) {
+	fqo.f(opts)
+}
+
+func newFuncQueueOption(f func(*queueOptions)) *funcQueueOption {
+	return &funcQueueOption{
+		f: f,
+	}
+}
+
+// WithLogger returns a QueueOption that uses the provided logger.
+func WithLogger(logger *slog.Logger) QueueOption {
+	return newFuncQueueOption(func(opts *queueOptions) {
+		opts.Logger = logger
+	})
+}
+
+// WithPath returns a QueueOption that appends misses to the JSONL file at path.
+func WithPath(path string) QueueOption {
+	return newFuncQueueOption(func(opts *queueOptions) {
+		opts.Path = path
+	})
+}
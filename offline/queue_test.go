@@ -0,0 +1,131 @@
+package offline
+
+import (
+	"context"
+	"github.com/chainalysis-oss/oslc"
+	"github.com/stretchr/testify/require"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func newTestQueue(t *testing.T, path string) *Queue {
+	t.Helper()
+	q, err := NewQueue(WithPath(path), WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	require.NoError(t, err)
+	t.Cleanup(func() { q.Close() })
+	return q
+}
+
+func readQueueFile(t *testing.T, path string) []oslc.PackageMiss {
+	t.Helper()
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	misses, err := ReadMisses(f)
+	require.NoError(t, err)
+	return misses
+}
+
+func TestNewQueue_ErrMissingOptionPath(t *testing.T) {
+	_, err := NewQueue()
+	require.ErrorIs(t, err, ErrMissingOptionPath)
+}
+
+func TestNewQueue_invalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "misses.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte("not json\n"), 0o644))
+	_, err := NewQueue(WithPath(path))
+	require.ErrorContains(t, err, "line 1")
+}
+
+func TestQueue_RecordMiss(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "misses.jsonl")
+	q := newTestQueue(t, path)
+	misses := []oslc.PackageMiss{
+		{Distributor: oslc.DistributorNpm, Name: "test", Version: "^1.0.0"},
+		{Distributor: oslc.DistributorPypi, Name: "requests"},
+	}
+	for _, miss := range misses {
+		require.NoError(t, q.RecordMiss(context.Background(), miss))
+	}
+	// Recording a miss again does not add it to the file.
+	require.NoError(t, q.RecordMiss(context.Background(), misses[0]))
+
+	require.Equal(t, 2, q.Len())
+	require.Equal(t, misses, readQueueFile(t, path))
+}
+
+func TestQueue_RecordMiss_reopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "misses.jsonl")
+	miss := oslc.PackageMiss{Distributor: oslc.DistributorNpm, Name: "test", Version: "1.0.0"}
+	q := newTestQueue(t, path)
+	require.NoError(t, q.RecordMiss(context.Background(), miss))
+	require.NoError(t, q.Close())
+
+	q = newTestQueue(t, path)
+	require.Equal(t, 1, q.Len())
+	require.NoError(t, q.RecordMiss(context.Background(), miss))
+	require.NoError(t, q.RecordMiss(context.Background(), oslc.PackageMiss{Distributor: oslc.DistributorNpm, Name: "other"}))
+	require.Len(t, readQueueFile(t, path), 2)
+}
+
+func TestQueue_RecordMiss_concurrent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "misses.jsonl")
+	q := newTestQueue(t, path)
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			require.NoError(t, q.RecordMiss(context.Background(), oslc.PackageMiss{Distributor: oslc.DistributorNpm, Name: "test", Version: "1.0.0"}))
+		}()
+	}
+	wg.Wait()
+	require.Len(t, readQueueFile(t, path), 1)
+}
+
+func TestQueue_RecordMiss_closed(t *testing.T) {
+	q := newTestQueue(t, filepath.Join(t.TempDir(), "misses.jsonl"))
+	require.NoError(t, q.Close())
+	require.Error(t, q.RecordMiss(context.Background(), oslc.PackageMiss{Distributor: oslc.DistributorNpm, Name: "test"}))
+}
+
+func TestReadMisses(t *testing.T) {
+	input := `{"distributor":"npm","name":"test","version":"1.0.0"}
+
+{"distributor":"pypi","name":"requests"}
+{"distributor":"npm","name":"test","version":"1.0.0"}
+`
+	misses, err := ReadMisses(strings.NewReader(input))
+	require.NoError(t, err)
+	require.Equal(t, []oslc.PackageMiss{
+		{Distributor: oslc.DistributorNpm, Name: "test", Version: "1.0.0"},
+		{Distributor: oslc.DistributorPypi, Name: "requests"},
+	}, misses)
+}
+
+func TestReadMisses_errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr error
+	}{
+		{name: "invalid json", input: "{\n"},
+		{name: "missing name", input: `{"distributor":"npm"}`, wantErr: ErrIncompleteMiss},
+		{name: "missing distributor", input: `{"name":"test"}`, wantErr: ErrIncompleteMiss},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadMisses(strings.NewReader(tt.input))
+			require.Error(t, err)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
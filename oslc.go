@@ -4,6 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
 )
 
 type Entry struct {
@@ -11,12 +16,25 @@ type Entry struct {
 	DistributionPoints []DistributionPoint `json:"distribution_points,omitempty"`
 	License            string              `json:"license"`
 	Version            string              `json:"version"`
+	// RawLicense is the license as declared in the distributor's metadata, before normalization.
+	RawLicense string `json:"raw_license,omitempty"`
+	// NormalizationStatus describes how License was derived from RawLicense. It is empty for entries that were stored
+	// before the outcome of normalization was recorded.
+	NormalizationStatus LicenseNormalizationStatus `json:"normalization_status,omitempty"`
+	// LicenseListVersion is the version of the license list that RawLicense was normalized against.
+	LicenseListVersion string `json:"license_list_version,omitempty"`
 }
 
 type DistributionPoint struct {
 	Name        string `json:"name"`
 	URL         string `json:"url"`
 	Distributor string `json:"distributor"`
+	// Kind describes what URL points to, such as "sdist" or "wheel" on PyPI. It is empty if the distributor does not
+	// distinguish between kinds of distribution points.
+	Kind string `json:"kind,omitempty"`
+	// Checksum is the checksum of the artifact at URL, prefixed with the name of the algorithm and a colon, such as
+	// "sha256:<hex digest>". It is empty if the checksum is unknown.
+	Checksum string `json:"checksum,omitempty"`
 }
 
 const (
@@ -33,22 +51,439 @@ type DatastoreSaver interface {
 
 type DatastoreRetriever interface {
 	Retrieve(ctx context.Context, name, version, distributor string) (Entry, error)
+	// RetrieveVersions returns the entries for every stored version of the package with the provided name and
+	// distributor, in no particular order. If no versions are stored, an empty slice is returned.
+	RetrieveVersions(ctx context.Context, name, distributor string) ([]Entry, error)
+}
+
+// EntryFilter selects entries in a datastore. Fields left at their zero value match every entry.
+type EntryFilter struct {
+	Distributor string
+	// NamePattern matches the name of the package. An asterisk (*) matches any sequence of characters, so "@types/*"
+	// matches every package in the @types scope on npm. A pattern without asterisks must match the name exactly.
+	NamePattern string
+	Version     string
+	// License, if not nil, matches entries with exactly this license. A pointer to an empty string matches entries for
+	// which no license could be normalized.
+	License *string
+}
+
+// IsZero reports whether the filter matches every entry.
+func (f EntryFilter) IsZero() bool {
+	return f.Distributor == "" && f.NamePattern == "" && f.Version == "" && f.License == nil
+}
+
+type DatastoreInvalidator interface {
+	// Invalidate deletes all entries matching the filter, and returns the number of deleted entries.
+	Invalidate(ctx context.Context, filter EntryFilter) (int64, error)
+}
+
+// SearchQuery selects entries in a datastore for [DatastoreSearcher]. Fields left at their zero value match every
+// entry.
+type SearchQuery struct {
+	Distributor string
+	// NamePrefix matches packages whose name starts with the prefix.
+	NamePrefix string
+	// NameContains matches packages whose name contains the substring.
+	NameContains string
+	// License, if not nil, matches entries with exactly this license. A pointer to an empty string matches entries for
+	// which no license could be normalized.
+	License *string
+	// LicenseInExpression extends License to also match entries whose license is a license expression, such as
+	// "MIT OR Apache-2.0", that contains the license identifier.
+	LicenseInExpression bool
+	// FetchedAfter and FetchedBefore, if not zero, match entries fetched from the distributor at or after, and before
+	// the respective time.
+	FetchedAfter  time.Time
+	FetchedBefore time.Time
+	// After, if not nil, matches only entries ordered after the cursor.
+	After *SearchCursor
+	// Limit is the maximum number of entries returned.
+	Limit int
+}
+
+// SearchCursor is the position of an entry in the order of search results. Results are ordered by distributor, name
+// and version.
+type SearchCursor struct {
+	Distributor string `json:"d"`
+	Name        string `json:"n"`
+	Version     string `json:"v"`
+}
+
+// StoredEntry is an [Entry] of a single distributor as stored in a datastore.
+type StoredEntry struct {
+	Entry
+	Distributor string
+	// FetchedAt is the time the entry was last fetched from the distributor.
+	FetchedAt time.Time
+}
+
+// Cursor returns the position of the entry in the order of search results.
+func (e StoredEntry) Cursor() SearchCursor {
+	return SearchCursor{Distributor: e.Distributor, Name: e.Name, Version: e.Version}
+}
+
+type DatastoreSearcher interface {
+	// Search returns at most query.Limit entries matching the query, ordered by distributor, name and version.
+	Search(ctx context.Context, query SearchQuery) ([]StoredEntry, error)
+}
+
+// PackageCount is the number of stored entries with a given distributor and license.
+type PackageCount struct {
+	Distributor         string
+	License             string
+	NormalizationStatus LicenseNormalizationStatus
+	Count               int64
+}
+
+// DatastoreIterator is an interface for visiting every stored entry of a datastore, for example to export the catalog.
+// Any [DatastoreSearcher] can be iterated by paging through its search results; the catalog package provides such an
+// iterator for datastores that do not implement DatastoreIterator themselves.
+type DatastoreIterator interface {
+	// Iterate calls f for every entry matching query, ordered by distributor, name and version. query.Limit is
+	// ignored. Iteration stops at the first error returned by f, which Iterate returns.
+	Iterate(ctx context.Context, query SearchQuery, f func(StoredEntry) error) error
+}
+
+type DatastoreCounter interface {
+	// CountPackages returns the number of stored entries for every combination of distributor and license that has at
+	// least one entry, in no particular order.
+	CountPackages(ctx context.Context) ([]PackageCount, error)
 }
 
 type Datastore interface {
 	DatastoreSaver
 	DatastoreRetriever
+	DatastoreInvalidator
+	DatastoreSearcher
+	DatastoreCounter
+}
+
+// CatalogStats summarizes the entries in a datastore. Every map holds the number of entries per key.
+type CatalogStats struct {
+	Total                 int64
+	ByLicense             map[string]int64
+	ByLicenseCategory     map[string]int64
+	ByDistributor         map[string]int64
+	ByNormalizationStatus map[string]int64
+	// ComputedAt is the time the statistics were computed. Statistics may be cached, so this can be in the past.
+	ComputedAt time.Time
+}
+
+// CatalogStatsProvider is an interface for retrieving [CatalogStats].
+type CatalogStatsProvider interface {
+	CatalogStats(ctx context.Context) (CatalogStats, error)
+}
+
+// LicenseOverride is an authoritative license for a range of versions of a package. Overrides are used to correct
+// packages for which the distributor's metadata names the wrong license, or no usable license at all.
+//
+// Overrides are identified by the combination of Tenant, Distributor, Name and VersionRange. The syntax of VersionRange
+// is documented by ParseRange in the versions package, and versions are compared in the version scheme of Distributor.
+//
+// Overrides without a tenant are shared, and apply to every request. The overrides of a tenant apply only to the
+// requests of that tenant, and take precedence over the shared overrides.
+type LicenseOverride struct {
+	Tenant        string    `json:"tenant"`
+	Distributor   string    `json:"distributor"`
+	Name          string    `json:"name"`
+	VersionRange  string    `json:"version_range"`
+	License       string    `json:"license"`
+	Justification string    `json:"justification"`
+	Author        string    `json:"author"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// CurationStore is an interface for storing [LicenseOverride] objects.
+//
+// SetOverride creates the override, or replaces the existing override with the same tenant, distributor, name and
+// version range. It returns the override as stored.
+//
+// ListOverrides returns the overrides of the provided tenant matching the provided distributor and name, most recently
+// updated first. An empty tenant selects the shared overrides only. An empty distributor or name matches all
+// distributors or names respectively.
+//
+// DeleteOverride deletes the override with the provided tenant, distributor, name and version range. If no such
+// override exists, the implementation must return [ErrDatastoreObjectNotFound].
+type CurationStore interface {
+	SetOverride(ctx context.Context, override LicenseOverride) (LicenseOverride, error)
+	ListOverrides(ctx context.Context, tenant, distributor, name string) ([]LicenseOverride, error)
+	DeleteOverride(ctx context.Context, tenant, distributor, name, versionRange string) error
+}
+
+// tenantContextKey is the key of the tenant in the values of a context.
+type tenantContextKey struct{}
+
+// ContextWithTenant returns a copy of ctx carrying the tenant the request is made for. Tenants share one deployment,
+// but each has its own license overrides, policy and quota.
+func ContextWithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenant)
+}
+
+// TenantFromContext returns the tenant carried by ctx, or an empty string if the request is not made for a tenant.
+func TenantFromContext(ctx context.Context) string {
+	tenant, _ := ctx.Value(tenantContextKey{}).(string)
+	return tenant
+}
+
+// LicenseChangeEvent describes a package version whose license differs from the license of the preceding version of
+// the same package.
+type LicenseChangeEvent struct {
+	Distributor     string    `json:"distributor"`
+	Name            string    `json:"name"`
+	Version         string    `json:"version"`
+	License         string    `json:"license"`
+	PreviousVersion string    `json:"previous_version"`
+	PreviousLicense string    `json:"previous_license"`
+	DetectedAt      time.Time `json:"detected_at"`
+}
+
+// LicenseChangeNotifier is an interface for notifying interested parties of a [LicenseChangeEvent].
+type LicenseChangeNotifier interface {
+	NotifyLicenseChange(ctx context.Context, event LicenseChangeEvent) error
+}
+
+// PackageMiss describes a request that a server in offline mode could not answer from its datastore. Version is the
+// version as requested, so it may be a version constraint, a dist-tag, or empty for the latest version.
+type PackageMiss struct {
+	Distributor string `json:"distributor"`
+	Name        string `json:"name"`
+	Version     string `json:"version,omitempty"`
+}
+
+// MissRecorder is an interface for recording [PackageMiss] objects, so the missing packages can be resolved on a
+// machine with access to the distributors and imported into the offline catalog. Implementations must be safe for
+// concurrent use, and may ignore misses they have already recorded.
+type MissRecorder interface {
+	RecordMiss(ctx context.Context, miss PackageMiss) error
+}
+
+// PackageEventType is the kind of change described by a [PackageEvent].
+type PackageEventType string
+
+const (
+	// PackageEventCreated is the type of events for package versions added to the catalog.
+	PackageEventCreated PackageEventType = "created"
+	// PackageEventLicenseChanged is the type of events for package versions that were fetched again from the
+	// distributor, and found to have a different license than the one in the catalog.
+	PackageEventLicenseChanged PackageEventType = "license_changed"
+	// PackageEventCurated is the type of events for license overrides that were set for a package.
+	PackageEventCurated PackageEventType = "curated"
+)
+
+// PackageEvent describes a change to the catalog.
+type PackageEvent struct {
+	Type        PackageEventType
+	Distributor string
+	Name        string
+	// Version is the version of the package. It is empty for [PackageEventCurated] events.
+	Version string
+	// License is the license of the package version, or the license of the override for [PackageEventCurated]
+	// events.
+	License string
+	// PreviousLicense is the license the catalog held before a [PackageEventLicenseChanged] event.
+	PreviousLicense string
+	// VersionRange is the version range of the override of a [PackageEventCurated] event.
+	VersionRange string
+	// Tenant is the tenant of the override of a [PackageEventCurated] event. Events with a tenant are only delivered
+	// to subscribers of that tenant.
+	Tenant     string
+	OccurredAt time.Time
+}
+
+// PackageEventFilter selects the [PackageEvent] objects delivered to a subscriber. Fields left at their zero value
+// match every event.
+type PackageEventFilter struct {
+	Distributor string
+	// Names, if not empty, restricts events to packages with one of these names.
+	Names []string
+	// Licenses, if not empty, restricts events to those whose License or PreviousLicense is one of these licenses.
+	Licenses []string
+	// Tenant is the tenant of the subscriber. Unlike the other fields, it does not restrict the events that are not
+	// specific to a tenant.
+	Tenant string
+}
+
+// Matches reports whether the event matches every field of the filter.
+func (f PackageEventFilter) Matches(event PackageEvent) bool {
+	if event.Tenant != "" && event.Tenant != f.Tenant {
+		return false
+	}
+	if f.Distributor != "" && f.Distributor != event.Distributor {
+		return false
+	}
+	if len(f.Names) > 0 && !slices.Contains(f.Names, event.Name) {
+		return false
+	}
+	if len(f.Licenses) > 0 && !slices.Contains(f.Licenses, event.License) &&
+		(event.PreviousLicense == "" || !slices.Contains(f.Licenses, event.PreviousLicense)) {
+		return false
+	}
+	return true
+}
+
+// PackageEventSubscription is a subscription to the events of a [PackageEventBroker].
+type PackageEventSubscription interface {
+	// Events returns the channel on which matching events are delivered. The channel is closed when the subscription
+	// ends.
+	Events() <-chan PackageEvent
+	// Err returns the reason the subscription ended once the channel returned by Events is closed, and nil before.
+	Err() error
+}
+
+// ErrSubscriptionOverflow ends a [PackageEventSubscription] whose subscriber did not keep up with the published
+// events.
+var ErrSubscriptionOverflow = errors.New("subscriber did not keep up with events")
+
+// ErrTooManySubscriptions is returned by [PackageEventBroker] when it cannot accept another subscription.
+var ErrTooManySubscriptions = errors.New("too many subscriptions")
+
+// ErrBrokerClosed is returned by [PackageEventBroker] when it has been closed, and ends its subscriptions.
+var ErrBrokerClosed = errors.New("broker closed")
+
+// PackageEventBroker distributes [PackageEvent] objects to subscribers.
+//
+// PublishPackageEvent must not block, so that publishing never delays the request that caused the event. Subscribers
+// that do not keep up are ended with [ErrSubscriptionOverflow] rather than slowing down publishing.
+//
+// SubscribePackageEvents returns a subscription to the events matching filter that are published after it returns.
+// The subscription ends with the error of ctx when ctx is done. It may fail with [ErrTooManySubscriptions] or
+// [ErrBrokerClosed].
+//
+// Subscriptions returns the number of active subscriptions, so that publishers can skip the work of preparing events
+// nobody receives.
+type PackageEventBroker interface {
+	PublishPackageEvent(ctx context.Context, event PackageEvent)
+	SubscribePackageEvents(ctx context.Context, filter PackageEventFilter) (PackageEventSubscription, error)
+	Subscriptions() int
+}
+
+// WebhookSubscription is a subscription to [LicenseChangeEvent] notifications delivered to URL. Events are signed with
+// Secret. Filters left at their zero value match every event.
+type WebhookSubscription struct {
+	ID     int64
+	URL    string
+	Secret string
+	// Distributor, if set, restricts the subscription to events for packages from this distributor.
+	Distributor string
+	// PackagePattern, if set, restricts the subscription to events for packages with a matching name. An asterisk (*)
+	// matches any sequence of characters.
+	PackagePattern string
+	// LicenseCategories, if not empty, restricts the subscription to events where the new license is in one of these
+	// categories.
+	LicenseCategories []string
+	CreatedAt         time.Time
+}
+
+// WebhookDelivery is a pending delivery of an event to a [WebhookSubscription].
+type WebhookDelivery struct {
+	ID             int64
+	SubscriptionID int64
+	URL            string
+	Secret         string
+	Event          LicenseChangeEvent
+	// Attempts is the number of delivery attempts, including the attempt the delivery was claimed for.
+	Attempts int
+}
+
+// WebhookStore is an interface for storing [WebhookSubscription] objects and the queue of [WebhookDelivery] objects.
+//
+// DeleteSubscription must return [ErrDatastoreObjectNotFound] if no subscription with the provided ID exists. Pending
+// deliveries for a deleted subscription are deleted with it.
+//
+// EnqueueDelivery queues the event for delivery to the subscription with the provided ID.
+//
+// ClaimDeliveries returns at most limit deliveries that are due, and makes them unavailable to other callers for the
+// duration of lease. This allows several servers to deliver from the same queue. A claimed delivery must be resolved
+// with exactly one of CompleteDelivery, RetryDelivery or FailDelivery. If it is not resolved before the lease expires,
+// it is claimed again.
+//
+// CompleteDelivery removes a delivery from the queue. RetryDelivery makes a delivery due again at the provided time.
+// FailDelivery keeps a delivery for inspection, but never makes it due again.
+type WebhookStore interface {
+	CreateSubscription(ctx context.Context, subscription WebhookSubscription) (WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context) ([]WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id int64) error
+	EnqueueDelivery(ctx context.Context, subscriptionID int64, event LicenseChangeEvent) error
+	ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]WebhookDelivery, error)
+	CompleteDelivery(ctx context.Context, id int64) error
+	RetryDelivery(ctx context.Context, id int64, next time.Time, lastError string) error
+	FailDelivery(ctx context.Context, id int64, lastError string) error
+}
+
+// JobState is the state of a [Job].
+type JobState string
+
+const (
+	// JobPending is the state of jobs waiting to be claimed, either for the first time or to be retried.
+	JobPending JobState = "pending"
+	// JobRunning is the state of jobs claimed by a worker.
+	JobRunning JobState = "running"
+	// JobSucceeded is the state of jobs that resolved their package.
+	JobSucceeded JobState = "succeeded"
+	// JobFailed is the state of jobs that could not resolve their package and will not be retried.
+	JobFailed JobState = "failed"
+)
+
+// JobRequest identifies the package resolved by a [Job]. Version is the version as requested, which may be a version
+// constraint, or empty for the latest version.
+type JobRequest struct {
+	Distributor string
+	Name        string
+	Version     string
+}
+
+// Job is a lookup of a package that is resolved in the background.
+type Job struct {
+	ID int64
+	JobRequest
+	State JobState
+	// Attempts is the number of attempts to resolve the package, including the attempt the job was claimed for.
+	Attempts int
+	// Result is the encoded outcome of a finished job. It is opaque to the store.
+	Result []byte
+	// LastError is the error of the last failed attempt, if any.
+	LastError string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// JobStore is an interface for storing the queue of [Job] objects.
+//
+// EnqueueJob queues a job for the request, unless a pending or running job for the same request exists, in which case
+// that job is returned instead. GetJob must return [ErrDatastoreObjectNotFound] if no job with the provided ID exists.
+//
+// ClaimJobs returns at most limit pending jobs that are due, marks them as running, and makes them unavailable to other
+// callers for the duration of lease. A claimed job must be resolved with exactly one of CompleteJob, RetryJob or
+// FailJob. If it is not resolved before the lease expires, it is claimed again. Resolving a job that is neither
+// pending nor running has no effect.
+//
+// CompleteJob marks a job as succeeded with the provided result. RetryJob makes a job pending again, due at the
+// provided time. FailJob marks a job as failed with the provided result. DeleteFinishedJobs deletes the succeeded and
+// failed jobs last updated before the provided time, and returns the number of deleted jobs.
+type JobStore interface {
+	EnqueueJob(ctx context.Context, request JobRequest) (Job, error)
+	GetJob(ctx context.Context, id int64) (Job, error)
+	ClaimJobs(ctx context.Context, limit int, lease time.Duration) ([]Job, error)
+	CompleteJob(ctx context.Context, id int64, result []byte) error
+	RetryJob(ctx context.Context, id int64, next time.Time, lastError string) error
+	FailJob(ctx context.Context, id int64, result []byte, lastError string) error
+	DeleteFinishedJobs(ctx context.Context, before time.Time) (int, error)
 }
 
 var ErrNoSuchPackage = errors.New("no such package")
 
 // DistributorClient is an interface that represents a client that can communicate with a distributor.
 //
+// The provided context is used for all communication with the distributor, and carries deadlines, cancellation and
+// trace context to the upstream requests.
+//
 // Errors from the distributor must be returned as a [DistributorError]. The distributor name must be set to the
 // distributor's name. The format of the name is implementation-specific. The [DistributorError] will ensure the
 // underlying error is not exposed to the caller. Exceptions to this rule are errors indicating that a specific
 // package or version is not found. These errors must be returned as a [DistributionError] wrapping either
-// [ErrNoSuchPackage] or [ErrVersionNotFound].
+// [ErrNoSuchPackage] or [ErrVersionNotFound]. Errors of requests to the distributor should be created with
+// [NewTransportError] and [NewStatusError], so that they are categorized by their [DistributorErrorKind].
 //
 // GetPackage returns the [Entry] object that corresponds to the provided name. If the package is not found,
 // the implementation must return [ErrNoSuchPackage]. If a package version is not found, the implementation
@@ -62,8 +497,27 @@ var ErrNoSuchPackage = errors.New("no such package")
 // with the distributor, the implementation must return a [DistributorError] with the distributor name set to the
 // distributor's name. The format of the name and version is implementation-specific.
 type DistributorClient interface {
-	GetPackage(name string) (Entry, error)
-	GetPackageVersion(name, version string) (Entry, error)
+	GetPackage(ctx context.Context, name string) (Entry, error)
+	GetPackageVersion(ctx context.Context, name, version string) (Entry, error)
+}
+
+// VersionLister is implemented by [DistributorClient] implementations that can enumerate the versions of a package.
+// Not every distributor exposes a list of versions, so callers must check for this capability with a type assertion.
+//
+// ListVersions returns every version of the package with the provided name that is known to the distributor, in no
+// particular order. Errors must be returned in the same way as for [DistributorClient], in particular a
+// [DistributorError] wrapping [ErrNoSuchPackage] if the package is not found.
+type VersionLister interface {
+	ListVersions(ctx context.Context, name string) ([]string, error)
+}
+
+// DistTagLister is implemented by [DistributorClient] implementations for distributors that let publishers point named
+// tags at versions of a package, such as npm's dist-tags "latest" and "next".
+//
+// ListDistTags returns the tags of the package with the provided name, mapped to the versions they point to. Errors
+// must be returned in the same way as for [DistributorClient].
+type DistTagLister interface {
+	ListDistTags(ctx context.Context, name string) (map[string]string, error)
 }
 
 var ErrDatastoreObjectNotFound = errors.New("not found")
@@ -102,10 +556,138 @@ type LicenseIDNormalizer interface {
 	NormalizeID(ctx context.Context, id string) string
 }
 
+// LicenseNormalizationStatus is the outcome of normalizing a license declared by a distributor.
+type LicenseNormalizationStatus string
+
+const (
+	// LicenseNormalizationExact means the declared license is a license identifier of the license list, apart from
+	// case.
+	LicenseNormalizationExact LicenseNormalizationStatus = "exact"
+	// LicenseNormalizationAlias means the declared license is a known alternative name of a license, such as its full
+	// name or a common abbreviation.
+	LicenseNormalizationAlias LicenseNormalizationStatus = "alias"
+	// LicenseNormalizationExpression means the declared license is a license expression, such as "MIT OR Apache-2.0",
+	// whose license identifiers are all in the license list.
+	LicenseNormalizationExpression LicenseNormalizationStatus = "expression"
+	// LicenseNormalizationFailed means the declared license could not be mapped to the license list.
+	LicenseNormalizationFailed LicenseNormalizationStatus = "failed"
+)
+
+// LicenseNormalization is the result of normalizing a declared license.
+type LicenseNormalization struct {
+	// License is the normalized license identifier or expression. It is empty if Status is
+	// [LicenseNormalizationFailed].
+	License string
+	Status  LicenseNormalizationStatus
+	// LicenseListVersion is the version of the license list used for normalization.
+	LicenseListVersion string
+}
+
+// LicenseNormalizer is a [LicenseIDNormalizer] that reports how a license was normalized, and normalizes licenses that
+// are not a single license identifier, such as alternative names and license expressions.
+type LicenseNormalizer interface {
+	LicenseIDNormalizer
+	// Normalize returns the normalized form of the declared license.
+	Normalize(ctx context.Context, license string) LicenseNormalization
+}
+
+// DistributorErrorKind categorizes a [DistributorError] by its cause, so that callers can tell transient failures of
+// the distributor apart from other errors.
+type DistributorErrorKind int
+
+const (
+	// DistributorErrorUnknown is the kind of errors without a more specific category, such as malformed responses.
+	DistributorErrorUnknown DistributorErrorKind = iota
+	// DistributorErrorUnavailable is the kind of errors caused by the distributor being unreachable or responding
+	// with a server error.
+	DistributorErrorUnavailable
+	// DistributorErrorTimeout is the kind of errors caused by a request to the distributor timing out.
+	DistributorErrorTimeout
+	// DistributorErrorRateLimited is the kind of errors caused by the distributor rejecting requests because of rate
+	// limiting.
+	DistributorErrorRateLimited
+	// DistributorErrorInvalidName is the kind of errors caused by a package name that is malformed for the
+	// distributor, such as a Maven name that is not of the form groupId:artifactId.
+	DistributorErrorInvalidName
+)
+
+// String returns the name of the kind.
+func (k DistributorErrorKind) String() string {
+	switch k {
+	case DistributorErrorUnavailable:
+		return "unavailable"
+	case DistributorErrorTimeout:
+		return "timeout"
+	case DistributorErrorRateLimited:
+		return "rate_limited"
+	case DistributorErrorInvalidName:
+		return "invalid_name"
+	}
+	return "unknown"
+}
+
 // DistributorError is an error type that represents an error that occurred in communicating with a distributor.
 type DistributorError struct {
 	Distributor string
 	Err         error
+	// Kind categorizes the error. See [NewTransportError] and [NewStatusError] for errors of HTTP requests.
+	Kind DistributorErrorKind
+	// RetryAfter is the delay after which the distributor asked for a request to be retried, if it did.
+	RetryAfter time.Duration
+}
+
+// NewTransportError returns a [DistributorError] for err, an error that occurred while sending a request to the
+// distributor or reading its response. Timeouts are categorized as [DistributorErrorTimeout], and all other errors as
+// [DistributorErrorUnavailable].
+func NewTransportError(distributor string, err error) DistributorError {
+	kind := DistributorErrorUnavailable
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		kind = DistributorErrorTimeout
+	}
+	return DistributorError{Distributor: distributor, Err: err, Kind: kind}
+}
+
+// NewStatusError returns a [DistributorError] for err, an error describing an unexpected status code in resp.
+// Responses with status 429 are categorized as [DistributorErrorRateLimited], responses with status 408 and 504 as
+// [DistributorErrorTimeout], and other server errors as [DistributorErrorUnavailable]. The Retry-After header of the
+// response, if any, is used for RetryAfter.
+func NewStatusError(distributor string, resp *http.Response, err error) DistributorError {
+	e := DistributorError{Distributor: distributor, Err: err}
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		e.Kind = DistributorErrorRateLimited
+	case resp.StatusCode == http.StatusRequestTimeout || resp.StatusCode == http.StatusGatewayTimeout:
+		e.Kind = DistributorErrorTimeout
+	case resp.StatusCode >= http.StatusInternalServerError:
+		e.Kind = DistributorErrorUnavailable
+	}
+	e.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+	return e
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either a number of seconds or an HTTP date. Invalid
+// values, and dates in the past, result in a zero delay.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.ParseUint(value, 10, 32); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return 0
+}
+
+// WrapDistributorError returns err as a [DistributorError] of the distributor. If err already is a [DistributorError],
+// it is returned unchanged, so that its kind is preserved.
+func WrapDistributorError(distributor string, err error) DistributorError {
+	if e, ok := err.(DistributorError); ok {
+		return e
+	}
+	return DistributorError{Distributor: distributor, Err: err}
 }
 
 func (e DistributorError) Error() string {
@@ -132,3 +714,41 @@ func (e DistributorError) Unwrap() error {
 	}
 	return nil
 }
+
+// ErrorInfoDomain is the domain of the google.rpc.ErrorInfo details attached to errors returned by the servers. The
+// reasons of the details are shared with the client, which converts the errors back to [DistributorError]s.
+const ErrorInfoDomain = "github.com/chainalysis-oss/oslc"
+
+// ErrorInfoDistributorKey is the key of the google.rpc.ErrorInfo metadata entry naming the distributor an error relates
+// to.
+const ErrorInfoDistributorKey = "distributor"
+
+// The reasons of the google.rpc.ErrorInfo details attached to errors returned by the servers.
+const (
+	// ReasonInvalidDistributor is used when the distributor of a request is not supported.
+	ReasonInvalidDistributor = "INVALID_DISTRIBUTOR"
+	// ReasonInvalidPackageName is used when the name of a package is missing or malformed.
+	ReasonInvalidPackageName = "INVALID_PACKAGE_NAME"
+	// ReasonInvalidVersionConstraint is used when a version constraint cannot be parsed or is not supported for the
+	// distributor.
+	ReasonInvalidVersionConstraint = "INVALID_VERSION_CONSTRAINT"
+	// ReasonPackageNotFound is used when the distributor does not know the package.
+	ReasonPackageNotFound = "PACKAGE_NOT_FOUND"
+	// ReasonVersionNotFound is used when the distributor does not know the requested version of a package.
+	ReasonVersionNotFound = "VERSION_NOT_FOUND"
+	// ReasonUpstreamUnavailable is used when the distributor cannot be reached or responds with a server error.
+	ReasonUpstreamUnavailable = "UPSTREAM_UNAVAILABLE"
+	// ReasonUpstreamTimeout is used when a request to the distributor times out.
+	ReasonUpstreamTimeout = "UPSTREAM_TIMEOUT"
+	// ReasonUpstreamRateLimited is used when the distributor rejects requests because of rate limiting.
+	ReasonUpstreamRateLimited = "UPSTREAM_RATE_LIMITED"
+	// ReasonUpstreamError is used for all other failures of the distributor.
+	ReasonUpstreamError = "UPSTREAM_ERROR"
+	// ReasonNotInOfflineCatalog is used when a server in offline mode cannot answer a request from its datastore.
+	ReasonNotInOfflineCatalog = "NOT_IN_OFFLINE_CATALOG"
+	// ReasonDistributorDisabled is used when a request cannot be answered from the datastore, and the server has no
+	// client for the distributor because it was disabled.
+	ReasonDistributorDisabled = "DISTRIBUTOR_DISABLED"
+	// ReasonBatchExceedsQuota is used when a batch has more requests than the quota of the tenant allows at once.
+	ReasonBatchExceedsQuota = "BATCH_EXCEEDS_QUOTA"
+)
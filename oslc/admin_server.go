@@ -0,0 +1,373 @@
+package oslc
+
+import (
+	"buf.build/gen/go/chainalysis-oss/oslc/grpc/go/chainalysis_oss/oslc/v1alpha/oslcv1alphagrpc"
+	oslcv1alpha "buf.build/gen/go/chainalysis-oss/oslc/protocolbuffers/go/chainalysis_oss/oslc/v1alpha"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"github.com/chainalysis-oss/oslc"
+	"github.com/chainalysis-oss/oslc/licensecategory"
+	"github.com/chainalysis-oss/oslc/versions"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"log/slog"
+	"net/url"
+)
+
+// AdminServer implements the OslcAdminService, which manages the data served by [Server]. It is configured with the
+// same options as [Server].
+type AdminServer struct {
+	options *serverOptions
+	// server is used to fetch packages from the distributors in the same way as the OslcService does.
+	server Server
+	oslcv1alphagrpc.UnimplementedOslcAdminServiceServer
+}
+
+// NewAdminServer returns a new AdminServer. The Datastore and CurationStore options are required.
+func NewAdminServer(options ...ServerOption) (*AdminServer, error) {
+	opts := defaultServerOptions
+	for _, opt := range globalServerOptions {
+		opt.apply(&opts)
+	}
+	for _, opt := range options {
+		opt.apply(&opts)
+	}
+
+	if opts.Datastore == nil {
+		return nil, ErrMissingOptionDatastore
+	}
+	if opts.CurationStore == nil {
+		return nil, ErrMissingOptionCurationStore
+	}
+
+	return &AdminServer{
+		options: &opts,
+		server:  Server{options: &opts},
+	}, nil
+}
+
+var ErrMissingOptionDatastore = errors.New("missing option: datastore")
+var ErrMissingOptionCurationStore = errors.New("missing option: curation store")
+
+func licenseOverrideToProto(o oslc.LicenseOverride) *oslcv1alpha.LicenseOverride {
+	return &oslcv1alpha.LicenseOverride{
+		Tenant:        o.Tenant,
+		Distributor:   o.Distributor,
+		Name:          o.Name,
+		VersionRange:  o.VersionRange,
+		License:       o.License,
+		Justification: o.Justification,
+		Author:        o.Author,
+		UpdateTime:    timestamppb.New(o.UpdatedAt),
+	}
+}
+
+// overrideTenant returns the tenant whose license overrides a request manages: the tenant named in the request, or else
+// the tenant of the request. Admin requests are authenticated with the admin token rather than an API key, so tenants
+// that are only identified by their API keys must be named in the request.
+func (s AdminServer) overrideTenant(ctx context.Context, named string) (string, error) {
+	tenant := oslc.TenantFromContext(ctx)
+	switch {
+	case named == "":
+		return tenant, nil
+	case tenant != "" && tenant != named:
+		return "", status.Errorf(codes.InvalidArgument, "tenant %q does not match the tenant of the request %q", named, tenant)
+	case s.options.Tenants != nil && !s.options.Tenants.HasTenant(named):
+		return "", status.Errorf(codes.InvalidArgument, "unknown tenant %q", named)
+	}
+	return named, nil
+}
+
+func (s AdminServer) SetLicenseOverride(ctx context.Context, request *oslcv1alpha.SetLicenseOverrideRequest) (*oslcv1alpha.SetLicenseOverrideResponse, error) {
+	o := request.GetOverride()
+	switch {
+	case o == nil:
+		return nil, status.Error(codes.InvalidArgument, "override is required")
+	case !validDistributor(o.Distributor):
+		return nil, invalidDistributorError()
+	case o.Name == "":
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	case o.License == "":
+		return nil, status.Error(codes.InvalidArgument, "license is required")
+	case o.Justification == "":
+		return nil, status.Error(codes.InvalidArgument, "justification is required")
+	case o.Author == "":
+		return nil, status.Error(codes.InvalidArgument, "author is required")
+	}
+	if _, err := versions.ParseRange(o.Distributor, o.VersionRange); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	tenant, err := s.overrideTenant(ctx, o.Tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	override, err := s.options.CurationStore.SetOverride(ctx, oslc.LicenseOverride{
+		Tenant:        tenant,
+		Distributor:   o.Distributor,
+		Name:          o.Name,
+		VersionRange:  o.VersionRange,
+		License:       o.License,
+		Justification: o.Justification,
+		Author:        o.Author,
+	})
+	if err != nil {
+		s.options.Logger.ErrorContext(ctx, "failed to set license override", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+	s.options.Logger.InfoContext(ctx, "license override set",
+		slog.String("tenant", override.Tenant),
+		slog.String("distributor", override.Distributor),
+		slog.String("name", override.Name),
+		slog.String("version_range", override.VersionRange),
+		slog.String("license", override.License),
+		slog.String("author", override.Author),
+	)
+	if s.options.PackageEventBroker != nil {
+		s.options.PackageEventBroker.PublishPackageEvent(ctx, oslc.PackageEvent{
+			Type:         oslc.PackageEventCurated,
+			Distributor:  override.Distributor,
+			Name:         override.Name,
+			License:      override.License,
+			VersionRange: override.VersionRange,
+			Tenant:       override.Tenant,
+			OccurredAt:   override.UpdatedAt,
+		})
+	}
+
+	return &oslcv1alpha.SetLicenseOverrideResponse{
+		Override: licenseOverrideToProto(override),
+	}, nil
+}
+
+func (s AdminServer) ListLicenseOverrides(ctx context.Context, request *oslcv1alpha.ListLicenseOverridesRequest) (*oslcv1alpha.ListLicenseOverridesResponse, error) {
+	if request.Distributor != "" && !validDistributor(request.Distributor) {
+		return nil, invalidDistributorError()
+	}
+
+	tenant, err := s.overrideTenant(ctx, request.Tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides, err := s.options.CurationStore.ListOverrides(ctx, tenant, request.Distributor, request.Name)
+	if err != nil {
+		s.options.Logger.ErrorContext(ctx, "failed to list license overrides", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+
+	resp := &oslcv1alpha.ListLicenseOverridesResponse{
+		Overrides: make([]*oslcv1alpha.LicenseOverride, len(overrides)),
+	}
+	for i, o := range overrides {
+		resp.Overrides[i] = licenseOverrideToProto(o)
+	}
+	return resp, nil
+}
+
+func (s AdminServer) DeleteLicenseOverride(ctx context.Context, request *oslcv1alpha.DeleteLicenseOverrideRequest) (*oslcv1alpha.DeleteLicenseOverrideResponse, error) {
+	if !validDistributor(request.Distributor) {
+		return nil, invalidDistributorError()
+	}
+	if request.Name == "" {
+		return nil, missingNameError(request.Distributor)
+	}
+
+	tenant, err := s.overrideTenant(ctx, request.Tenant)
+	if err != nil {
+		return nil, err
+	}
+	err = s.options.CurationStore.DeleteOverride(ctx, tenant, request.Distributor, request.Name, request.VersionRange)
+	if err != nil {
+		if errors.Is(err, oslc.ErrDatastoreObjectNotFound) {
+			return nil, status.Error(codes.NotFound, "override not found")
+		}
+		s.options.Logger.ErrorContext(ctx, "failed to delete license override", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+	s.options.Logger.InfoContext(ctx, "license override deleted",
+		slog.String("tenant", tenant),
+		slog.String("distributor", request.Distributor),
+		slog.String("name", request.Name),
+		slog.String("version_range", request.VersionRange),
+	)
+	return &oslcv1alpha.DeleteLicenseOverrideResponse{}, nil
+}
+
+func (s AdminServer) InvalidatePackages(ctx context.Context, request *oslcv1alpha.InvalidatePackagesRequest) (*oslcv1alpha.InvalidatePackagesResponse, error) {
+	if request.Distributor != "" && !validDistributor(request.Distributor) {
+		return nil, invalidDistributorError()
+	}
+	filter := oslc.EntryFilter{
+		Distributor: request.Distributor,
+		NamePattern: request.Name,
+		Version:     request.Version,
+		License:     request.License,
+	}
+	// Deleting the entire catalog is never what an operator wants, so it is rejected rather than treated as a match-all.
+	if filter.IsZero() {
+		return nil, status.Error(codes.InvalidArgument, "at least one of distributor, name, version or license is required")
+	}
+
+	n, err := s.options.Datastore.Invalidate(ctx, filter)
+	if err != nil {
+		s.options.Logger.ErrorContext(ctx, "failed to invalidate packages", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+	s.options.Logger.InfoContext(ctx, "packages invalidated",
+		slog.String("distributor", request.Distributor),
+		slog.String("name", request.Name),
+		slog.String("version", request.Version),
+		slog.Any("license", request.License),
+		slog.Int64("deleted_count", n),
+	)
+	return &oslcv1alpha.InvalidatePackagesResponse{
+		DeletedCount: n,
+	}, nil
+}
+
+func (s AdminServer) RefreshPackage(ctx context.Context, request *oslcv1alpha.RefreshPackageRequest) (*oslcv1alpha.RefreshPackageResponse, error) {
+	if !validDistributor(request.Distributor) {
+		return nil, invalidDistributorError()
+	}
+	if request.Name == "" {
+		return nil, missingNameError(request.Distributor)
+	}
+	if s.options.Offline {
+		return nil, status.Error(codes.FailedPrecondition, "packages cannot be refreshed in offline mode")
+	}
+
+	entry, err := s.server.getPackageFromDistributor(ctx, request.Distributor, request.Name, request.Version)
+	if err != nil {
+		return nil, s.server.upstreamErrorToStatus(ctx, err)
+	}
+	s.server.detectPackageChange(ctx, request.Distributor, entry)
+	// Unlike GetPackageInfo, failing to save is an error, since updating the catalog is the purpose of the call.
+	if err := s.options.Datastore.Save(ctx, entry); err != nil {
+		s.options.Logger.ErrorContext(ctx, "failed to save to datastore", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+	s.options.Logger.InfoContext(ctx, "package refreshed",
+		slog.String("distributor", request.Distributor),
+		slog.String("name", entry.Name),
+		slog.String("version", entry.Version),
+		slog.String("license", entry.License),
+	)
+
+	entry, curated := s.server.applyOverrides(ctx, request.Distributor, entry)
+	return &oslcv1alpha.RefreshPackageResponse{
+		Package: entryToResponse(entry, curated),
+	}, nil
+}
+
+// webhookSecretBytes is the number of random bytes in a generated webhook secret.
+const webhookSecretBytes = 32
+
+func webhookSubscriptionToProto(s oslc.WebhookSubscription) *oslcv1alpha.WebhookSubscription {
+	return &oslcv1alpha.WebhookSubscription{
+		Id:                s.ID,
+		Url:               s.URL,
+		Distributor:       s.Distributor,
+		PackagePattern:    s.PackagePattern,
+		LicenseCategories: s.LicenseCategories,
+		CreateTime:        timestamppb.New(s.CreatedAt),
+	}
+}
+
+// webhookStore returns the WebhookStore, or an error if the server was not configured with one.
+func (s AdminServer) webhookStore() (oslc.WebhookStore, error) {
+	if s.options.WebhookStore == nil {
+		return nil, status.Error(codes.Unimplemented, "webhook notifications are not enabled")
+	}
+	return s.options.WebhookStore, nil
+}
+
+func (s AdminServer) CreateWebhookSubscription(ctx context.Context, request *oslcv1alpha.CreateWebhookSubscriptionRequest) (*oslcv1alpha.CreateWebhookSubscriptionResponse, error) {
+	store, err := s.webhookStore()
+	if err != nil {
+		return nil, err
+	}
+	sub := request.GetSubscription()
+	if sub == nil {
+		return nil, status.Error(codes.InvalidArgument, "subscription is required")
+	}
+	if u, err := url.Parse(sub.Url); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, status.Error(codes.InvalidArgument, "url must be an absolute http or https URL")
+	}
+	if sub.Distributor != "" && !validDistributor(sub.Distributor) {
+		return nil, invalidDistributorError()
+	}
+	for _, c := range sub.LicenseCategories {
+		if !licensecategory.Valid(c) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid license category: %s", c)
+		}
+	}
+
+	secret := request.Secret
+	if secret == "" {
+		b := make([]byte, webhookSecretBytes)
+		if _, err := rand.Read(b); err != nil {
+			s.options.Logger.ErrorContext(ctx, "failed to generate webhook secret", slog.String("error", err.Error()))
+			return nil, status.Error(codes.Internal, "internal server error")
+		}
+		secret = hex.EncodeToString(b)
+	}
+
+	subscription, err := store.CreateSubscription(ctx, oslc.WebhookSubscription{
+		URL:               sub.Url,
+		Secret:            secret,
+		Distributor:       sub.Distributor,
+		PackagePattern:    sub.PackagePattern,
+		LicenseCategories: sub.LicenseCategories,
+	})
+	if err != nil {
+		s.options.Logger.ErrorContext(ctx, "failed to create webhook subscription", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+	s.options.Logger.InfoContext(ctx, "webhook subscription created",
+		slog.Int64("id", subscription.ID),
+		slog.String("url", subscription.URL),
+	)
+	return &oslcv1alpha.CreateWebhookSubscriptionResponse{
+		Subscription: webhookSubscriptionToProto(subscription),
+		Secret:       subscription.Secret,
+	}, nil
+}
+
+func (s AdminServer) ListWebhookSubscriptions(ctx context.Context, _ *oslcv1alpha.ListWebhookSubscriptionsRequest) (*oslcv1alpha.ListWebhookSubscriptionsResponse, error) {
+	store, err := s.webhookStore()
+	if err != nil {
+		return nil, err
+	}
+	subscriptions, err := store.ListSubscriptions(ctx)
+	if err != nil {
+		s.options.Logger.ErrorContext(ctx, "failed to list webhook subscriptions", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+	resp := &oslcv1alpha.ListWebhookSubscriptionsResponse{
+		Subscriptions: make([]*oslcv1alpha.WebhookSubscription, len(subscriptions)),
+	}
+	for i, subscription := range subscriptions {
+		resp.Subscriptions[i] = webhookSubscriptionToProto(subscription)
+	}
+	return resp, nil
+}
+
+func (s AdminServer) DeleteWebhookSubscription(ctx context.Context, request *oslcv1alpha.DeleteWebhookSubscriptionRequest) (*oslcv1alpha.DeleteWebhookSubscriptionResponse, error) {
+	store, err := s.webhookStore()
+	if err != nil {
+		return nil, err
+	}
+	if err := store.DeleteSubscription(ctx, request.Id); err != nil {
+		if errors.Is(err, oslc.ErrDatastoreObjectNotFound) {
+			return nil, status.Error(codes.NotFound, "subscription not found")
+		}
+		s.options.Logger.ErrorContext(ctx, "failed to delete webhook subscription", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+	s.options.Logger.InfoContext(ctx, "webhook subscription deleted", slog.Int64("id", request.Id))
+	return &oslcv1alpha.DeleteWebhookSubscriptionResponse{}, nil
+}
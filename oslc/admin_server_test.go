@@ -0,0 +1,578 @@
+package oslc
+
+import (
+	oslcv1alpha "buf.build/gen/go/chainalysis-oss/oslc/protocolbuffers/go/chainalysis_oss/oslc/v1alpha"
+	"context"
+	"github.com/chainalysis-oss/oslc"
+	"github.com/chainalysis-oss/oslc/memory"
+	oslcMocks "github.com/chainalysis-oss/oslc/mocks/oslc"
+	"github.com/chainalysis-oss/oslc/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+)
+
+var requestsOverride = oslc.LicenseOverride{
+	Distributor:   oslc.DistributorPypi,
+	Name:          "requests",
+	VersionRange:  ">=2.0.0",
+	License:       "Apache-2.0",
+	Justification: "license field contains the full license text",
+	Author:        "legal",
+}
+
+func newTestAdminServer(t *testing.T, options ...ServerOption) (*AdminServer, *oslcMocks.MockDatastore, *oslcMocks.MockCurationStore) {
+	t.Helper()
+	datastore := oslcMocks.NewMockDatastore(t)
+	store := oslcMocks.NewMockCurationStore(t)
+	options = append([]ServerOption{
+		WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		WithDatastore(datastore),
+		WithCurationStore(store),
+	}, options...)
+	s, err := NewAdminServer(options...)
+	require.NoError(t, err)
+	return s, datastore, store
+}
+
+func TestNewAdminServer_ErrMissingOptionDatastore(t *testing.T) {
+	_, err := NewAdminServer(WithCurationStore(oslcMocks.NewMockCurationStore(t)))
+	require.ErrorIs(t, err, ErrMissingOptionDatastore)
+}
+
+func TestNewAdminServer_ErrMissingOptionCurationStore(t *testing.T) {
+	_, err := NewAdminServer(WithDatastore(oslcMocks.NewMockDatastore(t)))
+	require.ErrorIs(t, err, ErrMissingOptionCurationStore)
+}
+
+func TestAdminServer_SetLicenseOverride(t *testing.T) {
+	s, _, store := newTestAdminServer(t)
+	updatedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	stored := requestsOverride
+	stored.UpdatedAt = updatedAt
+	store.EXPECT().SetOverride(context.Background(), requestsOverride).Return(stored, nil)
+
+	resp, err := s.SetLicenseOverride(context.Background(), &oslcv1alpha.SetLicenseOverrideRequest{
+		Override: &oslcv1alpha.LicenseOverride{
+			Distributor:   requestsOverride.Distributor,
+			Name:          requestsOverride.Name,
+			VersionRange:  requestsOverride.VersionRange,
+			License:       requestsOverride.License,
+			Justification: requestsOverride.Justification,
+			Author:        requestsOverride.Author,
+			// The update time is set by the store and must be ignored.
+			UpdateTime: timestamppb.New(time.Unix(0, 0)),
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, requestsOverride.License, resp.Override.License)
+	require.Equal(t, updatedAt, resp.Override.UpdateTime.AsTime())
+}
+
+// knownTenants holds the names of tenants.
+type knownTenants []string
+
+func (k knownTenants) HasTenant(tenant string) bool {
+	return slices.Contains(k, tenant)
+}
+
+func TestAdminServer_SetLicenseOverride_namedTenant(t *testing.T) {
+	tenantOverride := requestsOverride
+	tenantOverride.Tenant = "payments"
+	o := licenseOverrideToProto(tenantOverride)
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		tenant string
+		want   codes.Code
+	}{
+		{"named", context.Background(), "payments", codes.OK},
+		{"of the request", oslc.ContextWithTenant(context.Background(), "payments"), "", codes.OK},
+		{"named and of the request", oslc.ContextWithTenant(context.Background(), "payments"), "payments", codes.OK},
+		{"not of the request", oslc.ContextWithTenant(context.Background(), "research"), "payments", codes.InvalidArgument},
+		{"unknown", context.Background(), "unknown", codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _, store := newTestAdminServer(t, WithTenants(knownTenants{"payments", "research"}))
+			if tt.want == codes.OK {
+				store.EXPECT().SetOverride(tt.ctx, tenantOverride).Return(tenantOverride, nil)
+			}
+			o.Tenant = tt.tenant
+			resp, err := s.SetLicenseOverride(tt.ctx, &oslcv1alpha.SetLicenseOverrideRequest{Override: o})
+			require.Equal(t, tt.want, status.Code(err))
+			if err == nil {
+				require.Equal(t, "payments", resp.Override.Tenant)
+			}
+		})
+	}
+}
+
+// TestAdminServer_overrides_apiKeyTenants tests that the overrides of tenants identified only by their API keys can be
+// managed. Admin requests carry the admin token instead of an API key, so they name the tenant in the request.
+func TestAdminServer_overrides_apiKeyTenants(t *testing.T) {
+	registry, err := tenant.Parse([]byte("tenants: [{name: payments, api_keys: [payments-key]}]"), t.TempDir())
+	require.NoError(t, err)
+	store, err := memory.NewDatastore()
+	require.NoError(t, err)
+	s, _, _ := newTestAdminServer(t, WithCurationStore(store), WithTenants(registry))
+
+	adminTenant, err := registry.ResolveTenant(metadata.Pairs("authorization", "Bearer admin-token"))
+	require.NoError(t, err)
+	adminCtx := oslc.ContextWithTenant(context.Background(), adminTenant)
+	o := licenseOverrideToProto(requestsOverride)
+	o.Tenant = "payments"
+	_, err = s.SetLicenseOverride(adminCtx, &oslcv1alpha.SetLicenseOverrideRequest{Override: o})
+	require.NoError(t, err)
+
+	list, err := s.ListLicenseOverrides(adminCtx, &oslcv1alpha.ListLicenseOverridesRequest{Tenant: "payments"})
+	require.NoError(t, err)
+	require.Len(t, list.Overrides, 1)
+	shared, err := s.ListLicenseOverrides(adminCtx, &oslcv1alpha.ListLicenseOverridesRequest{})
+	require.NoError(t, err)
+	require.Empty(t, shared.Overrides)
+
+	// The override applies to the requests made with the API key of the tenant.
+	paymentsTenant, err := registry.ResolveTenant(metadata.Pairs("authorization", "Bearer payments-key"))
+	require.NoError(t, err)
+	normalizer := oslcMocks.NewMockLicenseIDNormalizer(t)
+	normalizer.EXPECT().NormalizeID(mock.Anything, requestsOverride.License).Return(requestsOverride.License)
+	server := Server{options: &serverOptions{Logger: s.options.Logger, CurationStore: store, LicenseIDNormalizer: normalizer}}
+	resp := server.applyTenantLayers(oslc.ContextWithTenant(context.Background(), paymentsTenant), oslc.DistributorPypi, &oslcv1alpha.GetPackageInfoResponse{
+		Name:    "requests",
+		Version: "2.32.3",
+		License: "NOASSERTION",
+	})
+	require.True(t, resp.Curated)
+	require.Equal(t, requestsOverride.License, resp.License)
+
+	_, err = s.DeleteLicenseOverride(adminCtx, &oslcv1alpha.DeleteLicenseOverrideRequest{
+		Distributor:  requestsOverride.Distributor,
+		Name:         requestsOverride.Name,
+		VersionRange: requestsOverride.VersionRange,
+		Tenant:       "payments",
+	})
+	require.NoError(t, err)
+	list, err = s.ListLicenseOverrides(adminCtx, &oslcv1alpha.ListLicenseOverridesRequest{Tenant: "payments"})
+	require.NoError(t, err)
+	require.Empty(t, list.Overrides)
+}
+
+func TestAdminServer_SetLicenseOverride_publishesEvent(t *testing.T) {
+	broker := oslcMocks.NewMockPackageEventBroker(t)
+	s, _, store := newTestAdminServer(t, WithPackageEventBroker(broker))
+	updatedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	stored := requestsOverride
+	stored.UpdatedAt = updatedAt
+	store.EXPECT().SetOverride(context.Background(), requestsOverride).Return(stored, nil)
+	broker.EXPECT().PublishPackageEvent(context.Background(), oslc.PackageEvent{
+		Type:         oslc.PackageEventCurated,
+		Distributor:  requestsOverride.Distributor,
+		Name:         requestsOverride.Name,
+		License:      requestsOverride.License,
+		VersionRange: requestsOverride.VersionRange,
+		OccurredAt:   updatedAt,
+	})
+
+	_, err := s.SetLicenseOverride(context.Background(), &oslcv1alpha.SetLicenseOverrideRequest{
+		Override: &oslcv1alpha.LicenseOverride{
+			Distributor:   requestsOverride.Distributor,
+			Name:          requestsOverride.Name,
+			VersionRange:  requestsOverride.VersionRange,
+			License:       requestsOverride.License,
+			Justification: requestsOverride.Justification,
+			Author:        requestsOverride.Author,
+		},
+	})
+	require.NoError(t, err)
+}
+
+func TestAdminServer_SetLicenseOverride_invalid(t *testing.T) {
+	valid := func() *oslcv1alpha.LicenseOverride {
+		return &oslcv1alpha.LicenseOverride{
+			Distributor:   oslc.DistributorNpm,
+			Name:          "left-pad",
+			License:       "MIT",
+			Justification: "j",
+			Author:        "a",
+		}
+	}
+	tests := []struct {
+		name   string
+		modify func(o *oslcv1alpha.LicenseOverride) *oslcv1alpha.LicenseOverride
+	}{
+		{"missing override", func(o *oslcv1alpha.LicenseOverride) *oslcv1alpha.LicenseOverride { return nil }},
+		{"invalid distributor", func(o *oslcv1alpha.LicenseOverride) *oslcv1alpha.LicenseOverride { o.Distributor = "invalid"; return o }},
+		{"missing name", func(o *oslcv1alpha.LicenseOverride) *oslcv1alpha.LicenseOverride { o.Name = ""; return o }},
+		{"missing license", func(o *oslcv1alpha.LicenseOverride) *oslcv1alpha.LicenseOverride { o.License = ""; return o }},
+		{"missing justification", func(o *oslcv1alpha.LicenseOverride) *oslcv1alpha.LicenseOverride { o.Justification = ""; return o }},
+		{"missing author", func(o *oslcv1alpha.LicenseOverride) *oslcv1alpha.LicenseOverride { o.Author = ""; return o }},
+		{"invalid version range", func(o *oslcv1alpha.LicenseOverride) *oslcv1alpha.LicenseOverride { o.VersionRange = ">="; return o }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _, _ := newTestAdminServer(t)
+			_, err := s.SetLicenseOverride(context.Background(), &oslcv1alpha.SetLicenseOverrideRequest{Override: tt.modify(valid())})
+			require.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+}
+
+func TestAdminServer_SetLicenseOverride_ErrStore(t *testing.T) {
+	s, _, store := newTestAdminServer(t)
+	store.EXPECT().SetOverride(context.Background(), requestsOverride).Return(oslc.LicenseOverride{}, assert.AnError)
+	_, err := s.SetLicenseOverride(context.Background(), &oslcv1alpha.SetLicenseOverrideRequest{
+		Override: licenseOverrideToProto(requestsOverride),
+	})
+	require.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestAdminServer_ListLicenseOverrides(t *testing.T) {
+	s, _, store := newTestAdminServer(t)
+	store.EXPECT().ListOverrides(context.Background(), "", oslc.DistributorPypi, "").Return([]oslc.LicenseOverride{requestsOverride}, nil)
+	resp, err := s.ListLicenseOverrides(context.Background(), &oslcv1alpha.ListLicenseOverridesRequest{Distributor: oslc.DistributorPypi})
+	require.NoError(t, err)
+	require.Len(t, resp.Overrides, 1)
+	require.Equal(t, requestsOverride.Name, resp.Overrides[0].Name)
+	require.Equal(t, requestsOverride.Justification, resp.Overrides[0].Justification)
+}
+
+func TestAdminServer_ListLicenseOverrides_invalidDistributor(t *testing.T) {
+	s, _, _ := newTestAdminServer(t)
+	_, err := s.ListLicenseOverrides(context.Background(), &oslcv1alpha.ListLicenseOverridesRequest{Distributor: "invalid"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestAdminServer_ListLicenseOverrides_namedTenant(t *testing.T) {
+	s, _, store := newTestAdminServer(t, WithTenants(knownTenants{"payments"}))
+	store.EXPECT().ListOverrides(context.Background(), "payments", "", "").Return([]oslc.LicenseOverride{}, nil)
+	_, err := s.ListLicenseOverrides(context.Background(), &oslcv1alpha.ListLicenseOverridesRequest{Tenant: "payments"})
+	require.NoError(t, err)
+	_, err = s.ListLicenseOverrides(context.Background(), &oslcv1alpha.ListLicenseOverridesRequest{Tenant: "unknown"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestAdminServer_ListLicenseOverrides_ErrStore(t *testing.T) {
+	s, _, store := newTestAdminServer(t)
+	store.EXPECT().ListOverrides(context.Background(), "", "", "").Return(nil, assert.AnError)
+	_, err := s.ListLicenseOverrides(context.Background(), &oslcv1alpha.ListLicenseOverridesRequest{})
+	require.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestAdminServer_DeleteLicenseOverride(t *testing.T) {
+	s, _, store := newTestAdminServer(t)
+	store.EXPECT().DeleteOverride(context.Background(), "", oslc.DistributorPypi, "requests", ">=2.0.0").Return(nil)
+	_, err := s.DeleteLicenseOverride(context.Background(), &oslcv1alpha.DeleteLicenseOverrideRequest{
+		Distributor:  oslc.DistributorPypi,
+		Name:         "requests",
+		VersionRange: ">=2.0.0",
+	})
+	require.NoError(t, err)
+}
+
+func TestAdminServer_DeleteLicenseOverride_errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  *oslcv1alpha.DeleteLicenseOverrideRequest
+		storeErr error
+		want     codes.Code
+	}{
+		{"invalid distributor", &oslcv1alpha.DeleteLicenseOverrideRequest{Distributor: "invalid", Name: "requests"}, nil, codes.InvalidArgument},
+		{"missing name", &oslcv1alpha.DeleteLicenseOverrideRequest{Distributor: oslc.DistributorPypi}, nil, codes.InvalidArgument},
+		{"unknown tenant", &oslcv1alpha.DeleteLicenseOverrideRequest{Distributor: oslc.DistributorPypi, Name: "requests", Tenant: "unknown"}, nil, codes.InvalidArgument},
+		{"not found", &oslcv1alpha.DeleteLicenseOverrideRequest{Distributor: oslc.DistributorPypi, Name: "requests"}, oslc.ErrDatastoreObjectNotFound, codes.NotFound},
+		{"store error", &oslcv1alpha.DeleteLicenseOverrideRequest{Distributor: oslc.DistributorPypi, Name: "requests"}, assert.AnError, codes.Internal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _, store := newTestAdminServer(t, WithTenants(knownTenants{"payments"}))
+			if tt.storeErr != nil {
+				store.EXPECT().DeleteOverride(context.Background(), "", tt.request.Distributor, tt.request.Name, tt.request.VersionRange).Return(tt.storeErr)
+			}
+			_, err := s.DeleteLicenseOverride(context.Background(), tt.request)
+			require.Equal(t, tt.want, status.Code(err))
+		})
+	}
+}
+
+func TestAdminServer_InvalidatePackages(t *testing.T) {
+	empty := ""
+	tests := []struct {
+		name    string
+		request *oslcv1alpha.InvalidatePackagesRequest
+		filter  oslc.EntryFilter
+	}{
+		{
+			name:    "exact coordinate",
+			request: &oslcv1alpha.InvalidatePackagesRequest{Distributor: oslc.DistributorPypi, Name: "requests", Version: "2.32.3"},
+			filter:  oslc.EntryFilter{Distributor: oslc.DistributorPypi, NamePattern: "requests", Version: "2.32.3"},
+		},
+		{
+			name:    "name pattern",
+			request: &oslcv1alpha.InvalidatePackagesRequest{Name: "@types/*"},
+			filter:  oslc.EntryFilter{NamePattern: "@types/*"},
+		},
+		{
+			name:    "distributor",
+			request: &oslcv1alpha.InvalidatePackagesRequest{Distributor: oslc.DistributorMaven},
+			filter:  oslc.EntryFilter{Distributor: oslc.DistributorMaven},
+		},
+		{
+			name:    "empty license",
+			request: &oslcv1alpha.InvalidatePackagesRequest{License: &empty},
+			filter:  oslc.EntryFilter{License: &empty},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, datastore, _ := newTestAdminServer(t)
+			datastore.EXPECT().Invalidate(context.Background(), tt.filter).Return(7, nil)
+			resp, err := s.InvalidatePackages(context.Background(), tt.request)
+			require.NoError(t, err)
+			require.EqualValues(t, 7, resp.DeletedCount)
+		})
+	}
+}
+
+func TestAdminServer_InvalidatePackages_errors(t *testing.T) {
+	t.Run("empty filter", func(t *testing.T) {
+		s, _, _ := newTestAdminServer(t)
+		_, err := s.InvalidatePackages(context.Background(), &oslcv1alpha.InvalidatePackagesRequest{})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("invalid distributor", func(t *testing.T) {
+		s, _, _ := newTestAdminServer(t)
+		_, err := s.InvalidatePackages(context.Background(), &oslcv1alpha.InvalidatePackagesRequest{Distributor: "invalid"})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("datastore error", func(t *testing.T) {
+		s, datastore, _ := newTestAdminServer(t)
+		datastore.EXPECT().Invalidate(context.Background(), oslc.EntryFilter{Distributor: oslc.DistributorGo}).Return(0, assert.AnError)
+		_, err := s.InvalidatePackages(context.Background(), &oslcv1alpha.InvalidatePackagesRequest{Distributor: oslc.DistributorGo})
+		require.Equal(t, codes.Internal, status.Code(err))
+	})
+}
+
+func TestAdminServer_RefreshPackage(t *testing.T) {
+	client := oslcMocks.NewMockDistributorClient(t)
+	normalizer := oslcMocks.NewMockLicenseNormalizer(t)
+	s, datastore, store := newTestAdminServer(t, WithPypiClient(client), WithLicenseIDNormalizer(normalizer))
+
+	upstream := pypiRequestsEntry
+	upstream.License = "Apache 2.0"
+	client.EXPECT().GetPackageVersion(context.Background(), "requests", "2.32.3").Return(upstream, nil)
+	normalizer.EXPECT().Normalize(context.Background(), "Apache 2.0").Return(oslc.LicenseNormalization{
+		License:            "Apache-2.0",
+		Status:             oslc.LicenseNormalizationAlias,
+		LicenseListVersion: "3.25.0",
+	})
+	saved := pypiRequestsEntry
+	saved.RawLicense = "Apache 2.0"
+	saved.NormalizationStatus = oslc.LicenseNormalizationAlias
+	saved.LicenseListVersion = "3.25.0"
+	datastore.EXPECT().Save(context.Background(), saved).Return(nil)
+	store.EXPECT().ListOverrides(context.Background(), "", oslc.DistributorPypi, "requests").Return(nil, nil)
+
+	resp, err := s.RefreshPackage(context.Background(), &oslcv1alpha.RefreshPackageRequest{
+		Name:        "requests",
+		Version:     "2.32.3",
+		Distributor: oslc.DistributorPypi,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Apache-2.0", resp.Package.License)
+	require.Equal(t, "Apache 2.0", resp.Package.RawLicense)
+	require.Equal(t, oslcv1alpha.LicenseNormalizationStatus_LICENSE_NORMALIZATION_STATUS_ALIAS, resp.Package.NormalizationStatus)
+	require.Equal(t, "3.25.0", resp.Package.LicenseListVersion)
+	require.False(t, resp.Package.Curated)
+	require.Len(t, resp.Package.DistributionPoints, 1)
+}
+
+func TestAdminServer_RefreshPackage_errors(t *testing.T) {
+	t.Run("invalid distributor", func(t *testing.T) {
+		s, _, _ := newTestAdminServer(t)
+		_, err := s.RefreshPackage(context.Background(), &oslcv1alpha.RefreshPackageRequest{Name: "requests", Distributor: "invalid"})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("missing name", func(t *testing.T) {
+		s, _, _ := newTestAdminServer(t)
+		_, err := s.RefreshPackage(context.Background(), &oslcv1alpha.RefreshPackageRequest{Distributor: oslc.DistributorPypi})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("not found upstream", func(t *testing.T) {
+		client := oslcMocks.NewMockDistributorClient(t)
+		s, _, _ := newTestAdminServer(t, WithPypiClient(client))
+		client.EXPECT().GetPackageVersion(context.Background(), "requests", "0.0.0").Return(oslc.Entry{}, oslc.DistributorError{Distributor: "pypi", Err: oslc.ErrVersionNotFound})
+		_, err := s.RefreshPackage(context.Background(), &oslcv1alpha.RefreshPackageRequest{Name: "requests", Version: "0.0.0", Distributor: oslc.DistributorPypi})
+		require.Equal(t, codes.NotFound, status.Code(err))
+	})
+	t.Run("save error", func(t *testing.T) {
+		client := oslcMocks.NewMockDistributorClient(t)
+		normalizer := oslcMocks.NewMockLicenseIDNormalizer(t)
+		s, datastore, _ := newTestAdminServer(t, WithPypiClient(client), WithLicenseIDNormalizer(normalizer))
+		client.EXPECT().GetPackageVersion(context.Background(), "requests", "2.32.3").Return(pypiRequestsEntry, nil)
+		normalizer.EXPECT().NormalizeID(context.Background(), "Apache-2.0").Return("Apache-2.0")
+		datastore.EXPECT().Save(context.Background(), pypiRequestsEntry).Return(assert.AnError)
+		_, err := s.RefreshPackage(context.Background(), &oslcv1alpha.RefreshPackageRequest{Name: "requests", Version: "2.32.3", Distributor: oslc.DistributorPypi})
+		require.Equal(t, codes.Internal, status.Code(err))
+	})
+}
+
+func TestAdminServer_RefreshPackage_licenseChange(t *testing.T) {
+	client := oslcMocks.NewMockDistributorClient(t)
+	normalizer := oslcMocks.NewMockLicenseIDNormalizer(t)
+	notifier := oslcMocks.NewMockLicenseChangeNotifier(t)
+	s, datastore, store := newTestAdminServer(t, WithPypiClient(client), WithLicenseIDNormalizer(normalizer), WithLicenseChangeNotifier(notifier))
+
+	stored := pypiRequestsEntry
+	stored.License = "MIT"
+	client.EXPECT().GetPackageVersion(context.Background(), "requests", "2.32.3").Return(pypiRequestsEntry, nil)
+	normalizer.EXPECT().NormalizeID(context.Background(), "Apache-2.0").Return("Apache-2.0")
+	datastore.EXPECT().RetrieveVersions(context.Background(), "requests", oslc.DistributorPypi).Return([]oslc.Entry{stored}, nil)
+	notifier.EXPECT().NotifyLicenseChange(context.Background(), mock.MatchedBy(func(e oslc.LicenseChangeEvent) bool {
+		return e.Version == "2.32.3" && e.License == "Apache-2.0" && e.PreviousVersion == "2.32.3" && e.PreviousLicense == "MIT"
+	})).Return(nil)
+	datastore.EXPECT().Save(context.Background(), pypiRequestsEntry).Return(nil)
+	store.EXPECT().ListOverrides(context.Background(), "", oslc.DistributorPypi, "requests").Return(nil, nil)
+
+	_, err := s.RefreshPackage(context.Background(), &oslcv1alpha.RefreshPackageRequest{Name: "requests", Version: "2.32.3", Distributor: oslc.DistributorPypi})
+	require.NoError(t, err)
+}
+
+func TestAdminServer_webhooks_not_enabled(t *testing.T) {
+	s, _, _ := newTestAdminServer(t)
+	_, err := s.CreateWebhookSubscription(context.Background(), &oslcv1alpha.CreateWebhookSubscriptionRequest{})
+	require.Equal(t, codes.Unimplemented, status.Code(err))
+	_, err = s.ListWebhookSubscriptions(context.Background(), &oslcv1alpha.ListWebhookSubscriptionsRequest{})
+	require.Equal(t, codes.Unimplemented, status.Code(err))
+	_, err = s.DeleteWebhookSubscription(context.Background(), &oslcv1alpha.DeleteWebhookSubscriptionRequest{Id: 1})
+	require.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestAdminServer_CreateWebhookSubscription(t *testing.T) {
+	webhooks := oslcMocks.NewMockWebhookStore(t)
+	s, _, _ := newTestAdminServer(t, WithWebhookStore(webhooks))
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	webhooks.EXPECT().CreateSubscription(context.Background(), oslc.WebhookSubscription{
+		URL:               "https://example.com/hook",
+		Secret:            "secret",
+		Distributor:       oslc.DistributorNpm,
+		PackagePattern:    "@types/*",
+		LicenseCategories: []string{"copyleft"},
+	}).RunAndReturn(func(_ context.Context, sub oslc.WebhookSubscription) (oslc.WebhookSubscription, error) {
+		sub.ID = 1
+		sub.CreatedAt = createdAt
+		return sub, nil
+	})
+
+	resp, err := s.CreateWebhookSubscription(context.Background(), &oslcv1alpha.CreateWebhookSubscriptionRequest{
+		Subscription: &oslcv1alpha.WebhookSubscription{
+			Url:               "https://example.com/hook",
+			Distributor:       oslc.DistributorNpm,
+			PackagePattern:    "@types/*",
+			LicenseCategories: []string{"copyleft"},
+		},
+		Secret: "secret",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "secret", resp.Secret)
+	require.Equal(t, int64(1), resp.Subscription.Id)
+	require.Equal(t, "https://example.com/hook", resp.Subscription.Url)
+	require.Equal(t, timestamppb.New(createdAt), resp.Subscription.CreateTime)
+}
+
+func TestAdminServer_CreateWebhookSubscription_generates_secret(t *testing.T) {
+	webhooks := oslcMocks.NewMockWebhookStore(t)
+	s, _, _ := newTestAdminServer(t, WithWebhookStore(webhooks))
+	webhooks.EXPECT().CreateSubscription(context.Background(), mock.Anything).
+		RunAndReturn(func(_ context.Context, sub oslc.WebhookSubscription) (oslc.WebhookSubscription, error) {
+			return sub, nil
+		})
+
+	resp, err := s.CreateWebhookSubscription(context.Background(), &oslcv1alpha.CreateWebhookSubscriptionRequest{
+		Subscription: &oslcv1alpha.WebhookSubscription{Url: "http://localhost:8080/hook"},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Secret, 2*webhookSecretBytes)
+}
+
+func TestAdminServer_CreateWebhookSubscription_invalid(t *testing.T) {
+	testcases := []struct {
+		name    string
+		request *oslcv1alpha.CreateWebhookSubscriptionRequest
+	}{
+		{"missing subscription", &oslcv1alpha.CreateWebhookSubscriptionRequest{}},
+		{"missing url", &oslcv1alpha.CreateWebhookSubscriptionRequest{Subscription: &oslcv1alpha.WebhookSubscription{}}},
+		{"relative url", &oslcv1alpha.CreateWebhookSubscriptionRequest{Subscription: &oslcv1alpha.WebhookSubscription{Url: "/hook"}}},
+		{"unsupported scheme", &oslcv1alpha.CreateWebhookSubscriptionRequest{Subscription: &oslcv1alpha.WebhookSubscription{Url: "ftp://example.com/hook"}}},
+		{"invalid distributor", &oslcv1alpha.CreateWebhookSubscriptionRequest{Subscription: &oslcv1alpha.WebhookSubscription{Url: "https://example.com", Distributor: "invalid"}}},
+		{"invalid category", &oslcv1alpha.CreateWebhookSubscriptionRequest{Subscription: &oslcv1alpha.WebhookSubscription{Url: "https://example.com", LicenseCategories: []string{"viral"}}}},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _, _ := newTestAdminServer(t, WithWebhookStore(oslcMocks.NewMockWebhookStore(t)))
+			_, err := s.CreateWebhookSubscription(context.Background(), tt.request)
+			require.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+}
+
+func TestAdminServer_CreateWebhookSubscription_ErrStore(t *testing.T) {
+	webhooks := oslcMocks.NewMockWebhookStore(t)
+	s, _, _ := newTestAdminServer(t, WithWebhookStore(webhooks))
+	webhooks.EXPECT().CreateSubscription(context.Background(), mock.Anything).Return(oslc.WebhookSubscription{}, assert.AnError)
+	_, err := s.CreateWebhookSubscription(context.Background(), &oslcv1alpha.CreateWebhookSubscriptionRequest{
+		Subscription: &oslcv1alpha.WebhookSubscription{Url: "https://example.com"},
+	})
+	require.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestAdminServer_ListWebhookSubscriptions(t *testing.T) {
+	webhooks := oslcMocks.NewMockWebhookStore(t)
+	s, _, _ := newTestAdminServer(t, WithWebhookStore(webhooks))
+	webhooks.EXPECT().ListSubscriptions(context.Background()).Return([]oslc.WebhookSubscription{
+		{ID: 1, URL: "https://a", Secret: "s1"},
+		{ID: 2, URL: "https://b", Secret: "s2", LicenseCategories: []string{"permissive"}},
+	}, nil)
+	resp, err := s.ListWebhookSubscriptions(context.Background(), &oslcv1alpha.ListWebhookSubscriptionsRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Subscriptions, 2)
+	require.Equal(t, int64(2), resp.Subscriptions[1].Id)
+	require.Equal(t, []string{"permissive"}, resp.Subscriptions[1].LicenseCategories)
+
+	webhooks = oslcMocks.NewMockWebhookStore(t)
+	s, _, _ = newTestAdminServer(t, WithWebhookStore(webhooks))
+	webhooks.EXPECT().ListSubscriptions(context.Background()).Return(nil, assert.AnError)
+	_, err = s.ListWebhookSubscriptions(context.Background(), &oslcv1alpha.ListWebhookSubscriptionsRequest{})
+	require.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestAdminServer_DeleteWebhookSubscription(t *testing.T) {
+	testcases := []struct {
+		name string
+		err  error
+		code codes.Code
+	}{
+		{"deleted", nil, codes.OK},
+		{"not found", oslc.ErrDatastoreObjectNotFound, codes.NotFound},
+		{"store error", assert.AnError, codes.Internal},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.name, func(t *testing.T) {
+			webhooks := oslcMocks.NewMockWebhookStore(t)
+			s, _, _ := newTestAdminServer(t, WithWebhookStore(webhooks))
+			webhooks.EXPECT().DeleteSubscription(context.Background(), int64(1)).Return(tt.err)
+			_, err := s.DeleteWebhookSubscription(context.Background(), &oslcv1alpha.DeleteWebhookSubscriptionRequest{Id: 1})
+			require.Equal(t, tt.code, status.Code(err))
+		})
+	}
+}
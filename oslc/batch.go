@@ -0,0 +1,73 @@
+package oslc
+
+import (
+	oslcv1alpha "buf.build/gen/go/chainalysis-oss/oslc/protocolbuffers/go/chainalysis_oss/oslc/v1alpha"
+	"context"
+	"fmt"
+	"github.com/chainalysis-oss/oslc"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"sync"
+)
+
+const (
+	// batchMaxSize is the largest number of requests in a single BatchGetPackageInfo request.
+	batchMaxSize = 100
+	// batchConcurrency is the number of requests of a batch that are handled at the same time.
+	batchConcurrency = 8
+)
+
+func (s Server) BatchGetPackageInfo(ctx context.Context, request *oslcv1alpha.BatchGetPackageInfoRequest) (*oslcv1alpha.BatchGetPackageInfoResponse, error) {
+	if len(request.Requests) > batchMaxSize {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("at most %d requests may be made in a batch", batchMaxSize))
+	}
+	// The batch itself was counted as one request when it was received, so only the other requests are counted here.
+	if tenant := oslc.TenantFromContext(ctx); tenant != "" && s.options.TenantQuotas != nil && len(request.Requests) > 1 {
+		// A batch larger than the burst of the quota would never be allowed, so retrying it would not help.
+		if burst, ok := s.options.TenantQuotas.RequestBurst(tenant); ok && len(request.Requests) > burst {
+			return nil, statusError(codes.FailedPrecondition,
+				fmt.Sprintf("the quota of the tenant allows at most %d requests at once, split the batch into smaller batches", burst),
+				errorInfo(oslc.ReasonBatchExceedsQuota, ""))
+		}
+		if !s.options.TenantQuotas.AllowRequests(tenant, len(request.Requests)-1) {
+			return nil, status.Error(codes.ResourceExhausted, "tenant quota exceeded")
+		}
+	}
+
+	results := make([]*oslcv1alpha.BatchGetPackageInfoResult, len(request.Requests))
+	semaphore := make(chan struct{}, batchConcurrency)
+	var wg sync.WaitGroup
+	for i, r := range request.Requests {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			resp, err := s.GetPackageInfo(ctx, r)
+			if err != nil {
+				results[i] = &oslcv1alpha.BatchGetPackageInfoResult{Result: &oslcv1alpha.BatchGetPackageInfoResult_Error{Error: packageInfoError(err)}}
+				return
+			}
+			results[i] = &oslcv1alpha.BatchGetPackageInfoResult{Result: &oslcv1alpha.BatchGetPackageInfoResult_Package{Package: resp}}
+		}()
+	}
+	wg.Wait()
+	return &oslcv1alpha.BatchGetPackageInfoResponse{Results: results}, nil
+}
+
+// packageInfoError converts an error returned by GetPackageInfo to the error of a batch result, keeping the reason and
+// retry delay of its details.
+func packageInfoError(err error) *oslcv1alpha.PackageInfoError {
+	st := status.Convert(err)
+	e := &oslcv1alpha.PackageInfoError{Code: int32(st.Code()), Message: st.Message()}
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			e.Reason = d.Reason
+		case *errdetails.RetryInfo:
+			e.RetryDelay = d.RetryDelay
+		}
+	}
+	return e
+}
@@ -0,0 +1,119 @@
+package oslc
+
+import (
+	oslcv1alpha "buf.build/gen/go/chainalysis-oss/oslc/protocolbuffers/go/chainalysis_oss/oslc/v1alpha"
+	"context"
+	"github.com/chainalysis-oss/oslc"
+	oslcMocks "github.com/chainalysis-oss/oslc/mocks/oslc"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestServer_BatchGetPackageInfo(t *testing.T) {
+	mockDatastore := oslcMocks.NewMockDatastore(t)
+	mockDatastore.EXPECT().Retrieve(context.Background(), pypiRequestsGetPackageInfoRequest.Name, pypiRequestsGetPackageInfoRequest.Version, oslc.DistributorPypi).
+		Return(pypiRequestsEntry, nil)
+	s := Server{options: &serverOptions{Datastore: mockDatastore, Logger: slog.New(slog.NewTextHandler(io.Discard, nil))}}
+
+	resp, err := s.BatchGetPackageInfo(context.Background(), &oslcv1alpha.BatchGetPackageInfoRequest{Requests: []*oslcv1alpha.GetPackageInfoRequest{
+		&pypiRequestsGetPackageInfoRequest,
+		{Distributor: "unknown", Name: "requests"},
+		{Distributor: oslc.DistributorNpm},
+	}})
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 3)
+	// The shared response is not passed to proto.Equal, which would change its internal state and break other tests
+	// comparing it with require.Equal.
+	require.Equal(t, pypiRequestsGetPackageInfoResponse.Name, resp.Results[0].GetPackage().GetName())
+	require.Equal(t, pypiRequestsGetPackageInfoResponse.Version, resp.Results[0].GetPackage().GetVersion())
+	require.Equal(t, pypiRequestsGetPackageInfoResponse.License, resp.Results[0].GetPackage().GetLicense())
+	require.True(t, proto.Equal(&oslcv1alpha.PackageInfoError{Code: int32(codes.InvalidArgument), Message: "invalid distributor", Reason: oslc.ReasonInvalidDistributor}, resp.Results[1].GetError()))
+	require.True(t, proto.Equal(&oslcv1alpha.PackageInfoError{Code: int32(codes.InvalidArgument), Message: "name is required", Reason: oslc.ReasonInvalidPackageName}, resp.Results[2].GetError()))
+}
+
+func TestServer_BatchGetPackageInfo_too_large(t *testing.T) {
+	s := Server{options: &serverOptions{}}
+	requests := make([]*oslcv1alpha.GetPackageInfoRequest, batchMaxSize+1)
+	_, err := s.BatchGetPackageInfo(context.Background(), &oslcv1alpha.BatchGetPackageInfoRequest{Requests: requests})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	resp, err := s.BatchGetPackageInfo(context.Background(), &oslcv1alpha.BatchGetPackageInfoRequest{})
+	require.NoError(t, err)
+	require.Empty(t, resp.Results)
+}
+
+// tenantQuota allows the payments tenant a number of requests, and burst requests at once if burst is set.
+type tenantQuota struct {
+	remaining int
+	burst     int
+}
+
+func (q *tenantQuota) AllowRequests(tenant string, n int) bool {
+	if tenant != "payments" || n > q.remaining {
+		return false
+	}
+	q.remaining -= n
+	return true
+}
+
+func (q *tenantQuota) RequestBurst(tenant string) (int, bool) {
+	return q.burst, tenant == "payments" && q.burst > 0
+}
+
+func TestServer_BatchGetPackageInfo_tenantQuota(t *testing.T) {
+	quota := &tenantQuota{remaining: 2}
+	s := Server{options: &serverOptions{Logger: slog.New(slog.NewTextHandler(io.Discard, nil)), TenantQuotas: quota}}
+	requests := []*oslcv1alpha.GetPackageInfoRequest{{Distributor: "unknown"}, {Distributor: "unknown"}, {Distributor: "unknown"}}
+
+	// The batch itself was counted by the gRPC server, so only the other two requests are counted.
+	resp, err := s.BatchGetPackageInfo(paymentsCtx, &oslcv1alpha.BatchGetPackageInfoRequest{Requests: requests})
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 3)
+	require.Zero(t, quota.remaining)
+
+	_, err = s.BatchGetPackageInfo(paymentsCtx, &oslcv1alpha.BatchGetPackageInfoRequest{Requests: requests})
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// Single requests and requests not made for a tenant are not counted again.
+	_, err = s.BatchGetPackageInfo(paymentsCtx, &oslcv1alpha.BatchGetPackageInfoRequest{Requests: requests[:1]})
+	require.NoError(t, err)
+	_, err = s.BatchGetPackageInfo(context.Background(), &oslcv1alpha.BatchGetPackageInfoRequest{Requests: requests})
+	require.NoError(t, err)
+}
+
+func TestServer_BatchGetPackageInfo_exceedsQuotaBurst(t *testing.T) {
+	quota := &tenantQuota{remaining: 10, burst: 2}
+	s := Server{options: &serverOptions{Logger: slog.New(slog.NewTextHandler(io.Discard, nil)), TenantQuotas: quota}}
+	requests := []*oslcv1alpha.GetPackageInfoRequest{{Distributor: "unknown"}, {Distributor: "unknown"}, {Distributor: "unknown"}}
+
+	// The batch could never be allowed, so it fails without being counted, and with an error that is not retried.
+	_, err := s.BatchGetPackageInfo(paymentsCtx, &oslcv1alpha.BatchGetPackageInfoRequest{Requests: requests})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	require.Equal(t, oslc.ReasonBatchExceedsQuota, packageInfoError(err).GetReason())
+	require.Equal(t, 10, quota.remaining)
+
+	resp, err := s.BatchGetPackageInfo(paymentsCtx, &oslcv1alpha.BatchGetPackageInfoRequest{Requests: requests[:2]})
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 2)
+	require.Equal(t, 9, quota.remaining)
+}
+
+func Test_packageInfoError(t *testing.T) {
+	err := statusError(codes.Unavailable, "distributor unavailable", errorInfo(oslc.ReasonUpstreamUnavailable, oslc.DistributorNpm), retryInfo(time.Minute))
+	require.True(t, proto.Equal(&oslcv1alpha.PackageInfoError{
+		Code:       int32(codes.Unavailable),
+		Message:    "distributor unavailable",
+		Reason:     oslc.ReasonUpstreamUnavailable,
+		RetryDelay: durationpb.New(time.Minute),
+	}, packageInfoError(err)))
+
+	require.True(t, proto.Equal(&oslcv1alpha.PackageInfoError{Code: int32(codes.Internal), Message: "internal server error"},
+		packageInfoError(status.Error(codes.Internal, "internal server error"))))
+}
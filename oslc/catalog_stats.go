@@ -0,0 +1,29 @@
+package oslc
+
+import (
+	oslcv1alpha "buf.build/gen/go/chainalysis-oss/oslc/protocolbuffers/go/chainalysis_oss/oslc/v1alpha"
+	"context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"log/slog"
+)
+
+func (s Server) GetCatalogStats(ctx context.Context, _ *oslcv1alpha.GetCatalogStatsRequest) (*oslcv1alpha.GetCatalogStatsResponse, error) {
+	if s.options.CatalogStatsProvider == nil {
+		return nil, status.Error(codes.Unimplemented, "catalog statistics are not enabled")
+	}
+	stats, err := s.options.CatalogStatsProvider.CatalogStats(ctx)
+	if err != nil {
+		s.options.Logger.ErrorContext(ctx, "failed to retrieve catalog statistics", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+	return &oslcv1alpha.GetCatalogStatsResponse{
+		TotalPackages:         stats.Total,
+		ByLicense:             stats.ByLicense,
+		ByLicenseCategory:     stats.ByLicenseCategory,
+		ByDistributor:         stats.ByDistributor,
+		ByNormalizationStatus: stats.ByNormalizationStatus,
+		ComputeTime:           timestamppb.New(stats.ComputedAt),
+	}, nil
+}
@@ -0,0 +1,55 @@
+package oslc
+
+import (
+	oslcv1alpha "buf.build/gen/go/chainalysis-oss/oslc/protocolbuffers/go/chainalysis_oss/oslc/v1alpha"
+	"context"
+	"github.com/chainalysis-oss/oslc"
+	oslcMocks "github.com/chainalysis-oss/oslc/mocks/oslc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestServer_GetCatalogStats(t *testing.T) {
+	provider := oslcMocks.NewMockCatalogStatsProvider(t)
+	s := Server{options: &serverOptions{Logger: slog.New(slog.NewTextHandler(io.Discard, nil)), CatalogStatsProvider: provider}}
+	computedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	provider.EXPECT().CatalogStats(context.Background()).Return(oslc.CatalogStats{
+		Total:                 3,
+		ByLicense:             map[string]int64{"MIT": 2, "": 1},
+		ByLicenseCategory:     map[string]int64{"permissive": 2, "unknown": 1},
+		ByDistributor:         map[string]int64{oslc.DistributorNpm: 3},
+		ByNormalizationStatus: map[string]int64{"exact": 2, "failed": 1},
+		ComputedAt:            computedAt,
+	}, nil)
+
+	resp, err := s.GetCatalogStats(context.Background(), &oslcv1alpha.GetCatalogStatsRequest{})
+	require.NoError(t, err)
+	require.EqualValues(t, 3, resp.TotalPackages)
+	require.Equal(t, map[string]int64{"MIT": 2, "": 1}, resp.ByLicense)
+	require.Equal(t, map[string]int64{"permissive": 2, "unknown": 1}, resp.ByLicenseCategory)
+	require.Equal(t, map[string]int64{oslc.DistributorNpm: 3}, resp.ByDistributor)
+	require.Equal(t, map[string]int64{"exact": 2, "failed": 1}, resp.ByNormalizationStatus)
+	require.Equal(t, timestamppb.New(computedAt), resp.ComputeTime)
+}
+
+func TestServer_GetCatalogStats_errors(t *testing.T) {
+	t.Run("not enabled", func(t *testing.T) {
+		s := Server{options: &serverOptions{Logger: slog.New(slog.NewTextHandler(io.Discard, nil))}}
+		_, err := s.GetCatalogStats(context.Background(), &oslcv1alpha.GetCatalogStatsRequest{})
+		require.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+	t.Run("provider error", func(t *testing.T) {
+		provider := oslcMocks.NewMockCatalogStatsProvider(t)
+		s := Server{options: &serverOptions{Logger: slog.New(slog.NewTextHandler(io.Discard, nil)), CatalogStatsProvider: provider}}
+		provider.EXPECT().CatalogStats(context.Background()).Return(oslc.CatalogStats{}, assert.AnError)
+		_, err := s.GetCatalogStats(context.Background(), &oslcv1alpha.GetCatalogStatsRequest{})
+		require.Equal(t, codes.Internal, status.Code(err))
+	})
+}
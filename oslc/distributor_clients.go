@@ -0,0 +1,32 @@
+package oslc
+
+import (
+	"github.com/chainalysis-oss/oslc"
+	"maps"
+	"sync/atomic"
+)
+
+// DistributorClients holds the clients of the distributors, keyed by distributor. The clients can be replaced while the
+// servers using them are running, for example when their configuration is reloaded. It is safe for concurrent use.
+type DistributorClients struct {
+	clients atomic.Pointer[map[string]oslc.DistributorClient]
+}
+
+// NewDistributorClients returns DistributorClients holding the provided clients.
+func NewDistributorClients(clients map[string]oslc.DistributorClient) *DistributorClients {
+	c := &DistributorClients{}
+	c.Set(clients)
+	return c
+}
+
+// Set replaces every client. Distributors without a client are disabled. Requests already using a replaced client
+// finish with it.
+func (c *DistributorClients) Set(clients map[string]oslc.DistributorClient) {
+	clients = maps.Clone(clients)
+	c.clients.Store(&clients)
+}
+
+// Client returns the client of the distributor, or nil if it has none.
+func (c *DistributorClients) Client(distributor string) oslc.DistributorClient {
+	return (*c.clients.Load())[distributor]
+}
@@ -0,0 +1,44 @@
+package oslc
+
+import (
+	"github.com/chainalysis-oss/oslc"
+	oslcMocks "github.com/chainalysis-oss/oslc/mocks/oslc"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestDistributorClients(t *testing.T) {
+	pypi := oslcMocks.NewMockDistributorClient(t)
+	npm := oslcMocks.NewMockDistributorClient(t)
+	clients := map[string]oslc.DistributorClient{oslc.DistributorPypi: pypi}
+	c := NewDistributorClients(clients)
+	// Later changes to the map are not seen.
+	clients[oslc.DistributorNpm] = npm
+	require.Equal(t, pypi, c.Client(oslc.DistributorPypi))
+	require.Nil(t, c.Client(oslc.DistributorNpm))
+
+	c.Set(map[string]oslc.DistributorClient{oslc.DistributorNpm: npm})
+	require.Nil(t, c.Client(oslc.DistributorPypi))
+	require.Equal(t, npm, c.Client(oslc.DistributorNpm))
+}
+
+func TestServer_clientFor_distributorClients(t *testing.T) {
+	pypi := oslcMocks.NewMockDistributorClient(t)
+	npm := oslcMocks.NewMockDistributorClient(t)
+	clients := NewDistributorClients(map[string]oslc.DistributorClient{oslc.DistributorPypi: pypi})
+	// The clients take precedence over the clients of the individual options.
+	s := Server{options: &serverOptions{DistributorClients: clients, NpmClient: npm}}
+
+	client, err := s.clientFor(oslc.DistributorPypi)
+	require.NoError(t, err)
+	require.Equal(t, pypi, client)
+	_, err = s.clientFor(oslc.DistributorNpm)
+	require.ErrorAs(t, err, &InvalidDistributorError{})
+
+	clients.Set(map[string]oslc.DistributorClient{oslc.DistributorNpm: npm})
+	_, err = s.clientFor(oslc.DistributorPypi)
+	require.ErrorAs(t, err, &InvalidDistributorError{})
+	client, err = s.clientFor(oslc.DistributorNpm)
+	require.NoError(t, err)
+	require.Equal(t, npm, client)
+}
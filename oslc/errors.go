@@ -0,0 +1,116 @@
+package oslc
+
+import (
+	"context"
+	"errors"
+	"github.com/chainalysis-oss/oslc"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"log/slog"
+	"time"
+)
+
+// defaultRetryDelay is the retry delay suggested for transient failures of a distributor that did not specify one.
+const defaultRetryDelay = 30 * time.Second
+
+// upstreamErrorToStatus converts an error returned by a distributor client to a gRPC status error. Errors caused by the
+// request, such as missing packages or malformed names, and transient failures of the distributor are reported with a
+// google.rpc.ErrorInfo naming the distributor, and a google.rpc.RetryInfo if the request may be retried. Other errors
+// are logged and hidden from the caller.
+func (s Server) upstreamErrorToStatus(ctx context.Context, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return status.FromContextError(ctxErr).Err()
+	}
+
+	// The distributor of a request is validated before its client is needed, so a missing client is a disabled one.
+	var ide InvalidDistributorError
+	if errors.As(err, &ide) {
+		return statusError(codes.FailedPrecondition, "distributor is disabled", errorInfo(oslc.ReasonDistributorDisabled, ide.Distributor))
+	}
+
+	var de oslc.DistributorError
+	errors.As(err, &de)
+	switch {
+	case de.Kind == oslc.DistributorErrorInvalidName:
+		return invalidFieldError("invalid package name", oslc.ReasonInvalidPackageName, "name", de.Err.Error(), de.Distributor)
+	case errors.Is(err, oslc.ErrNoSuchPackage):
+		return packageNotFoundError(de.Distributor)
+	case errors.Is(err, oslc.ErrVersionNotFound):
+		return statusError(codes.NotFound, "version not found", errorInfo(oslc.ReasonVersionNotFound, de.Distributor))
+	}
+
+	switch de.Kind {
+	case oslc.DistributorErrorUnavailable:
+		s.options.Logger.WarnContext(ctx, "upstream unavailable", slog.String("error", err.Error()))
+		return statusError(codes.Unavailable, "distributor unavailable",
+			errorInfo(oslc.ReasonUpstreamUnavailable, de.Distributor), retryInfo(de.RetryAfter))
+	case oslc.DistributorErrorTimeout:
+		s.options.Logger.WarnContext(ctx, "upstream timed out", slog.String("error", err.Error()))
+		return statusError(codes.DeadlineExceeded, "distributor timed out", errorInfo(oslc.ReasonUpstreamTimeout, de.Distributor))
+	case oslc.DistributorErrorRateLimited:
+		s.options.Logger.WarnContext(ctx, "upstream rate limit exceeded", slog.String("error", err.Error()))
+		return statusError(codes.ResourceExhausted, "distributor rate limit exceeded",
+			errorInfo(oslc.ReasonUpstreamRateLimited, de.Distributor), retryInfo(de.RetryAfter))
+	}
+
+	s.options.Logger.ErrorContext(ctx, "failed to retrieve from upstream", slog.String("error", err.Error()))
+	if de.Distributor == "" {
+		return status.Error(codes.Internal, "internal server error")
+	}
+	return statusError(codes.Internal, "internal server error", errorInfo(oslc.ReasonUpstreamError, de.Distributor))
+}
+
+// statusError returns a status error with the provided code, message and details. If the details cannot be attached,
+// the error is returned without them.
+func statusError(code codes.Code, msg string, details ...protoadapt.MessageV1) error {
+	st := status.New(code, msg)
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+// errorInfo returns a google.rpc.ErrorInfo with the provided reason. The distributor is added to the metadata, unless
+// it is empty.
+func errorInfo(reason, distributor string) *errdetails.ErrorInfo {
+	info := &errdetails.ErrorInfo{Reason: reason, Domain: oslc.ErrorInfoDomain}
+	if distributor != "" {
+		info.Metadata = map[string]string{oslc.ErrorInfoDistributorKey: distributor}
+	}
+	return info
+}
+
+// retryInfo returns a google.rpc.RetryInfo with the provided delay, or [defaultRetryDelay] if it is not positive.
+func retryInfo(delay time.Duration) *errdetails.RetryInfo {
+	if delay <= 0 {
+		delay = defaultRetryDelay
+	}
+	return &errdetails.RetryInfo{RetryDelay: durationpb.New(delay)}
+}
+
+// invalidFieldError returns an InvalidArgument error for a malformed field of the request, with a google.rpc.BadRequest
+// describing the violation.
+func invalidFieldError(msg, reason, field, description, distributor string) error {
+	return statusError(codes.InvalidArgument, msg,
+		errorInfo(reason, distributor),
+		&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: field, Description: description}}})
+}
+
+// invalidDistributorError returns the error for requests with an unsupported distributor.
+func invalidDistributorError() error {
+	return invalidFieldError("invalid distributor", oslc.ReasonInvalidDistributor, "distributor",
+		"must be one of pypi, npm, maven, cratesio and go", "")
+}
+
+// missingNameError returns the error for requests without a package name.
+func missingNameError(distributor string) error {
+	return invalidFieldError("name is required", oslc.ReasonInvalidPackageName, "name", "must not be empty", distributor)
+}
+
+// packageNotFoundError returns the error for packages the distributor does not know.
+func packageNotFoundError(distributor string) error {
+	return statusError(codes.NotFound, "package not found", errorInfo(oslc.ReasonPackageNotFound, distributor))
+}
@@ -0,0 +1,173 @@
+package oslc
+
+import (
+	"context"
+	"github.com/chainalysis-oss/oslc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// statusDetails returns the details of a status error, keyed by their type.
+func statusDetails(t *testing.T, err error) (*errdetails.ErrorInfo, *errdetails.RetryInfo, *errdetails.BadRequest) {
+	t.Helper()
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	var info *errdetails.ErrorInfo
+	var retry *errdetails.RetryInfo
+	var badRequest *errdetails.BadRequest
+	for _, d := range st.Details() {
+		switch d := d.(type) {
+		case *errdetails.ErrorInfo:
+			info = d
+		case *errdetails.RetryInfo:
+			retry = d
+		case *errdetails.BadRequest:
+			badRequest = d
+		}
+	}
+	return info, retry, badRequest
+}
+
+func TestServer_upstreamErrorToStatus(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		wantCode       codes.Code
+		wantReason     string
+		wantRetryDelay time.Duration
+		wantField      string
+	}{
+		{
+			name:       "package not found",
+			err:        oslc.DistributorError{Distributor: oslc.DistributorNpm, Err: oslc.ErrNoSuchPackage},
+			wantCode:   codes.NotFound,
+			wantReason: oslc.ReasonPackageNotFound,
+		},
+		{
+			name:       "version not found",
+			err:        oslc.DistributorError{Distributor: oslc.DistributorNpm, Err: oslc.ErrVersionNotFound},
+			wantCode:   codes.NotFound,
+			wantReason: oslc.ReasonVersionNotFound,
+		},
+		{
+			name:       "invalid name",
+			err:        oslc.DistributorError{Distributor: oslc.DistributorNpm, Kind: oslc.DistributorErrorInvalidName, Err: oslc.ErrNoSuchPackage},
+			wantCode:   codes.InvalidArgument,
+			wantReason: oslc.ReasonInvalidPackageName,
+			wantField:  "name",
+		},
+		{
+			name:           "unavailable",
+			err:            oslc.DistributorError{Distributor: oslc.DistributorNpm, Kind: oslc.DistributorErrorUnavailable, Err: assert.AnError},
+			wantCode:       codes.Unavailable,
+			wantReason:     oslc.ReasonUpstreamUnavailable,
+			wantRetryDelay: defaultRetryDelay,
+		},
+		{
+			name:           "unavailable with retry-after",
+			err:            oslc.DistributorError{Distributor: oslc.DistributorNpm, Kind: oslc.DistributorErrorUnavailable, RetryAfter: time.Minute, Err: assert.AnError},
+			wantCode:       codes.Unavailable,
+			wantReason:     oslc.ReasonUpstreamUnavailable,
+			wantRetryDelay: time.Minute,
+		},
+		{
+			name:       "timeout",
+			err:        oslc.DistributorError{Distributor: oslc.DistributorNpm, Kind: oslc.DistributorErrorTimeout, Err: assert.AnError},
+			wantCode:   codes.DeadlineExceeded,
+			wantReason: oslc.ReasonUpstreamTimeout,
+		},
+		{
+			name:           "rate limited",
+			err:            oslc.DistributorError{Distributor: oslc.DistributorNpm, Kind: oslc.DistributorErrorRateLimited, RetryAfter: 10 * time.Second, Err: assert.AnError},
+			wantCode:       codes.ResourceExhausted,
+			wantReason:     oslc.ReasonUpstreamRateLimited,
+			wantRetryDelay: 10 * time.Second,
+		},
+		{
+			name:       "unknown distributor error",
+			err:        oslc.DistributorError{Distributor: oslc.DistributorNpm, Err: assert.AnError},
+			wantCode:   codes.Internal,
+			wantReason: oslc.ReasonUpstreamError,
+		},
+		{
+			name:       "disabled distributor",
+			err:        InvalidDistributorError{Distributor: oslc.DistributorNpm},
+			wantCode:   codes.FailedPrecondition,
+			wantReason: oslc.ReasonDistributorDisabled,
+		},
+		{
+			name:     "other error",
+			err:      assert.AnError,
+			wantCode: codes.Internal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := Server{options: &serverOptions{Logger: slog.New(slog.NewTextHandler(io.Discard, nil))}}
+			err := s.upstreamErrorToStatus(context.Background(), tt.err)
+			require.Equal(t, tt.wantCode, status.Code(err))
+
+			info, retry, badRequest := statusDetails(t, err)
+			if tt.wantReason == "" {
+				require.Nil(t, info)
+			} else {
+				require.NotNil(t, info)
+				require.Equal(t, tt.wantReason, info.Reason)
+				require.Equal(t, oslc.ErrorInfoDomain, info.Domain)
+				require.Equal(t, oslc.DistributorNpm, info.Metadata[oslc.ErrorInfoDistributorKey])
+			}
+			if tt.wantRetryDelay == 0 {
+				require.Nil(t, retry)
+			} else {
+				require.NotNil(t, retry)
+				require.Equal(t, tt.wantRetryDelay, retry.RetryDelay.AsDuration())
+			}
+			if tt.wantField == "" {
+				require.Nil(t, badRequest)
+			} else {
+				require.NotNil(t, badRequest)
+				require.Len(t, badRequest.FieldViolations, 1)
+				require.Equal(t, tt.wantField, badRequest.FieldViolations[0].Field)
+			}
+		})
+	}
+}
+
+func TestServer_upstreamErrorToStatus_context(t *testing.T) {
+	s := Server{options: &serverOptions{Logger: slog.New(slog.NewTextHandler(io.Discard, nil))}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := s.upstreamErrorToStatus(ctx, oslc.NewTransportError(oslc.DistributorNpm, context.Canceled))
+	require.Equal(t, codes.Canceled, status.Code(err))
+
+	ctx, cancel = context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	err = s.upstreamErrorToStatus(ctx, oslc.NewTransportError(oslc.DistributorNpm, context.DeadlineExceeded))
+	require.Equal(t, codes.DeadlineExceeded, status.Code(err))
+}
+
+func TestInvalidDistributorError(t *testing.T) {
+	err := invalidDistributorError()
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	info, _, badRequest := statusDetails(t, err)
+	require.Equal(t, oslc.ReasonInvalidDistributor, info.Reason)
+	require.Empty(t, info.Metadata)
+	require.Equal(t, "distributor", badRequest.FieldViolations[0].Field)
+}
+
+func TestMissingNameError(t *testing.T) {
+	err := missingNameError(oslc.DistributorPypi)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	info, _, badRequest := statusDetails(t, err)
+	require.Equal(t, oslc.ReasonInvalidPackageName, info.Reason)
+	require.Equal(t, oslc.DistributorPypi, info.Metadata[oslc.ErrorInfoDistributorKey])
+	require.Equal(t, "name", badRequest.FieldViolations[0].Field)
+}
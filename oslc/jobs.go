@@ -0,0 +1,114 @@
+package oslc
+
+import (
+	oslcv1alpha "buf.build/gen/go/chainalysis-oss/oslc/protocolbuffers/go/chainalysis_oss/oslc/v1alpha"
+	"context"
+	"errors"
+	"github.com/chainalysis-oss/oslc"
+	"github.com/chainalysis-oss/oslc/jobs"
+	"github.com/chainalysis-oss/oslc/versions"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"log/slog"
+)
+
+// getPackageInfoAsync answers an async GetPackageInfo request. A single version that is stored in the datastore is
+// answered right away, as by a synchronous request. Everything else requires the distributor, so it is left to a job,
+// which is returned in the response.
+func (s Server) getPackageInfoAsync(ctx context.Context, request *oslcv1alpha.GetPackageInfoRequest) (*oslcv1alpha.GetPackageInfoResponse, error) {
+	if s.options.JobStore == nil {
+		return nil, status.Error(codes.Unimplemented, "async lookups are not enabled")
+	}
+
+	if request.Version != "" && !versions.IsConstraint(request.Distributor, request.Version) {
+		entry, err := s.options.Datastore.Retrieve(ctx, request.Name, request.Version, request.Distributor)
+		if err == nil {
+			entry, curated := s.applyOverrides(ctx, request.Distributor, entry)
+			return entryToResponse(entry, curated), nil
+		}
+		if !errors.Is(err, oslc.ErrDatastoreObjectNotFound) {
+			s.options.Logger.ErrorContext(ctx, "failed to retrieve from datastore", slog.String("error", err.Error()))
+		}
+	}
+
+	job, err := s.options.JobStore.EnqueueJob(ctx, oslc.JobRequest{
+		Distributor: request.Distributor,
+		Name:        request.Name,
+		Version:     request.Version,
+	})
+	if err != nil {
+		s.options.Logger.ErrorContext(ctx, "failed to enqueue job", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+	s.options.Logger.DebugContext(ctx, "package not found in datastore, queued job", slog.Int64("job_id", job.ID))
+	return &oslcv1alpha.GetPackageInfoResponse{Job: s.jobToProto(ctx, job)}, nil
+}
+
+func (s Server) GetJob(ctx context.Context, request *oslcv1alpha.GetJobRequest) (*oslcv1alpha.GetJobResponse, error) {
+	if s.options.JobStore == nil {
+		return nil, status.Error(codes.Unimplemented, "async lookups are not enabled")
+	}
+	job, err := s.options.JobStore.GetJob(ctx, request.Id)
+	if errors.Is(err, oslc.ErrDatastoreObjectNotFound) {
+		return nil, status.Error(codes.NotFound, "job not found")
+	}
+	if err != nil {
+		s.options.Logger.ErrorContext(ctx, "failed to retrieve job", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+	return &oslcv1alpha.GetJobResponse{Job: s.jobToProto(ctx, job)}, nil
+}
+
+// jobToProto converts a job to its protobuf representation, decoding the result of finished jobs. Jobs are shared by
+// every tenant, so the overrides and policy of the request's tenant are applied to the decoded result. A result that
+// cannot be decoded is logged, and reported as an internal error of the job.
+func (s Server) jobToProto(ctx context.Context, job oslc.Job) *oslcv1alpha.Job {
+	j := &oslcv1alpha.Job{
+		Id:    job.ID,
+		State: jobStateToProto(job.State),
+		Request: &oslcv1alpha.GetPackageInfoRequest{
+			Distributor: job.Distributor,
+			Name:        job.Name,
+			Version:     job.Version,
+			Async:       true,
+		},
+		Attempts:   int32(job.Attempts),
+		CreateTime: timestamppb.New(job.CreatedAt),
+		UpdateTime: timestamppb.New(job.UpdatedAt),
+	}
+
+	var err error
+	switch job.State {
+	case oslc.JobSucceeded:
+		j.Result, err = jobs.DecodeResponse(job.Result)
+	case oslc.JobFailed:
+		var jobErr error
+		if jobErr, err = jobs.DecodeError(job.Result); err == nil {
+			j.Error = packageInfoError(jobErr)
+		}
+	}
+	if err != nil {
+		s.options.Logger.ErrorContext(ctx, "failed to decode job result", slog.Int64("job_id", job.ID), slog.String("error", err.Error()))
+		j.Result = nil
+		j.Error = &oslcv1alpha.PackageInfoError{Code: int32(codes.Internal), Message: "internal server error"}
+	}
+	if j.Result != nil {
+		j.Result = s.applyTenantLayers(ctx, job.Distributor, j.Result)
+	}
+	return j
+}
+
+func jobStateToProto(state oslc.JobState) oslcv1alpha.JobState {
+	switch state {
+	case oslc.JobPending:
+		return oslcv1alpha.JobState_JOB_STATE_PENDING
+	case oslc.JobRunning:
+		return oslcv1alpha.JobState_JOB_STATE_RUNNING
+	case oslc.JobSucceeded:
+		return oslcv1alpha.JobState_JOB_STATE_SUCCEEDED
+	case oslc.JobFailed:
+		return oslcv1alpha.JobState_JOB_STATE_FAILED
+	}
+	return oslcv1alpha.JobState_JOB_STATE_UNSPECIFIED
+}
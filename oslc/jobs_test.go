@@ -0,0 +1,185 @@
+package oslc
+
+import (
+	oslcv1alpha "buf.build/gen/go/chainalysis-oss/oslc/protocolbuffers/go/chainalysis_oss/oslc/v1alpha"
+	"context"
+	"github.com/chainalysis-oss/oslc"
+	"github.com/chainalysis-oss/oslc/jobs"
+	oslcMocks "github.com/chainalysis-oss/oslc/mocks/oslc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"testing"
+	"time"
+)
+
+// newAsyncServer returns a server with a job store. The distributor client has no expectations, so the test fails if
+// the server calls the distributor.
+func newAsyncServer(t *testing.T) (Server, *oslcMocks.MockDatastore, *oslcMocks.MockJobStore) {
+	t.Helper()
+	store := oslcMocks.NewMockJobStore(t)
+	s, datastore := newHistoryServer(t, newTaggingDistributorClient(t), func(opts *serverOptions) {
+		opts.JobStore = store
+	})
+	return s, datastore, store
+}
+
+func TestServer_GetPackageInfo_async(t *testing.T) {
+	s, datastore, store := newAsyncServer(t)
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	datastore.EXPECT().Retrieve(context.Background(), "test", "1.0.0", oslc.DistributorNpm).
+		Return(oslc.Entry{}, oslc.ErrDatastoreObjectNotFound)
+	store.EXPECT().EnqueueJob(context.Background(), oslc.JobRequest{Distributor: oslc.DistributorNpm, Name: "test", Version: "1.0.0"}).
+		Return(oslc.Job{
+			ID:         7,
+			JobRequest: oslc.JobRequest{Distributor: oslc.DistributorNpm, Name: "test", Version: "1.0.0"},
+			State:      oslc.JobPending,
+			CreatedAt:  createdAt,
+			UpdatedAt:  createdAt,
+		}, nil)
+
+	resp, err := s.GetPackageInfo(context.Background(), &oslcv1alpha.GetPackageInfoRequest{
+		Name:        "test",
+		Version:     "1.0.0",
+		Distributor: oslc.DistributorNpm,
+		Async:       true,
+	})
+	require.NoError(t, err)
+	require.Empty(t, resp.Name)
+	require.True(t, proto.Equal(&oslcv1alpha.Job{
+		Id:         7,
+		State:      oslcv1alpha.JobState_JOB_STATE_PENDING,
+		Request:    &oslcv1alpha.GetPackageInfoRequest{Name: "test", Version: "1.0.0", Distributor: oslc.DistributorNpm, Async: true},
+		CreateTime: timestamppb.New(createdAt),
+		UpdateTime: timestamppb.New(createdAt),
+	}, resp.Job), resp.Job)
+}
+
+func TestServer_GetPackageInfo_async_stored(t *testing.T) {
+	s, datastore, _ := newAsyncServer(t)
+	datastore.EXPECT().Retrieve(context.Background(), "test", "1.0.0", oslc.DistributorNpm).
+		Return(historyEntry("1.0.0", "MIT"), nil)
+
+	resp, err := s.GetPackageInfo(context.Background(), &oslcv1alpha.GetPackageInfoRequest{
+		Name:        "test",
+		Version:     "1.0.0",
+		Distributor: oslc.DistributorNpm,
+		Async:       true,
+	})
+	require.NoError(t, err)
+	require.Nil(t, resp.Job)
+	require.Equal(t, "MIT", resp.License)
+}
+
+func TestServer_GetPackageInfo_async_constraint(t *testing.T) {
+	s, _, store := newAsyncServer(t)
+	// Resolving a constraint requires the versions of the distributor, so it is left to the job.
+	store.EXPECT().EnqueueJob(context.Background(), oslc.JobRequest{Distributor: oslc.DistributorNpm, Name: "test", Version: "^1.0.0"}).
+		Return(oslc.Job{ID: 1, State: oslc.JobRunning}, nil)
+
+	resp, err := s.GetPackageInfo(context.Background(), &oslcv1alpha.GetPackageInfoRequest{
+		Name:        "test",
+		Version:     "^1.0.0",
+		Distributor: oslc.DistributorNpm,
+		Async:       true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, oslcv1alpha.JobState_JOB_STATE_RUNNING, resp.Job.State)
+}
+
+func TestServer_GetPackageInfo_async_errors(t *testing.T) {
+	t.Run("not enabled", func(t *testing.T) {
+		s, _ := newHistoryServer(t, newTaggingDistributorClient(t))
+		_, err := s.GetPackageInfo(context.Background(), &oslcv1alpha.GetPackageInfoRequest{Name: "test", Distributor: oslc.DistributorNpm, Async: true})
+		require.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+	t.Run("enqueue", func(t *testing.T) {
+		s, datastore, store := newAsyncServer(t)
+		datastore.EXPECT().Retrieve(context.Background(), "test", "1.0.0", oslc.DistributorNpm).Return(oslc.Entry{}, assert.AnError)
+		store.EXPECT().EnqueueJob(context.Background(), oslc.JobRequest{Distributor: oslc.DistributorNpm, Name: "test", Version: "1.0.0"}).
+			Return(oslc.Job{}, assert.AnError)
+		_, err := s.GetPackageInfo(context.Background(), &oslcv1alpha.GetPackageInfoRequest{Name: "test", Version: "1.0.0", Distributor: oslc.DistributorNpm, Async: true})
+		require.Equal(t, codes.Internal, status.Code(err))
+	})
+}
+
+func TestServer_GetPackageInfo_async_offline(t *testing.T) {
+	s, datastore, recorder := newOfflineServer(t)
+	s.options.JobStore = oslcMocks.NewMockJobStore(t)
+	datastore.EXPECT().Retrieve(context.Background(), "test", "1.0.0", oslc.DistributorNpm).
+		Return(oslc.Entry{}, oslc.ErrDatastoreObjectNotFound)
+	recorder.EXPECT().RecordMiss(context.Background(), oslc.PackageMiss{Distributor: oslc.DistributorNpm, Name: "test", Version: "1.0.0"}).Return(nil)
+
+	// An offline server never queries the distributor, so async requests are answered right away.
+	_, err := s.GetPackageInfo(context.Background(), &oslcv1alpha.GetPackageInfoRequest{
+		Name:        "test",
+		Version:     "1.0.0",
+		Distributor: oslc.DistributorNpm,
+		Async:       true,
+	})
+	requireNotInOfflineCatalog(t, err)
+}
+
+func TestServer_GetJob(t *testing.T) {
+	s, _, store := newAsyncServer(t)
+	result, err := jobs.EncodeResponse(&oslcv1alpha.GetPackageInfoResponse{Name: "test", Version: "1.0.0", License: "MIT"})
+	require.NoError(t, err)
+	failure, err := jobs.EncodeError(packageNotFoundError(oslc.DistributorNpm))
+	require.NoError(t, err)
+	store.EXPECT().GetJob(context.Background(), int64(1)).
+		Return(oslc.Job{ID: 1, State: oslc.JobSucceeded, Attempts: 1, Result: result}, nil)
+	store.EXPECT().GetJob(context.Background(), int64(2)).
+		Return(oslc.Job{ID: 2, State: oslc.JobFailed, Attempts: 1, Result: failure}, nil)
+	store.EXPECT().GetJob(context.Background(), int64(3)).
+		Return(oslc.Job{ID: 3, State: oslc.JobSucceeded, Result: []byte("not protobuf")}, nil)
+
+	resp, err := s.GetJob(context.Background(), &oslcv1alpha.GetJobRequest{Id: 1})
+	require.NoError(t, err)
+	require.Equal(t, oslcv1alpha.JobState_JOB_STATE_SUCCEEDED, resp.Job.State)
+	require.EqualValues(t, 1, resp.Job.Attempts)
+	require.Equal(t, "MIT", resp.Job.Result.License)
+	require.Nil(t, resp.Job.Error)
+
+	resp, err = s.GetJob(context.Background(), &oslcv1alpha.GetJobRequest{Id: 2})
+	require.NoError(t, err)
+	require.Equal(t, oslcv1alpha.JobState_JOB_STATE_FAILED, resp.Job.State)
+	require.Nil(t, resp.Job.Result)
+	require.EqualValues(t, codes.NotFound, resp.Job.Error.Code)
+	require.Equal(t, oslc.ReasonPackageNotFound, resp.Job.Error.Reason)
+
+	resp, err = s.GetJob(context.Background(), &oslcv1alpha.GetJobRequest{Id: 3})
+	require.NoError(t, err)
+	require.Nil(t, resp.Job.Result)
+	require.EqualValues(t, codes.Internal, resp.Job.Error.Code)
+}
+
+func TestServer_GetJob_errors(t *testing.T) {
+	t.Run("not enabled", func(t *testing.T) {
+		s, _ := newHistoryServer(t, newTaggingDistributorClient(t))
+		_, err := s.GetJob(context.Background(), &oslcv1alpha.GetJobRequest{Id: 1})
+		require.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+	t.Run("not found", func(t *testing.T) {
+		s, _, store := newAsyncServer(t)
+		store.EXPECT().GetJob(context.Background(), int64(1)).Return(oslc.Job{}, oslc.ErrDatastoreObjectNotFound)
+		_, err := s.GetJob(context.Background(), &oslcv1alpha.GetJobRequest{Id: 1})
+		require.Equal(t, codes.NotFound, status.Code(err))
+	})
+	t.Run("store error", func(t *testing.T) {
+		s, _, store := newAsyncServer(t)
+		store.EXPECT().GetJob(context.Background(), int64(1)).Return(oslc.Job{}, assert.AnError)
+		_, err := s.GetJob(context.Background(), &oslcv1alpha.GetJobRequest{Id: 1})
+		require.Equal(t, codes.Internal, status.Code(err))
+	})
+}
+
+func TestJobStateToProto(t *testing.T) {
+	require.Equal(t, oslcv1alpha.JobState_JOB_STATE_PENDING, jobStateToProto(oslc.JobPending))
+	require.Equal(t, oslcv1alpha.JobState_JOB_STATE_RUNNING, jobStateToProto(oslc.JobRunning))
+	require.Equal(t, oslcv1alpha.JobState_JOB_STATE_SUCCEEDED, jobStateToProto(oslc.JobSucceeded))
+	require.Equal(t, oslcv1alpha.JobState_JOB_STATE_FAILED, jobStateToProto(oslc.JobFailed))
+	require.Equal(t, oslcv1alpha.JobState_JOB_STATE_UNSPECIFIED, jobStateToProto("unknown"))
+}
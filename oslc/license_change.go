@@ -0,0 +1,106 @@
+package oslc
+
+import (
+	"context"
+	"github.com/chainalysis-oss/oslc"
+	"github.com/chainalysis-oss/oslc/versions"
+	"log/slog"
+	"time"
+)
+
+// detectPackageChange compares entry to the versions of the package in the datastore, and reports the change that
+// saving it makes to the catalog.
+//
+// The LicenseChangeNotifier is notified if the license of entry differs from the license of the version preceding it
+// in the datastore. If the datastore already holds the same version, as is the case when a package is refreshed, that
+// version is the predecessor. Otherwise, the predecessor is the highest stored version below the entry's version.
+// Without a predecessor, there is nothing to compare to, and no notification is sent.
+//
+// The PackageEventBroker receives a [oslc.PackageEventCreated] event if the datastore does not hold the version yet,
+// and a [oslc.PackageEventLicenseChanged] event if it holds the version with a different license.
+//
+// It must be called before entry is saved. Without a LicenseChangeNotifier, and a PackageEventBroker with
+// subscriptions, it does nothing. Errors are logged, since notifications never affect the response.
+func (s Server) detectPackageChange(ctx context.Context, distributor string, entry oslc.Entry) {
+	// Events are only published to current subscriptions, so without any there is no need to look up the versions.
+	publish := s.options.PackageEventBroker != nil && s.options.PackageEventBroker.Subscriptions() > 0
+	if s.options.LicenseChangeNotifier == nil && !publish {
+		return
+	}
+	stored, err := s.options.Datastore.RetrieveVersions(ctx, entry.Name, distributor)
+	if err != nil {
+		s.options.Logger.ErrorContext(ctx, "failed to retrieve versions for package change detection", slog.String("error", err.Error()))
+		return
+	}
+	previous, ok := predecessor(distributor, stored, entry.Version)
+	now := time.Now().UTC()
+
+	if publish {
+		switch {
+		case !ok || previous.Version != entry.Version:
+			s.options.PackageEventBroker.PublishPackageEvent(ctx, oslc.PackageEvent{
+				Type:        oslc.PackageEventCreated,
+				Distributor: distributor,
+				Name:        entry.Name,
+				Version:     entry.Version,
+				License:     entry.License,
+				OccurredAt:  now,
+			})
+		case previous.License != entry.License:
+			s.options.PackageEventBroker.PublishPackageEvent(ctx, oslc.PackageEvent{
+				Type:            oslc.PackageEventLicenseChanged,
+				Distributor:     distributor,
+				Name:            entry.Name,
+				Version:         entry.Version,
+				License:         entry.License,
+				PreviousLicense: previous.License,
+				OccurredAt:      now,
+			})
+		}
+	}
+
+	if s.options.LicenseChangeNotifier == nil || !ok || previous.License == entry.License {
+		return
+	}
+	event := oslc.LicenseChangeEvent{
+		Distributor:     distributor,
+		Name:            entry.Name,
+		Version:         entry.Version,
+		License:         entry.License,
+		PreviousVersion: previous.Version,
+		PreviousLicense: previous.License,
+		DetectedAt:      now,
+	}
+	s.options.Logger.InfoContext(ctx, "license change detected",
+		slog.String("distributor", distributor),
+		slog.String("name", entry.Name),
+		slog.String("version", entry.Version),
+		slog.String("license", entry.License),
+		slog.String("previous_version", previous.Version),
+		slog.String("previous_license", previous.License),
+	)
+	if err := s.options.LicenseChangeNotifier.NotifyLicenseChange(ctx, event); err != nil {
+		s.options.Logger.ErrorContext(ctx, "failed to notify license change", slog.String("error", err.Error()))
+	}
+}
+
+// predecessor returns the entry in entries with the provided version, or else the entry with the highest version below
+// it, in the version scheme of the distributor. The boolean is false if there is no such entry.
+func predecessor(distributor string, entries []oslc.Entry, version string) (oslc.Entry, bool) {
+	compare := versions.Comparer(distributor)
+	var previous oslc.Entry
+	found := false
+	for _, e := range entries {
+		if e.Version == version {
+			return e, true
+		}
+		if compare(e.Version, version) >= 0 {
+			continue
+		}
+		if !found || compare(e.Version, previous.Version) > 0 {
+			previous = e
+			found = true
+		}
+	}
+	return previous, found
+}
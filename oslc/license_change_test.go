@@ -0,0 +1,164 @@
+package oslc
+
+import (
+	oslcv1alpha "buf.build/gen/go/chainalysis-oss/oslc/protocolbuffers/go/chainalysis_oss/oslc/v1alpha"
+	"context"
+	"github.com/chainalysis-oss/oslc"
+	oslcMocks "github.com/chainalysis-oss/oslc/mocks/oslc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestPredecessor(t *testing.T) {
+	entries := []oslc.Entry{
+		historyEntry("1.2.0", "MIT"),
+		historyEntry("1.10.0", "MIT"),
+		historyEntry("2.0.0", "BUSL-1.1"),
+	}
+	testcases := []struct {
+		version  string
+		expected string
+		found    bool
+	}{
+		{"1.0.0", "", false},
+		{"1.2.0", "1.2.0", true},
+		{"1.9.0", "1.2.0", true},
+		{"1.11.0", "1.10.0", true},
+		{"3.0.0", "2.0.0", true},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.version, func(t *testing.T) {
+			e, ok := predecessor(oslc.DistributorNpm, entries, tt.version)
+			require.Equal(t, tt.found, ok)
+			require.Equal(t, tt.expected, e.Version)
+		})
+	}
+}
+
+func TestServer_GetPackageInfo_licenseChange(t *testing.T) {
+	testcases := []struct {
+		name     string
+		stored   []oslc.Entry
+		expected *oslc.LicenseChangeEvent
+	}{
+		{
+			name:   "changed",
+			stored: []oslc.Entry{historyEntry("1.0.0", "MIT"), historyEntry("3.0.0", "MIT")},
+			expected: &oslc.LicenseChangeEvent{
+				Distributor:     oslc.DistributorNpm,
+				Name:            "test",
+				Version:         "2.0.0",
+				License:         "BUSL-1.1",
+				PreviousVersion: "1.0.0",
+				PreviousLicense: "MIT",
+			},
+		},
+		{
+			name:   "unchanged",
+			stored: []oslc.Entry{historyEntry("1.0.0", "BUSL-1.1")},
+		},
+		{
+			name:   "no predecessor",
+			stored: []oslc.Entry{historyEntry("3.0.0", "MIT")},
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.name, func(t *testing.T) {
+			client := oslcMocks.NewMockDistributorClient(t)
+			notifier := oslcMocks.NewMockLicenseChangeNotifier(t)
+			s, datastore := newHistoryServer(t, client, func(o *serverOptions) {
+				o.LicenseChangeNotifier = notifier
+			})
+			entry := historyEntry("2.0.0", "BUSL-1.1")
+			datastore.EXPECT().Retrieve(context.Background(), "test", "2.0.0", oslc.DistributorNpm).Return(oslc.Entry{}, oslc.ErrDatastoreObjectNotFound)
+			client.EXPECT().GetPackageVersion(context.Background(), "test", "2.0.0").Return(entry, nil)
+			datastore.EXPECT().RetrieveVersions(context.Background(), "test", oslc.DistributorNpm).Return(tt.stored, nil)
+			if tt.expected != nil {
+				before := time.Now()
+				notifier.EXPECT().NotifyLicenseChange(context.Background(), mock.Anything).
+					Run(func(_ context.Context, event oslc.LicenseChangeEvent) {
+						require.WithinRange(t, event.DetectedAt, before, time.Now())
+						event.DetectedAt = time.Time{}
+						require.Equal(t, *tt.expected, event)
+					}).Return(assert.AnError)
+			}
+			datastore.EXPECT().Save(context.Background(), entry).Return(nil)
+
+			resp, err := s.GetPackageInfo(context.Background(), &oslcv1alpha.GetPackageInfoRequest{Name: "test", Version: "2.0.0", Distributor: oslc.DistributorNpm})
+			// Failing to notify never fails the request.
+			require.NoError(t, err)
+			require.Equal(t, "BUSL-1.1", resp.License)
+		})
+	}
+}
+
+func TestServer_detectPackageChange_events(t *testing.T) {
+	testcases := []struct {
+		name     string
+		stored   []oslc.Entry
+		expected *oslc.PackageEvent
+	}{
+		{
+			name:     "created",
+			stored:   []oslc.Entry{historyEntry("1.0.0", "MIT")},
+			expected: &oslc.PackageEvent{Type: oslc.PackageEventCreated, Distributor: oslc.DistributorNpm, Name: "test", Version: "2.0.0", License: "BUSL-1.1"},
+		},
+		{
+			name:     "license changed",
+			stored:   []oslc.Entry{historyEntry("2.0.0", "MIT")},
+			expected: &oslc.PackageEvent{Type: oslc.PackageEventLicenseChanged, Distributor: oslc.DistributorNpm, Name: "test", Version: "2.0.0", License: "BUSL-1.1", PreviousLicense: "MIT"},
+		},
+		{
+			name:   "refreshed without change",
+			stored: []oslc.Entry{historyEntry("2.0.0", "BUSL-1.1")},
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.name, func(t *testing.T) {
+			broker := oslcMocks.NewMockPackageEventBroker(t)
+			s, datastore := newHistoryServer(t, oslcMocks.NewMockDistributorClient(t), func(o *serverOptions) {
+				o.PackageEventBroker = broker
+			})
+			broker.EXPECT().Subscriptions().Return(1)
+			datastore.EXPECT().RetrieveVersions(context.Background(), "test", oslc.DistributorNpm).Return(tt.stored, nil)
+			if tt.expected != nil {
+				before := time.Now()
+				broker.EXPECT().PublishPackageEvent(context.Background(), mock.Anything).
+					Run(func(_ context.Context, event oslc.PackageEvent) {
+						require.WithinRange(t, event.OccurredAt, before, time.Now())
+						event.OccurredAt = time.Time{}
+						require.Equal(t, *tt.expected, event)
+					})
+			}
+			s.detectPackageChange(context.Background(), oslc.DistributorNpm, historyEntry("2.0.0", "BUSL-1.1"))
+		})
+	}
+}
+
+func TestServer_detectPackageChange_ErrRetrieveVersions(t *testing.T) {
+	notifier := oslcMocks.NewMockLicenseChangeNotifier(t)
+	s, datastore := newHistoryServer(t, oslcMocks.NewMockDistributorClient(t), func(o *serverOptions) {
+		o.LicenseChangeNotifier = notifier
+	})
+	datastore.EXPECT().RetrieveVersions(context.Background(), "test", oslc.DistributorNpm).Return(nil, assert.AnError)
+	s.detectPackageChange(context.Background(), oslc.DistributorNpm, historyEntry("2.0.0", "MIT"))
+}
+
+func TestServer_detectPackageChange_without_notifier_or_broker(t *testing.T) {
+	// The datastore mock fails the test if it is called.
+	s, _ := newHistoryServer(t, oslcMocks.NewMockDistributorClient(t))
+	s.detectPackageChange(context.Background(), oslc.DistributorNpm, historyEntry("2.0.0", "MIT"))
+}
+
+func TestServer_detectPackageChange_without_subscriptions(t *testing.T) {
+	broker := oslcMocks.NewMockPackageEventBroker(t)
+	broker.EXPECT().Subscriptions().Return(0)
+	// The datastore mock fails the test if it is called.
+	s, _ := newHistoryServer(t, oslcMocks.NewMockDistributorClient(t), func(o *serverOptions) {
+		o.PackageEventBroker = broker
+	})
+	s.detectPackageChange(context.Background(), oslc.DistributorNpm, historyEntry("2.0.0", "MIT"))
+}
@@ -0,0 +1,176 @@
+package oslc
+
+import (
+	oslcv1alpha "buf.build/gen/go/chainalysis-oss/oslc/protocolbuffers/go/chainalysis_oss/oslc/v1alpha"
+	"context"
+	"errors"
+	"github.com/chainalysis-oss/oslc"
+	"github.com/chainalysis-oss/oslc/versions"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"slices"
+	"sync"
+)
+
+// licenseHistoryFetchConcurrency is the number of versions fetched from a distributor concurrently while answering a
+// GetLicenseHistory request.
+const licenseHistoryFetchConcurrency = 4
+
+func (s Server) GetLicenseHistory(ctx context.Context, request *oslcv1alpha.GetLicenseHistoryRequest) (*oslcv1alpha.GetLicenseHistoryResponse, error) {
+	if !validDistributor(request.Distributor) {
+		return nil, invalidDistributorError()
+	}
+	if request.Name == "" {
+		return nil, missingNameError(request.Distributor)
+	}
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(
+		attribute.String("oslc.distributor", request.Distributor),
+		attribute.String("oslc.package.name", request.Name),
+	)
+
+	entries := make(map[string]oslc.Entry)
+	stored, err := s.options.Datastore.RetrieveVersions(ctx, request.Name, request.Distributor)
+	if err != nil {
+		// The distributor may still be able to answer the request, so this is not fatal.
+		s.options.Logger.ErrorContext(ctx, "failed to retrieve versions from datastore", slog.String("error", err.Error()))
+	}
+	for _, entry := range stored {
+		entries[entry.Version] = entry
+	}
+
+	if s.options.Offline && len(entries) == 0 {
+		return nil, s.notInOfflineCatalogError(ctx, request.Distributor, request.Name, "")
+	}
+
+	upstream, err := s.listUpstreamVersions(ctx, request.Distributor, request.Name)
+	if err != nil {
+		if errors.Is(err, oslc.ErrNoSuchPackage) && len(entries) == 0 {
+			return nil, packageNotFoundError(request.Distributor)
+		}
+		s.options.Logger.WarnContext(ctx, "failed to list versions from upstream, using stored versions only", slog.String("error", err.Error()))
+	}
+
+	missing := make([]string, 0)
+	for _, v := range upstream {
+		if _, ok := entries[v]; !ok {
+			missing = append(missing, v)
+		}
+	}
+	fetched, unresolved := s.fetchVersions(ctx, request.Distributor, request.Name, missing)
+	if err := ctx.Err(); err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+	for _, entry := range fetched {
+		entries[entry.Version] = entry
+	}
+	span.SetAttributes(
+		attribute.Int("oslc.history.stored_versions", len(stored)),
+		attribute.Int("oslc.history.fetched_versions", len(fetched)),
+		attribute.Int("oslc.history.unresolved_versions", len(unresolved)),
+	)
+
+	if len(entries) == 0 {
+		return nil, status.Error(codes.NotFound, "no versions found")
+	}
+
+	sorted := make([]oslc.Entry, 0, len(entries))
+	for _, entry := range entries {
+		sorted = append(sorted, entry)
+	}
+	// The order defines the change points, so it must be the one of the distributor's version scheme.
+	compare := versions.Comparer(request.Distributor)
+	slices.SortFunc(sorted, func(a, b oslc.Entry) int {
+		return compare(a.Version, b.Version)
+	})
+	slices.SortFunc(unresolved, compare)
+
+	// Failing to list overrides is already logged, and the history is still useful without them.
+	overrides, _ := s.listOverrides(ctx, request.Distributor, request.Name)
+
+	resp := &oslcv1alpha.GetLicenseHistoryResponse{
+		Name:               request.Name,
+		Versions:           make([]*oslcv1alpha.LicenseHistoryEntry, len(sorted)),
+		UnresolvedVersions: unresolved,
+	}
+	for i, entry := range sorted {
+		entry, curated := s.applyOverrideList(ctx, overrides, entry)
+		h := &oslcv1alpha.LicenseHistoryEntry{
+			Version: entry.Version,
+			License: entry.License,
+			Curated: curated,
+		}
+		if i > 0 && resp.Versions[i-1].License != entry.License {
+			h.LicenseChanged = true
+			h.PreviousLicense = resp.Versions[i-1].License
+		}
+		resp.Versions[i] = h
+	}
+	return resp, nil
+}
+
+// listUpstreamVersions returns the versions of the package known to the distributor. Distributors whose client cannot
+// enumerate versions, and servers in offline mode, return no versions.
+func (s Server) listUpstreamVersions(ctx context.Context, distributor, name string) ([]string, error) {
+	if s.options.Offline {
+		return nil, nil
+	}
+	client, err := s.clientFor(distributor)
+	if err != nil {
+		return nil, err
+	}
+	lister, ok := client.(oslc.VersionLister)
+	if !ok {
+		return nil, nil
+	}
+	return lister.ListVersions(ctx, name)
+}
+
+// fetchVersions fetches the provided versions of a package from the distributor, and saves them to the datastore. At
+// most LicenseHistoryFetchLimit versions are fetched, newest first. Versions that are not fetched, or could not be
+// fetched, are returned as unresolved.
+func (s Server) fetchVersions(ctx context.Context, distributor, name string, missing []string) ([]oslc.Entry, []string) {
+	compare := versions.Comparer(distributor)
+	slices.SortFunc(missing, func(a, b string) int {
+		return compare(b, a)
+	})
+	limit := min(len(missing), max(s.options.LicenseHistoryFetchLimit, 0))
+	unresolved := slices.Clone(missing[limit:])
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	fetched := make([]oslc.Entry, 0, limit)
+	sem := make(chan struct{}, licenseHistoryFetchConcurrency)
+	for _, version := range missing[:limit] {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			entry, err := s.getPackageFromDistributor(ctx, distributor, name, version)
+			if err == nil {
+				if err := s.options.Datastore.Save(ctx, entry); err != nil {
+					s.options.Logger.ErrorContext(ctx, "failed to save to datastore", slog.String("error", err.Error()))
+				}
+			} else {
+				s.options.Logger.WarnContext(ctx, "failed to retrieve version from upstream",
+					slog.String("version", version), slog.String("error", err.Error()))
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				unresolved = append(unresolved, version)
+				return
+			}
+			fetched = append(fetched, entry)
+		}()
+	}
+	wg.Wait()
+	return fetched, unresolved
+}
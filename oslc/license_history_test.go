@@ -0,0 +1,207 @@
+package oslc
+
+import (
+	oslcv1alpha "buf.build/gen/go/chainalysis-oss/oslc/protocolbuffers/go/chainalysis_oss/oslc/v1alpha"
+	"context"
+	"github.com/chainalysis-oss/oslc"
+	oslcMocks "github.com/chainalysis-oss/oslc/mocks/oslc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"log/slog"
+	"testing"
+)
+
+// listingDistributorClient is a distributor client that can also enumerate versions.
+type listingDistributorClient struct {
+	*oslcMocks.MockDistributorClient
+	*oslcMocks.MockVersionLister
+}
+
+// historyEntry returns an entry of the test package, as normalized by the normalizer of [newHistoryServer].
+func historyEntry(version, license string) oslc.Entry {
+	status := oslc.LicenseNormalizationExact
+	if license == "" {
+		status = oslc.LicenseNormalizationFailed
+	}
+	return oslc.Entry{
+		Name:                "test",
+		Version:             version,
+		License:             license,
+		RawLicense:          license,
+		NormalizationStatus: status,
+		DistributionPoints: []oslc.DistributionPoint{{
+			Name:        "test",
+			URL:         "https://example.com/" + version,
+			Distributor: oslc.DistributorNpm,
+		}},
+	}
+}
+
+func newHistoryServer(t *testing.T, client oslc.DistributorClient, options ...func(*serverOptions)) (Server, *oslcMocks.MockDatastore) {
+	t.Helper()
+	datastore := oslcMocks.NewMockDatastore(t)
+	normalizer := oslcMocks.NewMockLicenseIDNormalizer(t)
+	// Licenses from the distributor are already normalized in these tests.
+	normalizer.EXPECT().NormalizeID(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, id string) string {
+		return id
+	}).Maybe()
+	opts := &serverOptions{
+		Logger:                   slog.New(slog.NewTextHandler(io.Discard, nil)),
+		NpmClient:                client,
+		Datastore:                datastore,
+		LicenseIDNormalizer:      normalizer,
+		LicenseHistoryFetchLimit: 50,
+	}
+	for _, o := range options {
+		o(opts)
+	}
+	return Server{options: opts}, datastore
+}
+
+func historyVersions(resp *oslcv1alpha.GetLicenseHistoryResponse) []string {
+	v := make([]string, len(resp.Versions))
+	for i, h := range resp.Versions {
+		v[i] = h.Version
+	}
+	return v
+}
+
+var historyRequest = &oslcv1alpha.GetLicenseHistoryRequest{Name: "test", Distributor: oslc.DistributorNpm}
+
+func TestServer_GetLicenseHistory_storedOnly(t *testing.T) {
+	// A client without the VersionLister capability.
+	s, datastore := newHistoryServer(t, oslcMocks.NewMockDistributorClient(t))
+	datastore.EXPECT().RetrieveVersions(context.Background(), "test", oslc.DistributorNpm).Return([]oslc.Entry{
+		historyEntry("2.0.0", "BUSL-1.1"),
+		historyEntry("1.10.0", "MIT"),
+		historyEntry("1.2.0", "MIT"),
+	}, nil)
+
+	resp, err := s.GetLicenseHistory(context.Background(), historyRequest)
+	require.NoError(t, err)
+	require.Equal(t, "test", resp.Name)
+	require.Equal(t, []string{"1.2.0", "1.10.0", "2.0.0"}, historyVersions(resp))
+	require.False(t, resp.Versions[0].LicenseChanged)
+	require.False(t, resp.Versions[1].LicenseChanged)
+	require.True(t, resp.Versions[2].LicenseChanged)
+	require.Equal(t, "MIT", resp.Versions[2].PreviousLicense)
+	require.Equal(t, "BUSL-1.1", resp.Versions[2].License)
+	require.Empty(t, resp.UnresolvedVersions)
+}
+
+func TestServer_GetLicenseHistory_versionScheme(t *testing.T) {
+	s, datastore := newHistoryServer(t, oslcMocks.NewMockDistributorClient(t), func(o *serverOptions) {
+		o.PypiClient = o.NpmClient
+	})
+	datastore.EXPECT().RetrieveVersions(context.Background(), "test", oslc.DistributorPypi).Return([]oslc.Entry{
+		historyEntry("1.0.0.post1", "Apache-2.0"),
+		historyEntry("1.0.0", "MIT"),
+		historyEntry("1.0.0rc1", "MIT"),
+	}, nil)
+
+	resp, err := s.GetLicenseHistory(context.Background(), &oslcv1alpha.GetLicenseHistoryRequest{Name: "test", Distributor: oslc.DistributorPypi})
+	require.NoError(t, err)
+	// PEP 440 sorts post-releases after the release, and release candidates before it.
+	require.Equal(t, []string{"1.0.0rc1", "1.0.0", "1.0.0.post1"}, historyVersions(resp))
+	require.True(t, resp.Versions[2].LicenseChanged)
+}
+
+func TestServer_GetLicenseHistory_fetchesMissingVersions(t *testing.T) {
+	client := listingDistributorClient{oslcMocks.NewMockDistributorClient(t), oslcMocks.NewMockVersionLister(t)}
+	s, datastore := newHistoryServer(t, client)
+	datastore.EXPECT().RetrieveVersions(context.Background(), "test", oslc.DistributorNpm).Return([]oslc.Entry{historyEntry("1.0.0", "MIT")}, nil)
+	client.MockVersionLister.EXPECT().ListVersions(context.Background(), "test").Return([]string{"1.0.0", "1.1.0", "2.0.0", "3.0.0"}, nil)
+	client.MockDistributorClient.EXPECT().GetPackageVersion(context.Background(), "test", "1.1.0").Return(historyEntry("1.1.0", "MIT"), nil)
+	client.MockDistributorClient.EXPECT().GetPackageVersion(context.Background(), "test", "2.0.0").Return(historyEntry("2.0.0", "Apache-2.0"), nil)
+	client.MockDistributorClient.EXPECT().GetPackageVersion(context.Background(), "test", "3.0.0").Return(oslc.Entry{}, oslc.DistributorError{Distributor: oslc.DistributorNpm, Err: assert.AnError})
+	datastore.EXPECT().Save(context.Background(), historyEntry("1.1.0", "MIT")).Return(nil)
+	datastore.EXPECT().Save(context.Background(), historyEntry("2.0.0", "Apache-2.0")).Return(assert.AnError)
+
+	resp, err := s.GetLicenseHistory(context.Background(), historyRequest)
+	require.NoError(t, err)
+	require.Equal(t, []string{"1.0.0", "1.1.0", "2.0.0"}, historyVersions(resp))
+	require.True(t, resp.Versions[2].LicenseChanged)
+	require.Equal(t, []string{"3.0.0"}, resp.UnresolvedVersions)
+}
+
+func TestServer_GetLicenseHistory_fetchLimit(t *testing.T) {
+	client := listingDistributorClient{oslcMocks.NewMockDistributorClient(t), oslcMocks.NewMockVersionLister(t)}
+	s, datastore := newHistoryServer(t, client, func(o *serverOptions) { o.LicenseHistoryFetchLimit = 1 })
+	datastore.EXPECT().RetrieveVersions(context.Background(), "test", oslc.DistributorNpm).Return(nil, nil)
+	client.MockVersionLister.EXPECT().ListVersions(context.Background(), "test").Return([]string{"1.0.0", "3.0.0", "2.0.0"}, nil)
+	// Only the newest version is fetched.
+	client.MockDistributorClient.EXPECT().GetPackageVersion(context.Background(), "test", "3.0.0").Return(historyEntry("3.0.0", "MIT"), nil)
+	datastore.EXPECT().Save(context.Background(), historyEntry("3.0.0", "MIT")).Return(nil)
+
+	resp, err := s.GetLicenseHistory(context.Background(), historyRequest)
+	require.NoError(t, err)
+	require.Equal(t, []string{"3.0.0"}, historyVersions(resp))
+	require.Equal(t, []string{"1.0.0", "2.0.0"}, resp.UnresolvedVersions)
+}
+
+func TestServer_GetLicenseHistory_overrides(t *testing.T) {
+	store := oslcMocks.NewMockCurationStore(t)
+	s, datastore := newHistoryServer(t, oslcMocks.NewMockDistributorClient(t), func(o *serverOptions) { o.CurationStore = store })
+	datastore.EXPECT().RetrieveVersions(context.Background(), "test", oslc.DistributorNpm).Return([]oslc.Entry{
+		historyEntry("1.0.0", "MIT"),
+		historyEntry("2.0.0", "Unknown"),
+	}, nil)
+	store.EXPECT().ListOverrides(context.Background(), "", oslc.DistributorNpm, "test").Return([]oslc.LicenseOverride{
+		{Distributor: oslc.DistributorNpm, Name: "test", VersionRange: ">=2.0.0", License: "MIT"},
+	}, nil).Once()
+
+	resp, err := s.GetLicenseHistory(context.Background(), historyRequest)
+	require.NoError(t, err)
+	require.False(t, resp.Versions[0].Curated)
+	require.True(t, resp.Versions[1].Curated)
+	require.Equal(t, "MIT", resp.Versions[1].License)
+	require.False(t, resp.Versions[1].LicenseChanged)
+}
+
+func TestServer_GetLicenseHistory_errors(t *testing.T) {
+	t.Run("invalid distributor", func(t *testing.T) {
+		s, _ := newHistoryServer(t, nil)
+		_, err := s.GetLicenseHistory(context.Background(), &oslcv1alpha.GetLicenseHistoryRequest{Name: "test", Distributor: "invalid"})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("missing name", func(t *testing.T) {
+		s, _ := newHistoryServer(t, nil)
+		_, err := s.GetLicenseHistory(context.Background(), &oslcv1alpha.GetLicenseHistoryRequest{Distributor: oslc.DistributorNpm})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("package not found upstream", func(t *testing.T) {
+		client := listingDistributorClient{oslcMocks.NewMockDistributorClient(t), oslcMocks.NewMockVersionLister(t)}
+		s, datastore := newHistoryServer(t, client)
+		datastore.EXPECT().RetrieveVersions(context.Background(), "test", oslc.DistributorNpm).Return(nil, nil)
+		client.MockVersionLister.EXPECT().ListVersions(context.Background(), "test").Return(nil, oslc.DistributorError{Distributor: oslc.DistributorNpm, Err: oslc.ErrNoSuchPackage})
+		_, err := s.GetLicenseHistory(context.Background(), historyRequest)
+		require.Equal(t, codes.NotFound, status.Code(err))
+	})
+	t.Run("no versions", func(t *testing.T) {
+		s, datastore := newHistoryServer(t, oslcMocks.NewMockDistributorClient(t))
+		datastore.EXPECT().RetrieveVersions(context.Background(), "test", oslc.DistributorNpm).Return(nil, assert.AnError)
+		_, err := s.GetLicenseHistory(context.Background(), historyRequest)
+		require.Equal(t, codes.NotFound, status.Code(err))
+	})
+	t.Run("upstream listing fails with stored versions", func(t *testing.T) {
+		client := listingDistributorClient{oslcMocks.NewMockDistributorClient(t), oslcMocks.NewMockVersionLister(t)}
+		s, datastore := newHistoryServer(t, client)
+		datastore.EXPECT().RetrieveVersions(context.Background(), "test", oslc.DistributorNpm).Return([]oslc.Entry{historyEntry("1.0.0", "MIT")}, nil)
+		client.MockVersionLister.EXPECT().ListVersions(context.Background(), "test").Return(nil, oslc.DistributorError{Distributor: oslc.DistributorNpm, Err: assert.AnError})
+		resp, err := s.GetLicenseHistory(context.Background(), historyRequest)
+		require.NoError(t, err)
+		require.Equal(t, []string{"1.0.0"}, historyVersions(resp))
+	})
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		s, datastore := newHistoryServer(t, oslcMocks.NewMockDistributorClient(t))
+		datastore.EXPECT().RetrieveVersions(ctx, "test", oslc.DistributorNpm).Return(nil, context.Canceled)
+		_, err := s.GetLicenseHistory(ctx, historyRequest)
+		require.Equal(t, codes.Canceled, status.Code(err))
+	})
+}
@@ -0,0 +1,75 @@
+package oslc
+
+import (
+	"context"
+	"github.com/chainalysis-oss/oslc"
+	"github.com/chainalysis-oss/oslc/versions"
+	"google.golang.org/grpc/codes"
+	"log/slog"
+	"strings"
+)
+
+// notInOfflineCatalogError records the miss and returns the error for requests a server in offline mode cannot answer
+// from its datastore. Version is the version as requested, so the miss can be resolved in the same way as an online
+// server would have.
+func (s Server) notInOfflineCatalogError(ctx context.Context, distributor, name, version string) error {
+	if s.options.MissRecorder != nil {
+		miss := oslc.PackageMiss{Distributor: distributor, Name: name, Version: version}
+		if err := s.options.MissRecorder.RecordMiss(ctx, miss); err != nil {
+			s.options.Logger.ErrorContext(ctx, "failed to record miss", slog.String("error", err.Error()))
+		}
+	}
+	return statusError(codes.FailedPrecondition, "package is not in the offline catalog",
+		errorInfo(oslc.ReasonNotInOfflineCatalog, distributor))
+}
+
+// resolveStoredVersion is the offline counterpart of resolveVersion. The empty version and "latest" resolve to the
+// highest stored release, as the distributor reports its latest version, and version constraints to the highest stored
+// version that satisfies them. Both are misses if no stored version qualifies. Dist-tags cannot
+// be resolved without the distributor, so they are misses. Single versions are returned unchanged. The returned error
+// is a gRPC status error.
+func (s Server) resolveStoredVersion(ctx context.Context, distributor, name, version string) (string, error) {
+	trimmed := strings.TrimSpace(version)
+	latest := trimmed == "" || trimmed == "latest"
+	if !latest && !versions.IsConstraint(distributor, version) {
+		return version, nil
+	}
+
+	var constraint versions.Constraint
+	if !latest {
+		var err error
+		constraint, err = versions.ParseConstraint(distributor, version)
+		if err != nil {
+			if client, cerr := s.clientFor(distributor); cerr == nil {
+				if _, ok := client.(oslc.DistTagLister); ok {
+					return "", s.notInOfflineCatalogError(ctx, distributor, name, version)
+				}
+			}
+			return "", invalidFieldError("invalid version constraint", oslc.ReasonInvalidVersionConstraint, "version", err.Error(), distributor)
+		}
+	}
+
+	stored, err := s.options.Datastore.RetrieveVersions(ctx, name, distributor)
+	if err != nil {
+		s.options.Logger.ErrorContext(ctx, "failed to retrieve versions from datastore", slog.String("error", err.Error()))
+	}
+	if len(stored) == 0 {
+		return "", s.notInOfflineCatalogError(ctx, distributor, name, version)
+	}
+
+	available := make([]string, len(stored))
+	for i, entry := range stored {
+		available[i] = entry.Version
+	}
+	var resolved string
+	var ok bool
+	if latest {
+		resolved, ok = versions.Latest(distributor, name, available)
+	} else {
+		resolved, ok = constraint.Select(name, available)
+	}
+	if !ok {
+		return "", s.notInOfflineCatalogError(ctx, distributor, name, version)
+	}
+	return resolved, nil
+}
@@ -0,0 +1,241 @@
+package oslc
+
+import (
+	oslcv1alpha "buf.build/gen/go/chainalysis-oss/oslc/protocolbuffers/go/chainalysis_oss/oslc/v1alpha"
+	"context"
+	"github.com/chainalysis-oss/oslc"
+	oslcMocks "github.com/chainalysis-oss/oslc/mocks/oslc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+// newOfflineServer returns a server in offline mode. The distributor client has no expectations, so the test fails if
+// the server calls the distributor.
+func newOfflineServer(t *testing.T) (Server, *oslcMocks.MockDatastore, *oslcMocks.MockMissRecorder) {
+	t.Helper()
+	recorder := oslcMocks.NewMockMissRecorder(t)
+	s, datastore := newHistoryServer(t, newTaggingDistributorClient(t), func(opts *serverOptions) {
+		opts.Offline = true
+		opts.MissRecorder = recorder
+	})
+	return s, datastore, recorder
+}
+
+func requireNotInOfflineCatalog(t *testing.T, err error) {
+	t.Helper()
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	info, _, _ := statusDetails(t, err)
+	require.NotNil(t, info)
+	require.Equal(t, oslc.ReasonNotInOfflineCatalog, info.Reason)
+	require.Equal(t, oslc.DistributorNpm, info.Metadata[oslc.ErrorInfoDistributorKey])
+}
+
+func TestServer_GetPackageInfo_offline(t *testing.T) {
+	s, datastore, _ := newOfflineServer(t)
+	datastore.EXPECT().Retrieve(context.Background(), "test", "1.0.0", oslc.DistributorNpm).
+		Return(historyEntry("1.0.0", "MIT"), nil)
+
+	resp, err := s.GetPackageInfo(context.Background(), &oslcv1alpha.GetPackageInfoRequest{
+		Name:        "test",
+		Version:     "1.0.0",
+		Distributor: oslc.DistributorNpm,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "MIT", resp.License)
+}
+
+func TestServer_GetPackageInfo_offlineMiss(t *testing.T) {
+	s, datastore, recorder := newOfflineServer(t)
+	datastore.EXPECT().Retrieve(context.Background(), "test", "1.0.0", oslc.DistributorNpm).
+		Return(oslc.Entry{}, oslc.ErrDatastoreObjectNotFound)
+	recorder.EXPECT().RecordMiss(context.Background(), oslc.PackageMiss{Distributor: oslc.DistributorNpm, Name: "test", Version: "1.0.0"}).
+		Return(nil)
+
+	_, err := s.GetPackageInfo(context.Background(), &oslcv1alpha.GetPackageInfoRequest{
+		Name:        "test",
+		Version:     "1.0.0",
+		Distributor: oslc.DistributorNpm,
+	})
+	requireNotInOfflineCatalog(t, err)
+}
+
+func TestServer_GetPackageInfo_offlineMissRecorderError(t *testing.T) {
+	s, datastore, recorder := newOfflineServer(t)
+	datastore.EXPECT().Retrieve(context.Background(), "test", "1.0.0", oslc.DistributorNpm).
+		Return(oslc.Entry{}, oslc.ErrDatastoreObjectNotFound)
+	recorder.EXPECT().RecordMiss(context.Background(), oslc.PackageMiss{Distributor: oslc.DistributorNpm, Name: "test", Version: "1.0.0"}).
+		Return(assert.AnError)
+
+	_, err := s.GetPackageInfo(context.Background(), &oslcv1alpha.GetPackageInfoRequest{
+		Name:        "test",
+		Version:     "1.0.0",
+		Distributor: oslc.DistributorNpm,
+	})
+	requireNotInOfflineCatalog(t, err)
+}
+
+func TestServer_GetPackageInfo_offlineDatastoreError(t *testing.T) {
+	s, datastore, _ := newOfflineServer(t)
+	datastore.EXPECT().Retrieve(context.Background(), "test", "1.0.0", oslc.DistributorNpm).
+		Return(oslc.Entry{}, assert.AnError)
+
+	_, err := s.GetPackageInfo(context.Background(), &oslcv1alpha.GetPackageInfoRequest{
+		Name:        "test",
+		Version:     "1.0.0",
+		Distributor: oslc.DistributorNpm,
+	})
+	require.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestServer_GetPackageInfo_offlineVersionConstraint(t *testing.T) {
+	s, datastore, _ := newOfflineServer(t)
+	datastore.EXPECT().RetrieveVersions(context.Background(), "test", oslc.DistributorNpm).
+		Return([]oslc.Entry{historyEntry("4.16.6", "MIT"), historyEntry("4.17.21", "MIT"), historyEntry("5.0.0", "MIT")}, nil)
+	datastore.EXPECT().Retrieve(context.Background(), "test", "4.17.21", oslc.DistributorNpm).
+		Return(historyEntry("4.17.21", "MIT"), nil)
+
+	resp, err := s.GetPackageInfo(context.Background(), &oslcv1alpha.GetPackageInfoRequest{
+		Name:        "test",
+		Version:     "^4.17.0",
+		Distributor: oslc.DistributorNpm,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "4.17.21", resp.Version)
+	require.Equal(t, "^4.17.0", resp.RequestedVersion)
+}
+
+func TestServer_resolveStoredVersion_misses(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		setup   func(datastore *oslcMocks.MockDatastore)
+	}{
+		{
+			name:    "no stored version satisfies the constraint",
+			version: "^9.0.0",
+			setup: func(datastore *oslcMocks.MockDatastore) {
+				datastore.EXPECT().RetrieveVersions(context.Background(), "test", oslc.DistributorNpm).
+					Return([]oslc.Entry{historyEntry("1.0.0", "MIT")}, nil)
+			},
+		},
+		{
+			name:    "no stored versions",
+			version: "^1.0.0",
+			setup: func(datastore *oslcMocks.MockDatastore) {
+				datastore.EXPECT().RetrieveVersions(context.Background(), "test", oslc.DistributorNpm).
+					Return([]oslc.Entry{}, nil)
+			},
+		},
+		{
+			name:    "datastore error",
+			version: "^1.0.0",
+			setup: func(datastore *oslcMocks.MockDatastore) {
+				datastore.EXPECT().RetrieveVersions(context.Background(), "test", oslc.DistributorNpm).
+					Return(nil, assert.AnError)
+			},
+		},
+		{
+			name:    "latest without stored versions",
+			version: "",
+			setup: func(datastore *oslcMocks.MockDatastore) {
+				datastore.EXPECT().RetrieveVersions(context.Background(), "test", oslc.DistributorNpm).
+					Return([]oslc.Entry{}, nil)
+			},
+		},
+		{
+			name:    "latest with only pre-releases stored",
+			version: "latest",
+			setup: func(datastore *oslcMocks.MockDatastore) {
+				datastore.EXPECT().RetrieveVersions(context.Background(), "test", oslc.DistributorNpm).
+					Return([]oslc.Entry{historyEntry("2.0.0-rc.1", "MIT")}, nil)
+			},
+		},
+		{
+			name:    "dist-tag",
+			version: "next",
+			setup:   func(*oslcMocks.MockDatastore) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, datastore, recorder := newOfflineServer(t)
+			tt.setup(datastore)
+			recorder.EXPECT().RecordMiss(context.Background(), oslc.PackageMiss{Distributor: oslc.DistributorNpm, Name: "test", Version: tt.version}).
+				Return(nil)
+
+			_, err := s.resolveStoredVersion(context.Background(), oslc.DistributorNpm, "test", tt.version)
+			requireNotInOfflineCatalog(t, err)
+		})
+	}
+}
+
+func TestServer_resolveStoredVersion_latest(t *testing.T) {
+	for _, version := range []string{"", "latest"} {
+		t.Run(version, func(t *testing.T) {
+			s, datastore, _ := newOfflineServer(t)
+			datastore.EXPECT().RetrieveVersions(context.Background(), "test", oslc.DistributorNpm).
+				Return([]oslc.Entry{historyEntry("1.10.0", "MIT"), historyEntry("2.0.0", "MIT"), historyEntry("2.1.0-rc.1", "MIT"), historyEntry("1.9.0", "MIT")}, nil)
+
+			resolved, err := s.resolveStoredVersion(context.Background(), oslc.DistributorNpm, "test", version)
+			require.NoError(t, err)
+			require.Equal(t, "2.0.0", resolved)
+		})
+	}
+}
+
+func TestServer_resolveStoredVersion_latestPEP440(t *testing.T) {
+	s, datastore, _ := newOfflineServer(t)
+	datastore.EXPECT().RetrieveVersions(context.Background(), "test", oslc.DistributorPypi).
+		Return([]oslc.Entry{historyEntry("1.0.0.post1", "MIT"), historyEntry("1.0.0", "MIT"), historyEntry("1.1.0rc1", "MIT")}, nil)
+
+	resolved, err := s.resolveStoredVersion(context.Background(), oslc.DistributorPypi, "test", "latest")
+	require.NoError(t, err)
+	require.Equal(t, "1.0.0.post1", resolved)
+}
+
+func TestServer_resolveStoredVersion_invalidConstraint(t *testing.T) {
+	s, _, _ := newOfflineServer(t)
+	_, err := s.resolveStoredVersion(context.Background(), oslc.DistributorMaven, "org.example:test", "[1.0")
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestServer_GetLicenseHistory_offline(t *testing.T) {
+	s, datastore, _ := newOfflineServer(t)
+	datastore.EXPECT().RetrieveVersions(context.Background(), "test", oslc.DistributorNpm).
+		Return([]oslc.Entry{historyEntry("1.0.0", "MIT"), historyEntry("2.0.0", "Apache-2.0")}, nil)
+
+	resp, err := s.GetLicenseHistory(context.Background(), &oslcv1alpha.GetLicenseHistoryRequest{
+		Name:        "test",
+		Distributor: oslc.DistributorNpm,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"1.0.0", "2.0.0"}, historyVersions(resp))
+	require.Empty(t, resp.UnresolvedVersions)
+}
+
+func TestServer_GetLicenseHistory_offlineMiss(t *testing.T) {
+	s, datastore, recorder := newOfflineServer(t)
+	datastore.EXPECT().RetrieveVersions(context.Background(), "test", oslc.DistributorNpm).Return([]oslc.Entry{}, nil)
+	recorder.EXPECT().RecordMiss(context.Background(), oslc.PackageMiss{Distributor: oslc.DistributorNpm, Name: "test"}).
+		Return(nil)
+
+	_, err := s.GetLicenseHistory(context.Background(), &oslcv1alpha.GetLicenseHistoryRequest{
+		Name:        "test",
+		Distributor: oslc.DistributorNpm,
+	})
+	requireNotInOfflineCatalog(t, err)
+}
+
+func TestAdminServer_RefreshPackage_offline(t *testing.T) {
+	s, _, _ := newTestAdminServer(t, WithOffline(true), WithNpmClient(oslcMocks.NewMockDistributorClient(t)))
+
+	_, err := s.RefreshPackage(context.Background(), &oslcv1alpha.RefreshPackageRequest{
+		Name:        "test",
+		Version:     "1.0.0",
+		Distributor: oslc.DistributorNpm,
+	})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
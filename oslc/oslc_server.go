@@ -6,6 +6,9 @@ import (
 	"context"
 	"errors"
 	"github.com/chainalysis-oss/oslc"
+	"github.com/chainalysis-oss/oslc/versions"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"log/slog"
@@ -24,23 +27,36 @@ func (e InvalidDistributorError) Error() string {
 	return "invalid distributor: " + e.Distributor
 }
 
-func (s Server) getPackageFromDistributor(ctx context.Context, distributor string, name string, version string) (oslc.Entry, error) {
-	var entry oslc.Entry
-	var err error
+// clientFor returns the client for the provided distributor.
+func (s Server) clientFor(distributor string) (oslc.DistributorClient, error) {
+	var client oslc.DistributorClient
 	switch distributor {
 	case oslc.DistributorPypi:
-		entry, err = s.options.PypiClient.GetPackageVersion(name, version)
+		client = s.options.PypiClient
 	case oslc.DistributorNpm:
-		entry, err = s.options.NpmClient.GetPackageVersion(name, version)
+		client = s.options.NpmClient
 	case oslc.DistributorMaven:
-		entry, err = s.options.MavenClient.GetPackageVersion(name, version)
+		client = s.options.MavenClient
 	case oslc.DistributorCratesIo:
-		entry, err = s.options.CratesIoClient.GetPackageVersion(name, version)
+		client = s.options.CratesIoClient
 	case oslc.DistributorGo:
-		entry, err = s.options.GoClient.GetPackageVersion(name, version)
-	default:
-		return oslc.Entry{}, InvalidDistributorError{Distributor: distributor}
+		client = s.options.GoClient
+	}
+	if validDistributor(distributor) && s.options.DistributorClients != nil {
+		client = s.options.DistributorClients.Client(distributor)
+	}
+	if client == nil {
+		return nil, InvalidDistributorError{Distributor: distributor}
 	}
+	return client, nil
+}
+
+func (s Server) getPackageFromDistributor(ctx context.Context, distributor string, name string, version string) (oslc.Entry, error) {
+	client, err := s.clientFor(distributor)
+	if err != nil {
+		return oslc.Entry{}, err
+	}
+	entry, err := client.GetPackageVersion(ctx, name, version)
 	if err != nil {
 		return oslc.Entry{}, err
 	}
@@ -50,44 +66,171 @@ func (s Server) getPackageFromDistributor(ctx context.Context, distributor strin
 	return entry, nil
 }
 
+// normalizeEntry normalizes the license of an entry fetched from a distributor, keeping the declared license in
+// RawLicense.
 func (s Server) normalizeEntry(ctx context.Context, entry oslc.Entry) oslc.Entry {
-	lic := s.options.LicenseIDNormalizer.NormalizeID(ctx, entry.License)
-	entry.License = lic
+	n := s.normalizeLicense(ctx, entry.License)
+	entry.RawLicense = entry.License
+	entry.License = n.License
+	entry.NormalizationStatus = n.Status
+	entry.LicenseListVersion = n.LicenseListVersion
 	return entry
 }
 
+// normalizeLicense normalizes the provided license. If the configured normalizer does not implement
+// [oslc.LicenseNormalizer], licenses recognized by NormalizeID are reported as exact matches, and the license list
+// version is unknown.
+func (s Server) normalizeLicense(ctx context.Context, license string) oslc.LicenseNormalization {
+	if n, ok := s.options.LicenseIDNormalizer.(oslc.LicenseNormalizer); ok {
+		return n.Normalize(ctx, license)
+	}
+	id := s.options.LicenseIDNormalizer.NormalizeID(ctx, license)
+	if id == "" {
+		return oslc.LicenseNormalization{Status: oslc.LicenseNormalizationFailed}
+	}
+	return oslc.LicenseNormalization{License: id, Status: oslc.LicenseNormalizationExact}
+}
+
+// applyOverrides replaces the license of entry with the license of the most recently updated override that matches the
+// entry's version, if any. The overrides of the request's tenant take precedence over shared overrides. See
+// [Server.applyOverrideList] for details. The returned boolean reports whether an override was applied.
+//
+// Failing to retrieve overrides is logged, and the entry is returned unchanged.
+func (s Server) applyOverrides(ctx context.Context, distributor string, entry oslc.Entry) (oslc.Entry, bool) {
+	overrides, err := s.listOverrides(ctx, distributor, entry.Name)
+	if err != nil {
+		return entry, false
+	}
+	return s.applyOverrideList(ctx, overrides, entry)
+}
+
+// listOverrides returns the overrides for the package with the provided name: those of the request's tenant, if any,
+// followed by the shared overrides. Failing to retrieve overrides is logged and returned. Without a CurationStore, there
+// are no overrides.
+func (s Server) listOverrides(ctx context.Context, distributor, name string) ([]oslc.LicenseOverride, error) {
+	if s.options.CurationStore == nil {
+		return nil, nil
+	}
+	var overrides []oslc.LicenseOverride
+	if tenant := oslc.TenantFromContext(ctx); tenant != "" {
+		tenantOverrides, err := s.options.CurationStore.ListOverrides(ctx, tenant, distributor, name)
+		if err != nil {
+			s.options.Logger.ErrorContext(ctx, "failed to retrieve license overrides", slog.String("error", err.Error()))
+			return nil, err
+		}
+		overrides = tenantOverrides
+	}
+	shared, err := s.options.CurationStore.ListOverrides(ctx, "", distributor, name)
+	if err != nil {
+		s.options.Logger.ErrorContext(ctx, "failed to retrieve license overrides", slog.String("error", err.Error()))
+		return nil, err
+	}
+	return append(overrides, shared...), nil
+}
+
+// applyOverrideList applies the first override in overrides that matches the entry's version, and reports whether one
+// was applied. The override license is normalized in the same way as licenses found in the distributor's metadata. If
+// the normalizer does not recognize it, it is used as-is, since overrides are considered authoritative. The raw license
+// and normalization status of the entry are left unchanged, as they describe the license declared by the distributor.
+func (s Server) applyOverrideList(ctx context.Context, overrides []oslc.LicenseOverride, entry oslc.Entry) (oslc.Entry, bool) {
+	for _, override := range overrides {
+		ok, err := versions.MatchRange(override.Distributor, override.VersionRange, entry.Version)
+		if err != nil {
+			s.options.Logger.WarnContext(ctx, "ignoring license override with invalid version range",
+				slog.String("version_range", override.VersionRange), slog.String("error", err.Error()))
+			continue
+		}
+		if !ok {
+			continue
+		}
+		license := s.normalizeLicense(ctx, override.License).License
+		if license == "" {
+			license = override.License
+		}
+		entry.License = license
+		return entry, true
+	}
+	return entry, false
+}
+
 func (s Server) GetPackageInfo(ctx context.Context, request *oslcv1alpha.GetPackageInfoRequest) (*oslcv1alpha.GetPackageInfoResponse, error) {
+	resp, err := s.getPackageInfo(ctx, request)
+	if err != nil || resp.Job != nil {
+		return resp, err
+	}
+	resp.Policy = s.evaluatePolicy(ctx, request.Distributor, resp)
+	return resp, nil
+}
+
+// getPackageInfo answers a GetPackageInfo request, without evaluating the policy of the request's tenant.
+func (s Server) getPackageInfo(ctx context.Context, request *oslcv1alpha.GetPackageInfoRequest) (*oslcv1alpha.GetPackageInfoResponse, error) {
 	if !validDistributor(request.Distributor) {
-		return nil, status.Error(codes.InvalidArgument, "invalid distributor")
+		return nil, invalidDistributorError()
+	}
+	if request.Name == "" {
+		return nil, missingNameError(request.Distributor)
+	}
+
+	// The span is started by the gRPC server's tracing interceptor. When tracing is disabled, this is a no-op span.
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(
+		attribute.String("oslc.distributor", request.Distributor),
+		attribute.String("oslc.package.name", request.Name),
+		attribute.String("oslc.package.version", request.Version),
+	)
+
+	// In offline mode, every request is answered from the datastore, so there is nothing to wait for.
+	if request.Async && !s.options.Offline {
+		return s.getPackageInfoAsync(ctx, request)
+	}
+
+	version, err := s.resolveVersion(ctx, request.Distributor, request.Name, request.Version)
+	if err != nil {
+		return nil, err
+	}
+	if version != request.Version {
+		span.SetAttributes(attribute.String("oslc.package.resolved_version", version))
 	}
 
 	var entry oslc.Entry
-	var err error
-	entry, err = s.options.Datastore.Retrieve(ctx, request.Name, request.Version, request.Distributor)
+	entry, err = s.options.Datastore.Retrieve(ctx, request.Name, version, request.Distributor)
+	span.SetAttributes(attribute.Bool("oslc.cache_hit", err == nil))
 	if err != nil {
 		if errors.Is(err, oslc.ErrDatastoreObjectNotFound) {
+			if s.options.Offline {
+				return nil, s.notInOfflineCatalogError(ctx, request.Distributor, request.Name, request.Version)
+			}
 			s.options.Logger.DebugContext(ctx, "package not found in datastore, querying upstream")
 		} else {
-			s.options.Logger.Error("failed to retrieve from datastore", slog.String("error", err.Error()))
+			s.options.Logger.ErrorContext(ctx, "failed to retrieve from datastore", slog.String("error", err.Error()))
+			if s.options.Offline {
+				return nil, status.Error(codes.Internal, "internal server error")
+			}
 		}
 
-		entry, err = s.getPackageFromDistributor(ctx, request.Distributor, request.Name, request.Version)
+		entry, err = s.getPackageFromDistributor(ctx, request.Distributor, request.Name, version)
 
 		if err != nil {
-			if errors.Is(err, oslc.ErrNoSuchPackage) {
-				return nil, status.Error(codes.NotFound, "package not found")
-			}
-			if errors.Is(err, oslc.ErrVersionNotFound) {
-				return nil, status.Error(codes.NotFound, "version not found")
-			}
-			s.options.Logger.Error("failed to retrieve from upstream", slog.String("error", err.Error()))
-			return nil, status.Error(codes.Internal, "internal server error")
+			return nil, s.upstreamErrorToStatus(ctx, err)
 		}
 
+		s.detectPackageChange(ctx, request.Distributor, entry)
 		if err := s.options.Datastore.Save(ctx, entry); err != nil {
-			s.options.Logger.Error("failed to save to datastore", slog.String("error", err.Error()))
+			s.options.Logger.ErrorContext(ctx, "failed to save to datastore", slog.String("error", err.Error()))
 		}
 	}
+
+	entry, curated := s.applyOverrides(ctx, request.Distributor, entry)
+	span.SetAttributes(attribute.Bool("oslc.curated", curated))
+
+	resp := entryToResponse(entry, curated)
+	if version != request.Version {
+		resp.RequestedVersion = request.Version
+	}
+	return resp, nil
+}
+
+func entryToResponse(entry oslc.Entry, curated bool) *oslcv1alpha.GetPackageInfoResponse {
 	dps := make([]*oslcv1alpha.DistributionPoint, len(entry.DistributionPoints))
 	for i, dp := range entry.DistributionPoints {
 		dps[i] = &oslcv1alpha.DistributionPoint{
@@ -97,11 +240,29 @@ func (s Server) GetPackageInfo(ctx context.Context, request *oslcv1alpha.GetPack
 		}
 	}
 	return &oslcv1alpha.GetPackageInfoResponse{
-		Name:               entry.Name,
-		Version:            entry.Version,
-		License:            entry.License,
-		DistributionPoints: dps,
-	}, nil
+		Name:                entry.Name,
+		Version:             entry.Version,
+		License:             entry.License,
+		DistributionPoints:  dps,
+		Curated:             curated,
+		RawLicense:          entry.RawLicense,
+		NormalizationStatus: normalizationStatusToProto(entry.NormalizationStatus),
+		LicenseListVersion:  entry.LicenseListVersion,
+	}
+}
+
+func normalizationStatusToProto(status oslc.LicenseNormalizationStatus) oslcv1alpha.LicenseNormalizationStatus {
+	switch status {
+	case oslc.LicenseNormalizationExact:
+		return oslcv1alpha.LicenseNormalizationStatus_LICENSE_NORMALIZATION_STATUS_EXACT
+	case oslc.LicenseNormalizationAlias:
+		return oslcv1alpha.LicenseNormalizationStatus_LICENSE_NORMALIZATION_STATUS_ALIAS
+	case oslc.LicenseNormalizationExpression:
+		return oslcv1alpha.LicenseNormalizationStatus_LICENSE_NORMALIZATION_STATUS_EXPRESSION
+	case oslc.LicenseNormalizationFailed:
+		return oslcv1alpha.LicenseNormalizationStatus_LICENSE_NORMALIZATION_STATUS_FAILED
+	}
+	return oslcv1alpha.LicenseNormalizationStatus_LICENSE_NORMALIZATION_STATUS_UNSPECIFIED
 }
 
 func NewServer(options ...ServerOption) (*Server, error) {
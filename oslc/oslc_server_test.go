@@ -7,6 +7,9 @@ import (
 	oslcMocks "github.com/chainalysis-oss/oslc/mocks/oslc"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"io"
 	"log/slog"
 	"testing"
@@ -65,9 +68,11 @@ func Test_validDistributor(t *testing.T) {
 }
 
 var pypiRequestsEntry = oslc.Entry{
-	Name:    "requests",
-	Version: "2.32.3",
-	License: "Apache-2.0",
+	Name:                "requests",
+	Version:             "2.32.3",
+	License:             "Apache-2.0",
+	RawLicense:          "Apache-2.0",
+	NormalizationStatus: oslc.LicenseNormalizationExact,
 	DistributionPoints: []oslc.DistributionPoint{{
 		Name:        "requests",
 		URL:         "https://pypi.org/project/requests/",
@@ -82,9 +87,11 @@ var pypiRequestsGetPackageInfoRequest = oslcv1alpha.GetPackageInfoRequest{
 }
 
 var pypiRequestsGetPackageInfoResponse = oslcv1alpha.GetPackageInfoResponse{
-	Name:    "requests",
-	Version: "2.32.3",
-	License: "Apache-2.0",
+	Name:                "requests",
+	Version:             "2.32.3",
+	License:             "Apache-2.0",
+	RawLicense:          "Apache-2.0",
+	NormalizationStatus: oslcv1alpha.LicenseNormalizationStatus_LICENSE_NORMALIZATION_STATUS_EXACT,
 	DistributionPoints: []*oslcv1alpha.DistributionPoint{{
 		Name:        "requests",
 		Url:         "https://pypi.org/project/requests/",
@@ -92,9 +99,11 @@ var pypiRequestsGetPackageInfoResponse = oslcv1alpha.GetPackageInfoResponse{
 	}},
 }
 var npmTestEntry = oslc.Entry{
-	Name:    "test",
-	Version: "3.3.0",
-	License: "MIT",
+	Name:                "test",
+	Version:             "3.3.0",
+	License:             "MIT",
+	RawLicense:          "MIT",
+	NormalizationStatus: oslc.LicenseNormalizationExact,
 	DistributionPoints: []oslc.DistributionPoint{{
 		Name:        "test",
 		URL:         "https://www.npmjs.com/package/test",
@@ -109,9 +118,11 @@ var npmTestGetPackageInfoRequest = oslcv1alpha.GetPackageInfoRequest{
 }
 
 var npmTestGetPackageInfoResponse = oslcv1alpha.GetPackageInfoResponse{
-	Name:    "test",
-	Version: "3.3.0",
-	License: "MIT",
+	Name:                "test",
+	Version:             "3.3.0",
+	License:             "MIT",
+	RawLicense:          "MIT",
+	NormalizationStatus: oslcv1alpha.LicenseNormalizationStatus_LICENSE_NORMALIZATION_STATUS_EXACT,
 	DistributionPoints: []*oslcv1alpha.DistributionPoint{{
 		Name:        "test",
 		Url:         "https://www.npmjs.com/package/test",
@@ -120,9 +131,11 @@ var npmTestGetPackageInfoResponse = oslcv1alpha.GetPackageInfoResponse{
 }
 
 var mavenLog4jEntry = oslc.Entry{
-	Name:    "org.apache.logging.log4j:log4j",
-	Version: "3.0.0-beta2",
-	License: "Apache-2.0",
+	Name:                "org.apache.logging.log4j:log4j",
+	Version:             "3.0.0-beta2",
+	License:             "Apache-2.0",
+	RawLicense:          "Apache-2.0",
+	NormalizationStatus: oslc.LicenseNormalizationExact,
 	DistributionPoints: []oslc.DistributionPoint{{
 		Name:        "org.apache.logging.log4j:log4j",
 		URL:         "https://central.sonatype.com/artifact/org.apache.logging.log4j/log4j",
@@ -137,9 +150,11 @@ var mavenLog4jGetPackageInfoRequest = oslcv1alpha.GetPackageInfoRequest{
 }
 
 var mavenLog4jGetPackageInfoResponse = oslcv1alpha.GetPackageInfoResponse{
-	Name:    "org.apache.logging.log4j:log4j",
-	Version: "3.0.0-beta2",
-	License: "Apache-2.0",
+	Name:                "org.apache.logging.log4j:log4j",
+	Version:             "3.0.0-beta2",
+	License:             "Apache-2.0",
+	RawLicense:          "Apache-2.0",
+	NormalizationStatus: oslcv1alpha.LicenseNormalizationStatus_LICENSE_NORMALIZATION_STATUS_EXACT,
 	DistributionPoints: []*oslcv1alpha.DistributionPoint{{
 		Name:        "org.apache.logging.log4j:log4j",
 		Url:         "https://central.sonatype.com/artifact/org.apache.logging.log4j/log4j",
@@ -148,9 +163,11 @@ var mavenLog4jGetPackageInfoResponse = oslcv1alpha.GetPackageInfoResponse{
 }
 
 var cratesIoSnarkVMEntry = oslc.Entry{
-	Name:    "snarkvm-marlin",
-	Version: "0.8.0",
-	License: "GPL-3.0",
+	Name:                "snarkvm-marlin",
+	Version:             "0.8.0",
+	License:             "GPL-3.0",
+	RawLicense:          "GPL-3.0",
+	NormalizationStatus: oslc.LicenseNormalizationExact,
 	DistributionPoints: []oslc.DistributionPoint{{
 		Name:        "snarkvm-marlin",
 		URL:         "https://crates.io/crates/snarkvm-marlin",
@@ -165,9 +182,11 @@ var cratesIoSnarkVMGetPackageInfoRequest = oslcv1alpha.GetPackageInfoRequest{
 }
 
 var cratesIoSnarkVMGetPackageInfoResponse = oslcv1alpha.GetPackageInfoResponse{
-	Name:    "snarkvm-marlin",
-	Version: "0.8.0",
-	License: "GPL-3.0",
+	Name:                "snarkvm-marlin",
+	Version:             "0.8.0",
+	License:             "GPL-3.0",
+	RawLicense:          "GPL-3.0",
+	NormalizationStatus: oslcv1alpha.LicenseNormalizationStatus_LICENSE_NORMALIZATION_STATUS_EXACT,
 	DistributionPoints: []*oslcv1alpha.DistributionPoint{{
 		Name:        "snarkvm-marlin",
 		Url:         "https://crates.io/crates/snarkvm-marlin",
@@ -176,9 +195,11 @@ var cratesIoSnarkVMGetPackageInfoResponse = oslcv1alpha.GetPackageInfoResponse{
 }
 
 var goOslcEntry = oslc.Entry{
-	Name:    "github.com/chainalysis-oss/oslc",
-	Version: "v0.3.0",
-	License: "MIT",
+	Name:                "github.com/chainalysis-oss/oslc",
+	Version:             "v0.3.0",
+	License:             "MIT",
+	RawLicense:          "MIT",
+	NormalizationStatus: oslc.LicenseNormalizationExact,
 	DistributionPoints: []oslc.DistributionPoint{{
 		Name:        "github.com/chainalysis-oss/oslc",
 		URL:         "https://proxy.golang.org/github.com/chainalysis-oss/oslc/@v/v0.3.0.zip",
@@ -193,9 +214,11 @@ var goOslcGetPackageInfoRequest = oslcv1alpha.GetPackageInfoRequest{
 }
 
 var goOslcGetPackageInfoResponse = oslcv1alpha.GetPackageInfoResponse{
-	Name:    "github.com/chainalysis-oss/oslc",
-	Version: "v0.3.0",
-	License: "MIT",
+	Name:                "github.com/chainalysis-oss/oslc",
+	Version:             "v0.3.0",
+	License:             "MIT",
+	RawLicense:          "MIT",
+	NormalizationStatus: oslcv1alpha.LicenseNormalizationStatus_LICENSE_NORMALIZATION_STATUS_EXACT,
 	DistributionPoints: []*oslcv1alpha.DistributionPoint{{
 		Name:        "github.com/chainalysis-oss/oslc",
 		Url:         "https://proxy.golang.org/github.com/chainalysis-oss/oslc/@v/v0.3.0.zip",
@@ -251,7 +274,7 @@ func TestServer_GetPackageInfo(t *testing.T) {
 					}(),
 					PypiClient: func() oslc.DistributorClient {
 						mockClient := oslcMocks.NewMockDistributorClient(t)
-						mockClient.EXPECT().GetPackageVersion(pypiRequestsGetPackageInfoRequest.Name, pypiRequestsGetPackageInfoRequest.Version).
+						mockClient.EXPECT().GetPackageVersion(context.Background(), pypiRequestsGetPackageInfoRequest.Name, pypiRequestsGetPackageInfoRequest.Version).
 							Return(pypiRequestsEntry, nil)
 						return mockClient
 					}(),
@@ -286,7 +309,7 @@ func TestServer_GetPackageInfo(t *testing.T) {
 					Logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
 					PypiClient: func() oslc.DistributorClient {
 						mockClient := oslcMocks.NewMockDistributorClient(t)
-						mockClient.EXPECT().GetPackageVersion(pypiRequestsGetPackageInfoRequest.Name, pypiRequestsGetPackageInfoRequest.Version).
+						mockClient.EXPECT().GetPackageVersion(context.Background(), pypiRequestsGetPackageInfoRequest.Name, pypiRequestsGetPackageInfoRequest.Version).
 							Return(pypiRequestsEntry, nil)
 						return mockClient
 					}(),
@@ -320,7 +343,7 @@ func TestServer_GetPackageInfo(t *testing.T) {
 					Logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
 					PypiClient: func() oslc.DistributorClient {
 						mockClient := oslcMocks.NewMockDistributorClient(t)
-						mockClient.EXPECT().GetPackageVersion(pypiRequestsGetPackageInfoRequest.Name, pypiRequestsGetPackageInfoRequest.Version).
+						mockClient.EXPECT().GetPackageVersion(context.Background(), pypiRequestsGetPackageInfoRequest.Name, pypiRequestsGetPackageInfoRequest.Version).
 							Return(pypiRequestsEntry, nil)
 						return mockClient
 					}(),
@@ -351,7 +374,7 @@ func TestServer_GetPackageInfo(t *testing.T) {
 					}(),
 					PypiClient: func() oslc.DistributorClient {
 						mockClient := oslcMocks.NewMockDistributorClient(t)
-						mockClient.EXPECT().GetPackageVersion(pypiRequestsGetPackageInfoRequest.Name, pypiRequestsGetPackageInfoRequest.Version).
+						mockClient.EXPECT().GetPackageVersion(context.Background(), pypiRequestsGetPackageInfoRequest.Name, pypiRequestsGetPackageInfoRequest.Version).
 							Return(oslc.Entry{}, assert.AnError)
 						return mockClient
 					}(),
@@ -379,7 +402,7 @@ func TestServer_GetPackageInfo(t *testing.T) {
 					}(),
 					PypiClient: func() oslc.DistributorClient {
 						mockClient := oslcMocks.NewMockDistributorClient(t)
-						mockClient.EXPECT().GetPackageVersion(pypiRequestsGetPackageInfoRequest.Name, pypiRequestsGetPackageInfoRequest.Version).
+						mockClient.EXPECT().GetPackageVersion(context.Background(), pypiRequestsGetPackageInfoRequest.Name, pypiRequestsGetPackageInfoRequest.Version).
 							Return(pypiRequestsEntry, nil)
 						return mockClient
 					}(),
@@ -413,7 +436,7 @@ func TestServer_GetPackageInfo(t *testing.T) {
 					}(),
 					NpmClient: func() oslc.DistributorClient {
 						mockClient := oslcMocks.NewMockDistributorClient(t)
-						mockClient.EXPECT().GetPackageVersion(npmTestGetPackageInfoRequest.Name, npmTestGetPackageInfoRequest.Version).
+						mockClient.EXPECT().GetPackageVersion(context.Background(), npmTestGetPackageInfoRequest.Name, npmTestGetPackageInfoRequest.Version).
 							Return(npmTestEntry, nil)
 						return mockClient
 					}(),
@@ -447,7 +470,7 @@ func TestServer_GetPackageInfo(t *testing.T) {
 					}(),
 					MavenClient: func() oslc.DistributorClient {
 						mockClient := oslcMocks.NewMockDistributorClient(t)
-						mockClient.EXPECT().GetPackageVersion(mavenLog4jGetPackageInfoRequest.Name, mavenLog4jGetPackageInfoRequest.Version).
+						mockClient.EXPECT().GetPackageVersion(context.Background(), mavenLog4jGetPackageInfoRequest.Name, mavenLog4jGetPackageInfoRequest.Version).
 							Return(mavenLog4jEntry, nil)
 						return mockClient
 					}(),
@@ -481,7 +504,7 @@ func TestServer_GetPackageInfo(t *testing.T) {
 					}(),
 					CratesIoClient: func() oslc.DistributorClient {
 						mockClient := oslcMocks.NewMockDistributorClient(t)
-						mockClient.EXPECT().GetPackageVersion(cratesIoSnarkVMGetPackageInfoRequest.Name, cratesIoSnarkVMGetPackageInfoRequest.Version).
+						mockClient.EXPECT().GetPackageVersion(context.Background(), cratesIoSnarkVMGetPackageInfoRequest.Name, cratesIoSnarkVMGetPackageInfoRequest.Version).
 							Return(cratesIoSnarkVMEntry, nil)
 						return mockClient
 					}(),
@@ -515,7 +538,7 @@ func TestServer_GetPackageInfo(t *testing.T) {
 					}(),
 					GoClient: func() oslc.DistributorClient {
 						mockClient := oslcMocks.NewMockDistributorClient(t)
-						mockClient.EXPECT().GetPackageVersion(goOslcGetPackageInfoRequest.Name, goOslcGetPackageInfoRequest.Version).
+						mockClient.EXPECT().GetPackageVersion(context.Background(), goOslcGetPackageInfoRequest.Name, goOslcGetPackageInfoRequest.Version).
 							Return(goOslcEntry, nil)
 						return mockClient
 					}(),
@@ -576,3 +599,153 @@ func TestInvalidDistributorError_Error(t *testing.T) {
 	ide := InvalidDistributorError{Distributor: "invalid"}
 	require.Equal(t, "invalid distributor: invalid", ide.Error())
 }
+
+func TestServer_GetPackageInfo_spanAttributes(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	ctx, span := tp.Tracer("test").Start(context.Background(), "test")
+
+	mockDatastore := oslcMocks.NewMockDatastore(t)
+	mockDatastore.EXPECT().Retrieve(ctx, pypiRequestsGetPackageInfoRequest.Name, pypiRequestsGetPackageInfoRequest.Version, oslc.DistributorPypi).
+		Return(pypiRequestsEntry, nil)
+	s := Server{
+		options: &serverOptions{
+			Datastore: mockDatastore,
+		},
+	}
+	_, err := s.GetPackageInfo(ctx, &pypiRequestsGetPackageInfoRequest)
+	require.NoError(t, err)
+	span.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	require.Contains(t, spans[0].Attributes(), attribute.String("oslc.distributor", oslc.DistributorPypi))
+	require.Contains(t, spans[0].Attributes(), attribute.String("oslc.package.name", pypiRequestsGetPackageInfoRequest.Name))
+	require.Contains(t, spans[0].Attributes(), attribute.String("oslc.package.version", pypiRequestsGetPackageInfoRequest.Version))
+	require.Contains(t, spans[0].Attributes(), attribute.Bool("oslc.cache_hit", true))
+}
+
+func TestServer_GetPackageInfo_overrides(t *testing.T) {
+	tests := []struct {
+		name        string
+		overrides   []oslc.LicenseOverride
+		storeErr    error
+		normalized  string
+		wantLicense string
+		wantCurated bool
+	}{
+		{
+			name:        "no overrides",
+			wantLicense: pypiRequestsEntry.License,
+		},
+		{
+			name:        "store error",
+			storeErr:    assert.AnError,
+			wantLicense: pypiRequestsEntry.License,
+		},
+		{
+			name: "version outside range",
+			overrides: []oslc.LicenseOverride{
+				{Distributor: oslc.DistributorPypi, Name: "requests", VersionRange: "<2.0.0", License: "MIT"},
+			},
+			wantLicense: pypiRequestsEntry.License,
+		},
+		{
+			name: "invalid range is skipped",
+			overrides: []oslc.LicenseOverride{
+				{Distributor: oslc.DistributorPypi, Name: "requests", VersionRange: ">=", License: "MIT"},
+				{Distributor: oslc.DistributorPypi, Name: "requests", VersionRange: "*", License: "mit"},
+			},
+			normalized:  "MIT",
+			wantLicense: "MIT",
+			wantCurated: true,
+		},
+		{
+			name: "unrecognized license is used as-is",
+			overrides: []oslc.LicenseOverride{
+				{Distributor: oslc.DistributorPypi, Name: "requests", VersionRange: "2.32.3", License: "MIT OR Apache-2.0"},
+			},
+			wantLicense: "MIT OR Apache-2.0",
+			wantCurated: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDatastore := oslcMocks.NewMockDatastore(t)
+			mockDatastore.EXPECT().Retrieve(context.Background(), "requests", "2.32.3", oslc.DistributorPypi).Return(pypiRequestsEntry, nil)
+			mockStore := oslcMocks.NewMockCurationStore(t)
+			mockStore.EXPECT().ListOverrides(context.Background(), "", oslc.DistributorPypi, "requests").Return(tt.overrides, tt.storeErr)
+			mockNormalizer := oslcMocks.NewMockLicenseIDNormalizer(t)
+			if tt.wantCurated {
+				mockNormalizer.EXPECT().NormalizeID(context.Background(), tt.overrides[len(tt.overrides)-1].License).Return(tt.normalized)
+			}
+			s := Server{
+				options: &serverOptions{
+					Logger:              slog.New(slog.NewTextHandler(io.Discard, nil)),
+					Datastore:           mockDatastore,
+					CurationStore:       mockStore,
+					LicenseIDNormalizer: mockNormalizer,
+				},
+			}
+			resp, err := s.GetPackageInfo(context.Background(), &pypiRequestsGetPackageInfoRequest)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantLicense, resp.License)
+			require.Equal(t, tt.wantCurated, resp.Curated)
+		})
+	}
+}
+
+func TestServer_normalizeEntry(t *testing.T) {
+	upstream := oslc.Entry{Name: "requests", Version: "2.32.3", License: "BSD License"}
+	t.Run("license normalizer", func(t *testing.T) {
+		mockNormalizer := oslcMocks.NewMockLicenseNormalizer(t)
+		mockNormalizer.EXPECT().Normalize(context.Background(), "BSD License").Return(oslc.LicenseNormalization{
+			Status:             oslc.LicenseNormalizationFailed,
+			LicenseListVersion: "3.25.0",
+		})
+		s := Server{options: &serverOptions{LicenseIDNormalizer: mockNormalizer}}
+		entry := s.normalizeEntry(context.Background(), upstream)
+		require.Equal(t, oslc.Entry{
+			Name:                "requests",
+			Version:             "2.32.3",
+			RawLicense:          "BSD License",
+			NormalizationStatus: oslc.LicenseNormalizationFailed,
+			LicenseListVersion:  "3.25.0",
+		}, entry)
+	})
+	t.Run("license id normalizer", func(t *testing.T) {
+		mockNormalizer := oslcMocks.NewMockLicenseIDNormalizer(t)
+		mockNormalizer.EXPECT().NormalizeID(context.Background(), "BSD License").Return("")
+		s := Server{options: &serverOptions{LicenseIDNormalizer: mockNormalizer}}
+		entry := s.normalizeEntry(context.Background(), upstream)
+		require.Equal(t, "", entry.License)
+		require.Equal(t, "BSD License", entry.RawLicense)
+		require.Equal(t, oslc.LicenseNormalizationFailed, entry.NormalizationStatus)
+	})
+}
+
+func Test_entryToResponse_normalization(t *testing.T) {
+	tests := []struct {
+		status oslc.LicenseNormalizationStatus
+		want   oslcv1alpha.LicenseNormalizationStatus
+	}{
+		{"", oslcv1alpha.LicenseNormalizationStatus_LICENSE_NORMALIZATION_STATUS_UNSPECIFIED},
+		{oslc.LicenseNormalizationExact, oslcv1alpha.LicenseNormalizationStatus_LICENSE_NORMALIZATION_STATUS_EXACT},
+		{oslc.LicenseNormalizationAlias, oslcv1alpha.LicenseNormalizationStatus_LICENSE_NORMALIZATION_STATUS_ALIAS},
+		{oslc.LicenseNormalizationExpression, oslcv1alpha.LicenseNormalizationStatus_LICENSE_NORMALIZATION_STATUS_EXPRESSION},
+		{oslc.LicenseNormalizationFailed, oslcv1alpha.LicenseNormalizationStatus_LICENSE_NORMALIZATION_STATUS_FAILED},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			resp := entryToResponse(oslc.Entry{
+				License:             "MIT OR Apache-2.0",
+				RawLicense:          "MIT/Apache-2.0",
+				NormalizationStatus: tt.status,
+				LicenseListVersion:  "3.25.0",
+			}, false)
+			require.Equal(t, tt.want, resp.NormalizationStatus)
+			require.Equal(t, "MIT/Apache-2.0", resp.RawLicense)
+			require.Equal(t, "3.25.0", resp.LicenseListVersion)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package oslc
+
+import (
+	oslcv1alpha "buf.build/gen/go/chainalysis-oss/oslc/protocolbuffers/go/chainalysis_oss/oslc/v1alpha"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"github.com/chainalysis-oss/oslc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"log/slog"
+)
+
+// The page sizes of SearchPackages.
+const (
+	searchDefaultPageSize = 50
+	searchMaxPageSize     = 500
+)
+
+var errInvalidPageToken = errors.New("invalid page token")
+
+// encodePageToken returns an opaque page token for the cursor.
+func encodePageToken(cursor oslc.SearchCursor) string {
+	b, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodePageToken returns the cursor encoded in a page token returned by encodePageToken.
+func decodePageToken(token string) (oslc.SearchCursor, error) {
+	var cursor oslc.SearchCursor
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return cursor, errInvalidPageToken
+	}
+	if err := json.Unmarshal(b, &cursor); err != nil {
+		return cursor, errInvalidPageToken
+	}
+	return cursor, nil
+}
+
+func (s Server) SearchPackages(ctx context.Context, request *oslcv1alpha.SearchPackagesRequest) (*oslcv1alpha.SearchPackagesResponse, error) {
+	if request.Distributor != "" && !validDistributor(request.Distributor) {
+		return nil, invalidDistributorError()
+	}
+	if request.PageSize < 0 {
+		return nil, status.Error(codes.InvalidArgument, "page size must not be negative")
+	}
+	pageSize := int(request.PageSize)
+	if pageSize == 0 {
+		pageSize = searchDefaultPageSize
+	}
+	pageSize = min(pageSize, searchMaxPageSize)
+
+	query := oslc.SearchQuery{
+		Distributor:         request.Distributor,
+		NamePrefix:          request.NamePrefix,
+		NameContains:        request.NameContains,
+		License:             request.License,
+		LicenseInExpression: request.LicenseInExpression,
+		// One more entry than requested is retrieved to determine whether there is a next page.
+		Limit: pageSize + 1,
+	}
+	if request.FetchedAfter != nil {
+		query.FetchedAfter = request.FetchedAfter.AsTime()
+	}
+	if request.FetchedBefore != nil {
+		query.FetchedBefore = request.FetchedBefore.AsTime()
+	}
+	if request.PageToken != "" {
+		cursor, err := decodePageToken(request.PageToken)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		query.After = &cursor
+	}
+
+	entries, err := s.options.Datastore.Search(ctx, query)
+	if err != nil {
+		s.options.Logger.ErrorContext(ctx, "failed to search datastore", slog.String("error", err.Error()))
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+
+	resp := &oslcv1alpha.SearchPackagesResponse{}
+	if len(entries) > pageSize {
+		entries = entries[:pageSize]
+		resp.NextPageToken = encodePageToken(entries[pageSize-1].Cursor())
+	}
+	resp.Packages = make([]*oslcv1alpha.CatalogPackage, len(entries))
+	for i, e := range entries {
+		info := entryToResponse(e.Entry, false)
+		resp.Packages[i] = &oslcv1alpha.CatalogPackage{
+			Name:               e.Name,
+			Version:            e.Version,
+			Distributor:        e.Distributor,
+			License:            e.License,
+			DistributionPoints: info.DistributionPoints,
+			FetchTime:          timestamppb.New(e.FetchedAt),
+		}
+		s.applyTenantLayersToCatalogPackage(ctx, resp.Packages[i])
+	}
+	return resp, nil
+}
+
+// applyTenantLayersToCatalogPackage applies the license overrides, both those of the request's tenant and the shared
+// ones, and the policy of the tenant to a search result, so that the tenant sees the license GetPackageInfo returns.
+// Results of requests not made for a tenant are left as in the catalog.
+func (s Server) applyTenantLayersToCatalogPackage(ctx context.Context, pkg *oslcv1alpha.CatalogPackage) {
+	if oslc.TenantFromContext(ctx) == "" {
+		return
+	}
+	entry, curated := s.applyOverrides(ctx, pkg.Distributor, oslc.Entry{Name: pkg.Name, Version: pkg.Version, License: pkg.License})
+	pkg.License = entry.License
+	pkg.Curated = curated
+	pkg.Policy = s.evaluatePolicy(ctx, pkg.Distributor, &oslcv1alpha.GetPackageInfoResponse{Name: pkg.Name, Version: pkg.Version, License: pkg.License})
+}
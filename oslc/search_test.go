@@ -0,0 +1,141 @@
+package oslc
+
+import (
+	oslcv1alpha "buf.build/gen/go/chainalysis-oss/oslc/protocolbuffers/go/chainalysis_oss/oslc/v1alpha"
+	"context"
+	"github.com/chainalysis-oss/oslc"
+	oslcMocks "github.com/chainalysis-oss/oslc/mocks/oslc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func newSearchServer(t *testing.T) (Server, *oslcMocks.MockDatastore) {
+	t.Helper()
+	datastore := oslcMocks.NewMockDatastore(t)
+	return Server{options: &serverOptions{
+		Logger:    slog.New(slog.NewTextHandler(io.Discard, nil)),
+		Datastore: datastore,
+	}}, datastore
+}
+
+func storedEntry(name, version string) oslc.StoredEntry {
+	return oslc.StoredEntry{
+		Entry: oslc.Entry{
+			Name:    name,
+			Version: version,
+			License: "MIT",
+			DistributionPoints: []oslc.DistributionPoint{{
+				Name:        name,
+				URL:         "https://example.com/" + name,
+				Distributor: oslc.DistributorCratesIo,
+			}},
+		},
+		Distributor: oslc.DistributorCratesIo,
+		FetchedAt:   time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+}
+
+func TestPageToken(t *testing.T) {
+	cursor := oslc.SearchCursor{Distributor: oslc.DistributorNpm, Name: "@types/node", Version: "1.0.0"}
+	decoded, err := decodePageToken(encodePageToken(cursor))
+	require.NoError(t, err)
+	require.Equal(t, cursor, decoded)
+
+	_, err = decodePageToken("!")
+	require.ErrorIs(t, err, errInvalidPageToken)
+	_, err = decodePageToken("bm90IGpzb24")
+	require.ErrorIs(t, err, errInvalidPageToken)
+}
+
+func TestServer_SearchPackages(t *testing.T) {
+	s, datastore := newSearchServer(t)
+	empty := ""
+	after := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	datastore.EXPECT().Search(context.Background(), oslc.SearchQuery{
+		Distributor:  oslc.DistributorCratesIo,
+		NamePrefix:   "serde",
+		License:      &empty,
+		FetchedAfter: after,
+		Limit:        3,
+	}).Return([]oslc.StoredEntry{
+		storedEntry("serde", "1.0.0"),
+		storedEntry("serde", "1.0.1"),
+		storedEntry("serde_json", "1.0.0"),
+	}, nil)
+
+	resp, err := s.SearchPackages(context.Background(), &oslcv1alpha.SearchPackagesRequest{
+		Distributor:  oslc.DistributorCratesIo,
+		NamePrefix:   "serde",
+		License:      &empty,
+		FetchedAfter: timestamppb.New(after),
+		PageSize:     2,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Packages, 2)
+	require.Equal(t, "serde", resp.Packages[1].Name)
+	require.Equal(t, "1.0.1", resp.Packages[1].Version)
+	require.Equal(t, oslc.DistributorCratesIo, resp.Packages[1].Distributor)
+	require.Equal(t, "MIT", resp.Packages[1].License)
+	require.Equal(t, "https://example.com/serde", resp.Packages[1].DistributionPoints[0].Url)
+	require.Equal(t, timestamppb.New(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)), resp.Packages[1].FetchTime)
+
+	cursor, err := decodePageToken(resp.NextPageToken)
+	require.NoError(t, err)
+	require.Equal(t, oslc.SearchCursor{Distributor: oslc.DistributorCratesIo, Name: "serde", Version: "1.0.1"}, cursor)
+}
+
+func TestServer_SearchPackages_last_page(t *testing.T) {
+	s, datastore := newSearchServer(t)
+	cursor := oslc.SearchCursor{Distributor: oslc.DistributorCratesIo, Name: "serde", Version: "1.0.1"}
+	datastore.EXPECT().Search(context.Background(), oslc.SearchQuery{
+		After: &cursor,
+		Limit: searchDefaultPageSize + 1,
+	}).Return([]oslc.StoredEntry{storedEntry("serde_json", "1.0.0")}, nil)
+
+	resp, err := s.SearchPackages(context.Background(), &oslcv1alpha.SearchPackagesRequest{
+		PageToken: encodePageToken(cursor),
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Packages, 1)
+	require.Empty(t, resp.NextPageToken)
+}
+
+func TestServer_SearchPackages_max_page_size(t *testing.T) {
+	s, datastore := newSearchServer(t)
+	datastore.EXPECT().Search(context.Background(), oslc.SearchQuery{Limit: searchMaxPageSize + 1}).Return(nil, nil)
+	resp, err := s.SearchPackages(context.Background(), &oslcv1alpha.SearchPackagesRequest{PageSize: 10000})
+	require.NoError(t, err)
+	require.Empty(t, resp.Packages)
+}
+
+func TestServer_SearchPackages_errors(t *testing.T) {
+	testcases := []struct {
+		name    string
+		request *oslcv1alpha.SearchPackagesRequest
+	}{
+		{"invalid distributor", &oslcv1alpha.SearchPackagesRequest{Distributor: "invalid"}},
+		{"negative page size", &oslcv1alpha.SearchPackagesRequest{PageSize: -1}},
+		{"invalid page token", &oslcv1alpha.SearchPackagesRequest{PageToken: "!"}},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := newSearchServer(t)
+			_, err := s.SearchPackages(context.Background(), tt.request)
+			require.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+
+	t.Run("datastore error", func(t *testing.T) {
+		s, datastore := newSearchServer(t)
+		datastore.EXPECT().Search(context.Background(), oslc.SearchQuery{Limit: searchDefaultPageSize + 1}).Return(nil, assert.AnError)
+		_, err := s.SearchPackages(context.Background(), &oslcv1alpha.SearchPackagesRequest{})
+		require.Equal(t, codes.Internal, status.Code(err))
+	})
+}
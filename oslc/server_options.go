@@ -14,10 +14,37 @@ type serverOptions struct {
 	GoClient            oslc.DistributorClient
 	Datastore           oslc.Datastore
 	LicenseIDNormalizer oslc.LicenseIDNormalizer
+	CurationStore       oslc.CurationStore
+	// LicenseChangeNotifier is notified when a package version is fetched whose license differs from the preceding
+	// version.
+	LicenseChangeNotifier oslc.LicenseChangeNotifier
+	WebhookStore          oslc.WebhookStore
+	CatalogStatsProvider  oslc.CatalogStatsProvider
+	// PackageEventBroker receives events for changes to the catalog, and serves WatchPackages subscriptions.
+	PackageEventBroker oslc.PackageEventBroker
+	// LicenseHistoryFetchLimit is the maximum number of versions fetched from a distributor to answer a single
+	// GetLicenseHistory request. Every request costs one request to list the versions, and one per fetched version.
+	LicenseHistoryFetchLimit int
+	// Offline disables every call to a distributor. Requests are answered from the datastore only, and misses are
+	// passed to MissRecorder.
+	Offline      bool
+	MissRecorder oslc.MissRecorder
+	// JobStore queues the async GetPackageInfo requests for packages that are not in the datastore.
+	JobStore oslc.JobStore
+	// DistributorClients holds the clients of the distributors. If set, it takes precedence over the clients set
+	// individually.
+	DistributorClients *DistributorClients
+	// TenantPolicies holds the license policies that responses are evaluated against for the tenant of the request.
+	TenantPolicies TenantPolicies
+	// TenantQuotas holds the request quotas that the requests of a batch are counted against.
+	TenantQuotas TenantQuotas
+	// Tenants holds the tenants that admin requests may manage the license overrides of.
+	Tenants Tenants
 }
 
 var defaultServerOptions = serverOptions{
-	Logger: slog.Default(),
+	Logger:                   slog.Default(),
+	LicenseHistoryFetchLimit: 5,
 }
 
 var globalServerOptions []ServerOption
@@ -98,3 +125,113 @@ func WithGoClient(c oslc.DistributorClient) ServerOption {
 		opts.GoClient = c
 	})
 }
+
+// WithDistributorClients returns a ServerOption that uses the clients held by c for every distributor, in place of the
+// clients set individually. The clients can be replaced while the server is running.
+func WithDistributorClients(c *DistributorClients) ServerOption {
+	return newFuncClientOption(func(opts *serverOptions) {
+		opts.DistributorClients = c
+	})
+}
+
+// WithCurationStore returns a ServerOption that uses the provided CurationStore. When set, license overrides from the
+// store are applied to every package returned by the server.
+func WithCurationStore(c oslc.CurationStore) ServerOption {
+	return newFuncClientOption(func(opts *serverOptions) {
+		opts.CurationStore = c
+	})
+}
+
+// WithLicenseHistoryFetchLimit returns a ServerOption that limits the number of versions fetched from a distributor to
+// answer a single GetLicenseHistory request. Versions beyond the limit are reported as unresolved, and fetched by later
+// requests. The default is 5, and 0 disables fetching, so histories are made of the stored versions only.
+func WithLicenseHistoryFetchLimit(limit int) ServerOption {
+	return newFuncClientOption(func(opts *serverOptions) {
+		opts.LicenseHistoryFetchLimit = limit
+	})
+}
+
+// WithLicenseChangeNotifier returns a ServerOption that uses the provided LicenseChangeNotifier. When set, the notifier
+// is called whenever a package version fetched from a distributor has a different license than the preceding version
+// in the datastore.
+func WithLicenseChangeNotifier(n oslc.LicenseChangeNotifier) ServerOption {
+	return newFuncClientOption(func(opts *serverOptions) {
+		opts.LicenseChangeNotifier = n
+	})
+}
+
+// WithWebhookStore returns a ServerOption that uses the provided WebhookStore. It is used by [AdminServer] to manage
+// webhook subscriptions. Without it, the webhook subscription RPCs are unavailable.
+func WithWebhookStore(w oslc.WebhookStore) ServerOption {
+	return newFuncClientOption(func(opts *serverOptions) {
+		opts.WebhookStore = w
+	})
+}
+
+// WithCatalogStatsProvider returns a ServerOption that uses the provided CatalogStatsProvider to answer GetCatalogStats
+// requests. Without it, GetCatalogStats is unavailable.
+func WithCatalogStatsProvider(p oslc.CatalogStatsProvider) ServerOption {
+	return newFuncClientOption(func(opts *serverOptions) {
+		opts.CatalogStatsProvider = p
+	})
+}
+
+// WithPackageEventBroker returns a ServerOption that uses the provided PackageEventBroker. When set, events are
+// published to it whenever a package version is added to the catalog, refreshed with a different license, or curated.
+// Without it, WatchPackages is unavailable.
+func WithPackageEventBroker(b oslc.PackageEventBroker) ServerOption {
+	return newFuncClientOption(func(opts *serverOptions) {
+		opts.PackageEventBroker = b
+	})
+}
+
+// WithOffline returns a ServerOption that enables or disables offline mode. In offline mode the server never calls a
+// distributor: packages are served from the datastore only, version constraints are resolved against the stored
+// versions, and requests for unknown packages fail with codes.FailedPrecondition and reason
+// [oslc.ReasonNotInOfflineCatalog].
+func WithOffline(offline bool) ServerOption {
+	return newFuncClientOption(func(opts *serverOptions) {
+		opts.Offline = offline
+	})
+}
+
+// WithMissRecorder returns a ServerOption that uses the provided MissRecorder. In offline mode, every request that
+// cannot be answered from the datastore is recorded to it.
+func WithMissRecorder(r oslc.MissRecorder) ServerOption {
+	return newFuncClientOption(func(opts *serverOptions) {
+		opts.MissRecorder = r
+	})
+}
+
+// WithJobStore returns a ServerOption that uses the provided JobStore. Async GetPackageInfo requests for packages that
+// are not in the datastore queue a job in it, which must be resolved by a worker pool of the jobs package. Without it,
+// async requests and GetJob are unavailable.
+func WithJobStore(j oslc.JobStore) ServerOption {
+	return newFuncClientOption(func(opts *serverOptions) {
+		opts.JobStore = j
+	})
+}
+
+// WithTenantPolicies returns a ServerOption that evaluates the responses to the requests of tenants against the
+// license policies of the tenants.
+func WithTenantPolicies(p TenantPolicies) ServerOption {
+	return newFuncClientOption(func(opts *serverOptions) {
+		opts.TenantPolicies = p
+	})
+}
+
+// WithTenantQuotas returns a ServerOption that counts every request of a BatchGetPackageInfo request against the quota
+// of the tenant. The gRPC server only counts the batch itself, as a single request.
+func WithTenantQuotas(q TenantQuotas) ServerOption {
+	return newFuncClientOption(func(opts *serverOptions) {
+		opts.TenantQuotas = q
+	})
+}
+
+// WithTenants returns a ServerOption that validates the tenants named by admin requests against the provided tenants.
+// Without it, admin requests may name any tenant.
+func WithTenants(t Tenants) ServerOption {
+	return newFuncClientOption(func(opts *serverOptions) {
+		opts.Tenants = t
+	})
+}
@@ -114,6 +114,22 @@ func (m mockDatastore) Retrieve(ctx context.Context, name, version, distributor
 	return oslc.Entry{}, nil
 }
 
+func (m mockDatastore) RetrieveVersions(ctx context.Context, name, distributor string) ([]oslc.Entry, error) {
+	return nil, nil
+}
+
+func (m mockDatastore) Invalidate(ctx context.Context, filter oslc.EntryFilter) (int64, error) {
+	return 0, nil
+}
+
+func (m mockDatastore) Search(ctx context.Context, query oslc.SearchQuery) ([]oslc.StoredEntry, error) {
+	return nil, nil
+}
+
+func (m mockDatastore) CountPackages(ctx context.Context) ([]oslc.PackageCount, error) {
+	return nil, nil
+}
+
 func TestWithDatastore(t *testing.T) {
 	ds := mockDatastore{}
 	opts := serverOptions{}
@@ -137,3 +153,66 @@ func TestWithCratesIoClient(t *testing.T) {
 	f.apply(&opts)
 	require.Equal(t, client, opts.CratesIoClient)
 }
+
+func TestWithCurationStore(t *testing.T) {
+	mock := oslcmocks.NewMockCurationStore(t)
+	opts := serverOptions{}
+	f := WithCurationStore(mock)
+	f.apply(&opts)
+	require.Equal(t, mock, opts.CurationStore)
+}
+
+func TestWithLicenseHistoryFetchLimit(t *testing.T) {
+	opts := serverOptions{}
+	f := WithLicenseHistoryFetchLimit(10)
+	f.apply(&opts)
+	require.Equal(t, 10, opts.LicenseHistoryFetchLimit)
+}
+
+func TestWithPackageEventBroker(t *testing.T) {
+	mock := oslcmocks.NewMockPackageEventBroker(t)
+	opts := serverOptions{}
+	f := WithPackageEventBroker(mock)
+	f.apply(&opts)
+	require.Equal(t, mock, opts.PackageEventBroker)
+}
+
+func TestWithJobStore(t *testing.T) {
+	mock := oslcmocks.NewMockJobStore(t)
+	opts := serverOptions{}
+	f := WithJobStore(mock)
+	f.apply(&opts)
+	require.Equal(t, mock, opts.JobStore)
+}
+
+func TestWithTenantPolicies(t *testing.T) {
+	policies := tenantPolicies{}
+	opts := serverOptions{}
+	f := WithTenantPolicies(policies)
+	f.apply(&opts)
+	require.Equal(t, policies, opts.TenantPolicies)
+}
+
+func TestWithTenantQuotas(t *testing.T) {
+	quota := &tenantQuota{}
+	opts := serverOptions{}
+	f := WithTenantQuotas(quota)
+	f.apply(&opts)
+	require.Equal(t, quota, opts.TenantQuotas)
+}
+
+func TestWithTenants(t *testing.T) {
+	tenants := knownTenants{"payments"}
+	opts := serverOptions{}
+	f := WithTenants(tenants)
+	f.apply(&opts)
+	require.Equal(t, tenants, opts.Tenants)
+}
+
+func TestWithDistributorClients(t *testing.T) {
+	clients := NewDistributorClients(nil)
+	opts := serverOptions{}
+	f := WithDistributorClients(clients)
+	f.apply(&opts)
+	require.Equal(t, clients, opts.DistributorClients)
+}
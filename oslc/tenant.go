@@ -0,0 +1,73 @@
+package oslc
+
+import (
+	oslcv1alpha "buf.build/gen/go/chainalysis-oss/oslc/protocolbuffers/go/chainalysis_oss/oslc/v1alpha"
+	"context"
+	"github.com/chainalysis-oss/oslc"
+	"github.com/chainalysis-oss/oslc/policy"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"log/slog"
+)
+
+// TenantPolicies holds the license policies of tenants.
+type TenantPolicies interface {
+	// TenantPolicy returns the license policy of the tenant, if it has one.
+	TenantPolicy(tenant string) (*policy.Policy, bool)
+}
+
+// TenantQuotas enforces the request quotas of tenants.
+type TenantQuotas interface {
+	// AllowRequests reports whether the quota of the tenant allows n more requests, and if so, counts them against the
+	// quota.
+	AllowRequests(tenant string, n int) bool
+	// RequestBurst returns the number of requests the quota of the tenant allows at once, and reports false if the
+	// tenant has no quota.
+	RequestBurst(tenant string) (int, bool)
+}
+
+// Tenants holds the tenants of a deployment.
+type Tenants interface {
+	// HasTenant reports whether the tenant exists.
+	HasTenant(tenant string) bool
+}
+
+// evaluatePolicy evaluates the response against the license policy of the request's tenant. It returns nil if the
+// request is not made for a tenant, or the tenant has no policy.
+func (s Server) evaluatePolicy(ctx context.Context, distributor string, resp *oslcv1alpha.GetPackageInfoResponse) *oslcv1alpha.PolicyEvaluation {
+	tenant := oslc.TenantFromContext(ctx)
+	if tenant == "" || s.options.TenantPolicies == nil {
+		return nil
+	}
+	p, ok := s.options.TenantPolicies.TenantPolicy(tenant)
+	if !ok {
+		return nil
+	}
+	violation, violated := p.Check(policy.Package{
+		Distributor: distributor,
+		Name:        resp.Name,
+		Version:     resp.Version,
+		License:     resp.License,
+	})
+	trace.SpanFromContext(ctx).SetAttributes(attribute.Bool("oslc.policy.compliant", !violated))
+	return &oslcv1alpha.PolicyEvaluation{Compliant: !violated, Reason: violation.Reason}
+}
+
+// applyTenantLayers applies the overrides and policy of the request's tenant to a response that was resolved without a
+// tenant, such as the result of a job. The shared overrides are expected to be applied already.
+func (s Server) applyTenantLayers(ctx context.Context, distributor string, resp *oslcv1alpha.GetPackageInfoResponse) *oslcv1alpha.GetPackageInfoResponse {
+	tenant := oslc.TenantFromContext(ctx)
+	if tenant != "" && s.options.CurationStore != nil {
+		overrides, err := s.options.CurationStore.ListOverrides(ctx, tenant, distributor, resp.Name)
+		if err != nil {
+			s.options.Logger.ErrorContext(ctx, "failed to retrieve license overrides", slog.String("error", err.Error()))
+		}
+		entry, curated := s.applyOverrideList(ctx, overrides, oslc.Entry{Name: resp.Name, Version: resp.Version, License: resp.License})
+		if curated {
+			resp.License = entry.License
+			resp.Curated = true
+		}
+	}
+	resp.Policy = s.evaluatePolicy(ctx, distributor, resp)
+	return resp
+}
@@ -0,0 +1,202 @@
+package oslc
+
+import (
+	oslcv1alpha "buf.build/gen/go/chainalysis-oss/oslc/protocolbuffers/go/chainalysis_oss/oslc/v1alpha"
+	"context"
+	"github.com/chainalysis-oss/oslc"
+	"github.com/chainalysis-oss/oslc/jobs"
+	oslcMocks "github.com/chainalysis-oss/oslc/mocks/oslc"
+	"github.com/chainalysis-oss/oslc/policy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// tenantPolicies holds the license policies of tenants, keyed by tenant.
+type tenantPolicies map[string]*policy.Policy
+
+func (p tenantPolicies) TenantPolicy(tenant string) (*policy.Policy, bool) {
+	pol, ok := p[tenant]
+	return pol, ok
+}
+
+var paymentsCtx = oslc.ContextWithTenant(context.Background(), "payments")
+
+func newTenantServer(t *testing.T) (Server, *oslcMocks.MockDatastore, *oslcMocks.MockCurationStore) {
+	t.Helper()
+	datastore := oslcMocks.NewMockDatastore(t)
+	store := oslcMocks.NewMockCurationStore(t)
+	normalizer := oslcMocks.NewMockLicenseIDNormalizer(t)
+	normalizer.EXPECT().NormalizeID(paymentsCtx, "MIT").Return("MIT").Maybe()
+	return Server{
+		options: &serverOptions{
+			Logger:              slog.New(slog.NewTextHandler(io.Discard, nil)),
+			Datastore:           datastore,
+			CurationStore:       store,
+			LicenseIDNormalizer: normalizer,
+			TenantPolicies: tenantPolicies{
+				"payments": {Deny: []string{"MIT"}},
+			},
+		},
+	}, datastore, store
+}
+
+func TestServer_GetPackageInfo_tenantOverrides(t *testing.T) {
+	s, datastore, store := newTenantServer(t)
+	datastore.EXPECT().Retrieve(paymentsCtx, "requests", "2.32.3", oslc.DistributorPypi).Return(pypiRequestsEntry, nil)
+	store.EXPECT().ListOverrides(paymentsCtx, "payments", oslc.DistributorPypi, "requests").Return([]oslc.LicenseOverride{
+		{Tenant: "payments", Distributor: oslc.DistributorPypi, Name: "requests", VersionRange: "*", License: "MIT"},
+	}, nil)
+	store.EXPECT().ListOverrides(paymentsCtx, "", oslc.DistributorPypi, "requests").Return([]oslc.LicenseOverride{
+		{Distributor: oslc.DistributorPypi, Name: "requests", VersionRange: "*", License: "BSD-3-Clause"},
+	}, nil)
+
+	// The overrides of the tenant take precedence over the shared overrides, and the result is evaluated against the
+	// policy of the tenant.
+	resp, err := s.GetPackageInfo(paymentsCtx, &pypiRequestsGetPackageInfoRequest)
+	require.NoError(t, err)
+	require.Equal(t, "MIT", resp.License)
+	require.True(t, resp.Curated)
+	require.False(t, resp.Policy.Compliant)
+	require.NotEmpty(t, resp.Policy.Reason)
+}
+
+func TestServer_GetPackageInfo_tenantOverrides_errors(t *testing.T) {
+	t.Run("tenant", func(t *testing.T) {
+		s, datastore, store := newTenantServer(t)
+		datastore.EXPECT().Retrieve(paymentsCtx, "requests", "2.32.3", oslc.DistributorPypi).Return(pypiRequestsEntry, nil)
+		store.EXPECT().ListOverrides(paymentsCtx, "payments", oslc.DistributorPypi, "requests").Return(nil, assert.AnError)
+		resp, err := s.GetPackageInfo(paymentsCtx, &pypiRequestsGetPackageInfoRequest)
+		require.NoError(t, err)
+		require.Equal(t, pypiRequestsEntry.License, resp.License)
+	})
+	t.Run("shared", func(t *testing.T) {
+		s, datastore, store := newTenantServer(t)
+		datastore.EXPECT().Retrieve(paymentsCtx, "requests", "2.32.3", oslc.DistributorPypi).Return(pypiRequestsEntry, nil)
+		store.EXPECT().ListOverrides(paymentsCtx, "payments", oslc.DistributorPypi, "requests").Return(nil, nil)
+		store.EXPECT().ListOverrides(paymentsCtx, "", oslc.DistributorPypi, "requests").Return(nil, assert.AnError)
+		resp, err := s.GetPackageInfo(paymentsCtx, &pypiRequestsGetPackageInfoRequest)
+		require.NoError(t, err)
+		require.Equal(t, pypiRequestsEntry.License, resp.License)
+	})
+}
+
+func TestServer_SearchPackages_tenant(t *testing.T) {
+	s, datastore, store := newTenantServer(t)
+	serde := storedEntry("serde", "1.0.0")
+	serde.License = "Apache-2.0"
+	anyhow := storedEntry("anyhow", "1.0.0")
+	anyhow.License = "Apache-2.0"
+	datastore.EXPECT().Search(paymentsCtx, oslc.SearchQuery{Limit: searchDefaultPageSize + 1}).Return([]oslc.StoredEntry{serde, anyhow}, nil)
+	store.EXPECT().ListOverrides(paymentsCtx, "payments", oslc.DistributorCratesIo, "serde").Return(nil, nil)
+	store.EXPECT().ListOverrides(paymentsCtx, "", oslc.DistributorCratesIo, "serde").Return(nil, nil)
+	store.EXPECT().ListOverrides(paymentsCtx, "payments", oslc.DistributorCratesIo, "anyhow").Return([]oslc.LicenseOverride{
+		{Tenant: "payments", Distributor: oslc.DistributorCratesIo, Name: "anyhow", VersionRange: "*", License: "MIT"},
+	}, nil)
+	store.EXPECT().ListOverrides(paymentsCtx, "", oslc.DistributorCratesIo, "anyhow").Return(nil, nil)
+
+	// Search results show the license the tenant gets from GetPackageInfo, evaluated against the policy of the tenant.
+	resp, err := s.SearchPackages(paymentsCtx, &oslcv1alpha.SearchPackagesRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Packages, 2)
+	require.Equal(t, "Apache-2.0", resp.Packages[0].License)
+	require.False(t, resp.Packages[0].Curated)
+	require.True(t, resp.Packages[0].Policy.Compliant)
+	require.Equal(t, "MIT", resp.Packages[1].License)
+	require.True(t, resp.Packages[1].Curated)
+	require.False(t, resp.Packages[1].Policy.Compliant)
+}
+
+func TestServer_evaluatePolicy(t *testing.T) {
+	s := Server{options: &serverOptions{TenantPolicies: tenantPolicies{
+		"payments": {Deny: []string{"MIT"}},
+	}}}
+	compliant := &oslcv1alpha.GetPackageInfoResponse{Name: "requests", Version: "2.32.3", License: "Apache-2.0"}
+	violating := &oslcv1alpha.GetPackageInfoResponse{Name: "requests", Version: "2.32.3", License: "MIT"}
+
+	require.Equal(t, &oslcv1alpha.PolicyEvaluation{Compliant: true}, s.evaluatePolicy(paymentsCtx, oslc.DistributorPypi, compliant))
+	evaluation := s.evaluatePolicy(paymentsCtx, oslc.DistributorPypi, violating)
+	require.False(t, evaluation.Compliant)
+	require.NotEmpty(t, evaluation.Reason)
+
+	// Responses are only evaluated for tenants with a policy.
+	require.Nil(t, s.evaluatePolicy(context.Background(), oslc.DistributorPypi, violating))
+	require.Nil(t, s.evaluatePolicy(oslc.ContextWithTenant(context.Background(), "research"), oslc.DistributorPypi, violating))
+	s.options.TenantPolicies = nil
+	require.Nil(t, s.evaluatePolicy(paymentsCtx, oslc.DistributorPypi, violating))
+}
+
+func TestServer_GetJob_tenant(t *testing.T) {
+	s, _, store := newTenantServer(t)
+	jobStore := oslcMocks.NewMockJobStore(t)
+	s.options.JobStore = jobStore
+	result, err := jobs.EncodeResponse(&oslcv1alpha.GetPackageInfoResponse{Name: "requests", Version: "2.32.3", License: "Apache-2.0"})
+	require.NoError(t, err)
+	jobStore.EXPECT().GetJob(paymentsCtx, int64(1)).Return(oslc.Job{
+		ID:         1,
+		JobRequest: oslc.JobRequest{Distributor: oslc.DistributorPypi, Name: "requests", Version: "2.32.3"},
+		State:      oslc.JobSucceeded,
+		Result:     result,
+	}, nil)
+	store.EXPECT().ListOverrides(paymentsCtx, "payments", oslc.DistributorPypi, "requests").Return([]oslc.LicenseOverride{
+		{Tenant: "payments", Distributor: oslc.DistributorPypi, Name: "requests", VersionRange: "*", License: "MIT"},
+	}, nil)
+
+	// Jobs are resolved without a tenant, so the layers of the tenant are applied to the result.
+	resp, err := s.GetJob(paymentsCtx, &oslcv1alpha.GetJobRequest{Id: 1})
+	require.NoError(t, err)
+	require.Equal(t, "MIT", resp.Job.Result.License)
+	require.True(t, resp.Job.Result.Curated)
+	require.False(t, resp.Job.Result.Policy.Compliant)
+}
+
+func TestAdminServer_licenseOverrides_tenant(t *testing.T) {
+	broker := oslcMocks.NewMockPackageEventBroker(t)
+	s, _, store := newTestAdminServer(t, WithPackageEventBroker(broker))
+	updatedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	override := requestsOverride
+	override.Tenant = "payments"
+	stored := override
+	stored.UpdatedAt = updatedAt
+	store.EXPECT().SetOverride(paymentsCtx, override).Return(stored, nil)
+	broker.EXPECT().PublishPackageEvent(paymentsCtx, oslc.PackageEvent{
+		Type:         oslc.PackageEventCurated,
+		Distributor:  override.Distributor,
+		Name:         override.Name,
+		License:      override.License,
+		VersionRange: override.VersionRange,
+		Tenant:       "payments",
+		OccurredAt:   updatedAt,
+	})
+	store.EXPECT().ListOverrides(paymentsCtx, "payments", "", "").Return([]oslc.LicenseOverride{stored}, nil)
+	store.EXPECT().DeleteOverride(paymentsCtx, "payments", override.Distributor, override.Name, override.VersionRange).Return(nil)
+
+	// Overrides are managed for the tenant of the request if the request names none.
+	setResp, err := s.SetLicenseOverride(paymentsCtx, &oslcv1alpha.SetLicenseOverrideRequest{
+		Override: &oslcv1alpha.LicenseOverride{
+			Distributor:   override.Distributor,
+			Name:          override.Name,
+			VersionRange:  override.VersionRange,
+			License:       override.License,
+			Justification: override.Justification,
+			Author:        override.Author,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "payments", setResp.Override.Tenant)
+
+	listResp, err := s.ListLicenseOverrides(paymentsCtx, &oslcv1alpha.ListLicenseOverridesRequest{})
+	require.NoError(t, err)
+	require.Len(t, listResp.Overrides, 1)
+	require.Equal(t, "payments", listResp.Overrides[0].Tenant)
+
+	_, err = s.DeleteLicenseOverride(paymentsCtx, &oslcv1alpha.DeleteLicenseOverrideRequest{
+		Distributor:  override.Distributor,
+		Name:         override.Name,
+		VersionRange: override.VersionRange,
+	})
+	require.NoError(t, err)
+}
@@ -0,0 +1,62 @@
+package oslc
+
+import (
+	"context"
+	"github.com/chainalysis-oss/oslc"
+	"github.com/chainalysis-oss/oslc/versions"
+	"google.golang.org/grpc/codes"
+)
+
+// resolveVersion resolves a version constraint, such as "^4.17.0" or "[1.2,2.0)", to the highest version of the package
+// listed by the distributor that satisfies it. For distributors with dist-tags, a version that is not a valid
+// constraint is looked up as a dist-tag. Single versions, and the empty version, are returned unchanged. In offline
+// mode, versions are resolved by resolveStoredVersion instead. The returned error is a gRPC status error.
+func (s Server) resolveVersion(ctx context.Context, distributor, name, version string) (string, error) {
+	if s.options.Offline {
+		return s.resolveStoredVersion(ctx, distributor, name, version)
+	}
+	if !versions.IsConstraint(distributor, version) {
+		return version, nil
+	}
+	client, err := s.clientFor(distributor)
+	if err != nil {
+		return "", s.upstreamErrorToStatus(ctx, err)
+	}
+
+	constraint, err := versions.ParseConstraint(distributor, version)
+	if err != nil {
+		if tagLister, ok := client.(oslc.DistTagLister); ok {
+			return s.resolveDistTag(ctx, tagLister, distributor, name, version)
+		}
+		return "", invalidFieldError("invalid version constraint", oslc.ReasonInvalidVersionConstraint, "version", err.Error(), distributor)
+	}
+
+	lister, ok := client.(oslc.VersionLister)
+	if !ok {
+		return "", invalidFieldError("version constraints are not supported for this distributor", oslc.ReasonInvalidVersionConstraint,
+			"version", "must be a single version", distributor)
+	}
+	available, err := lister.ListVersions(ctx, name)
+	if err != nil {
+		return "", s.upstreamErrorToStatus(ctx, err)
+	}
+	resolved, ok := constraint.Select(name, available)
+	if !ok {
+		return "", statusError(codes.NotFound, "no version satisfies the version constraint", errorInfo(oslc.ReasonVersionNotFound, distributor))
+	}
+	return resolved, nil
+}
+
+// resolveDistTag returns the version the dist-tag points to.
+func (s Server) resolveDistTag(ctx context.Context, lister oslc.DistTagLister, distributor, name, tag string) (string, error) {
+	tags, err := lister.ListDistTags(ctx, name)
+	if err != nil {
+		return "", s.upstreamErrorToStatus(ctx, err)
+	}
+	version, ok := tags[tag]
+	if !ok {
+		return "", statusError(codes.NotFound, "version constraint is invalid and no dist-tag of that name exists",
+			errorInfo(oslc.ReasonVersionNotFound, distributor))
+	}
+	return version, nil
+}
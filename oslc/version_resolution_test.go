@@ -0,0 +1,201 @@
+package oslc
+
+import (
+	oslcv1alpha "buf.build/gen/go/chainalysis-oss/oslc/protocolbuffers/go/chainalysis_oss/oslc/v1alpha"
+	"context"
+	"github.com/chainalysis-oss/oslc"
+	oslcMocks "github.com/chainalysis-oss/oslc/mocks/oslc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+// taggingDistributorClient is a distributor client that can enumerate versions and dist-tags.
+type taggingDistributorClient struct {
+	*oslcMocks.MockDistributorClient
+	*oslcMocks.MockVersionLister
+	*oslcMocks.MockDistTagLister
+}
+
+func newTaggingDistributorClient(t *testing.T) taggingDistributorClient {
+	return taggingDistributorClient{
+		oslcMocks.NewMockDistributorClient(t),
+		oslcMocks.NewMockVersionLister(t),
+		oslcMocks.NewMockDistTagLister(t),
+	}
+}
+
+func TestServer_GetPackageInfo_versionConstraint(t *testing.T) {
+	client := newTaggingDistributorClient(t)
+	s, datastore := newHistoryServer(t, client)
+	client.MockVersionLister.EXPECT().ListVersions(context.Background(), "test").
+		Return([]string{"4.16.6", "4.17.0", "4.17.21", "5.0.0"}, nil)
+	datastore.EXPECT().Retrieve(context.Background(), "test", "4.17.21", oslc.DistributorNpm).
+		Return(historyEntry("4.17.21", "MIT"), nil)
+
+	resp, err := s.GetPackageInfo(context.Background(), &oslcv1alpha.GetPackageInfoRequest{
+		Name:        "test",
+		Version:     "^4.17.0",
+		Distributor: oslc.DistributorNpm,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "4.17.21", resp.Version)
+	require.Equal(t, "^4.17.0", resp.RequestedVersion)
+	require.Equal(t, "MIT", resp.License)
+}
+
+func TestServer_GetPackageInfo_versionConstraint_upstream(t *testing.T) {
+	client := newTaggingDistributorClient(t)
+	s, datastore := newHistoryServer(t, client)
+	client.MockVersionLister.EXPECT().ListVersions(context.Background(), "test").
+		Return([]string{"0.2.9", "0.3.0", "0.3.12", "0.4.0"}, nil)
+	datastore.EXPECT().Retrieve(context.Background(), "test", "0.3.12", oslc.DistributorNpm).
+		Return(oslc.Entry{}, oslc.ErrDatastoreObjectNotFound)
+	client.MockDistributorClient.EXPECT().GetPackageVersion(context.Background(), "test", "0.3.12").
+		Return(historyEntry("0.3.12", "MIT"), nil)
+	datastore.EXPECT().Save(context.Background(), historyEntry("0.3.12", "MIT")).Return(nil)
+
+	resp, err := s.GetPackageInfo(context.Background(), &oslcv1alpha.GetPackageInfoRequest{
+		Name:        "test",
+		Version:     ">=0.3.0 <0.4.0",
+		Distributor: oslc.DistributorNpm,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "0.3.12", resp.Version)
+	require.Equal(t, ">=0.3.0 <0.4.0", resp.RequestedVersion)
+}
+
+func TestServer_GetPackageInfo_distTag(t *testing.T) {
+	client := newTaggingDistributorClient(t)
+	s, datastore := newHistoryServer(t, client)
+	client.MockDistTagLister.EXPECT().ListDistTags(context.Background(), "test").
+		Return(map[string]string{"latest": "1.0.0", "next": "2.0.0-rc.1"}, nil)
+	datastore.EXPECT().Retrieve(context.Background(), "test", "2.0.0-rc.1", oslc.DistributorNpm).
+		Return(historyEntry("2.0.0-rc.1", "Apache-2.0"), nil)
+
+	resp, err := s.GetPackageInfo(context.Background(), &oslcv1alpha.GetPackageInfoRequest{
+		Name:        "test",
+		Version:     "next",
+		Distributor: oslc.DistributorNpm,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "2.0.0-rc.1", resp.Version)
+	require.Equal(t, "next", resp.RequestedVersion)
+}
+
+func TestServer_GetPackageInfo_exactVersionIsNotResolved(t *testing.T) {
+	// The mocks fail the test if the versions or dist-tags are listed.
+	client := newTaggingDistributorClient(t)
+	s, datastore := newHistoryServer(t, client)
+	datastore.EXPECT().Retrieve(context.Background(), "test", "1.0.0", oslc.DistributorNpm).
+		Return(historyEntry("1.0.0", "MIT"), nil)
+
+	resp, err := s.GetPackageInfo(context.Background(), &oslcv1alpha.GetPackageInfoRequest{
+		Name:        "test",
+		Version:     "1.0.0",
+		Distributor: oslc.DistributorNpm,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "1.0.0", resp.Version)
+	require.Empty(t, resp.RequestedVersion)
+}
+
+func TestServer_resolveVersion_errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		distributor string
+		version     string
+		setup       func(s *Server, client taggingDistributorClient)
+		wantCode    codes.Code
+	}{
+		{
+			name:        "no matching version",
+			distributor: oslc.DistributorNpm,
+			version:     "^9.0.0",
+			setup: func(_ *Server, client taggingDistributorClient) {
+				client.MockVersionLister.EXPECT().ListVersions(context.Background(), "test").Return([]string{"1.0.0"}, nil)
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name:        "package not found",
+			distributor: oslc.DistributorNpm,
+			version:     "^1.0.0",
+			setup: func(_ *Server, client taggingDistributorClient) {
+				client.MockVersionLister.EXPECT().ListVersions(context.Background(), "test").
+					Return(nil, oslc.DistributorError{Distributor: oslc.DistributorNpm, Err: oslc.ErrNoSuchPackage})
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name:        "listing versions fails",
+			distributor: oslc.DistributorNpm,
+			version:     "^1.0.0",
+			setup: func(_ *Server, client taggingDistributorClient) {
+				client.MockVersionLister.EXPECT().ListVersions(context.Background(), "test").
+					Return(nil, oslc.DistributorError{Distributor: oslc.DistributorNpm, Err: assert.AnError})
+			},
+			wantCode: codes.Internal,
+		},
+		{
+			name:        "unknown dist-tag",
+			distributor: oslc.DistributorNpm,
+			version:     "canary",
+			setup: func(_ *Server, client taggingDistributorClient) {
+				client.MockDistTagLister.EXPECT().ListDistTags(context.Background(), "test").Return(map[string]string{"latest": "1.0.0"}, nil)
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name:        "listing dist-tags fails",
+			distributor: oslc.DistributorNpm,
+			version:     "canary",
+			setup: func(_ *Server, client taggingDistributorClient) {
+				client.MockDistTagLister.EXPECT().ListDistTags(context.Background(), "test").
+					Return(nil, oslc.DistributorError{Distributor: oslc.DistributorNpm, Err: assert.AnError})
+			},
+			wantCode: codes.Internal,
+		},
+		{
+			name:        "invalid constraint",
+			distributor: oslc.DistributorPypi,
+			version:     "~=2",
+			setup: func(s *Server, client taggingDistributorClient) {
+				s.options.PypiClient = client.MockDistributorClient
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:        "distributor cannot list versions",
+			distributor: oslc.DistributorPypi,
+			version:     "~=2.31",
+			setup: func(s *Server, client taggingDistributorClient) {
+				s.options.PypiClient = client.MockDistributorClient
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:        "distributor disabled",
+			distributor: oslc.DistributorMaven,
+			version:     "[1.0,2.0)",
+			setup:       func(*Server, taggingDistributorClient) {},
+			wantCode:    codes.FailedPrecondition,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTaggingDistributorClient(t)
+			s, _ := newHistoryServer(t, client)
+			tt.setup(&s, client)
+
+			_, err := s.resolveVersion(context.Background(), tt.distributor, "test", tt.version)
+			require.Equal(t, tt.wantCode, status.Code(err))
+			if tt.wantCode == codes.InvalidArgument {
+				_, _, badRequest := statusDetails(t, err)
+				require.Equal(t, "version", badRequest.FieldViolations[0].Field)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package oslc
+
+import (
+	oslcv1alpha "buf.build/gen/go/chainalysis-oss/oslc/protocolbuffers/go/chainalysis_oss/oslc/v1alpha"
+	"context"
+	"errors"
+	"github.com/chainalysis-oss/oslc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"log/slog"
+)
+
+func (s Server) WatchPackages(request *oslcv1alpha.WatchPackagesRequest, stream grpc.ServerStreamingServer[oslcv1alpha.PackageEvent]) error {
+	ctx := stream.Context()
+	if s.options.PackageEventBroker == nil {
+		return status.Error(codes.Unimplemented, "watching packages is not enabled")
+	}
+	if request.Distributor != "" && !validDistributor(request.Distributor) {
+		return invalidDistributorError()
+	}
+
+	subscription, err := s.options.PackageEventBroker.SubscribePackageEvents(ctx, oslc.PackageEventFilter{
+		Distributor: request.Distributor,
+		Names:       request.Names,
+		Licenses:    request.Licenses,
+		Tenant:      oslc.TenantFromContext(ctx),
+	})
+	if err != nil {
+		return s.subscriptionErrorToStatus(ctx, err)
+	}
+	for event := range subscription.Events() {
+		if err := stream.Send(packageEventToProto(event)); err != nil {
+			return err
+		}
+	}
+	return s.subscriptionErrorToStatus(ctx, subscription.Err())
+}
+
+// subscriptionErrorToStatus converts the error ending or refusing a subscription to the status ending the stream. A
+// subscription ended because the client went away ends the stream without an error.
+func (s Server) subscriptionErrorToStatus(ctx context.Context, err error) error {
+	switch {
+	case err == nil, errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return nil
+	case errors.Is(err, oslc.ErrSubscriptionOverflow):
+		return status.Error(codes.ResourceExhausted, "client did not keep up with events")
+	case errors.Is(err, oslc.ErrTooManySubscriptions):
+		return status.Error(codes.ResourceExhausted, "too many watchers")
+	case errors.Is(err, oslc.ErrBrokerClosed):
+		return status.Error(codes.Unavailable, "server is shutting down")
+	}
+	s.options.Logger.ErrorContext(ctx, "package event subscription failed", slog.String("error", err.Error()))
+	return status.Error(codes.Internal, "internal server error")
+}
+
+func packageEventToProto(event oslc.PackageEvent) *oslcv1alpha.PackageEvent {
+	return &oslcv1alpha.PackageEvent{
+		Type:            packageEventTypeToProto(event.Type),
+		Distributor:     event.Distributor,
+		Name:            event.Name,
+		Version:         event.Version,
+		License:         event.License,
+		PreviousLicense: event.PreviousLicense,
+		VersionRange:    event.VersionRange,
+		OccurTime:       timestamppb.New(event.OccurredAt),
+	}
+}
+
+func packageEventTypeToProto(t oslc.PackageEventType) oslcv1alpha.PackageEventType {
+	switch t {
+	case oslc.PackageEventCreated:
+		return oslcv1alpha.PackageEventType_PACKAGE_EVENT_TYPE_CREATED
+	case oslc.PackageEventLicenseChanged:
+		return oslcv1alpha.PackageEventType_PACKAGE_EVENT_TYPE_LICENSE_CHANGED
+	case oslc.PackageEventCurated:
+		return oslcv1alpha.PackageEventType_PACKAGE_EVENT_TYPE_CURATED
+	default:
+		return oslcv1alpha.PackageEventType_PACKAGE_EVENT_TYPE_UNSPECIFIED
+	}
+}
@@ -0,0 +1,133 @@
+package oslc
+
+import (
+	oslcv1alpha "buf.build/gen/go/chainalysis-oss/oslc/protocolbuffers/go/chainalysis_oss/oslc/v1alpha"
+	"context"
+	"github.com/chainalysis-oss/oslc"
+	oslcMocks "github.com/chainalysis-oss/oslc/mocks/oslc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// watchStream is a grpc.ServerStreamingServer that records the events sent on it.
+type watchStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	sent    []*oslcv1alpha.PackageEvent
+	sendErr error
+}
+
+func (w *watchStream) Context() context.Context {
+	return w.ctx
+}
+
+func (w *watchStream) Send(event *oslcv1alpha.PackageEvent) error {
+	w.sent = append(w.sent, event)
+	return w.sendErr
+}
+
+func newWatchServer(t *testing.T) (Server, *oslcMocks.MockPackageEventBroker) {
+	t.Helper()
+	broker := oslcMocks.NewMockPackageEventBroker(t)
+	return Server{options: &serverOptions{Logger: slog.New(slog.NewTextHandler(io.Discard, nil)), PackageEventBroker: broker}}, broker
+}
+
+// newSubscription returns a subscription that delivers events, and then ends with err.
+func newSubscription(t *testing.T, err error, events ...oslc.PackageEvent) *oslcMocks.MockPackageEventSubscription {
+	t.Helper()
+	ch := make(chan oslc.PackageEvent, len(events))
+	for _, e := range events {
+		ch <- e
+	}
+	close(ch)
+	subscription := oslcMocks.NewMockPackageEventSubscription(t)
+	subscription.EXPECT().Events().Return(ch)
+	subscription.EXPECT().Err().Return(err).Maybe()
+	return subscription
+}
+
+func TestServer_WatchPackages(t *testing.T) {
+	s, broker := newWatchServer(t)
+	occurredAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	events := []oslc.PackageEvent{
+		{Type: oslc.PackageEventCreated, Distributor: oslc.DistributorNpm, Name: "lodash", Version: "4.17.21", License: "MIT", OccurredAt: occurredAt},
+		{Type: oslc.PackageEventLicenseChanged, Distributor: oslc.DistributorNpm, Name: "lodash", Version: "4.17.21", License: "Apache-2.0", PreviousLicense: "MIT", OccurredAt: occurredAt},
+		{Type: oslc.PackageEventCurated, Distributor: oslc.DistributorNpm, Name: "lodash", License: "MIT", VersionRange: ">=4.0.0", OccurredAt: occurredAt},
+	}
+	stream := &watchStream{ctx: context.Background()}
+	filter := oslc.PackageEventFilter{Distributor: oslc.DistributorNpm, Names: []string{"lodash"}, Licenses: []string{"MIT"}}
+	broker.EXPECT().SubscribePackageEvents(context.Background(), filter).Return(newSubscription(t, context.Canceled, events...), nil)
+
+	err := s.WatchPackages(&oslcv1alpha.WatchPackagesRequest{Distributor: oslc.DistributorNpm, Names: []string{"lodash"}, Licenses: []string{"MIT"}}, stream)
+	// The client going away ends the stream without an error.
+	require.NoError(t, err)
+	require.Equal(t, []*oslcv1alpha.PackageEvent{
+		{Type: oslcv1alpha.PackageEventType_PACKAGE_EVENT_TYPE_CREATED, Distributor: oslc.DistributorNpm, Name: "lodash", Version: "4.17.21", License: "MIT", OccurTime: timestamppb.New(occurredAt)},
+		{Type: oslcv1alpha.PackageEventType_PACKAGE_EVENT_TYPE_LICENSE_CHANGED, Distributor: oslc.DistributorNpm, Name: "lodash", Version: "4.17.21", License: "Apache-2.0", PreviousLicense: "MIT", OccurTime: timestamppb.New(occurredAt)},
+		{Type: oslcv1alpha.PackageEventType_PACKAGE_EVENT_TYPE_CURATED, Distributor: oslc.DistributorNpm, Name: "lodash", License: "MIT", VersionRange: ">=4.0.0", OccurTime: timestamppb.New(occurredAt)},
+	}, stream.sent)
+}
+
+func TestServer_WatchPackages_tenant(t *testing.T) {
+	s, broker := newWatchServer(t)
+	ctx := oslc.ContextWithTenant(context.Background(), "payments")
+	// The events of the overrides of other tenants are not delivered to the subscribers of a tenant.
+	broker.EXPECT().SubscribePackageEvents(ctx, oslc.PackageEventFilter{Tenant: "payments"}).Return(newSubscription(t, context.Canceled), nil)
+
+	err := s.WatchPackages(&oslcv1alpha.WatchPackagesRequest{}, &watchStream{ctx: ctx})
+	require.NoError(t, err)
+}
+
+func TestServer_WatchPackages_errors(t *testing.T) {
+	t.Run("not enabled", func(t *testing.T) {
+		s := Server{options: &serverOptions{Logger: slog.New(slog.NewTextHandler(io.Discard, nil))}}
+		err := s.WatchPackages(&oslcv1alpha.WatchPackagesRequest{}, &watchStream{ctx: context.Background()})
+		require.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+	t.Run("invalid distributor", func(t *testing.T) {
+		s, _ := newWatchServer(t)
+		err := s.WatchPackages(&oslcv1alpha.WatchPackagesRequest{Distributor: "unknown"}, &watchStream{ctx: context.Background()})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("send error", func(t *testing.T) {
+		s, broker := newWatchServer(t)
+		broker.EXPECT().SubscribePackageEvents(context.Background(), oslc.PackageEventFilter{}).
+			Return(newSubscription(t, nil, oslc.PackageEvent{Type: oslc.PackageEventCreated}), nil)
+		err := s.WatchPackages(&oslcv1alpha.WatchPackagesRequest{}, &watchStream{ctx: context.Background(), sendErr: assert.AnError})
+		require.ErrorIs(t, err, assert.AnError)
+	})
+
+	testcases := []struct {
+		name         string
+		subscribeErr error
+		endErr       error
+		code         codes.Code
+	}{
+		{"too many subscriptions", oslc.ErrTooManySubscriptions, nil, codes.ResourceExhausted},
+		{"broker closed on subscribe", oslc.ErrBrokerClosed, nil, codes.Unavailable},
+		{"subscribe error", assert.AnError, nil, codes.Internal},
+		{"overflow", nil, oslc.ErrSubscriptionOverflow, codes.ResourceExhausted},
+		{"broker closed", nil, oslc.ErrBrokerClosed, codes.Unavailable},
+		{"deadline exceeded", nil, context.DeadlineExceeded, codes.OK},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.name, func(t *testing.T) {
+			s, broker := newWatchServer(t)
+			if tt.subscribeErr != nil {
+				broker.EXPECT().SubscribePackageEvents(context.Background(), oslc.PackageEventFilter{}).Return(nil, tt.subscribeErr)
+			} else {
+				broker.EXPECT().SubscribePackageEvents(context.Background(), oslc.PackageEventFilter{}).Return(newSubscription(t, tt.endErr), nil)
+			}
+			err := s.WatchPackages(&oslcv1alpha.WatchPackagesRequest{}, &watchStream{ctx: context.Background()})
+			require.Equal(t, tt.code, status.Code(err))
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package policy
+
+import (
+	"github.com/chainalysis-oss/oslc/spdxnormalizer"
+)
+
+// evaluateExpression reports whether the license expression is allowed, and if not, why. Single licenses are judged by
+// allowed.
+func evaluateExpression(e spdxnormalizer.Expression, allowed func(license string) (string, bool)) (string, bool) {
+	switch e.Op {
+	case spdxnormalizer.OpWith:
+		// A license with an exception is judged by the license alone, since exceptions only grant permissions.
+		return evaluateExpression(e.Operands[0], allowed)
+	case spdxnormalizer.OpOr:
+		// OR is allowed if any operand is. The reason of the first operand is kept, so the reason for "A OR B" is that
+		// A is not allowed.
+		var reason string
+		for i, operand := range e.Operands {
+			operandReason, ok := evaluateExpression(operand, allowed)
+			if ok {
+				return "", true
+			}
+			if i == 0 {
+				reason = operandReason
+			}
+		}
+		return reason, false
+	case spdxnormalizer.OpAnd:
+		// AND is allowed if all operands are. The reason is that of the first operand that is not allowed.
+		for _, operand := range e.Operands {
+			if reason, ok := evaluateExpression(operand, allowed); !ok {
+				return reason, false
+			}
+		}
+		return "", true
+	}
+	reason, ok := allowed(e.String())
+	if ok {
+		return "", true
+	}
+	return reason, false
+}
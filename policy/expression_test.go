@@ -0,0 +1,38 @@
+package policy
+
+import (
+	"github.com/chainalysis-oss/oslc/spdxnormalizer"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestEvaluateExpression(t *testing.T) {
+	allowed := func(license string) (string, bool) {
+		return license + " denied", license == "A"
+	}
+	testcases := []struct {
+		expression string
+		reason     string
+		ok         bool
+	}{
+		{"A", "", true},
+		{"B", "B denied", false},
+		{"B+", "B+ denied", false},
+		{"B OR A", "", true},
+		{"B or C", "B denied", false},
+		{"B/A", "", true},
+		{"A AND B", "B denied", false},
+		{"A AND (B OR A)", "", true},
+		{"A WITH B", "", true},
+		{"B WITH A", "B denied", false},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.expression, func(t *testing.T) {
+			e, err := spdxnormalizer.ParseExpression(tt.expression)
+			require.NoError(t, err)
+			reason, ok := evaluateExpression(e, allowed)
+			require.Equal(t, tt.ok, ok)
+			require.Equal(t, tt.reason, reason)
+		})
+	}
+}
@@ -0,0 +1,181 @@
+// Package policy evaluates the licenses of packages against a license policy, such as one kept in a repository to be
+// enforced in CI.
+//
+// A policy is written in YAML:
+//
+//	# Licenses that are allowed. If either allow or allow_categories is set, other licenses are violations.
+//	allow: [MIT, Apache-2.0]
+//	# License categories that are allowed. See the licensecategory package for the categories.
+//	allow_categories: [permissive, public-domain]
+//	# Licenses and license categories that are violations, even if they are allowed above.
+//	deny: [SSPL-1.0]
+//	deny_categories: [network-copyleft]
+//	# Whether packages without a recognized license are allowed. Defaults to false.
+//	allow_unknown: false
+//	# Packages that are exempt from the policy.
+//	exceptions:
+//	  - distributor: npm
+//	    name: caniuse-lite
+//	    versions: ">=1.0.0"
+//	    reason: CC-BY-4.0 data, approved by legal
+//
+// Licenses may be SPDX license expressions, parsed as by [spdxnormalizer.ParseExpression]. An expression combining
+// licenses with OR is allowed if any of them is allowed, and one combining them with AND is allowed if all of them are.
+package policy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/chainalysis-oss/oslc/licensecategory"
+	"github.com/chainalysis-oss/oslc/spdxnormalizer"
+	"github.com/chainalysis-oss/oslc/versions"
+	"gopkg.in/yaml.v3"
+	"io"
+	"os"
+	"slices"
+	"strings"
+)
+
+// Policy is a license policy.
+type Policy struct {
+	Allow           []string    `yaml:"allow"`
+	AllowCategories []string    `yaml:"allow_categories"`
+	Deny            []string    `yaml:"deny"`
+	DenyCategories  []string    `yaml:"deny_categories"`
+	AllowUnknown    bool        `yaml:"allow_unknown"`
+	Exceptions      []Exception `yaml:"exceptions"`
+}
+
+// Exception exempts versions of a package from the policy.
+type Exception struct {
+	Distributor string `yaml:"distributor"`
+	Name        string `yaml:"name"`
+	// Versions is a version range, in the syntax of [versions.ParseRange], compared in the version scheme of the
+	// package's distributor. Empty matches every version.
+	Versions string `yaml:"versions"`
+	Reason   string `yaml:"reason"`
+}
+
+// Package is a package whose license is checked.
+type Package struct {
+	Distributor string
+	Name        string
+	Version     string
+	// License is an SPDX license identifier or expression, or empty if the license is unknown.
+	License string
+}
+
+// Violation is a package whose license violates the policy.
+type Violation struct {
+	Package Package
+	Reason  string
+}
+
+// ErrInvalidPolicy is returned by [Parse] and [Load] for policies that are not valid.
+var ErrInvalidPolicy = errors.New("invalid policy")
+
+// Load reads the policy from the file at path.
+func Load(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse parses a policy in YAML. Unknown fields are rejected, so that misspelled fields do not silently weaken the
+// policy.
+func Parse(data []byte) (*Policy, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	var p Policy
+	if err := decoder.Decode(&p); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPolicy, err)
+	}
+	if err := p.validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPolicy, err)
+	}
+	return &p, nil
+}
+
+func (p *Policy) validate() error {
+	for _, c := range slices.Concat(p.AllowCategories, p.DenyCategories) {
+		if !licensecategory.Valid(c) {
+			return fmt.Errorf("unknown license category %q, must be one of %s", c, strings.Join(licensecategory.All(), ", "))
+		}
+	}
+	for i, e := range p.Exceptions {
+		if e.Name == "" {
+			return fmt.Errorf("exception %d: name is required", i+1)
+		}
+		if _, err := versions.ParseRange(e.Distributor, e.Versions); err != nil {
+			return fmt.Errorf("exception %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// Check returns the violation of the policy by pkg, if any. The boolean is false if pkg complies with the policy.
+func (p *Policy) Check(pkg Package) (Violation, bool) {
+	if p.exempt(pkg) {
+		return Violation{}, false
+	}
+	if pkg.License == "" {
+		if p.AllowUnknown {
+			return Violation{}, false
+		}
+		return Violation{Package: pkg, Reason: "license is unknown"}, true
+	}
+	if reason, ok := p.evaluate(pkg.License); !ok {
+		return Violation{Package: pkg, Reason: reason}, true
+	}
+	return Violation{}, false
+}
+
+// exempt reports whether an exception applies to pkg.
+func (p *Policy) exempt(pkg Package) bool {
+	for _, e := range p.Exceptions {
+		if e.Name != pkg.Name || (e.Distributor != "" && e.Distributor != pkg.Distributor) {
+			continue
+		}
+		if ok, _ := versions.MatchRange(pkg.Distributor, e.Versions, pkg.Version); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// evaluate reports whether the license expression is allowed, and if not, why.
+func (p *Policy) evaluate(expression string) (string, bool) {
+	e, err := spdxnormalizer.ParseExpression(expression)
+	if err != nil {
+		// An expression that cannot be parsed is treated as a single license, which is only allowed if listed as is.
+		return p.licenseAllowed(expression)
+	}
+	return evaluateExpression(e, p.licenseAllowed)
+}
+
+// licenseAllowed reports whether a single license is allowed, and if not, why.
+func (p *Policy) licenseAllowed(license string) (string, bool) {
+	// A trailing plus means "this version or any later version", which is judged by the version named.
+	id := strings.TrimSuffix(license, "+")
+	category := licensecategory.Of(id)
+	switch {
+	case containsFold(p.Deny, license), containsFold(p.Deny, id):
+		return fmt.Sprintf("license %s is denied", license), false
+	case slices.Contains(p.DenyCategories, category):
+		return fmt.Sprintf("license %s is in denied category %s", license, category), false
+	case len(p.Allow) == 0 && len(p.AllowCategories) == 0:
+		return "", true
+	case containsFold(p.Allow, license), containsFold(p.Allow, id), slices.Contains(p.AllowCategories, category):
+		return "", true
+	}
+	return fmt.Sprintf("license %s is not allowed", license), false
+}
+
+func containsFold(s []string, v string) bool {
+	return slices.ContainsFunc(s, func(e string) bool {
+		return strings.EqualFold(e, v)
+	})
+}
@@ -0,0 +1,125 @@
+package policy
+
+import (
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	p, err := Parse([]byte(`
+allow: [MIT]
+allow_categories: [permissive]
+deny: [SSPL-1.0]
+deny_categories: [network-copyleft]
+allow_unknown: true
+exceptions:
+  - distributor: npm
+    name: caniuse-lite
+    versions: ">=1.0.0"
+    reason: approved by legal
+`))
+	require.NoError(t, err)
+	require.Equal(t, &Policy{
+		Allow:           []string{"MIT"},
+		AllowCategories: []string{"permissive"},
+		Deny:            []string{"SSPL-1.0"},
+		DenyCategories:  []string{"network-copyleft"},
+		AllowUnknown:    true,
+		Exceptions:      []Exception{{Distributor: "npm", Name: "caniuse-lite", Versions: ">=1.0.0", Reason: "approved by legal"}},
+	}, p)
+
+	p, err = Parse(nil)
+	require.NoError(t, err)
+	require.Equal(t, &Policy{}, p)
+}
+
+func TestParse_invalid(t *testing.T) {
+	testcases := []struct {
+		name   string
+		policy string
+	}{
+		{"unknown field", "alow: [MIT]"},
+		{"unknown category", "allow_categories: [free]"},
+		{"unknown deny category", "deny_categories: [viral]"},
+		{"exception without name", "exceptions: [{distributor: npm}]"},
+		{"invalid exception range", "exceptions: [{name: lodash, versions: '>=1,'}]"},
+		{"not yaml", "allow: [MIT"},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.policy))
+			require.ErrorIs(t, err, ErrInvalidPolicy)
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("deny: [GPL-3.0-only]"), 0o600))
+	p, err := Load(path)
+	require.NoError(t, err)
+	require.Equal(t, []string{"GPL-3.0-only"}, p.Deny)
+
+	_, err = Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestPolicy_Check(t *testing.T) {
+	p := &Policy{
+		Allow:           []string{"CC-BY-SA-4.0"},
+		AllowCategories: []string{"permissive", "public-domain"},
+		Deny:            []string{"Zlib"},
+		DenyCategories:  []string{"network-copyleft"},
+		Exceptions: []Exception{
+			{Distributor: "npm", Name: "caniuse-lite", Versions: ">=1.0.0"},
+			{Name: "certifi"},
+		},
+	}
+	testcases := []struct {
+		name   string
+		pkg    Package
+		reason string
+	}{
+		{"allowed category", Package{Distributor: "npm", Name: "lodash", License: "MIT"}, ""},
+		{"allowed license", Package{Distributor: "npm", Name: "a", License: "CC-BY-SA-4.0"}, ""},
+		{"allowed license case insensitive", Package{Distributor: "npm", Name: "a", License: "cc-by-sa-4.0"}, ""},
+		{"not allowed", Package{Distributor: "npm", Name: "a", License: "GPL-3.0-only"}, "license GPL-3.0-only is not allowed"},
+		{"denied license", Package{Distributor: "npm", Name: "a", License: "Zlib"}, "license Zlib is denied"},
+		{"denied category", Package{Distributor: "npm", Name: "a", License: "AGPL-3.0-only"}, "license AGPL-3.0-only is in denied category network-copyleft"},
+		{"unknown", Package{Distributor: "npm", Name: "a"}, "license is unknown"},
+		{"or allowed", Package{Distributor: "npm", Name: "a", License: "GPL-3.0-only OR MIT"}, ""},
+		{"or not allowed", Package{Distributor: "npm", Name: "a", License: "GPL-3.0-only OR LGPL-2.1-only"}, "license GPL-3.0-only is not allowed"},
+		{"and not allowed", Package{Distributor: "npm", Name: "a", License: "MIT AND GPL-3.0-only"}, "license GPL-3.0-only is not allowed"},
+		{"and allowed", Package{Distributor: "npm", Name: "a", License: "(MIT AND ISC) OR GPL-3.0-only"}, ""},
+		{"with", Package{Distributor: "npm", Name: "a", License: "Apache-2.0 WITH LLVM-exception"}, ""},
+		{"plus", Package{Distributor: "npm", Name: "a", License: "Apache-2.0+"}, ""},
+		{"unparseable", Package{Distributor: "npm", Name: "a", License: "MIT OR"}, "license MIT OR is not allowed"},
+		{"exception", Package{Distributor: "npm", Name: "caniuse-lite", Version: "1.0.30001", License: "CC-BY-4.0 AND GPL-3.0-only"}, ""},
+		{"exception other version", Package{Distributor: "npm", Name: "caniuse-lite", Version: "0.9.0"}, "license is unknown"},
+		{"exception other distributor", Package{Distributor: "pypi", Name: "caniuse-lite", Version: "1.0.0"}, "license is unknown"},
+		{"exception any distributor", Package{Distributor: "pypi", Name: "certifi", License: "MPL-2.0"}, ""},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.name, func(t *testing.T) {
+			violation, violated := p.Check(tt.pkg)
+			require.Equal(t, tt.reason != "", violated)
+			if violated {
+				require.Equal(t, Violation{Package: tt.pkg, Reason: tt.reason}, violation)
+			}
+		})
+	}
+}
+
+func TestPolicy_Check_defaults(t *testing.T) {
+	p := &Policy{}
+	_, violated := p.Check(Package{Name: "a", License: "GPL-3.0-only"})
+	require.False(t, violated)
+	_, violated = p.Check(Package{Name: "a"})
+	require.True(t, violated)
+
+	p.AllowUnknown = true
+	_, violated = p.Check(Package{Name: "a"})
+	require.False(t, violated)
+}
@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// The statistics of the connection pools of the datastore are exported per pool, which is either the primary or the
+// replica pool.
+var (
+	poolAcquiredConnsDesc = prometheus.NewDesc("oslc_postgres_pool_acquired_conns",
+		"Number of connections of the pool that are in use.", []string{"pool"}, nil)
+	poolIdleConnsDesc = prometheus.NewDesc("oslc_postgres_pool_idle_conns",
+		"Number of idle connections of the pool.", []string{"pool"}, nil)
+	poolTotalConnsDesc = prometheus.NewDesc("oslc_postgres_pool_total_conns",
+		"Number of connections of the pool, including connections that are being established.", []string{"pool"}, nil)
+	poolMaxConnsDesc = prometheus.NewDesc("oslc_postgres_pool_max_conns",
+		"Maximum number of connections of the pool.", []string{"pool"}, nil)
+	poolAcquiresDesc = prometheus.NewDesc("oslc_postgres_pool_acquires_total",
+		"Number of connections acquired from the pool.", []string{"pool"}, nil)
+	poolAcquireDurationDesc = prometheus.NewDesc("oslc_postgres_pool_acquire_duration_seconds_total",
+		"Total time spent acquiring connections from the pool.", []string{"pool"}, nil)
+	poolEmptyAcquiresDesc = prometheus.NewDesc("oslc_postgres_pool_empty_acquires_total",
+		"Number of acquires that waited for a connection because the pool had no idle connection.", []string{"pool"}, nil)
+	poolCanceledAcquiresDesc = prometheus.NewDesc("oslc_postgres_pool_canceled_acquires_total",
+		"Number of acquires that were canceled before a connection was acquired.", []string{"pool"}, nil)
+)
+
+// Compile time check to ensure Datastore implements [prometheus.Collector].
+var _ prometheus.Collector = (*Datastore)(nil)
+
+// Describe implements [prometheus.Collector].
+func (d *Datastore) Describe(ch chan<- *prometheus.Desc) {
+	ch <- poolAcquiredConnsDesc
+	ch <- poolIdleConnsDesc
+	ch <- poolTotalConnsDesc
+	ch <- poolMaxConnsDesc
+	ch <- poolAcquiresDesc
+	ch <- poolAcquireDurationDesc
+	ch <- poolEmptyAcquiresDesc
+	ch <- poolCanceledAcquiresDesc
+}
+
+// Collect implements [prometheus.Collector]. It exports the statistics of the primary pool, and of the replica pool if
+// there is one.
+func (d *Datastore) Collect(ch chan<- prometheus.Metric) {
+	pools := map[string]Pool{"primary": d.options.Pool}
+	if d.options.ReplicaPool != nil {
+		pools["replica"] = d.options.ReplicaPool
+	}
+	for name, p := range pools {
+		stat := p.Stat()
+		ch <- prometheus.MustNewConstMetric(poolAcquiredConnsDesc, prometheus.GaugeValue, float64(stat.AcquiredConns()), name)
+		ch <- prometheus.MustNewConstMetric(poolIdleConnsDesc, prometheus.GaugeValue, float64(stat.IdleConns()), name)
+		ch <- prometheus.MustNewConstMetric(poolTotalConnsDesc, prometheus.GaugeValue, float64(stat.TotalConns()), name)
+		ch <- prometheus.MustNewConstMetric(poolMaxConnsDesc, prometheus.GaugeValue, float64(stat.MaxConns()), name)
+		ch <- prometheus.MustNewConstMetric(poolAcquiresDesc, prometheus.CounterValue, float64(stat.AcquireCount()), name)
+		ch <- prometheus.MustNewConstMetric(poolAcquireDurationDesc, prometheus.CounterValue, stat.AcquireDuration().Seconds(), name)
+		ch <- prometheus.MustNewConstMetric(poolEmptyAcquiresDesc, prometheus.CounterValue, float64(stat.EmptyAcquireCount()), name)
+		ch <- prometheus.MustNewConstMetric(poolCanceledAcquiresDesc, prometheus.CounterValue, float64(stat.CanceledAcquireCount()), name)
+	}
+}
@@ -0,0 +1,82 @@
+package postgres
+
+import (
+	"context"
+	"github.com/chainalysis-oss/oslc"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"time"
+)
+
+// Compile time check to ensure Datastore implements [oslc.CurationStore].
+var _ oslc.CurationStore = (*Datastore)(nil)
+
+// overrideAttributes returns the span attributes identifying the license override with the provided tenant,
+// distributor, name and version range.
+func overrideAttributes(tenant, distributor, name, versionRange string) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("oslc.tenant", tenant),
+		attribute.String("oslc.distributor", distributor),
+		attribute.String("oslc.package.name", name),
+		attribute.String("oslc.override.version_range", versionRange),
+	}
+}
+
+var datastoreSetOverrideStatement = "INSERT INTO license_overrides (tenant, distributor, name, version_range, license, justification, author) VALUES ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT ON CONSTRAINT license_overrides_pk DO UPDATE SET license = $5, justification = $6, author = $7, updated_at = now() RETURNING updated_at"
+
+func (d *Datastore) SetOverride(ctx context.Context, override oslc.LicenseOverride) (_ oslc.LicenseOverride, err error) {
+	ctx, span := d.startSpan(ctx, "INSERT", datastoreSetOverrideStatement, overrideAttributes(override.Tenant, override.Distributor, override.Name, override.VersionRange)...)
+	defer func() { endSpan(span, err) }()
+
+	rows, err := d.options.Pool.Query(ctx, datastoreSetOverrideStatement, override.Tenant, override.Distributor, override.Name, override.VersionRange, override.License, override.Justification, override.Author)
+	if err != nil {
+		return oslc.LicenseOverride{}, err
+	}
+	override.UpdatedAt, err = pgx.CollectExactlyOneRow(rows, pgx.RowTo[time.Time])
+	if err != nil {
+		return oslc.LicenseOverride{}, err
+	}
+	return override, nil
+}
+
+var datastoreListOverridesStatement = "SELECT tenant, distributor, name, version_range, license, justification, author, updated_at FROM license_overrides WHERE tenant = $1 AND ($2 = '' OR distributor = $2) AND ($3 = '' OR name = $3) ORDER BY updated_at DESC"
+
+func (d *Datastore) ListOverrides(ctx context.Context, tenant, distributor, name string) (_ []oslc.LicenseOverride, err error) {
+	ctx, span := d.startSpan(ctx, "SELECT", datastoreListOverridesStatement,
+		attribute.String("oslc.tenant", tenant),
+		attribute.String("oslc.distributor", distributor),
+		attribute.String("oslc.package.name", name),
+	)
+	defer func() { endSpan(span, err) }()
+
+	rows, err := d.options.Pool.Query(ctx, datastoreListOverridesStatement, tenant, distributor, name)
+	if err != nil {
+		return nil, err
+	}
+	var o oslc.LicenseOverride
+	overrides := make([]oslc.LicenseOverride, 0)
+	_, err = pgx.ForEachRow(rows, []any{&o.Tenant, &o.Distributor, &o.Name, &o.VersionRange, &o.License, &o.Justification, &o.Author, &o.UpdatedAt}, func() error {
+		overrides = append(overrides, o)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return overrides, nil
+}
+
+var datastoreDeleteOverrideStatement = "DELETE FROM license_overrides WHERE tenant = $1 AND distributor = $2 AND name = $3 AND version_range = $4"
+
+func (d *Datastore) DeleteOverride(ctx context.Context, tenant, distributor, name, versionRange string) (err error) {
+	ctx, span := d.startSpan(ctx, "DELETE", datastoreDeleteOverrideStatement, overrideAttributes(tenant, distributor, name, versionRange)...)
+	defer func() { endSpan(span, err) }()
+
+	tag, err := d.options.Pool.Exec(ctx, datastoreDeleteOverrideStatement, tenant, distributor, name, versionRange)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return oslc.ErrDatastoreObjectNotFound
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package postgres
+
+import (
+	"context"
+	"github.com/chainalysis-oss/oslc"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestDatastore_SetOverride(t *testing.T) {
+	mock := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(mock))
+	require.NoError(t, err)
+	updatedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	mock.ExpectQuery(datastoreSetOverrideStatement).
+		WithArgs("payments", oslc.DistributorPypi, "test", ">=1.0.0", "MIT", "license field contains the full text", "legal").
+		WillReturnRows(mock.NewRows([]string{"updated_at"}).AddRow(updatedAt)).
+		Times(1)
+	override, err := ds.SetOverride(context.Background(), oslc.LicenseOverride{
+		Tenant:        "payments",
+		Distributor:   oslc.DistributorPypi,
+		Name:          "test",
+		VersionRange:  ">=1.0.0",
+		License:       "MIT",
+		Justification: "license field contains the full text",
+		Author:        "legal",
+	})
+	require.NoError(t, err)
+	require.Equal(t, oslc.LicenseOverride{
+		Tenant:        "payments",
+		Distributor:   oslc.DistributorPypi,
+		Name:          "test",
+		VersionRange:  ">=1.0.0",
+		License:       "MIT",
+		Justification: "license field contains the full text",
+		Author:        "legal",
+		UpdatedAt:     updatedAt,
+	}, override)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatastore_SetOverride_ErrQuery(t *testing.T) {
+	mock := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(mock))
+	require.NoError(t, err)
+	mock.ExpectQuery(datastoreSetOverrideStatement).
+		WithArgs("", "", "", "", "", "", "").
+		WillReturnError(assert.AnError)
+	_, err = ds.SetOverride(context.Background(), oslc.LicenseOverride{})
+	require.ErrorIs(t, err, assert.AnError)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatastore_SetOverride_ErrNoRows(t *testing.T) {
+	mock := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(mock))
+	require.NoError(t, err)
+	mock.ExpectQuery(datastoreSetOverrideStatement).
+		WithArgs("", "", "", "", "", "", "").
+		WillReturnRows(mock.NewRows([]string{"updated_at"}))
+	_, err = ds.SetOverride(context.Background(), oslc.LicenseOverride{})
+	require.Error(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatastore_ListOverrides(t *testing.T) {
+	mock := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(mock))
+	require.NoError(t, err)
+	updatedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	mock.ExpectQuery(datastoreListOverridesStatement).
+		WithArgs("payments", oslc.DistributorNpm, "").
+		WillReturnRows(mock.NewRows([]string{"tenant", "distributor", "name", "version_range", "license", "justification", "author", "updated_at"}).
+			AddRow("payments", oslc.DistributorNpm, "a", "*", "MIT", "j1", "author1", updatedAt).
+			AddRow("payments", oslc.DistributorNpm, "b", "1.0.0", "Apache-2.0", "j2", "author2", updatedAt)).
+		Times(1)
+	overrides, err := ds.ListOverrides(context.Background(), "payments", oslc.DistributorNpm, "")
+	require.NoError(t, err)
+	require.Equal(t, []oslc.LicenseOverride{
+		{Tenant: "payments", Distributor: oslc.DistributorNpm, Name: "a", VersionRange: "*", License: "MIT", Justification: "j1", Author: "author1", UpdatedAt: updatedAt},
+		{Tenant: "payments", Distributor: oslc.DistributorNpm, Name: "b", VersionRange: "1.0.0", License: "Apache-2.0", Justification: "j2", Author: "author2", UpdatedAt: updatedAt},
+	}, overrides)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatastore_ListOverrides_empty(t *testing.T) {
+	mock := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(mock))
+	require.NoError(t, err)
+	mock.ExpectQuery(datastoreListOverridesStatement).
+		WithArgs("", "", "").
+		WillReturnRows(mock.NewRows([]string{"tenant", "distributor", "name", "version_range", "license", "justification", "author", "updated_at"}))
+	overrides, err := ds.ListOverrides(context.Background(), "", "", "")
+	require.NoError(t, err)
+	require.Empty(t, overrides)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatastore_ListOverrides_ErrQuery(t *testing.T) {
+	mock := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(mock))
+	require.NoError(t, err)
+	mock.ExpectQuery(datastoreListOverridesStatement).
+		WithArgs("", "", "").
+		WillReturnError(assert.AnError)
+	_, err = ds.ListOverrides(context.Background(), "", "", "")
+	require.ErrorIs(t, err, assert.AnError)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatastore_ListOverrides_ErrRows(t *testing.T) {
+	mock := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(mock))
+	require.NoError(t, err)
+	mock.ExpectQuery(datastoreListOverridesStatement).
+		WithArgs("", "", "").
+		// intentionally return a row that cannot be scanned, forcing the code to return an error.
+		WillReturnRows(mock.NewRows([]string{"distributor"}).AddRow(oslc.DistributorNpm))
+	_, err = ds.ListOverrides(context.Background(), "", "", "")
+	require.Error(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatastore_DeleteOverride(t *testing.T) {
+	mock := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(mock))
+	require.NoError(t, err)
+	mock.ExpectExec(datastoreDeleteOverrideStatement).
+		WithArgs("payments", oslc.DistributorMaven, "g:a", "*").
+		WillReturnResult(pgxmock.NewResult("DELETE", 1)).
+		Times(1)
+	err = ds.DeleteOverride(context.Background(), "payments", oslc.DistributorMaven, "g:a", "*")
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatastore_DeleteOverride_ErrNotFound(t *testing.T) {
+	mock := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(mock))
+	require.NoError(t, err)
+	mock.ExpectExec(datastoreDeleteOverrideStatement).
+		WithArgs("", oslc.DistributorMaven, "g:a", "*").
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	err = ds.DeleteOverride(context.Background(), "", oslc.DistributorMaven, "g:a", "*")
+	require.ErrorIs(t, err, oslc.ErrDatastoreObjectNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatastore_DeleteOverride_ErrExec(t *testing.T) {
+	mock := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(mock))
+	require.NoError(t, err)
+	mock.ExpectExec(datastoreDeleteOverrideStatement).
+		WithArgs("", oslc.DistributorMaven, "g:a", "*").
+		WillReturnError(assert.AnError)
+	err = ds.DeleteOverride(context.Background(), "", oslc.DistributorMaven, "g:a", "*")
+	require.ErrorIs(t, err, assert.AnError)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -5,9 +5,20 @@ import (
 	"errors"
 	"github.com/chainalysis-oss/oslc"
 	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 	"log/slog"
+	"regexp"
+	"strings"
+	"time"
 )
 
+// tracerName is the name of the tracer used to create spans for datastore operations.
+const tracerName = "github.com/chainalysis-oss/oslc/postgres"
+
 type Datastore struct {
 	options *datastoreOptions
 }
@@ -31,12 +42,15 @@ func NewDatastore(options ...DatastoreOption) (*Datastore, error) {
 }
 
 type datastoreOptions struct {
-	Logger *slog.Logger
-	Pool   Pool
+	Logger         *slog.Logger
+	Pool           Pool
+	ReplicaPool    Pool
+	TracerProvider trace.TracerProvider
 }
 
 var defaultDatastoreOptions = datastoreOptions{
-	Logger: slog.Default(),
+	Logger:         slog.Default(),
+	TracerProvider: otel.GetTracerProvider(),
 }
 
 var globalDatastoreOptions []DatastoreOption
@@ -71,17 +85,109 @@ func WithPool(pool Pool) DatastoreOption {
 	})
 }
 
-var datastoreSaveStatement = "INSERT INTO packages (name, license, version, distributor, distribution_url) VALUES ($1, $2, $3, $4, $5) ON CONFLICT ON CONSTRAINT packages_pk DO UPDATE SET license = $2, distribution_url = $5"
+// WithReplicaPool sets the pool of a read replica of the database. If set, Retrieve and RetrieveVersions query the
+// replica, so lookups do not load the primary. Everything else, including Save, uses the primary pool, so entries may
+// not be retrievable until they are replicated.
+func WithReplicaPool(pool Pool) DatastoreOption {
+	return newFuncDatastoreOption(func(opts *datastoreOptions) {
+		opts.ReplicaPool = pool
+	})
+}
+
+func WithTracerProvider(tp trace.TracerProvider) DatastoreOption {
+	return newFuncDatastoreOption(func(opts *datastoreOptions) {
+		opts.TracerProvider = tp
+	})
+}
+
+// readPool returns the pool that lookups are queried with: the replica pool if there is one, and the primary pool
+// otherwise.
+func (d *Datastore) readPool() Pool {
+	if d.options.ReplicaPool != nil {
+		return d.options.ReplicaPool
+	}
+	return d.options.Pool
+}
+
+// startSpan starts a client span for a datastore operation. The provided attributes are added to the span in addition
+// to the database attributes.
+func (d *Datastore) startSpan(ctx context.Context, operation, statement string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return d.options.TracerProvider.Tracer(tracerName).Start(ctx, "postgres "+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBOperationName(operation),
+			semconv.DBQueryText(statement),
+		),
+		trace.WithAttributes(attrs...),
+	)
+}
+
+// packageAttributes returns the span attributes identifying the package with the provided name and version.
+func packageAttributes(name, version string) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("oslc.package.name", name),
+		attribute.String("oslc.package.version", version),
+	}
+}
+
+// endSpan records err on span, if any, and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Entries are stored in three tables: packages holds a row per distributor and package name, package_versions a row per
+// version of a package with its license, and distribution_points the distribution points of a version, in order.
+var (
+	datastoreSaveVersionStatement              = "WITH p AS (INSERT INTO packages (distributor, name) VALUES ($1, $2) ON CONFLICT ON CONSTRAINT packages_distributor_name_key DO UPDATE SET name = excluded.name RETURNING id) INSERT INTO package_versions (package_id, version, license, raw_license, normalization_status, license_list_version) SELECT id, $3, $4, $5, $6, $7 FROM p ON CONFLICT ON CONSTRAINT package_versions_package_id_version_key DO UPDATE SET license = excluded.license, raw_license = excluded.raw_license, normalization_status = excluded.normalization_status, license_list_version = excluded.license_list_version, fetched_at = now() RETURNING id"
+	datastoreDeleteDistributionPointsStatement = "DELETE FROM distribution_points WHERE version_id = $1"
+	datastoreSaveDistributionPointsStatement   = "INSERT INTO distribution_points (version_id, position, name, url, distributor, kind, checksum) SELECT $1, dp.position - 1, dp.name, dp.url, dp.distributor, dp.kind, dp.checksum FROM unnest($2::text[], $3::text[], $4::text[], $5::text[], $6::text[]) WITH ORDINALITY AS dp(name, url, distributor, kind, checksum, position)"
+)
+
+// Save stores the entry under the distributor of each of its distribution points, so it can be retrieved with any of
+// them. Every stored copy holds all distribution points of the entry. Entries without distribution points are not
+// stored, since they have no distributor.
+func (d *Datastore) Save(ctx context.Context, entry oslc.Entry) (err error) {
+	ctx, span := d.startSpan(ctx, "INSERT", datastoreSaveVersionStatement, packageAttributes(entry.Name, entry.Version)...)
+	defer func() { endSpan(span, err) }()
 
-func (d *Datastore) Save(ctx context.Context, entry oslc.Entry) error {
 	tx, err := d.options.Pool.Begin(ctx)
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback(ctx)
 
+	var names, urls, distributors, kinds, checksums []string
 	for _, dp := range entry.DistributionPoints {
-		_, err = tx.Exec(ctx, datastoreSaveStatement, entry.Name, entry.License, entry.Version, dp.Distributor, dp.URL)
+		names = append(names, dp.Name)
+		urls = append(urls, dp.URL)
+		distributors = append(distributors, dp.Distributor)
+		kinds = append(kinds, dp.Kind)
+		checksums = append(checksums, dp.Checksum)
+	}
+
+	saved := make(map[string]bool)
+	for _, distributor := range distributors {
+		if saved[distributor] {
+			continue
+		}
+		saved[distributor] = true
+
+		var versionID int64
+		err = tx.QueryRow(ctx, datastoreSaveVersionStatement, distributor, entry.Name, entry.Version, entry.License,
+			entry.RawLicense, string(entry.NormalizationStatus), entry.LicenseListVersion).Scan(&versionID)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(ctx, datastoreDeleteDistributionPointsStatement, versionID)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(ctx, datastoreSaveDistributionPointsStatement, versionID, names, urls, distributors, kinds, checksums)
 		if err != nil {
 			return err
 		}
@@ -94,42 +200,221 @@ func (d *Datastore) Save(ctx context.Context, entry oslc.Entry) error {
 	return nil
 }
 
-var datastoreRetrieveStatement = "SELECT license, distribution_url FROM packages WHERE name = $1 AND version = $2 AND distributor = $3"
+var datastoreRetrieveStatement = "SELECT v.license, v.raw_license, v.normalization_status, v.license_list_version, d.name, d.url, d.distributor, d.kind, d.checksum FROM packages p JOIN package_versions v ON v.package_id = p.id JOIN distribution_points d ON d.version_id = v.id WHERE p.name = $1 AND v.version = $2 AND p.distributor = $3 ORDER BY d.position"
 
-func (d *Datastore) Retrieve(ctx context.Context, name, version, distributor string) (oslc.Entry, error) {
-	rows, err := d.options.Pool.Query(ctx, datastoreRetrieveStatement, name, version, distributor)
+func (d *Datastore) Retrieve(ctx context.Context, name, version, distributor string) (_ oslc.Entry, err error) {
+	ctx, span := d.startSpan(ctx, "SELECT", datastoreRetrieveStatement,
+		append(packageAttributes(name, version), attribute.String("oslc.distributor", distributor))...)
+	defer func() {
+		// Not finding an entry is expected for every cache miss, so it is not recorded as an error on the span.
+		if errors.Is(err, oslc.ErrDatastoreObjectNotFound) {
+			endSpan(span, nil)
+			return
+		}
+		endSpan(span, err)
+	}()
+
+	rows, err := d.readPool().Query(ctx, datastoreRetrieveStatement, name, version, distributor)
 	if err != nil {
 		return oslc.Entry{}, err
 	}
 	var entry oslc.Entry
-	var license string
-	var url string
-
-	dp := make([]oslc.DistributionPoint, 0)
-	_, err = pgx.ForEachRow(rows, []any{&license, &url}, func() error {
-		dp = append(dp, oslc.DistributionPoint{
-			Name:        name,
-			URL:         url,
-			Distributor: distributor,
-		})
+	var license, rawLicense, status, listVersion string
+	var dp oslc.DistributionPoint
+
+	dps := make([]oslc.DistributionPoint, 0)
+	_, err = pgx.ForEachRow(rows, []any{&license, &rawLicense, &status, &listVersion, &dp.Name, &dp.URL, &dp.Distributor, &dp.Kind, &dp.Checksum}, func() error {
+		dps = append(dps, dp)
 		return nil
 	})
 	if err != nil {
 		return oslc.Entry{}, err
 	}
 
-	if len(dp) == 0 {
+	if len(dps) == 0 {
 		return oslc.Entry{}, oslc.ErrDatastoreObjectNotFound
 	}
 
 	entry = oslc.Entry{
-		Name:               name,
-		DistributionPoints: dp,
-		License:            license,
-		Version:            version,
+		Name:                name,
+		DistributionPoints:  dps,
+		License:             license,
+		Version:             version,
+		RawLicense:          rawLicense,
+		NormalizationStatus: oslc.LicenseNormalizationStatus(status),
+		LicenseListVersion:  listVersion,
 	}
 
 	return entry, nil
 }
 
+var datastoreRetrieveVersionsStatement = "SELECT v.version, v.license, v.raw_license, v.normalization_status, v.license_list_version, d.name, d.url, d.distributor, d.kind, d.checksum FROM packages p JOIN package_versions v ON v.package_id = p.id JOIN distribution_points d ON d.version_id = v.id WHERE p.name = $1 AND p.distributor = $2 ORDER BY v.id, d.position"
+
+func (d *Datastore) RetrieveVersions(ctx context.Context, name, distributor string) (_ []oslc.Entry, err error) {
+	ctx, span := d.startSpan(ctx, "SELECT", datastoreRetrieveVersionsStatement,
+		attribute.String("oslc.package.name", name),
+		attribute.String("oslc.distributor", distributor),
+	)
+	defer func() { endSpan(span, err) }()
+
+	rows, err := d.readPool().Query(ctx, datastoreRetrieveVersionsStatement, name, distributor)
+	if err != nil {
+		return nil, err
+	}
+	var version, license, rawLicense, status, listVersion string
+	var dp oslc.DistributionPoint
+	entries := make([]oslc.Entry, 0)
+	_, err = pgx.ForEachRow(rows, []any{&version, &license, &rawLicense, &status, &listVersion, &dp.Name, &dp.URL, &dp.Distributor, &dp.Kind, &dp.Checksum}, func() error {
+		// The rows of a version are adjacent, so a distribution point belongs to the last entry if the versions match.
+		if n := len(entries); n > 0 && entries[n-1].Version == version {
+			entries[n-1].DistributionPoints = append(entries[n-1].DistributionPoints, dp)
+			return nil
+		}
+		entries = append(entries, oslc.Entry{
+			Name:                name,
+			DistributionPoints:  []oslc.DistributionPoint{dp},
+			License:             license,
+			Version:             version,
+			RawLicense:          rawLicense,
+			NormalizationStatus: oslc.LicenseNormalizationStatus(status),
+			LicenseListVersion:  listVersion,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+var datastoreInvalidateStatement = `DELETE FROM package_versions v USING packages p WHERE v.package_id = p.id AND ($1 = '' OR p.distributor = $1) AND ($2 = '' OR p.name LIKE $2 ESCAPE '\') AND ($3 = '' OR v.version = $3) AND ($4 = false OR v.license = $5)`
+
+// namePatternToLike converts a name pattern, as used by [oslc.EntryFilter], to a pattern for the SQL LIKE operator.
+func namePatternToLike(pattern string) string {
+	return strings.ReplaceAll(escapeLike(pattern), "*", "%")
+}
+
+func (d *Datastore) Invalidate(ctx context.Context, filter oslc.EntryFilter) (_ int64, err error) {
+	var license string
+	if filter.License != nil {
+		license = *filter.License
+	}
+	ctx, span := d.startSpan(ctx, "DELETE", datastoreInvalidateStatement,
+		attribute.String("oslc.distributor", filter.Distributor),
+		attribute.String("oslc.package.name", filter.NamePattern),
+		attribute.String("oslc.package.version", filter.Version),
+	)
+	defer func() { endSpan(span, err) }()
+
+	tag, err := d.options.Pool.Exec(ctx, datastoreInvalidateStatement, filter.Distributor, namePatternToLike(filter.NamePattern), filter.Version, filter.License != nil, license)
+	if err != nil {
+		return 0, err
+	}
+	span.SetAttributes(attribute.Int64("db.response.affected_rows", tag.RowsAffected()))
+	return tag.RowsAffected(), nil
+}
+
+var datastoreSearchStatement = `SELECT p.name, v.version, p.distributor, v.license, v.raw_license, v.normalization_status, v.license_list_version, v.fetched_at, array_agg(d.name ORDER BY d.position), array_agg(d.url ORDER BY d.position), array_agg(d.distributor ORDER BY d.position), array_agg(d.kind ORDER BY d.position), array_agg(d.checksum ORDER BY d.position) FROM packages p JOIN package_versions v ON v.package_id = p.id JOIN distribution_points d ON d.version_id = v.id WHERE ($1 = '' OR p.distributor = $1) AND ($2 = '' OR p.name LIKE $2 ESCAPE '\') AND ($3 = '' OR p.name LIKE $3 ESCAPE '\') AND ($4 = false OR v.license = $5 OR ($6 AND v.license ~ $7)) AND ($8::timestamptz IS NULL OR v.fetched_at >= $8) AND ($9::timestamptz IS NULL OR v.fetched_at < $9) AND ($10 = false OR (p.distributor, p.name, v.version) > ($11, $12, $13)) GROUP BY p.id, v.id ORDER BY p.distributor, p.name, v.version LIMIT $14`
+
+// escapeLike escapes the special characters of the SQL LIKE operator in s.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// licenseExpressionPattern returns a regular expression matching license expressions that contain the license
+// identifier as an operand, such as "(MIT OR Apache-2.0) AND BSD-3-Clause" for "MIT".
+func licenseExpressionPattern(license string) string {
+	return `(^|[ (])` + regexp.QuoteMeta(license) + `($|[ )])`
+}
+
+// nullTime returns nil for the zero time, so it is passed to the database as NULL.
+func nullTime(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
+
+func (d *Datastore) Search(ctx context.Context, query oslc.SearchQuery) (_ []oslc.StoredEntry, err error) {
+	ctx, span := d.startSpan(ctx, "SELECT", datastoreSearchStatement, attribute.String("oslc.distributor", query.Distributor))
+	defer func() { endSpan(span, err) }()
+
+	var prefix, contains, license, expression string
+	if query.NamePrefix != "" {
+		prefix = escapeLike(query.NamePrefix) + "%"
+	}
+	if query.NameContains != "" {
+		contains = "%" + escapeLike(query.NameContains) + "%"
+	}
+	if query.License != nil {
+		license = *query.License
+		expression = licenseExpressionPattern(license)
+	}
+	// An empty license is never part of an expression.
+	inExpression := query.License != nil && query.LicenseInExpression && license != ""
+	var after oslc.SearchCursor
+	if query.After != nil {
+		after = *query.After
+	}
+
+	rows, err := d.options.Pool.Query(ctx, datastoreSearchStatement,
+		query.Distributor, prefix, contains,
+		query.License != nil, license, inExpression, expression,
+		nullTime(query.FetchedAfter), nullTime(query.FetchedBefore),
+		query.After != nil, after.Distributor, after.Name, after.Version,
+		query.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	var e oslc.StoredEntry
+	var status string
+	var names, urls, distributors, kinds, checksums []string
+	entries := make([]oslc.StoredEntry, 0)
+	_, err = pgx.ForEachRow(rows, []any{&e.Name, &e.Version, &e.Distributor, &e.License, &e.RawLicense, &status, &e.LicenseListVersion, &e.FetchedAt, &names, &urls, &distributors, &kinds, &checksums}, func() error {
+		e.NormalizationStatus = oslc.LicenseNormalizationStatus(status)
+		e.DistributionPoints = make([]oslc.DistributionPoint, len(names))
+		for i := range names {
+			e.DistributionPoints[i] = oslc.DistributionPoint{
+				Name:        names[i],
+				URL:         urls[i],
+				Distributor: distributors[i],
+				Kind:        kinds[i],
+				Checksum:    checksums[i],
+			}
+		}
+		entries = append(entries, e)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int("db.response.returned_rows", len(entries)))
+	return entries, nil
+}
+
+var datastoreCountPackagesStatement = "SELECT p.distributor, v.license, v.normalization_status, count(*) FROM packages p JOIN package_versions v ON v.package_id = p.id GROUP BY p.distributor, v.license, v.normalization_status"
+
+func (d *Datastore) CountPackages(ctx context.Context) (_ []oslc.PackageCount, err error) {
+	ctx, span := d.startSpan(ctx, "SELECT", datastoreCountPackagesStatement)
+	defer func() { endSpan(span, err) }()
+
+	rows, err := d.options.Pool.Query(ctx, datastoreCountPackagesStatement)
+	if err != nil {
+		return nil, err
+	}
+	var c oslc.PackageCount
+	var status string
+	counts := make([]oslc.PackageCount, 0)
+	_, err = pgx.ForEachRow(rows, []any{&c.Distributor, &c.License, &status, &c.Count}, func() error {
+		c.NormalizationStatus = oslc.LicenseNormalizationStatus(status)
+		counts = append(counts, c)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
 var ErrMissingOptionPool = errors.New("missing option: pool")
@@ -6,9 +6,16 @@ import (
 	"github.com/pashagolub/pgxmock/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
 	"log/slog"
 	"os"
+	"regexp"
 	"testing"
+	"time"
 )
 
 func newPoolMock(t *testing.T) pgxmock.PgxPoolIface {
@@ -82,14 +89,56 @@ func TestWithPool(t *testing.T) {
 	require.Equal(t, mock, opts.Pool)
 }
 
+func TestWithReplicaPool(t *testing.T) {
+	mock := newPoolMock(t)
+	opts := datastoreOptions{}
+	f := WithReplicaPool(mock)
+	f.apply(&opts)
+	require.Equal(t, mock, opts.ReplicaPool)
+}
+
+func TestDatastore_replica(t *testing.T) {
+	primary := newPoolMock(t)
+	replica := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(primary), WithReplicaPool(replica))
+	require.NoError(t, err)
+	replica.ExpectQuery(datastoreRetrieveStatement).
+		WithArgs("test", "1.0.0", "npm").
+		WillReturnRows(replica.NewRows([]string{"license", "raw_license", "normalization_status", "license_list_version", "name", "url", "distributor", "kind", "checksum"}))
+	replica.ExpectQuery(datastoreRetrieveVersionsStatement).
+		WithArgs("test", "npm").
+		WillReturnRows(replica.NewRows([]string{"version", "license", "raw_license", "normalization_status", "license_list_version", "name", "url", "distributor", "kind", "checksum"}))
+	primary.ExpectExec(datastoreInvalidateStatement).
+		WithArgs("npm", "test", "", false, "").
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+
+	_, err = ds.Retrieve(context.Background(), "test", "1.0.0", "npm")
+	require.ErrorIs(t, err, oslc.ErrDatastoreObjectNotFound)
+	_, err = ds.RetrieveVersions(context.Background(), "test", "npm")
+	require.NoError(t, err)
+	// Writes go to the primary.
+	_, err = ds.Invalidate(context.Background(), oslc.EntryFilter{Distributor: "npm", NamePattern: "test"})
+	require.NoError(t, err)
+	require.NoError(t, replica.ExpectationsWereMet())
+	require.NoError(t, primary.ExpectationsWereMet())
+}
+
 func TestDatastore_Save(t *testing.T) {
 	mock := newPoolMock(t)
 	ds, err := NewDatastore(WithPool(mock))
 	require.NoError(t, err)
 	require.NotNil(t, ds)
 	mock.ExpectBegin().Times(1)
-	mock.ExpectExec(datastoreSaveStatement).
-		WithArgs("test", "test4", "test5", "test3", "https://example.com").
+	mock.ExpectQuery(datastoreSaveVersionStatement).
+		WithArgs("test3", "test", "test5", "test4", "test 4", "alias", "3.25.0").
+		WillReturnRows(mock.NewRows([]string{"id"}).AddRow(int64(7))).
+		Times(1)
+	mock.ExpectExec(datastoreDeleteDistributionPointsStatement).
+		WithArgs(int64(7)).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0)).
+		Times(1)
+	mock.ExpectExec(datastoreSaveDistributionPointsStatement).
+		WithArgs(int64(7), []string{"test2"}, []string{"https://example.com"}, []string{"test3"}, []string{"sdist"}, []string{"sha256:abc"}).
 		WillReturnResult(pgxmock.NewResult("INSERT", 1)).
 		Times(1)
 	mock.ExpectCommit().Times(1)
@@ -99,9 +148,50 @@ func TestDatastore_Save(t *testing.T) {
 			Name:        "test2",
 			URL:         "https://example.com",
 			Distributor: "test3",
+			Kind:        "sdist",
+			Checksum:    "sha256:abc",
 		}},
-		License: "test4",
-		Version: "test5",
+		License:             "test4",
+		Version:             "test5",
+		RawLicense:          "test 4",
+		NormalizationStatus: oslc.LicenseNormalizationAlias,
+		LicenseListVersion:  "3.25.0",
+	})
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatastore_Save_multipleDistributors(t *testing.T) {
+	mock := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(mock))
+	require.NoError(t, err)
+	names := []string{"a", "a-mirror", "b"}
+	urls := []string{"https://a.example.com", "https://mirror.example.com", "https://b.example.com"}
+	distributors := []string{"d1", "d1", "d2"}
+	empty := []string{"", "", ""}
+	mock.ExpectBegin()
+	for i, distributor := range []string{"d1", "d2"} {
+		id := int64(i + 1)
+		mock.ExpectQuery(datastoreSaveVersionStatement).
+			WithArgs(distributor, "test", "1.0.0", "MIT", "", "", "").
+			WillReturnRows(mock.NewRows([]string{"id"}).AddRow(id))
+		mock.ExpectExec(datastoreDeleteDistributionPointsStatement).
+			WithArgs(id).
+			WillReturnResult(pgxmock.NewResult("DELETE", 0))
+		mock.ExpectExec(datastoreSaveDistributionPointsStatement).
+			WithArgs(id, names, urls, distributors, empty, empty).
+			WillReturnResult(pgxmock.NewResult("INSERT", 3))
+	}
+	mock.ExpectCommit()
+	err = ds.Save(context.Background(), oslc.Entry{
+		Name: "test",
+		DistributionPoints: []oslc.DistributionPoint{
+			{Name: "a", URL: "https://a.example.com", Distributor: "d1"},
+			{Name: "a-mirror", URL: "https://mirror.example.com", Distributor: "d1"},
+			{Name: "b", URL: "https://b.example.com", Distributor: "d2"},
+		},
+		License: "MIT",
+		Version: "1.0.0",
 	})
 	require.NoError(t, err)
 	require.NoError(t, mock.ExpectationsWereMet())
@@ -117,14 +207,44 @@ func TestDatastore_Save_ErrBegin(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestDatastore_Save_ErrQuery(t *testing.T) {
+	mock := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(mock))
+	require.NoError(t, err)
+	require.NotNil(t, ds)
+	mock.ExpectBegin().Times(1)
+	mock.ExpectQuery(datastoreSaveVersionStatement).
+		WithArgs("test3", "test", "test5", "test4", "", "", "").
+		WillReturnError(assert.AnError)
+	mock.ExpectRollback().Times(1)
+	err = ds.Save(context.Background(), oslc.Entry{
+		Name: "test",
+		DistributionPoints: []oslc.DistributionPoint{{
+			Name:        "test2",
+			URL:         "https://example.com",
+			Distributor: "test3",
+		}},
+		License: "test4",
+		Version: "test5",
+	})
+	require.Error(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestDatastore_Save_ErrExec(t *testing.T) {
 	mock := newPoolMock(t)
 	ds, err := NewDatastore(WithPool(mock))
 	require.NoError(t, err)
 	require.NotNil(t, ds)
 	mock.ExpectBegin().Times(1)
-	mock.ExpectExec(datastoreSaveStatement).
-		WithArgs("test", "test4", "test5", "test3", "https://example.com").
+	mock.ExpectQuery(datastoreSaveVersionStatement).
+		WithArgs("test3", "test", "test5", "test4", "", "", "").
+		WillReturnRows(mock.NewRows([]string{"id"}).AddRow(int64(7)))
+	mock.ExpectExec(datastoreDeleteDistributionPointsStatement).
+		WithArgs(int64(7)).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	mock.ExpectExec(datastoreSaveDistributionPointsStatement).
+		WithArgs(int64(7), []string{"test2"}, []string{"https://example.com"}, []string{"test3"}, []string{""}, []string{""}).
 		WillReturnError(assert.AnError)
 	mock.ExpectRollback().Times(1)
 	err = ds.Save(context.Background(), oslc.Entry{
@@ -147,10 +267,15 @@ func TestDatastore_Save_ErrCommit(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, ds)
 	mock.ExpectBegin().Times(1)
-	mock.ExpectExec(datastoreSaveStatement).
-		WithArgs("test", "test4", "test5", "test3", "https://example.com").
-		WillReturnResult(pgxmock.NewResult("INSERT", 1)).
-		Times(1)
+	mock.ExpectQuery(datastoreSaveVersionStatement).
+		WithArgs("test3", "test", "test5", "test4", "", "", "").
+		WillReturnRows(mock.NewRows([]string{"id"}).AddRow(int64(7)))
+	mock.ExpectExec(datastoreDeleteDistributionPointsStatement).
+		WithArgs(int64(7)).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	mock.ExpectExec(datastoreSaveDistributionPointsStatement).
+		WithArgs(int64(7), []string{"test2"}, []string{"https://example.com"}, []string{"test3"}, []string{""}, []string{""}).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
 	mock.ExpectCommit().WillReturnError(assert.AnError)
 	err = ds.Save(context.Background(), oslc.Entry{
 		Name: "test",
@@ -173,19 +298,23 @@ func TestDatastore_Retrieve(t *testing.T) {
 	require.NotNil(t, ds)
 	mock.ExpectQuery(datastoreRetrieveStatement).
 		WithArgs("test", "test2", "test3").
-		WillReturnRows(mock.NewRows([]string{"license", "distribution_url"}).AddRow("test4", "https://example.com")).
+		WillReturnRows(mock.NewRows([]string{"license", "raw_license", "normalization_status", "license_list_version", "name", "url", "distributor", "kind", "checksum"}).
+			AddRow("test4", "test 4", "alias", "3.25.0", "test", "https://example.com", "test3", "", "").
+			AddRow("test4", "test 4", "alias", "3.25.0", "test-mirror", "https://mirror.example.com", "other", "wheel", "sha256:abc")).
 		Times(1)
 	entry, err := ds.Retrieve(context.Background(), "test", "test2", "test3")
 	require.NoError(t, err)
 	require.Equal(t, oslc.Entry{
 		Name: "test",
-		DistributionPoints: []oslc.DistributionPoint{{
-			Name:        "test",
-			URL:         "https://example.com",
-			Distributor: "test3",
-		}},
-		License: "test4",
-		Version: "test2",
+		DistributionPoints: []oslc.DistributionPoint{
+			{Name: "test", URL: "https://example.com", Distributor: "test3"},
+			{Name: "test-mirror", URL: "https://mirror.example.com", Distributor: "other", Kind: "wheel", Checksum: "sha256:abc"},
+		},
+		License:             "test4",
+		Version:             "test2",
+		RawLicense:          "test 4",
+		NormalizationStatus: oslc.LicenseNormalizationAlias,
+		LicenseListVersion:  "3.25.0",
 	}, entry)
 	require.NoError(t, mock.ExpectationsWereMet())
 }
@@ -225,10 +354,336 @@ func TestDatastore_Retrieve_ErrNotFound(t *testing.T) {
 	require.NotNil(t, ds)
 	mock.ExpectQuery(datastoreRetrieveStatement).
 		WithArgs("test", "test2", "test3").
-		WillReturnRows(mock.NewRows([]string{"license", "distribution_url"})).
+		WillReturnRows(mock.NewRows([]string{"license", "raw_license", "normalization_status", "license_list_version", "name", "url", "distributor", "kind", "checksum"})).
 		Times(1)
 	_, err = ds.Retrieve(context.Background(), "test", "test2", "test3")
 	require.Error(t, err)
 	require.Equal(t, oslc.ErrDatastoreObjectNotFound, err)
 	require.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestWithTracerProvider(t *testing.T) {
+	tp := noop.NewTracerProvider()
+	opts := datastoreOptions{}
+	f := WithTracerProvider(tp)
+	f.apply(&opts)
+	require.Equal(t, tp, opts.TracerProvider)
+}
+
+func TestDatastore_Retrieve_tracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	mock := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(mock), WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))))
+	require.NoError(t, err)
+	mock.ExpectQuery(datastoreRetrieveStatement).
+		WithArgs("test", "test2", "test3").
+		WillReturnRows(mock.NewRows([]string{"license", "raw_license", "normalization_status", "license_list_version", "name", "url", "distributor", "kind", "checksum"})).
+		Times(1)
+	mock.ExpectQuery(datastoreRetrieveStatement).
+		WithArgs("test", "test2", "test3").
+		WillReturnError(assert.AnError)
+
+	_, err = ds.Retrieve(context.Background(), "test", "test2", "test3")
+	require.ErrorIs(t, err, oslc.ErrDatastoreObjectNotFound)
+	_, err = ds.Retrieve(context.Background(), "test", "test2", "test3")
+	require.Error(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	require.Equal(t, "postgres SELECT", spans[0].Name())
+	require.Contains(t, spans[0].Attributes(), attribute.String("oslc.package.name", "test"))
+	require.Contains(t, spans[0].Attributes(), attribute.String("oslc.distributor", "test3"))
+	require.Equal(t, codes.Unset, spans[0].Status().Code)
+	require.Equal(t, codes.Error, spans[1].Status().Code)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatastore_Save_tracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	mock := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(mock), WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))))
+	require.NoError(t, err)
+	mock.ExpectBegin().WillReturnError(assert.AnError)
+
+	err = ds.Save(context.Background(), oslc.Entry{Name: "test", Version: "test2"})
+	require.Error(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	require.Equal(t, "postgres INSERT", spans[0].Name())
+	require.Contains(t, spans[0].Attributes(), attribute.String("oslc.package.version", "test2"))
+	require.Equal(t, codes.Error, spans[0].Status().Code)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNamePatternToLike(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    string
+	}{
+		{"", ""},
+		{"requests", "requests"},
+		{"@types/*", "@types/%"},
+		{"*-utils*", "%-utils%"},
+		{"my_package", `my\_package`},
+		{"100%", `100\%`},
+		{`back\slash`, `back\\slash`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			require.Equal(t, tt.want, namePatternToLike(tt.pattern))
+		})
+	}
+}
+
+func TestDatastore_Invalidate(t *testing.T) {
+	empty := ""
+	tests := []struct {
+		name   string
+		filter oslc.EntryFilter
+		args   []any
+	}{
+		{
+			name:   "exact coordinate",
+			filter: oslc.EntryFilter{Distributor: oslc.DistributorPypi, NamePattern: "requests", Version: "2.32.3"},
+			args:   []any{oslc.DistributorPypi, "requests", "2.32.3", false, ""},
+		},
+		{
+			name:   "name pattern",
+			filter: oslc.EntryFilter{Distributor: oslc.DistributorNpm, NamePattern: "@types/*"},
+			args:   []any{oslc.DistributorNpm, "@types/%", "", false, ""},
+		},
+		{
+			name:   "empty license",
+			filter: oslc.EntryFilter{License: &empty},
+			args:   []any{"", "", "", true, ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newPoolMock(t)
+			ds, err := NewDatastore(WithPool(mock))
+			require.NoError(t, err)
+			mock.ExpectExec(datastoreInvalidateStatement).
+				WithArgs(tt.args...).
+				WillReturnResult(pgxmock.NewResult("DELETE", 3)).
+				Times(1)
+			n, err := ds.Invalidate(context.Background(), tt.filter)
+			require.NoError(t, err)
+			require.EqualValues(t, 3, n)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDatastore_Invalidate_ErrExec(t *testing.T) {
+	mock := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(mock))
+	require.NoError(t, err)
+	mock.ExpectExec(datastoreInvalidateStatement).
+		WithArgs(oslc.DistributorGo, "", "", false, "").
+		WillReturnError(assert.AnError)
+	_, err = ds.Invalidate(context.Background(), oslc.EntryFilter{Distributor: oslc.DistributorGo})
+	require.ErrorIs(t, err, assert.AnError)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatastore_RetrieveVersions(t *testing.T) {
+	mock := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(mock))
+	require.NoError(t, err)
+	mock.ExpectQuery(datastoreRetrieveVersionsStatement).
+		WithArgs("test", "test2").
+		WillReturnRows(mock.NewRows([]string{"version", "license", "raw_license", "normalization_status", "license_list_version", "name", "url", "distributor", "kind", "checksum"}).
+			AddRow("1.0.0", "MIT", "MIT", "exact", "3.25.0", "test", "https://example.com/1", "test2", "", "").
+			AddRow("1.0.0", "MIT", "MIT", "exact", "3.25.0", "test", "https://mirror.example.com/1", "test2", "", "").
+			AddRow("2.0.0", "BSL-1.1", "BSL-1.1", "", "", "test", "https://example.com/2", "test2", "", "")).
+		Times(1)
+	entries, err := ds.RetrieveVersions(context.Background(), "test", "test2")
+	require.NoError(t, err)
+	require.Equal(t, []oslc.Entry{
+		{
+			Name: "test",
+			DistributionPoints: []oslc.DistributionPoint{
+				{Name: "test", URL: "https://example.com/1", Distributor: "test2"},
+				{Name: "test", URL: "https://mirror.example.com/1", Distributor: "test2"},
+			},
+			License:             "MIT",
+			Version:             "1.0.0",
+			RawLicense:          "MIT",
+			NormalizationStatus: oslc.LicenseNormalizationExact,
+			LicenseListVersion:  "3.25.0",
+		},
+		{
+			Name:               "test",
+			DistributionPoints: []oslc.DistributionPoint{{Name: "test", URL: "https://example.com/2", Distributor: "test2"}},
+			License:            "BSL-1.1",
+			Version:            "2.0.0",
+			RawLicense:         "BSL-1.1",
+		},
+	}, entries)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatastore_RetrieveVersions_empty(t *testing.T) {
+	mock := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(mock))
+	require.NoError(t, err)
+	mock.ExpectQuery(datastoreRetrieveVersionsStatement).
+		WithArgs("test", "test2").
+		WillReturnRows(mock.NewRows([]string{"version", "license", "raw_license", "normalization_status", "license_list_version", "name", "url", "distributor", "kind", "checksum"}))
+	entries, err := ds.RetrieveVersions(context.Background(), "test", "test2")
+	require.NoError(t, err)
+	require.Empty(t, entries)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatastore_RetrieveVersions_ErrQuery(t *testing.T) {
+	mock := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(mock))
+	require.NoError(t, err)
+	mock.ExpectQuery(datastoreRetrieveVersionsStatement).
+		WithArgs("test", "test2").
+		WillReturnError(assert.AnError)
+	_, err = ds.RetrieveVersions(context.Background(), "test", "test2")
+	require.ErrorIs(t, err, assert.AnError)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatastore_RetrieveVersions_ErrRows(t *testing.T) {
+	mock := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(mock))
+	require.NoError(t, err)
+	mock.ExpectQuery(datastoreRetrieveVersionsStatement).
+		WithArgs("test", "test2").
+		// intentionally return a row that cannot be scanned, forcing the code to return an error.
+		WillReturnRows(mock.NewRows([]string{"version"}).AddRow("1.0.0"))
+	_, err = ds.RetrieveVersions(context.Background(), "test", "test2")
+	require.Error(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLicenseExpressionPattern(t *testing.T) {
+	pattern := regexp.MustCompile(licenseExpressionPattern("GPL-2.0+"))
+	require.True(t, pattern.MatchString("GPL-2.0+"))
+	require.True(t, pattern.MatchString("MIT OR GPL-2.0+"))
+	require.True(t, pattern.MatchString("(GPL-2.0+ AND MIT) OR ISC"))
+	require.False(t, pattern.MatchString("LGPL-2.0+"))
+	require.False(t, pattern.MatchString("GPL-2.00"))
+}
+
+func TestDatastore_Search(t *testing.T) {
+	mit := "MIT"
+	empty := ""
+	after := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		query oslc.SearchQuery
+		args  []any
+	}{
+		{
+			name:  "no filters",
+			query: oslc.SearchQuery{Limit: 10},
+			args:  []any{"", "", "", false, "", false, "", nil, nil, false, "", "", "", 10},
+		},
+		{
+			name:  "names",
+			query: oslc.SearchQuery{Distributor: oslc.DistributorNpm, NamePrefix: "@types/", NameContains: "no_de", Limit: 10},
+			args:  []any{oslc.DistributorNpm, "@types/%", `%no\_de%`, false, "", false, "", nil, nil, false, "", "", "", 10},
+		},
+		{
+			name:  "license",
+			query: oslc.SearchQuery{License: &mit, Limit: 10},
+			args:  []any{"", "", "", true, "MIT", false, licenseExpressionPattern("MIT"), nil, nil, false, "", "", "", 10},
+		},
+		{
+			name:  "license in expression",
+			query: oslc.SearchQuery{License: &mit, LicenseInExpression: true, Limit: 10},
+			args:  []any{"", "", "", true, "MIT", true, licenseExpressionPattern("MIT"), nil, nil, false, "", "", "", 10},
+		},
+		{
+			name:  "empty license in expression",
+			query: oslc.SearchQuery{License: &empty, LicenseInExpression: true, Limit: 10},
+			args:  []any{"", "", "", true, "", false, licenseExpressionPattern(""), nil, nil, false, "", "", "", 10},
+		},
+		{
+			name:  "fetched range and cursor",
+			query: oslc.SearchQuery{FetchedAfter: after, FetchedBefore: before, After: &oslc.SearchCursor{Distributor: "go", Name: "a", Version: "v1"}, Limit: 10},
+			args:  []any{"", "", "", false, "", false, "", after, before, true, "go", "a", "v1", 10},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newPoolMock(t)
+			ds, err := NewDatastore(WithPool(mock))
+			require.NoError(t, err)
+			fetchedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+			mock.ExpectQuery(datastoreSearchStatement).
+				WithArgs(tt.args...).
+				WillReturnRows(mock.NewRows([]string{"name", "version", "distributor", "license", "raw_license", "normalization_status", "license_list_version", "fetched_at", "names", "urls", "distributors", "kinds", "checksums"}).
+					AddRow("test", "1.0.0", oslc.DistributorNpm, "MIT", "MIT License", "alias", "3.25.0", fetchedAt,
+						[]string{"test", "test"}, []string{"https://example.com/1", "https://example.com/1.tgz"}, []string{oslc.DistributorNpm, oslc.DistributorNpm}, []string{"", "tarball"}, []string{"", "sha512:abc"})).
+				Times(1)
+			entries, err := ds.Search(context.Background(), tt.query)
+			require.NoError(t, err)
+			require.Equal(t, []oslc.StoredEntry{{
+				Entry: oslc.Entry{
+					Name:                "test",
+					Version:             "1.0.0",
+					License:             "MIT",
+					RawLicense:          "MIT License",
+					NormalizationStatus: oslc.LicenseNormalizationAlias,
+					LicenseListVersion:  "3.25.0",
+					DistributionPoints: []oslc.DistributionPoint{
+						{Name: "test", URL: "https://example.com/1", Distributor: oslc.DistributorNpm},
+						{Name: "test", URL: "https://example.com/1.tgz", Distributor: oslc.DistributorNpm, Kind: "tarball", Checksum: "sha512:abc"},
+					},
+				},
+				Distributor: oslc.DistributorNpm,
+				FetchedAt:   fetchedAt,
+			}}, entries)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDatastore_Search_ErrQuery(t *testing.T) {
+	mock := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(mock))
+	require.NoError(t, err)
+	mock.ExpectQuery(datastoreSearchStatement).
+		WithArgs("", "", "", false, "", false, "", nil, nil, false, "", "", "", 10).
+		WillReturnError(assert.AnError)
+	_, err = ds.Search(context.Background(), oslc.SearchQuery{Limit: 10})
+	require.ErrorIs(t, err, assert.AnError)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatastore_CountPackages(t *testing.T) {
+	mock := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(mock))
+	require.NoError(t, err)
+	mock.ExpectQuery(datastoreCountPackagesStatement).
+		WillReturnRows(mock.NewRows([]string{"distributor", "license", "normalization_status", "count"}).
+			AddRow(oslc.DistributorNpm, "MIT", "exact", int64(10)).
+			AddRow(oslc.DistributorPypi, "", "failed", int64(2))).
+		Times(1)
+	counts, err := ds.CountPackages(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []oslc.PackageCount{
+		{Distributor: oslc.DistributorNpm, License: "MIT", NormalizationStatus: oslc.LicenseNormalizationExact, Count: 10},
+		{Distributor: oslc.DistributorPypi, License: "", NormalizationStatus: oslc.LicenseNormalizationFailed, Count: 2},
+	}, counts)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatastore_CountPackages_ErrQuery(t *testing.T) {
+	mock := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(mock))
+	require.NoError(t, err)
+	mock.ExpectQuery(datastoreCountPackagesStatement).WillReturnError(assert.AnError)
+	_, err = ds.CountPackages(context.Background())
+	require.ErrorIs(t, err, assert.AnError)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"github.com/chainalysis-oss/oslc"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"time"
+)
+
+// Compile time check to ensure Datastore implements [oslc.JobStore].
+var _ oslc.JobStore = (*Datastore)(nil)
+
+// jobColumns are the columns scanned by scanJob, in order.
+const jobColumns = "id, distributor, name, version, state, attempts, result, last_error, created_at, updated_at"
+
+// scanJob returns the destinations for the jobColumns of a row, scanned into job.
+func scanJob(job *oslc.Job) []any {
+	return []any{&job.ID, &job.Distributor, &job.Name, &job.Version, (*string)(&job.State), &job.Attempts, &job.Result, &job.LastError, &job.CreatedAt, &job.UpdatedAt}
+}
+
+// datastoreEnqueueJobStatement inserts a job, unless an active job for the same package exists, and returns the
+// inserted or the existing job. The select of the existing job does not see the inserted row, so at most one row is
+// returned. No row is returned if a concurrent statement inserted the job after this one started, because the select
+// runs against the snapshot taken at the start of the statement.
+var datastoreEnqueueJobStatement = "WITH inserted AS (INSERT INTO lookup_jobs (distributor, name, version) VALUES ($1, $2, $3) ON CONFLICT (distributor, name, version) WHERE state IN ('pending', 'running') DO NOTHING RETURNING " + jobColumns + ") SELECT " + jobColumns + " FROM inserted UNION ALL SELECT " + jobColumns + " FROM lookup_jobs WHERE distributor = $1 AND name = $2 AND version = $3 AND state IN ('pending', 'running') LIMIT 1"
+
+func (d *Datastore) EnqueueJob(ctx context.Context, request oslc.JobRequest) (_ oslc.Job, err error) {
+	ctx, span := d.startSpan(ctx, "INSERT", datastoreEnqueueJobStatement,
+		append(packageAttributes(request.Name, request.Version), attribute.String("oslc.distributor", request.Distributor))...)
+	defer func() { endSpan(span, err) }()
+
+	// A statement that lost the race against a concurrent insert returns no row. Running it again takes a new
+	// snapshot, which sees the job of the winner, or inserts a new job if that one finished in the meantime.
+	for {
+		rows, err := d.options.Pool.Query(ctx, datastoreEnqueueJobStatement, request.Distributor, request.Name, request.Version)
+		if err != nil {
+			return oslc.Job{}, err
+		}
+		job, err := pgx.CollectExactlyOneRow(rows, func(row pgx.CollectableRow) (oslc.Job, error) {
+			var job oslc.Job
+			return job, row.Scan(scanJob(&job)...)
+		})
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return job, err
+		}
+	}
+}
+
+var datastoreGetJobStatement = "SELECT " + jobColumns + " FROM lookup_jobs WHERE id = $1"
+
+func (d *Datastore) GetJob(ctx context.Context, id int64) (_ oslc.Job, err error) {
+	ctx, span := d.startSpan(ctx, "SELECT", datastoreGetJobStatement, attribute.Int64("oslc.job_id", id))
+	defer func() { endSpan(span, err) }()
+
+	rows, err := d.options.Pool.Query(ctx, datastoreGetJobStatement, id)
+	if err != nil {
+		return oslc.Job{}, err
+	}
+	job, err := pgx.CollectExactlyOneRow(rows, func(row pgx.CollectableRow) (oslc.Job, error) {
+		var job oslc.Job
+		return job, row.Scan(scanJob(&job)...)
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return oslc.Job{}, oslc.ErrDatastoreObjectNotFound
+	}
+	return job, err
+}
+
+// datastoreClaimJobsStatement claims due jobs by marking them as running and moving their next attempt past the lease.
+// Rows locked by concurrent claims are skipped, so every job is claimed by a single caller.
+var datastoreClaimJobsStatement = "UPDATE lookup_jobs SET state = 'running', attempts = attempts + 1, next_attempt_at = now() + make_interval(secs => $2), updated_at = now() WHERE id IN (SELECT id FROM lookup_jobs WHERE state IN ('pending', 'running') AND next_attempt_at <= now() ORDER BY next_attempt_at LIMIT $1 FOR UPDATE SKIP LOCKED) RETURNING " + jobColumns
+
+func (d *Datastore) ClaimJobs(ctx context.Context, limit int, lease time.Duration) (_ []oslc.Job, err error) {
+	ctx, span := d.startSpan(ctx, "UPDATE", datastoreClaimJobsStatement)
+	defer func() { endSpan(span, err) }()
+
+	rows, err := d.options.Pool.Query(ctx, datastoreClaimJobsStatement, limit, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	var job oslc.Job
+	jobs := make([]oslc.Job, 0)
+	_, err = pgx.ForEachRow(rows, scanJob(&job), func() error {
+		jobs = append(jobs, job)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+var datastoreCompleteJobStatement = "UPDATE lookup_jobs SET state = 'succeeded', result = $2, updated_at = now() WHERE id = $1 AND state IN ('pending', 'running')"
+
+func (d *Datastore) CompleteJob(ctx context.Context, id int64, result []byte) (err error) {
+	ctx, span := d.startSpan(ctx, "UPDATE", datastoreCompleteJobStatement, attribute.Int64("oslc.job_id", id))
+	defer func() { endSpan(span, err) }()
+
+	_, err = d.options.Pool.Exec(ctx, datastoreCompleteJobStatement, id, result)
+	return err
+}
+
+var datastoreRetryJobStatement = "UPDATE lookup_jobs SET state = 'pending', next_attempt_at = $2, last_error = $3, updated_at = now() WHERE id = $1 AND state IN ('pending', 'running')"
+
+func (d *Datastore) RetryJob(ctx context.Context, id int64, next time.Time, lastError string) (err error) {
+	ctx, span := d.startSpan(ctx, "UPDATE", datastoreRetryJobStatement, attribute.Int64("oslc.job_id", id))
+	defer func() { endSpan(span, err) }()
+
+	_, err = d.options.Pool.Exec(ctx, datastoreRetryJobStatement, id, next, lastError)
+	return err
+}
+
+var datastoreFailJobStatement = "UPDATE lookup_jobs SET state = 'failed', result = $2, last_error = $3, updated_at = now() WHERE id = $1 AND state IN ('pending', 'running')"
+
+func (d *Datastore) FailJob(ctx context.Context, id int64, result []byte, lastError string) (err error) {
+	ctx, span := d.startSpan(ctx, "UPDATE", datastoreFailJobStatement, attribute.Int64("oslc.job_id", id))
+	defer func() { endSpan(span, err) }()
+
+	_, err = d.options.Pool.Exec(ctx, datastoreFailJobStatement, id, result, lastError)
+	return err
+}
+
+var datastoreDeleteFinishedJobsStatement = "DELETE FROM lookup_jobs WHERE state IN ('succeeded', 'failed') AND updated_at < $1"
+
+func (d *Datastore) DeleteFinishedJobs(ctx context.Context, before time.Time) (_ int, err error) {
+	ctx, span := d.startSpan(ctx, "DELETE", datastoreDeleteFinishedJobsStatement)
+	defer func() { endSpan(span, err) }()
+
+	tag, err := d.options.Pool.Exec(ctx, datastoreDeleteFinishedJobsStatement, before)
+	if err != nil {
+		return 0, err
+	}
+	return int(tag.RowsAffected()), nil
+}
@@ -0,0 +1,174 @@
+package postgres
+
+import (
+	"context"
+	"github.com/chainalysis-oss/oslc"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+var jobColumnNames = []string{"id", "distributor", "name", "version", "state", "attempts", "result", "last_error", "created_at", "updated_at"}
+
+func TestDatastore_EnqueueJob(t *testing.T) {
+	mock := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(mock))
+	require.NoError(t, err)
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	mock.ExpectQuery(datastoreEnqueueJobStatement).
+		WithArgs(oslc.DistributorGo, "github.com/a/b", "v1.0.0").
+		WillReturnRows(mock.NewRows(jobColumnNames).
+			AddRow(int64(7), oslc.DistributorGo, "github.com/a/b", "v1.0.0", "pending", 0, []byte(nil), "", createdAt, createdAt)).
+		Times(1)
+	job, err := ds.EnqueueJob(context.Background(), oslc.JobRequest{Distributor: oslc.DistributorGo, Name: "github.com/a/b", Version: "v1.0.0"})
+	require.NoError(t, err)
+	require.Equal(t, oslc.Job{
+		ID:         7,
+		JobRequest: oslc.JobRequest{Distributor: oslc.DistributorGo, Name: "github.com/a/b", Version: "v1.0.0"},
+		State:      oslc.JobPending,
+		CreatedAt:  createdAt,
+		UpdatedAt:  createdAt,
+	}, job)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatastore_EnqueueJob_concurrentInsert(t *testing.T) {
+	mock := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(mock))
+	require.NoError(t, err)
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	// The first statement lost the race against a concurrent insert, so it returns no row.
+	mock.ExpectQuery(datastoreEnqueueJobStatement).
+		WithArgs(oslc.DistributorGo, "github.com/a/b", "v1.0.0").
+		WillReturnRows(mock.NewRows(jobColumnNames))
+	mock.ExpectQuery(datastoreEnqueueJobStatement).
+		WithArgs(oslc.DistributorGo, "github.com/a/b", "v1.0.0").
+		WillReturnRows(mock.NewRows(jobColumnNames).
+			AddRow(int64(7), oslc.DistributorGo, "github.com/a/b", "v1.0.0", "pending", 0, []byte(nil), "", createdAt, createdAt))
+	job, err := ds.EnqueueJob(context.Background(), oslc.JobRequest{Distributor: oslc.DistributorGo, Name: "github.com/a/b", Version: "v1.0.0"})
+	require.NoError(t, err)
+	require.Equal(t, int64(7), job.ID)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatastore_EnqueueJob_ErrQuery(t *testing.T) {
+	mock := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(mock))
+	require.NoError(t, err)
+	mock.ExpectQuery(datastoreEnqueueJobStatement).WithArgs("", "", "").WillReturnError(assert.AnError)
+	_, err = ds.EnqueueJob(context.Background(), oslc.JobRequest{})
+	require.ErrorIs(t, err, assert.AnError)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatastore_GetJob(t *testing.T) {
+	mock := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(mock))
+	require.NoError(t, err)
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	updatedAt := createdAt.Add(time.Minute)
+	mock.ExpectQuery(datastoreGetJobStatement).
+		WithArgs(int64(7)).
+		WillReturnRows(mock.NewRows(jobColumnNames).
+			AddRow(int64(7), oslc.DistributorNpm, "test", "", "succeeded", 2, []byte("result"), "timeout", createdAt, updatedAt)).
+		Times(1)
+	mock.ExpectQuery(datastoreGetJobStatement).
+		WithArgs(int64(8)).
+		WillReturnRows(mock.NewRows(jobColumnNames))
+	mock.ExpectQuery(datastoreGetJobStatement).
+		WithArgs(int64(9)).
+		WillReturnError(assert.AnError)
+
+	job, err := ds.GetJob(context.Background(), 7)
+	require.NoError(t, err)
+	require.Equal(t, oslc.Job{
+		ID:         7,
+		JobRequest: oslc.JobRequest{Distributor: oslc.DistributorNpm, Name: "test"},
+		State:      oslc.JobSucceeded,
+		Attempts:   2,
+		Result:     []byte("result"),
+		LastError:  "timeout",
+		CreatedAt:  createdAt,
+		UpdatedAt:  updatedAt,
+	}, job)
+	_, err = ds.GetJob(context.Background(), 8)
+	require.ErrorIs(t, err, oslc.ErrDatastoreObjectNotFound)
+	_, err = ds.GetJob(context.Background(), 9)
+	require.ErrorIs(t, err, assert.AnError)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatastore_ClaimJobs(t *testing.T) {
+	mock := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(mock))
+	require.NoError(t, err)
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	mock.ExpectQuery(datastoreClaimJobsStatement).
+		WithArgs(10, float64(60)).
+		WillReturnRows(mock.NewRows(jobColumnNames).
+			AddRow(int64(1), oslc.DistributorNpm, "a", "1.0.0", "running", 1, []byte(nil), "", createdAt, createdAt).
+			AddRow(int64(2), oslc.DistributorGo, "b", "", "running", 3, []byte(nil), "timeout", createdAt, createdAt)).
+		Times(1)
+	jobs, err := ds.ClaimJobs(context.Background(), 10, time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, []oslc.Job{
+		{ID: 1, JobRequest: oslc.JobRequest{Distributor: oslc.DistributorNpm, Name: "a", Version: "1.0.0"}, State: oslc.JobRunning, Attempts: 1, CreatedAt: createdAt, UpdatedAt: createdAt},
+		{ID: 2, JobRequest: oslc.JobRequest{Distributor: oslc.DistributorGo, Name: "b"}, State: oslc.JobRunning, Attempts: 3, LastError: "timeout", CreatedAt: createdAt, UpdatedAt: createdAt},
+	}, jobs)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatastore_ClaimJobs_ErrQuery(t *testing.T) {
+	mock := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(mock))
+	require.NoError(t, err)
+	mock.ExpectQuery(datastoreClaimJobsStatement).WithArgs(10, float64(60)).WillReturnError(assert.AnError)
+	_, err = ds.ClaimJobs(context.Background(), 10, time.Minute)
+	require.ErrorIs(t, err, assert.AnError)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatastore_resolveJob(t *testing.T) {
+	mock := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(mock))
+	require.NoError(t, err)
+	next := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	mock.ExpectExec(datastoreCompleteJobStatement).
+		WithArgs(int64(1), []byte("response")).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec(datastoreRetryJobStatement).
+		WithArgs(int64(2), next, "distributor unavailable").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec(datastoreFailJobStatement).
+		WithArgs(int64(3), []byte("status"), "package not found").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec(datastoreCompleteJobStatement).
+		WithArgs(int64(4), []byte(nil)).
+		WillReturnError(assert.AnError)
+	require.NoError(t, ds.CompleteJob(context.Background(), 1, []byte("response")))
+	require.NoError(t, ds.RetryJob(context.Background(), 2, next, "distributor unavailable"))
+	require.NoError(t, ds.FailJob(context.Background(), 3, []byte("status"), "package not found"))
+	require.ErrorIs(t, ds.CompleteJob(context.Background(), 4, nil), assert.AnError)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatastore_DeleteFinishedJobs(t *testing.T) {
+	mock := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(mock))
+	require.NoError(t, err)
+	before := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	mock.ExpectExec(datastoreDeleteFinishedJobsStatement).
+		WithArgs(before).
+		WillReturnResult(pgxmock.NewResult("DELETE", 3))
+	mock.ExpectExec(datastoreDeleteFinishedJobsStatement).
+		WithArgs(before).
+		WillReturnError(assert.AnError)
+	n, err := ds.DeleteFinishedJobs(context.Background(), before)
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+	_, err = ds.DeleteFinishedJobs(context.Background(), before)
+	require.ErrorIs(t, err, assert.AnError)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
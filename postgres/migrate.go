@@ -0,0 +1,156 @@
+package postgres
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"github.com/chainalysis-oss/oslc/migrate"
+	"log/slog"
+	"time"
+)
+
+// migrationsFS holds the schema migrations, in the layout documented by [migrate.Load].
+//
+//go:embed migrations/*.sql
+var migrationsFS embed.FS
+
+// migrationLockKey is the key of the advisory lock held while migrating, so replicas starting at the same time do not
+// apply migrations concurrently.
+const migrationLockKey int64 = 0x6f736c63 // "oslc"
+
+// Migrations returns the schema migrations, ordered by version.
+func Migrations() ([]migrate.Migration, error) {
+	return migrate.Load(migrationsFS, "migrations")
+}
+
+var (
+	migrateLockStatement        = "SELECT pg_advisory_xact_lock($1)"
+	migrateCreateTableStatement = "CREATE TABLE IF NOT EXISTS schema_migrations (version bigint PRIMARY KEY, name text NOT NULL, applied_at timestamptz NOT NULL DEFAULT now())"
+	migrateAppliedStatement     = "SELECT version, name, applied_at FROM schema_migrations ORDER BY version"
+	migrateInsertStatement      = "INSERT INTO schema_migrations (version, name) VALUES ($1, $2)"
+	migrateDeleteStatement      = "DELETE FROM schema_migrations WHERE version = $1"
+)
+
+// MigrateUp applies the migrations that have not been applied yet, in order, and returns them. The applied migrations
+// are recorded in the schema_migrations table.
+//
+// All migrations are applied in a single transaction holding an advisory lock, so either all of them are applied or
+// none, and servers migrating concurrently wait for each other.
+func (d *Datastore) MigrateUp(ctx context.Context) ([]migrate.Migration, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+	var pending []migrate.Migration
+	err = d.inMigrationTransaction(ctx, func(exec func(sql string, args ...any) error, applied []migrate.Applied) error {
+		pending = migrate.Pending(migrations, applied)
+		for _, m := range pending {
+			d.options.Logger.InfoContext(ctx, "applying migration", slog.Int("version", m.Version), slog.String("name", m.Name))
+			if err := exec(m.Up); err != nil {
+				return fmt.Errorf("failed to apply migration %d_%s: %w", m.Version, m.Name, err)
+			}
+			if err := exec(migrateInsertStatement, m.Version, m.Name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pending, nil
+}
+
+// MigrateDown reverts the most recently applied migrations, up to steps of them, and returns them in the order they were
+// reverted. Like [Datastore.MigrateUp], it reverts all migrations or none.
+func (d *Datastore) MigrateDown(ctx context.Context, steps int) ([]migrate.Migration, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+	var reverted []migrate.Migration
+	err = d.inMigrationTransaction(ctx, func(exec func(sql string, args ...any) error, applied []migrate.Applied) error {
+		reverted, err = migrate.Revert(migrations, applied, steps)
+		if err != nil {
+			return err
+		}
+		for _, m := range reverted {
+			d.options.Logger.InfoContext(ctx, "reverting migration", slog.Int("version", m.Version), slog.String("name", m.Name))
+			if err := exec(m.Down); err != nil {
+				return fmt.Errorf("failed to revert migration %d_%s: %w", m.Version, m.Name, err)
+			}
+			if err := exec(migrateDeleteStatement, m.Version); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reverted, nil
+}
+
+// MigrationStatus returns the status of every migration, including migrations applied by newer versions of OSLC,
+// ordered by version.
+func (d *Datastore) MigrationStatus(ctx context.Context) ([]migrate.Status, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+	var statuses []migrate.Status
+	err = d.inMigrationTransaction(ctx, func(_ func(string, ...any) error, applied []migrate.Applied) error {
+		statuses = migrate.Statuses(migrations, applied)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return statuses, nil
+}
+
+// inMigrationTransaction calls f in a transaction holding the migration lock, with the migrations applied so far. The
+// schema_migrations table is created if it does not exist. The transaction is committed if f succeeds.
+func (d *Datastore) inMigrationTransaction(ctx context.Context, f func(exec func(sql string, args ...any) error, applied []migrate.Applied) error) error {
+	tx, err := d.options.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	exec := func(sql string, args ...any) error {
+		_, err := tx.Exec(ctx, sql, args...)
+		return err
+	}
+	if err := exec(migrateLockStatement, migrationLockKey); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	if err := exec(migrateCreateTableStatement); err != nil {
+		return err
+	}
+
+	rows, err := tx.Query(ctx, migrateAppliedStatement)
+	if err != nil {
+		return err
+	}
+	var applied []migrate.Applied
+	for rows.Next() {
+		var version int64
+		var name string
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &name, &appliedAt); err != nil {
+			rows.Close()
+			return err
+		}
+		applied = append(applied, migrate.Applied{Version: int(version), Name: name, AppliedAt: appliedAt})
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if err := f(exec, applied); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
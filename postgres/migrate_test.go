@@ -0,0 +1,178 @@
+package postgres
+
+import (
+	"context"
+	"github.com/chainalysis-oss/oslc/migrate"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestMigrations(t *testing.T) {
+	migrations, err := Migrations()
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+	require.Equal(t, 1, migrations[0].Version)
+	require.Equal(t, "the_beginning", migrations[0].Name)
+	for i, m := range migrations {
+		require.Equal(t, i+1, m.Version)
+		require.NotEmpty(t, m.Up)
+		require.NotEmpty(t, m.Down)
+	}
+}
+
+// expectMigrationTransaction sets up the expectations for the start of a migration transaction, with the given
+// migration versions already applied.
+func expectMigrationTransaction(mock pgxmock.PgxPoolIface, appliedAt time.Time, applied ...int) {
+	migrations, _ := Migrations()
+	mock.ExpectBegin()
+	mock.ExpectExec(migrateLockStatement).WithArgs(migrationLockKey).WillReturnResult(pgxmock.NewResult("SELECT", 1))
+	mock.ExpectExec(migrateCreateTableStatement).WillReturnResult(pgxmock.NewResult("CREATE TABLE", 0))
+	rows := pgxmock.NewRows([]string{"version", "name", "applied_at"})
+	for _, version := range applied {
+		name := "future"
+		if version <= len(migrations) {
+			name = migrations[version-1].Name
+		}
+		rows.AddRow(int64(version), name, appliedAt)
+	}
+	mock.ExpectQuery(migrateAppliedStatement).WillReturnRows(rows)
+}
+
+func TestDatastore_MigrateUp(t *testing.T) {
+	mock := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(mock))
+	require.NoError(t, err)
+	migrations, err := Migrations()
+	require.NoError(t, err)
+
+	expectMigrationTransaction(mock, time.Now(), 1)
+	for _, m := range migrations[1:] {
+		mock.ExpectExec(m.Up).WillReturnResult(pgxmock.NewResult("CREATE TABLE", 0))
+		mock.ExpectExec(migrateInsertStatement).WithArgs(m.Version, m.Name).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	}
+	mock.ExpectCommit()
+
+	applied, err := ds.MigrateUp(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, migrations[1:], applied)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatastore_MigrateUp_UpToDate(t *testing.T) {
+	mock := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(mock))
+	require.NoError(t, err)
+	migrations, err := Migrations()
+	require.NoError(t, err)
+
+	versions := make([]int, len(migrations))
+	for i, m := range migrations {
+		versions[i] = m.Version
+	}
+	expectMigrationTransaction(mock, time.Now(), versions...)
+	mock.ExpectCommit()
+
+	applied, err := ds.MigrateUp(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, applied)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatastore_MigrateUp_ErrMigration(t *testing.T) {
+	mock := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(mock))
+	require.NoError(t, err)
+	migrations, err := Migrations()
+	require.NoError(t, err)
+
+	expectMigrationTransaction(mock, time.Now())
+	mock.ExpectExec(migrations[0].Up).WillReturnError(assert.AnError)
+	mock.ExpectRollback()
+
+	_, err = ds.MigrateUp(context.Background())
+	require.ErrorIs(t, err, assert.AnError)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatastore_MigrateUp_ErrLock(t *testing.T) {
+	mock := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(mock))
+	require.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(migrateLockStatement).WithArgs(migrationLockKey).WillReturnError(assert.AnError)
+	mock.ExpectRollback()
+
+	_, err = ds.MigrateUp(context.Background())
+	require.ErrorIs(t, err, assert.AnError)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatastore_MigrateUp_ErrBegin(t *testing.T) {
+	mock := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(mock))
+	require.NoError(t, err)
+
+	mock.ExpectBegin().WillReturnError(assert.AnError)
+
+	_, err = ds.MigrateUp(context.Background())
+	require.ErrorIs(t, err, assert.AnError)
+}
+
+func TestDatastore_MigrateDown(t *testing.T) {
+	mock := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(mock))
+	require.NoError(t, err)
+	migrations, err := Migrations()
+	require.NoError(t, err)
+
+	expectMigrationTransaction(mock, time.Now(), 1, 2, 3)
+	for _, m := range []migrate.Migration{migrations[2], migrations[1]} {
+		mock.ExpectExec(m.Down).WillReturnResult(pgxmock.NewResult("DROP TABLE", 0))
+		mock.ExpectExec(migrateDeleteStatement).WithArgs(m.Version).WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	}
+	mock.ExpectCommit()
+
+	reverted, err := ds.MigrateDown(context.Background(), 2)
+	require.NoError(t, err)
+	require.Equal(t, []migrate.Migration{migrations[2], migrations[1]}, reverted)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatastore_MigrateDown_ErrUnknownMigration(t *testing.T) {
+	mock := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(mock))
+	require.NoError(t, err)
+	migrations, err := Migrations()
+	require.NoError(t, err)
+
+	expectMigrationTransaction(mock, time.Now(), 1, len(migrations)+1)
+	mock.ExpectRollback()
+
+	_, err = ds.MigrateDown(context.Background(), 1)
+	require.ErrorIs(t, err, migrate.ErrUnknownMigration)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatastore_MigrationStatus(t *testing.T) {
+	mock := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(mock))
+	require.NoError(t, err)
+	migrations, err := Migrations()
+	require.NoError(t, err)
+
+	appliedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	expectMigrationTransaction(mock, appliedAt, 1, len(migrations)+1)
+	mock.ExpectCommit()
+
+	statuses, err := ds.MigrationStatus(context.Background())
+	require.NoError(t, err)
+	require.Len(t, statuses, len(migrations)+1)
+	require.Equal(t, migrate.Status{Version: 1, Name: "the_beginning", Applied: true, AppliedAt: appliedAt}, statuses[0])
+	require.Equal(t, migrate.Status{Version: 2, Name: migrations[1].Name}, statuses[1])
+	require.Equal(t, migrate.Status{Version: len(migrations) + 1, Name: "future", Applied: true, AppliedAt: appliedAt, Unknown: true}, statuses[len(statuses)-1])
+	require.NoError(t, mock.ExpectationsWereMet())
+}
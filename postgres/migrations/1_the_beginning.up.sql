@@ -1,4 +1,4 @@
-create table packages
+create table if not exists packages
 (
     name text not null,
     license text not null,
@@ -0,0 +1 @@
+drop table if exists license_overrides;
\ No newline at end of file
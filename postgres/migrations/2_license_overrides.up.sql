@@ -0,0 +1,11 @@
+create table if not exists license_overrides
+(
+    distributor text not null,
+    name text not null,
+    version_range text not null,
+    license text not null,
+    justification text not null,
+    author text not null,
+    updated_at timestamptz not null default now(),
+    constraint license_overrides_pk primary key (distributor, name, version_range)
+);
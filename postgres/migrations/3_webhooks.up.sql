@@ -0,0 +1,24 @@
+create table if not exists webhook_subscriptions
+(
+    id bigserial primary key,
+    url text not null,
+    secret text not null,
+    distributor text not null default '',
+    package_pattern text not null default '',
+    license_categories text[] not null default '{}',
+    created_at timestamptz not null default now()
+);
+
+create table if not exists webhook_deliveries
+(
+    id bigserial primary key,
+    subscription_id bigint not null references webhook_subscriptions (id) on delete cascade,
+    event jsonb not null,
+    attempts integer not null default 0,
+    next_attempt_at timestamptz not null default now(),
+    last_error text not null default '',
+    failed boolean not null default false,
+    created_at timestamptz not null default now()
+);
+
+create index if not exists webhook_deliveries_due_idx on webhook_deliveries (next_attempt_at) where not failed;
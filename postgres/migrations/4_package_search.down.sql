@@ -0,0 +1,9 @@
+drop index if exists packages_fetched_at_idx;
+drop index if exists packages_name_trgm_idx;
+drop index if exists packages_name_prefix_idx;
+drop index if exists packages_license_idx;
+drop index if exists packages_search_order_idx;
+
+-- The pg_trgm extension is left in place, since other objects in the database may depend on it.
+
+alter table packages drop column if exists fetched_at;
@@ -0,0 +1,10 @@
+alter table packages add column if not exists fetched_at timestamptz not null default now();
+
+create extension if not exists pg_trgm;
+
+-- Search results are ordered, and paginated, by (distributor, name, version).
+create index if not exists packages_search_order_idx on packages (distributor, name, version);
+create index if not exists packages_license_idx on packages (license);
+create index if not exists packages_name_prefix_idx on packages (name text_pattern_ops);
+create index if not exists packages_name_trgm_idx on packages using gin (name gin_trgm_ops);
+create index if not exists packages_fetched_at_idx on packages (fetched_at);
@@ -0,0 +1,3 @@
+alter table packages drop column if exists license_list_version;
+alter table packages drop column if exists normalization_status;
+alter table packages drop column if exists raw_license;
@@ -0,0 +1,5 @@
+-- The license as declared by the distributor, and the outcome of normalizing it, so licenses can be audited and
+-- normalized again later. Packages stored before this migration have empty values.
+alter table packages add column if not exists raw_license text not null default '';
+alter table packages add column if not exists normalization_status text not null default '';
+alter table packages add column if not exists license_list_version text not null default '';
@@ -0,0 +1,33 @@
+create table legacy_packages
+(
+    name text not null,
+    license text not null,
+    version text not null,
+    distributor text not null,
+    distribution_url text not null,
+    fetched_at timestamptz not null default now(),
+    raw_license text not null default '',
+    normalization_status text not null default '',
+    license_list_version text not null default '',
+    constraint legacy_packages_pk primary key (name, version, distributor)
+);
+
+-- Only the first distribution point of each version is kept, which is what the flat table held.
+insert into legacy_packages (name, license, version, distributor, distribution_url, fetched_at, raw_license, normalization_status, license_list_version)
+select p.name, v.license, v.version, p.distributor, d.url, v.fetched_at, v.raw_license, v.normalization_status, v.license_list_version
+from packages p
+         join package_versions v on v.package_id = p.id
+         join lateral (select url from distribution_points where version_id = v.id order by position limit 1) d on true;
+
+drop table distribution_points;
+drop table package_versions;
+drop table packages;
+
+alter table legacy_packages rename to packages;
+alter table packages rename constraint legacy_packages_pk to packages_pk;
+
+create index packages_search_order_idx on packages (distributor, name, version);
+create index packages_license_idx on packages (license);
+create index packages_name_prefix_idx on packages (name text_pattern_ops);
+create index packages_name_trgm_idx on packages using gin (name gin_trgm_ops);
+create index packages_fetched_at_idx on packages (fetched_at);
@@ -0,0 +1,66 @@
+-- Packages, their versions and the points they are distributed from are stored in separate tables, so an entry with
+-- several distribution points is stored once per distributor instead of once per distribution point.
+alter table packages rename to legacy_packages;
+alter table legacy_packages rename constraint packages_pk to legacy_packages_pk;
+drop index if exists packages_fetched_at_idx;
+drop index if exists packages_name_trgm_idx;
+drop index if exists packages_name_prefix_idx;
+drop index if exists packages_license_idx;
+drop index if exists packages_search_order_idx;
+
+create table packages
+(
+    id bigserial primary key,
+    distributor text not null,
+    name text not null,
+    constraint packages_distributor_name_key unique (distributor, name)
+);
+
+create table package_versions
+(
+    id bigserial primary key,
+    package_id bigint not null references packages (id) on delete cascade,
+    version text not null,
+    -- The license is an SPDX license expression, such as "MIT OR Apache-2.0", or a single identifier.
+    license text not null,
+    raw_license text not null default '',
+    normalization_status text not null default '',
+    license_list_version text not null default '',
+    fetched_at timestamptz not null default now(),
+    constraint package_versions_package_id_version_key unique (package_id, version)
+);
+
+create table distribution_points
+(
+    version_id bigint not null references package_versions (id) on delete cascade,
+    -- The position of the distribution point in the entry, so the order of distribution points is preserved.
+    position integer not null,
+    name text not null,
+    url text not null,
+    distributor text not null,
+    kind text not null default '',
+    checksum text not null default '',
+    constraint distribution_points_pk primary key (version_id, position)
+);
+
+insert into packages (distributor, name)
+select distinct distributor, name
+from legacy_packages;
+
+insert into package_versions (package_id, version, license, raw_license, normalization_status, license_list_version, fetched_at)
+select p.id, l.version, l.license, l.raw_license, l.normalization_status, l.license_list_version, l.fetched_at
+from legacy_packages l
+         join packages p on p.distributor = l.distributor and p.name = l.name;
+
+insert into distribution_points (version_id, position, name, url, distributor)
+select v.id, 0, l.name, l.distribution_url, l.distributor
+from legacy_packages l
+         join packages p on p.distributor = l.distributor and p.name = l.name
+         join package_versions v on v.package_id = p.id and v.version = l.version;
+
+drop table legacy_packages;
+
+create index packages_name_prefix_idx on packages (name text_pattern_ops);
+create index packages_name_trgm_idx on packages using gin (name gin_trgm_ops);
+create index package_versions_license_idx on package_versions (license);
+create index package_versions_fetched_at_idx on package_versions (fetched_at);
@@ -0,0 +1,19 @@
+create table if not exists lookup_jobs
+(
+    id bigserial primary key,
+    distributor text not null,
+    name text not null,
+    version text not null,
+    state text not null default 'pending',
+    attempts integer not null default 0,
+    next_attempt_at timestamptz not null default now(),
+    result bytea,
+    last_error text not null default '',
+    created_at timestamptz not null default now(),
+    updated_at timestamptz not null default now()
+);
+
+-- Only one job per package may be pending or running, so concurrent lookups of the same package share it.
+create unique index if not exists lookup_jobs_active_idx on lookup_jobs (distributor, name, version) where state in ('pending', 'running');
+create index if not exists lookup_jobs_due_idx on lookup_jobs (next_attempt_at) where state in ('pending', 'running');
+create index if not exists lookup_jobs_finished_idx on lookup_jobs (updated_at) where state in ('succeeded', 'failed');
@@ -0,0 +1,5 @@
+-- The overrides of tenants cannot be kept without the tenant column.
+delete from license_overrides where tenant <> '';
+alter table license_overrides drop constraint license_overrides_pk;
+alter table license_overrides drop column if exists tenant;
+alter table license_overrides add constraint license_overrides_pk primary key (distributor, name, version_range);
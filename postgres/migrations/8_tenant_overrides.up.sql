@@ -0,0 +1,4 @@
+-- Overrides without a tenant are shared by every tenant.
+alter table license_overrides add column if not exists tenant text not null default '';
+alter table license_overrides drop constraint license_overrides_pk;
+alter table license_overrides add constraint license_overrides_pk primary key (tenant, distributor, name, version_range);
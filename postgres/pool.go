@@ -2,8 +2,12 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"strconv"
+	"time"
 )
 
 // Pool is an interface for a database pool. It's sole purpose is to abstract away the underlying implementation and
@@ -11,7 +15,9 @@ import (
 // code intended to be used in production.
 type Pool interface {
 	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
 	Begin(ctx context.Context) (pgx.Tx, error)
+	Stat() *pgxpool.Stat
 	Close()
 }
 
@@ -22,27 +28,125 @@ type pool struct {
 }
 
 // NewPool creates a new database connection pool for use in production code. It returns a private type, that
-// implements the [Pool] interface.
-func NewPool(ctx context.Context, connString string) (Pool, error) {
+// implements the [Pool] interface. The options take precedence over the corresponding settings of connString.
+func NewPool(ctx context.Context, connString string, options ...PoolOption) (Pool, error) {
+	opts := defaultPoolOptions
+	for _, opt := range globalPoolOptions {
+		opt.apply(&opts)
+	}
+	for _, opt := range options {
+		opt.apply(&opts)
+	}
+
+	config, err := pgxpool.ParseConfig(connString)
+	if err != nil {
+		return nil, err
+	}
+	if opts.MaxConns > 0 {
+		config.MaxConns = opts.MaxConns
+	}
+	if opts.MinConns > 0 {
+		config.MinConns = opts.MinConns
+	}
+	if config.MinConns > config.MaxConns {
+		return nil, ErrMinConnsExceedMaxConns
+	}
+	if opts.ApplicationName != "" {
+		config.ConnConfig.RuntimeParams["application_name"] = opts.ApplicationName
+	}
+	if opts.StatementTimeout > 0 {
+		config.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(opts.StatementTimeout.Milliseconds(), 10)
+	}
+
 	var p pool
-	var err error
-	p.pool, err = pgxpool.New(ctx, connString)
+	p.pool, err = pgxpool.NewWithConfig(ctx, config)
 	if err != nil {
 		return nil, err
 	}
 	return &p, nil
 }
 
+var ErrMinConnsExceedMaxConns = errors.New("minimum number of connections exceeds the maximum number of connections")
+
+type poolOptions struct {
+	MaxConns         int32
+	MinConns         int32
+	StatementTimeout time.Duration
+	ApplicationName  string
+}
+
+var defaultPoolOptions = poolOptions{}
+
+var globalPoolOptions []PoolOption
+
+type PoolOption interface {
+	apply(*poolOptions)
+}
+
+type funcPoolOption struct {
+	f func(*poolOptions)
+}
+
+func (fpo *funcPoolOption) apply(opts *poolOptions) {
+	fpo.f(opts)
+}
+
+func newFuncPoolOption(f func(*poolOptions)) *funcPoolOption {
+	return &funcPoolOption{
+		f: f,
+	}
+}
+
+// WithMaxConns sets the maximum number of connections of the pool. The default of pgx is used if n is not positive.
+func WithMaxConns(n int32) PoolOption {
+	return newFuncPoolOption(func(opts *poolOptions) {
+		opts.MaxConns = n
+	})
+}
+
+// WithMinConns sets the number of connections the pool keeps open when idle. The default of pgx is used if n is not
+// positive.
+func WithMinConns(n int32) PoolOption {
+	return newFuncPoolOption(func(opts *poolOptions) {
+		opts.MinConns = n
+	})
+}
+
+// WithStatementTimeout sets the statement_timeout of the connections of the pool, after which the server cancels a
+// statement. Statements have no timeout if timeout is not positive, unless one is set by the connection string.
+func WithStatementTimeout(timeout time.Duration) PoolOption {
+	return newFuncPoolOption(func(opts *poolOptions) {
+		opts.StatementTimeout = timeout
+	})
+}
+
+// WithApplicationName sets the application_name the connections of the pool report to the server.
+func WithApplicationName(name string) PoolOption {
+	return newFuncPoolOption(func(opts *poolOptions) {
+		opts.ApplicationName = name
+	})
+}
+
 // Query executes a query against the database. It wraps the underlying [pgxpool.Pool.Query] function.
 func (p *pool) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
 	return p.pool.Query(ctx, sql, args...)
 }
 
+// Exec executes a statement against the database. It wraps the underlying [pgxpool.Pool.Exec] function.
+func (p *pool) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	return p.pool.Exec(ctx, sql, args...)
+}
+
 // Begin starts a transaction. It wraps the underlying [pgxpool.Pool.Begin] function.
 func (p *pool) Begin(ctx context.Context) (pgx.Tx, error) {
 	return p.pool.Begin(ctx)
 }
 
+// Stat returns the statistics of the pool. It wraps the underlying [pgxpool.Pool.Stat] function.
+func (p *pool) Stat() *pgxpool.Stat {
+	return p.pool.Stat()
+}
+
 // Close closes the pool. It wraps the underlying [pgxpool.Pool.Close] function.
 func (p *pool) Close() {
 	p.pool.Close()
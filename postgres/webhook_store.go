@@ -0,0 +1,149 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/chainalysis-oss/oslc"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"time"
+)
+
+// Compile time check to ensure Datastore implements [oslc.WebhookStore].
+var _ oslc.WebhookStore = (*Datastore)(nil)
+
+var datastoreCreateSubscriptionStatement = "INSERT INTO webhook_subscriptions (url, secret, distributor, package_pattern, license_categories) VALUES ($1, $2, $3, $4, $5) RETURNING id, created_at"
+
+func (d *Datastore) CreateSubscription(ctx context.Context, subscription oslc.WebhookSubscription) (_ oslc.WebhookSubscription, err error) {
+	ctx, span := d.startSpan(ctx, "INSERT", datastoreCreateSubscriptionStatement)
+	defer func() { endSpan(span, err) }()
+
+	categories := subscription.LicenseCategories
+	if categories == nil {
+		categories = []string{}
+	}
+	rows, err := d.options.Pool.Query(ctx, datastoreCreateSubscriptionStatement, subscription.URL, subscription.Secret, subscription.Distributor, subscription.PackagePattern, categories)
+	if err != nil {
+		return oslc.WebhookSubscription{}, err
+	}
+	_, err = pgx.CollectExactlyOneRow(rows, func(row pgx.CollectableRow) (struct{}, error) {
+		return struct{}{}, row.Scan(&subscription.ID, &subscription.CreatedAt)
+	})
+	if err != nil {
+		return oslc.WebhookSubscription{}, err
+	}
+	subscription.LicenseCategories = categories
+	return subscription, nil
+}
+
+var datastoreListSubscriptionsStatement = "SELECT id, url, secret, distributor, package_pattern, license_categories, created_at FROM webhook_subscriptions ORDER BY id"
+
+func (d *Datastore) ListSubscriptions(ctx context.Context) (_ []oslc.WebhookSubscription, err error) {
+	ctx, span := d.startSpan(ctx, "SELECT", datastoreListSubscriptionsStatement)
+	defer func() { endSpan(span, err) }()
+
+	rows, err := d.options.Pool.Query(ctx, datastoreListSubscriptionsStatement)
+	if err != nil {
+		return nil, err
+	}
+	var s oslc.WebhookSubscription
+	subscriptions := make([]oslc.WebhookSubscription, 0)
+	_, err = pgx.ForEachRow(rows, []any{&s.ID, &s.URL, &s.Secret, &s.Distributor, &s.PackagePattern, &s.LicenseCategories, &s.CreatedAt}, func() error {
+		subscriptions = append(subscriptions, s)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+var datastoreDeleteSubscriptionStatement = "DELETE FROM webhook_subscriptions WHERE id = $1"
+
+func (d *Datastore) DeleteSubscription(ctx context.Context, id int64) (err error) {
+	ctx, span := d.startSpan(ctx, "DELETE", datastoreDeleteSubscriptionStatement, attribute.Int64("oslc.webhook.subscription_id", id))
+	defer func() { endSpan(span, err) }()
+
+	tag, err := d.options.Pool.Exec(ctx, datastoreDeleteSubscriptionStatement, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return oslc.ErrDatastoreObjectNotFound
+	}
+	return nil
+}
+
+var datastoreEnqueueDeliveryStatement = "INSERT INTO webhook_deliveries (subscription_id, event) VALUES ($1, $2)"
+
+func (d *Datastore) EnqueueDelivery(ctx context.Context, subscriptionID int64, event oslc.LicenseChangeEvent) (err error) {
+	ctx, span := d.startSpan(ctx, "INSERT", datastoreEnqueueDeliveryStatement, attribute.Int64("oslc.webhook.subscription_id", subscriptionID))
+	defer func() { endSpan(span, err) }()
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("encoding event: %w", err)
+	}
+	_, err = d.options.Pool.Exec(ctx, datastoreEnqueueDeliveryStatement, subscriptionID, string(payload))
+	return err
+}
+
+// datastoreClaimDeliveriesStatement claims due deliveries by moving their next attempt past the lease. Rows locked by
+// concurrent claims are skipped, so every delivery is claimed by a single caller.
+var datastoreClaimDeliveriesStatement = "UPDATE webhook_deliveries d SET attempts = d.attempts + 1, next_attempt_at = now() + make_interval(secs => $2) FROM webhook_subscriptions s WHERE s.id = d.subscription_id AND d.id IN (SELECT id FROM webhook_deliveries WHERE NOT failed AND next_attempt_at <= now() ORDER BY next_attempt_at LIMIT $1 FOR UPDATE SKIP LOCKED) RETURNING d.id, d.subscription_id, s.url, s.secret, d.event, d.attempts"
+
+func (d *Datastore) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) (_ []oslc.WebhookDelivery, err error) {
+	ctx, span := d.startSpan(ctx, "UPDATE", datastoreClaimDeliveriesStatement)
+	defer func() { endSpan(span, err) }()
+
+	rows, err := d.options.Pool.Query(ctx, datastoreClaimDeliveriesStatement, limit, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	var delivery oslc.WebhookDelivery
+	var event []byte
+	deliveries := make([]oslc.WebhookDelivery, 0)
+	_, err = pgx.ForEachRow(rows, []any{&delivery.ID, &delivery.SubscriptionID, &delivery.URL, &delivery.Secret, &event, &delivery.Attempts}, func() error {
+		delivery.Event = oslc.LicenseChangeEvent{}
+		if err := json.Unmarshal(event, &delivery.Event); err != nil {
+			return fmt.Errorf("decoding event of delivery %d: %w", delivery.ID, err)
+		}
+		deliveries = append(deliveries, delivery)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+var datastoreCompleteDeliveryStatement = "DELETE FROM webhook_deliveries WHERE id = $1"
+
+func (d *Datastore) CompleteDelivery(ctx context.Context, id int64) (err error) {
+	ctx, span := d.startSpan(ctx, "DELETE", datastoreCompleteDeliveryStatement, attribute.Int64("oslc.webhook.delivery_id", id))
+	defer func() { endSpan(span, err) }()
+
+	_, err = d.options.Pool.Exec(ctx, datastoreCompleteDeliveryStatement, id)
+	return err
+}
+
+var datastoreRetryDeliveryStatement = "UPDATE webhook_deliveries SET next_attempt_at = $2, last_error = $3 WHERE id = $1"
+
+func (d *Datastore) RetryDelivery(ctx context.Context, id int64, next time.Time, lastError string) (err error) {
+	ctx, span := d.startSpan(ctx, "UPDATE", datastoreRetryDeliveryStatement, attribute.Int64("oslc.webhook.delivery_id", id))
+	defer func() { endSpan(span, err) }()
+
+	_, err = d.options.Pool.Exec(ctx, datastoreRetryDeliveryStatement, id, next, lastError)
+	return err
+}
+
+var datastoreFailDeliveryStatement = "UPDATE webhook_deliveries SET failed = true, last_error = $2 WHERE id = $1"
+
+func (d *Datastore) FailDelivery(ctx context.Context, id int64, lastError string) (err error) {
+	ctx, span := d.startSpan(ctx, "UPDATE", datastoreFailDeliveryStatement, attribute.Int64("oslc.webhook.delivery_id", id))
+	defer func() { endSpan(span, err) }()
+
+	_, err = d.options.Pool.Exec(ctx, datastoreFailDeliveryStatement, id, lastError)
+	return err
+}
@@ -0,0 +1,184 @@
+package postgres
+
+import (
+	"context"
+	"github.com/chainalysis-oss/oslc"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestDatastore_CreateSubscription(t *testing.T) {
+	mock := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(mock))
+	require.NoError(t, err)
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	mock.ExpectQuery(datastoreCreateSubscriptionStatement).
+		WithArgs("https://example.com/hook", "secret", oslc.DistributorNpm, "@types/*", []string{}).
+		WillReturnRows(mock.NewRows([]string{"id", "created_at"}).AddRow(int64(7), createdAt)).
+		Times(1)
+	subscription, err := ds.CreateSubscription(context.Background(), oslc.WebhookSubscription{
+		URL:            "https://example.com/hook",
+		Secret:         "secret",
+		Distributor:    oslc.DistributorNpm,
+		PackagePattern: "@types/*",
+	})
+	require.NoError(t, err)
+	require.Equal(t, oslc.WebhookSubscription{
+		ID:                7,
+		URL:               "https://example.com/hook",
+		Secret:            "secret",
+		Distributor:       oslc.DistributorNpm,
+		PackagePattern:    "@types/*",
+		LicenseCategories: []string{},
+		CreatedAt:         createdAt,
+	}, subscription)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatastore_CreateSubscription_ErrQuery(t *testing.T) {
+	mock := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(mock))
+	require.NoError(t, err)
+	mock.ExpectQuery(datastoreCreateSubscriptionStatement).
+		WithArgs("", "", "", "", []string{"copyleft"}).
+		WillReturnError(assert.AnError)
+	_, err = ds.CreateSubscription(context.Background(), oslc.WebhookSubscription{LicenseCategories: []string{"copyleft"}})
+	require.ErrorIs(t, err, assert.AnError)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatastore_ListSubscriptions(t *testing.T) {
+	mock := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(mock))
+	require.NoError(t, err)
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	mock.ExpectQuery(datastoreListSubscriptionsStatement).
+		WillReturnRows(mock.NewRows([]string{"id", "url", "secret", "distributor", "package_pattern", "license_categories", "created_at"}).
+			AddRow(int64(1), "https://a", "s1", "", "", []string{}, createdAt).
+			AddRow(int64(2), "https://b", "s2", oslc.DistributorPypi, "django*", []string{"copyleft"}, createdAt)).
+		Times(1)
+	subscriptions, err := ds.ListSubscriptions(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []oslc.WebhookSubscription{
+		{ID: 1, URL: "https://a", Secret: "s1", LicenseCategories: []string{}, CreatedAt: createdAt},
+		{ID: 2, URL: "https://b", Secret: "s2", Distributor: oslc.DistributorPypi, PackagePattern: "django*", LicenseCategories: []string{"copyleft"}, CreatedAt: createdAt},
+	}, subscriptions)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatastore_ListSubscriptions_ErrQuery(t *testing.T) {
+	mock := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(mock))
+	require.NoError(t, err)
+	mock.ExpectQuery(datastoreListSubscriptionsStatement).WillReturnError(assert.AnError)
+	_, err = ds.ListSubscriptions(context.Background())
+	require.ErrorIs(t, err, assert.AnError)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatastore_DeleteSubscription(t *testing.T) {
+	mock := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(mock))
+	require.NoError(t, err)
+	mock.ExpectExec(datastoreDeleteSubscriptionStatement).
+		WithArgs(int64(1)).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	mock.ExpectExec(datastoreDeleteSubscriptionStatement).
+		WithArgs(int64(2)).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	mock.ExpectExec(datastoreDeleteSubscriptionStatement).
+		WithArgs(int64(3)).
+		WillReturnError(assert.AnError)
+	require.NoError(t, ds.DeleteSubscription(context.Background(), 1))
+	require.ErrorIs(t, ds.DeleteSubscription(context.Background(), 2), oslc.ErrDatastoreObjectNotFound)
+	require.ErrorIs(t, ds.DeleteSubscription(context.Background(), 3), assert.AnError)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatastore_EnqueueDelivery(t *testing.T) {
+	mock := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(mock))
+	require.NoError(t, err)
+	mock.ExpectExec(datastoreEnqueueDeliveryStatement).
+		WithArgs(int64(1), `{"distributor":"npm","name":"test","version":"2.0.0","license":"MIT","previous_version":"1.0.0","previous_license":"ISC","detected_at":"2024-01-02T03:04:05Z"}`).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	require.NoError(t, ds.EnqueueDelivery(context.Background(), 1, oslc.LicenseChangeEvent{
+		Distributor:     oslc.DistributorNpm,
+		Name:            "test",
+		Version:         "2.0.0",
+		License:         "MIT",
+		PreviousVersion: "1.0.0",
+		PreviousLicense: "ISC",
+		DetectedAt:      time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatastore_ClaimDeliveries(t *testing.T) {
+	mock := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(mock))
+	require.NoError(t, err)
+	mock.ExpectQuery(datastoreClaimDeliveriesStatement).
+		WithArgs(10, float64(60)).
+		WillReturnRows(mock.NewRows([]string{"id", "subscription_id", "url", "secret", "event", "attempts"}).
+			AddRow(int64(1), int64(2), "https://a", "s", []byte(`{"distributor":"npm","name":"test","license":"MIT"}`), 1).
+			AddRow(int64(3), int64(2), "https://a", "s", []byte(`{"distributor":"npm","name":"other"}`), 4)).
+		Times(1)
+	deliveries, err := ds.ClaimDeliveries(context.Background(), 10, time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, []oslc.WebhookDelivery{
+		{ID: 1, SubscriptionID: 2, URL: "https://a", Secret: "s", Event: oslc.LicenseChangeEvent{Distributor: oslc.DistributorNpm, Name: "test", License: "MIT"}, Attempts: 1},
+		{ID: 3, SubscriptionID: 2, URL: "https://a", Secret: "s", Event: oslc.LicenseChangeEvent{Distributor: oslc.DistributorNpm, Name: "other"}, Attempts: 4},
+	}, deliveries)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatastore_ClaimDeliveries_errors(t *testing.T) {
+	t.Run("query", func(t *testing.T) {
+		mock := newPoolMock(t)
+		ds, err := NewDatastore(WithPool(mock))
+		require.NoError(t, err)
+		mock.ExpectQuery(datastoreClaimDeliveriesStatement).WithArgs(10, float64(60)).WillReturnError(assert.AnError)
+		_, err = ds.ClaimDeliveries(context.Background(), 10, time.Minute)
+		require.ErrorIs(t, err, assert.AnError)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("invalid event", func(t *testing.T) {
+		mock := newPoolMock(t)
+		ds, err := NewDatastore(WithPool(mock))
+		require.NoError(t, err)
+		mock.ExpectQuery(datastoreClaimDeliveriesStatement).WithArgs(10, float64(60)).
+			WillReturnRows(mock.NewRows([]string{"id", "subscription_id", "url", "secret", "event", "attempts"}).
+				AddRow(int64(1), int64(2), "https://a", "s", []byte(`not json`), 1))
+		_, err = ds.ClaimDeliveries(context.Background(), 10, time.Minute)
+		require.Error(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDatastore_resolveDelivery(t *testing.T) {
+	mock := newPoolMock(t)
+	ds, err := NewDatastore(WithPool(mock))
+	require.NoError(t, err)
+	next := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	mock.ExpectExec(datastoreCompleteDeliveryStatement).
+		WithArgs(int64(1)).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	mock.ExpectExec(datastoreRetryDeliveryStatement).
+		WithArgs(int64(2), next, "unexpected status code: 500").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec(datastoreFailDeliveryStatement).
+		WithArgs(int64(3), "timeout").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectExec(datastoreCompleteDeliveryStatement).
+		WithArgs(int64(4)).
+		WillReturnError(assert.AnError)
+	require.NoError(t, ds.CompleteDelivery(context.Background(), 1))
+	require.NoError(t, ds.RetryDelivery(context.Background(), 2, next, "unexpected status code: 500"))
+	require.NoError(t, ds.FailDelivery(context.Background(), 3, "timeout"))
+	require.ErrorIs(t, ds.CompleteDelivery(context.Background(), 4), assert.AnError)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,113 @@
+// Package purl converts between package URLs, as specified by https://github.com/package-url/purl-spec, and the
+// distributors and package names used by OSLC.
+package purl
+
+import (
+	"errors"
+	"fmt"
+	"github.com/chainalysis-oss/oslc"
+	"net/url"
+	"strings"
+)
+
+// Package is a package identified by a package URL.
+type Package struct {
+	Distributor string
+	Name        string
+	Version     string
+}
+
+// ErrUnsupported is returned for package URLs that are malformed, or whose type is not served by a distributor
+// supported by OSLC.
+var ErrUnsupported = errors.New("unsupported package URL")
+
+// distributors maps package URL types to the distributors serving them.
+var distributors = map[string]string{
+	"npm":    oslc.DistributorNpm,
+	"pypi":   oslc.DistributorPypi,
+	"maven":  oslc.DistributorMaven,
+	"cargo":  oslc.DistributorCratesIo,
+	"golang": oslc.DistributorGo,
+}
+
+// Parse parses a package URL, such as "pkg:npm/%40babel/core@7.24.0". The name is returned as used by the distributor,
+// so the namespace and name of a Maven package are joined by a colon. Qualifiers and subpaths are ignored.
+func Parse(purl string) (Package, error) {
+	rest, ok := strings.CutPrefix(purl, "pkg:")
+	if !ok {
+		return Package{}, fmt.Errorf("%w: %q", ErrUnsupported, purl)
+	}
+	rest, _, _ = strings.Cut(rest, "#")
+	rest, _, _ = strings.Cut(rest, "?")
+	typ, path, ok := strings.Cut(strings.TrimLeft(rest, "/"), "/")
+	distributor, known := distributors[strings.ToLower(typ)]
+	if !ok || !known {
+		return Package{}, fmt.Errorf("%w: %q", ErrUnsupported, purl)
+	}
+	path, version, _ := strings.Cut(path, "@")
+
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, segment := range segments {
+		unescaped, err := url.PathUnescape(segment)
+		if err != nil {
+			return Package{}, fmt.Errorf("%w: %q: %w", ErrUnsupported, purl, err)
+		}
+		segments[i] = unescaped
+	}
+	version, err := url.PathUnescape(version)
+	if err != nil {
+		return Package{}, fmt.Errorf("%w: %q: %w", ErrUnsupported, purl, err)
+	}
+
+	name := strings.Join(segments, "/")
+	if distributor == oslc.DistributorMaven {
+		if len(segments) != 2 {
+			return Package{}, fmt.Errorf("%w: %q: expected group and artifact", ErrUnsupported, purl)
+		}
+		name = segments[0] + ":" + segments[1]
+	}
+	if name == "" {
+		return Package{}, fmt.Errorf("%w: %q: missing name", ErrUnsupported, purl)
+	}
+	return Package{Distributor: distributor, Name: name, Version: version}, nil
+}
+
+// Format returns the package URL of the package. The version is omitted if it is empty.
+func Format(p Package) (string, error) {
+	var typ string
+	for t, distributor := range distributors {
+		if distributor == p.Distributor {
+			typ = t
+		}
+	}
+	if typ == "" {
+		return "", fmt.Errorf("%w: no package URL type for distributor %q", ErrUnsupported, p.Distributor)
+	}
+
+	segments := strings.Split(p.Name, "/")
+	if p.Distributor == oslc.DistributorMaven {
+		group, artifact, ok := strings.Cut(p.Name, ":")
+		if !ok {
+			return "", fmt.Errorf("%w: expected Maven name of the form groupId:artifactId, got %q", ErrUnsupported, p.Name)
+		}
+		segments = []string{group, artifact}
+	}
+	for i, segment := range segments {
+		if segment == "" {
+			return "", fmt.Errorf("%w: invalid package name %q", ErrUnsupported, p.Name)
+		}
+		segments[i] = escape(segment)
+	}
+
+	purl := "pkg:" + typ + "/" + strings.Join(segments, "/")
+	if p.Version != "" {
+		purl += "@" + escape(p.Version)
+	}
+	return purl, nil
+}
+
+// escape percent-encodes a segment of a package URL. Unlike [url.PathEscape], it also encodes "@", which separates the
+// version, and "+", as the specification requires.
+func escape(s string) string {
+	return strings.NewReplacer("@", "%40", "+", "%2B").Replace(url.PathEscape(s))
+}
@@ -0,0 +1,73 @@
+package purl
+
+import (
+	"github.com/chainalysis-oss/oslc"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		purl    string
+		want    Package
+		wantErr bool
+	}{
+		{purl: "pkg:npm/lodash@4.17.21", want: Package{oslc.DistributorNpm, "lodash", "4.17.21"}},
+		{purl: "pkg:npm/%40babel/core@7.24.0", want: Package{oslc.DistributorNpm, "@babel/core", "7.24.0"}},
+		{purl: "pkg:maven/org.apache.commons/commons-lang3@3.14.0", want: Package{oslc.DistributorMaven, "org.apache.commons:commons-lang3", "3.14.0"}},
+		{purl: "pkg:golang/github.com/stretchr/testify@v1.10.0#assert", want: Package{oslc.DistributorGo, "github.com/stretchr/testify", "v1.10.0"}},
+		{purl: "pkg:PyPI/requests", want: Package{oslc.DistributorPypi, "requests", ""}},
+		{purl: "pkg:cargo/serde@1.0.0%2Bbuild", want: Package{oslc.DistributorCratesIo, "serde", "1.0.0+build"}},
+		{purl: "pkg:gem/rails@7.1.3", wantErr: true},
+		{purl: "pkg:maven/guava@33.0.0", wantErr: true},
+		{purl: "pkg:npm", wantErr: true},
+		{purl: "pkg:npm/@1.0.0", wantErr: true},
+		{purl: "npm/lodash@4.17.21", wantErr: true},
+		{purl: "pkg:npm/%zz@1.0.0", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.purl, func(t *testing.T) {
+			p, err := Parse(tt.purl)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrUnsupported)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, p)
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		p       Package
+		want    string
+		wantErr bool
+	}{
+		{p: Package{oslc.DistributorNpm, "lodash", "4.17.21"}, want: "pkg:npm/lodash@4.17.21"},
+		{p: Package{oslc.DistributorNpm, "@babel/core", "7.24.0"}, want: "pkg:npm/%40babel/core@7.24.0"},
+		{p: Package{oslc.DistributorMaven, "org.apache.commons:commons-lang3", "3.14.0"}, want: "pkg:maven/org.apache.commons/commons-lang3@3.14.0"},
+		{p: Package{oslc.DistributorGo, "github.com/stretchr/testify", "v1.10.0"}, want: "pkg:golang/github.com/stretchr/testify@v1.10.0"},
+		{p: Package{oslc.DistributorPypi, "requests", ""}, want: "pkg:pypi/requests"},
+		{p: Package{oslc.DistributorCratesIo, "serde", "1.0.0+build"}, want: "pkg:cargo/serde@1.0.0%2Bbuild"},
+		{p: Package{"rubygems", "rails", "7.1.3"}, wantErr: true},
+		{p: Package{oslc.DistributorMaven, "guava", "33.0.0"}, wantErr: true},
+		{p: Package{oslc.DistributorNpm, "", "1.0.0"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			purl, err := Format(tt.p)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrUnsupported)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, purl)
+
+			// Formatting and parsing are inverses.
+			p, err := Parse(purl)
+			require.NoError(t, err)
+			require.Equal(t, tt.p, p)
+		})
+	}
+}
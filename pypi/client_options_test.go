@@ -52,3 +52,10 @@ func TestWithLogger(t *testing.T) {
 	f.apply(&opts)
 	require.Equal(t, logger, opts.Logger)
 }
+
+func TestWithBaseURL(t *testing.T) {
+	opts := clientOptions{}
+	f := WithBaseURL("https://mirror.example.com")
+	f.apply(&opts)
+	require.Equal(t, "https://mirror.example.com", opts.BaseURL)
+}
@@ -1,6 +1,7 @@
 package pypi
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/chainalysis-oss/oslc"
@@ -73,22 +74,22 @@ func NewClient(options ...ClientOption) (*Client, error) {
 
 // GetPackageVersion returns the package with the given name and version.
 // If version is empty, the latest version is returned.
-func (c *Client) GetPackageVersion(name, version string) (oslc.Entry, error) {
+func (c *Client) GetPackageVersion(ctx context.Context, name, version string) (oslc.Entry, error) {
 	path := fmt.Sprintf("pypi/%s/%s/json", name, version)
 	if version == "" {
 		path = fmt.Sprintf("pypi/%s/json", name)
 	}
 
-	resp, err := c.options.HttpClient.Query(fmt.Sprintf("%s/%s", c.options.BaseURL, path))
+	resp, err := c.options.HttpClient.Query(ctx, fmt.Sprintf("%s/%s", c.options.BaseURL, path))
 	if err != nil {
-		return oslc.Entry{}, oslc.DistributorError{Distributor: oslc.DistributorPypi, Err: err}
+		return oslc.Entry{}, oslc.NewTransportError(oslc.DistributorPypi, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode == http.StatusNotFound {
-		ok, err := c.packageExists(name)
+		ok, err := c.packageExists(ctx, name)
 		if err != nil {
-			return oslc.Entry{}, oslc.DistributorError{Distributor: oslc.DistributorPypi, Err: err}
+			return oslc.Entry{}, oslc.WrapDistributorError(oslc.DistributorPypi, err)
 		}
 		if !ok {
 			return oslc.Entry{}, oslc.DistributorError{Distributor: oslc.DistributorPypi, Err: fmt.Errorf("%w: %s", oslc.ErrNoSuchPackage, name)}
@@ -97,7 +98,7 @@ func (c *Client) GetPackageVersion(name, version string) (oslc.Entry, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return oslc.Entry{}, oslc.DistributorError{Distributor: oslc.DistributorPypi, Err: fmt.Errorf("unexpected status code: %d", resp.StatusCode)}
+		return oslc.Entry{}, oslc.NewStatusError(oslc.DistributorPypi, resp, fmt.Errorf("unexpected status code: %d", resp.StatusCode))
 	}
 
 	var pkg pypiPackageResponse
@@ -110,14 +111,41 @@ func (c *Client) GetPackageVersion(name, version string) (oslc.Entry, error) {
 
 // GetPackage returns the package with the given name. It is a convenience function for [GetPackageVersion]
 // with an empty version.
-func (c *Client) GetPackage(name string) (oslc.Entry, error) {
-	return c.GetPackageVersion(name, "")
+func (c *Client) GetPackage(ctx context.Context, name string) (oslc.Entry, error) {
+	return c.GetPackageVersion(ctx, name, "")
 }
 
-func (c *Client) packageExists(name string) (bool, error) {
-	resp, err := c.options.HttpClient.Query(fmt.Sprintf("%s/pypi/%s/json", c.options.BaseURL, name))
+// ListVersions returns the versions listed in the releases of the package with the given name.
+func (c *Client) ListVersions(ctx context.Context, name string) ([]string, error) {
+	resp, err := c.options.HttpClient.Query(ctx, fmt.Sprintf("%s/pypi/%s/json", c.options.BaseURL, name))
 	if err != nil {
-		return false, err
+		return nil, oslc.NewTransportError(oslc.DistributorPypi, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, oslc.DistributorError{Distributor: oslc.DistributorPypi, Err: fmt.Errorf("%w: %s", oslc.ErrNoSuchPackage, name)}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, oslc.NewStatusError(oslc.DistributorPypi, resp, fmt.Errorf("unexpected status code: %d", resp.StatusCode))
+	}
+
+	var pkg pypiPackageResponse
+	err = json.NewDecoder(resp.Body).Decode(&pkg)
+	if err != nil {
+		return nil, oslc.DistributorError{Distributor: oslc.DistributorPypi, Err: err}
+	}
+	versions := make([]string, 0, len(pkg.Releases))
+	for v := range pkg.Releases {
+		versions = append(versions, v)
+	}
+	return versions, nil
+}
+
+func (c *Client) packageExists(ctx context.Context, name string) (bool, error) {
+	resp, err := c.options.HttpClient.Query(ctx, fmt.Sprintf("%s/pypi/%s/json", c.options.BaseURL, name))
+	if err != nil {
+		return false, oslc.NewTransportError(oslc.DistributorPypi, err)
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode == http.StatusOK {
@@ -126,5 +154,5 @@ func (c *Client) packageExists(name string) (bool, error) {
 	if resp.StatusCode == http.StatusNotFound {
 		return false, nil
 	}
-	return false, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	return false, oslc.NewStatusError(oslc.DistributorPypi, resp, fmt.Errorf("unexpected status code: %d", resp.StatusCode))
 }
@@ -2,6 +2,7 @@ package pypi
 
 import (
 	"bytes"
+	"context"
 	"github.com/chainalysis-oss/oslc"
 	ownHTTP "github.com/chainalysis-oss/oslc/http"
 	"github.com/stretchr/testify/assert"
@@ -9,6 +10,7 @@ import (
 	"io"
 	"net/http"
 	"testing"
+	"time"
 )
 
 func TestPypiPackageResponse_AsEntry(t *testing.T) {
@@ -141,7 +143,7 @@ func TestClient_GetPackageVersion(t *testing.T) {
 	for _, tt := range testcases {
 		t.Run(tt.name, func(t *testing.T) {
 			c := setupClient(t, setupHttpClientWithBody(t, tt.body))
-			out, err := c.GetPackageVersion(tt.pkgName, tt.pkgVersion)
+			out, err := c.GetPackageVersion(context.Background(), tt.pkgName, tt.pkgVersion)
 			require.NoError(t, err)
 			require.Equal(t, tt.expected, out)
 		})
@@ -159,7 +161,7 @@ func TestClient_GetPackageVersion_version_path(t *testing.T) {
 	httpClient, err := ownHTTP.NewClient(ownHTTP.WithHTTPClient(mock))
 	require.NoError(t, err)
 	c := setupClient(t, httpClient)
-	_, err = c.GetPackageVersion("test", "1.0.0")
+	_, err = c.GetPackageVersion(context.Background(), "test", "1.0.0")
 	require.NoError(t, err)
 }
 
@@ -174,7 +176,7 @@ func TestClient_GetPackageVersion_no_version_path(t *testing.T) {
 	httpClient, err := ownHTTP.NewClient(ownHTTP.WithHTTPClient(mock))
 	require.NoError(t, err)
 	c := setupClient(t, httpClient)
-	_, err = c.GetPackageVersion("test", "")
+	_, err = c.GetPackageVersion(context.Background(), "test", "")
 	require.NoError(t, err)
 }
 
@@ -185,19 +187,124 @@ func TestClient_GetPackageVersion_http_client_error(t *testing.T) {
 	httpClient, err := ownHTTP.NewClient(ownHTTP.WithHTTPClient(mock))
 	require.NoError(t, err)
 	c := setupClient(t, httpClient)
-	_, err = c.GetPackageVersion("test", "")
+	_, err = c.GetPackageVersion(context.Background(), "test", "")
 	assert.Error(t, err)
 }
 
 func TestClient_GetPackageVersion_http_client_status_error(t *testing.T) {
 	c := setupClient(t, setupHttpClientWithStatusAndBody(t, http.StatusNotFound, ""))
-	_, err := c.GetPackageVersion("test", "")
+	_, err := c.GetPackageVersion(context.Background(), "test", "")
 	assert.Error(t, err)
 }
 
+func TestClient_GetPackageVersion_error_kinds(t *testing.T) {
+	testcases := []struct {
+		name           string
+		status         int
+		header         http.Header
+		err            error
+		wantKind       oslc.DistributorErrorKind
+		wantRetryAfter time.Duration
+	}{
+		{
+			name:     "connection error",
+			err:      assert.AnError,
+			wantKind: oslc.DistributorErrorUnavailable,
+		},
+		{
+			name:     "deadline exceeded",
+			err:      context.DeadlineExceeded,
+			wantKind: oslc.DistributorErrorTimeout,
+		},
+		{
+			name:     "server error",
+			status:   http.StatusBadGateway,
+			wantKind: oslc.DistributorErrorUnavailable,
+		},
+		{
+			name:           "service unavailable with retry-after",
+			status:         http.StatusServiceUnavailable,
+			header:         http.Header{"Retry-After": {"120"}},
+			wantKind:       oslc.DistributorErrorUnavailable,
+			wantRetryAfter: 2 * time.Minute,
+		},
+		{
+			name:     "gateway timeout",
+			status:   http.StatusGatewayTimeout,
+			wantKind: oslc.DistributorErrorTimeout,
+		},
+		{
+			name:           "rate limited",
+			status:         http.StatusTooManyRequests,
+			header:         http.Header{"Retry-After": {"30"}},
+			wantKind:       oslc.DistributorErrorRateLimited,
+			wantRetryAfter: 30 * time.Second,
+		},
+		{
+			name:     "rate limited with invalid retry-after",
+			status:   http.StatusTooManyRequests,
+			header:   http.Header{"Retry-After": {"soon"}},
+			wantKind: oslc.DistributorErrorRateLimited,
+		},
+		{
+			name:     "rate limited with past retry-after date",
+			status:   http.StatusTooManyRequests,
+			header:   http.Header{"Retry-After": {"Wed, 21 Oct 2015 07:28:00 GMT"}},
+			wantKind: oslc.DistributorErrorRateLimited,
+		},
+		{
+			name:     "unexpected client error",
+			status:   http.StatusForbidden,
+			wantKind: oslc.DistributorErrorUnknown,
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := ownHTTP.NewTestHTTPClient(func(req *http.Request) (*http.Response, error) {
+				if tt.err != nil {
+					return nil, tt.err
+				}
+				return &http.Response{
+					StatusCode: tt.status,
+					Header:     tt.header,
+					Body:       http.NoBody,
+				}, nil
+			})
+			httpClient, err := ownHTTP.NewClient(ownHTTP.WithHTTPClient(mock))
+			require.NoError(t, err)
+			c := setupClient(t, httpClient)
+
+			_, err = c.GetPackageVersion(context.Background(), "test", "1.0.0")
+			var de oslc.DistributorError
+			require.ErrorAs(t, err, &de)
+			require.Equal(t, oslc.DistributorPypi, de.Distributor)
+			require.Equal(t, tt.wantKind, de.Kind)
+			require.Equal(t, tt.wantRetryAfter, de.RetryAfter)
+		})
+	}
+}
+
+func TestClient_GetPackageVersion_retry_after_date(t *testing.T) {
+	mock := ownHTTP.NewTestHTTPClient(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusTooManyRequests,
+			Header:     http.Header{"Retry-After": {time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)}},
+			Body:       http.NoBody,
+		}, nil
+	})
+	httpClient, err := ownHTTP.NewClient(ownHTTP.WithHTTPClient(mock))
+	require.NoError(t, err)
+	c := setupClient(t, httpClient)
+
+	_, err = c.GetPackageVersion(context.Background(), "test", "1.0.0")
+	var de oslc.DistributorError
+	require.ErrorAs(t, err, &de)
+	require.InDelta(t, time.Hour, de.RetryAfter, float64(2*time.Second))
+}
+
 func TestClient_GetPackageVersion_json_decode_error(t *testing.T) {
 	c := setupClient(t, setupHttpClientWithBody(t, "test"))
-	_, err := c.GetPackageVersion("test", "")
+	_, err := c.GetPackageVersion(context.Background(), "test", "")
 	assert.Error(t, err)
 }
 
@@ -212,6 +319,75 @@ func TestClient_GetPackage(t *testing.T) {
 	httpClient, err := ownHTTP.NewClient(ownHTTP.WithHTTPClient(mock))
 	require.NoError(t, err)
 	c := setupClient(t, httpClient)
-	_, err = c.GetPackage("test")
+	_, err = c.GetPackage(context.Background(), "test")
+	require.NoError(t, err)
+}
+
+func TestClient_ListVersions(t *testing.T) {
+	testcases := []struct {
+		name     string
+		status   int
+		body     string
+		expected []string
+		err      error
+	}{
+		{
+			name:     "versions",
+			status:   http.StatusOK,
+			body:     `{"info":{"name":"test"},"releases":{"1.0.0":[],"1.1.0":[],"2.0.0":[]}}`,
+			expected: []string{"1.0.0", "1.1.0", "2.0.0"},
+		},
+		{
+			name:     "no versions",
+			status:   http.StatusOK,
+			body:     `{}`,
+			expected: []string{},
+		},
+		{
+			name:   "not found",
+			status: http.StatusNotFound,
+			err:    oslc.ErrNoSuchPackage,
+		},
+		{
+			name:   "unexpected status",
+			status: http.StatusInternalServerError,
+			err:    oslc.DistributorError{},
+		},
+		{
+			name:   "decode error",
+			status: http.StatusOK,
+			body:   "test",
+			err:    oslc.DistributorError{},
+		},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.name, func(t *testing.T) {
+			c := setupClient(t, setupHttpClientWithStatusAndBody(t, tt.status, tt.body))
+			out, err := c.ListVersions(context.Background(), "test")
+			if tt.err != nil {
+				require.ErrorAs(t, err, &oslc.DistributorError{})
+				if tt.err != (oslc.DistributorError{}) {
+					require.ErrorIs(t, err, tt.err)
+				}
+				return
+			}
+			require.NoError(t, err)
+			require.ElementsMatch(t, tt.expected, out)
+		})
+	}
+}
+
+func TestClient_ListVersions_path(t *testing.T) {
+	mock := ownHTTP.NewTestHTTPClient(func(req *http.Request) (*http.Response, error) {
+		require.Equal(t, "https://pypi.org/pypi/test/json", req.URL.String())
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBuffer([]byte(`{}`))),
+		}, nil
+	})
+	httpClient, err := ownHTTP.NewClient(ownHTTP.WithHTTPClient(mock))
+	require.NoError(t, err)
+	c := setupClient(t, httpClient)
+	_, err = c.ListVersions(context.Background(), "test")
 	require.NoError(t, err)
 }
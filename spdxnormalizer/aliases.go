@@ -0,0 +1,53 @@
+package spdxnormalizer
+
+import "strings"
+
+// builtinAliases maps alias keys of common alternative names of licenses, as found in the metadata of packages, to
+// their SPDX License Identifiers. Names that do not identify a single license, such as "BSD License" or "GPL", are
+// deliberately left out.
+var builtinAliases = map[string]string{
+	"apache 2":                                 "Apache-2.0",
+	"apache 2.0":                               "Apache-2.0",
+	"apache-2":                                 "Apache-2.0",
+	"apache license 2":                         "Apache-2.0",
+	"apache license v2":                        "Apache-2.0",
+	"apache license v2.0":                      "Apache-2.0",
+	"apache license version 2.0":               "Apache-2.0",
+	"apache license, version 2.0":              "Apache-2.0",
+	"apache software license":                  "Apache-2.0",
+	"apache software license 2.0":              "Apache-2.0",
+	"apache software license, version 2.0":     "Apache-2.0",
+	"apache v2":                                "Apache-2.0",
+	"apache2":                                  "Apache-2.0",
+	"asl 2.0":                                  "Apache-2.0",
+	"the apache license, version 2.0":          "Apache-2.0",
+	"the apache software license, version 2.0": "Apache-2.0",
+	"bsd 2-clause":                             "BSD-2-Clause",
+	"bsd 3-clause":                             "BSD-3-Clause",
+	"bsd-3":                                    "BSD-3-Clause",
+	"new bsd":                                  "BSD-3-Clause",
+	"new bsd license":                          "BSD-3-Clause",
+	"simplified bsd":                           "BSD-2-Clause",
+	"simplified bsd license":                   "BSD-2-Clause",
+	"the bsd 3-clause license":                 "BSD-3-Clause",
+	"cc0":                                      "CC0-1.0",
+	"eclipse public license - v 1.0":           "EPL-1.0",
+	"eclipse public license - v 2.0":           "EPL-2.0",
+	"epl 2.0":                                  "EPL-2.0",
+	"gplv2":                                    "GPL-2.0-only",
+	"gplv3":                                    "GPL-3.0-only",
+	"lgplv2.1":                                 "LGPL-2.1-only",
+	"lgplv3":                                   "LGPL-3.0-only",
+	"mit/x11":                                  "MIT",
+	"mpl 2.0":                                  "MPL-2.0",
+	"mpl-2":                                    "MPL-2.0",
+	"python software foundation license":       "PSF-2.0",
+	"the mit license":                          "MIT",
+	"the unlicense":                            "Unlicense",
+}
+
+// aliasKey returns the key an alias is matched by: the alias in lower case, with leading and trailing whitespace
+// removed, and runs of whitespace replaced by a single space.
+func aliasKey(alias string) string {
+	return strings.ToLower(strings.Join(strings.Fields(alias), " "))
+}
@@ -0,0 +1,216 @@
+package spdxnormalizer
+
+import (
+	"errors"
+	"fmt"
+	"github.com/chainalysis-oss/oslc"
+	"regexp"
+	"strings"
+)
+
+// The following constants are the operators of license expressions.
+const (
+	OpAnd  = "AND"
+	OpOr   = "OR"
+	OpWith = "WITH"
+)
+
+// ErrInvalidExpression is returned by [ParseExpression] for strings that are not valid license expressions.
+var ErrInvalidExpression = errors.New("invalid license expression")
+
+// idPattern matches license identifiers. Whether they are in the license list is not checked by [ParseExpression].
+var idPattern = regexp.MustCompile(`^[A-Za-z0-9.-]+$`)
+
+// refPattern matches user-defined license references, which are not part of the license list.
+var refPattern = regexp.MustCompile(`^(DocumentRef-[A-Za-z0-9.-]+:)?LicenseRef-[A-Za-z0-9.-]+$`)
+
+// exceptionPattern matches license exception identifiers. Exceptions are not part of the license list, so only their
+// syntax is checked.
+var exceptionPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9.-]*$`)
+
+// Expression is a parsed SPDX license expression. It is either a single license, an exception to a single license, or
+// two or more operands combined by AND or OR.
+type Expression struct {
+	// Op is the operator of the expression, or empty for a single license.
+	Op string
+	// License is the identifier of a single license, without its trailing plus.
+	License string
+	// OrLater is set if the license has a trailing plus, which means "this version or any later version".
+	OrLater bool
+	// Exception is the identifier of the exception of a WITH expression.
+	Exception string
+	// Operands are the operands of an AND or OR expression, or the single license of a WITH expression.
+	Operands []Expression
+}
+
+// String returns the expression with its operators in upper case, and parentheses only where they are needed.
+func (e Expression) String() string {
+	switch e.Op {
+	case "":
+		if e.OrLater {
+			return e.License + "+"
+		}
+		return e.License
+	case OpWith:
+		return e.Operands[0].String() + " WITH " + e.Exception
+	}
+	parts := make([]string, len(e.Operands))
+	for i, operand := range e.Operands {
+		parts[i] = operand.String()
+		// AND binds more tightly than OR, so OR operands of AND need parentheses.
+		if e.Op == OpAnd && operand.Op == OpOr {
+			parts[i] = "(" + parts[i] + ")"
+		}
+	}
+	return strings.Join(parts, " "+e.Op+" ")
+}
+
+// ParseExpression parses an SPDX license expression, such as "(MIT OR Apache-2.0) AND BSD-3-Clause", following the
+// grammar in Annex D of the SPDX specification. Operators are matched case-insensitively. Only the syntax of license
+// identifiers is checked, not whether they are in the license list. As used by crates.io before it adopted SPDX
+// expressions, a slash is read as OR, so "MIT/Apache-2.0" is parsed as "MIT OR Apache-2.0".
+func ParseExpression(s string) (Expression, error) {
+	p := expressionParser{tokens: tokenizeExpression(s)}
+	expr, ok := p.parseOr()
+	if !ok || p.pos != len(p.tokens) {
+		return Expression{}, fmt.Errorf("%w: %q", ErrInvalidExpression, s)
+	}
+	return expr, nil
+}
+
+// normalizeExpression normalizes an SPDX license expression, and reports whether s is a valid expression whose license
+// identifiers are all in the license list of lr or are license references. License identifiers are written as in the
+// license list, and the expression as by [Expression.String].
+func normalizeExpression(s string, lr oslc.LicenseRetriever) (string, bool) {
+	expr, err := ParseExpression(s)
+	if err != nil {
+		return "", false
+	}
+	expr, ok := resolveLicenses(expr, lr)
+	if !ok {
+		return "", false
+	}
+	return expr.String(), true
+}
+
+// resolveLicenses returns the expression with its license identifiers written as in the license list of lr. It reports
+// false if a license is neither in the list nor a license reference.
+func resolveLicenses(e Expression, lr oslc.LicenseRetriever) (Expression, bool) {
+	if e.Op == "" {
+		if refPattern.MatchString(e.License) {
+			return e, true
+		}
+		e.License = lr.Lookup(e.License).ID
+		return e, e.License != ""
+	}
+	operands := make([]Expression, len(e.Operands))
+	for i, operand := range e.Operands {
+		resolved, ok := resolveLicenses(operand, lr)
+		if !ok {
+			return Expression{}, false
+		}
+		operands[i] = resolved
+	}
+	e.Operands = operands
+	return e, true
+}
+
+func tokenizeExpression(s string) []string {
+	s = strings.NewReplacer("(", " ( ", ")", " ) ", "/", " OR ").Replace(s)
+	return strings.Fields(s)
+}
+
+// expressionParser is a recursive descent parser for license expressions, in which WITH binds more tightly than AND,
+// which binds more tightly than OR.
+type expressionParser struct {
+	tokens []string
+	pos    int
+}
+
+// accept consumes the next token and reports true if it is the operator op, in any case.
+func (p *expressionParser) accept(op string) bool {
+	if p.pos < len(p.tokens) && strings.EqualFold(p.tokens[p.pos], op) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *expressionParser) parseOr() (Expression, bool) {
+	return p.parseBinary(OpOr, p.parseAnd)
+}
+
+func (p *expressionParser) parseAnd() (Expression, bool) {
+	return p.parseBinary(OpAnd, p.parseWith)
+}
+
+// parseBinary parses one or more operands separated by op.
+func (p *expressionParser) parseBinary(op string, operand func() (Expression, bool)) (Expression, bool) {
+	first, ok := operand()
+	if !ok {
+		return Expression{}, false
+	}
+	operands := []Expression{first}
+	for p.accept(op) {
+		next, ok := operand()
+		if !ok {
+			return Expression{}, false
+		}
+		operands = append(operands, next)
+	}
+	if len(operands) == 1 {
+		return first, true
+	}
+	return Expression{Op: op, Operands: operands}, true
+}
+
+// parseWith parses a license with an optional exception. Exceptions only apply to single licenses.
+func (p *expressionParser) parseWith() (Expression, bool) {
+	license, ok := p.parseAtom()
+	if !ok {
+		return Expression{}, false
+	}
+	if !p.accept(OpWith) {
+		return license, true
+	}
+	if license.Op != "" || p.pos >= len(p.tokens) || p.isOperator() || !exceptionPattern.MatchString(p.tokens[p.pos]) {
+		return Expression{}, false
+	}
+	exception := p.tokens[p.pos]
+	p.pos++
+	return Expression{Op: OpWith, Exception: exception, Operands: []Expression{license}}, true
+}
+
+func (p *expressionParser) parseAtom() (Expression, bool) {
+	if p.accept("(") {
+		expr, ok := p.parseOr()
+		if !ok || !p.accept(")") {
+			return Expression{}, false
+		}
+		return expr, true
+	}
+	if p.pos >= len(p.tokens) || p.isOperator() || p.tokens[p.pos] == ")" {
+		return Expression{}, false
+	}
+	token := p.tokens[p.pos]
+	p.pos++
+	if refPattern.MatchString(token) {
+		return Expression{License: token}, true
+	}
+	// A trailing plus means "this version or any later version", which license references have no use for.
+	id, plus := strings.CutSuffix(token, "+")
+	if !idPattern.MatchString(id) || refPattern.MatchString(id) {
+		return Expression{}, false
+	}
+	return Expression{License: id, OrLater: plus}, true
+}
+
+// isOperator reports whether the next token is an operator.
+func (p *expressionParser) isOperator() bool {
+	for _, op := range []string{OpAnd, OpOr, OpWith} {
+		if strings.EqualFold(p.tokens[p.pos], op) {
+			return true
+		}
+	}
+	return false
+}
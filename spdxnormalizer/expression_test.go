@@ -0,0 +1,60 @@
+package spdxnormalizer
+
+import (
+	"errors"
+	"github.com/chainalysis-oss/oslc/sll"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func Test_normalizeExpression(t *testing.T) {
+	tests := []struct {
+		in     string
+		want   string
+		wantOK bool
+	}{
+		{"MIT OR Apache-2.0", "MIT OR Apache-2.0", true},
+		{"(mit or apache-2.0)", "MIT OR Apache-2.0", true},
+		{"MIT/Apache-2.0", "MIT OR Apache-2.0", true},
+		{"(MIT OR Apache-2.0) AND BSD-3-Clause", "(MIT OR Apache-2.0) AND BSD-3-Clause", true},
+		{"MIT AND (Apache-2.0 AND ISC)", "MIT AND Apache-2.0 AND ISC", true},
+		{"MIT OR Apache-2.0 AND ISC", "MIT OR Apache-2.0 AND ISC", true},
+		{"GPL-2.0-only WITH Classpath-exception-2.0", "GPL-2.0-only WITH Classpath-exception-2.0", true},
+		{"gpl-2.0+ OR LicenseRef-Proprietary", "GPL-2.0+ OR LicenseRef-Proprietary", true},
+		{"(MIT)", "MIT", true},
+		{"MIT OR", "", false},
+		{"MIT Apache-2.0", "", false},
+		{"(MIT OR Apache-2.0", "", false},
+		{"MIT OR Apache-2.0)", "", false},
+		{"MIT OR Apache 2", "", false},
+		{"BSD License", "", false},
+		{"(MIT OR ISC) WITH Classpath-exception-2.0", "", false},
+		{"MIT WITH", "", false},
+		{"MIT WITH OR", "", false},
+		{"MIT WITH Bad_Exception", "", false},
+		{"LicenseRef-Proprietary+", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, ok := normalizeExpression(tt.in, sll.AsLicenseRetriever())
+			require.Equal(t, tt.wantOK, ok)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseExpression(t *testing.T) {
+	got, err := ParseExpression("(mit+ or Unknown-1.0) and GPL-2.0-only with Classpath-exception-2.0")
+	require.NoError(t, err)
+	require.Equal(t, Expression{Op: OpAnd, Operands: []Expression{
+		{Op: OpOr, Operands: []Expression{{License: "mit", OrLater: true}, {License: "Unknown-1.0"}}},
+		{Op: OpWith, Exception: "Classpath-exception-2.0", Operands: []Expression{{License: "GPL-2.0-only"}}},
+	}}, got)
+	require.Equal(t, "(mit+ OR Unknown-1.0) AND GPL-2.0-only WITH Classpath-exception-2.0", got.String())
+
+	for _, s := range []string{"", "MIT OR", "BSD License", "MIT;Apache-2.0", "(MIT OR ISC) WITH Classpath-exception-2.0"} {
+		_, err := ParseExpression(s)
+		require.True(t, errors.Is(err, ErrInvalidExpression), s)
+	}
+}
@@ -3,12 +3,15 @@ package spdxnormalizer
 import (
 	"context"
 	"github.com/chainalysis-oss/oslc"
+	"log/slog"
+	"strings"
+	"sync"
 )
 
-// Compile time check to ensure Normalizer implements [oslc.LicenseIDNormalizer].
-var _ oslc.LicenseIDNormalizer = (*Normalizer)(nil)
+// Compile time check to ensure Normalizer implements [oslc.LicenseNormalizer].
+var _ oslc.LicenseNormalizer = (*Normalizer)(nil)
 
-// Normalizer implements the [oslc.LicenseIDNormalizer] interface.
+// Normalizer implements the [oslc.LicenseIDNormalizer] and [oslc.LicenseNormalizer] interfaces.
 //
 // The normalizer normalizes SPDX license identifiers to the corresponding license object, and as such it is
 // recommended that the [WithLicenseRetriever] option is used and is provided with a license retriever that implements
@@ -18,6 +21,11 @@ var _ oslc.LicenseIDNormalizer = (*Normalizer)(nil)
 // expects a LicenseRetriever that adheres to the SPDX specification and contains a list of SPDX licenses.
 type Normalizer struct {
 	options *normalizerOptions
+
+	// names maps the lower-cased full names of the licenses in the license list to their identifiers. It is built on
+	// first use, since listing every license is only needed for licenses that are not identifiers.
+	names     map[string]string
+	namesOnce sync.Once
 }
 
 // NewNormalizer creates a new Normalizer instance with the provided options.
@@ -56,3 +64,62 @@ func (n *Normalizer) NormalizeID(ctx context.Context, id string) string {
 	n.options.Logger.DebugContext(ctx, "normalized license id", "id", id, "normalized", norm, "success", norm != "")
 	return norm
 }
+
+// Normalize normalizes the provided license, as declared by a distributor, and reports how it was normalized. In order,
+// the license is matched against:
+//
+//   - the license identifiers of the license list, using [Normalizer.NormalizeID], resulting in
+//     [oslc.LicenseNormalizationExact].
+//   - the aliases provided with [WithAliases], the built-in aliases of common licenses, such as "Apache 2", and the full
+//     names of the licenses in the license list, such as "MIT License", resulting in [oslc.LicenseNormalizationAlias].
+//     Aliases are matched case-insensitively, ignoring repeated whitespace.
+//   - SPDX license expressions, such as "(MIT OR Apache-2.0)", resulting in [oslc.LicenseNormalizationExpression]. See
+//     [normalizeExpression] for details.
+//
+// If none match, the status is [oslc.LicenseNormalizationFailed] and the license is empty. Ambiguous names, such as
+// "BSD License", are never matched.
+func (n *Normalizer) Normalize(ctx context.Context, license string) oslc.LicenseNormalization {
+	result := oslc.LicenseNormalization{
+		Status:             oslc.LicenseNormalizationFailed,
+		LicenseListVersion: n.options.LicenseRetriever.Version(),
+	}
+	trimmed := strings.TrimSpace(license)
+	if trimmed == "" {
+		return result
+	}
+
+	if id := n.options.LicenseRetriever.Lookup(trimmed).ID; id != "" {
+		result.License, result.Status = id, oslc.LicenseNormalizationExact
+	} else if id := n.lookupAlias(trimmed); id != "" {
+		result.License, result.Status = id, oslc.LicenseNormalizationAlias
+	} else if expr, ok := normalizeExpression(trimmed, n.options.LicenseRetriever); ok {
+		result.License, result.Status = expr, oslc.LicenseNormalizationExpression
+	}
+
+	n.options.Logger.DebugContext(ctx, "normalized license", slog.String("license", license),
+		slog.String("normalized", result.License), slog.String("status", string(result.Status)))
+	return result
+}
+
+// lookupAlias returns the identifier of the license with the provided alias, or an empty string if there is none.
+func (n *Normalizer) lookupAlias(alias string) string {
+	key := aliasKey(alias)
+	if id, ok := n.options.Aliases[key]; ok {
+		return n.options.LicenseRetriever.Lookup(id).ID
+	}
+	if id, ok := builtinAliases[key]; ok {
+		if id = n.options.LicenseRetriever.Lookup(id).ID; id != "" {
+			return id
+		}
+	}
+	n.namesOnce.Do(func() {
+		n.names = make(map[string]string)
+		for _, id := range n.options.LicenseRetriever.Licenses() {
+			lic := n.options.LicenseRetriever.Lookup(id)
+			if lic.Name != "" {
+				n.names[aliasKey(lic.Name)] = lic.ID
+			}
+		}
+	})
+	return n.names[key]
+}
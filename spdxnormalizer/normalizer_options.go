@@ -8,6 +8,8 @@ import (
 type normalizerOptions struct {
 	Logger           *slog.Logger
 	LicenseRetriever oslc.LicenseRetriever
+	// Aliases maps alias keys, as returned by aliasKey, to license identifiers.
+	Aliases map[string]string
 }
 
 var defaultNormalizerOptions = normalizerOptions{
@@ -52,3 +54,20 @@ func WithLogger(logger *slog.Logger) NormalizerOption {
 		opts.Logger = logger
 	})
 }
+
+// WithAliases returns a NormalizerOption that adds aliases to those the normalizer recognizes, mapping alternative
+// names of licenses to their identifiers, such as "BSD License" to "BSD-3-Clause". Aliases are matched
+// case-insensitively, and take precedence over the built-in aliases. Aliases of identifiers that are not in the license
+// list are ignored.
+func WithAliases(aliases map[string]string) NormalizerOption {
+	return newFuncNormalizerOption(func(opts *normalizerOptions) {
+		merged := make(map[string]string, len(opts.Aliases)+len(aliases))
+		for k, v := range opts.Aliases {
+			merged[k] = v
+		}
+		for k, v := range aliases {
+			merged[aliasKey(k)] = v
+		}
+		opts.Aliases = merged
+	})
+}
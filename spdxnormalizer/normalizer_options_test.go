@@ -55,3 +55,10 @@ func TestWithLicenseRetriever(t *testing.T) {
 	WithLicenseRetriever(mock).apply(&opts)
 	require.Equal(t, mock, opts.LicenseRetriever)
 }
+
+func TestWithAliases(t *testing.T) {
+	opts := normalizerOptions{}
+	WithAliases(map[string]string{"BSD  License": "BSD-3-Clause"}).apply(&opts)
+	WithAliases(map[string]string{"Apache 2": "Apache-1.1"}).apply(&opts)
+	require.Equal(t, map[string]string{"bsd license": "BSD-3-Clause", "apache 2": "Apache-1.1"}, opts.Aliases)
+}
@@ -1,8 +1,10 @@
 package spdxnormalizer
 
 import (
+	"context"
 	"github.com/chainalysis-oss/oslc"
 	oslcmocks "github.com/chainalysis-oss/oslc/mocks/oslc"
+	"github.com/chainalysis-oss/oslc/sll"
 	"github.com/stretchr/testify/require"
 	"io"
 	"log/slog"
@@ -23,3 +25,65 @@ func TestNormalizer_NormalizeID(t *testing.T) {
 
 	require.Equal(t, "MIT", out)
 }
+
+func TestNormalizer_Normalize(t *testing.T) {
+	tests := []struct {
+		in         string
+		want       string
+		wantStatus oslc.LicenseNormalizationStatus
+	}{
+		{"MIT", "MIT", oslc.LicenseNormalizationExact},
+		{" apache-2.0 ", "Apache-2.0", oslc.LicenseNormalizationExact},
+		{"Apache 2", "Apache-2.0", oslc.LicenseNormalizationAlias},
+		{"The Apache Software License,  Version 2.0", "Apache-2.0", oslc.LicenseNormalizationAlias},
+		{"MIT License", "MIT", oslc.LicenseNormalizationAlias},
+		{"mozilla public license 2.0", "MPL-2.0", oslc.LicenseNormalizationAlias},
+		{"MIT/Apache-2.0", "MIT OR Apache-2.0", oslc.LicenseNormalizationExpression},
+		{"(MIT OR Apache-2.0)", "MIT OR Apache-2.0", oslc.LicenseNormalizationExpression},
+		{"BSD License", "", oslc.LicenseNormalizationFailed},
+		{"Unknown", "", oslc.LicenseNormalizationFailed},
+		{"", "", oslc.LicenseNormalizationFailed},
+	}
+	nm, err := NewNormalizer(
+		WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		WithLicenseRetriever(sll.AsLicenseRetriever()),
+	)
+	require.NoError(t, err)
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got := nm.Normalize(context.Background(), tt.in)
+			require.Equal(t, oslc.LicenseNormalization{
+				License:            tt.want,
+				Status:             tt.wantStatus,
+				LicenseListVersion: sll.Version(),
+			}, got)
+		})
+	}
+}
+
+func TestNormalizer_Normalize_aliases(t *testing.T) {
+	nm, err := NewNormalizer(
+		WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		WithLicenseRetriever(sll.AsLicenseRetriever()),
+		WithAliases(map[string]string{"BSD License": "BSD-3-Clause", "Apache 2": "Apache-1.1", "Custom": "not-a-license"}),
+	)
+	require.NoError(t, err)
+
+	got := nm.Normalize(context.Background(), "bsd license")
+	require.Equal(t, "BSD-3-Clause", got.License)
+	require.Equal(t, oslc.LicenseNormalizationAlias, got.Status)
+
+	// Configured aliases take precedence over the built-in aliases.
+	require.Equal(t, "Apache-1.1", nm.Normalize(context.Background(), "Apache 2").License)
+
+	// Aliases of licenses that are not in the license list are ignored.
+	require.Equal(t, oslc.LicenseNormalizationFailed, nm.Normalize(context.Background(), "Custom").Status)
+}
+
+func Test_builtinAliases(t *testing.T) {
+	lr := sll.AsLicenseRetriever()
+	for alias, id := range builtinAliases {
+		require.Equal(t, aliasKey(alias), alias, "alias %q is not a key", alias)
+		require.Equal(t, id, lr.Lookup(id).ID, "alias %q refers to an unknown license", alias)
+	}
+}
@@ -0,0 +1,90 @@
+package sqlite
+
+import (
+	"context"
+	"github.com/chainalysis-oss/oslc"
+	"go.opentelemetry.io/otel/attribute"
+	"time"
+)
+
+// Compile time check to ensure Datastore implements [oslc.CurationStore].
+var _ oslc.CurationStore = (*Datastore)(nil)
+
+// overrideAttributes returns the span attributes identifying the license override with the provided tenant,
+// distributor, name and version range.
+func overrideAttributes(tenant, distributor, name, versionRange string) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("oslc.tenant", tenant),
+		attribute.String("oslc.distributor", distributor),
+		attribute.String("oslc.package.name", name),
+		attribute.String("oslc.override.version_range", versionRange),
+	}
+}
+
+var datastoreSetOverrideStatement = "INSERT INTO license_overrides (tenant, distributor, name, version_range, license, justification, author, updated_at) VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8) ON CONFLICT (tenant, distributor, name, version_range) DO UPDATE SET license = excluded.license, justification = excluded.justification, author = excluded.author, updated_at = excluded.updated_at"
+
+func (d *Datastore) SetOverride(ctx context.Context, override oslc.LicenseOverride) (_ oslc.LicenseOverride, err error) {
+	ctx, span := d.startSpan(ctx, "INSERT", datastoreSetOverrideStatement, overrideAttributes(override.Tenant, override.Distributor, override.Name, override.VersionRange)...)
+	defer func() { endSpan(span, err) }()
+
+	// The time is truncated to the precision of the database, so the returned override equals the stored one.
+	updatedAt := time.Now().UTC().Truncate(time.Microsecond)
+	_, err = d.options.DB.ExecContext(ctx, datastoreSetOverrideStatement, override.Tenant, override.Distributor, override.Name, override.VersionRange, override.License, override.Justification, override.Author, toMicros(updatedAt))
+	if err != nil {
+		return oslc.LicenseOverride{}, err
+	}
+	override.UpdatedAt = updatedAt
+	return override, nil
+}
+
+var datastoreListOverridesStatement = "SELECT tenant, distributor, name, version_range, license, justification, author, updated_at FROM license_overrides WHERE tenant = ?1 AND (?2 = '' OR distributor = ?2) AND (?3 = '' OR name = ?3) ORDER BY updated_at DESC"
+
+func (d *Datastore) ListOverrides(ctx context.Context, tenant, distributor, name string) (_ []oslc.LicenseOverride, err error) {
+	ctx, span := d.startSpan(ctx, "SELECT", datastoreListOverridesStatement,
+		attribute.String("oslc.tenant", tenant),
+		attribute.String("oslc.distributor", distributor),
+		attribute.String("oslc.package.name", name),
+	)
+	defer func() { endSpan(span, err) }()
+
+	rows, err := d.options.DB.QueryContext(ctx, datastoreListOverridesStatement, tenant, distributor, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	overrides := make([]oslc.LicenseOverride, 0)
+	for rows.Next() {
+		var o oslc.LicenseOverride
+		var updatedAt int64
+		if err = rows.Scan(&o.Tenant, &o.Distributor, &o.Name, &o.VersionRange, &o.License, &o.Justification, &o.Author, &updatedAt); err != nil {
+			return nil, err
+		}
+		o.UpdatedAt = fromMicros(updatedAt)
+		overrides = append(overrides, o)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return overrides, nil
+}
+
+var datastoreDeleteOverrideStatement = "DELETE FROM license_overrides WHERE tenant = ?1 AND distributor = ?2 AND name = ?3 AND version_range = ?4"
+
+func (d *Datastore) DeleteOverride(ctx context.Context, tenant, distributor, name, versionRange string) (err error) {
+	ctx, span := d.startSpan(ctx, "DELETE", datastoreDeleteOverrideStatement, overrideAttributes(tenant, distributor, name, versionRange)...)
+	defer func() { endSpan(span, err) }()
+
+	result, err := d.options.DB.ExecContext(ctx, datastoreDeleteOverrideStatement, tenant, distributor, name, versionRange)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return oslc.ErrDatastoreObjectNotFound
+	}
+	return nil
+}
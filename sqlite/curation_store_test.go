@@ -0,0 +1,63 @@
+package sqlite
+
+import (
+	"context"
+	"github.com/chainalysis-oss/oslc"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestDatastore_SetOverride(t *testing.T) {
+	ds := newTestDatastore(t)
+	ctx := context.Background()
+	override := oslc.LicenseOverride{Distributor: oslc.DistributorPypi, Name: "requests", VersionRange: ">=2", License: "Apache-2.0", Justification: "LICENSE file", Author: "alice"}
+	saved, err := ds.SetOverride(ctx, override)
+	require.NoError(t, err)
+
+	overrides, err := ds.ListOverrides(ctx, "", oslc.DistributorPypi, "")
+	require.NoError(t, err)
+	require.Equal(t, []oslc.LicenseOverride{saved}, overrides)
+
+	overrides, err = ds.ListOverrides(ctx, "", oslc.DistributorNpm, "")
+	require.NoError(t, err)
+	require.Empty(t, overrides)
+}
+
+func TestDatastore_overrides_tenants(t *testing.T) {
+	ds := newTestDatastore(t)
+	ctx := context.Background()
+	shared, err := ds.SetOverride(ctx, oslc.LicenseOverride{Distributor: oslc.DistributorPypi, Name: "requests", VersionRange: "*", License: "MIT"})
+	require.NoError(t, err)
+	tenant, err := ds.SetOverride(ctx, oslc.LicenseOverride{Tenant: "payments", Distributor: oslc.DistributorPypi, Name: "requests", VersionRange: "*", License: "Apache-2.0"})
+	require.NoError(t, err)
+
+	overrides, err := ds.ListOverrides(ctx, "", "", "")
+	require.NoError(t, err)
+	require.Equal(t, []oslc.LicenseOverride{shared}, overrides)
+	overrides, err = ds.ListOverrides(ctx, "payments", "", "")
+	require.NoError(t, err)
+	require.Equal(t, []oslc.LicenseOverride{tenant}, overrides)
+
+	require.NoError(t, ds.DeleteOverride(ctx, "payments", oslc.DistributorPypi, "requests", "*"))
+	overrides, err = ds.ListOverrides(ctx, "", "", "")
+	require.NoError(t, err)
+	require.Equal(t, []oslc.LicenseOverride{shared}, overrides)
+}
+
+func TestDatastore_DeleteOverride_ErrNotFound(t *testing.T) {
+	ds := newTestDatastore(t)
+	err := ds.DeleteOverride(context.Background(), "", oslc.DistributorPypi, "requests", ">=2")
+	require.ErrorIs(t, err, oslc.ErrDatastoreObjectNotFound)
+}
+
+func TestDatastore_overrides_closedDB(t *testing.T) {
+	ds := newClosedDatastore(t)
+	ctx := context.Background()
+	_, err := ds.SetOverride(ctx, oslc.LicenseOverride{})
+	require.Error(t, err)
+	_, err = ds.ListOverrides(ctx, "", "", "")
+	require.Error(t, err)
+	err = ds.DeleteOverride(ctx, "", "", "", "")
+	require.Error(t, err)
+	require.NotErrorIs(t, err, oslc.ErrDatastoreObjectNotFound)
+}
@@ -0,0 +1,429 @@
+// Package sqlite implements the OSLC datastore on SQLite, for deployments where running PostgreSQL is not worth it,
+// such as a single server, a laptop or a CI runner. The schema and semantics match those of the postgres package.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/chainalysis-oss/oslc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"log/slog"
+	"strings"
+	"time"
+
+	// The driver is written in Go, so OSLC can still be built without cgo.
+	_ "modernc.org/sqlite"
+)
+
+// tracerName is the name of the tracer used to create spans for datastore operations.
+const tracerName = "github.com/chainalysis-oss/oslc/sqlite"
+
+// Open opens the SQLite database at path, creating it if it does not exist. The database uses write-ahead logging, so
+// reads are not blocked by writes, and every transaction takes the write lock when it begins, so concurrent
+// transactions wait for each other instead of failing when they start writing.
+func Open(ctx context.Context, path string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite", "file:"+path+"?_pragma=foreign_keys(1)&_pragma=busy_timeout(10000)&_pragma=journal_mode(WAL)&_pragma=case_sensitive_like(1)&_txlock=immediate")
+	if err != nil {
+		return nil, err
+	}
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	return db, nil
+}
+
+type Datastore struct {
+	options *datastoreOptions
+}
+
+func NewDatastore(options ...DatastoreOption) (*Datastore, error) {
+	opts := defaultDatastoreOptions
+	for _, opt := range globalDatastoreOptions {
+		opt.apply(&opts)
+	}
+	for _, opt := range options {
+		opt.apply(&opts)
+	}
+
+	if opts.DB == nil {
+		return nil, ErrMissingOptionDB
+	}
+
+	return &Datastore{
+		options: &opts,
+	}, nil
+}
+
+type datastoreOptions struct {
+	Logger         *slog.Logger
+	DB             *sql.DB
+	TracerProvider trace.TracerProvider
+}
+
+var defaultDatastoreOptions = datastoreOptions{
+	Logger:         slog.Default(),
+	TracerProvider: otel.GetTracerProvider(),
+}
+
+var globalDatastoreOptions []DatastoreOption
+
+type DatastoreOption interface {
+	apply(*datastoreOptions)
+}
+
+type funcDatastoreOption struct {
+	f func(*datastoreOptions)
+}
+
+func (fdo *funcDatastoreOption) apply(opts *datastoreOptions) {
+	fdo.f(opts)
+}
+
+func newFuncDatastoreOption(f func(*datastoreOptions)) *funcDatastoreOption {
+	return &funcDatastoreOption{
+		f: f,
+	}
+}
+
+func WithLogger(logger *slog.Logger) DatastoreOption {
+	return newFuncDatastoreOption(func(opts *datastoreOptions) {
+		opts.Logger = logger
+	})
+}
+
+// WithDB sets the database of the datastore. Databases should be opened with [Open], which configures them as the
+// datastore expects.
+func WithDB(db *sql.DB) DatastoreOption {
+	return newFuncDatastoreOption(func(opts *datastoreOptions) {
+		opts.DB = db
+	})
+}
+
+func WithTracerProvider(tp trace.TracerProvider) DatastoreOption {
+	return newFuncDatastoreOption(func(opts *datastoreOptions) {
+		opts.TracerProvider = tp
+	})
+}
+
+// Compile time check to ensure Datastore implements [oslc.Datastore].
+var _ oslc.Datastore = (*Datastore)(nil)
+
+// startSpan starts a client span for a datastore operation. The provided attributes are added to the span in addition
+// to the database attributes.
+func (d *Datastore) startSpan(ctx context.Context, operation, statement string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return d.options.TracerProvider.Tracer(tracerName).Start(ctx, "sqlite "+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemSqlite,
+			semconv.DBOperationName(operation),
+			semconv.DBQueryText(statement),
+		),
+		trace.WithAttributes(attrs...),
+	)
+}
+
+// packageAttributes returns the span attributes identifying the package with the provided name and version.
+func packageAttributes(name, version string) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("oslc.package.name", name),
+		attribute.String("oslc.package.version", version),
+	}
+}
+
+// endSpan records err on span, if any, and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// toMicros converts t to the representation of timestamps in the database, which is microseconds since the Unix epoch.
+func toMicros(t time.Time) int64 {
+	return t.UnixMicro()
+}
+
+// fromMicros converts a timestamp in the database to a time.
+func fromMicros(us int64) time.Time {
+	return time.UnixMicro(us).UTC()
+}
+
+// nullMicros returns nil for the zero time, so it is passed to the database as NULL.
+func nullMicros(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return toMicros(t)
+}
+
+var (
+	datastoreSavePackageStatement              = "INSERT INTO packages (distributor, name) VALUES (?1, ?2) ON CONFLICT DO NOTHING"
+	datastoreSaveVersionStatement              = "INSERT INTO package_versions (package_id, version, license, raw_license, normalization_status, license_list_version, fetched_at) SELECT id, ?3, ?4, ?5, ?6, ?7, ?8 FROM packages WHERE distributor = ?1 AND name = ?2 ON CONFLICT (package_id, version) DO UPDATE SET license = excluded.license, raw_license = excluded.raw_license, normalization_status = excluded.normalization_status, license_list_version = excluded.license_list_version, fetched_at = excluded.fetched_at RETURNING id"
+	datastoreDeleteDistributionPointsStatement = "DELETE FROM distribution_points WHERE version_id = ?1"
+	datastoreSaveDistributionPointStatement    = "INSERT INTO distribution_points (version_id, position, name, url, distributor, kind, checksum) VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7)"
+)
+
+// Save stores the entry under the distributor of each of its distribution points, so it can be retrieved with any of
+// them. Every stored copy holds all distribution points of the entry. Entries without distribution points are not
+// stored, since they have no distributor.
+func (d *Datastore) Save(ctx context.Context, entry oslc.Entry) (err error) {
+	ctx, span := d.startSpan(ctx, "INSERT", datastoreSaveVersionStatement, packageAttributes(entry.Name, entry.Version)...)
+	defer func() { endSpan(span, err) }()
+
+	tx, err := d.options.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	fetchedAt := toMicros(time.Now())
+	saved := make(map[string]bool)
+	for _, dp := range entry.DistributionPoints {
+		if saved[dp.Distributor] {
+			continue
+		}
+		saved[dp.Distributor] = true
+
+		_, err = tx.ExecContext(ctx, datastoreSavePackageStatement, dp.Distributor, entry.Name)
+		if err != nil {
+			return err
+		}
+		var versionID int64
+		err = tx.QueryRowContext(ctx, datastoreSaveVersionStatement, dp.Distributor, entry.Name, entry.Version, entry.License,
+			entry.RawLicense, string(entry.NormalizationStatus), entry.LicenseListVersion, fetchedAt).Scan(&versionID)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, datastoreDeleteDistributionPointsStatement, versionID)
+		if err != nil {
+			return err
+		}
+		for i, point := range entry.DistributionPoints {
+			_, err = tx.ExecContext(ctx, datastoreSaveDistributionPointStatement, versionID, i, point.Name, point.URL, point.Distributor, point.Kind, point.Checksum)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return tx.Commit()
+}
+
+var datastoreRetrieveStatement = "SELECT v.license, v.raw_license, v.normalization_status, v.license_list_version, d.name, d.url, d.distributor, d.kind, d.checksum FROM packages p JOIN package_versions v ON v.package_id = p.id JOIN distribution_points d ON d.version_id = v.id WHERE p.name = ?1 AND v.version = ?2 AND p.distributor = ?3 ORDER BY d.position"
+
+func (d *Datastore) Retrieve(ctx context.Context, name, version, distributor string) (_ oslc.Entry, err error) {
+	ctx, span := d.startSpan(ctx, "SELECT", datastoreRetrieveStatement,
+		append(packageAttributes(name, version), attribute.String("oslc.distributor", distributor))...)
+	defer func() {
+		// Not finding an entry is expected for every cache miss, so it is not recorded as an error on the span.
+		if errors.Is(err, oslc.ErrDatastoreObjectNotFound) {
+			endSpan(span, nil)
+			return
+		}
+		endSpan(span, err)
+	}()
+
+	rows, err := d.options.DB.QueryContext(ctx, datastoreRetrieveStatement, name, version, distributor)
+	if err != nil {
+		return oslc.Entry{}, err
+	}
+	defer rows.Close()
+
+	entry := oslc.Entry{Name: name, Version: version}
+	var status string
+	for rows.Next() {
+		var dp oslc.DistributionPoint
+		err = rows.Scan(&entry.License, &entry.RawLicense, &status, &entry.LicenseListVersion, &dp.Name, &dp.URL, &dp.Distributor, &dp.Kind, &dp.Checksum)
+		if err != nil {
+			return oslc.Entry{}, err
+		}
+		entry.DistributionPoints = append(entry.DistributionPoints, dp)
+	}
+	if err = rows.Err(); err != nil {
+		return oslc.Entry{}, err
+	}
+	if len(entry.DistributionPoints) == 0 {
+		return oslc.Entry{}, oslc.ErrDatastoreObjectNotFound
+	}
+	entry.NormalizationStatus = oslc.LicenseNormalizationStatus(status)
+	return entry, nil
+}
+
+var datastoreRetrieveVersionsStatement = "SELECT v.version, v.license, v.raw_license, v.normalization_status, v.license_list_version, d.name, d.url, d.distributor, d.kind, d.checksum FROM packages p JOIN package_versions v ON v.package_id = p.id JOIN distribution_points d ON d.version_id = v.id WHERE p.name = ?1 AND p.distributor = ?2 ORDER BY v.id, d.position"
+
+func (d *Datastore) RetrieveVersions(ctx context.Context, name, distributor string) (_ []oslc.Entry, err error) {
+	ctx, span := d.startSpan(ctx, "SELECT", datastoreRetrieveVersionsStatement,
+		attribute.String("oslc.package.name", name),
+		attribute.String("oslc.distributor", distributor),
+	)
+	defer func() { endSpan(span, err) }()
+
+	rows, err := d.options.DB.QueryContext(ctx, datastoreRetrieveVersionsStatement, name, distributor)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make([]oslc.Entry, 0)
+	for rows.Next() {
+		e := oslc.Entry{Name: name}
+		var status string
+		var dp oslc.DistributionPoint
+		err = rows.Scan(&e.Version, &e.License, &e.RawLicense, &status, &e.LicenseListVersion, &dp.Name, &dp.URL, &dp.Distributor, &dp.Kind, &dp.Checksum)
+		if err != nil {
+			return nil, err
+		}
+		// The rows of a version are adjacent, so a distribution point belongs to the last entry if the versions match.
+		if n := len(entries); n > 0 && entries[n-1].Version == e.Version {
+			entries[n-1].DistributionPoints = append(entries[n-1].DistributionPoints, dp)
+			continue
+		}
+		e.NormalizationStatus = oslc.LicenseNormalizationStatus(status)
+		e.DistributionPoints = []oslc.DistributionPoint{dp}
+		entries = append(entries, e)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+var datastoreInvalidateStatement = `DELETE FROM package_versions WHERE id IN (SELECT v.id FROM packages p JOIN package_versions v ON v.package_id = p.id WHERE (?1 = '' OR p.distributor = ?1) AND (?2 = '' OR p.name LIKE ?2 ESCAPE '\') AND (?3 = '' OR v.version = ?3) AND (NOT ?4 OR v.license = ?5))`
+
+// escapeLike escapes the special characters of the SQL LIKE operator in s.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// namePatternToLike converts a name pattern, as used by [oslc.EntryFilter], to a pattern for the SQL LIKE operator.
+func namePatternToLike(pattern string) string {
+	return strings.ReplaceAll(escapeLike(pattern), "*", "%")
+}
+
+func (d *Datastore) Invalidate(ctx context.Context, filter oslc.EntryFilter) (_ int64, err error) {
+	var license string
+	if filter.License != nil {
+		license = *filter.License
+	}
+	ctx, span := d.startSpan(ctx, "DELETE", datastoreInvalidateStatement,
+		attribute.String("oslc.distributor", filter.Distributor),
+		attribute.String("oslc.package.name", filter.NamePattern),
+		attribute.String("oslc.package.version", filter.Version),
+	)
+	defer func() { endSpan(span, err) }()
+
+	result, err := d.options.DB.ExecContext(ctx, datastoreInvalidateStatement, filter.Distributor, namePatternToLike(filter.NamePattern), filter.Version, filter.License != nil, license)
+	if err != nil {
+		return 0, err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	span.SetAttributes(attribute.Int64("db.response.affected_rows", n))
+	return n, nil
+}
+
+// datastoreSearchStatement selects the matching versions first, so the limit applies to versions rather than to
+// distribution points. SQLite has no regular expressions, so a license is found in an expression by replacing the
+// parentheses of the expression with spaces, and looking for the license surrounded by spaces.
+var datastoreSearchStatement = `WITH v AS (SELECT v.id, p.name, v.version, p.distributor, v.license, v.raw_license, v.normalization_status, v.license_list_version, v.fetched_at FROM packages p JOIN package_versions v ON v.package_id = p.id WHERE (?1 = '' OR p.distributor = ?1) AND (?2 = '' OR p.name LIKE ?2 ESCAPE '\') AND (?3 = '' OR p.name LIKE ?3 ESCAPE '\') AND (NOT ?4 OR v.license = ?5 OR (?6 AND ' ' || replace(replace(v.license, '(', ' '), ')', ' ') || ' ' LIKE ?7 ESCAPE '\')) AND (?8 IS NULL OR v.fetched_at >= ?8) AND (?9 IS NULL OR v.fetched_at < ?9) AND (NOT ?10 OR (p.distributor, p.name, v.version) > (?11, ?12, ?13)) ORDER BY p.distributor, p.name, v.version LIMIT ?14) ` +
+	`SELECT v.name, v.version, v.distributor, v.license, v.raw_license, v.normalization_status, v.license_list_version, v.fetched_at, d.name, d.url, d.distributor, d.kind, d.checksum FROM v JOIN distribution_points d ON d.version_id = v.id ORDER BY v.distributor, v.name, v.version, d.position`
+
+func (d *Datastore) Search(ctx context.Context, query oslc.SearchQuery) (_ []oslc.StoredEntry, err error) {
+	ctx, span := d.startSpan(ctx, "SELECT", datastoreSearchStatement, attribute.String("oslc.distributor", query.Distributor))
+	defer func() { endSpan(span, err) }()
+
+	var prefix, contains, license, expression string
+	if query.NamePrefix != "" {
+		prefix = escapeLike(query.NamePrefix) + "%"
+	}
+	if query.NameContains != "" {
+		contains = "%" + escapeLike(query.NameContains) + "%"
+	}
+	if query.License != nil {
+		license = *query.License
+		expression = "% " + escapeLike(license) + " %"
+	}
+	// An empty license is never part of an expression.
+	inExpression := query.License != nil && query.LicenseInExpression && license != ""
+	var after oslc.SearchCursor
+	if query.After != nil {
+		after = *query.After
+	}
+
+	rows, err := d.options.DB.QueryContext(ctx, datastoreSearchStatement,
+		query.Distributor, prefix, contains,
+		query.License != nil, license, inExpression, expression,
+		nullMicros(query.FetchedAfter), nullMicros(query.FetchedBefore),
+		query.After != nil, after.Distributor, after.Name, after.Version,
+		query.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make([]oslc.StoredEntry, 0)
+	for rows.Next() {
+		var e oslc.StoredEntry
+		var status string
+		var fetchedAt int64
+		var dp oslc.DistributionPoint
+		err = rows.Scan(&e.Name, &e.Version, &e.Distributor, &e.License, &e.RawLicense, &status, &e.LicenseListVersion, &fetchedAt, &dp.Name, &dp.URL, &dp.Distributor, &dp.Kind, &dp.Checksum)
+		if err != nil {
+			return nil, err
+		}
+		if n := len(entries); n > 0 && entries[n-1].Distributor == e.Distributor && entries[n-1].Name == e.Name && entries[n-1].Version == e.Version {
+			entries[n-1].DistributionPoints = append(entries[n-1].DistributionPoints, dp)
+			continue
+		}
+		e.NormalizationStatus = oslc.LicenseNormalizationStatus(status)
+		e.FetchedAt = fromMicros(fetchedAt)
+		e.DistributionPoints = []oslc.DistributionPoint{dp}
+		entries = append(entries, e)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int("db.response.returned_rows", len(entries)))
+	return entries, nil
+}
+
+var datastoreCountPackagesStatement = "SELECT p.distributor, v.license, v.normalization_status, count(*) FROM packages p JOIN package_versions v ON v.package_id = p.id GROUP BY p.distributor, v.license, v.normalization_status"
+
+func (d *Datastore) CountPackages(ctx context.Context) (_ []oslc.PackageCount, err error) {
+	ctx, span := d.startSpan(ctx, "SELECT", datastoreCountPackagesStatement)
+	defer func() { endSpan(span, err) }()
+
+	rows, err := d.options.DB.QueryContext(ctx, datastoreCountPackagesStatement)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make([]oslc.PackageCount, 0)
+	for rows.Next() {
+		var c oslc.PackageCount
+		var status string
+		if err = rows.Scan(&c.Distributor, &c.License, &status, &c.Count); err != nil {
+			return nil, err
+		}
+		c.NormalizationStatus = oslc.LicenseNormalizationStatus(status)
+		counts = append(counts, c)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+var ErrMissingOptionDB = errors.New("missing option: db")
@@ -0,0 +1,202 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"github.com/chainalysis-oss/oslc"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := Open(context.Background(), filepath.Join(t.TempDir(), "oslc.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// newTestDatastore returns a datastore backed by a new, migrated database.
+func newTestDatastore(t *testing.T, options ...DatastoreOption) *Datastore {
+	t.Helper()
+	ds, err := NewDatastore(append([]DatastoreOption{WithDB(openTestDB(t))}, options...)...)
+	require.NoError(t, err)
+	_, err = ds.MigrateUp(context.Background())
+	require.NoError(t, err)
+	return ds
+}
+
+// newClosedDatastore returns a datastore whose database is closed, so every operation fails.
+func newClosedDatastore(t *testing.T) *Datastore {
+	t.Helper()
+	db := openTestDB(t)
+	require.NoError(t, db.Close())
+	ds, err := NewDatastore(WithDB(db))
+	require.NoError(t, err)
+	return ds
+}
+
+func TestOpen(t *testing.T) {
+	db := openTestDB(t)
+	var foreignKeys, caseSensitiveLike int
+	var journalMode string
+	require.NoError(t, db.QueryRow("PRAGMA foreign_keys").Scan(&foreignKeys))
+	require.NoError(t, db.QueryRow("PRAGMA journal_mode").Scan(&journalMode))
+	require.NoError(t, db.QueryRow("SELECT 'a' LIKE 'A'").Scan(&caseSensitiveLike))
+	require.Equal(t, 1, foreignKeys)
+	require.Equal(t, "wal", journalMode)
+	require.Equal(t, 0, caseSensitiveLike)
+}
+
+func TestOpen_Err(t *testing.T) {
+	_, err := Open(context.Background(), filepath.Join(t.TempDir(), "missing", "oslc.db"))
+	require.Error(t, err)
+}
+
+func TestNewDatastore(t *testing.T) {
+	db := openTestDB(t)
+	ds, err := NewDatastore(WithDB(db))
+	require.NoError(t, err)
+	require.Equal(t, db, ds.options.DB)
+}
+
+func TestNewDatastore_ErrMissingOptionDB(t *testing.T) {
+	_, err := NewDatastore()
+	require.Equal(t, ErrMissingOptionDB, err)
+}
+
+func TestNewDatastore_globalOptionsAreApplied(t *testing.T) {
+	optCopy := make([]DatastoreOption, len(globalDatastoreOptions))
+	copy(optCopy, globalDatastoreOptions)
+	defer func() {
+		globalDatastoreOptions = optCopy
+	}()
+
+	db := openTestDB(t)
+	globalDatastoreOptions = append(globalDatastoreOptions, WithDB(db))
+	ds, err := NewDatastore()
+	require.NoError(t, err)
+	require.Equal(t, db, ds.options.DB)
+}
+
+func TestWithLogger(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	opts := datastoreOptions{}
+	WithLogger(logger).apply(&opts)
+	require.Equal(t, logger, opts.Logger)
+}
+
+func TestWithTracerProvider(t *testing.T) {
+	tp := noop.NewTracerProvider()
+	opts := datastoreOptions{}
+	WithTracerProvider(tp).apply(&opts)
+	require.Equal(t, tp, opts.TracerProvider)
+}
+
+func TestMicros(t *testing.T) {
+	now := time.Now()
+	require.True(t, now.Truncate(time.Microsecond).Equal(fromMicros(toMicros(now))))
+	require.Equal(t, time.UTC, fromMicros(0).Location())
+	require.Nil(t, nullMicros(time.Time{}))
+	require.Equal(t, toMicros(now), nullMicros(now))
+}
+
+func TestNamePatternToLike(t *testing.T) {
+	require.Equal(t, "@types/%", namePatternToLike("@types/*"))
+	require.Equal(t, `my\_package`, namePatternToLike("my_package"))
+	require.Equal(t, `100\%`, namePatternToLike("100%"))
+	require.Equal(t, `back\\slash`, namePatternToLike(`back\slash`))
+}
+
+func TestDatastore_Save_multipleDistributors(t *testing.T) {
+	ds := newTestDatastore(t)
+	ctx := context.Background()
+	entry := oslc.Entry{
+		Name:    "test",
+		Version: "1.0.0",
+		License: "MIT",
+		DistributionPoints: []oslc.DistributionPoint{
+			{Name: "test", URL: "https://a.example.com", Distributor: "a"},
+			{Name: "test", URL: "https://b.example.com", Distributor: "b", Kind: "sdist", Checksum: "sha256:abc"},
+		},
+	}
+	require.NoError(t, ds.Save(ctx, entry))
+
+	for _, distributor := range []string{"a", "b"} {
+		got, err := ds.Retrieve(ctx, "test", "1.0.0", distributor)
+		require.NoError(t, err)
+		require.Equal(t, entry, got)
+	}
+	counts, err := ds.CountPackages(ctx)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []oslc.PackageCount{{Distributor: "a", License: "MIT", Count: 1}, {Distributor: "b", License: "MIT", Count: 1}}, counts)
+}
+
+func TestDatastore_Save_withoutDistributionPoints(t *testing.T) {
+	ds := newTestDatastore(t)
+	require.NoError(t, ds.Save(context.Background(), oslc.Entry{Name: "test", Version: "1.0.0"}))
+	counts, err := ds.CountPackages(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, counts)
+}
+
+func TestDatastore_Invalidate_cascades(t *testing.T) {
+	ds := newTestDatastore(t)
+	ctx := context.Background()
+	require.NoError(t, ds.Save(ctx, oslc.Entry{Name: "test", Version: "1.0.0", DistributionPoints: []oslc.DistributionPoint{{Name: "test", URL: "https://example.com", Distributor: "a"}}}))
+	n, err := ds.Invalidate(ctx, oslc.EntryFilter{})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, n)
+
+	var points int
+	require.NoError(t, ds.options.DB.QueryRow("SELECT count(*) FROM distribution_points").Scan(&points))
+	require.Zero(t, points)
+}
+
+func TestDatastore_closedDB(t *testing.T) {
+	ds := newClosedDatastore(t)
+	ctx := context.Background()
+	entry := oslc.Entry{Name: "test", Version: "1.0.0", DistributionPoints: []oslc.DistributionPoint{{Distributor: "a"}}}
+
+	require.Error(t, ds.Save(ctx, entry))
+	_, err := ds.Retrieve(ctx, "test", "1.0.0", "a")
+	require.Error(t, err)
+	require.NotErrorIs(t, err, oslc.ErrDatastoreObjectNotFound)
+	_, err = ds.RetrieveVersions(ctx, "test", "a")
+	require.Error(t, err)
+	_, err = ds.Invalidate(ctx, oslc.EntryFilter{})
+	require.Error(t, err)
+	_, err = ds.Search(ctx, oslc.SearchQuery{Limit: 10})
+	require.Error(t, err)
+	_, err = ds.CountPackages(ctx)
+	require.Error(t, err)
+}
+
+func TestDatastore_Retrieve_tracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	ds := newTestDatastore(t, WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))))
+
+	_, err := ds.Retrieve(context.Background(), "test", "test2", "test3")
+	require.ErrorIs(t, err, oslc.ErrDatastoreObjectNotFound)
+	require.NoError(t, ds.options.DB.Close())
+	_, err = ds.Retrieve(context.Background(), "test", "test2", "test3")
+	require.Error(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	require.Equal(t, "sqlite SELECT", spans[0].Name())
+	require.Contains(t, spans[0].Attributes(), attribute.String("db.system", "sqlite"))
+	require.Contains(t, spans[0].Attributes(), attribute.String("oslc.package.name", "test"))
+	require.Contains(t, spans[0].Attributes(), attribute.String("oslc.distributor", "test3"))
+	require.Equal(t, codes.Unset, spans[0].Status().Code)
+	require.Equal(t, codes.Error, spans[1].Status().Code)
+}
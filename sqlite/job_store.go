@@ -0,0 +1,195 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/chainalysis-oss/oslc"
+	"go.opentelemetry.io/otel/attribute"
+	"strings"
+	"time"
+)
+
+// Compile time check to ensure Datastore implements [oslc.JobStore].
+var _ oslc.JobStore = (*Datastore)(nil)
+
+// jobColumns are the columns scanned by scanJob, in order.
+const jobColumns = "id, distributor, name, version, state, attempts, result, last_error, created_at, updated_at"
+
+// rowScanner is implemented by [sql.Row] and [sql.Rows].
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanJob scans the jobColumns of a row.
+func scanJob(row rowScanner) (oslc.Job, error) {
+	var job oslc.Job
+	var createdAt, updatedAt int64
+	err := row.Scan(&job.ID, &job.Distributor, &job.Name, &job.Version, (*string)(&job.State), &job.Attempts, &job.Result, &job.LastError, &createdAt, &updatedAt)
+	if err != nil {
+		return oslc.Job{}, err
+	}
+	job.CreatedAt = fromMicros(createdAt)
+	job.UpdatedAt = fromMicros(updatedAt)
+	return job, nil
+}
+
+var (
+	datastoreActiveJobStatement  = "SELECT " + jobColumns + " FROM lookup_jobs WHERE distributor = ?1 AND name = ?2 AND version = ?3 AND state IN ('pending', 'running')"
+	datastoreEnqueueJobStatement = "INSERT INTO lookup_jobs (distributor, name, version, next_attempt_at, created_at, updated_at) VALUES (?1, ?2, ?3, ?4, ?4, ?4) RETURNING " + jobColumns
+)
+
+// EnqueueJob looks for an active job for the request and inserts a job if there is none, in a transaction holding the
+// write lock, so concurrent callers share a single job.
+func (d *Datastore) EnqueueJob(ctx context.Context, request oslc.JobRequest) (_ oslc.Job, err error) {
+	ctx, span := d.startSpan(ctx, "INSERT", datastoreEnqueueJobStatement,
+		append(packageAttributes(request.Name, request.Version), attribute.String("oslc.distributor", request.Distributor))...)
+	defer func() { endSpan(span, err) }()
+
+	tx, err := d.options.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return oslc.Job{}, err
+	}
+	defer tx.Rollback()
+
+	job, err := scanJob(tx.QueryRowContext(ctx, datastoreActiveJobStatement, request.Distributor, request.Name, request.Version))
+	if err == nil {
+		return job, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return oslc.Job{}, err
+	}
+	job, err = scanJob(tx.QueryRowContext(ctx, datastoreEnqueueJobStatement, request.Distributor, request.Name, request.Version, toMicros(time.Now())))
+	if err != nil {
+		return oslc.Job{}, err
+	}
+	if err = tx.Commit(); err != nil {
+		return oslc.Job{}, err
+	}
+	return job, nil
+}
+
+var datastoreGetJobStatement = "SELECT " + jobColumns + " FROM lookup_jobs WHERE id = ?1"
+
+func (d *Datastore) GetJob(ctx context.Context, id int64) (_ oslc.Job, err error) {
+	ctx, span := d.startSpan(ctx, "SELECT", datastoreGetJobStatement, attribute.Int64("oslc.job_id", id))
+	defer func() { endSpan(span, err) }()
+
+	job, err := scanJob(d.options.DB.QueryRowContext(ctx, datastoreGetJobStatement, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return oslc.Job{}, oslc.ErrDatastoreObjectNotFound
+	}
+	return job, err
+}
+
+var (
+	datastoreDueJobsStatement   = "SELECT id FROM lookup_jobs WHERE state IN ('pending', 'running') AND next_attempt_at <= ?1 ORDER BY next_attempt_at LIMIT ?2"
+	datastoreClaimJobsStatement = "UPDATE lookup_jobs SET state = 'running', attempts = attempts + 1, next_attempt_at = ?1, updated_at = ?2 WHERE id IN (%s) RETURNING " + jobColumns
+)
+
+// ClaimJobs claims due jobs by marking them as running and moving their next attempt past the lease. The jobs are
+// selected and claimed in a transaction holding the write lock, so every job is claimed by a single caller.
+func (d *Datastore) ClaimJobs(ctx context.Context, limit int, lease time.Duration) (_ []oslc.Job, err error) {
+	ctx, span := d.startSpan(ctx, "UPDATE", datastoreClaimJobsStatement)
+	defer func() { endSpan(span, err) }()
+
+	tx, err := d.options.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	rows, err := tx.QueryContext(ctx, datastoreDueJobsStatement, toMicros(now), limit)
+	if err != nil {
+		return nil, err
+	}
+	args := []any{toMicros(now.Add(lease)), toMicros(now)}
+	placeholders := make([]string, 0)
+	for rows.Next() {
+		var id int64
+		if err = rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		args = append(args, id)
+		placeholders = append(placeholders, fmt.Sprintf("?%d", len(args)))
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	jobs := make([]oslc.Job, 0, len(placeholders))
+	if len(placeholders) == 0 {
+		return jobs, nil
+	}
+
+	rows, err = tx.QueryContext(ctx, fmt.Sprintf(datastoreClaimJobsStatement, strings.Join(placeholders, ", ")), args...)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+var datastoreCompleteJobStatement = "UPDATE lookup_jobs SET state = 'succeeded', result = ?2, updated_at = ?3 WHERE id = ?1 AND state IN ('pending', 'running')"
+
+func (d *Datastore) CompleteJob(ctx context.Context, id int64, result []byte) (err error) {
+	ctx, span := d.startSpan(ctx, "UPDATE", datastoreCompleteJobStatement, attribute.Int64("oslc.job_id", id))
+	defer func() { endSpan(span, err) }()
+
+	_, err = d.options.DB.ExecContext(ctx, datastoreCompleteJobStatement, id, result, toMicros(time.Now()))
+	return err
+}
+
+var datastoreRetryJobStatement = "UPDATE lookup_jobs SET state = 'pending', next_attempt_at = ?2, last_error = ?3, updated_at = ?4 WHERE id = ?1 AND state IN ('pending', 'running')"
+
+func (d *Datastore) RetryJob(ctx context.Context, id int64, next time.Time, lastError string) (err error) {
+	ctx, span := d.startSpan(ctx, "UPDATE", datastoreRetryJobStatement, attribute.Int64("oslc.job_id", id))
+	defer func() { endSpan(span, err) }()
+
+	_, err = d.options.DB.ExecContext(ctx, datastoreRetryJobStatement, id, toMicros(next), lastError, toMicros(time.Now()))
+	return err
+}
+
+var datastoreFailJobStatement = "UPDATE lookup_jobs SET state = 'failed', result = ?2, last_error = ?3, updated_at = ?4 WHERE id = ?1 AND state IN ('pending', 'running')"
+
+func (d *Datastore) FailJob(ctx context.Context, id int64, result []byte, lastError string) (err error) {
+	ctx, span := d.startSpan(ctx, "UPDATE", datastoreFailJobStatement, attribute.Int64("oslc.job_id", id))
+	defer func() { endSpan(span, err) }()
+
+	_, err = d.options.DB.ExecContext(ctx, datastoreFailJobStatement, id, result, lastError, toMicros(time.Now()))
+	return err
+}
+
+var datastoreDeleteFinishedJobsStatement = "DELETE FROM lookup_jobs WHERE state IN ('succeeded', 'failed') AND updated_at < ?1"
+
+func (d *Datastore) DeleteFinishedJobs(ctx context.Context, before time.Time) (_ int, err error) {
+	ctx, span := d.startSpan(ctx, "DELETE", datastoreDeleteFinishedJobsStatement)
+	defer func() { endSpan(span, err) }()
+
+	result, err := d.options.DB.ExecContext(ctx, datastoreDeleteFinishedJobsStatement, toMicros(before))
+	if err != nil {
+		return 0, err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(n), nil
+}
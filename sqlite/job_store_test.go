@@ -0,0 +1,47 @@
+package sqlite
+
+import (
+	"context"
+	"github.com/chainalysis-oss/oslc"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDatastore_EnqueueJob_concurrent(t *testing.T) {
+	ds := newTestDatastore(t)
+	request := oslc.JobRequest{Distributor: oslc.DistributorGo, Name: "github.com/aws/aws-sdk-go"}
+
+	ids := make([]int64, 8)
+	var wg sync.WaitGroup
+	for i := range ids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			job, err := ds.EnqueueJob(context.Background(), request)
+			require.NoError(t, err)
+			ids[i] = job.ID
+		}()
+	}
+	wg.Wait()
+	for _, id := range ids {
+		require.Equal(t, ids[0], id)
+	}
+}
+
+func TestDatastore_jobs_closedDB(t *testing.T) {
+	ds := newClosedDatastore(t)
+	ctx := context.Background()
+	_, err := ds.EnqueueJob(ctx, oslc.JobRequest{})
+	require.Error(t, err)
+	_, err = ds.GetJob(ctx, 1)
+	require.Error(t, err)
+	_, err = ds.ClaimJobs(ctx, 1, time.Minute)
+	require.Error(t, err)
+	require.Error(t, ds.CompleteJob(ctx, 1, nil))
+	require.Error(t, ds.RetryJob(ctx, 1, time.Now(), ""))
+	require.Error(t, ds.FailJob(ctx, 1, nil, ""))
+	_, err = ds.DeleteFinishedJobs(ctx, time.Now())
+	require.Error(t, err)
+}
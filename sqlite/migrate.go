@@ -0,0 +1,144 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"github.com/chainalysis-oss/oslc/migrate"
+	"log/slog"
+	"time"
+)
+
+// migrationsFS holds the schema migrations, in the layout documented by [migrate.Load].
+//
+//go:embed migrations/*.sql
+var migrationsFS embed.FS
+
+// Migrations returns the schema migrations, ordered by version.
+func Migrations() ([]migrate.Migration, error) {
+	return migrate.Load(migrationsFS, "migrations")
+}
+
+var (
+	migrateCreateTableStatement = "CREATE TABLE IF NOT EXISTS schema_migrations (version integer PRIMARY KEY, name text NOT NULL, applied_at integer NOT NULL)"
+	migrateAppliedStatement     = "SELECT version, name, applied_at FROM schema_migrations ORDER BY version"
+	migrateInsertStatement      = "INSERT INTO schema_migrations (version, name, applied_at) VALUES (?1, ?2, ?3)"
+	migrateDeleteStatement      = "DELETE FROM schema_migrations WHERE version = ?1"
+)
+
+// MigrateUp applies the migrations that have not been applied yet, in order, and returns them. The applied migrations
+// are recorded in the schema_migrations table.
+//
+// All migrations are applied in a single transaction, which holds the write lock of the database from the start, so
+// either all of them are applied or none, and processes migrating concurrently wait for each other.
+func (d *Datastore) MigrateUp(ctx context.Context) ([]migrate.Migration, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+	var pending []migrate.Migration
+	err = d.inMigrationTransaction(ctx, func(tx *sql.Tx, applied []migrate.Applied) error {
+		pending = migrate.Pending(migrations, applied)
+		for _, m := range pending {
+			d.options.Logger.InfoContext(ctx, "applying migration", slog.Int("version", m.Version), slog.String("name", m.Name))
+			if _, err := tx.ExecContext(ctx, m.Up); err != nil {
+				return fmt.Errorf("failed to apply migration %d_%s: %w", m.Version, m.Name, err)
+			}
+			if _, err := tx.ExecContext(ctx, migrateInsertStatement, m.Version, m.Name, toMicros(time.Now())); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pending, nil
+}
+
+// MigrateDown reverts the most recently applied migrations, up to steps of them, and returns them in the order they were
+// reverted. Like [Datastore.MigrateUp], it reverts all migrations or none.
+func (d *Datastore) MigrateDown(ctx context.Context, steps int) ([]migrate.Migration, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+	var reverted []migrate.Migration
+	err = d.inMigrationTransaction(ctx, func(tx *sql.Tx, applied []migrate.Applied) error {
+		reverted, err = migrate.Revert(migrations, applied, steps)
+		if err != nil {
+			return err
+		}
+		for _, m := range reverted {
+			d.options.Logger.InfoContext(ctx, "reverting migration", slog.Int("version", m.Version), slog.String("name", m.Name))
+			if _, err := tx.ExecContext(ctx, m.Down); err != nil {
+				return fmt.Errorf("failed to revert migration %d_%s: %w", m.Version, m.Name, err)
+			}
+			if _, err := tx.ExecContext(ctx, migrateDeleteStatement, m.Version); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reverted, nil
+}
+
+// MigrationStatus returns the status of every migration, including migrations applied by newer versions of OSLC,
+// ordered by version.
+func (d *Datastore) MigrationStatus(ctx context.Context) ([]migrate.Status, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+	var statuses []migrate.Status
+	err = d.inMigrationTransaction(ctx, func(_ *sql.Tx, applied []migrate.Applied) error {
+		statuses = migrate.Statuses(migrations, applied)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return statuses, nil
+}
+
+// inMigrationTransaction calls f in a transaction with the migrations applied so far. The schema_migrations table is
+// created if it does not exist. The transaction is committed if f succeeds.
+func (d *Datastore) inMigrationTransaction(ctx context.Context, f func(tx *sql.Tx, applied []migrate.Applied) error) error {
+	tx, err := d.options.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, migrateCreateTableStatement); err != nil {
+		return err
+	}
+	rows, err := tx.QueryContext(ctx, migrateAppliedStatement)
+	if err != nil {
+		return err
+	}
+	var applied []migrate.Applied
+	for rows.Next() {
+		var a migrate.Applied
+		var appliedAt int64
+		if err := rows.Scan(&a.Version, &a.Name, &appliedAt); err != nil {
+			rows.Close()
+			return err
+		}
+		a.AppliedAt = fromMicros(appliedAt)
+		applied = append(applied, a)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if err := f(tx, applied); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
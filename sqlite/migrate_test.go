@@ -0,0 +1,75 @@
+package sqlite
+
+import (
+	"context"
+	"github.com/chainalysis-oss/oslc/migrate"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestMigrations(t *testing.T) {
+	migrations, err := Migrations()
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+	require.Equal(t, "the_beginning", migrations[0].Name)
+}
+
+func TestDatastore_Migrate(t *testing.T) {
+	ds, err := NewDatastore(WithDB(openTestDB(t)))
+	require.NoError(t, err)
+	ctx := context.Background()
+	migrations, err := Migrations()
+	require.NoError(t, err)
+
+	statuses, err := ds.MigrationStatus(ctx)
+	require.NoError(t, err)
+	require.Len(t, statuses, len(migrations))
+	require.False(t, statuses[0].Applied)
+
+	applied, err := ds.MigrateUp(ctx)
+	require.NoError(t, err)
+	require.Equal(t, migrations, applied)
+	applied, err = ds.MigrateUp(ctx)
+	require.NoError(t, err)
+	require.Empty(t, applied)
+
+	statuses, err = ds.MigrationStatus(ctx)
+	require.NoError(t, err)
+	require.True(t, statuses[0].Applied)
+	require.False(t, statuses[0].AppliedAt.IsZero())
+
+	reverted, err := ds.MigrateDown(ctx, len(migrations))
+	require.NoError(t, err)
+	require.Len(t, reverted, len(migrations))
+	var tables int
+	require.NoError(t, ds.options.DB.QueryRow("SELECT count(*) FROM sqlite_master WHERE type = 'table' AND name != 'schema_migrations'").Scan(&tables))
+	require.Zero(t, tables)
+
+	// The schema can be created again after it has been reverted.
+	applied, err = ds.MigrateUp(ctx)
+	require.NoError(t, err)
+	require.Equal(t, migrations, applied)
+}
+
+func TestDatastore_MigrateDown_ErrUnknownMigration(t *testing.T) {
+	ds := newTestDatastore(t)
+	_, err := ds.options.DB.Exec(migrateInsertStatement, 1000, "future", 0)
+	require.NoError(t, err)
+
+	_, err = ds.MigrateDown(context.Background(), 1)
+	require.ErrorIs(t, err, migrate.ErrUnknownMigration)
+
+	statuses, err := ds.MigrationStatus(context.Background())
+	require.NoError(t, err)
+	require.True(t, statuses[len(statuses)-1].Unknown)
+}
+
+func TestDatastore_MigrateUp_ErrClosed(t *testing.T) {
+	ds := newClosedDatastore(t)
+	_, err := ds.MigrateUp(context.Background())
+	require.Error(t, err)
+	_, err = ds.MigrateDown(context.Background(), 1)
+	require.Error(t, err)
+	_, err = ds.MigrationStatus(context.Background())
+	require.Error(t, err)
+}
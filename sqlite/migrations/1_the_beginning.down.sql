@@ -0,0 +1,6 @@
+drop table if exists webhook_deliveries;
+drop table if exists webhook_subscriptions;
+drop table if exists license_overrides;
+drop table if exists distribution_points;
+drop table if exists package_versions;
+drop table if exists packages;
@@ -0,0 +1,76 @@
+-- The schema mirrors the schema of the postgres package. Timestamps are stored as microseconds since the Unix epoch,
+-- so they compare correctly regardless of how they are formatted.
+create table packages
+(
+    id integer primary key,
+    distributor text not null,
+    name text not null,
+    constraint packages_distributor_name_key unique (distributor, name)
+);
+
+create table package_versions
+(
+    id integer primary key,
+    package_id integer not null references packages (id) on delete cascade,
+    version text not null,
+    -- The license is an SPDX license expression, such as "MIT OR Apache-2.0", or a single identifier.
+    license text not null,
+    raw_license text not null default '',
+    normalization_status text not null default '',
+    license_list_version text not null default '',
+    fetched_at integer not null,
+    constraint package_versions_package_id_version_key unique (package_id, version)
+);
+
+create index package_versions_license_idx on package_versions (license);
+create index package_versions_fetched_at_idx on package_versions (fetched_at);
+
+create table distribution_points
+(
+    version_id integer not null references package_versions (id) on delete cascade,
+    position integer not null,
+    name text not null,
+    url text not null,
+    distributor text not null,
+    kind text not null default '',
+    checksum text not null default '',
+    constraint distribution_points_pk primary key (version_id, position)
+);
+
+create table license_overrides
+(
+    distributor text not null,
+    name text not null,
+    version_range text not null,
+    license text not null,
+    justification text not null,
+    author text not null,
+    updated_at integer not null,
+    constraint license_overrides_pk primary key (distributor, name, version_range)
+);
+
+create table webhook_subscriptions
+(
+    id integer primary key,
+    url text not null,
+    secret text not null,
+    distributor text not null default '',
+    package_pattern text not null default '',
+    -- A JSON array of license categories.
+    license_categories text not null default '[]',
+    created_at integer not null
+);
+
+create table webhook_deliveries
+(
+    id integer primary key,
+    subscription_id integer not null references webhook_subscriptions (id) on delete cascade,
+    event text not null,
+    attempts integer not null default 0,
+    next_attempt_at integer not null,
+    last_error text not null default '',
+    failed integer not null default 0,
+    created_at integer not null
+);
+
+create index webhook_deliveries_due_idx on webhook_deliveries (next_attempt_at) where not failed;
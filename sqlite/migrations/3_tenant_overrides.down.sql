@@ -0,0 +1,18 @@
+-- The overrides of tenants cannot be kept without the tenant column.
+create table license_overrides_old
+(
+    distributor text not null,
+    name text not null,
+    version_range text not null,
+    license text not null,
+    justification text not null,
+    author text not null,
+    updated_at integer not null,
+    constraint license_overrides_pk primary key (distributor, name, version_range)
+);
+
+insert into license_overrides_old (distributor, name, version_range, license, justification, author, updated_at)
+select distributor, name, version_range, license, justification, author, updated_at from license_overrides where tenant = '';
+
+drop table license_overrides;
+alter table license_overrides_old rename to license_overrides;
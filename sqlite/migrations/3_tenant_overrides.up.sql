@@ -0,0 +1,20 @@
+-- SQLite cannot change the primary key of a table, so the table is recreated. Overrides without a tenant are shared by
+-- every tenant.
+create table license_overrides_new
+(
+    tenant text not null default '',
+    distributor text not null,
+    name text not null,
+    version_range text not null,
+    license text not null,
+    justification text not null,
+    author text not null,
+    updated_at integer not null,
+    constraint license_overrides_pk primary key (tenant, distributor, name, version_range)
+);
+
+insert into license_overrides_new (distributor, name, version_range, license, justification, author, updated_at)
+select distributor, name, version_range, license, justification, author, updated_at from license_overrides;
+
+drop table license_overrides;
+alter table license_overrides_new rename to license_overrides;
@@ -0,0 +1,198 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/chainalysis-oss/oslc"
+	"go.opentelemetry.io/otel/attribute"
+	"strings"
+	"time"
+)
+
+// Compile time check to ensure Datastore implements [oslc.WebhookStore].
+var _ oslc.WebhookStore = (*Datastore)(nil)
+
+var datastoreCreateSubscriptionStatement = "INSERT INTO webhook_subscriptions (url, secret, distributor, package_pattern, license_categories, created_at) VALUES (?1, ?2, ?3, ?4, ?5, ?6)"
+
+func (d *Datastore) CreateSubscription(ctx context.Context, subscription oslc.WebhookSubscription) (_ oslc.WebhookSubscription, err error) {
+	ctx, span := d.startSpan(ctx, "INSERT", datastoreCreateSubscriptionStatement)
+	defer func() { endSpan(span, err) }()
+
+	categories := subscription.LicenseCategories
+	if categories == nil {
+		categories = []string{}
+	}
+	encoded, err := json.Marshal(categories)
+	if err != nil {
+		return oslc.WebhookSubscription{}, fmt.Errorf("encoding license categories: %w", err)
+	}
+	createdAt := time.Now().UTC().Truncate(time.Microsecond)
+	result, err := d.options.DB.ExecContext(ctx, datastoreCreateSubscriptionStatement, subscription.URL, subscription.Secret, subscription.Distributor, subscription.PackagePattern, string(encoded), toMicros(createdAt))
+	if err != nil {
+		return oslc.WebhookSubscription{}, err
+	}
+	subscription.ID, err = result.LastInsertId()
+	if err != nil {
+		return oslc.WebhookSubscription{}, err
+	}
+	subscription.CreatedAt = createdAt
+	subscription.LicenseCategories = categories
+	return subscription, nil
+}
+
+var datastoreListSubscriptionsStatement = "SELECT id, url, secret, distributor, package_pattern, license_categories, created_at FROM webhook_subscriptions ORDER BY id"
+
+func (d *Datastore) ListSubscriptions(ctx context.Context) (_ []oslc.WebhookSubscription, err error) {
+	ctx, span := d.startSpan(ctx, "SELECT", datastoreListSubscriptionsStatement)
+	defer func() { endSpan(span, err) }()
+
+	rows, err := d.options.DB.QueryContext(ctx, datastoreListSubscriptionsStatement)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	subscriptions := make([]oslc.WebhookSubscription, 0)
+	for rows.Next() {
+		var s oslc.WebhookSubscription
+		var categories string
+		var createdAt int64
+		if err = rows.Scan(&s.ID, &s.URL, &s.Secret, &s.Distributor, &s.PackagePattern, &categories, &createdAt); err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal([]byte(categories), &s.LicenseCategories); err != nil {
+			return nil, fmt.Errorf("decoding license categories of subscription %d: %w", s.ID, err)
+		}
+		s.CreatedAt = fromMicros(createdAt)
+		subscriptions = append(subscriptions, s)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+var datastoreDeleteSubscriptionStatement = "DELETE FROM webhook_subscriptions WHERE id = ?1"
+
+func (d *Datastore) DeleteSubscription(ctx context.Context, id int64) (err error) {
+	ctx, span := d.startSpan(ctx, "DELETE", datastoreDeleteSubscriptionStatement, attribute.Int64("oslc.webhook.subscription_id", id))
+	defer func() { endSpan(span, err) }()
+
+	result, err := d.options.DB.ExecContext(ctx, datastoreDeleteSubscriptionStatement, id)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return oslc.ErrDatastoreObjectNotFound
+	}
+	return nil
+}
+
+var datastoreEnqueueDeliveryStatement = "INSERT INTO webhook_deliveries (subscription_id, event, next_attempt_at, created_at) VALUES (?1, ?2, ?3, ?3)"
+
+func (d *Datastore) EnqueueDelivery(ctx context.Context, subscriptionID int64, event oslc.LicenseChangeEvent) (err error) {
+	ctx, span := d.startSpan(ctx, "INSERT", datastoreEnqueueDeliveryStatement, attribute.Int64("oslc.webhook.subscription_id", subscriptionID))
+	defer func() { endSpan(span, err) }()
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("encoding event: %w", err)
+	}
+	_, err = d.options.DB.ExecContext(ctx, datastoreEnqueueDeliveryStatement, subscriptionID, string(payload), toMicros(time.Now()))
+	return err
+}
+
+var (
+	datastoreDueDeliveriesStatement   = "SELECT d.id, d.subscription_id, s.url, s.secret, d.event, d.attempts + 1 FROM webhook_deliveries d JOIN webhook_subscriptions s ON s.id = d.subscription_id WHERE NOT d.failed AND d.next_attempt_at <= ?1 ORDER BY d.next_attempt_at LIMIT ?2"
+	datastoreClaimDeliveriesStatement = "UPDATE webhook_deliveries SET attempts = attempts + 1, next_attempt_at = ?1 WHERE id IN (%s)"
+)
+
+// ClaimDeliveries claims due deliveries by moving their next attempt past the lease. The deliveries are selected and
+// claimed in a transaction holding the write lock, so every delivery is claimed by a single caller.
+func (d *Datastore) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) (_ []oslc.WebhookDelivery, err error) {
+	ctx, span := d.startSpan(ctx, "UPDATE", datastoreClaimDeliveriesStatement)
+	defer func() { endSpan(span, err) }()
+
+	tx, err := d.options.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	rows, err := tx.QueryContext(ctx, datastoreDueDeliveriesStatement, toMicros(now), limit)
+	if err != nil {
+		return nil, err
+	}
+	deliveries := make([]oslc.WebhookDelivery, 0)
+	for rows.Next() {
+		var delivery oslc.WebhookDelivery
+		var event string
+		if err = rows.Scan(&delivery.ID, &delivery.SubscriptionID, &delivery.URL, &delivery.Secret, &event, &delivery.Attempts); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if err = json.Unmarshal([]byte(event), &delivery.Event); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("decoding event of delivery %d: %w", delivery.ID, err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(deliveries) == 0 {
+		return deliveries, nil
+	}
+
+	args := []any{toMicros(now.Add(lease))}
+	placeholders := make([]string, len(deliveries))
+	for i, delivery := range deliveries {
+		args = append(args, delivery.ID)
+		placeholders[i] = fmt.Sprintf("?%d", i+2)
+	}
+	_, err = tx.ExecContext(ctx, fmt.Sprintf(datastoreClaimDeliveriesStatement, strings.Join(placeholders, ", ")), args...)
+	if err != nil {
+		return nil, err
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+var datastoreCompleteDeliveryStatement = "DELETE FROM webhook_deliveries WHERE id = ?1"
+
+func (d *Datastore) CompleteDelivery(ctx context.Context, id int64) (err error) {
+	ctx, span := d.startSpan(ctx, "DELETE", datastoreCompleteDeliveryStatement, attribute.Int64("oslc.webhook.delivery_id", id))
+	defer func() { endSpan(span, err) }()
+
+	_, err = d.options.DB.ExecContext(ctx, datastoreCompleteDeliveryStatement, id)
+	return err
+}
+
+var datastoreRetryDeliveryStatement = "UPDATE webhook_deliveries SET next_attempt_at = ?2, last_error = ?3 WHERE id = ?1"
+
+func (d *Datastore) RetryDelivery(ctx context.Context, id int64, next time.Time, lastError string) (err error) {
+	ctx, span := d.startSpan(ctx, "UPDATE", datastoreRetryDeliveryStatement, attribute.Int64("oslc.webhook.delivery_id", id))
+	defer func() { endSpan(span, err) }()
+
+	_, err = d.options.DB.ExecContext(ctx, datastoreRetryDeliveryStatement, id, toMicros(next), lastError)
+	return err
+}
+
+var datastoreFailDeliveryStatement = "UPDATE webhook_deliveries SET failed = 1, last_error = ?2 WHERE id = ?1"
+
+func (d *Datastore) FailDelivery(ctx context.Context, id int64, lastError string) (err error) {
+	ctx, span := d.startSpan(ctx, "UPDATE", datastoreFailDeliveryStatement, attribute.Int64("oslc.webhook.delivery_id", id))
+	defer func() { endSpan(span, err) }()
+
+	_, err = d.options.DB.ExecContext(ctx, datastoreFailDeliveryStatement, id, lastError)
+	return err
+}
@@ -0,0 +1,64 @@
+package sqlite
+
+import (
+	"context"
+	"github.com/chainalysis-oss/oslc"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestDatastore_DeleteSubscription_cascades(t *testing.T) {
+	ds := newTestDatastore(t)
+	ctx := context.Background()
+	subscription, err := ds.CreateSubscription(ctx, oslc.WebhookSubscription{URL: "https://example.com", Secret: "s"})
+	require.NoError(t, err)
+	require.NoError(t, ds.EnqueueDelivery(ctx, subscription.ID, oslc.LicenseChangeEvent{Name: "test"}))
+
+	require.NoError(t, ds.DeleteSubscription(ctx, subscription.ID))
+	deliveries, err := ds.ClaimDeliveries(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Empty(t, deliveries)
+}
+
+func TestDatastore_EnqueueDelivery_ErrUnknownSubscription(t *testing.T) {
+	ds := newTestDatastore(t)
+	require.Error(t, ds.EnqueueDelivery(context.Background(), 42, oslc.LicenseChangeEvent{}))
+}
+
+func TestDatastore_ClaimDeliveries_ErrDecodingEvent(t *testing.T) {
+	ds := newTestDatastore(t)
+	ctx := context.Background()
+	subscription, err := ds.CreateSubscription(ctx, oslc.WebhookSubscription{URL: "https://example.com", Secret: "s"})
+	require.NoError(t, err)
+	_, err = ds.options.DB.Exec(datastoreEnqueueDeliveryStatement, subscription.ID, "not json", 0)
+	require.NoError(t, err)
+
+	_, err = ds.ClaimDeliveries(ctx, 10, time.Minute)
+	require.ErrorContains(t, err, "decoding event")
+}
+
+func TestDatastore_ListSubscriptions_ErrDecodingCategories(t *testing.T) {
+	ds := newTestDatastore(t)
+	_, err := ds.options.DB.Exec(datastoreCreateSubscriptionStatement, "https://example.com", "s", "", "", "not json", 0)
+	require.NoError(t, err)
+
+	_, err = ds.ListSubscriptions(context.Background())
+	require.ErrorContains(t, err, "decoding license categories")
+}
+
+func TestDatastore_webhooks_closedDB(t *testing.T) {
+	ds := newClosedDatastore(t)
+	ctx := context.Background()
+	_, err := ds.CreateSubscription(ctx, oslc.WebhookSubscription{})
+	require.Error(t, err)
+	_, err = ds.ListSubscriptions(ctx)
+	require.Error(t, err)
+	require.Error(t, ds.DeleteSubscription(ctx, 1))
+	require.Error(t, ds.EnqueueDelivery(ctx, 1, oslc.LicenseChangeEvent{}))
+	_, err = ds.ClaimDeliveries(ctx, 1, time.Minute)
+	require.Error(t, err)
+	require.Error(t, ds.CompleteDelivery(ctx, 1))
+	require.Error(t, ds.RetryDelivery(ctx, 1, time.Now(), ""))
+	require.Error(t, ds.FailDelivery(ctx, 1, ""))
+}
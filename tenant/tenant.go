@@ -0,0 +1,252 @@
+// Package tenant identifies the tenants sharing a deployment of OSLC, and holds their license policies and request
+// quotas. The catalog is shared by every tenant; see the oslc/oslc package for how tenants see it.
+//
+// Tenants are configured in YAML:
+//
+//	# The request header naming the tenant of a request, for deployments behind a gateway that authenticates callers.
+//	# If empty, tenants are only identified by their API keys.
+//	header: x-oslc-tenant
+//	tenants:
+//	  - name: payments
+//	    # API keys identifying the tenant, sent by clients as bearer tokens in the authorization header.
+//	    api_keys: [payments-ci-key]
+//	    # The license policy of the tenant, in the format of the policy package. A relative path is relative to the
+//	    # directory of the configuration file.
+//	    policy: policies/payments.yaml
+//	    # The rate of requests allowed for the tenant, and the number of requests allowed in a burst. Requests are not
+//	    # limited if requests_per_second is 0.
+//	    quota:
+//	      requests_per_second: 50
+//	      burst: 100
+package tenant
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"github.com/chainalysis-oss/oslc/policy"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gopkg.in/yaml.v3"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Config is the configuration of the tenants.
+type Config struct {
+	Header  string   `yaml:"header"`
+	Tenants []Tenant `yaml:"tenants"`
+}
+
+// Tenant is the configuration of a tenant.
+type Tenant struct {
+	Name    string   `yaml:"name"`
+	APIKeys []string `yaml:"api_keys"`
+	Policy  string   `yaml:"policy"`
+	Quota   Quota    `yaml:"quota"`
+}
+
+// Quota limits the rate of requests of a tenant.
+type Quota struct {
+	RequestsPerSecond float64 `yaml:"requests_per_second"`
+	// Burst is the number of requests allowed at once. It defaults to the number of requests allowed per second,
+	// rounded up.
+	Burst int `yaml:"burst"`
+}
+
+// ErrInvalidConfig is returned by [Parse] and [Load] for configurations that are not valid.
+var ErrInvalidConfig = errors.New("invalid tenant configuration")
+
+// Registry holds the tenants of a configuration. It identifies the tenant of requests, and holds the license policies
+// and request quotas of the tenants. It is safe for concurrent use.
+type Registry struct {
+	header   string
+	keys     []apiKey
+	tenants  map[string]bool
+	policies map[string]*policy.Policy
+	limiters map[string]*rate.Limiter
+}
+
+// apiKey is the digest of an API key of a tenant. Keys are compared by their digests, so comparisons take the same
+// time whatever the length of the key.
+type apiKey struct {
+	digest [sha256.Size]byte
+	tenant string
+}
+
+// Load reads the configuration from the file at path.
+func Load(path string) (*Registry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tenant configuration: %w", err)
+	}
+	return Parse(data, filepath.Dir(path))
+}
+
+// Parse parses a configuration in YAML. The policies of tenants are loaded relative to dir. Unknown fields are rejected,
+// so that misspelled fields are not silently ignored.
+func Parse(data []byte, dir string) (*Registry, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	var c Config
+	if err := decoder.Decode(&c); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+	r, err := newRegistry(c, dir)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+	return r, nil
+}
+
+func newRegistry(c Config, dir string) (*Registry, error) {
+	r := &Registry{
+		// Metadata keys are lowercase.
+		header:   strings.ToLower(c.Header),
+		tenants:  make(map[string]bool),
+		policies: make(map[string]*policy.Policy),
+		limiters: make(map[string]*rate.Limiter),
+	}
+	for i, t := range c.Tenants {
+		switch {
+		case t.Name == "":
+			return nil, fmt.Errorf("tenant %d: name is required", i+1)
+		case r.tenants[t.Name]:
+			return nil, fmt.Errorf("tenant %s: duplicate name", t.Name)
+		case t.Quota.RequestsPerSecond < 0 || t.Quota.Burst < 0:
+			return nil, fmt.Errorf("tenant %s: quota must not be negative", t.Name)
+		}
+		r.tenants[t.Name] = true
+
+		for _, key := range t.APIKeys {
+			if key == "" {
+				return nil, fmt.Errorf("tenant %s: API keys must not be empty", t.Name)
+			}
+			digest := sha256.Sum256([]byte(key))
+			for _, k := range r.keys {
+				if k.digest == digest {
+					return nil, fmt.Errorf("tenant %s: API key is already used by tenant %s", t.Name, k.tenant)
+				}
+			}
+			r.keys = append(r.keys, apiKey{digest: digest, tenant: t.Name})
+		}
+
+		if t.Policy != "" {
+			path := t.Policy
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(dir, path)
+			}
+			p, err := policy.Load(path)
+			if err != nil {
+				return nil, fmt.Errorf("tenant %s: %w", t.Name, err)
+			}
+			r.policies[t.Name] = p
+		}
+
+		if t.Quota.RequestsPerSecond > 0 {
+			burst := t.Quota.Burst
+			if burst == 0 {
+				burst = int(math.Ceil(t.Quota.RequestsPerSecond))
+			}
+			r.limiters[t.Name] = rate.NewLimiter(rate.Limit(t.Quota.RequestsPerSecond), burst)
+		}
+	}
+	return r, nil
+}
+
+// ResolveTenant returns the tenant of the request with the provided incoming metadata, or an empty string if the
+// request is not made for a tenant. A request is made for the tenant whose API key it carries as a bearer token, or
+// else for the tenant named by the tenant header, if one is configured. Bearer tokens that are not API keys, such as
+// the admin token, are ignored. The returned errors are gRPC status errors.
+func (r *Registry) ResolveTenant(md metadata.MD) (string, error) {
+	tenant := r.tenantOfAPIKey(bearerToken(md))
+	if r.header == "" {
+		return tenant, nil
+	}
+	values := md.Get(r.header)
+	if len(values) == 0 || values[0] == "" {
+		return tenant, nil
+	}
+	named := values[0]
+	switch {
+	case !r.tenants[named]:
+		return "", status.Errorf(codes.PermissionDenied, "unknown tenant %q", named)
+	case tenant != "" && tenant != named:
+		return "", status.Errorf(codes.PermissionDenied, "API key does not belong to tenant %q", named)
+	}
+	return named, nil
+}
+
+// tenantOfAPIKey returns the tenant with the API key, or an empty string if no tenant has it.
+func (r *Registry) tenantOfAPIKey(key string) string {
+	if key == "" {
+		return ""
+	}
+	digest := sha256.Sum256([]byte(key))
+	tenant := ""
+	// Every key is compared, so the time taken does not reveal which key matched.
+	for _, k := range r.keys {
+		if subtle.ConstantTimeCompare(digest[:], k.digest[:]) == 1 {
+			tenant = k.tenant
+		}
+	}
+	return tenant
+}
+
+// bearerToken returns the bearer token of the authorization metadata, or an empty string if there is none.
+func bearerToken(md metadata.MD) string {
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return ""
+	}
+	scheme, token, ok := strings.Cut(values[0], " ")
+	if !ok || !strings.EqualFold(scheme, "bearer") {
+		return ""
+	}
+	return token
+}
+
+// AllowRequest reports whether the quota of the tenant allows another request, and if so, counts the request against
+// the quota. Tenants without a quota, and requests not made for a tenant, are always allowed.
+func (r *Registry) AllowRequest(tenant string) bool {
+	return r.AllowRequests(tenant, 1)
+}
+
+// AllowRequests is like [Registry.AllowRequest], for n requests at once. More requests than the burst of the quota are
+// never allowed at once.
+func (r *Registry) AllowRequests(tenant string, n int) bool {
+	limiter, ok := r.limiters[tenant]
+	if !ok {
+		return true
+	}
+	return limiter.AllowN(time.Now(), n)
+}
+
+// RequestBurst returns the number of requests the quota of the tenant allows at once, and reports false if the tenant
+// has no quota.
+func (r *Registry) RequestBurst(tenant string) (int, bool) {
+	limiter, ok := r.limiters[tenant]
+	if !ok {
+		return 0, false
+	}
+	return limiter.Burst(), true
+}
+
+// HasTenant reports whether the tenant is configured.
+func (r *Registry) HasTenant(tenant string) bool {
+	return r.tenants[tenant]
+}
+
+// TenantPolicy returns the license policy of the tenant, if it has one.
+func (r *Registry) TenantPolicy(tenant string) (*policy.Policy, bool) {
+	p, ok := r.policies[tenant]
+	return p, ok
+}
@@ -0,0 +1,202 @@
+package tenant
+
+import (
+	"github.com/chainalysis-oss/oslc/policy"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "payments.yaml"), []byte("deny: [GPL-3.0-only]"), 0o600))
+
+	r, err := Parse([]byte(`
+header: X-OSLC-Tenant
+tenants:
+  - name: payments
+    api_keys: [payments-key]
+    policy: payments.yaml
+    quota:
+      requests_per_second: 2.5
+  - name: research
+`), dir)
+	require.NoError(t, err)
+	require.Equal(t, "x-oslc-tenant", r.header)
+	require.Equal(t, map[string]bool{"payments": true, "research": true}, r.tenants)
+	require.Len(t, r.keys, 1)
+
+	p, ok := r.TenantPolicy("payments")
+	require.True(t, ok)
+	require.Equal(t, []string{"GPL-3.0-only"}, p.Deny)
+	_, ok = r.TenantPolicy("research")
+	require.False(t, ok)
+
+	// The burst defaults to the rate, rounded up.
+	require.Equal(t, 3, r.limiters["payments"].Burst())
+	require.NotContains(t, r.limiters, "research")
+
+	r, err = Parse(nil, dir)
+	require.NoError(t, err)
+	require.Empty(t, r.tenants)
+}
+
+func TestParse_invalid(t *testing.T) {
+	testcases := []struct {
+		name   string
+		config string
+	}{
+		{"unknown field", "tenant: []"},
+		{"missing name", "tenants: [{api_keys: [key]}]"},
+		{"duplicate name", "tenants: [{name: a}, {name: a}]"},
+		{"empty API key", "tenants: [{name: a, api_keys: ['']}]"},
+		{"duplicate API key", "tenants: [{name: a, api_keys: [key]}, {name: b, api_keys: [key]}]"},
+		{"negative rate", "tenants: [{name: a, quota: {requests_per_second: -1}}]"},
+		{"negative burst", "tenants: [{name: a, quota: {burst: -1}}]"},
+		{"missing policy", "tenants: [{name: a, policy: missing.yaml}]"},
+		{"not yaml", "tenants: [{name: a"},
+	}
+	for _, tt := range testcases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.config), t.TempDir())
+			require.ErrorIs(t, err, ErrInvalidConfig)
+		})
+	}
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "invalid.yaml"), []byte("alow: [MIT]"), 0o600))
+	_, err := Parse([]byte("tenants: [{name: a, policy: invalid.yaml}]"), dir)
+	require.ErrorIs(t, err, policy.ErrInvalidPolicy)
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "policies"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "policies", "a.yaml"), []byte("deny: [SSPL-1.0]"), 0o600))
+	path := filepath.Join(dir, "tenants.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("tenants: [{name: a, policy: policies/a.yaml}]"), 0o600))
+
+	// Policies are loaded relative to the configuration file.
+	r, err := Load(path)
+	require.NoError(t, err)
+	p, ok := r.TenantPolicy("a")
+	require.True(t, ok)
+	require.Equal(t, []string{"SSPL-1.0"}, p.Deny)
+
+	_, err = Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestRegistry_ResolveTenant(t *testing.T) {
+	r, err := Parse([]byte(`
+header: x-oslc-tenant
+tenants:
+  - name: payments
+    api_keys: [payments-key, payments-ci-key]
+  - name: research
+    api_keys: [research-key]
+`), t.TempDir())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		md   metadata.MD
+		want string
+		code codes.Code
+	}{
+		{"no metadata", nil, "", codes.OK},
+		{"API key", metadata.Pairs("authorization", "Bearer payments-ci-key"), "payments", codes.OK},
+		{"unknown bearer token", metadata.Pairs("authorization", "Bearer admin-token"), "", codes.OK},
+		{"other scheme", metadata.Pairs("authorization", "Basic research-key"), "", codes.OK},
+		{"header", metadata.Pairs("x-oslc-tenant", "research"), "research", codes.OK},
+		{"header and API key", metadata.Pairs("x-oslc-tenant", "research", "authorization", "Bearer research-key"), "research", codes.OK},
+		{"header and admin token", metadata.Pairs("x-oslc-tenant", "research", "authorization", "Bearer admin-token"), "research", codes.OK},
+		{"unknown tenant", metadata.Pairs("x-oslc-tenant", "marketing"), "", codes.PermissionDenied},
+		{"header of another tenant", metadata.Pairs("x-oslc-tenant", "research", "authorization", "Bearer payments-key"), "", codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.ResolveTenant(tt.md)
+			require.Equal(t, tt.code, status.Code(err))
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRegistry_ResolveTenant_withoutHeader(t *testing.T) {
+	r, err := Parse([]byte("tenants: [{name: payments, api_keys: [payments-key]}]"), t.TempDir())
+	require.NoError(t, err)
+
+	// Without a configured header, tenants can only be identified by their API keys.
+	got, err := r.ResolveTenant(metadata.Pairs("x-oslc-tenant", "payments"))
+	require.NoError(t, err)
+	require.Empty(t, got)
+	got, err = r.ResolveTenant(metadata.Pairs("authorization", "Bearer payments-key"))
+	require.NoError(t, err)
+	require.Equal(t, "payments", got)
+}
+
+func TestRegistry_HasTenant(t *testing.T) {
+	r, err := Parse([]byte("tenants: [{name: payments}]"), t.TempDir())
+	require.NoError(t, err)
+	require.True(t, r.HasTenant("payments"))
+	require.False(t, r.HasTenant("research"))
+	require.False(t, r.HasTenant(""))
+}
+
+func TestRegistry_AllowRequest(t *testing.T) {
+	r, err := Parse([]byte(`
+tenants:
+  - name: limited
+    quota: {requests_per_second: 0.001, burst: 2}
+  - name: unlimited
+`), t.TempDir())
+	require.NoError(t, err)
+
+	require.True(t, r.AllowRequest("limited"))
+	require.True(t, r.AllowRequest("limited"))
+	require.False(t, r.AllowRequest("limited"))
+	for range 10 {
+		require.True(t, r.AllowRequest("unlimited"))
+		require.True(t, r.AllowRequest(""))
+	}
+}
+
+func TestRegistry_AllowRequests(t *testing.T) {
+	r, err := Parse([]byte(`
+tenants:
+  - name: limited
+    quota: {requests_per_second: 0.001, burst: 5}
+  - name: unlimited
+`), t.TempDir())
+	require.NoError(t, err)
+
+	require.False(t, r.AllowRequests("limited", 6))
+	require.True(t, r.AllowRequests("limited", 4))
+	require.False(t, r.AllowRequests("limited", 2))
+	require.True(t, r.AllowRequest("limited"))
+	require.False(t, r.AllowRequest("limited"))
+	require.True(t, r.AllowRequests("unlimited", 100))
+}
+
+func TestRegistry_RequestBurst(t *testing.T) {
+	r, err := Parse([]byte(`
+tenants:
+  - name: limited
+    quota: {requests_per_second: 2.5}
+  - name: unlimited
+`), t.TempDir())
+	require.NoError(t, err)
+
+	burst, ok := r.RequestBurst("limited")
+	require.True(t, ok)
+	require.Equal(t, 3, burst)
+	_, ok = r.RequestBurst("unlimited")
+	require.False(t, ok)
+	_, ok = r.RequestBurst("")
+	require.False(t, ok)
+}
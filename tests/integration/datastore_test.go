@@ -0,0 +1,524 @@
+package integration
+
+import (
+	"context"
+	"github.com/chainalysis-oss/oslc"
+	"github.com/chainalysis-oss/oslc/memory"
+	"github.com/chainalysis-oss/oslc/postgres"
+	"github.com/chainalysis-oss/oslc/sqlite"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// contractDatastore is the set of interfaces every datastore backend of the request server implements.
+type contractDatastore interface {
+	oslc.Datastore
+	oslc.CurationStore
+	oslc.WebhookStore
+	oslc.JobStore
+}
+
+// postgresDSNEnv is the environment variable holding the connection string of a PostgreSQL database to run the
+// datastore contract tests against. The tests for PostgreSQL are skipped if it is not set. The database is migrated,
+// and every table of the datastore is emptied by the tests.
+const postgresDSNEnv = "OSLC_TEST_POSTGRES_DSN"
+
+// TestImplementations_of_Datastore runs the same tests against every datastore backend, so they can be used
+// interchangeably by the request server.
+func TestImplementations_of_Datastore(t *testing.T) {
+	cases := []struct {
+		kind            string
+		createDatastore func(t *testing.T) contractDatastore
+	}{
+		{
+			kind: "postgres",
+			createDatastore: func(t *testing.T) contractDatastore {
+				dsn, ok := os.LookupEnv(postgresDSNEnv)
+				if !ok {
+					t.Skipf("%s is not set", postgresDSNEnv)
+				}
+				ctx := context.Background()
+				pool, err := postgres.NewPool(ctx, dsn)
+				require.NoError(t, err)
+				t.Cleanup(pool.Close)
+				ds, err := postgres.NewDatastore(postgres.WithPool(pool))
+				require.NoError(t, err)
+				_, err = ds.MigrateUp(ctx)
+				require.NoError(t, err)
+				_, err = pool.Exec(ctx, "TRUNCATE packages, license_overrides, webhook_subscriptions, lookup_jobs RESTART IDENTITY CASCADE")
+				require.NoError(t, err)
+				return ds
+			},
+		},
+		{
+			kind: "sqlite",
+			createDatastore: func(t *testing.T) contractDatastore {
+				ctx := context.Background()
+				db, err := sqlite.Open(ctx, filepath.Join(t.TempDir(), "oslc.db"))
+				require.NoError(t, err)
+				t.Cleanup(func() { db.Close() })
+				ds, err := sqlite.NewDatastore(sqlite.WithDB(db))
+				require.NoError(t, err)
+				_, err = ds.MigrateUp(ctx)
+				require.NoError(t, err)
+				return ds
+			},
+		},
+		{
+			kind: "memory",
+			createDatastore: func(t *testing.T) contractDatastore {
+				ds, err := memory.NewDatastore()
+				require.NoError(t, err)
+				return ds
+			},
+		},
+	}
+
+	tests := map[string]func(t *testing.T, ds contractDatastore){
+		"save and retrieve":          testSaveAndRetrieve,
+		"save overwrites":            testSaveOverwrites,
+		"retrieve versions":          testRetrieveVersions,
+		"invalidate":                 testInvalidate,
+		"search":                     testSearch,
+		"count packages":             testCountPackages,
+		"license overrides":          testLicenseOverrides,
+		"webhook subscriptions":      testWebhookSubscriptions,
+		"webhook delivery lifecycle": testWebhookDeliveryLifecycle,
+		"job lifecycle":              testJobLifecycle,
+		"concurrent enqueue":         testConcurrentEnqueue,
+		"delete finished jobs":       testDeleteFinishedJobs,
+	}
+
+	for _, c := range cases {
+		t.Run(c.kind, func(t *testing.T) {
+			for name, test := range tests {
+				t.Run(name, func(t *testing.T) {
+					test(t, c.createDatastore(t))
+				})
+			}
+		})
+	}
+}
+
+func entry(distributor, name, version, license string) oslc.Entry {
+	return oslc.Entry{
+		Name:    name,
+		Version: version,
+		License: license,
+		DistributionPoints: []oslc.DistributionPoint{{
+			Name:        name,
+			URL:         "https://example.com/" + distributor + "/" + name + "/" + version,
+			Distributor: distributor,
+		}},
+	}
+}
+
+func saveAll(t *testing.T, ds contractDatastore, entries ...oslc.Entry) {
+	t.Helper()
+	for _, e := range entries {
+		require.NoError(t, ds.Save(context.Background(), e))
+	}
+}
+
+func testSaveAndRetrieve(t *testing.T, ds contractDatastore) {
+	ctx := context.Background()
+	e := oslc.Entry{
+		Name:    "left-pad",
+		Version: "1.3.0",
+		License: "MIT OR Apache-2.0",
+		DistributionPoints: []oslc.DistributionPoint{
+			{Name: "left-pad", URL: "https://registry.npmjs.org/left-pad/-/left-pad-1.3.0.tgz", Distributor: oslc.DistributorNpm, Kind: "tarball", Checksum: "sha512:abc"},
+			{Name: "left-pad", URL: "https://www.npmjs.com/package/left-pad", Distributor: oslc.DistributorNpm},
+			{Name: "left_pad", URL: "https://pypi.org/project/left_pad", Distributor: oslc.DistributorPypi, Kind: "sdist"},
+		},
+		RawLicense:          "(MIT OR Apache-2.0)",
+		NormalizationStatus: oslc.LicenseNormalizationExpression,
+		LicenseListVersion:  "3.25.0",
+	}
+	require.NoError(t, ds.Save(ctx, e))
+
+	for _, distributor := range []string{oslc.DistributorNpm, oslc.DistributorPypi} {
+		got, err := ds.Retrieve(ctx, "left-pad", "1.3.0", distributor)
+		require.NoError(t, err)
+		require.Equal(t, e, got)
+	}
+
+	_, err := ds.Retrieve(ctx, "left-pad", "1.3.0", oslc.DistributorMaven)
+	require.ErrorIs(t, err, oslc.ErrDatastoreObjectNotFound)
+	_, err = ds.Retrieve(ctx, "left-pad", "9.9.9", oslc.DistributorNpm)
+	require.ErrorIs(t, err, oslc.ErrDatastoreObjectNotFound)
+}
+
+func testSaveOverwrites(t *testing.T, ds contractDatastore) {
+	ctx := context.Background()
+	e := entry(oslc.DistributorPypi, "requests", "2.32.3", "Apache-2.0")
+	e.DistributionPoints = append(e.DistributionPoints, oslc.DistributionPoint{Name: "requests", URL: "https://mirror.example.com/requests", Distributor: oslc.DistributorPypi})
+	require.NoError(t, ds.Save(ctx, e))
+
+	updated := entry(oslc.DistributorPypi, "requests", "2.32.3", "MIT")
+	require.NoError(t, ds.Save(ctx, updated))
+
+	got, err := ds.Retrieve(ctx, "requests", "2.32.3", oslc.DistributorPypi)
+	require.NoError(t, err)
+	require.Equal(t, updated, got)
+}
+
+func testRetrieveVersions(t *testing.T, ds contractDatastore) {
+	ctx := context.Background()
+	v1 := entry(oslc.DistributorCratesIo, "serde", "1.0.0", "MIT")
+	v2 := entry(oslc.DistributorCratesIo, "serde", "1.0.1", "MIT OR Apache-2.0")
+	saveAll(t, ds, v1, v2, entry(oslc.DistributorNpm, "serde", "1.0.0", "ISC"))
+
+	versions, err := ds.RetrieveVersions(ctx, "serde", oslc.DistributorCratesIo)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []oslc.Entry{v1, v2}, versions)
+
+	versions, err = ds.RetrieveVersions(ctx, "missing", oslc.DistributorCratesIo)
+	require.NoError(t, err)
+	require.Empty(t, versions)
+}
+
+func testInvalidate(t *testing.T, ds contractDatastore) {
+	ctx := context.Background()
+	empty := ""
+	mit := "MIT"
+	saveAll(t, ds,
+		entry(oslc.DistributorNpm, "@types/node", "20.0.0", "MIT"),
+		entry(oslc.DistributorNpm, "@types/react", "18.0.0", "MIT"),
+		entry(oslc.DistributorNpm, "react", "18.0.0", "MIT"),
+		entry(oslc.DistributorNpm, "my_pkg", "1.0.0", ""),
+		entry(oslc.DistributorNpm, "myXpkg", "1.0.0", "ISC"),
+		entry(oslc.DistributorPypi, "react", "18.0.0", "BSD-3-Clause"),
+	)
+
+	n, err := ds.Invalidate(ctx, oslc.EntryFilter{Distributor: oslc.DistributorNpm, NamePattern: "@types/*"})
+	require.NoError(t, err)
+	require.EqualValues(t, 2, n)
+
+	// Underscores are not wildcards.
+	n, err = ds.Invalidate(ctx, oslc.EntryFilter{NamePattern: "my_pkg"})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, n)
+	_, err = ds.Retrieve(ctx, "myXpkg", "1.0.0", oslc.DistributorNpm)
+	require.NoError(t, err)
+
+	n, err = ds.Invalidate(ctx, oslc.EntryFilter{License: &empty})
+	require.NoError(t, err)
+	require.EqualValues(t, 0, n)
+
+	n, err = ds.Invalidate(ctx, oslc.EntryFilter{Version: "18.0.0", License: &mit})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, n)
+	_, err = ds.Retrieve(ctx, "react", "18.0.0", oslc.DistributorNpm)
+	require.ErrorIs(t, err, oslc.ErrDatastoreObjectNotFound)
+	_, err = ds.Retrieve(ctx, "react", "18.0.0", oslc.DistributorPypi)
+	require.NoError(t, err)
+
+	n, err = ds.Invalidate(ctx, oslc.EntryFilter{})
+	require.NoError(t, err)
+	require.EqualValues(t, 2, n)
+}
+
+// names returns the coordinates of the entries, for comparing search results.
+func names(entries []oslc.StoredEntry) []string {
+	var coordinates []string
+	for _, e := range entries {
+		coordinates = append(coordinates, e.Distributor+"/"+e.Name+"@"+e.Version)
+	}
+	return coordinates
+}
+
+func testSearch(t *testing.T, ds contractDatastore) {
+	ctx := context.Background()
+	start := time.Now().Add(-time.Minute)
+	mit := "MIT"
+	multi := entry(oslc.DistributorNpm, "lodash", "4.17.21", "(MIT AND CC0-1.0) OR ISC")
+	multi.DistributionPoints = append(multi.DistributionPoints, oslc.DistributionPoint{Name: "lodash", URL: "https://example.com/lodash.tgz", Distributor: oslc.DistributorNpm, Kind: "tarball", Checksum: "sha512:abc"})
+	saveAll(t, ds,
+		entry(oslc.DistributorPypi, "requests", "2.32.3", "Apache-2.0"),
+		entry(oslc.DistributorNpm, "lodash", "4.17.20", "MIT"),
+		multi,
+		entry(oslc.DistributorNpm, "lodash-es", "4.17.21", "MIT-0"),
+		// The name differs from the others only by case, and is stored under another distributor, so the order of results
+		// does not depend on the collation of the database.
+		entry(oslc.DistributorGo, "Lodash", "v1.0.0", "MIT"),
+		entry(oslc.DistributorNpm, "my_lodash", "1.0.0", "MIT"),
+	)
+
+	all, err := ds.Search(ctx, oslc.SearchQuery{Limit: 100})
+	require.NoError(t, err)
+	require.Equal(t, []string{"go/Lodash@v1.0.0", "npm/lodash@4.17.20", "npm/lodash@4.17.21", "npm/lodash-es@4.17.21", "npm/my_lodash@1.0.0", "pypi/requests@2.32.3"}, names(all))
+	require.Equal(t, multi, all[2].Entry)
+	require.WithinRange(t, all[2].FetchedAt, start, time.Now().Add(time.Minute))
+
+	results, err := ds.Search(ctx, oslc.SearchQuery{NamePrefix: "lodash", Limit: 100})
+	require.NoError(t, err)
+	require.Equal(t, []string{"npm/lodash@4.17.20", "npm/lodash@4.17.21", "npm/lodash-es@4.17.21"}, names(results))
+
+	results, err = ds.Search(ctx, oslc.SearchQuery{NameContains: "_lo", Limit: 100})
+	require.NoError(t, err)
+	require.Equal(t, []string{"npm/my_lodash@1.0.0"}, names(results))
+
+	results, err = ds.Search(ctx, oslc.SearchQuery{License: &mit, Limit: 100})
+	require.NoError(t, err)
+	require.Equal(t, []string{"go/Lodash@v1.0.0", "npm/lodash@4.17.20", "npm/my_lodash@1.0.0"}, names(results))
+
+	results, err = ds.Search(ctx, oslc.SearchQuery{License: &mit, LicenseInExpression: true, Limit: 100})
+	require.NoError(t, err)
+	require.Equal(t, []string{"go/Lodash@v1.0.0", "npm/lodash@4.17.20", "npm/lodash@4.17.21", "npm/my_lodash@1.0.0"}, names(results))
+
+	results, err = ds.Search(ctx, oslc.SearchQuery{FetchedAfter: start, Limit: 2})
+	require.NoError(t, err)
+	require.Equal(t, []string{"go/Lodash@v1.0.0", "npm/lodash@4.17.20"}, names(results))
+	cursor := results[1].Cursor()
+	results, err = ds.Search(ctx, oslc.SearchQuery{After: &cursor, Limit: 2})
+	require.NoError(t, err)
+	require.Equal(t, []string{"npm/lodash@4.17.21", "npm/lodash-es@4.17.21"}, names(results))
+
+	results, err = ds.Search(ctx, oslc.SearchQuery{FetchedBefore: start, Limit: 100})
+	require.NoError(t, err)
+	require.Empty(t, results)
+}
+
+func testCountPackages(t *testing.T, ds contractDatastore) {
+	counts, err := ds.CountPackages(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, counts)
+
+	unrecognized := entry(oslc.DistributorNpm, "b", "1.0.0", "")
+	unrecognized.NormalizationStatus = oslc.LicenseNormalizationFailed
+	saveAll(t, ds,
+		entry(oslc.DistributorNpm, "a", "1.0.0", "MIT"),
+		entry(oslc.DistributorNpm, "a", "2.0.0", "MIT"),
+		unrecognized,
+		entry(oslc.DistributorGo, "c", "v1.0.0", "MIT"),
+	)
+	counts, err = ds.CountPackages(context.Background())
+	require.NoError(t, err)
+	require.ElementsMatch(t, []oslc.PackageCount{
+		{Distributor: oslc.DistributorNpm, License: "MIT", Count: 2},
+		{Distributor: oslc.DistributorNpm, License: "", NormalizationStatus: oslc.LicenseNormalizationFailed, Count: 1},
+		{Distributor: oslc.DistributorGo, License: "MIT", Count: 1},
+	}, counts)
+}
+
+func testLicenseOverrides(t *testing.T, ds contractDatastore) {
+	ctx := context.Background()
+	override := oslc.LicenseOverride{Distributor: oslc.DistributorPypi, Name: "requests", VersionRange: ">=2", License: "Apache-2.0", Justification: "LICENSE file", Author: "alice"}
+	first, err := ds.SetOverride(ctx, override)
+	require.NoError(t, err)
+	require.False(t, first.UpdatedAt.IsZero())
+
+	override.License = "MIT"
+	second, err := ds.SetOverride(ctx, override)
+	require.NoError(t, err)
+	other, err := ds.SetOverride(ctx, oslc.LicenseOverride{Distributor: oslc.DistributorNpm, Name: "react", VersionRange: "*", License: "MIT", Justification: "j", Author: "bob"})
+	require.NoError(t, err)
+
+	overrides, err := ds.ListOverrides(ctx, "", oslc.DistributorPypi, "requests")
+	require.NoError(t, err)
+	require.Len(t, overrides, 1)
+	require.Equal(t, "MIT", overrides[0].License)
+	require.True(t, second.UpdatedAt.Equal(overrides[0].UpdatedAt))
+
+	overrides, err = ds.ListOverrides(ctx, "", "", "")
+	require.NoError(t, err)
+	require.Len(t, overrides, 2)
+	require.Equal(t, other.Name, overrides[0].Name)
+
+	require.NoError(t, ds.DeleteOverride(ctx, "", oslc.DistributorPypi, "requests", ">=2"))
+	require.ErrorIs(t, ds.DeleteOverride(ctx, "", oslc.DistributorPypi, "requests", ">=2"), oslc.ErrDatastoreObjectNotFound)
+
+	// The overrides of tenants are kept apart from the shared overrides, even for the same package and version range.
+	tenant, err := ds.SetOverride(ctx, oslc.LicenseOverride{Tenant: "payments", Distributor: oslc.DistributorNpm, Name: "react", VersionRange: "*", License: "Apache-2.0", Justification: "j", Author: "carol"})
+	require.NoError(t, err)
+	require.Equal(t, "payments", tenant.Tenant)
+	overrides, err = ds.ListOverrides(ctx, "payments", "", "")
+	require.NoError(t, err)
+	require.Len(t, overrides, 1)
+	require.Equal(t, "Apache-2.0", overrides[0].License)
+	require.Equal(t, "payments", overrides[0].Tenant)
+	overrides, err = ds.ListOverrides(ctx, "", oslc.DistributorNpm, "react")
+	require.NoError(t, err)
+	require.Len(t, overrides, 1)
+	require.Equal(t, "MIT", overrides[0].License)
+	require.ErrorIs(t, ds.DeleteOverride(ctx, "research", oslc.DistributorNpm, "react", "*"), oslc.ErrDatastoreObjectNotFound)
+	require.NoError(t, ds.DeleteOverride(ctx, "payments", oslc.DistributorNpm, "react", "*"))
+}
+
+func testWebhookSubscriptions(t *testing.T, ds contractDatastore) {
+	ctx := context.Background()
+	created, err := ds.CreateSubscription(ctx, oslc.WebhookSubscription{URL: "https://example.com/hook", Secret: "s3cret", Distributor: oslc.DistributorNpm, PackagePattern: "@types/*", LicenseCategories: []string{"copyleft"}})
+	require.NoError(t, err)
+	require.NotZero(t, created.ID)
+	plain, err := ds.CreateSubscription(ctx, oslc.WebhookSubscription{URL: "https://example.com/other", Secret: "s"})
+	require.NoError(t, err)
+	require.Equal(t, []string{}, plain.LicenseCategories)
+
+	subscriptions, err := ds.ListSubscriptions(ctx)
+	require.NoError(t, err)
+	require.Len(t, subscriptions, 2)
+	require.Equal(t, created.URL, subscriptions[0].URL)
+	require.Equal(t, created.LicenseCategories, subscriptions[0].LicenseCategories)
+	require.Equal(t, []string{}, subscriptions[1].LicenseCategories)
+	require.True(t, created.CreatedAt.Equal(subscriptions[0].CreatedAt))
+
+	require.NoError(t, ds.DeleteSubscription(ctx, created.ID))
+	require.ErrorIs(t, ds.DeleteSubscription(ctx, created.ID), oslc.ErrDatastoreObjectNotFound)
+}
+
+func testWebhookDeliveryLifecycle(t *testing.T, ds contractDatastore) {
+	ctx := context.Background()
+	subscription, err := ds.CreateSubscription(ctx, oslc.WebhookSubscription{URL: "https://example.com/hook", Secret: "s3cret"})
+	require.NoError(t, err)
+	event := oslc.LicenseChangeEvent{Distributor: oslc.DistributorNpm, Name: "react", Version: "19.0.0", License: "MIT"}
+	require.NoError(t, ds.EnqueueDelivery(ctx, subscription.ID, event))
+	require.NoError(t, ds.EnqueueDelivery(ctx, subscription.ID, event))
+
+	claimed, err := ds.ClaimDeliveries(ctx, 1, time.Hour)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	require.Equal(t, subscription.URL, claimed[0].URL)
+	require.Equal(t, subscription.Secret, claimed[0].Secret)
+	require.Equal(t, 1, claimed[0].Attempts)
+	require.Equal(t, event.Name, claimed[0].Event.Name)
+
+	second, err := ds.ClaimDeliveries(ctx, 10, time.Hour)
+	require.NoError(t, err)
+	require.Len(t, second, 1)
+	require.NotEqual(t, claimed[0].ID, second[0].ID)
+
+	// Both deliveries are leased, so none are due.
+	none, err := ds.ClaimDeliveries(ctx, 10, time.Hour)
+	require.NoError(t, err)
+	require.Empty(t, none)
+
+	require.NoError(t, ds.CompleteDelivery(ctx, claimed[0].ID))
+	require.NoError(t, ds.RetryDelivery(ctx, second[0].ID, time.Now().Add(-time.Second), "503"))
+	retried, err := ds.ClaimDeliveries(ctx, 10, time.Hour)
+	require.NoError(t, err)
+	require.Len(t, retried, 1)
+	require.Equal(t, 2, retried[0].Attempts)
+
+	require.NoError(t, ds.FailDelivery(ctx, retried[0].ID, "gone"))
+	require.NoError(t, ds.RetryDelivery(ctx, retried[0].ID, time.Now().Add(-time.Second), "503"))
+	failed, err := ds.ClaimDeliveries(ctx, 10, time.Hour)
+	require.NoError(t, err)
+	require.Empty(t, failed)
+}
+
+func testJobLifecycle(t *testing.T, ds contractDatastore) {
+	ctx := context.Background()
+	request := oslc.JobRequest{Distributor: oslc.DistributorGo, Name: "github.com/aws/aws-sdk-go", Version: "v1.55.0"}
+	job, err := ds.EnqueueJob(ctx, request)
+	require.NoError(t, err)
+	require.Equal(t, request, job.JobRequest)
+	require.Equal(t, oslc.JobPending, job.State)
+	require.Zero(t, job.Attempts)
+
+	// An active job is shared by every request for the same package.
+	same, err := ds.EnqueueJob(ctx, request)
+	require.NoError(t, err)
+	require.Equal(t, job.ID, same.ID)
+	other, err := ds.EnqueueJob(ctx, oslc.JobRequest{Distributor: oslc.DistributorGo, Name: "github.com/aws/aws-sdk-go"})
+	require.NoError(t, err)
+	require.NotEqual(t, job.ID, other.ID)
+
+	claimed, err := ds.ClaimJobs(ctx, 1, time.Hour)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	require.Equal(t, job.ID, claimed[0].ID)
+	require.Equal(t, oslc.JobRunning, claimed[0].State)
+	require.Equal(t, 1, claimed[0].Attempts)
+
+	// A running job is still shared, and is not claimed again while it is leased.
+	same, err = ds.EnqueueJob(ctx, request)
+	require.NoError(t, err)
+	require.Equal(t, job.ID, same.ID)
+	second, err := ds.ClaimJobs(ctx, 10, time.Hour)
+	require.NoError(t, err)
+	require.Len(t, second, 1)
+	require.Equal(t, other.ID, second[0].ID)
+	none, err := ds.ClaimJobs(ctx, 10, time.Hour)
+	require.NoError(t, err)
+	require.Empty(t, none)
+
+	require.NoError(t, ds.RetryJob(ctx, job.ID, time.Now().Add(-time.Second), "timeout"))
+	retried, err := ds.ClaimJobs(ctx, 10, time.Hour)
+	require.NoError(t, err)
+	require.Len(t, retried, 1)
+	require.Equal(t, 2, retried[0].Attempts)
+	require.Equal(t, "timeout", retried[0].LastError)
+
+	require.NoError(t, ds.CompleteJob(ctx, job.ID, []byte("response")))
+	require.NoError(t, ds.FailJob(ctx, other.ID, []byte("status"), "not found"))
+	succeeded, err := ds.GetJob(ctx, job.ID)
+	require.NoError(t, err)
+	require.Equal(t, oslc.JobSucceeded, succeeded.State)
+	require.Equal(t, []byte("response"), succeeded.Result)
+	failed, err := ds.GetJob(ctx, other.ID)
+	require.NoError(t, err)
+	require.Equal(t, oslc.JobFailed, failed.State)
+	require.Equal(t, []byte("status"), failed.Result)
+	require.Equal(t, "not found", failed.LastError)
+
+	// Finished jobs are never due again, and a new request for their package creates a new job.
+	require.NoError(t, ds.RetryJob(ctx, other.ID, time.Now().Add(-time.Second), "timeout"))
+	none, err = ds.ClaimJobs(ctx, 10, time.Hour)
+	require.NoError(t, err)
+	require.Empty(t, none)
+	again, err := ds.EnqueueJob(ctx, request)
+	require.NoError(t, err)
+	require.NotEqual(t, job.ID, again.ID)
+
+	_, err = ds.GetJob(ctx, 1000)
+	require.ErrorIs(t, err, oslc.ErrDatastoreObjectNotFound)
+}
+
+func testConcurrentEnqueue(t *testing.T, ds contractDatastore) {
+	request := oslc.JobRequest{Distributor: oslc.DistributorGo, Name: "github.com/aws/aws-sdk-go", Version: "v1.55.0"}
+	jobs := make([]oslc.Job, 8)
+	errs := make([]error, len(jobs))
+	var wg sync.WaitGroup
+	for i := range jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			jobs[i], errs[i] = ds.EnqueueJob(context.Background(), request)
+		}()
+	}
+	wg.Wait()
+
+	// Every concurrent request for the same package shares a single job.
+	for i := range jobs {
+		require.NoError(t, errs[i])
+		require.Equal(t, jobs[0].ID, jobs[i].ID)
+	}
+}
+
+func testDeleteFinishedJobs(t *testing.T, ds contractDatastore) {
+	ctx := context.Background()
+	finished, err := ds.EnqueueJob(ctx, oslc.JobRequest{Distributor: oslc.DistributorNpm, Name: "react"})
+	require.NoError(t, err)
+	_, err = ds.ClaimJobs(ctx, 1, time.Hour)
+	require.NoError(t, err)
+	require.NoError(t, ds.CompleteJob(ctx, finished.ID, []byte("response")))
+	pending, err := ds.EnqueueJob(ctx, oslc.JobRequest{Distributor: oslc.DistributorNpm, Name: "vue"})
+	require.NoError(t, err)
+
+	n, err := ds.DeleteFinishedJobs(ctx, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.Zero(t, n)
+	n, err = ds.DeleteFinishedJobs(ctx, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+
+	_, err = ds.GetJob(ctx, finished.ID)
+	require.ErrorIs(t, err, oslc.ErrDatastoreObjectNotFound)
+	_, err = ds.GetJob(ctx, pending.ID)
+	require.NoError(t, err)
+}
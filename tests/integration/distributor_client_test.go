@@ -1,6 +1,7 @@
 package integration
 
 import (
+	"context"
 	"github.com/chainalysis-oss/oslc"
 	"github.com/chainalysis-oss/oslc/cratesio"
 	"github.com/chainalysis-oss/oslc/goproxy"
@@ -108,22 +109,22 @@ func TestImplementations_of_DistributorClient(t *testing.T) {
 			require.Len(t, tc.iv.packageAndVersionNotFound, 2)
 			client := tc.createDistributorClient(t)
 			t.Run("version_not_found", func(t *testing.T) {
-				resp, err := client.GetPackageVersion(tc.iv.versionNotFound[0], tc.iv.versionNotFound[1])
+				resp, err := client.GetPackageVersion(context.Background(), tc.iv.versionNotFound[0], tc.iv.versionNotFound[1])
 				require.Empty(t, resp)
 				require.ErrorIs(t, err, oslc.ErrVersionNotFound)
 			})
 			t.Run("package_not_found", func(t *testing.T) {
-				resp, err := client.GetPackageVersion(tc.iv.packageNotFound[0], tc.iv.packageNotFound[1])
+				resp, err := client.GetPackageVersion(context.Background(), tc.iv.packageNotFound[0], tc.iv.packageNotFound[1])
 				require.Empty(t, resp)
 				require.ErrorIs(t, err, oslc.ErrNoSuchPackage)
 			})
 			t.Run("package_and_version_not_found", func(t *testing.T) {
-				resp, err := client.GetPackageVersion(tc.iv.packageAndVersionNotFound[0], tc.iv.packageAndVersionNotFound[1])
+				resp, err := client.GetPackageVersion(context.Background(), tc.iv.packageAndVersionNotFound[0], tc.iv.packageAndVersionNotFound[1])
 				require.Empty(t, resp)
 				require.ErrorIs(t, err, oslc.ErrNoSuchPackage)
 			})
 			t.Run("package_is_empty", func(t *testing.T) {
-				resp, err := client.GetPackageVersion("", "")
+				resp, err := client.GetPackageVersion(context.Background(), "", "")
 				require.Empty(t, resp)
 				require.ErrorIs(t, err, oslc.ErrNoSuchPackage)
 			})
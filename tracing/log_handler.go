@@ -0,0 +1,51 @@
+package tracing
+
+import (
+	"context"
+	"go.opentelemetry.io/otel/trace"
+	"log/slog"
+)
+
+// Compile time check to ensure LogHandler implements [slog.Handler].
+var _ slog.Handler = (*LogHandler)(nil)
+
+// LogHandler is a [slog.Handler] that adds the trace and span IDs of the span in the record's context to every record
+// before passing it on to the wrapped handler. Records logged without a context, or with a context that carries no
+// valid span, are passed on unchanged.
+type LogHandler struct {
+	handler slog.Handler
+}
+
+// NewLogHandler returns a LogHandler that wraps the provided handler.
+func NewLogHandler(handler slog.Handler) *LogHandler {
+	return &LogHandler{
+		handler: handler,
+	}
+}
+
+// Enabled reports whether the wrapped handler handles records at the given level.
+func (h *LogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler.Enabled(ctx, level)
+}
+
+// Handle adds the trace_id and span_id attributes to the record and passes it on to the wrapped handler.
+func (h *LogHandler) Handle(ctx context.Context, record slog.Record) error {
+	sc := trace.SpanContextFromContext(ctx)
+	if sc.IsValid() {
+		record.AddAttrs(
+			slog.String("trace_id", sc.TraceID().String()),
+			slog.String("span_id", sc.SpanID().String()),
+		)
+	}
+	return h.handler.Handle(ctx, record)
+}
+
+// WithAttrs returns a new LogHandler wrapping the result of the wrapped handler's WithAttrs.
+func (h *LogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return NewLogHandler(h.handler.WithAttrs(attrs))
+}
+
+// WithGroup returns a new LogHandler wrapping the result of the wrapped handler's WithGroup.
+func (h *LogHandler) WithGroup(name string) slog.Handler {
+	return NewLogHandler(h.handler.WithGroup(name))
+}
@@ -0,0 +1,74 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	"log/slog"
+	"testing"
+)
+
+func newTestSpanContext(t *testing.T) trace.SpanContext {
+	t.Helper()
+	traceID, err := trace.TraceIDFromHex("0102030405060708090a0b0c0d0e0f10")
+	require.NoError(t, err)
+	spanID, err := trace.SpanIDFromHex("0102030405060708")
+	require.NoError(t, err)
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+}
+
+func TestLogHandler_Handle(t *testing.T) {
+	var out bytes.Buffer
+	logger := slog.New(NewLogHandler(slog.NewJSONHandler(&out, nil)))
+	ctx := trace.ContextWithSpanContext(context.Background(), newTestSpanContext(t))
+
+	logger.InfoContext(ctx, "test")
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &record))
+	require.Equal(t, "0102030405060708090a0b0c0d0e0f10", record["trace_id"])
+	require.Equal(t, "0102030405060708", record["span_id"])
+}
+
+func TestLogHandler_Handle_noSpan(t *testing.T) {
+	var out bytes.Buffer
+	logger := slog.New(NewLogHandler(slog.NewJSONHandler(&out, nil)))
+
+	logger.InfoContext(context.Background(), "test")
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &record))
+	require.NotContains(t, record, "trace_id")
+	require.NotContains(t, record, "span_id")
+}
+
+func TestLogHandler_Enabled(t *testing.T) {
+	h := NewLogHandler(slog.NewJSONHandler(&bytes.Buffer{}, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	require.False(t, h.Enabled(context.Background(), slog.LevelInfo))
+	require.True(t, h.Enabled(context.Background(), slog.LevelError))
+}
+
+func TestLogHandler_WithAttrs(t *testing.T) {
+	var out bytes.Buffer
+	logger := slog.New(NewLogHandler(slog.NewJSONHandler(&out, nil))).With(slog.String("service", "test"))
+	require.IsType(t, &LogHandler{}, logger.Handler())
+
+	logger.InfoContext(trace.ContextWithSpanContext(context.Background(), newTestSpanContext(t)), "test")
+	require.Contains(t, out.String(), `"service":"test"`)
+	require.Contains(t, out.String(), `"trace_id":"0102030405060708090a0b0c0d0e0f10"`)
+}
+
+func TestLogHandler_WithGroup(t *testing.T) {
+	var out bytes.Buffer
+	logger := slog.New(NewLogHandler(slog.NewJSONHandler(&out, nil))).WithGroup("group")
+	require.IsType(t, &LogHandler{}, logger.Handler())
+
+	logger.InfoContext(context.Background(), "test", slog.String("key", "value"))
+	require.Contains(t, out.String(), `"group":{"key":"value"}`)
+}
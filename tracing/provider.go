@@ -0,0 +1,108 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"log/slog"
+)
+
+// The following constants are the exporters supported by [NewProvider].
+const (
+	// ExporterNone disables tracing. Spans are still created, but they are never recorded or exported.
+	ExporterNone = "none"
+	// ExporterStdout writes spans as JSON to the configured writer. This is mostly useful for local development.
+	ExporterStdout = "stdout"
+	// ExporterOTLP sends spans to an OpenTelemetry collector using the OTLP protocol over gRPC.
+	ExporterOTLP = "otlp"
+)
+
+// ErrUnknownExporter is returned by [NewProvider] when the configured exporter is not supported.
+var ErrUnknownExporter = errors.New("unknown exporter")
+
+// Provider wraps an OpenTelemetry [trace.TracerProvider] together with the exporter it sends spans to.
+// It should never be created directly, use [NewProvider] instead.
+type Provider struct {
+	options        *providerOptions
+	tracerProvider trace.TracerProvider
+	shutdown       func(ctx context.Context) error
+}
+
+// NewProvider creates a new Provider using the exporter selected through the [WithExporter] option.
+func NewProvider(ctx context.Context, options ...ProviderOption) (*Provider, error) {
+	opts := defaultProviderOptions
+	for _, opt := range globalProviderOptions {
+		opt.apply(&opts)
+	}
+	for _, opt := range options {
+		opt.apply(&opts)
+	}
+
+	p := &Provider{
+		options: &opts,
+	}
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch opts.Exporter {
+	case ExporterNone:
+		p.tracerProvider = noop.NewTracerProvider()
+		p.shutdown = func(context.Context) error { return nil }
+		return p, nil
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(opts.Writer))
+	case ExporterOTLP:
+		grpcOpts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(opts.OTLPEndpoint)}
+		if opts.OTLPInsecure {
+			grpcOpts = append(grpcOpts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err = otlptracegrpc.New(ctx, grpcOpts...)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownExporter, opts.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s exporter: %w", opts.Exporter, err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(opts.ServiceName),
+		semconv.ServiceVersion(opts.ServiceVersion),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create resource: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+	)
+	p.tracerProvider = tp
+	p.shutdown = tp.Shutdown
+
+	opts.Logger.Info("tracing enabled", slog.String("exporter", opts.Exporter), slog.Float64("sample_ratio", opts.SampleRatio))
+	return p, nil
+}
+
+// TracerProvider returns the [trace.TracerProvider] that spans should be created with.
+func (p *Provider) TracerProvider() trace.TracerProvider {
+	return p.tracerProvider
+}
+
+// Shutdown flushes any spans that have not yet been exported and stops the exporter.
+func (p *Provider) Shutdown(ctx context.Context) error {
+	p.options.Logger.Info("stopping tracing provider")
+	err := p.shutdown(ctx)
+	if err != nil {
+		p.options.Logger.Error("failed to stop tracing provider", slog.String("error", err.Error()))
+	}
+	return err
+}
@@ -0,0 +1,108 @@
+package tracing
+
+import (
+	"io"
+	"log/slog"
+	"os"
+)
+
+type providerOptions struct {
+	Logger         *slog.Logger
+	Exporter       string
+	OTLPEndpoint   string
+	OTLPInsecure   bool
+	Writer         io.Writer
+	ServiceName    string
+	ServiceVersion string
+	// SampleRatio is the fraction of root spans that are sampled. Child spans follow the decision of their parent.
+	SampleRatio float64
+}
+
+var defaultProviderOptions = providerOptions{
+	Logger:       slog.Default(),
+	Exporter:     ExporterNone,
+	OTLPEndpoint: "localhost:4317",
+	Writer:       os.Stdout,
+	ServiceName:  "oslc-request-server",
+	SampleRatio:  1,
+}
+
+var globalProviderOptions []ProviderOption
+
+// ProviderOption is an option for configuring a Provider.
+type ProviderOption interface {
+	apply(*providerOptions)
+}
+
+// funcProviderOption is a ProviderOption that calls a function.
+// It is used to wrap a function, so it satisfies the ProviderOption interface.
+type funcProviderOption struct {
+	f func(*providerOptions)
+}
+
+func (fdo *funcProviderOption) apply(opts *providerOptions) {
+	fdo.f(opts)
+}
+
+func newFuncProviderOption(f func(*providerOptions)) *funcProviderOption {
+	return &funcProviderOption{
+		f: f,
+	}
+}
+
+// WithLogger returns a ProviderOption that uses the provided logger.
+func WithLogger(logger *slog.Logger) ProviderOption {
+	return newFuncProviderOption(func(opts *providerOptions) {
+		opts.Logger = logger
+	})
+}
+
+// WithExporter returns a ProviderOption that selects the exporter spans are sent to. Valid values are
+// [ExporterNone], [ExporterStdout] and [ExporterOTLP].
+func WithExporter(exporter string) ProviderOption {
+	return newFuncProviderOption(func(opts *providerOptions) {
+		opts.Exporter = exporter
+	})
+}
+
+// WithOTLPEndpoint returns a ProviderOption that sets the host:port of the OTLP collector.
+func WithOTLPEndpoint(endpoint string) ProviderOption {
+	return newFuncProviderOption(func(opts *providerOptions) {
+		opts.OTLPEndpoint = endpoint
+	})
+}
+
+// WithOTLPInsecure returns a ProviderOption that disables TLS when connecting to the OTLP collector.
+func WithOTLPInsecure(insecure bool) ProviderOption {
+	return newFuncProviderOption(func(opts *providerOptions) {
+		opts.OTLPInsecure = insecure
+	})
+}
+
+// WithWriter returns a ProviderOption that sets the writer used by the stdout exporter.
+func WithWriter(w io.Writer) ProviderOption {
+	return newFuncProviderOption(func(opts *providerOptions) {
+		opts.Writer = w
+	})
+}
+
+// WithServiceName returns a ProviderOption that sets the service name reported with every span.
+func WithServiceName(name string) ProviderOption {
+	return newFuncProviderOption(func(opts *providerOptions) {
+		opts.ServiceName = name
+	})
+}
+
+// WithServiceVersion returns a ProviderOption that sets the service version reported with every span.
+func WithServiceVersion(version string) ProviderOption {
+	return newFuncProviderOption(func(opts *providerOptions) {
+		opts.ServiceVersion = version
+	})
+}
+
+// WithSampleRatio returns a ProviderOption that sets the fraction of traces that are sampled.
+func WithSampleRatio(ratio float64) ProviderOption {
+	return newFuncProviderOption(func(opts *providerOptions) {
+		opts.SampleRatio = ratio
+	})
+}
@@ -0,0 +1,75 @@
+package tracing
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"os"
+	"testing"
+)
+
+func TestFuncProviderOption_apply(t *testing.T) {
+	opts := providerOptions{}
+	fdo := newFuncProviderOption(func(o *providerOptions) {
+		o.Logger = slog.Default()
+	})
+	fdo.apply(&opts)
+	require.Equal(t, slog.Default(), opts.Logger)
+}
+
+func TestNewFuncProviderOption(t *testing.T) {
+	fdo := newFuncProviderOption(func(o *providerOptions) {
+		o.Logger = slog.Default()
+	})
+	require.NotNil(t, fdo)
+}
+
+func TestWithLogger(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	opts := providerOptions{}
+	WithLogger(logger).apply(&opts)
+	require.Equal(t, logger, opts.Logger)
+}
+
+func TestWithExporter(t *testing.T) {
+	opts := providerOptions{}
+	WithExporter(ExporterOTLP).apply(&opts)
+	require.Equal(t, ExporterOTLP, opts.Exporter)
+}
+
+func TestWithOTLPEndpoint(t *testing.T) {
+	opts := providerOptions{}
+	WithOTLPEndpoint("collector:4317").apply(&opts)
+	require.Equal(t, "collector:4317", opts.OTLPEndpoint)
+}
+
+func TestWithOTLPInsecure(t *testing.T) {
+	opts := providerOptions{}
+	WithOTLPInsecure(true).apply(&opts)
+	require.True(t, opts.OTLPInsecure)
+}
+
+func TestWithWriter(t *testing.T) {
+	var w bytes.Buffer
+	opts := providerOptions{}
+	WithWriter(&w).apply(&opts)
+	require.Equal(t, &w, opts.Writer)
+}
+
+func TestWithServiceName(t *testing.T) {
+	opts := providerOptions{}
+	WithServiceName("test").apply(&opts)
+	require.Equal(t, "test", opts.ServiceName)
+}
+
+func TestWithServiceVersion(t *testing.T) {
+	opts := providerOptions{}
+	WithServiceVersion("1.0.0").apply(&opts)
+	require.Equal(t, "1.0.0", opts.ServiceVersion)
+}
+
+func TestWithSampleRatio(t *testing.T) {
+	opts := providerOptions{}
+	WithSampleRatio(0.5).apply(&opts)
+	require.Equal(t, 0.5, opts.SampleRatio)
+}
@@ -0,0 +1,82 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+	"io"
+	"log/slog"
+	"testing"
+)
+
+func TestNewProvider_none(t *testing.T) {
+	p, err := NewProvider(context.Background())
+	require.NoError(t, err)
+	require.IsType(t, noop.NewTracerProvider(), p.TracerProvider())
+	require.NoError(t, p.Shutdown(context.Background()))
+}
+
+func TestNewProvider_stdout(t *testing.T) {
+	var out bytes.Buffer
+	p, err := NewProvider(context.Background(),
+		WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		WithExporter(ExporterStdout),
+		WithWriter(&out),
+		WithServiceVersion("1.2.3"),
+	)
+	require.NoError(t, err)
+
+	_, span := p.TracerProvider().Tracer("test").Start(context.Background(), "test-span")
+	span.End()
+	require.NoError(t, p.Shutdown(context.Background()))
+	require.Contains(t, out.String(), "test-span")
+	require.Contains(t, out.String(), "1.2.3")
+}
+
+func TestNewProvider_otlp(t *testing.T) {
+	// The exporter connects lazily, so creating the provider succeeds without a collector being available.
+	p, err := NewProvider(context.Background(),
+		WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		WithExporter(ExporterOTLP),
+		WithOTLPEndpoint("localhost:0"),
+		WithOTLPInsecure(true),
+	)
+	require.NoError(t, err)
+	require.NotNil(t, p.TracerProvider())
+}
+
+func TestNewProvider_unknownExporter(t *testing.T) {
+	_, err := NewProvider(context.Background(), WithExporter("invalid"))
+	require.ErrorIs(t, err, ErrUnknownExporter)
+}
+
+func TestNewProvider_sampleRatioZero(t *testing.T) {
+	var out bytes.Buffer
+	p, err := NewProvider(context.Background(),
+		WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		WithExporter(ExporterStdout),
+		WithWriter(&out),
+		WithSampleRatio(0),
+	)
+	require.NoError(t, err)
+
+	_, span := p.TracerProvider().Tracer("test").Start(context.Background(), "test-span")
+	require.False(t, span.SpanContext().IsSampled())
+	span.End()
+	require.NoError(t, p.Shutdown(context.Background()))
+	require.Empty(t, out.String())
+}
+
+func TestNewProvider_globalOptionsAreApplied(t *testing.T) {
+	optCopy := make([]ProviderOption, len(globalProviderOptions))
+	copy(optCopy, globalProviderOptions)
+	defer func() {
+		globalProviderOptions = optCopy
+	}()
+
+	globalProviderOptions = append(globalProviderOptions, WithServiceName("test"))
+	p, err := NewProvider(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "test", p.options.ServiceName)
+}
@@ -0,0 +1,50 @@
+package versions
+
+import (
+	"fmt"
+	"strings"
+)
+
+// cargoOperators are the operators of Cargo's version requirement syntax. Longer operators come first, so they are
+// matched before their prefixes.
+var cargoOperators = []string{">=", "<=", ">", "<", "=", "^", "~"}
+
+// parseCargoRequirement parses a Cargo version requirement, such as "1.2", "~1.2.3" or ">=0.3, <0.4". As in
+// Cargo.toml, a version without an operator is a caret requirement.
+func parseCargoRequirement(s string) (matcher, error) {
+	var set comparatorSet
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		op := ""
+		for _, candidate := range cargoOperators {
+			if strings.HasPrefix(part, candidate) {
+				op = candidate
+				part = strings.TrimSpace(strings.TrimPrefix(part, candidate))
+				break
+			}
+		}
+		if part == "" {
+			return nil, fmt.Errorf("missing version")
+		}
+		p, err := parsePartial(part)
+		if err != nil {
+			return nil, err
+		}
+		if op == "" {
+			op = "^"
+		}
+		comparators, err := desugar(op, p)
+		if err != nil {
+			return nil, err
+		}
+		set = append(set, comparators...)
+	}
+	return semverMatcher{sets: []comparatorSet{set}, parse: parseSemver}, nil
+}
+
+// cargoIsExact reports whether s is a single version. Unlike in Cargo.toml, a complete version without an operator
+// refers to that version only, as it does in the crates.io API.
+func cargoIsExact(s string) bool {
+	_, err := parseSemver(s)
+	return err == nil
+}
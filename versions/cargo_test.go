@@ -0,0 +1,51 @@
+package versions
+
+import (
+	"github.com/chainalysis-oss/oslc"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParseCargoRequirement_matches(t *testing.T) {
+	cases := []struct {
+		r       string
+		version string
+		want    bool
+	}{
+		{"1.2", "1.9.0", true},
+		{"1.2", "1.1.0", false},
+		{"1.2", "2.0.0", false},
+		{"0.3", "0.3.5", true},
+		{"0.3", "0.4.0", false},
+		{"^1.2.3", "1.2.3", true},
+		{"~1.2.3", "1.2.9", true},
+		{"~1.2.3", "1.3.0", false},
+		{"=1.2.3", "1.2.3", true},
+		{"=1.2.3", "1.2.4", false},
+		{"=1.2", "1.2.7", true},
+		{">=0.3, <0.4", "0.3.9", true},
+		{">=0.3, <0.4", "0.4.0", false},
+		{">=0.3, <0.4", "0.2.9", false},
+		{"*", "5.0.0", true},
+		{"1.*", "1.4.0", true},
+		{"1.*", "2.0.0", false},
+		{"1.2", "1.3.0-alpha.1", false},
+		{"1.2.3", "v1.2.3", false},
+	}
+	for _, tt := range cases {
+		t.Run(tt.r+"_"+tt.version, func(t *testing.T) {
+			c, err := ParseConstraint(oslc.DistributorCratesIo, tt.r)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, c.Matches(tt.version))
+		})
+	}
+}
+
+func TestParseCargoRequirement_invalid(t *testing.T) {
+	for _, r := range []string{"", ">=", "1.2,", "a.b", "1 || 2"} {
+		t.Run(r, func(t *testing.T) {
+			_, err := ParseConstraint(oslc.DistributorCratesIo, r)
+			require.ErrorIs(t, err, ErrInvalidConstraint)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package versions
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// goOperators are the comparison operators accepted in Go module version queries.
+var goOperators = []string{">=", "<=", ">", "<", "="}
+
+// parseGoQuery parses a Go module version query, as accepted by "go get". A query is either "latest", a version
+// prefix such as "v1" or "v1.2", or a comparison such as ">=v1.2.0". Unlike "go get", several comma-separated
+// comparisons may be combined, as in ">=v0.3.0, <v0.4.0".
+func parseGoQuery(s string) (matcher, error) {
+	m := semverMatcher{parse: parseGoVersion}
+	if s == "latest" {
+		m.sets = []comparatorSet{nil}
+		return m, nil
+	}
+
+	var set comparatorSet
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		op := ""
+		for _, candidate := range goOperators {
+			if strings.HasPrefix(part, candidate) {
+				op = candidate
+				part = strings.TrimSpace(strings.TrimPrefix(part, candidate))
+				break
+			}
+		}
+		if !strings.HasPrefix(part, "v") {
+			return nil, fmt.Errorf("version %q does not start with v", part)
+		}
+		p, err := parsePartial(strings.TrimPrefix(part, "v"))
+		if err != nil {
+			return nil, err
+		}
+		if p.parts == 0 {
+			return nil, fmt.Errorf("version %q is missing a major version", part)
+		}
+		if op == "" && p.parts == 3 {
+			set = append(set, comparator{op: "=", version: p.floor()})
+			continue
+		}
+		if op == "" {
+			// An incomplete version is a prefix query, matching the versions it is a prefix of, including
+			// pre-releases: v1.2 matches v1.2.0-rc.1.
+			lo := p.floor()
+			lo.pre = preZero
+			hi := nextMajor(p.major)
+			if p.parts == 2 {
+				hi = nextMinor(p.major, p.minor)
+			}
+			set = append(set, comparator{op: ">=", version: lo}, comparator{op: "<", version: hi})
+			continue
+		}
+		// In comparisons, Go completes partial versions with zeros, so ">v1.2" is the same as ">v1.2.0".
+		set = append(set, comparator{op: op, version: p.floor()})
+	}
+	m.sets = []comparatorSet{set}
+	return m, nil
+}
+
+// parseGoVersion parses a Go module version, which is a semantic version with a leading "v".
+func parseGoVersion(s string) (semver, error) {
+	if !strings.HasPrefix(s, "v") {
+		return semver{}, fmt.Errorf("version %q does not start with v", s)
+	}
+	return parseSemver(s[1:])
+}
+
+// goIsExact reports whether s is a complete module version, including pseudo-versions and +incompatible versions.
+func goIsExact(s string) bool {
+	_, err := parseGoVersion(s)
+	return err == nil
+}
+
+// goCompatible reports whether version may be a version of the module with the provided path, following semantic
+// import versioning: major versions 2 and above must be reflected in a /vN suffix of the module path (or a .vN suffix
+// for gopkg.in), while paths without a suffix only have v0 and v1 versions, plus +incompatible versions of modules
+// that predate Go modules.
+func goCompatible(path, version string) bool {
+	v, err := parseGoVersion(version)
+	if err != nil {
+		return false
+	}
+	major, ok := goPathMajor(path)
+	if !ok {
+		return v.major <= 1 || v.build == "incompatible"
+	}
+	if strings.HasPrefix(path, "gopkg.in/") && major <= 1 {
+		return v.major <= 1 && v.build != "incompatible"
+	}
+	return v.major == major && v.build != "incompatible"
+}
+
+// goPathMajor returns the major version encoded in the suffix of a module path, if any.
+func goPathMajor(path string) (uint64, bool) {
+	sep := "/"
+	if strings.HasPrefix(path, "gopkg.in/") {
+		sep = "."
+	}
+	i := strings.LastIndex(path, sep)
+	if i < 0 || !strings.HasPrefix(path[i+1:], "v") {
+		return 0, false
+	}
+	digits := path[i+2:]
+	if digits == "" || (digits[0] == '0' && digits != "0") {
+		return 0, false
+	}
+	major, err := strconv.ParseUint(digits, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	if sep == "/" && major < 2 {
+		return 0, false
+	}
+	return major, true
+}
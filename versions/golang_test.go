@@ -0,0 +1,75 @@
+package versions
+
+import (
+	"github.com/chainalysis-oss/oslc"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParseGoQuery_matches(t *testing.T) {
+	cases := []struct {
+		q       string
+		version string
+		want    bool
+	}{
+		{"latest", "v1.2.3", true},
+		{"v1", "v1.9.0", true},
+		{"v1", "v2.0.0", false},
+		{"v1.2", "v1.2.9", true},
+		{"v1.2", "v1.3.0", false},
+		{"v1.2.3", "v1.2.3", true},
+		{"v1.2.3", "v1.2.4", false},
+		{">=v1.2.0", "v1.2.0", true},
+		{">v1.2", "v1.2.0", false},
+		{">v1.2", "v1.2.1", true},
+		{"<v1.2.3", "v1.2.2", true},
+		{">=v0.3.0, <v0.4.0", "v0.3.7", true},
+		{">=v0.3.0, <v0.4.0", "v0.4.0", false},
+		{"v1", "1.0.0", false},
+		{"v1", "v1.3.0-rc.1", false},
+		{"v1.3.0-rc.1", "v1.3.0-rc.1", true},
+	}
+	for _, tt := range cases {
+		t.Run(tt.q+"_"+tt.version, func(t *testing.T) {
+			c, err := ParseConstraint(oslc.DistributorGo, tt.q)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, c.Matches(tt.version))
+		})
+	}
+}
+
+func TestParseGoQuery_invalid(t *testing.T) {
+	for _, q := range []string{"", "1.2", ">=1.2.0", "v", "vx", "master"} {
+		t.Run(q, func(t *testing.T) {
+			_, err := ParseConstraint(oslc.DistributorGo, q)
+			require.ErrorIs(t, err, ErrInvalidConstraint)
+		})
+	}
+}
+
+func TestGoCompatible(t *testing.T) {
+	cases := []struct {
+		path    string
+		version string
+		want    bool
+	}{
+		{"github.com/foo/bar", "v0.1.0", true},
+		{"github.com/foo/bar", "v1.5.0", true},
+		{"github.com/foo/bar", "v2.0.0", false},
+		{"github.com/foo/bar", "v2.0.0+incompatible", true},
+		{"github.com/foo/bar/v2", "v2.3.0", true},
+		{"github.com/foo/bar/v2", "v1.0.0", false},
+		{"github.com/foo/bar/v2", "v3.0.0", false},
+		{"github.com/foo/bar/v2", "v2.0.0+incompatible", false},
+		{"github.com/foo/bar/v1", "v1.0.0", true},
+		{"gopkg.in/yaml.v3", "v3.0.1", true},
+		{"gopkg.in/yaml.v3", "v2.4.0", false},
+		{"gopkg.in/yaml.v1", "v1.0.0", true},
+		{"github.com/foo/bar", "1.0.0", false},
+	}
+	for _, tt := range cases {
+		t.Run(tt.path+"@"+tt.version, func(t *testing.T) {
+			require.Equal(t, tt.want, goCompatible(tt.path, tt.version))
+		})
+	}
+}
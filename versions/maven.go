@@ -0,0 +1,251 @@
+package versions
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// mavenQualifierRanks orders the well-known Maven qualifiers. Unknown qualifiers sort after all of them, lexically.
+var mavenQualifierRanks = map[string]int{
+	"alpha":     0,
+	"beta":      1,
+	"milestone": 2,
+	"rc":        3,
+	"snapshot":  4,
+	"":          5,
+	"sp":        6,
+}
+
+// mavenQualifierAliases maps alternative spellings of qualifiers to their canonical form.
+var mavenQualifierAliases = map[string]string{
+	"a":       "alpha",
+	"b":       "beta",
+	"m":       "milestone",
+	"cr":      "rc",
+	"ga":      "",
+	"final":   "",
+	"release": "",
+}
+
+// mavenItem is a single item of a Maven version, either a number or a qualifier.
+type mavenItem struct {
+	numeric   bool
+	number    uint64
+	qualifier string
+}
+
+// parseMavenVersion splits a Maven version into its items, approximating Maven's ComparableVersion: items are
+// separated by dots, hyphens and transitions between digits and letters, and trailing zeros and empty qualifiers are
+// dropped, so 1.0 equals 1 and 1-final.
+func parseMavenVersion(s string) []mavenItem {
+	s = strings.ToLower(strings.TrimSpace(s))
+	var tokens []string
+	start := 0
+	for i, r := range s {
+		switch {
+		case r == '.' || r == '-':
+			tokens = append(tokens, s[start:i])
+			start = i + 1
+		case i > start && unicode.IsDigit(r) != unicode.IsDigit(rune(s[i-1])):
+			tokens = append(tokens, s[start:i])
+			start = i
+		}
+	}
+	tokens = append(tokens, s[start:])
+
+	items := make([]mavenItem, 0, len(tokens))
+	for _, t := range tokens {
+		if n, err := strconv.ParseUint(t, 10, 64); err == nil {
+			items = append(items, mavenItem{numeric: true, number: n})
+			continue
+		}
+		if alias, ok := mavenQualifierAliases[t]; ok {
+			t = alias
+		}
+		// Zeros before a qualifier are insignificant, so 1.0-rc1 equals 1-rc1.
+		for len(items) > 0 && items[len(items)-1].numeric && items[len(items)-1].isNull() {
+			items = items[:len(items)-1]
+		}
+		items = append(items, mavenItem{qualifier: t})
+	}
+	for len(items) > 0 && items[len(items)-1].isNull() {
+		items = items[:len(items)-1]
+	}
+	return items
+}
+
+func (i mavenItem) isNull() bool {
+	if i.numeric {
+		return i.number == 0
+	}
+	return i.qualifier == ""
+}
+
+func (i mavenItem) compare(o mavenItem) int {
+	switch {
+	case i.numeric && o.numeric:
+		return compareUint(i.number, o.number)
+	case i.numeric:
+		return 1
+	case o.numeric:
+		return -1
+	}
+	ri, known := mavenQualifierRanks[i.qualifier]
+	ro, knownO := mavenQualifierRanks[o.qualifier]
+	switch {
+	case known && knownO:
+		return compareInt(int64(ri), int64(ro))
+	case known:
+		return -1
+	case knownO:
+		return 1
+	}
+	return strings.Compare(i.qualifier, o.qualifier)
+}
+
+// compareMaven compares two Maven versions. The result is 0 if a == b, -1 if a < b, and +1 if a > b.
+func compareMaven(a, b string) int {
+	ia, ib := parseMavenVersion(a), parseMavenVersion(b)
+	for i := 0; i < len(ia) || i < len(ib); i++ {
+		// Missing items compare like a zero or an empty qualifier, whichever is of the same kind as the other item.
+		var x, y mavenItem
+		switch {
+		case i >= len(ia):
+			y = ib[i]
+			x = mavenItem{numeric: y.numeric}
+		case i >= len(ib):
+			x = ia[i]
+			y = mavenItem{numeric: x.numeric}
+		default:
+			x, y = ia[i], ib[i]
+		}
+		if c := x.compare(y); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// mavenIsPrerelease reports whether version has a qualifier that sorts before releases, such as a snapshot, a release
+// candidate or a milestone. Unknown qualifiers, such as the "jre" of "31.1-jre", sort after releases and are not
+// pre-releases.
+func mavenIsPrerelease(version string) bool {
+	for _, item := range parseMavenVersion(version) {
+		if rank, ok := mavenQualifierRanks[item.qualifier]; ok && !item.numeric && rank < mavenQualifierRanks[""] {
+			return true
+		}
+	}
+	return false
+}
+
+func isMavenSnapshot(s string) bool {
+	return strings.HasSuffix(strings.ToUpper(s), "-SNAPSHOT")
+}
+
+// mavenRestriction is a single interval of a Maven version range. Empty bounds are unbounded.
+type mavenRestriction struct {
+	lower, upper                   string
+	lowerInclusive, upperInclusive bool
+}
+
+func (r mavenRestriction) contains(version string) bool {
+	if r.lower != "" {
+		c := compareMaven(version, r.lower)
+		if c < 0 || (c == 0 && !r.lowerInclusive) {
+			return false
+		}
+	}
+	if r.upper != "" {
+		c := compareMaven(version, r.upper)
+		if c > 0 || (c == 0 && !r.upperInclusive) {
+			return false
+		}
+	}
+	return true
+}
+
+// mavenRange is a union of restrictions.
+type mavenRange struct {
+	restrictions []mavenRestriction
+	// snapshot is set if a bound is a snapshot version, which allows snapshots to match.
+	snapshot bool
+}
+
+// parseMavenRange parses a Maven version range, such as "[1.2,2.0)", "(,1.0],[1.2,)" or "[1.5]". A version without
+// brackets is treated as the exact range containing only that version.
+func parseMavenRange(s string) (matcher, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "[") && !strings.HasPrefix(s, "(") {
+		return mavenRange{
+			restrictions: []mavenRestriction{{lower: s, upper: s, lowerInclusive: true, upperInclusive: true}},
+			snapshot:     isMavenSnapshot(s),
+		}, nil
+	}
+
+	var r mavenRange
+	for s != "" {
+		end := strings.IndexAny(s, "])")
+		if end < 0 || (s[0] != '[' && s[0] != '(') {
+			return nil, fmt.Errorf("unbalanced brackets in %q", s)
+		}
+		restriction, err := parseMavenRestriction(s[:end+1])
+		if err != nil {
+			return nil, err
+		}
+		r.restrictions = append(r.restrictions, restriction)
+		r.snapshot = r.snapshot || isMavenSnapshot(restriction.lower) || isMavenSnapshot(restriction.upper)
+		s = strings.TrimSpace(s[end+1:])
+		if s != "" {
+			if s[0] != ',' {
+				return nil, fmt.Errorf("expected a comma between ranges in %q", s)
+			}
+			s = strings.TrimSpace(s[1:])
+			if s == "" {
+				return nil, fmt.Errorf("trailing comma")
+			}
+		}
+	}
+	return r, nil
+}
+
+// parseMavenRestriction parses a single bracketed interval, such as "[1.0,2.0)".
+func parseMavenRestriction(s string) (mavenRestriction, error) {
+	r := mavenRestriction{lowerInclusive: s[0] == '[', upperInclusive: s[len(s)-1] == ']'}
+	inner := s[1 : len(s)-1]
+	lower, upper, isInterval := strings.Cut(inner, ",")
+	r.lower, r.upper = strings.TrimSpace(lower), strings.TrimSpace(upper)
+	if !isInterval {
+		if !r.lowerInclusive || !r.upperInclusive || r.lower == "" {
+			return mavenRestriction{}, fmt.Errorf("single version range %q must be of the form [version]", s)
+		}
+		r.upper = r.lower
+		return r, nil
+	}
+	if strings.Contains(r.upper, ",") {
+		return mavenRestriction{}, fmt.Errorf("range %q has more than two bounds", s)
+	}
+	if r.lower != "" && r.upper != "" && compareMaven(r.lower, r.upper) > 0 {
+		return mavenRestriction{}, fmt.Errorf("range %q has a lower bound greater than its upper bound", s)
+	}
+	return r, nil
+}
+
+func (r mavenRange) matches(version string, prerelease bool) bool {
+	if isMavenSnapshot(version) && !prerelease && !r.snapshot {
+		return false
+	}
+	for _, restriction := range r.restrictions {
+		if restriction.contains(version) {
+			return true
+		}
+	}
+	return false
+}
+
+// mavenIsExact reports whether s is a single version rather than a bracketed range.
+func mavenIsExact(s string) bool {
+	s = strings.TrimSpace(s)
+	return s != "" && !strings.HasPrefix(s, "[") && !strings.HasPrefix(s, "(")
+}
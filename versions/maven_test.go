@@ -0,0 +1,83 @@
+package versions
+
+import (
+	"github.com/chainalysis-oss/oslc"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCompareMaven(t *testing.T) {
+	ordered := []string{
+		"1-alpha-1",
+		"1.0-alpha-2",
+		"1.0-beta1",
+		"1.0-M1",
+		"1.0-rc1",
+		"1.0-SNAPSHOT",
+		"1.0",
+		"1.0-sp1",
+		"1.0-foo",
+		"1.0.1",
+		"1.1",
+		"1.10",
+		"2.0",
+	}
+	for i := range ordered {
+		for j := range ordered {
+			want := 0
+			switch {
+			case i < j:
+				want = -1
+			case i > j:
+				want = 1
+			}
+			require.Equal(t, want, compareMaven(ordered[i], ordered[j]), "%s <=> %s", ordered[i], ordered[j])
+		}
+	}
+	require.Equal(t, 0, compareMaven("1.0", "1"))
+	require.Equal(t, 0, compareMaven("1.0.0.RELEASE", "1.0"))
+	require.Equal(t, 0, compareMaven("1.0-final", "1.0.0"))
+	require.Equal(t, 0, compareMaven("1.0-cr1", "1.0-RC1"))
+}
+
+func TestParseMavenRange_matches(t *testing.T) {
+	cases := []struct {
+		r       string
+		version string
+		want    bool
+	}{
+		{"[1.2,2.0)", "1.2", true},
+		{"[1.2,2.0)", "1.9.9", true},
+		{"[1.2,2.0)", "2.0", false},
+		{"[1.2,2.0)", "1.1", false},
+		{"(1.2,2.0]", "1.2", false},
+		{"(1.2,2.0]", "2.0", true},
+		{"[1.5]", "1.5", true},
+		{"[1.5]", "1.5.1", false},
+		{"(,1.0]", "0.9", true},
+		{"(,1.0]", "1.1", false},
+		{"[1.2,)", "99.0", true},
+		{"(,1.0],[1.2,)", "1.1", false},
+		{"(,1.0],[1.2,)", "1.3", true},
+		{"(,1.1),(1.1,)", "1.1", false},
+		{"[1.0,2.0)", "1.5-SNAPSHOT", false},
+		{"[1.0-SNAPSHOT,2.0)", "1.5-SNAPSHOT", true},
+		{"1.5", "1.5", true},
+	}
+	for _, tt := range cases {
+		t.Run(tt.r+"_"+tt.version, func(t *testing.T) {
+			c, err := ParseConstraint(oslc.DistributorMaven, tt.r)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, c.Matches(tt.version))
+		})
+	}
+}
+
+func TestParseMavenRange_invalid(t *testing.T) {
+	for _, r := range []string{"[1.0", "(1.0)", "[]", "[2.0,1.0]", "[1.0,2.0],", "[1.0,2.0] [3.0,)", "[1,2,3]"} {
+		t.Run(r, func(t *testing.T) {
+			_, err := ParseConstraint(oslc.DistributorMaven, r)
+			require.ErrorIs(t, err, ErrInvalidConstraint)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package versions
+
+import (
+	"fmt"
+	"strings"
+)
+
+// npmOperators are the operators of npm's range syntax. Longer operators come first, so they are matched before their
+// prefixes.
+var npmOperators = []string{">=", "<=", "~>", ">", "<", "=", "^", "~"}
+
+// parseNpmRange parses an npm semver range, such as "^4.17.0", "1.2.x || >=2.0.0 <3.0.0" or "1.2.3 - 2.3.4".
+func parseNpmRange(s string) (matcher, error) {
+	m := semverMatcher{parse: parseNpmVersion}
+	for _, r := range strings.Split(s, "||") {
+		set, err := parseNpmComparatorSet(strings.TrimSpace(r))
+		if err != nil {
+			return nil, err
+		}
+		m.sets = append(m.sets, set)
+	}
+	return m, nil
+}
+
+func parseNpmComparatorSet(s string) (comparatorSet, error) {
+	if lo, hi, ok := strings.Cut(s, " - "); ok {
+		return parseNpmHyphenRange(strings.TrimSpace(lo), strings.TrimSpace(hi))
+	}
+
+	var set comparatorSet
+	fields := strings.Fields(s)
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+		op := npmOperator(field)
+		// Operators may be separated from their version by whitespace, as in ">= 1.2.3".
+		if op == field && i+1 < len(fields) {
+			i++
+			field += fields[i]
+		}
+		p, err := parseNpmPartial(strings.TrimPrefix(field, op))
+		if err != nil {
+			return nil, err
+		}
+		comparators, err := desugar(op, p)
+		if err != nil {
+			return nil, err
+		}
+		set = append(set, comparators...)
+	}
+	return set, nil
+}
+
+// parseNpmHyphenRange parses the inclusive range "lo - hi". Missing components of lo are replaced with zeros, while
+// missing components of hi allow any value.
+func parseNpmHyphenRange(lo, hi string) (comparatorSet, error) {
+	pl, err := parseNpmPartial(lo)
+	if err != nil {
+		return nil, err
+	}
+	ph, err := parseNpmPartial(hi)
+	if err != nil {
+		return nil, err
+	}
+	set, err := desugar(">=", pl)
+	if err != nil {
+		return nil, err
+	}
+	upper, err := desugar("<=", ph)
+	if err != nil {
+		return nil, err
+	}
+	return append(set, upper...), nil
+}
+
+func npmOperator(s string) string {
+	for _, op := range npmOperators {
+		if strings.HasPrefix(s, op) {
+			return op
+		}
+	}
+	return ""
+}
+
+// parseNpmPartial parses a partial version in an npm range. Like npm, a leading "v" or "=" is ignored.
+func parseNpmPartial(s string) (partial, error) {
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "="), "v")
+	if s == "" {
+		return partial{}, fmt.Errorf("missing version")
+	}
+	return parsePartial(s)
+}
+
+// parseNpmVersion parses a version as published to the npm registry.
+func parseNpmVersion(s string) (semver, error) {
+	return parseSemver(strings.TrimPrefix(s, "v"))
+}
+
+// npmIsExact reports whether s is a single version rather than a range or dist-tag.
+func npmIsExact(s string) bool {
+	_, err := parseSemver(s)
+	return err == nil
+}
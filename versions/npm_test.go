@@ -0,0 +1,72 @@
+package versions
+
+import (
+	"github.com/chainalysis-oss/oslc"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParseNpmRange_matches(t *testing.T) {
+	cases := []struct {
+		r       string
+		version string
+		want    bool
+	}{
+		{"^4.17.0", "4.17.21", true},
+		{"^4.17.0", "4.16.9", false},
+		{"^4.17.0", "5.0.0", false},
+		{"^0.2.3", "0.2.9", true},
+		{"^0.2.3", "0.3.0", false},
+		{"^0.0.3", "0.0.3", true},
+		{"^0.0.3", "0.0.4", false},
+		{"^0.0", "0.0.9", true},
+		{"^0.0", "0.1.0", false},
+		{"^1.x", "1.9.9", true},
+		{"~1.2.3", "1.2.9", true},
+		{"~1.2.3", "1.3.0", false},
+		{"~1", "1.9.0", true},
+		{"~1", "2.0.0", false},
+		{"1.2.x", "1.2.7", true},
+		{"1.2.x", "1.3.0", false},
+		{"1", "1.5.0", true},
+		{"*", "3.0.0", true},
+		{"", "3.0.0", true},
+		{"1.2.3", "1.2.3", true},
+		{"=v1.2.3", "1.2.3", true},
+		{">1.2", "1.2.9", false},
+		{">1.2", "1.3.0", true},
+		{"<=1.2", "1.2.9", true},
+		{"<=1.2", "1.3.0", false},
+		{"<1.2", "1.1.9", true},
+		{"<1.2", "1.2.0", false},
+		{">=1.2.3 <2.0.0", "1.9.0", true},
+		{">= 1.2.3 < 2.0.0", "2.0.0", false},
+		{"1.2.3 - 2.3.4", "2.3.4", true},
+		{"1.2.3 - 2.3.4", "2.3.5", false},
+		{"1.2 - 2.3", "2.3.9", true},
+		{"1.2 - 2.3", "1.1.0", false},
+		{"1.x || >=2.5.0", "2.6.0", true},
+		{"1.x || >=2.5.0", "2.4.0", false},
+		{"^1.2.3", "1.5.0-beta.1", false},
+		{"^1.2.3-beta.2", "1.2.3-beta.4", true},
+		{"^1.2.3-beta.2", "1.2.4-beta.1", false},
+		{">*", "1.0.0", false},
+		{"^1.2.3", "not-a-version", false},
+	}
+	for _, tt := range cases {
+		t.Run(tt.r+"_"+tt.version, func(t *testing.T) {
+			c, err := ParseConstraint(oslc.DistributorNpm, tt.r)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, c.Matches(tt.version))
+		})
+	}
+}
+
+func TestParseNpmRange_invalid(t *testing.T) {
+	for _, r := range []string{"next", "^", "1.x.2", ">=1.2.3 <", "1.2.3 - beta"} {
+		t.Run(r, func(t *testing.T) {
+			_, err := ParseConstraint(oslc.DistributorNpm, r)
+			require.ErrorIs(t, err, ErrInvalidConstraint)
+		})
+	}
+}
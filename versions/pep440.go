@@ -0,0 +1,345 @@
+package versions
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// pep440Pattern matches the versions accepted by PEP 440, including the alternative spellings it allows.
+var pep440Pattern = regexp.MustCompile(`(?i)^v?` +
+	`(?:(?P<epoch>[0-9]+)!)?` +
+	`(?P<release>[0-9]+(?:\.[0-9]+)*)` +
+	`(?:[-_.]?(?P<pre_l>alpha|a|beta|b|preview|pre|c|rc)[-_.]?(?P<pre_n>[0-9]+)?)?` +
+	`(?:-(?P<post_n1>[0-9]+)|[-_.]?(?P<post_l>post|rev|r)[-_.]?(?P<post_n2>[0-9]+)?)?` +
+	`(?:[-_.]?(?P<dev_l>dev)[-_.]?(?P<dev_n>[0-9]+)?)?` +
+	`(?:\+(?P<local>[a-z0-9]+(?:[-_.][a-z0-9]+)*))?$`)
+
+// pep440Version is a parsed PEP 440 version. Absent post and dev segments are -1.
+type pep440Version struct {
+	epoch   uint64
+	release []uint64
+	// preRank orders the pre-release phases: 0 for alpha, 1 for beta, 2 for release candidates and -1 without a
+	// pre-release.
+	preRank int
+	pre     uint64
+	post    int64
+	dev     int64
+	local   string
+}
+
+func parsePEP440(s string) (pep440Version, error) {
+	m := pep440Pattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return pep440Version{}, fmt.Errorf("%q is not a valid PEP 440 version", s)
+	}
+	group := func(name string) string {
+		return m[pep440Pattern.SubexpIndex(name)]
+	}
+	number := func(s string) uint64 {
+		n, _ := strconv.ParseUint(s, 10, 64)
+		return n
+	}
+
+	v := pep440Version{preRank: -1, post: -1, dev: -1, local: strings.ToLower(group("local"))}
+	v.epoch = number(group("epoch"))
+	for _, r := range strings.Split(group("release"), ".") {
+		v.release = append(v.release, number(r))
+	}
+	switch strings.ToLower(group("pre_l")) {
+	case "alpha", "a":
+		v.preRank = 0
+	case "beta", "b":
+		v.preRank = 1
+	case "preview", "pre", "c", "rc":
+		v.preRank = 2
+	}
+	v.pre = number(group("pre_n"))
+	switch {
+	case group("post_n1") != "":
+		v.post = int64(number(group("post_n1")))
+	case group("post_l") != "":
+		v.post = int64(number(group("post_n2")))
+	}
+	if group("dev_l") != "" {
+		v.dev = int64(number(group("dev_n")))
+	}
+	return v, nil
+}
+
+func (v pep440Version) isPrerelease() bool {
+	return v.preRank >= 0 || v.dev >= 0
+}
+
+// compare compares v and o following the ordering defined by PEP 440. The result is 0 if v == o, -1 if v < o, and +1
+// if v > o.
+func (v pep440Version) compare(o pep440Version) int {
+	if c := compareUint(v.epoch, o.epoch); c != 0 {
+		return c
+	}
+	if c := compareRelease(v.release, o.release); c != 0 {
+		return c
+	}
+	if c := compareInt(v.preKey(), o.preKey()); c != 0 {
+		return c
+	}
+	if v.preRank >= 0 {
+		if c := compareUint(v.pre, o.pre); c != 0 {
+			return c
+		}
+	}
+	if c := compareInt(v.post, o.post); c != 0 {
+		return c
+	}
+	if c := compareInt(v.devKey(), o.devKey()); c != 0 {
+		return c
+	}
+	return compareLocal(v.local, o.local)
+}
+
+// preKey orders the pre-release phase. Development releases without a pre-release sort before all pre-releases of the
+// same release, and final releases sort after them.
+func (v pep440Version) preKey() int64 {
+	switch {
+	case v.preRank >= 0:
+		return int64(v.preRank)
+	case v.post < 0 && v.dev >= 0:
+		return -1
+	}
+	return math.MaxInt64
+}
+
+// devKey sorts development releases before the version they lead up to.
+func (v pep440Version) devKey() int64 {
+	if v.dev < 0 {
+		return math.MaxInt64
+	}
+	return v.dev
+}
+
+// compareRelease compares release segments, padding the shorter one with zeros, so 1.0 equals 1.0.0.
+func compareRelease(a, b []uint64) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y uint64
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if c := compareUint(x, y); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// compareLocal compares local version labels. A version without a label sorts before one with a label, and the
+// segments of labels are compared like the segments in [Compare].
+func compareLocal(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return -1
+	case b == "":
+		return 1
+	}
+	return Compare(a, b)
+}
+
+func compareUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareInt(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// pep440Operators are the comparison operators of PEP 440 version specifiers. Longer operators come first, so they
+// are matched before their prefixes.
+var pep440Operators = []string{"===", "~=", "==", "!=", "<=", ">=", "<", ">"}
+
+// pep440Clause is a single clause of a version specifier.
+type pep440Clause struct {
+	op      string
+	raw     string
+	version pep440Version
+	// prefix is set for the == and != operators when the version ends in ".*".
+	prefix bool
+}
+
+// pep440Specifier is a comma-separated list of clauses which must all be satisfied.
+type pep440Specifier struct {
+	clauses []pep440Clause
+	// prerelease is set if a clause refers to a pre-release, which allows pre-releases to match.
+	prerelease bool
+}
+
+// parsePEP440Specifier parses a PEP 440 version specifier, such as "~=2.31", "==1.4.*" or ">=1.0, !=1.3.4, <2.0".
+func parsePEP440Specifier(s string) (matcher, error) {
+	var spec pep440Specifier
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		c := pep440Clause{op: "=="}
+		for _, op := range pep440Operators {
+			if strings.HasPrefix(part, op) {
+				c.op = op
+				part = strings.TrimSpace(strings.TrimPrefix(part, op))
+				break
+			}
+		}
+		if part == "" {
+			return nil, fmt.Errorf("missing version")
+		}
+		c.raw = part
+		if c.op == "===" {
+			spec.clauses = append(spec.clauses, c)
+			continue
+		}
+		if (c.op == "==" || c.op == "!=") && strings.HasSuffix(part, ".*") {
+			c.prefix = true
+			part = strings.TrimSuffix(part, ".*")
+		}
+		v, err := parsePEP440(part)
+		if err != nil {
+			return nil, err
+		}
+		if c.op == "~=" && len(v.release) < 2 {
+			return nil, fmt.Errorf("~= requires at least two release segments, got %q", part)
+		}
+		c.version = v
+		if v.isPrerelease() && c.op != "!=" {
+			spec.prerelease = true
+		}
+		spec.clauses = append(spec.clauses, c)
+	}
+	return spec, nil
+}
+
+func (s pep440Specifier) matches(version string, prerelease bool) bool {
+	v, err := parsePEP440(version)
+	if err != nil {
+		// Only arbitrary equality can match versions that do not follow PEP 440.
+		for _, c := range s.clauses {
+			if c.op != "===" || !c.matches(version, v) {
+				return false
+			}
+		}
+		return len(s.clauses) > 0
+	}
+	if v.isPrerelease() && !prerelease && !s.prerelease {
+		return false
+	}
+	for _, c := range s.clauses {
+		if !c.matches(version, v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c pep440Clause) matches(raw string, v pep440Version) bool {
+	if c.op == "===" {
+		return strings.EqualFold(strings.TrimSpace(raw), c.raw)
+	}
+	// Local version labels are ignored unless the specifier has one.
+	if c.version.local == "" {
+		v.local = ""
+	}
+	switch c.op {
+	case "==":
+		if c.prefix {
+			return c.hasPrefix(v)
+		}
+		return v.compare(c.version) == 0
+	case "!=":
+		if c.prefix {
+			return !c.hasPrefix(v)
+		}
+		return v.compare(c.version) != 0
+	case "~=":
+		prefix := pep440Clause{version: pep440Version{epoch: c.version.epoch, release: c.version.release[:len(c.version.release)-1]}}
+		return v.compare(c.version) >= 0 && prefix.hasPrefix(v)
+	case "<=":
+		return v.compare(c.version) <= 0
+	case ">=":
+		return v.compare(c.version) >= 0
+	case "<":
+		// An exclusive upper bound excludes pre-releases of the bound itself, unless the bound is a pre-release.
+		if !c.version.isPrerelease() && v.isPrerelease() && sameRelease(v, c.version) {
+			return false
+		}
+		return v.compare(c.version) < 0
+	case ">":
+		// An exclusive lower bound excludes post-releases of the bound itself, unless the bound is a post-release.
+		if c.version.post < 0 && v.post >= 0 && sameRelease(v, c.version) {
+			return false
+		}
+		return v.compare(c.version) > 0
+	}
+	return false
+}
+
+// hasPrefix reports whether the release segments of c's version are a prefix of those of v, after padding v with zeros.
+// Only the epoch and release segments of c's version are considered.
+func (c pep440Clause) hasPrefix(v pep440Version) bool {
+	if v.epoch != c.version.epoch {
+		return false
+	}
+	for i, r := range c.version.release {
+		var x uint64
+		if i < len(v.release) {
+			x = v.release[i]
+		}
+		if x != r {
+			return false
+		}
+	}
+	return true
+}
+
+func sameRelease(a, b pep440Version) bool {
+	return a.epoch == b.epoch && compareRelease(a.release, b.release) == 0
+}
+
+// comparePEP440 compares two PEP 440 versions. Versions that cannot be parsed sort before all others.
+func comparePEP440(a, b string) int {
+	va, errA := parsePEP440(a)
+	vb, errB := parsePEP440(b)
+	switch {
+	case errA != nil && errB != nil:
+		return strings.Compare(a, b)
+	case errA != nil:
+		return -1
+	case errB != nil:
+		return 1
+	}
+	return va.compare(vb)
+}
+
+// pep440IsPrerelease reports whether version is a PEP 440 pre-release or development release.
+func pep440IsPrerelease(version string) bool {
+	v, err := parsePEP440(version)
+	return err != nil || v.isPrerelease()
+}
+
+// pep440IsExact reports whether s is a single version rather than a specifier.
+func pep440IsExact(s string) bool {
+	_, err := parsePEP440(s)
+	return err == nil
+}
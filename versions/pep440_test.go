@@ -0,0 +1,114 @@
+package versions
+
+import (
+	"github.com/chainalysis-oss/oslc"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParsePEP440(t *testing.T) {
+	valid := []string{"1", "1.0", "2.31.0", "1!2.0", "1.0a1", "1.0-alpha.1", "1.0b2", "1.0rc1", "1.0c1", "1.0.post1",
+		"1.0-1", "1.0.dev3", "1.0a1.dev1", "1.0+ubuntu.1", "v1.0", "1.0RC1"}
+	for _, v := range valid {
+		t.Run(v, func(t *testing.T) {
+			_, err := parsePEP440(v)
+			require.NoError(t, err)
+		})
+	}
+	for _, v := range []string{"", "a", "1.0.x", "1.0+", "~=1.0"} {
+		t.Run(v, func(t *testing.T) {
+			_, err := parsePEP440(v)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestPEP440Version_compare(t *testing.T) {
+	// Ordered following the examples in PEP 440.
+	ordered := []string{
+		"1.0.dev456",
+		"1.0a1",
+		"1.0a2.dev456",
+		"1.0a12.dev456",
+		"1.0a12",
+		"1.0b1.dev456",
+		"1.0b2",
+		"1.0b2.post345.dev456",
+		"1.0b2.post345",
+		"1.0rc1.dev456",
+		"1.0rc1",
+		"1.0",
+		"1.0+abc.5",
+		"1.0+abc.7",
+		"1.0+5",
+		"1.0.post456.dev34",
+		"1.0.post456",
+		"1.1.dev1",
+		"1!0.1",
+	}
+	for i := range ordered {
+		for j := range ordered {
+			want := 0
+			switch {
+			case i < j:
+				want = -1
+			case i > j:
+				want = 1
+			}
+			require.Equal(t, want, comparePEP440(ordered[i], ordered[j]), "%s <=> %s", ordered[i], ordered[j])
+		}
+	}
+	require.Equal(t, 0, comparePEP440("1.0", "1.0.0"))
+	require.Equal(t, 0, comparePEP440("1.0alpha1", "1.0a1"))
+}
+
+func TestParsePEP440Specifier_matches(t *testing.T) {
+	cases := []struct {
+		spec    string
+		version string
+		want    bool
+	}{
+		{"~=2.31", "2.31.0", true},
+		{"~=2.31", "2.32.3", true},
+		{"~=2.31", "3.0.0", false},
+		{"~=2.31", "2.30.0", false},
+		{"~=2.31.0", "2.31.5", true},
+		{"~=2.31.0", "2.32.0", false},
+		{"==1.4.*", "1.4.2", true},
+		{"==1.4.*", "1.5.0", false},
+		{"==1.4", "1.4.0", true},
+		{"==1.4", "1.4.0+local", true},
+		{"==1.4+local", "1.4.0", false},
+		{"!=1.3.4", "1.3.4", false},
+		{"!=1.3.*", "1.3.9", false},
+		{">=1.0, !=1.3.4, <2.0", "1.9", true},
+		{">=1.0, !=1.3.4, <2.0", "1.3.4", false},
+		{">=1.0, !=1.3.4, <2.0", "2.0", false},
+		{"<2.0", "2.0rc1", false},
+		{"<2.0rc2", "2.0rc1", true},
+		{">1.7", "1.7.post1", false},
+		{">1.7", "1.7.1", true},
+		{">1.7.post1", "1.7.post2", true},
+		{"<=2.0", "2.0", true},
+		{"===1.0-foo", "1.0-foo", true},
+		{"===1.0", "1.0.0", false},
+		{">=1.0", "2.0b1", false},
+		{">=1.0b1", "2.0b1", true},
+	}
+	for _, tt := range cases {
+		t.Run(tt.spec+"_"+tt.version, func(t *testing.T) {
+			c, err := ParseConstraint(oslc.DistributorPypi, tt.spec)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, c.Matches(tt.version))
+		})
+	}
+}
+
+func TestParsePEP440Specifier_invalid(t *testing.T) {
+	for _, spec := range []string{"", "~=2", ">=", ">=1.0,", ">=abc", "<=1.*"} {
+		t.Run(spec, func(t *testing.T) {
+			_, err := ParseConstraint(oslc.DistributorPypi, spec)
+			require.ErrorIs(t, err, ErrInvalidConstraint)
+		})
+	}
+}
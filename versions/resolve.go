@@ -0,0 +1,190 @@
+package versions
+
+import (
+	"errors"
+	"fmt"
+	"github.com/chainalysis-oss/oslc"
+	"strings"
+)
+
+// ErrInvalidConstraint is returned when a version constraint cannot be parsed.
+var ErrInvalidConstraint = errors.New("invalid version constraint")
+
+// ErrUnsupportedDistributor is returned when version constraints are not supported for a distributor.
+var ErrUnsupportedDistributor = errors.New("version constraints are not supported for distributor")
+
+// matcher is a parsed constraint of a specific version scheme. Unless prerelease is set, pre-releases only match if the
+// constraint explicitly refers to one.
+type matcher interface {
+	matches(version string, prerelease bool) bool
+}
+
+// scheme describes the version scheme and constraint syntax of a distributor.
+type scheme struct {
+	parse func(s string) (matcher, error)
+	// exact reports whether s is a single version rather than a constraint.
+	exact   func(s string) bool
+	compare func(a, b string) int
+	// compatible reports whether version may be a version of the package with the provided name. It is nil if every
+	// version is compatible.
+	compatible func(name, version string) bool
+	// preferReleases is set for schemes in which pre-releases are selected if no release satisfies a constraint.
+	preferReleases bool
+	// prerelease reports whether version is a pre-release. Versions that do not follow the scheme are pre-releases.
+	prerelease func(version string) bool
+}
+
+var schemes = map[string]scheme{
+	oslc.DistributorNpm: {
+		parse:      parseNpmRange,
+		exact:      npmIsExact,
+		compare:    compareSemver(parseNpmVersion),
+		prerelease: semverIsPrerelease(parseNpmVersion),
+	},
+	oslc.DistributorPypi: {
+		parse:          parsePEP440Specifier,
+		exact:          pep440IsExact,
+		compare:        comparePEP440,
+		preferReleases: true,
+		prerelease:     pep440IsPrerelease,
+	},
+	oslc.DistributorMaven: {
+		parse:      parseMavenRange,
+		exact:      mavenIsExact,
+		compare:    compareMaven,
+		prerelease: mavenIsPrerelease,
+	},
+	oslc.DistributorCratesIo: {
+		parse:      parseCargoRequirement,
+		exact:      cargoIsExact,
+		compare:    compareSemver(parseSemver),
+		prerelease: semverIsPrerelease(parseSemver),
+	},
+	oslc.DistributorGo: {
+		parse:          parseGoQuery,
+		exact:          goIsExact,
+		compare:        compareSemver(parseGoVersion),
+		compatible:     goCompatible,
+		preferReleases: true,
+		prerelease:     semverIsPrerelease(parseGoVersion),
+	},
+}
+
+// Comparer returns the comparison of versions of the distributor, following its version scheme. The result of the
+// comparison is 0 if a == b, -1 if a < b, and +1 if a > b. The lenient [Compare] is returned for distributors without
+// a version scheme.
+func Comparer(distributor string) func(a, b string) int {
+	if sc, ok := schemes[distributor]; ok {
+		return sc.compare
+	}
+	return Compare
+}
+
+// Latest returns the highest release among the available versions of the package with the provided name, which is
+// the version a distributor reports as the latest one. For PyPI and Go, the highest pre-release is returned if there
+// is no release. The boolean is false if there is no such version, or versions of the distributor cannot be resolved.
+func Latest(distributor, name string, available []string) (string, bool) {
+	sc, ok := schemes[distributor]
+	if !ok {
+		return "", false
+	}
+	return Constraint{raw: "latest", scheme: sc, m: anyVersion{prerelease: sc.prerelease}}.Select(name, available)
+}
+
+// anyVersion is a matcher that matches every version, except for pre-releases unless they are allowed.
+type anyVersion struct {
+	prerelease func(version string) bool
+}
+
+func (m anyVersion) matches(version string, prerelease bool) bool {
+	return prerelease || !m.prerelease(version)
+}
+
+// Constraint is a version constraint in the syntax of a specific distributor. Constraints are created with
+// [ParseConstraint].
+type Constraint struct {
+	raw    string
+	scheme scheme
+	m      matcher
+}
+
+// IsConstraint reports whether s must be resolved against the versions of a package before it can be looked up,
+// because it is neither empty, "latest", nor a single version of the distributor's version scheme. For npm, this is
+// also the case for dist-tags such as "next", which [ParseConstraint] rejects.
+//
+// For Cargo, a complete version without an operator is a single version, even though Cargo.toml treats it as a caret
+// requirement. For Maven, every version without brackets is a single version.
+func IsConstraint(distributor, s string) bool {
+	s = strings.TrimSpace(s)
+	if s == "" || s == "latest" {
+		return false
+	}
+	sc, ok := schemes[distributor]
+	if !ok {
+		return false
+	}
+	return !sc.exact(s)
+}
+
+// ParseConstraint parses s as a version constraint of the distributor:
+//
+//   - npm: semver ranges, such as "^4.17.0", "~1.2", "1.x || >=2.1.0 <3.0.0" or "1.2.3 - 2.3.4".
+//   - PyPI: PEP 440 version specifiers, such as "~=2.31", "==1.4.*" or ">=1.0, !=1.3.4, <2.0".
+//   - Maven: version ranges, such as "[1.2,2.0)", "(,1.0],[1.2,)" or "[1.5]".
+//   - crates.io: Cargo version requirements, such as "1.2", "~1.2.3" or ">=0.3, <0.4".
+//   - Go: module version queries, such as "v1", "v1.2", ">=v1.2.0" or "latest".
+func ParseConstraint(distributor, s string) (Constraint, error) {
+	sc, ok := schemes[distributor]
+	if !ok {
+		return Constraint{}, fmt.Errorf("%w: %s", ErrUnsupportedDistributor, distributor)
+	}
+	s = strings.TrimSpace(s)
+	m, err := sc.parse(s)
+	if err != nil {
+		return Constraint{}, fmt.Errorf("%w: %q: %w", ErrInvalidConstraint, s, err)
+	}
+	return Constraint{raw: s, scheme: sc, m: m}, nil
+}
+
+// String returns the constraint as it was parsed.
+func (c Constraint) String() string {
+	return c.raw
+}
+
+// Matches reports whether version satisfies the constraint. Pre-releases only match if the constraint refers to a
+// pre-release.
+func (c Constraint) Matches(version string) bool {
+	return c.m != nil && c.m.matches(version, false)
+}
+
+// Select returns the highest of the available versions of the package with the provided name that satisfies the
+// constraint. The name is only relevant to distributors whose versions depend on it, such as Go modules with semantic
+// import versioning. Releases are preferred over pre-releases; for PyPI and Go, pre-releases are selected if no
+// release satisfies the constraint. The boolean is false if no version satisfies the constraint.
+func (c Constraint) Select(name string, available []string) (string, bool) {
+	if c.m == nil {
+		return "", false
+	}
+	best, ok := c.selectMatching(name, available, false)
+	if !ok && c.scheme.preferReleases {
+		best, ok = c.selectMatching(name, available, true)
+	}
+	return best, ok
+}
+
+func (c Constraint) selectMatching(name string, available []string, prerelease bool) (string, bool) {
+	var best string
+	found := false
+	for _, v := range available {
+		if c.scheme.compatible != nil && !c.scheme.compatible(name, v) {
+			continue
+		}
+		if !c.m.matches(v, prerelease) {
+			continue
+		}
+		if !found || c.scheme.compare(v, best) > 0 {
+			best, found = v, true
+		}
+	}
+	return best, found
+}
@@ -0,0 +1,220 @@
+package versions
+
+import (
+	"github.com/chainalysis-oss/oslc"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestIsConstraint(t *testing.T) {
+	cases := []struct {
+		distributor string
+		s           string
+		want        bool
+	}{
+		{oslc.DistributorNpm, "", false},
+		{oslc.DistributorNpm, "latest", false},
+		{oslc.DistributorNpm, "4.17.21", false},
+		{oslc.DistributorNpm, "^4.17.0", true},
+		{oslc.DistributorNpm, "next", true},
+		{oslc.DistributorPypi, "2.31.0", false},
+		{oslc.DistributorPypi, "2.31", false},
+		{oslc.DistributorPypi, "~=2.31", true},
+		{oslc.DistributorMaven, "1.0", false},
+		{oslc.DistributorMaven, "[1.2,2.0)", true},
+		{oslc.DistributorCratesIo, "0.3.1", false},
+		{oslc.DistributorCratesIo, "0.3", true},
+		{oslc.DistributorCratesIo, ">=0.3, <0.4", true},
+		{oslc.DistributorGo, "v1.2.3", false},
+		{oslc.DistributorGo, "v0.0.0-20240101000000-abcdefabcdef", false},
+		{oslc.DistributorGo, "v1.2", true},
+		{"unknown", "^1.0.0", false},
+	}
+	for _, tt := range cases {
+		t.Run(tt.distributor+"_"+tt.s, func(t *testing.T) {
+			require.Equal(t, tt.want, IsConstraint(tt.distributor, tt.s))
+		})
+	}
+}
+
+func TestParseConstraint_unsupported_distributor(t *testing.T) {
+	_, err := ParseConstraint("unknown", "1.0")
+	require.ErrorIs(t, err, ErrUnsupportedDistributor)
+}
+
+func TestConstraint_zero_value(t *testing.T) {
+	var c Constraint
+	require.False(t, c.Matches("1.0.0"))
+	_, ok := c.Select("test", []string{"1.0.0"})
+	require.False(t, ok)
+}
+
+func TestConstraint_String(t *testing.T) {
+	c, err := ParseConstraint(oslc.DistributorNpm, " ^1.2.3 ")
+	require.NoError(t, err)
+	require.Equal(t, "^1.2.3", c.String())
+}
+
+func TestConstraint_Select(t *testing.T) {
+	cases := []struct {
+		name        string
+		distributor string
+		pkg         string
+		constraint  string
+		available   []string
+		want        string
+		wantOK      bool
+	}{
+		{
+			name:        "npm caret",
+			distributor: oslc.DistributorNpm,
+			constraint:  "^4.17.0",
+			available:   []string{"4.16.6", "4.17.0", "4.17.21", "4.17.9", "5.0.0-beta.1", "5.0.0"},
+			want:        "4.17.21",
+			wantOK:      true,
+		},
+		{
+			name:        "npm excludes pre-releases",
+			distributor: oslc.DistributorNpm,
+			constraint:  ">=1.0.0",
+			available:   []string{"1.0.0", "1.1.0-beta.1"},
+			want:        "1.0.0",
+			wantOK:      true,
+		},
+		{
+			name:        "npm without pre-release fallback",
+			distributor: oslc.DistributorNpm,
+			constraint:  ">=1.0.0",
+			available:   []string{"1.1.0-beta.1"},
+		},
+		{
+			name:        "pypi compatible release",
+			distributor: oslc.DistributorPypi,
+			constraint:  "~=2.31",
+			available:   []string{"2.30.0", "2.31.0", "2.32.3", "2.32.4rc1", "3.0.0"},
+			want:        "2.32.3",
+			wantOK:      true,
+		},
+		{
+			name:        "pypi falls back to pre-releases",
+			distributor: oslc.DistributorPypi,
+			constraint:  ">2.0",
+			available:   []string{"2.0", "3.0b1", "3.0b2"},
+			want:        "3.0b2",
+			wantOK:      true,
+		},
+		{
+			name:        "maven range",
+			distributor: oslc.DistributorMaven,
+			constraint:  "[1.2,2.0)",
+			available:   []string{"1.1", "1.2", "1.10", "1.9", "2.0", "2.0-SNAPSHOT"},
+			want:        "1.10",
+			wantOK:      true,
+		},
+		{
+			name:        "cargo",
+			distributor: oslc.DistributorCratesIo,
+			constraint:  ">=0.3, <0.4",
+			available:   []string{"0.2.9", "0.3.0", "0.3.12", "0.3.2", "0.4.0"},
+			want:        "0.3.12",
+			wantOK:      true,
+		},
+		{
+			name:        "go semantic import versioning",
+			distributor: oslc.DistributorGo,
+			pkg:         "github.com/foo/bar/v2",
+			constraint:  "latest",
+			available:   []string{"v1.9.0", "v2.1.0", "v2.0.0", "v3.0.0"},
+			want:        "v2.1.0",
+			wantOK:      true,
+		},
+		{
+			name:        "go prefers releases",
+			distributor: oslc.DistributorGo,
+			pkg:         "github.com/foo/bar",
+			constraint:  "v1",
+			available:   []string{"v1.0.0", "v1.1.0-rc.1"},
+			want:        "v1.0.0",
+			wantOK:      true,
+		},
+		{
+			name:        "go falls back to pre-releases",
+			distributor: oslc.DistributorGo,
+			pkg:         "github.com/foo/bar",
+			constraint:  "v1.1",
+			available:   []string{"v1.0.0", "v1.1.0-rc.1", "v1.1.0-rc.2"},
+			want:        "v1.1.0-rc.2",
+			wantOK:      true,
+		},
+		{
+			name:        "no match",
+			distributor: oslc.DistributorNpm,
+			constraint:  "^9.0.0",
+			available:   []string{"1.0.0"},
+		},
+		{
+			name:        "no versions",
+			distributor: oslc.DistributorNpm,
+			constraint:  "*",
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := ParseConstraint(tt.distributor, tt.constraint)
+			require.NoError(t, err)
+			got, ok := c.Select(tt.pkg, tt.available)
+			require.Equal(t, tt.wantOK, ok)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestComparer(t *testing.T) {
+	cases := []struct {
+		distributor string
+		a, b        string
+		want        int
+	}{
+		{oslc.DistributorPypi, "1.0.0.post1", "1.0.0", 1},
+		{oslc.DistributorPypi, "1.0.0.dev1", "1.0.0a1", -1},
+		{oslc.DistributorMaven, "1.0-sp1", "1.0", 1},
+		{oslc.DistributorMaven, "1.0-ga", "1.0", 0},
+		{oslc.DistributorMaven, "1.0-final", "1.0-rc1", 1},
+		{oslc.DistributorNpm, "1.0.0-rc.10", "1.0.0-rc.9", 1},
+		{oslc.DistributorGo, "v1.10.0", "v1.9.0", 1},
+		{"unknown", "1.0.0", "1.0.0-beta", 1},
+	}
+	for _, tt := range cases {
+		t.Run(tt.distributor+"_"+tt.a+"_"+tt.b, func(t *testing.T) {
+			require.Equal(t, tt.want, Comparer(tt.distributor)(tt.a, tt.b))
+		})
+	}
+}
+
+func TestLatest(t *testing.T) {
+	cases := []struct {
+		name        string
+		distributor string
+		pkg         string
+		available   []string
+		want        string
+		wantOK      bool
+	}{
+		{"npm skips pre-releases", oslc.DistributorNpm, "", []string{"1.9.0", "2.0.0-rc.1"}, "1.9.0", true},
+		{"npm without releases", oslc.DistributorNpm, "", []string{"2.0.0-rc.1"}, "", false},
+		{"pypi post-release", oslc.DistributorPypi, "", []string{"1.0.0", "1.0.0.post1", "1.1.0rc1"}, "1.0.0.post1", true},
+		{"pypi falls back to pre-releases", oslc.DistributorPypi, "", []string{"1.0.0a1", "1.0.0b1"}, "1.0.0b1", true},
+		{"maven qualifiers", oslc.DistributorMaven, "", []string{"1.0", "1.0-sp1", "2.0-rc1", "2.0-SNAPSHOT"}, "1.0-sp1", true},
+		{"maven unknown qualifier", oslc.DistributorMaven, "", []string{"31.0-jre", "31.1-jre"}, "31.1-jre", true},
+		{"cargo", oslc.DistributorCratesIo, "", []string{"0.9.0", "0.10.0", "1.0.0-alpha"}, "0.10.0", true},
+		{"go major version", oslc.DistributorGo, "github.com/foo/bar/v2", []string{"v1.9.0", "v2.1.0", "v2.2.0-rc.1"}, "v2.1.0", true},
+		{"unknown distributor", "unknown", "", []string{"1.0.0"}, "", false},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Latest(tt.distributor, tt.pkg, tt.available)
+			require.Equal(t, tt.wantOK, ok)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,338 @@
+package versions
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// semver is a version following the Semantic Versioning 2.0.0 specification. Build metadata is retained, but ignored
+// when comparing versions.
+type semver struct {
+	major, minor, patch uint64
+	pre                 []string
+	build               string
+}
+
+// parseSemver parses s as a strict semantic version of the form MAJOR.MINOR.PATCH[-PRERELEASE][+BUILD].
+func parseSemver(s string) (semver, error) {
+	var v semver
+	s, v.build, _ = strings.Cut(s, "+")
+	s, pre, hasPre := strings.Cut(s, "-")
+	if hasPre {
+		if pre == "" {
+			return semver{}, fmt.Errorf("empty pre-release")
+		}
+		v.pre = strings.Split(pre, ".")
+		for _, id := range v.pre {
+			if id == "" {
+				return semver{}, fmt.Errorf("empty pre-release identifier")
+			}
+		}
+	}
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return semver{}, fmt.Errorf("%q is not of the form MAJOR.MINOR.PATCH", s)
+	}
+	var err error
+	for i, dst := range []*uint64{&v.major, &v.minor, &v.patch} {
+		if *dst, err = parseNumericIdentifier(parts[i]); err != nil {
+			return semver{}, err
+		}
+	}
+	return v, nil
+}
+
+func parseNumericIdentifier(s string) (uint64, error) {
+	if s == "" || strings.TrimLeft(s, "0123456789") != "" {
+		return 0, fmt.Errorf("%q is not a number", s)
+	}
+	return strconv.ParseUint(s, 10, 64)
+}
+
+func (v semver) isPrerelease() bool {
+	return len(v.pre) > 0
+}
+
+// sameTuple reports whether v and o have the same major, minor and patch versions.
+func (v semver) sameTuple(o semver) bool {
+	return v.major == o.major && v.minor == o.minor && v.patch == o.patch
+}
+
+// compare compares v and o by semantic version precedence. The result is 0 if v == o, -1 if v < o, and +1 if v > o.
+func (v semver) compare(o semver) int {
+	for _, p := range [][2]uint64{{v.major, o.major}, {v.minor, o.minor}, {v.patch, o.patch}} {
+		switch {
+		case p[0] < p[1]:
+			return -1
+		case p[0] > p[1]:
+			return 1
+		}
+	}
+	switch {
+	case !v.isPrerelease() && !o.isPrerelease():
+		return 0
+	case !v.isPrerelease():
+		return 1
+	case !o.isPrerelease():
+		return -1
+	}
+	for i := 0; i < len(v.pre) && i < len(o.pre); i++ {
+		if c := comparePrereleaseIdentifier(v.pre[i], o.pre[i]); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case len(v.pre) < len(o.pre):
+		return -1
+	case len(v.pre) > len(o.pre):
+		return 1
+	}
+	return 0
+}
+
+// comparePrereleaseIdentifier compares two dot-separated pre-release identifiers. Numeric identifiers are compared
+// numerically and have lower precedence than alphanumeric identifiers, which are compared lexically.
+func comparePrereleaseIdentifier(a, b string) int {
+	na, errA := parseNumericIdentifier(a)
+	nb, errB := parseNumericIdentifier(b)
+	switch {
+	case errA == nil && errB == nil:
+		switch {
+		case na < nb:
+			return -1
+		case na > nb:
+			return 1
+		}
+		return 0
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// partial is a possibly incomplete version used in semver ranges, such as "1", "1.2.x" or "*". Components after the
+// first missing or wildcard component are ignored.
+type partial struct {
+	// parts is the number of components that are present, between 0 and 3.
+	parts               int
+	major, minor, patch uint64
+	pre                 []string
+}
+
+// parsePartial parses a partial version. Missing components and the wildcards "x", "X" and "*" are equivalent.
+func parsePartial(s string) (partial, error) {
+	var p partial
+	s, _, _ = strings.Cut(s, "+")
+	s, pre, hasPre := strings.Cut(s, "-")
+	if s == "" {
+		return partial{}, fmt.Errorf("empty version")
+	}
+	components := strings.Split(s, ".")
+	if len(components) > 3 {
+		return partial{}, fmt.Errorf("%q has too many components", s)
+	}
+	wildcard := false
+	for i, dst := range []*uint64{&p.major, &p.minor, &p.patch} {
+		if i >= len(components) {
+			break
+		}
+		c := components[i]
+		if c == "x" || c == "X" || c == "*" {
+			wildcard = true
+			continue
+		}
+		if wildcard {
+			return partial{}, fmt.Errorf("%q has a version component after a wildcard", s)
+		}
+		n, err := parseNumericIdentifier(c)
+		if err != nil {
+			return partial{}, err
+		}
+		*dst = n
+		p.parts++
+	}
+	if hasPre {
+		if p.parts != 3 {
+			return partial{}, fmt.Errorf("%q has a pre-release but is incomplete", s)
+		}
+		p.pre = strings.Split(pre, ".")
+	}
+	return p, nil
+}
+
+// floor returns the lowest version described by p, with missing components set to zero.
+func (p partial) floor() semver {
+	return semver{major: p.major, minor: p.minor, patch: p.patch, pre: p.pre}
+}
+
+// preZero is the pre-release of the lowest possible pre-release version of a version tuple, used for exclusive upper
+// bounds so that pre-releases of the bound are excluded as well.
+var preZero = []string{"0"}
+
+func nextMajor(major uint64) semver {
+	return semver{major: major + 1, pre: preZero}
+}
+
+func nextMinor(major, minor uint64) semver {
+	return semver{major: major, minor: minor + 1, pre: preZero}
+}
+
+func nextPatch(major, minor, patch uint64) semver {
+	return semver{major: major, minor: minor, patch: patch + 1, pre: preZero}
+}
+
+// comparator is a single comparison of a semantic version against a fixed version.
+type comparator struct {
+	op      string
+	version semver
+}
+
+func (c comparator) matches(v semver) bool {
+	cmp := v.compare(c.version)
+	switch c.op {
+	case "=":
+		return cmp == 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	}
+	return false
+}
+
+// matchNothing is a comparator that no version satisfies.
+var matchNothing = comparator{op: "<", version: semver{pre: preZero}}
+
+// desugar expands a partial version with an npm or Cargo operator into the comparators it is equivalent to. An empty
+// op requires an exact match of the components present in p.
+func desugar(op string, p partial) ([]comparator, error) {
+	if p.parts == 0 {
+		if op == "<" || op == ">" {
+			return []comparator{matchNothing}, nil
+		}
+		return nil, nil
+	}
+	lo := comparator{op: ">=", version: p.floor()}
+	switch op {
+	case "^":
+		switch {
+		case p.major > 0 || p.parts == 1:
+			return []comparator{lo, {op: "<", version: nextMajor(p.major)}}, nil
+		case p.minor > 0 || p.parts == 2:
+			return []comparator{lo, {op: "<", version: nextMinor(p.major, p.minor)}}, nil
+		}
+		return []comparator{lo, {op: "<", version: nextPatch(p.major, p.minor, p.patch)}}, nil
+	case "~", "~>":
+		if p.parts == 1 {
+			return []comparator{lo, {op: "<", version: nextMajor(p.major)}}, nil
+		}
+		return []comparator{lo, {op: "<", version: nextMinor(p.major, p.minor)}}, nil
+	case "", "=":
+		switch p.parts {
+		case 1:
+			return []comparator{lo, {op: "<", version: nextMajor(p.major)}}, nil
+		case 2:
+			return []comparator{lo, {op: "<", version: nextMinor(p.major, p.minor)}}, nil
+		}
+		return []comparator{{op: "=", version: p.floor()}}, nil
+	case ">":
+		switch p.parts {
+		case 1:
+			return []comparator{{op: ">=", version: semver{major: p.major + 1}}}, nil
+		case 2:
+			return []comparator{{op: ">=", version: semver{major: p.major, minor: p.minor + 1}}}, nil
+		}
+		return []comparator{{op: ">", version: p.floor()}}, nil
+	case ">=":
+		return []comparator{lo}, nil
+	case "<":
+		if p.parts < 3 {
+			return []comparator{{op: "<", version: semver{major: p.major, minor: p.minor, pre: preZero}}}, nil
+		}
+		return []comparator{{op: "<", version: p.floor()}}, nil
+	case "<=":
+		switch p.parts {
+		case 1:
+			return []comparator{{op: "<", version: nextMajor(p.major)}}, nil
+		case 2:
+			return []comparator{{op: "<", version: nextMinor(p.major, p.minor)}}, nil
+		}
+		return []comparator{{op: "<=", version: p.floor()}}, nil
+	}
+	return nil, fmt.Errorf("unknown operator %q", op)
+}
+
+// comparatorSet is a list of comparators which must all be satisfied.
+type comparatorSet []comparator
+
+// matches reports whether v satisfies every comparator of the set. Following npm and Cargo, a pre-release version only
+// matches if a comparator refers to a pre-release of the same major, minor and patch version, unless pre-releases are
+// explicitly allowed.
+func (s comparatorSet) matches(v semver, prerelease bool) bool {
+	for _, c := range s {
+		if !c.matches(v) {
+			return false
+		}
+	}
+	if !v.isPrerelease() || prerelease {
+		return true
+	}
+	for _, c := range s {
+		if c.version.isPrerelease() && c.version.sameTuple(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// semverMatcher is a union of comparator sets, as used by npm, Cargo and Go constraints.
+type semverMatcher struct {
+	sets  []comparatorSet
+	parse func(string) (semver, error)
+}
+
+func (m semverMatcher) matches(version string, prerelease bool) bool {
+	v, err := m.parse(version)
+	if err != nil {
+		return false
+	}
+	for _, s := range m.sets {
+		if s.matches(v, prerelease) {
+			return true
+		}
+	}
+	return false
+}
+
+// semverIsPrerelease reports whether a semantic version parsed with parse is a pre-release.
+func semverIsPrerelease(parse func(string) (semver, error)) func(version string) bool {
+	return func(version string) bool {
+		v, err := parse(version)
+		return err != nil || v.isPrerelease()
+	}
+}
+
+// compareSemver compares two semantic versions parsed with parse. Versions that cannot be parsed sort before all
+// others.
+func compareSemver(parse func(string) (semver, error)) func(a, b string) int {
+	return func(a, b string) int {
+		va, errA := parse(a)
+		vb, errB := parse(b)
+		switch {
+		case errA != nil && errB != nil:
+			return strings.Compare(a, b)
+		case errA != nil:
+			return -1
+		case errB != nil:
+			return 1
+		}
+		return va.compare(vb)
+	}
+}
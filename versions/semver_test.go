@@ -0,0 +1,107 @@
+package versions
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParseSemver(t *testing.T) {
+	cases := []struct {
+		in      string
+		wantErr bool
+	}{
+		{"1.2.3", false},
+		{"1.2.3-beta.1", false},
+		{"1.2.3+build.5", false},
+		{"1.2.3-rc.1+build", false},
+		{"1.2", true},
+		{"1.2.3.4", true},
+		{"v1.2.3", true},
+		{"1.2.x", true},
+		{"1.2.3-", true},
+		{"1.2.3-a..b", true},
+	}
+	for _, tt := range cases {
+		t.Run(tt.in, func(t *testing.T) {
+			_, err := parseSemver(tt.in)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSemver_compare(t *testing.T) {
+	// Ordered by precedence, following the example in the Semantic Versioning specification.
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.2.0",
+		"2.0.0",
+	}
+	for i := range ordered {
+		for j := range ordered {
+			a, err := parseSemver(ordered[i])
+			require.NoError(t, err)
+			b, err := parseSemver(ordered[j])
+			require.NoError(t, err)
+			want := 0
+			switch {
+			case i < j:
+				want = -1
+			case i > j:
+				want = 1
+			}
+			require.Equal(t, want, a.compare(b), "%s <=> %s", ordered[i], ordered[j])
+		}
+	}
+}
+
+func TestSemver_compare_ignores_build(t *testing.T) {
+	a, err := parseSemver("1.0.0+a")
+	require.NoError(t, err)
+	b, err := parseSemver("1.0.0+b")
+	require.NoError(t, err)
+	require.Equal(t, 0, a.compare(b))
+}
+
+func TestParsePartial(t *testing.T) {
+	cases := []struct {
+		in        string
+		wantParts int
+		wantErr   bool
+	}{
+		{"*", 0, false},
+		{"1", 1, false},
+		{"1.x", 1, false},
+		{"1.2", 2, false},
+		{"1.2.*", 2, false},
+		{"1.2.3", 3, false},
+		{"1.2.3-beta", 3, false},
+		{"", 0, true},
+		{"1.x.3", 0, true},
+		{"1.2-beta", 0, true},
+		{"a.b.c", 0, true},
+		{"1.2.3.4", 0, true},
+	}
+	for _, tt := range cases {
+		t.Run(tt.in, func(t *testing.T) {
+			p, err := parsePartial(tt.in)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantParts, p.parts)
+		})
+	}
+}
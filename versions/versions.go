@@ -0,0 +1,165 @@
+// Package versions implements comparison of version strings and matching of versions against simple version ranges.
+//
+// Versions of a distributor are compared following its version scheme, see [Comparer]. Versions of other
+// distributors are compared leniently with [Compare]: a version is split into segments on any character that is
+// neither a letter nor a digit, and segments are compared pairwise. Numeric segments are compared numerically and are
+// considered greater than non-numeric segments, while non-numeric segments are compared lexically. A leading "v", as
+// used by Go modules, is ignored.
+//
+// In addition, the package parses version constraints in the native syntax of each distributor, such as npm semver
+// ranges or PEP 440 version specifiers, and selects the version of a package that satisfies them. See
+// [ParseConstraint].
+package versions
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ErrInvalidRange is returned when a version range cannot be parsed.
+var ErrInvalidRange = errors.New("invalid version range")
+
+// Compare compares the versions a and b leniently. The result is 0 if a == b, -1 if a < b, and +1 if a > b.
+//
+// Every non-numeric segment sorts like a pre-release, so the comparison is wrong for PEP 440 post-releases and Maven
+// qualifiers such as "sp". Prefer the comparison of the distributor's version scheme, returned by [Comparer].
+func Compare(a, b string) int {
+	sa, sb := segments(a), segments(b)
+	for i := 0; i < len(sa) || i < len(sb); i++ {
+		switch {
+		case i >= len(sa):
+			return compareMissing(sb[i])
+		case i >= len(sb):
+			return -compareMissing(sa[i])
+		}
+		if c := compareSegment(sa[i], sb[i]); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// compareMissing compares an absent segment to s. Absent numeric segments are treated as zero, so 1.0 equals 1.0.0,
+// while an absent segment is greater than a non-numeric one, so 1.0.0 is greater than 1.0.0-beta.
+func compareMissing(s string) int {
+	if n, err := strconv.ParseUint(s, 10, 64); err == nil {
+		if n == 0 {
+			return 0
+		}
+		return -1
+	}
+	return 1
+}
+
+func compareSegment(a, b string) int {
+	na, errA := strconv.ParseUint(a, 10, 64)
+	nb, errB := strconv.ParseUint(b, 10, 64)
+	switch {
+	case errA == nil && errB == nil:
+		switch {
+		case na < nb:
+			return -1
+		case na > nb:
+			return 1
+		}
+		return 0
+	case errA == nil:
+		return 1
+	case errB == nil:
+		return -1
+	}
+	return strings.Compare(a, b)
+}
+
+func segments(v string) []string {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	return strings.FieldsFunc(v, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// constraint is a single comparison of a version against a fixed version.
+type constraint struct {
+	op      string
+	version string
+}
+
+func (c constraint) matches(compare func(a, b string) int, version string) bool {
+	cmp := compare(version, c.version)
+	switch c.op {
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	}
+	return false
+}
+
+// Range is a parsed version range. The zero value matches every version.
+type Range struct {
+	constraints []constraint
+	// compare is the comparison of the distributor's version scheme. It is nil for the zero value.
+	compare func(a, b string) int
+}
+
+// ParseRange parses a version range of the distributor. Versions are compared following the version scheme of the
+// distributor, see [Comparer].
+//
+// An empty range or "*" matches every version. Otherwise, the range is a comma-separated list of constraints which
+// must all be satisfied. Each constraint is a version optionally prefixed by one of the operators =, !=, <, <=, > and
+// >=. A version without an operator must match exactly. For example, ">=1.0.0, <2.0.0" matches all 1.x versions.
+func ParseRange(distributor, s string) (Range, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || s == "*" {
+		return Range{}, nil
+	}
+
+	r := Range{compare: Comparer(distributor)}
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		op := "="
+		for _, candidate := range []string{"!=", "<=", ">=", "=", "<", ">"} {
+			if strings.HasPrefix(part, candidate) {
+				op = candidate
+				part = strings.TrimSpace(strings.TrimPrefix(part, candidate))
+				break
+			}
+		}
+		if part == "" {
+			return Range{}, fmt.Errorf("%w: %q: constraint is missing a version", ErrInvalidRange, s)
+		}
+		r.constraints = append(r.constraints, constraint{op: op, version: part})
+	}
+	return r, nil
+}
+
+// Matches reports whether version satisfies every constraint of the range.
+func (r Range) Matches(version string) bool {
+	for _, c := range r.constraints {
+		if !c.matches(r.compare, version) {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchRange is a convenience function that parses the range s of the distributor and reports whether version matches
+// it.
+func MatchRange(distributor, s, version string) (bool, error) {
+	r, err := ParseRange(distributor, s)
+	if err != nil {
+		return false, err
+	}
+	return r.Matches(version), nil
+}
@@ -0,0 +1,95 @@
+package versions
+
+import (
+	"github.com/chainalysis-oss/oslc"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"1.0.0", "1.0.0", 0},
+		{"1.0", "1.0.0", 0},
+		{"v1.2.3", "1.2.3", 0},
+		{"1.2.3", "1.2.4", -1},
+		{"1.10.0", "1.9.0", 1},
+		{"2.0.0", "10.0.0", -1},
+		{"1.0.0-beta", "1.0.0", -1},
+		{"1.0.0", "1.0.0-beta", 1},
+		{"1.0.0-alpha", "1.0.0-beta", -1},
+		{"1.0.0.1", "1.0.0", 1},
+		{"3.0.0-beta2", "3.0.0-beta10", 1},
+	}
+	for _, tt := range cases {
+		t.Run(tt.a+"_"+tt.b, func(t *testing.T) {
+			require.Equal(t, tt.want, Compare(tt.a, tt.b))
+		})
+	}
+}
+
+func TestParseRange(t *testing.T) {
+	cases := []struct {
+		name    string
+		r       string
+		wantErr bool
+	}{
+		{"empty", "", false},
+		{"wildcard", "*", false},
+		{"exact", "1.0.0", false},
+		{"bounded", ">=1.0.0, <2.0.0", false},
+		{"missing_version", ">=", true},
+		{"trailing_comma", "1.0.0,", true},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseRange("", tt.r)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidRange)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMatchRange(t *testing.T) {
+	cases := []struct {
+		r       string
+		version string
+		want    bool
+	}{
+		{"", "1.0.0", true},
+		{"*", "0.0.1", true},
+		{"1.0.0", "1.0.0", true},
+		{"1.0.0", "1.0.1", false},
+		{"=1.0.0", "1.0", true},
+		{"!=1.0.0", "1.0.0", false},
+		{"!=1.0.0", "1.0.1", true},
+		{">=1.0.0, <2.0.0", "1.5.0", true},
+		{">=1.0.0, <2.0.0", "2.0.0", false},
+		{">=1.0.0, <2.0.0", "0.9.9", false},
+		{">1.0.0", "1.0.0", false},
+		{"<=1.0.0", "1.0.0", true},
+		{">= v0.3.0, < v0.4.0", "v0.3.5", true},
+	}
+	for _, tt := range cases {
+		t.Run(tt.r+"_"+tt.version, func(t *testing.T) {
+			got, err := MatchRange("", tt.r, tt.version)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	got, err := MatchRange(oslc.DistributorPypi, ">=1.0.0.post1", "1.0.0")
+	require.NoError(t, err)
+	require.False(t, got)
+	got, err = MatchRange(oslc.DistributorMaven, ">1.0", "1.0-sp1")
+	require.NoError(t, err)
+	require.True(t, got)
+
+	_, err = MatchRange("", ">=", "1.0.0")
+	require.ErrorIs(t, err, ErrInvalidRange)
+}
@@ -0,0 +1,143 @@
+// Package watch distributes events about changes to the package catalog to subscribers within a single server.
+//
+// The [Broker] delivers every published [oslc.PackageEvent] to the subscriptions whose filter matches it. Delivery is
+// best-effort: events are only delivered to subscribers connected to the server that published them, and subscribers
+// that fall behind are disconnected rather than slowing down the requests that publish events. Subscribers that need
+// every change must reconcile with the catalog after reconnecting.
+package watch
+
+import (
+	"context"
+	"github.com/chainalysis-oss/oslc"
+	"log/slog"
+	"sync"
+)
+
+// Compile time check to ensure Broker implements [oslc.PackageEventBroker].
+var _ oslc.PackageEventBroker = (*Broker)(nil)
+
+// Broker is an in-memory [oslc.PackageEventBroker].
+type Broker struct {
+	options *brokerOptions
+
+	mu            sync.Mutex
+	subscriptions map[*subscription]struct{}
+	closed        bool
+}
+
+// NewBroker returns a new Broker.
+func NewBroker(options ...BrokerOption) (*Broker, error) {
+	opts := defaultBrokerOptions
+	for _, opt := range globalBrokerOptions {
+		opt.apply(&opts)
+	}
+	for _, opt := range options {
+		opt.apply(&opts)
+	}
+
+	return &Broker{
+		options:       &opts,
+		subscriptions: make(map[*subscription]struct{}),
+	}, nil
+}
+
+// subscription implements [oslc.PackageEventSubscription].
+type subscription struct {
+	filter oslc.PackageEventFilter
+	events chan oslc.PackageEvent
+	// done is closed after err is set, when the subscription ends.
+	done chan struct{}
+	err  error
+}
+
+func (s *subscription) Events() <-chan oslc.PackageEvent {
+	return s.events
+}
+
+func (s *subscription) Err() error {
+	select {
+	case <-s.done:
+		return s.err
+	default:
+		return nil
+	}
+}
+
+// PublishPackageEvent delivers the event to every subscription whose filter matches it. Subscriptions whose buffer is
+// full are ended with [oslc.ErrSubscriptionOverflow].
+func (b *Broker) PublishPackageEvent(ctx context.Context, event oslc.PackageEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for s := range b.subscriptions {
+		if !s.filter.Matches(event) {
+			continue
+		}
+		select {
+		case s.events <- event:
+		default:
+			b.options.Logger.WarnContext(ctx, "ending package event subscription that fell behind",
+				slog.Int("buffer_size", b.options.BufferSize))
+			b.end(s, oslc.ErrSubscriptionOverflow)
+		}
+	}
+}
+
+// SubscribePackageEvents subscribes to the events matching filter. The subscription buffers up to the configured
+// buffer size of events, and ends when ctx is done or the broker is closed.
+func (b *Broker) SubscribePackageEvents(ctx context.Context, filter oslc.PackageEventFilter) (oslc.PackageEventSubscription, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return nil, oslc.ErrBrokerClosed
+	}
+	if b.options.MaxSubscriptions > 0 && len(b.subscriptions) >= b.options.MaxSubscriptions {
+		return nil, oslc.ErrTooManySubscriptions
+	}
+
+	s := &subscription{
+		filter: filter,
+		events: make(chan oslc.PackageEvent, b.options.BufferSize),
+		done:   make(chan struct{}),
+	}
+	b.subscriptions[s] = struct{}{}
+	go func() {
+		select {
+		case <-ctx.Done():
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			b.end(s, ctx.Err())
+		case <-s.done:
+		}
+	}()
+	return s, nil
+}
+
+// Subscriptions returns the number of active subscriptions.
+func (b *Broker) Subscriptions() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subscriptions)
+}
+
+// Close ends every subscription with [oslc.ErrBrokerClosed], and rejects new subscriptions. Streams waiting for events
+// must be ended before a server can stop gracefully, so the broker should be closed first.
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	for s := range b.subscriptions {
+		b.end(s, oslc.ErrBrokerClosed)
+	}
+}
+
+// end removes the subscription and closes its channels, unless it has already ended. It must be called with b.mu held,
+// which ensures no event is sent on a closed channel.
+func (b *Broker) end(s *subscription, err error) {
+	if _, ok := b.subscriptions[s]; !ok {
+		return
+	}
+	delete(b.subscriptions, s)
+	s.err = err
+	close(s.events)
+	close(s.done)
+}
@@ -0,0 +1,64 @@
+package watch
+
+import (
+	"log/slog"
+)
+
+type brokerOptions struct {
+	Logger *slog.Logger
+	// BufferSize is the number of events buffered for each subscription before it is ended for falling behind.
+	BufferSize int
+	// MaxSubscriptions is the maximum number of concurrent subscriptions, or zero for no limit.
+	MaxSubscriptions int
+}
+
+var defaultBrokerOptions = brokerOptions{
+	Logger:           slog.Default(),
+	BufferSize:       64,
+	MaxSubscriptions: 1000,
+}
+
+var globalBrokerOptions []BrokerOption
+
+// BrokerOption is an option for configuring a Broker.
+type BrokerOption interface {
+	apply(*brokerOptions)
+}
+
+// funcBrokerOption is a BrokerOption that calls a function.
+// It is used to wrap a function, so it satisfies the BrokerOption interface.
+type funcBrokerOption struct {
+	f func(*brokerOptions)
+}
+
+func (fdo *funcBrokerOption) apply(opts *brokerOptions) {
+	fdo.f(opts)
+}
+
+func newFuncBrokerOption(f func(*brokerOptions)) *funcBrokerOption {
+	return &funcBrokerOption{
+		f: f,
+	}
+}
+
+// WithLogger returns a BrokerOption that uses the provided logger.
+func WithLogger(logger *slog.Logger) BrokerOption {
+	return newFuncBrokerOption(func(opts *brokerOptions) {
+		opts.Logger = logger
+	})
+}
+
+// WithBufferSize returns a BrokerOption that buffers up to size events for each subscription. A subscriber that falls
+// further behind is disconnected.
+func WithBufferSize(size int) BrokerOption {
+	return newFuncBrokerOption(func(opts *brokerOptions) {
+		opts.BufferSize = size
+	})
+}
+
+// WithMaxSubscriptions returns a BrokerOption that limits the number of concurrent subscriptions. Zero means no limit.
+func WithMaxSubscriptions(n int) BrokerOption {
+	return newFuncBrokerOption(func(opts *brokerOptions) {
+		opts.MaxSubscriptions = n
+	})
+}
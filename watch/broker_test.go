@@ -0,0 +1,168 @@
+package watch
+
+import (
+	"context"
+	"github.com/chainalysis-oss/oslc"
+	"github.com/stretchr/testify/require"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func newTestBroker(t *testing.T, options ...BrokerOption) *Broker {
+	t.Helper()
+	b, err := NewBroker(append([]BrokerOption{WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil)))}, options...)...)
+	require.NoError(t, err)
+	return b
+}
+
+// receive returns the next event of the subscription, failing the test if there is none within a second.
+func receive(t *testing.T, s oslc.PackageEventSubscription) (oslc.PackageEvent, bool) {
+	t.Helper()
+	select {
+	case e, ok := <-s.Events():
+		return e, ok
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for event")
+		return oslc.PackageEvent{}, false
+	}
+}
+
+func TestNewBroker(t *testing.T) {
+	b, err := NewBroker(WithBufferSize(3), WithMaxSubscriptions(5))
+	require.NoError(t, err)
+	require.Equal(t, 3, b.options.BufferSize)
+	require.Equal(t, 5, b.options.MaxSubscriptions)
+}
+
+func TestBroker_PublishPackageEvent(t *testing.T) {
+	b := newTestBroker(t)
+	all, err := b.SubscribePackageEvents(context.Background(), oslc.PackageEventFilter{})
+	require.NoError(t, err)
+	npm, err := b.SubscribePackageEvents(context.Background(), oslc.PackageEventFilter{Distributor: oslc.DistributorNpm})
+	require.NoError(t, err)
+
+	pypiEvent := oslc.PackageEvent{Type: oslc.PackageEventCreated, Distributor: oslc.DistributorPypi, Name: "requests"}
+	npmEvent := oslc.PackageEvent{Type: oslc.PackageEventCreated, Distributor: oslc.DistributorNpm, Name: "lodash"}
+	b.PublishPackageEvent(context.Background(), pypiEvent)
+	b.PublishPackageEvent(context.Background(), npmEvent)
+
+	e, ok := receive(t, all)
+	require.True(t, ok)
+	require.Equal(t, pypiEvent, e)
+	e, ok = receive(t, all)
+	require.True(t, ok)
+	require.Equal(t, npmEvent, e)
+	e, ok = receive(t, npm)
+	require.True(t, ok)
+	require.Equal(t, npmEvent, e)
+	require.Empty(t, npm.Events())
+	require.NoError(t, npm.Err())
+}
+
+func TestBroker_overflow(t *testing.T) {
+	b := newTestBroker(t, WithBufferSize(1))
+	s, err := b.SubscribePackageEvents(context.Background(), oslc.PackageEventFilter{})
+	require.NoError(t, err)
+
+	b.PublishPackageEvent(context.Background(), oslc.PackageEvent{Name: "a"})
+	b.PublishPackageEvent(context.Background(), oslc.PackageEvent{Name: "b"})
+	// Publishing to an ended subscription must not panic.
+	b.PublishPackageEvent(context.Background(), oslc.PackageEvent{Name: "c"})
+
+	// Buffered events are still delivered before the channel is closed.
+	e, ok := receive(t, s)
+	require.True(t, ok)
+	require.Equal(t, "a", e.Name)
+	_, ok = receive(t, s)
+	require.False(t, ok)
+	require.ErrorIs(t, s.Err(), oslc.ErrSubscriptionOverflow)
+	require.Equal(t, 0, b.Subscriptions())
+}
+
+func TestBroker_contextDone(t *testing.T) {
+	b := newTestBroker(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	s, err := b.SubscribePackageEvents(ctx, oslc.PackageEventFilter{})
+	require.NoError(t, err)
+	require.Equal(t, 1, b.Subscriptions())
+
+	cancel()
+	_, ok := receive(t, s)
+	require.False(t, ok)
+	require.ErrorIs(t, s.Err(), context.Canceled)
+	require.Equal(t, 0, b.Subscriptions())
+}
+
+func TestBroker_maxSubscriptions(t *testing.T) {
+	b := newTestBroker(t, WithMaxSubscriptions(1))
+	ctx, cancel := context.WithCancel(context.Background())
+	s, err := b.SubscribePackageEvents(ctx, oslc.PackageEventFilter{})
+	require.NoError(t, err)
+	_, err = b.SubscribePackageEvents(context.Background(), oslc.PackageEventFilter{})
+	require.ErrorIs(t, err, oslc.ErrTooManySubscriptions)
+
+	cancel()
+	_, ok := receive(t, s)
+	require.False(t, ok)
+	_, err = b.SubscribePackageEvents(context.Background(), oslc.PackageEventFilter{})
+	require.NoError(t, err)
+}
+
+func TestBroker_Close(t *testing.T) {
+	b := newTestBroker(t)
+	s, err := b.SubscribePackageEvents(context.Background(), oslc.PackageEventFilter{})
+	require.NoError(t, err)
+
+	b.Close()
+	_, ok := receive(t, s)
+	require.False(t, ok)
+	require.ErrorIs(t, s.Err(), oslc.ErrBrokerClosed)
+
+	_, err = b.SubscribePackageEvents(context.Background(), oslc.PackageEventFilter{})
+	require.ErrorIs(t, err, oslc.ErrBrokerClosed)
+}
+
+func TestPackageEventFilter_Matches(t *testing.T) {
+	event := oslc.PackageEvent{
+		Type:            oslc.PackageEventLicenseChanged,
+		Distributor:     oslc.DistributorNpm,
+		Name:            "left-pad",
+		Version:         "1.3.0",
+		License:         "MIT",
+		PreviousLicense: "WTFPL",
+	}
+	tests := []struct {
+		name   string
+		filter oslc.PackageEventFilter
+		want   bool
+	}{
+		{"empty filter", oslc.PackageEventFilter{}, true},
+		{"distributor", oslc.PackageEventFilter{Distributor: oslc.DistributorNpm}, true},
+		{"other distributor", oslc.PackageEventFilter{Distributor: oslc.DistributorPypi}, false},
+		{"names", oslc.PackageEventFilter{Names: []string{"lodash", "left-pad"}}, true},
+		{"other names", oslc.PackageEventFilter{Names: []string{"lodash"}}, false},
+		{"license", oslc.PackageEventFilter{Licenses: []string{"MIT"}}, true},
+		{"previous license", oslc.PackageEventFilter{Licenses: []string{"WTFPL"}}, true},
+		{"other license", oslc.PackageEventFilter{Licenses: []string{"Apache-2.0"}}, false},
+		{"all fields", oslc.PackageEventFilter{Distributor: oslc.DistributorNpm, Names: []string{"left-pad"}, Licenses: []string{"MIT"}}, true},
+		{"one field mismatches", oslc.PackageEventFilter{Distributor: oslc.DistributorNpm, Names: []string{"left-pad"}, Licenses: []string{"ISC"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.filter.Matches(event))
+		})
+	}
+
+	// An empty previous license does not match a filter for unlicensed packages.
+	created := oslc.PackageEvent{Type: oslc.PackageEventCreated, License: "MIT"}
+	require.False(t, oslc.PackageEventFilter{Licenses: []string{""}}.Matches(created))
+
+	// The events of the overrides of a tenant are only delivered to that tenant, while shared events reach every tenant.
+	curated := oslc.PackageEvent{Type: oslc.PackageEventCurated, Tenant: "payments"}
+	require.True(t, oslc.PackageEventFilter{Tenant: "payments"}.Matches(curated))
+	require.False(t, oslc.PackageEventFilter{Tenant: "research"}.Matches(curated))
+	require.False(t, oslc.PackageEventFilter{}.Matches(curated))
+	require.True(t, oslc.PackageEventFilter{Tenant: "payments"}.Matches(event))
+}